@@ -0,0 +1,210 @@
+package block
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time used by Manager to schedule block production and to track progress (see watchdog).
+// It allows tests to drive block production deterministically, without sleeping.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is an abstraction of time.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	Reset(d time.Duration) bool
+	Stop() bool
+}
+
+// Ticker is an abstraction of time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// realClock implements Clock using time package.
+type realClock struct{}
+
+var _ Clock = realClock{}
+
+// NewRealClock returns Clock backed by system time.
+func NewRealClock() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// ManualClock is a Clock that moves forward only when Advance is called.
+// Timers and tickers fire when clock is advanced past their deadlines.
+type ManualClock struct {
+	mtx    sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*manualTimer
+}
+
+var _ Clock = &ManualClock{}
+
+// NewManualClock returns ManualClock set to given time.
+func NewManualClock(now time.Time) *ManualClock {
+	c := &ManualClock{now: now}
+	c.cond = sync.NewCond(&c.mtx)
+	return c
+}
+
+// Now implements Clock interface.
+func (c *ManualClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.now
+}
+
+// NewTimer implements Clock interface.
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	t := &manualTimer{clock: c, ch: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// NewTicker implements Clock interface.
+func (c *ManualClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	t := &manualTimer{clock: c, ch: make(chan time.Time, 1), period: d}
+	t.Reset(d)
+	return manualTicker{t}
+}
+
+// Advance moves the clock forward by given duration, firing all timers and tickers with deadlines that passed.
+// Like time.Ticker, ManualClock drops ticks if ticker channel is not drained.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.timers {
+		if !t.active || t.deadline.After(c.now) {
+			continue
+		}
+		t.fire(c.now)
+		if t.period > 0 {
+			for !t.deadline.After(c.now) {
+				t.deadline = t.deadline.Add(t.period)
+			}
+		} else {
+			t.active = false
+		}
+	}
+	c.cond.Broadcast()
+}
+
+// BlockUntil blocks until at least n timers and tickers are waiting for the clock to advance.
+// It's used to make sure that component under test is ready before advancing the clock.
+func (c *ManualClock) BlockUntil(n int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for c.waiting() < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *ManualClock) waiting() int {
+	n := 0
+	for _, t := range c.timers {
+		if t.active {
+			n++
+		}
+	}
+	return n
+}
+
+// manualTimer implements Timer for ManualClock. If period is set, it's used as a ticker (see manualTicker).
+type manualTimer struct {
+	clock    *ManualClock
+	ch       chan time.Time
+	deadline time.Time
+	period   time.Duration
+	// active is true if timer is waiting to fire; protected by clock mutex
+	active     bool
+	registered bool
+}
+
+func (t *manualTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *manualTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	wasActive := t.active
+	if !t.registered {
+		c.timers = append(c.timers, t)
+		t.registered = true
+	}
+	if d <= 0 {
+		t.fire(c.now)
+		t.active = false
+	} else {
+		t.deadline = c.now.Add(d)
+		t.active = true
+	}
+	c.cond.Broadcast()
+	return wasActive
+}
+
+func (t *manualTimer) Stop() bool {
+	c := t.clock
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	wasActive := t.active
+	t.active = false
+	c.cond.Broadcast()
+	return wasActive
+}
+
+func (t *manualTimer) fire(now time.Time) {
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
+
+// manualTicker implements Ticker for ManualClock.
+type manualTicker struct {
+	*manualTimer
+}
+
+func (t manualTicker) Stop() {
+	t.manualTimer.Stop()
+}
@@ -0,0 +1,61 @@
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManualClock(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Unix(1000, 0)
+	clock := NewManualClock(start)
+	assert.Equal(start, clock.Now())
+
+	timer := clock.NewTimer(time.Second)
+	ticker := clock.NewTicker(300 * time.Millisecond)
+	clock.BlockUntil(2)
+
+	clock.Advance(299 * time.Millisecond)
+	assertNotFired(t, timer.C())
+	assertNotFired(t, ticker.C())
+
+	clock.Advance(time.Millisecond)
+	assertNotFired(t, timer.C())
+	assert.Equal(start.Add(300*time.Millisecond), <-ticker.C())
+
+	// ticks are dropped if channel is not drained
+	clock.Advance(700 * time.Millisecond)
+	assert.Equal(start.Add(time.Second), <-timer.C())
+	assert.Equal(start.Add(time.Second), <-ticker.C())
+	assertNotFired(t, ticker.C())
+
+	// fired timer is no longer active
+	assert.False(timer.Stop())
+	assert.False(timer.Reset(time.Second))
+	assert.True(timer.Reset(2 * time.Second))
+	clock.Advance(time.Second)
+	assertNotFired(t, timer.C())
+	clock.Advance(time.Second)
+	assert.Equal(start.Add(3*time.Second), <-timer.C())
+
+	// timer with non-positive duration fires immediately
+	timer.Reset(0)
+	assert.Equal(start.Add(3*time.Second), <-timer.C())
+
+	<-ticker.C()
+	ticker.Stop()
+	clock.Advance(time.Second)
+	assertNotFired(t, ticker.C())
+}
+
+func assertNotFired(t *testing.T, ch <-chan time.Time) {
+	t.Helper()
+	select {
+	case <-ch:
+		t.Error("unexpected timer event")
+	default:
+	}
+}
@@ -0,0 +1,117 @@
+package block
+
+import (
+	"context"
+
+	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// maxConfirmationsPerRound limits number of blocks checked in DA layer in a single round of confirmation loop.
+const maxConfirmationsPerRound = 100
+
+// ConfirmationLoop checks every DAConfirmInterval if blocks reported as included in DA layer (submitted by
+// aggregator or retrieved by full node) are available there. Confirmation status, DA height and commitment
+// are stored in DAInfo of every block, and EventDAConfirmation is published.
+func (m *Manager) ConfirmationLoop(ctx context.Context) {
+	confirmed := m.lastConfirmedHeight()
+	ticker := m.clock.NewTicker(m.conf.DAConfirmInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			confirmed = m.confirmBlocks(confirmed)
+		}
+	}
+}
+
+// lastConfirmedHeight returns the height of the last block confirmed in DA layer.
+func (m *Manager) lastConfirmedHeight() uint64 {
+	return m.lastHeightMatching(func(info *types.DAInfo) bool {
+		return info.Confirmation == types.DAConfirmationConfirmed
+	})
+}
+
+// confirmBlocks checks availability of blocks above confirmed height, and returns new confirmed height
+// (all blocks up to this height are confirmed).
+func (m *Manager) confirmBlocks(confirmed uint64) uint64 {
+	end := m.store.Height()
+	if end > confirmed+maxConfirmationsPerRound {
+		end = confirmed + maxConfirmationsPerRound
+	}
+	contiguous := true
+	for h := confirmed + 1; h <= end; h++ {
+		info, err := m.store.LoadDAInfo(h)
+		if err != nil {
+			// blocks are included in DA layer in order, so next blocks are not included yet
+			break
+		}
+		if info.Confirmation != types.DAConfirmationConfirmed && !m.confirmBlock(h, info) {
+			contiguous = false
+		}
+		if contiguous {
+			confirmed = h
+		}
+	}
+	return confirmed
+}
+
+// confirmBlock checks availability of block at given height in DA layer and stores the result.
+// Returns true if block is confirmed to be available.
+func (m *Manager) confirmBlock(height uint64, info *types.DAInfo) bool {
+	header, err := m.store.LoadHeader(height)
+	if err != nil {
+		m.logger.Error("failed to load block header", "height", height, "error", err)
+		return false
+	}
+	res := m.dalc.CheckBlockAvailability(header)
+	if res.Code != da.StatusSuccess {
+		m.logger.Error("failed to check block availability", "height", height, "error", res.Message)
+		return false
+	}
+
+	confirmation := types.DAConfirmationUnavailable
+	if res.DataAvailable {
+		confirmation = types.DAConfirmationConfirmed
+	}
+	if confirmation == info.Confirmation {
+		return res.DataAvailable
+	}
+	hash := header.Hash()
+	info.Commitment = hash[:]
+	info.Confirmation = confirmation
+	if res.DAHeight > 0 {
+		info.DAHeight = res.DAHeight
+	}
+	if err := m.store.SaveDAInfo(height, info); err != nil {
+		m.logger.Error("failed to save DA info", "height", height, "error", err)
+		return false
+	}
+
+	if res.DataAvailable {
+		m.logger.Debug("block confirmed in DA layer", "height", height, "daHeight", info.DAHeight)
+	} else {
+		m.logger.Error("block reported as included in DA layer is not available", "height", height,
+			"daHeight", info.DAHeight)
+	}
+	m.publishDAConfirmationEvent(height, info)
+	return res.DataAvailable
+}
+
+// publishDAConfirmationEvent publishes EventDAConfirmation with confirmation status of block at given height.
+func (m *Manager) publishDAConfirmationEvent(height uint64, info *types.DAInfo) {
+	if m.eventBus == nil {
+		return
+	}
+	err := m.eventBus.Publish(types.EventDAConfirmation, types.EventDataDAConfirmation{
+		Height:       height,
+		DAHeight:     info.DAHeight,
+		Commitment:   info.Commitment,
+		Confirmation: info.Confirmation,
+	})
+	if err != nil {
+		m.logger.Error("failed to publish DA confirmation event", "height", height, "error", err)
+	}
+}
@@ -0,0 +1,83 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	mockda "github.com/celestiaorg/optimint/da/mock"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestConfirmBlocks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	logger := log.TestingLogger()
+	dalc := &mockda.MockDataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(nil, store.NewDefaultInMemoryKVStore(), logger))
+
+	eventBus := tmtypes.NewEventBus()
+	require.NoError(eventBus.Start())
+	defer func() { _ = eventBus.Stop() }()
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryDAConfirmation, 10)
+	require.NoError(err)
+
+	// blocks 1 and 2 are included in DA layer, block 3 is reported as included but it's not available,
+	// block 4 is not included yet
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	blocks := make([]*types.Block, 5)
+	for h := uint64(1); h <= 4; h++ {
+		blocks[h] = &types.Block{Header: types.Header{Height: h}}
+		require.NoError(s.SaveBlock(blocks[h], &types.Commit{Height: h, HeaderHash: blocks[h].Header.Hash()}))
+	}
+	for h := uint64(1); h <= 2; h++ {
+		res := dalc.SubmitBlock(blocks[h])
+		require.NoError(s.SaveDAInfo(h, &types.DAInfo{DAHeight: res.DAHeight}))
+	}
+	require.NoError(s.SaveDAInfo(3, &types.DAInfo{DAHeight: 3}))
+
+	m := &Manager{
+		store:    s,
+		dalc:     dalc,
+		eventBus: eventBus,
+		metrics:  NopMetrics(),
+		logger:   logger,
+	}
+
+	assert.EqualValues(0, m.lastConfirmedHeight())
+	assert.EqualValues(2, m.confirmBlocks(0))
+	for h := uint64(1); h <= 3; h++ {
+		info, err := s.LoadDAInfo(h)
+		require.NoError(err)
+		hash := blocks[h].Header.Hash()
+		assert.Equal(hash[:], info.Commitment)
+		expected := types.DAConfirmationConfirmed
+		if h == 3 {
+			expected = types.DAConfirmationUnavailable
+		}
+		assert.Equal(expected, info.Confirmation)
+
+		data := (<-sub.Out()).Data().(types.EventDataDAConfirmation)
+		assert.Equal(h, data.Height)
+		assert.Equal(expected, data.Confirmation)
+	}
+	assert.EqualValues(2, m.lastConfirmedHeight())
+
+	// unchanged status is not published again
+	assert.EqualValues(2, m.confirmBlocks(2))
+	assert.Empty(sub.Out())
+
+	// block becomes available later
+	dalc.SubmitBlock(blocks[3])
+	assert.EqualValues(3, m.confirmBlocks(2))
+	data := (<-sub.Out()).Data().(types.EventDataDAConfirmation)
+	assert.EqualValues(3, data.Height)
+	assert.Equal(types.DAConfirmationConfirmed, data.Confirmation)
+	assert.EqualValues(3, m.lastConfirmedHeight())
+}
@@ -0,0 +1,63 @@
+package block
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/celestiaorg/optimint/types"
+)
+
+// DebugState is a snapshot of block manager state, used for diagnostics of running node.
+type DebugState struct {
+	// Height is the height of the latest block in the store.
+	Height uint64
+	// SyncTarget is the height of the latest block header received from P2P network.
+	SyncTarget uint64
+	Sync       types.SyncState
+	FirmHeight uint64
+	// PendingDA is the number of produced blocks waiting for submission to DA layer.
+	PendingDA     uint64
+	LastProduced  time.Time
+	LastSubmitted time.Time
+	// LastError is the error of the last failed block production or submission.
+	LastError   string
+	Stalled     bool
+	Aggregating bool
+	// ResultsMismatch is the height of synced block with results not matching the next block header (0 - none).
+	ResultsMismatch uint64
+	LastBlockTime   time.Time
+	AppHash         string
+}
+
+// DebugState returns a snapshot of block manager state. It's safe to call concurrently with running loops.
+func (m *Manager) DebugState() DebugState {
+	s := DebugState{
+		Height:          m.store.Height(),
+		SyncTarget:      atomic.LoadUint64(&m.syncTarget),
+		Sync:            m.getSyncState(),
+		FirmHeight:      m.FirmHeight(),
+		Aggregating:     m.Aggregating(),
+		ResultsMismatch: atomic.LoadUint64(&m.resultsMismatch),
+	}
+
+	m.lastStateMtx.RLock()
+	s.LastBlockTime = m.lastState.LastBlockTime
+	s.AppHash = fmt.Sprintf("%X", m.lastState.AppHash)
+	m.lastStateMtx.RUnlock()
+
+	w := m.watchdog
+	w.mtx.Lock()
+	if s.Height > w.submittedHeight {
+		s.PendingDA = s.Height - w.submittedHeight
+	}
+	s.LastProduced = w.lastProduced
+	s.LastSubmitted = w.lastSubmitted
+	if w.lastErr != nil {
+		s.LastError = w.lastErr.Error()
+	}
+	s.Stalled = w.stalled
+	w.mtx.Unlock()
+
+	return s
+}
@@ -0,0 +1,112 @@
+package block
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// FirmHeight returns the height of the latest final block. Block is final if it's included in DA layer, and the DA
+// block containing it has at least DAConfirmDepth descendants. All blocks below firm height are final too.
+func (m *Manager) FirmHeight() uint64 {
+	return atomic.LoadUint64(&m.firmHeight)
+}
+
+// FinalityLoop finalizes blocks included in DA layer deep enough (see DAConfirmDepth) and detects DA layer reorgs
+// (see DAReorgWindow), every block time. It's used only if any of them is set - otherwise blocks are finalized
+// as soon as they are included in DA layer.
+func (m *Manager) FinalityLoop(ctx context.Context) {
+	ticker := m.clock.NewTicker(m.conf.BlockTime)
+	defer ticker.Stop()
+
+	initialized := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			if m.conf.DAConfirmDepth == 0 {
+				m.checkReorgs(ctx)
+				continue
+			}
+			res := m.heightReader.LatestHeight()
+			if res.Code != da.StatusSuccess {
+				m.logger.Error("failed to get DA layer height", "error", res.Message)
+				continue
+			}
+			if !initialized {
+				// blocks finalized before restart are not finalized again
+				atomic.StoreUint64(&m.firmHeight, m.lastFinalHeight(res.DAHeight))
+				initialized = true
+			}
+			if m.conf.DAReorgWindow > 0 {
+				m.checkReorgs(ctx)
+			}
+			m.finalizeBlocks(res.DAHeight)
+		}
+	}
+}
+
+// finalizeBlocks finalizes blocks above firm height that are included in DA layer deep enough,
+// given the height of the latest DA block.
+func (m *Manager) finalizeBlocks(daHeight uint64) {
+	for h := m.FirmHeight() + 1; h <= m.store.Height(); h++ {
+		info, err := m.store.LoadDAInfo(h)
+		if err != nil || !m.isDeepEnough(info, daHeight) {
+			return
+		}
+		block, err := m.store.LoadBlock(h)
+		if err != nil {
+			m.logger.Error("failed to load block", "height", h, "error", err)
+			return
+		}
+		m.finalizeBlock(block, info.DAHeight)
+	}
+}
+
+// finalizeBlock records block as final: transactions are traced as finalized, EventNewFinalizedBlock is published
+// and firm height is updated.
+func (m *Manager) finalizeBlock(block *types.Block, daHeight uint64) {
+	m.txTracer.Finalized(block.Data.Txs, block.Header.Height)
+	m.publishFinalizedBlockEvent(block, daHeight)
+	atomic.StoreUint64(&m.firmHeight, block.Header.Height)
+}
+
+// rollbackFirmHeight makes block at given height (and all blocks above) not final anymore.
+func (m *Manager) rollbackFirmHeight(height uint64) {
+	if m.FirmHeight() >= height {
+		atomic.StoreUint64(&m.firmHeight, height-1)
+	}
+}
+
+// isDeepEnough returns true if DA block containing a block has at least DAConfirmDepth descendants.
+func (m *Manager) isDeepEnough(info *types.DAInfo, daHeight uint64) bool {
+	return info.DAHeight+m.conf.DAConfirmDepth <= daHeight
+}
+
+// lastIncludedHeight returns the height of the latest block included in DA layer.
+func (m *Manager) lastIncludedHeight() uint64 {
+	return m.lastHeightMatching(func(*types.DAInfo) bool { return true })
+}
+
+// lastFinalHeight returns the height of the latest block included in DA layer deep enough, given the height
+// of the latest DA block.
+func (m *Manager) lastFinalHeight(daHeight uint64) uint64 {
+	return m.lastHeightMatching(func(info *types.DAInfo) bool { return m.isDeepEnough(info, daHeight) })
+}
+
+func (m *Manager) lastHeightMatching(match func(*types.DAInfo) bool) uint64 {
+	base := m.store.Base()
+	for h := m.store.Height(); h >= base && h > 0; h-- {
+		info, err := m.store.LoadDAInfo(h)
+		if err == nil && match(info) {
+			return h
+		}
+	}
+	if base > 0 {
+		return base - 1
+	}
+	return 0
+}
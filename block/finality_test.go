@@ -0,0 +1,65 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/config"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestFinalizeBlocks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	eventBus := tmtypes.NewEventBus()
+	require.NoError(eventBus.Start())
+	defer func() { _ = eventBus.Stop() }()
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryNewFinalizedBlock, 10)
+	require.NoError(err)
+
+	// blocks 1-3 are included in DA blocks 10-12, block 4 is not included yet
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	for h := uint64(1); h <= 4; h++ {
+		block := &types.Block{Header: types.Header{Height: h}}
+		require.NoError(s.SaveBlock(block, &types.Commit{Height: h, HeaderHash: block.Header.Hash()}))
+		if h <= 3 {
+			require.NoError(s.SaveDAInfo(h, &types.DAInfo{DAHeight: 9 + h}))
+		}
+	}
+
+	m := &Manager{
+		conf:     config.BlockManagerConfig{DAConfirmDepth: 2},
+		store:    s,
+		eventBus: eventBus,
+		genesis:  &tmtypes.GenesisDoc{ChainID: "test"},
+		metrics:  NopMetrics(),
+		logger:   log.TestingLogger(),
+	}
+	assert.EqualValues(3, m.lastIncludedHeight())
+	assert.EqualValues(2, m.lastFinalHeight(13))
+
+	m.finalizeBlocks(11)
+	assert.EqualValues(0, m.FirmHeight())
+
+	m.finalizeBlocks(12)
+	assert.EqualValues(1, m.FirmHeight())
+	data := (<-sub.Out()).Data().(types.EventDataNewFinalizedBlock)
+	assert.EqualValues(1, data.Header.Height)
+	assert.EqualValues(10, data.DAHeight)
+
+	// block that is not included stops finalization
+	m.finalizeBlocks(100)
+	assert.EqualValues(3, m.FirmHeight())
+	for h := int64(2); h <= 3; h++ {
+		data := (<-sub.Out()).Data().(types.EventDataNewFinalizedBlock)
+		assert.Equal(h, data.Header.Height)
+	}
+	assert.Empty(sub.Out())
+}
@@ -0,0 +1,111 @@
+package block
+
+import (
+	"errors"
+	"fmt"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/state"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// forcedTxInjector includes transactions posted by users directly to DA layer, providing censorship resistance.
+//
+// Transactions posted to forced inclusion namespace at DA height d are placed at the beginning of block h,
+// where DA height of block h-window-1 <= d < DA height of block h-window. In other words, transaction has to be included
+// at most window blocks after the first block submitted to DA layer after the transaction.
+// DA heights of blocks are known to both sequencer and full nodes, so block contents can be verified by every node.
+//
+// If maxBytes is set, transactions exceeding it are deferred to next blocks (but at least one transaction is included
+// in every block, if any is due). Position of the first deferred transaction is stored for every block.
+type forcedTxInjector struct {
+	window        uint64
+	namespaceID   [8]byte
+	initialHeight uint64
+	startDAHeight uint64
+	maxBytes      int64
+
+	store     store.Store
+	retriever da.ForcedTxRetriever
+}
+
+var _ state.TxInjector = &forcedTxInjector{}
+
+// PreBlockTxs returns forced inclusion transactions that reached inclusion deadline at given height.
+func (f *forcedTxInjector) PreBlockTxs(height uint64, _ state.State) (types.Txs, error) {
+	if height < f.initialHeight+f.window {
+		return nil, nil
+	}
+	deadlineHeight := height - f.window
+	to, err := f.store.LoadDAInfo(deadlineHeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load DA info of block %d: %w", deadlineHeight, err)
+	}
+	from, err := f.cursor(height - 1)
+	if err != nil {
+		return nil, err
+	}
+
+	var txs types.Txs
+	var size int64
+	for daHeight := from.DAHeight; daHeight < to.DAHeight; daHeight++ {
+		res := f.retriever.RetrieveForcedTxs(f.namespaceID, daHeight)
+		if res.Code != da.StatusSuccess {
+			return nil, fmt.Errorf("failed to retrieve forced inclusion transactions at DA height %d: %s", daHeight, res.Message)
+		}
+		for i, tx := range res.Txs {
+			if daHeight == from.DAHeight && uint64(i) < from.Index {
+				continue
+			}
+			size += tmtypes.ComputeProtoSizeForTxs([]tmtypes.Tx{tmtypes.Tx(tx)})
+			if f.maxBytes > 0 && size > f.maxBytes && len(txs) > 0 {
+				return txs, f.saveCursor(height, &types.ForcedTxsCursor{DAHeight: daHeight, Index: uint64(i)})
+			}
+			txs = append(txs, tx)
+		}
+	}
+	return txs, f.saveCursor(height, &types.ForcedTxsCursor{DAHeight: to.DAHeight})
+}
+
+// PostBlockTxs returns nothing, forced inclusion transactions are always placed at the beginning of the block.
+func (f *forcedTxInjector) PostBlockTxs(uint64, state.State) (types.Txs, error) {
+	return nil, nil
+}
+
+// cursor returns position of the first forced inclusion transaction not included in blocks up to given height.
+//
+// Positions are stored only if maxBytes is set. Otherwise (or if position wasn't stored yet), all transactions posted
+// before DA height of deadline block are included.
+func (f *forcedTxInjector) cursor(height uint64) (*types.ForcedTxsCursor, error) {
+	if height < f.initialHeight+f.window {
+		return &types.ForcedTxsCursor{DAHeight: f.startDAHeight}, nil
+	}
+	if f.maxBytes > 0 {
+		cursor, err := f.store.LoadForcedTxsCursor(height)
+		if err == nil {
+			return cursor, nil
+		}
+		if !errors.Is(err, store.ErrKeyNotFound) {
+			return nil, fmt.Errorf("failed to load forced inclusion cursor of block %d: %w", height, err)
+		}
+	}
+	deadlineHeight := height - f.window
+	info, err := f.store.LoadDAInfo(deadlineHeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load DA info of block %d: %w", deadlineHeight, err)
+	}
+	return &types.ForcedTxsCursor{DAHeight: info.DAHeight}, nil
+}
+
+func (f *forcedTxInjector) saveCursor(height uint64, cursor *types.ForcedTxsCursor) error {
+	if f.maxBytes <= 0 {
+		return nil
+	}
+	if err := f.store.SaveForcedTxsCursor(height, cursor); err != nil {
+		return fmt.Errorf("failed to save forced inclusion cursor of block %d: %w", height, err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/celestiaorg/optimint/da"
+	mockda "github.com/celestiaorg/optimint/da/mock"
+	"github.com/celestiaorg/optimint/state"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestForcedTxInjector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	nsID := [8]byte{8, 7, 6, 5, 4, 3, 2, 1}
+
+	dalc := &mockda.MockDataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(nil, store.NewDefaultInMemoryKVStore(), log.TestingLogger()))
+	s := store.New(store.NewDefaultInMemoryKVStore())
+
+	// every transaction is posted at separate DA height, starting from 1
+	submit := func(ns [8]byte, tx types.Tx) {
+		res := dalc.SubmitForcedTx(ns, tx)
+		require.Equal(da.StatusSuccess, res.Code)
+	}
+	submit(nsID, types.Tx("a"))
+	submit([8]byte{}, types.Tx("other namespace"))
+	submit(nsID, types.Tx("b"))
+	submit(nsID, types.Tx("c"))
+
+	// blocks 1, 2 and 3 were included at DA heights 2, 4 and 5
+	for height, daHeight := range map[uint64]uint64{1: 2, 2: 4, 3: 5} {
+		require.NoError(s.SaveDAInfo(height, &types.DAInfo{DAHeight: daHeight}))
+	}
+
+	injector := &forcedTxInjector{
+		window:        2,
+		namespaceID:   nsID,
+		initialHeight: 1,
+		startDAHeight: 1,
+		store:         s,
+		retriever:     dalc,
+	}
+
+	cases := []struct {
+		height   uint64
+		expected types.Txs
+	}{
+		{1, nil},
+		{2, nil},
+		{3, types.Txs{types.Tx("a")}},
+		{4, types.Txs{types.Tx("b")}},
+		{5, types.Txs{types.Tx("c")}},
+	}
+	for _, c := range cases {
+		txs, err := injector.PreBlockTxs(c.height, state.State{})
+		assert.NoError(err)
+		assert.Equal(c.expected, txs, "height %d", c.height)
+
+		txs, err = injector.PostBlockTxs(c.height, state.State{})
+		assert.NoError(err)
+		assert.Empty(txs)
+	}
+
+	// DA info of block 4 is not available yet
+	_, err := injector.PreBlockTxs(6, state.State{})
+	assert.Error(err)
+}
+
+func TestForcedTxInjectorMaxBytes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	nsID := [8]byte{8, 7, 6, 5, 4, 3, 2, 1}
+
+	dalc := &mockda.MockDataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(nil, store.NewDefaultInMemoryKVStore(), log.TestingLogger()))
+	s := store.New(store.NewDefaultInMemoryKVStore())
+
+	// transactions are posted at DA heights 1, 2 and 3, blocks 1, 2 and 3 were included at DA heights 4, 5 and 6
+	for _, tx := range []string{"aaaa", "bbbb", "cccc"} {
+		require.Equal(da.StatusSuccess, dalc.SubmitForcedTx(nsID, types.Tx(tx)).Code)
+	}
+	for height := uint64(1); height <= 3; height++ {
+		require.NoError(s.SaveDAInfo(height, &types.DAInfo{DAHeight: height + 3}))
+	}
+
+	// only two transactions fit in a block
+	injector := &forcedTxInjector{
+		window:        1,
+		namespaceID:   nsID,
+		initialHeight: 1,
+		startDAHeight: 1,
+		maxBytes:      12,
+		store:         s,
+		retriever:     dalc,
+	}
+
+	cases := []struct {
+		height   uint64
+		expected types.Txs
+	}{
+		{1, nil},
+		{2, types.Txs{types.Tx("aaaa"), types.Tx("bbbb")}},
+		{3, types.Txs{types.Tx("cccc")}},
+		{4, nil},
+	}
+	for _, c := range cases {
+		txs, err := injector.PreBlockTxs(c.height, state.State{})
+		assert.NoError(err)
+		assert.Equal(c.expected, txs, "height %d", c.height)
+	}
+
+	cursor, err := s.LoadForcedTxsCursor(2)
+	require.NoError(err)
+	assert.Equal(&types.ForcedTxsCursor{DAHeight: 3}, cursor)
+
+	// results are the same when block is validated again
+	txs, err := injector.PreBlockTxs(3, state.State{})
+	assert.NoError(err)
+	assert.Equal(types.Txs{types.Tx("cccc")}, txs)
+}
@@ -0,0 +1,68 @@
+package block
+
+import (
+	"github.com/celestiaorg/optimint/types"
+)
+
+// Hooks are callbacks invoked by Manager on block lifecycle events. Any of them can be nil.
+//
+// Hooks are called synchronously, from block production and syncing goroutines, so they should return quickly.
+// Blocks passed to hooks are shared with the Manager and must not be modified.
+type Hooks struct {
+	// OnBlockProduced is called after block created by this node is applied and saved, before it's submitted to
+	// DA layer.
+	OnBlockProduced func(block *types.Block)
+	// OnBlockApplied is called after any block (produced by this node or synced) is applied and saved.
+	OnBlockApplied func(block *types.Block)
+	// OnDAIncluded is called after block is successfully submitted to DA layer.
+	OnDAIncluded func(block *types.Block, daHeight uint64)
+	// OnDASubmissionFailed is called when submission of block to DA layer fails.
+	OnDASubmissionFailed func(block *types.Block, err error)
+}
+
+// AddHooks registers callbacks invoked on block lifecycle events. Hooks are invoked in order of registration.
+func (m *Manager) AddHooks(hooks Hooks) {
+	m.hooksMtx.Lock()
+	defer m.hooksMtx.Unlock()
+	m.hooks = append(m.hooks, hooks)
+}
+
+func (m *Manager) runHooks(run func(h *Hooks)) {
+	m.hooksMtx.RLock()
+	defer m.hooksMtx.RUnlock()
+	for i := range m.hooks {
+		run(&m.hooks[i])
+	}
+}
+
+func (m *Manager) onBlockProduced(block *types.Block) {
+	m.runHooks(func(h *Hooks) {
+		if h.OnBlockProduced != nil {
+			h.OnBlockProduced(block)
+		}
+	})
+}
+
+func (m *Manager) onBlockApplied(block *types.Block) {
+	m.runHooks(func(h *Hooks) {
+		if h.OnBlockApplied != nil {
+			h.OnBlockApplied(block)
+		}
+	})
+}
+
+func (m *Manager) onDAIncluded(block *types.Block, daHeight uint64) {
+	m.runHooks(func(h *Hooks) {
+		if h.OnDAIncluded != nil {
+			h.OnDAIncluded(block, daHeight)
+		}
+	})
+}
+
+func (m *Manager) onDASubmissionFailed(block *types.Block, err error) {
+	m.runHooks(func(h *Hooks) {
+		if h.OnDASubmissionFailed != nil {
+			h.OnDASubmissionFailed(block, err)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	abciconv "github.com/celestiaorg/optimint/conv/abci"
+	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// maxPendingLightHeaders limits the number of headers waiting for data availability sampling in light mode.
+const maxPendingLightHeaders = 1024
+
+// lightSync is the state of light mode (see EnableLightMode).
+type lightSync struct {
+	sampler da.AvailabilitySampler
+	samples int
+
+	mtx sync.RWMutex
+	// header is the latest header with sampled data availability
+	header *types.Header
+	// pending are headers received via P2P, waiting for sampling, by height
+	pending map[uint64]*types.Header
+}
+
+// EnableLightMode makes manager follow headers received via P2P (sent to HeaderInCh) and verify availability of their
+// data with given number of samples (see da.AvailabilitySampler), instead of retrieving and applying blocks.
+// It has to be called before the manager is started.
+func (m *Manager) EnableLightMode(samples int) error {
+	sampler, ok := m.dalc.(da.AvailabilitySampler)
+	if !ok {
+		return errors.New("light mode requires data availability layer client supporting data availability sampling")
+	}
+	m.light = &lightSync{
+		sampler: sampler,
+		samples: samples,
+		pending: make(map[uint64]*types.Header),
+	}
+	return nil
+}
+
+// LightHeader returns the latest header with sampled data availability, or nil if there is none (or light mode is
+// disabled).
+func (m *Manager) LightHeader() *types.Header {
+	if m.light == nil {
+		return nil
+	}
+	m.light.mtx.RLock()
+	defer m.light.mtx.RUnlock()
+	return m.light.header
+}
+
+// LightSyncLoop samples data availability of headers received via P2P, in order of heights. Headers are gossiped
+// before blocks are included in DA layer, so sampling is retried every block time. Node doesn't follow headers above
+// the header which data is not available. Headers with available data are published as NewBlockHeader events.
+//
+// Headers are verified against the sequencer from genesis (see VerifyHeader), as light node doesn't execute blocks
+// and doesn't learn about sequencer rotation. Headers that were not received (e.g. while node was offline) are
+// skipped.
+func (m *Manager) LightSyncLoop(ctx context.Context) {
+	ticker := m.clock.NewTicker(m.conf.BlockTime)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case header := <-m.HeaderInCh:
+			m.logger.Debug("block header received", "height", header.Height, "hash", header.Hash())
+			m.light.add(header)
+			m.sampleHeaders()
+		case <-ticker.C():
+			m.sampleHeaders()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sampleHeaders samples pending headers, until data of a header is not available.
+func (m *Manager) sampleHeaders() {
+	for {
+		header := m.light.next()
+		if header == nil {
+			return
+		}
+		res := m.light.sampler.SampleAvailability(header, m.light.samples)
+		if res.Code != da.StatusSuccess {
+			m.logger.Error("failed to sample data availability", "height", header.Height, "error", res.Message)
+			return
+		}
+		if !res.DataAvailable {
+			m.logger.Debug("block data is not available", "height", header.Height)
+			return
+		}
+		m.light.setAvailable(header)
+		m.logger.Info("block data availability sampled", "height", header.Height, "hash", header.Hash())
+		m.publishHeaderEvent(header)
+	}
+}
+
+func (m *Manager) publishHeaderEvent(header *types.Header) {
+	if m.eventBus == nil {
+		return
+	}
+	abciHeader, err := abciconv.ToABCIHeader(header)
+	if err != nil {
+		m.logger.Error("failed to convert block header", "height", header.Height, "error", err)
+		return
+	}
+	abciHeader.ChainID = m.genesis.ChainID
+	err = m.eventBus.PublishEventNewBlockHeader(tmtypes.EventDataNewBlockHeader{Header: abciHeader})
+	if err != nil {
+		m.logger.Error("failed to publish block header event", "height", header.Height, "error", err)
+	}
+}
+
+// add adds header to pending headers, unless it's below the latest available header or there are too many of them.
+func (l *lightSync) add(header *types.Header) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.header != nil && header.Height <= l.header.Height {
+		return
+	}
+	if len(l.pending) >= maxPendingLightHeaders {
+		return
+	}
+	l.pending[header.Height] = header
+}
+
+// next returns pending header with the lowest height, or nil if there are no pending headers.
+func (l *lightSync) next() *types.Header {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	var next *types.Header
+	for _, header := range l.pending {
+		if next == nil || header.Height < next.Height {
+			next = header
+		}
+	}
+	return next
+}
+
+// setAvailable sets the latest available header and drops pending headers below it.
+func (l *lightSync) setAvailable(header *types.Header) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.header = header
+	for height := range l.pending {
+		if height <= header.Height {
+			delete(l.pending, height)
+		}
+	}
+}
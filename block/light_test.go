@@ -0,0 +1,87 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/da"
+	mockda "github.com/celestiaorg/optimint/da/mock"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestLightMode(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	eventBus := tmtypes.NewEventBus()
+	require.NoError(eventBus.Start())
+	defer func() { _ = eventBus.Stop() }()
+	sub, err := eventBus.Subscribe(context.Background(), "test", tmtypes.EventQueryNewBlockHeader, 10)
+	require.NoError(err)
+
+	dalc := &mockda.MockDataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(nil, store.NewDefaultInMemoryKVStore(), log.TestingLogger()))
+	require.NoError(dalc.Start())
+	defer func() { _ = dalc.Stop() }()
+	m := &Manager{
+		dalc:     dalc,
+		eventBus: eventBus,
+		genesis:  &tmtypes.GenesisDoc{ChainID: "test"},
+		logger:   log.TestingLogger(),
+	}
+	assert.Error((&Manager{dalc: struct{ da.DataAvailabilityLayerClient }{dalc}}).EnableLightMode(16))
+	require.NoError(m.EnableLightMode(16))
+	assert.Nil(m.LightHeader())
+
+	blocks := make([]*types.Block, 4)
+	for i := range blocks {
+		blocks[i] = &types.Block{
+			Header: types.Header{Height: uint64(i + 1)},
+			Data:   types.Data{Txs: types.Txs{types.Tx{byte(i)}}},
+		}
+		blocks[i].Header.DataHash = blocks[i].Data.Hash()
+	}
+	// data of block 3 is withheld, block 4 is not submitted yet
+	for _, b := range blocks[:3] {
+		res := dalc.SubmitBlock(b)
+		require.Equal(da.StatusSuccess, res.Code, res.Message)
+	}
+	require.NoError(dalc.Withhold(&blocks[2].Header))
+
+	// header 1 was not received, so it's skipped
+	for _, b := range blocks[1:] {
+		m.light.add(&b.Header)
+	}
+	m.sampleHeaders()
+	require.NotNil(m.LightHeader())
+	assert.EqualValues(2, m.LightHeader().Height)
+	data := (<-sub.Out()).Data().(tmtypes.EventDataNewBlockHeader)
+	assert.EqualValues(2, data.Header.Height)
+	assert.Equal("test", data.Header.ChainID)
+
+	// header 4 is not followed, even after its data is available
+	res := dalc.SubmitBlock(blocks[3])
+	require.Equal(da.StatusSuccess, res.Code, res.Message)
+	m.sampleHeaders()
+	assert.EqualValues(2, m.LightHeader().Height)
+	assert.Empty(sub.Out())
+
+	res = dalc.SubmitBlock(blocks[2])
+	require.Equal(da.StatusSuccess, res.Code, res.Message)
+	m.sampleHeaders()
+	assert.EqualValues(4, m.LightHeader().Height)
+	for h := int64(3); h <= 4; h++ {
+		data := (<-sub.Out()).Data().(tmtypes.EventDataNewBlockHeader)
+		assert.Equal(h, data.Header.Height)
+	}
+
+	// headers below the latest available header are ignored
+	m.light.add(&blocks[0].Header)
+	assert.Nil(m.light.next())
+}
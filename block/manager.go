@@ -1,8 +1,11 @@
 package block
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -14,7 +17,9 @@ import (
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/celestiaorg/optimint/config"
+	abciconv "github.com/celestiaorg/optimint/conv/abci"
 	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/da/account"
 	"github.com/celestiaorg/optimint/log"
 	"github.com/celestiaorg/optimint/mempool"
 	"github.com/celestiaorg/optimint/state"
@@ -22,9 +27,19 @@ import (
 	"github.com/celestiaorg/optimint/types"
 )
 
+var errNoSequencer = errors.New("no sequencer in validator set")
+
+// Sources of verified headers, used as metrics label.
+const (
+	headerSourceGossip = "gossip"
+	headerSourceDA     = "da"
+)
+
 // Manager is responsible for aggregating transactions into blocks.
 type Manager struct {
 	lastState state.State
+	// lastStateMtx guards lastState updates, so it can be read from other goroutines (see VerifyHeader)
+	lastStateMtx sync.RWMutex
 
 	conf    config.BlockManagerConfig
 	genesis *tmtypes.GenesisDoc
@@ -36,14 +51,61 @@ type Manager struct {
 
 	dalc      da.DataAvailabilityLayerClient
 	retriever da.BlockRetriever
+	// heightReader is used if blocks are produced by DA epoch
+	heightReader da.HeightReader
+	// hashReader is used if DA layer reorg detection is enabled
+	hashReader da.HashReader
+	// daAccount is set if node manages DA layer account paying fees for block submissions
+	daAccount *account.Account
+	// daSpend is the cumulative cost of block submissions, guarded by watchdog.submitMtx
+	daSpend types.DASpend
 
-	HeaderOutCh chan *types.Header
+	HeaderOutCh chan *types.SignedHeader
 	HeaderInCh  chan *types.Header
 
+	// BlockOutCh and BlockInCh are used only if block gossip is enabled (see EnableBlockGossip)
+	BlockOutCh  chan *types.Block
+	BlockInCh   chan *types.Block
+	blockGossip bool
+
 	syncTarget uint64
 	blockInCh  chan *types.Block
 	retrieveCh chan uint64
 	syncCache  map[uint64]*types.Block
+	// firmHeight is the height of the latest final block (see DAConfirmDepth), all blocks below are final too
+	firmHeight uint64
+	// syncState is persisted in store, so that restarted node doesn't have to re-derive it
+	syncState    types.SyncState
+	syncStateMtx sync.Mutex
+	// resultsMismatch is the height of synced block with results not matching the next block header (see
+	// VerifyResults); syncing is halted if it's set
+	resultsMismatch uint64
+
+	txTracer *TxTracer
+	eventBus *tmtypes.EventBus
+	metrics  *Metrics
+	mempool  mempool.Mempool
+	watchdog *watchdog
+	clock    Clock
+
+	// produceCh is used to request immediate block production from aggregation loop (see ProduceBlockNow)
+	produceCh chan chan error
+	// paused is set (to 1) if block production is paused by StopAggregating
+	paused int32
+
+	// forcedTxs is used if forced inclusion of transactions posted directly to DA layer is enabled
+	forcedTxs *forcedTxInjector
+	// ordering is used if first-come-first-served ordering of transactions is enabled
+	ordering *txOrdering
+	// light is used in light mode (see EnableLightMode)
+	light *lightSync
+
+	hooks    []Hooks
+	hooksMtx sync.RWMutex
+
+	// prover and verifier are used if validity proofs are enabled (see ValidityProver)
+	prover   ValidityProver
+	verifier ValidityVerifier
 
 	logger log.Logger
 }
@@ -63,11 +125,17 @@ func NewManager(
 	genesis *tmtypes.GenesisDoc,
 	store store.Store,
 	mempool mempool.Mempool,
-	proxyApp proxy.AppConnConsensus,
+	executor state.Executor,
 	dalc da.DataAvailabilityLayerClient,
 	eventBus *tmtypes.EventBus,
 	logger log.Logger,
 ) (*Manager, error) {
+	switch conf.HeaderVerification {
+	case "", config.HeaderVerificationStrict, config.HeaderVerificationPermissive:
+	default:
+		return nil, fmt.Errorf("unknown header verification mode: %q", conf.HeaderVerification)
+	}
+
 	s, err := getInitialState(store, genesis)
 	if err != nil {
 		return nil, err
@@ -78,14 +146,26 @@ func NewManager(
 		return nil, err
 	}
 
-	exec := state.NewBlockExecutor(proposerAddress, conf.NamespaceID, genesis.ChainID, mempool, proxyApp, eventBus, logger)
-	if s.LastBlockHeight+1 == genesis.InitialHeight {
+	exec := state.NewBlockExecutor(proposerAddress, conf.NamespaceID, genesis.ChainID, mempool, executor, eventBus, logger)
+	if limiter, ok := dalc.(da.BlobSizeLimiter); ok {
+		maxBlobSize := limiter.MaxBlobSize()
+		if maxBlobSize > 0 && state.MaxTxsBytes(maxBlobSize) <= 0 {
+			return nil, fmt.Errorf("max DA blob size %d is too small for a block", maxBlobSize)
+		}
+		exec.SetMaxBlobSize(maxBlobSize)
+	}
+	if s.LastBlockHeight == 0 {
+		// chain may start at height greater than 1 (e.g. restart from exported state)
+		store.SetHeight(uint64(genesis.InitialHeight - 1))
+
 		res, err := exec.InitChain(genesis)
 		if err != nil {
 			return nil, err
 		}
 
-		updateState(&s, res)
+		if err := updateState(&s, res); err != nil {
+			return nil, err
+		}
 		if err := store.UpdateState(s); err != nil {
 			return nil, err
 		}
@@ -100,13 +180,75 @@ func NewManager(
 		executor:    exec,
 		dalc:        dalc,
 		retriever:   dalc.(da.BlockRetriever), // TODO(tzdybal): do it in more gentle way (after MVP)
-		HeaderOutCh: make(chan *types.Header),
+		HeaderOutCh: make(chan *types.SignedHeader),
 		HeaderInCh:  make(chan *types.Header),
+		BlockOutCh:  make(chan *types.Block),
+		BlockInCh:   make(chan *types.Block),
 		blockInCh:   make(chan *types.Block),
-		retrieveCh:  make(chan uint64),
+		retrieveCh:  make(chan uint64, 1),
 		syncCache:   make(map[uint64]*types.Block),
+		eventBus:    eventBus,
+		metrics:     NopMetrics(),
+		mempool:     mempool,
+		clock:       realClock{},
+		produceCh:   make(chan chan error),
 		logger:      logger,
 	}
+	agg.initSyncState()
+	agg.watchdog = newWatchdog(agg.syncState.SubmittedHeight, time.Now())
+
+	if conf.DAEpoch > 0 || conf.DAConfirmDepth > 0 {
+		heightReader, ok := dalc.(da.HeightReader)
+		if !ok {
+			return nil, errors.New("block production by DA epoch or DA confirmation depth is enabled, but DA layer client doesn't report DA height")
+		}
+		agg.heightReader = heightReader
+	}
+	if conf.DAConfirmDepth == 0 {
+		agg.firmHeight = agg.syncState.IncludedHeight
+	}
+	if spend, err := store.LoadDASpend(); err == nil {
+		agg.daSpend = *spend
+	}
+	if conf.DAReorgWindow > 0 {
+		hashReader, ok := dalc.(da.HashReader)
+		if !ok {
+			return nil, errors.New("DA layer reorg detection is enabled, but DA layer client doesn't report DA block hashes")
+		}
+		agg.hashReader = hashReader
+	}
+
+	if conf.DevMode {
+		if conf.DAEpoch > 0 {
+			return nil, errors.New("dev mode can't be used with block production by DA epoch")
+		}
+		mempool.EnableTxsAvailable()
+	}
+
+	if conf.ForcedInclusionWindow > 0 {
+		retriever, ok := dalc.(da.ForcedTxRetriever)
+		if !ok {
+			return nil, errors.New("forced inclusion is enabled, but DA layer client doesn't support it")
+		}
+		agg.forcedTxs = &forcedTxInjector{
+			window:        conf.ForcedInclusionWindow,
+			namespaceID:   conf.ForcedInclusionNamespaceID,
+			initialHeight: uint64(genesis.InitialHeight),
+			startDAHeight: conf.DAStartHeight,
+			maxBytes:      conf.ForcedLaneMaxBytes,
+			store:         store,
+			retriever:     retriever,
+		}
+		exec.SetForcedTxInjector(agg.forcedTxs)
+	}
+	if conf.FCFSOrdering {
+		agg.ordering = newTxOrdering(genesis.ChainID, proposerKey)
+		exec.SetFCFSOrdering(agg.ordering)
+	}
+	exec.SetLaneBudgets(state.LaneBudgets{
+		System:  state.LaneBudget{MaxBytes: conf.SystemLaneMaxBytes},
+		Mempool: state.LaneBudget{MaxBytes: conf.MempoolLaneMaxBytes, MaxGas: conf.MempoolLaneMaxGas},
+	})
 
 	return agg, nil
 }
@@ -122,25 +264,223 @@ func getAddress(key crypto.PrivKey) ([]byte, error) {
 func (m *Manager) SetDALC(dalc da.DataAvailabilityLayerClient) {
 	m.dalc = dalc
 	m.retriever = dalc.(da.BlockRetriever)
+	if m.heightReader != nil {
+		m.heightReader = dalc.(da.HeightReader)
+	}
+	if m.hashReader != nil {
+		m.hashReader = dalc.(da.HashReader)
+	}
+	if m.forcedTxs != nil {
+		m.forcedTxs.retriever = dalc.(da.ForcedTxRetriever)
+	}
+	if m.light != nil {
+		m.light.sampler = dalc.(da.AvailabilitySampler)
+	}
+}
+
+// SetTxTracer sets TxTracer used to record transaction lifecycle.
+func (m *Manager) SetTxTracer(tracer *TxTracer) {
+	m.txTracer = tracer
+}
+
+// SetDAAccount sets DA layer account paying fees for block submissions.
+func (m *Manager) SetDAAccount(acc *account.Account) {
+	m.daAccount = acc
+}
+
+// SetMetrics sets metrics reported by Manager.
+func (m *Manager) SetMetrics(metrics *Metrics) {
+	m.metrics = metrics
+}
+
+// SetClock sets Clock used to schedule block production, e.g. ManualClock in tests.
+// It has to be called before the manager is started.
+func (m *Manager) SetClock(clock Clock) {
+	m.clock = clock
+	// submitted height set by NewManager is kept, so unsubmitted blocks are still submitted by watchdog
+	m.watchdog = newWatchdog(m.watchdog.submittedHeight, clock.Now())
+}
+
+// SetTxInjector sets TxInjector used to add system transactions to produced blocks and validate synced blocks.
+// If forced inclusion is enabled, forced inclusion transactions follow pre-block system transactions.
+// It has to be called before the manager is started.
+func (m *Manager) SetTxInjector(injector state.TxInjector) {
+	m.executor.SetTxInjector(injector)
+}
+
+// SetTxDecrypter enables decryption of encrypted transactions before execution (see state.TxDecrypter).
+// It has to be called before the manager is started.
+func (m *Manager) SetTxDecrypter(decrypter state.TxDecrypter) {
+	m.executor.SetTxDecrypter(decrypter)
 }
 
+// EnableBlockGossip makes aggregator send produced blocks to BlockOutCh (to be gossiped via P2P). Blocks received
+// via P2P (sent to BlockInCh) are applied before they are retrieved from DA layer.
+func (m *Manager) EnableBlockGossip() {
+	m.blockGossip = true
+}
+
+// SetMempoolChecks sets additional filters applied by mempool to transactions, after every block.
+func (m *Manager) SetMempoolChecks(preCheck mempool.PreCheckFunc, postCheck mempool.PostCheckFunc) {
+	m.executor.SetMempoolChecks(preCheck, postCheck)
+}
+
+// AggregationLoop produces blocks every block time. Loop is restarted by watchdog, if block production is stalled.
 func (m *Manager) AggregationLoop(ctx context.Context) {
-	timer := time.NewTimer(0)
+	m.resubmitPending(ctx)
+	m.refreshOrderingReceipts()
+	for {
+		loopCtx, cancel := context.WithCancel(ctx)
+		m.setAggregationCancel(cancel)
+		m.aggregate(loopCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (m *Manager) aggregate(ctx context.Context) {
+	if m.conf.DAEpoch > 0 {
+		m.aggregateByDAEpoch(ctx)
+		return
+	}
+	timer := m.clock.NewTimer(0)
+	defer timer.Stop()
+	// in dev mode, block is produced as soon as transactions are available (channel is nil otherwise)
+	var txsAvailable <-chan struct{}
+	if m.conf.DevMode {
+		txsAvailable = m.mempool.TxsAvailable()
+	}
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-timer.C:
-			start := time.Now()
+		case <-timer.C():
+			start := m.clock.Now()
 			err := m.publishBlock(ctx)
 			if err != nil {
 				m.logger.Error("error while publishing block", "error", err)
+				m.recordError(err)
 			}
 			timer.Reset(m.getRemainingSleep(start))
+		case <-txsAvailable:
+			err := m.publishBlock(ctx)
+			if err != nil {
+				m.logger.Error("error while publishing block", "error", err)
+				m.recordError(err)
+			}
+			// block time is the idle interval, counted from the last block
+			if !timer.Stop() {
+				select {
+				case <-timer.C():
+				default:
+				}
+			}
+			timer.Reset(m.conf.BlockTime)
+		case errCh := <-m.produceCh:
+			err := m.publishBlock(ctx)
+			if err != nil {
+				m.logger.Error("error while publishing block", "error", err)
+				m.recordError(err)
+			}
+			errCh <- err
 		}
 	}
 }
 
+// ProduceBlockNow makes aggregation loop produce a block immediately (regardless of block time) and waits until the
+// block is published. It's intended for development and testing, where blocks should be created on demand.
+// Block production schedule is not affected. It's not supported if blocks are produced by DA epoch.
+func (m *Manager) ProduceBlockNow(ctx context.Context) error {
+	if m.conf.DAEpoch > 0 {
+		return errors.New("on-demand block production is not supported when blocks are produced by DA epoch")
+	}
+	if !m.Aggregating() {
+		return errors.New("block production is paused")
+	}
+	errCh := make(chan error, 1)
+	select {
+	case m.produceCh <- errCh:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// StopAggregating pauses block production. Aggregation loop keeps running, but skips production of blocks (like
+// a node that is not the current sequencer) until StartAggregating is called.
+func (m *Manager) StopAggregating() {
+	atomic.StoreInt32(&m.paused, 1)
+}
+
+// StartAggregating resumes block production paused by StopAggregating.
+func (m *Manager) StartAggregating() {
+	atomic.StoreInt32(&m.paused, 0)
+}
+
+// Aggregating returns false if block production is paused.
+func (m *Manager) Aggregating() bool {
+	return atomic.LoadInt32(&m.paused) == 0
+}
+
+// aggregateByDAEpoch produces exactly one block for every DA epoch (DAEpoch DA layer blocks), checking DA layer
+// height every block time. Epochs that started before the loop are skipped.
+func (m *Manager) aggregateByDAEpoch(ctx context.Context) {
+	ticker := m.clock.NewTicker(m.conf.BlockTime)
+	defer ticker.Stop()
+	var lastEpoch uint64
+	initialized := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			res := m.heightReader.LatestHeight()
+			if res.Code != da.StatusSuccess {
+				err := fmt.Errorf("failed to get DA layer height: %s", res.Message)
+				m.logger.Error("error while checking DA epoch", "error", err)
+				m.recordError(err)
+				continue
+			}
+			epoch, ok := m.daEpoch(res.DAHeight)
+			if !ok {
+				m.recordProduced()
+				continue
+			}
+			if !initialized {
+				lastEpoch = epoch
+				initialized = true
+			}
+			if epoch == lastEpoch {
+				m.recordProduced()
+				continue
+			}
+			for ; lastEpoch < epoch; lastEpoch++ {
+				m.logger.Debug("new DA epoch", "epoch", lastEpoch+1, "daHeight", res.DAHeight)
+				if err := m.publishBlock(ctx); err != nil {
+					m.logger.Error("error while publishing block", "error", err)
+					m.recordError(err)
+					break
+				}
+			}
+		}
+	}
+}
+
+// daEpoch returns number of DA epoch containing given DA height. Epochs are counted from DAStartHeight.
+func (m *Manager) daEpoch(daHeight uint64) (uint64, bool) {
+	if daHeight < m.conf.DAStartHeight {
+		return 0, false
+	}
+	return (daHeight - m.conf.DAStartHeight) / m.conf.DAEpoch, true
+}
+
 func (m *Manager) SyncLoop(ctx context.Context) {
 	for {
 		select {
@@ -151,53 +491,158 @@ func (m *Manager) SyncLoop(ctx context.Context) {
 			// in case of client reconnecting after being offline
 			// newHeight may be significantly larger than currentHeight
 			// it's handled gently in RetrieveLoop
-			if newHeight > currentHeight {
+			// blocks received via P2P may be already applied, but they still have to be retrieved from DA layer
+			if newHeight > currentHeight || m.blockGossip {
 				atomic.StoreUint64(&m.syncTarget, newHeight)
-				m.retrieveCh <- newHeight
+				// RetrieveLoop reads the latest sync target, so it's enough to have one pending notification;
+				// blocking here could deadlock with RetrieveLoop sending retrieved block to SyncLoop
+				select {
+				case m.retrieveCh <- newHeight:
+				default:
+				}
 			}
 		case block := <-m.blockInCh:
 			m.logger.Debug("block body retrieved from DALC",
 				"height", block.Header.Height,
 				"hash", block.Hash(),
 			)
-			m.syncCache[block.Header.Height] = block
-			currentHeight := m.store.Height() // TODO(tzdybal): maybe store a copy in memory
-			b1, ok1 := m.syncCache[currentHeight+1]
-			b2, ok2 := m.syncCache[currentHeight+2]
-			if ok1 && ok2 {
-				newState, _, err := m.executor.ApplyBlock(ctx, m.lastState, b1)
-				if err != nil {
-					m.logger.Error("failed to ApplyBlock", "error", err)
-					continue
-				}
-				err = m.store.SaveBlock(b1, &b2.LastCommit)
-				if err != nil {
-					m.logger.Error("failed to save block", "error", err)
-					continue
-				}
-
-				m.lastState = newState
-				err = m.store.UpdateState(m.lastState)
-				if err != nil {
-					m.logger.Error("failed to save updated state", "error", err)
-					continue
-				}
-				delete(m.syncCache, currentHeight+1)
-			}
+			m.syncBlock(ctx, block)
+		case block := <-m.BlockInCh:
+			m.logger.Debug("block body received via P2P",
+				"height", block.Header.Height,
+				"hash", block.Hash(),
+			)
+			m.syncBlock(ctx, block)
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+// syncBlock applies blocks from sync cache. Block is applied when the next block (containing its commit) is known.
+// Blocks received via P2P (see EnableBlockGossip) may be applied before they are included in DA layer - they are
+// finalized when retrieved from DA layer.
+func (m *Manager) syncBlock(ctx context.Context, block *types.Block) {
+	if height := atomic.LoadUint64(&m.resultsMismatch); height > 0 {
+		m.logger.Debug("sync halted because of results mismatch", "height", height)
+		return
+	}
+	currentHeight := m.store.Height() // TODO(tzdybal): maybe store a copy in memory
+	if block.Header.Height <= currentHeight {
+		m.includeSyncedBlock(block)
+		return
+	}
+	m.syncCache[block.Header.Height] = block
+	b1, ok1 := m.syncCache[currentHeight+1]
+	b2, ok2 := m.syncCache[currentHeight+2]
+	if !ok1 || !ok2 {
+		return
+	}
+	err := m.verifyRetrievedHeader(m.lastState, &types.SignedHeader{Header: b1.Header, Commit: b2.LastCommit})
+	if err != nil {
+		m.logger.Error("failed to verify block", "height", b1.Header.Height, "error", err)
+		delete(m.syncCache, currentHeight+1)
+		return
+	}
+	err = m.verifyValidityProof(ctx, b1)
+	if errors.Is(err, errInvalidProof) {
+		m.logger.Error("failed to verify validity proof", "height", b1.Header.Height, "error", err)
+		delete(m.syncCache, currentHeight+1)
+		return
+	}
+	if err != nil {
+		// block is applied when proof is available, on next attempt to sync
+		m.logger.Debug("failed to fetch validity proof", "height", b1.Header.Height, "error", err)
+		return
+	}
+	newState, responses, _, err := m.executor.ApplyBlock(ctx, m.lastState, b1)
+	if err != nil {
+		m.logger.Error("failed to ApplyBlock", "error", err)
+		return
+	}
+	err = m.store.SaveBlock(b1, &b2.LastCommit)
+	if err != nil {
+		m.logger.Error("failed to save block", "error", err)
+		return
+	}
+	err = m.store.SaveBlockResponses(b1.Header.Height, responses)
+	if err != nil {
+		m.logger.Error("failed to save block responses", "error", err)
+		return
+	}
+
+	m.setLastState(newState)
+	err = m.store.UpdateState(m.lastState)
+	if err != nil {
+		m.logger.Error("failed to save updated state", "error", err)
+		return
+	}
+	m.recordApplied(b1.Header.Height)
+	m.onBlockApplied(b1)
+	delete(m.syncCache, currentHeight+1)
+	if m.conf.VerifyResults {
+		m.verifyResults(newState, b1, &b2.Header)
+	}
+	daInfo, err := m.store.LoadDAInfo(b1.Header.Height)
+	if err != nil && !m.blockGossip {
+		m.logger.Error("failed to load DA info", "height", b1.Header.Height, "error", err)
+	}
+	if err != nil {
+		// block was received via P2P, it's included and finalized when retrieved from DA layer
+		m.publishSoftBlockEvent(b1)
+		return
+	}
+	// block was retrieved from DA layer, so it's already included
+	m.txTracer.Included(b1.Data.Txs, b1.Header.Height)
+	m.publishSoftBlockEvent(b1)
+	if m.conf.DAConfirmDepth > 0 {
+		// block is finalized by FinalityLoop
+		return
+	}
+	m.finalizeBlock(b1, daInfo.DAHeight)
+}
+
+// includeSyncedBlock handles block retrieved from DA layer after it was already applied (block was received via
+// P2P). Block is finalized, if DAConfirmDepth is not set.
+func (m *Manager) includeSyncedBlock(block *types.Block) {
+	daInfo, err := m.store.LoadDAInfo(block.Header.Height)
+	if err != nil {
+		// block received via P2P is already applied
+		return
+	}
+	synced, err := m.store.LoadBlock(block.Header.Height)
+	if err != nil {
+		m.logger.Error("failed to load block", "height", block.Header.Height, "error", err)
+		return
+	}
+	if synced.Hash() != block.Hash() {
+		m.logger.Error("block included in DA layer doesn't match applied block", "height", block.Header.Height,
+			"included", block.Hash(), "applied", synced.Hash())
+		return
+	}
+	m.txTracer.Included(block.Data.Txs, block.Header.Height)
+	if m.conf.DAConfirmDepth == 0 && block.Header.Height > m.FirmHeight() {
+		m.finalizeBlock(block, daInfo.DAHeight)
+	}
+}
+
 func (m *Manager) RetrieveLoop(ctx context.Context) {
 	for {
 		select {
 		case <-m.retrieveCh:
 			target := atomic.LoadUint64(&m.syncTarget)
-			for h := m.store.Height() + 1; h <= target; h++ {
+			from := m.store.Height() + 1
+			if m.blockGossip {
+				// blocks received via P2P are retrieved from DA layer to be finalized
+				from = m.getSyncState().IncludedHeight + 1
+			}
+			for h := from; h <= target; h++ {
 				m.logger.Debug("trying to retrieve block from DALC", "height", h)
-				m.mustRetrieveBlock(ctx, h)
+				if err := m.retrieveBlock(ctx, h); err != nil {
+					// retrieval is retried when next header is received
+					m.logger.Error("failed to retrieve block from DALC", "height", h, "error", err)
+					break
+				}
 			}
 		case <-ctx.Done():
 			return
@@ -205,21 +650,21 @@ func (m *Manager) RetrieveLoop(ctx context.Context) {
 	}
 }
 
-func (m *Manager) mustRetrieveBlock(ctx context.Context, height uint64) {
+func (m *Manager) retrieveBlock(ctx context.Context, height uint64) error {
 	// TODO(tzdybal): extract configuration option
 	maxRetries := 10
 
+	var err error
 	for r := 0; r < maxRetries; r++ {
-		err := m.fetchBlock(ctx, height)
+		err = m.fetchBlock(ctx, height)
 		if err == nil {
-			return
+			return nil
 		}
 		// TODO(tzdybal): configuration option
 		// TODO(tzdybal): exponential backoff
 		time.Sleep(100 * time.Millisecond)
 	}
-	// TODO(tzdybal): this is only temporary solution, for MVP
-	panic("failed to retrieve block with DALC")
+	return err
 }
 
 func (m *Manager) fetchBlock(ctx context.Context, height uint64) error {
@@ -227,6 +672,14 @@ func (m *Manager) fetchBlock(ctx context.Context, height uint64) error {
 	blockRes := m.retriever.RetrieveBlock(height)
 	switch blockRes.Code {
 	case da.StatusSuccess:
+		if blockRes.DAHeight < m.conf.DAStartHeight {
+			return fmt.Errorf("block retrieved from DA height %d, before chain start height %d", blockRes.DAHeight, m.conf.DAStartHeight)
+		}
+		err = m.store.SaveDAInfo(height, &types.DAInfo{DAHeight: blockRes.DAHeight})
+		if err != nil {
+			return fmt.Errorf("failed to save DA info: %w", err)
+		}
+		m.recordIncluded(height, blockRes.DAHeight)
 		m.blockInCh <- blockRes.Block
 	case da.StatusError:
 		err = fmt.Errorf("failed to retrieve block: %s", blockRes.Message)
@@ -237,7 +690,7 @@ func (m *Manager) fetchBlock(ctx context.Context, height uint64) error {
 }
 
 func (m *Manager) getRemainingSleep(start time.Time) time.Duration {
-	publishingDuration := time.Since(start)
+	publishingDuration := m.clock.Now().Sub(start)
 	sleepDuration := m.conf.BlockTime - publishingDuration
 	if sleepDuration < 0 {
 		sleepDuration = 0
@@ -254,24 +707,38 @@ func (m *Manager) publishBlock(ctx context.Context) error {
 
 	// this is a special case, when first block is produced - there is no previous commit
 	if newHeight == uint64(m.genesis.InitialHeight) {
-		lastCommit = &types.Commit{Height: height, HeaderHash: [32]byte{}}
+		lastCommit = &types.Commit{}
 	} else {
 		lastCommit, err = m.store.LoadCommit(height)
 		if err != nil {
 			return fmt.Errorf("error while loading last commit: %w", err)
 		}
-		lastBlock, err := m.store.LoadBlock(height)
+		lastHeader, err := m.store.LoadHeader(height)
 		if err != nil {
 			return fmt.Errorf("error while loading last block: %w", err)
 		}
-		lastHeaderHash = lastBlock.Header.Hash()
+		lastHeaderHash = lastHeader.Hash()
+	}
+
+	if !m.isSequencer() {
+		m.logger.Debug("skipping block production, node is not the current sequencer", "height", newHeight)
+		m.recordProduced()
+		return nil
+	}
+	if !m.Aggregating() {
+		m.logger.Debug("skipping block production, block production is paused", "height", newHeight)
+		m.recordProduced()
+		return nil
 	}
 
 	m.logger.Info("Creating and publishing block", "height", newHeight)
 
-	block := m.executor.CreateBlock(newHeight, lastCommit, lastHeaderHash, m.lastState)
+	block, err := m.executor.CreateBlock(newHeight, lastCommit, lastHeaderHash, m.lastState)
+	if err != nil {
+		return fmt.Errorf("error while creating block: %w", err)
+	}
 	m.logger.Debug("block info", "num_tx", len(block.Data.Txs))
-	newState, _, err := m.executor.ApplyBlock(ctx, m.lastState, block)
+	newState, responses, _, err := m.executor.ApplyBlock(ctx, m.lastState, block)
 	if err != nil {
 		return err
 	}
@@ -295,27 +762,294 @@ func (m *Manager) publishBlock(ctx context.Context) error {
 		return err
 	}
 
-	m.lastState = newState
+	err = m.store.SaveBlockResponses(block.Header.Height, responses)
+	if err != nil {
+		return err
+	}
+
+	m.setLastState(newState)
 	err = m.store.UpdateState(m.lastState)
 	if err != nil {
 		return err
 	}
+	m.recordApplied(block.Header.Height)
+	m.recordProduced()
+	m.onBlockProduced(block)
+	m.onBlockApplied(block)
+	m.txTracer.Included(block.Data.Txs, block.Header.Height)
+	m.publishSoftBlockEvent(block)
+	m.refreshOrderingReceipts()
+	if m.blockGossip {
+		select {
+		case m.BlockOutCh <- block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return m.broadcastBlock(ctx, block, commit)
+}
+
+// Handshake makes sure that application state matches the node state, after connection to the application is
+// re-established. Blocks committed by the node, but not by the application (because it lost its latest state) are
+// replayed using given consensus connection.
+func (m *Manager) Handshake(info *abci.ResponseInfo, consensus proxy.AppConnConsensus) error {
+	m.lastStateMtx.RLock()
+	s := m.lastState
+	m.lastStateMtx.RUnlock()
+
+	appHeight := info.LastBlockHeight
+	m.logger.Info("ABCI handshake", "appHeight", appHeight, "height", s.LastBlockHeight)
+	if appHeight > s.LastBlockHeight {
+		return fmt.Errorf("application height %d is greater than node height %d", appHeight, s.LastBlockHeight)
+	}
+
+	executor := state.NewABCIExecutor(consensus, m.genesis.ChainID, m.logger)
+	exec := state.NewBlockExecutor(nil, m.conf.NamespaceID, m.genesis.ChainID, nil, executor, nil, m.logger)
+	appHash := info.LastBlockAppHash
+	if appHeight == 0 {
+		res, err := exec.InitChain(m.genesis)
+		if err != nil {
+			return err
+		}
+		if s.LastBlockHeight == 0 {
+			return nil
+		}
+		appHeight = s.InitialHeight - 1
+		appHash = res.AppHash
+	}
+
+	for height := appHeight + 1; height <= s.LastBlockHeight; height++ {
+		block, err := m.store.LoadBlock(uint64(height))
+		if err != nil {
+			return fmt.Errorf("failed to load block %d: %w", height, err)
+		}
+		m.logger.Info("replaying block", "height", height)
+		appHash, err = exec.ReplayBlock(context.Background(), s, block)
+		if err != nil {
+			return fmt.Errorf("failed to replay block %d: %w", height, err)
+		}
+	}
+
+	if !bytes.Equal(appHash, s.AppHash[:]) {
+		return fmt.Errorf("application hash mismatch at height %d: expected %X, got %X", s.LastBlockHeight, s.AppHash[:], appHash)
+	}
+	return nil
+}
+
+// VerifyHeader checks if header was signed by the current sequencer.
+//
+// Sequencer can be rotated with validator updates returned from EndBlock. Such change is known to the node one block
+// before it takes effect, so headers signed by the next sequencer are accepted as well.
+func (m *Manager) VerifyHeader(header *types.SignedHeader) error {
+	m.lastStateMtx.RLock()
+	defer m.lastStateMtx.RUnlock()
+
+	err := m.verifyCommit(m.lastState, header)
+	if err != nil && m.lastState.NextValidators.Size() > 0 {
+		err = header.VerifySignature(m.lastState.NextValidators.GetProposer().PubKey)
+	}
+	return m.handleSignatureError(header, err, headerSourceGossip)
+}
+
+// VerifySequencerSignature checks if message was signed by the current (or next, see VerifyHeader) sequencer.
+func (m *Manager) VerifySequencerSignature(msg []byte, signature []byte) error {
+	m.lastStateMtx.RLock()
+	defer m.lastStateMtx.RUnlock()
+
+	if m.lastState.Validators.Size() == 0 {
+		return errNoSequencer
+	}
+	if m.lastState.Validators.GetProposer().PubKey.VerifySignature(msg, signature) {
+		return nil
+	}
+	if m.lastState.NextValidators.Size() > 0 && m.lastState.NextValidators.GetProposer().PubKey.VerifySignature(msg, signature) {
+		return nil
+	}
+	return errors.New("invalid sequencer signature")
+}
+
+// verifyRetrievedHeader checks if header of a block retrieved from DA layer matches the commit and was signed
+// by the sequencer expected for next block.
+func (m *Manager) verifyRetrievedHeader(s state.State, header *types.SignedHeader) error {
+	if err := header.ValidateBasic(); err != nil {
+		return err
+	}
+	return m.handleSignatureError(header, m.verifyCommit(s, header), headerSourceDA)
+}
+
+// handleSignatureError records signature verification failure. In permissive mode, error is only logged.
+func (m *Manager) handleSignatureError(header *types.SignedHeader, err error, source string) error {
+	if err == nil {
+		return nil
+	}
+	m.metrics.HeaderSignatureFailures.With("source", source).Add(1)
+	if m.conf.HeaderVerification == config.HeaderVerificationPermissive {
+		m.logger.Error("accepting header with invalid signature (permissive header verification)",
+			"height", header.Header.Height, "source", source, "error", err)
+		return nil
+	}
+	return err
+}
+
+// verifyCommit checks if header was signed by the sequencer expected for next block, according to given state.
+func (m *Manager) verifyCommit(s state.State, header *types.SignedHeader) error {
+	if s.Validators.Size() == 0 {
+		return errNoSequencer
+	}
+	return header.VerifySignature(s.Validators.GetProposer().PubKey)
+}
+
+// verifyResults compares results of executing block (in given state) with the header of the next block.
+// On mismatch, syncing is halted and EventResultsMismatch is published.
+func (m *Manager) verifyResults(s state.State, block *types.Block, next *types.Header) {
+	if s.AppHash == next.AppHash && s.LastResultsHash == next.LastResultsHash {
+		return
+	}
+	height := block.Header.Height
+	m.logger.Error("results of executed block don't match next block header, halting sync", "height", height,
+		"appHash", fmt.Sprintf("%X", s.AppHash), "expectedAppHash", fmt.Sprintf("%X", next.AppHash),
+		"resultsHash", fmt.Sprintf("%X", s.LastResultsHash), "expectedResultsHash", fmt.Sprintf("%X", next.LastResultsHash))
+	m.metrics.ResultsMismatches.Add(1)
+	atomic.StoreUint64(&m.resultsMismatch, height)
+
+	if m.eventBus == nil {
+		return
+	}
+	err := m.eventBus.Publish(types.EventResultsMismatch, types.EventDataResultsMismatch{
+		Height:              height,
+		AppHash:             s.AppHash[:],
+		ExpectedAppHash:     next.AppHash[:],
+		ResultsHash:         s.LastResultsHash[:],
+		ExpectedResultsHash: next.LastResultsHash[:],
+	})
+	if err != nil {
+		m.logger.Error("failed to publish results mismatch event", "height", height, "error", err)
+	}
+}
+
+// isSequencer returns true if node's proposer key belongs to the sequencer expected for next block.
+func (m *Manager) isSequencer() bool {
+	if m.lastState.Validators.Size() == 0 {
+		return true
+	}
+	proposerAddress, err := getAddress(m.proposerKey)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(m.lastState.Validators.GetProposer().Address, proposerAddress)
+}
 
-	return m.broadcastBlock(ctx, block)
+func (m *Manager) setLastState(s state.State) {
+	m.lastStateMtx.Lock()
+	defer m.lastStateMtx.Unlock()
+	m.lastState = s
 }
 
-func (m *Manager) broadcastBlock(ctx context.Context, block *types.Block) error {
+func (m *Manager) broadcastBlock(ctx context.Context, block *types.Block, commit *types.Commit) error {
+	m.watchdog.submitMtx.Lock()
+	defer m.watchdog.submitMtx.Unlock()
+	if _, err := m.store.LoadDAInfo(block.Header.Height); err == nil {
+		// block was already submitted (by watchdog)
+		return nil
+	}
+
+	if m.daAccount != nil {
+		if err := m.daAccount.CheckBalance(); err != nil {
+			m.logger.Error("submitting block with low DA layer account balance", "height", block.Header.Height, "error", err)
+		}
+	}
 	res := m.dalc.SubmitBlock(block)
 	if res.Code != da.StatusSuccess {
-		return fmt.Errorf("DA layer submission failed: %s", res.Message)
+		if m.daAccount != nil {
+			// submission may fail because of outdated sequence number or insufficient funds
+			if err := m.daAccount.Sync(); err != nil {
+				m.logger.Error("failed to sync DA layer account", "error", err)
+			}
+		}
+		err := fmt.Errorf("DA layer submission failed: %s", res.Message)
+		m.onDASubmissionFailed(block, err)
+		return err
+	}
+	err := m.store.SaveDAInfo(block.Header.Height, &types.DAInfo{DAHeight: res.DAHeight, TxHash: res.TxHash, Fee: res.Fee})
+	if err != nil {
+		return fmt.Errorf("failed to save DA info: %w", err)
+	}
+	m.recordDAFee(res.Fee)
+	m.recordIncluded(block.Header.Height, res.DAHeight)
+	m.recordSubmitted(block.Header.Height)
+	m.onDAIncluded(block, res.DAHeight)
+	if m.conf.DAConfirmDepth == 0 {
+		m.finalizeBlock(block, res.DAHeight)
 	}
 
-	m.HeaderOutCh <- &block.Header
+	select {
+	case m.HeaderOutCh <- &types.SignedHeader{Header: block.Header, Commit: *commit}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 
 	return nil
 }
 
-func updateState(s *state.State, res *abci.ResponseInitChain) {
+// recordDAFee adds fee paid for block submission to cumulative DA layer spend. Caller must hold watchdog.submitMtx.
+func (m *Manager) recordDAFee(fee uint64) {
+	m.metrics.DAFees.Add(float64(fee))
+	m.metrics.DASubmissionFee.Observe(float64(fee))
+	m.daSpend.TotalFee += fee
+	m.daSpend.Submissions++
+	if err := m.store.SaveDASpend(&m.daSpend); err != nil {
+		m.logger.Error("failed to save DA spend", "error", err)
+	}
+}
+
+// publishSoftBlockEvent publishes EventNewSoftBlock for block applied by the node.
+func (m *Manager) publishSoftBlockEvent(block *types.Block) {
+	header, ok := m.eventHeader(block)
+	if !ok {
+		return
+	}
+	err := m.eventBus.Publish(types.EventNewSoftBlock, types.EventDataNewSoftBlock{
+		Header: header,
+		NumTxs: int64(len(block.Data.Txs)),
+	})
+	if err != nil {
+		m.logger.Error("failed to publish soft block event", "height", block.Header.Height, "error", err)
+	}
+}
+
+// publishFinalizedBlockEvent publishes EventNewFinalizedBlock for block included in DA layer.
+func (m *Manager) publishFinalizedBlockEvent(block *types.Block, daHeight uint64) {
+	header, ok := m.eventHeader(block)
+	if !ok {
+		return
+	}
+	err := m.eventBus.Publish(types.EventNewFinalizedBlock, types.EventDataNewFinalizedBlock{
+		Header:   header,
+		NumTxs:   int64(len(block.Data.Txs)),
+		DAHeight: daHeight,
+	})
+	if err != nil {
+		m.logger.Error("failed to publish finalized block event", "height", block.Header.Height, "error", err)
+	}
+}
+
+// eventHeader returns block header in Tendermint format, if events can be published.
+func (m *Manager) eventHeader(block *types.Block) (tmtypes.Header, bool) {
+	if m.eventBus == nil {
+		return tmtypes.Header{}, false
+	}
+	header, err := abciconv.ToABCIHeader(&block.Header)
+	if err != nil {
+		m.logger.Error("failed to convert block header", "height", block.Header.Height, "error", err)
+		return tmtypes.Header{}, false
+	}
+	header.ChainID = m.genesis.ChainID
+	return header, true
+}
+
+// updateState applies InitChain response (app hash, consensus params and validators) to the state.
+func updateState(s *state.State, res *abci.ResponseInitChain) error {
 	// If the app did not return an app hash, we keep the one set from the genesis doc in
 	// the state. We don't set appHash since we don't want the genesis doc app hash
 	// recorded in the genesis block. We should probably just remove GenesisDoc.AppHash.
@@ -346,4 +1080,19 @@ func updateState(s *state.State, res *abci.ResponseInitChain) {
 	}
 	// We update the last results hash with the empty hash, to conform with RFC-6962.
 	copy(s.LastResultsHash[:], merkle.HashFromByteSlices(nil))
+
+	// If the app returned validators, they replace the validators from genesis doc.
+	if len(res.Validators) > 0 {
+		if len(res.Validators) > 1 {
+			return fmt.Errorf("only single sequencer is supported, but app returned %d validators", len(res.Validators))
+		}
+		validators, err := tmtypes.PB2TM.ValidatorUpdates(res.Validators)
+		if err != nil {
+			return fmt.Errorf("invalid validators returned by InitChain: %w", err)
+		}
+		s.Validators = tmtypes.NewValidatorSet(validators)
+		s.NextValidators = tmtypes.NewValidatorSet(validators).CopyIncrementProposerPriority(1)
+	}
+
+	return nil
 }
@@ -7,21 +7,29 @@ import (
 
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/types"
 
 	"github.com/celestiaorg/optimint/config"
 	"github.com/celestiaorg/optimint/da"
 	mockda "github.com/celestiaorg/optimint/da/mock"
+	"github.com/celestiaorg/optimint/mocks"
 	"github.com/celestiaorg/optimint/state"
 	"github.com/celestiaorg/optimint/store"
+	optypes "github.com/celestiaorg/optimint/types"
 )
 
 func TestInitialState(t *testing.T) {
 	genesis := &types.GenesisDoc{
 		ChainID:       "genesis id",
 		InitialHeight: 100,
+		AppHash:       []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32},
 	}
 	sampleState := state.State{
 		ChainID:         "state id",
@@ -41,6 +49,7 @@ func TestInitialState(t *testing.T) {
 		genesis                 *types.GenesisDoc
 		expectedInitialHeight   int64
 		expectedLastBlockHeight int64
+		expectedStoreHeight     uint64
 		expectedChainID         string
 	}{
 		{
@@ -49,6 +58,7 @@ func TestInitialState(t *testing.T) {
 			genesis:                 genesis,
 			expectedInitialHeight:   genesis.InitialHeight,
 			expectedLastBlockHeight: 0,
+			expectedStoreHeight:     uint64(genesis.InitialHeight - 1),
 			expectedChainID:         genesis.ChainID,
 		},
 		{
@@ -57,6 +67,7 @@ func TestInitialState(t *testing.T) {
 			genesis:                 genesis,
 			expectedInitialHeight:   sampleState.InitialHeight,
 			expectedLastBlockHeight: sampleState.LastBlockHeight,
+			expectedStoreHeight:     uint64(sampleState.LastBlockHeight),
 			expectedChainID:         sampleState.ChainID,
 		},
 	}
@@ -67,17 +78,27 @@ func TestInitialState(t *testing.T) {
 		NamespaceID: [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
 	}
 
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(t, err)
+
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			assert := assert.New(t)
 			logger := log.TestingLogger()
 			dalc := getMockDALC(logger)
-			agg, err := NewManager(key, conf, c.genesis, c.store, nil, nil, dalc, nil, logger)
+			agg, err := NewManager(key, conf, c.genesis, c.store, nil, state.NewABCIExecutor(proxy.NewAppConnConsensus(client), c.genesis.ChainID, logger), dalc, nil, logger)
 			assert.NoError(err)
 			assert.NotNil(agg)
+			if c.expectedLastBlockHeight == 0 {
+				// app hash from genesis is kept, if app doesn't return one from InitChain
+				assert.Equal([]byte(c.genesis.AppHash), agg.lastState.AppHash[:])
+			}
 			assert.Equal(c.expectedChainID, agg.lastState.ChainID)
 			assert.Equal(c.expectedInitialHeight, agg.lastState.InitialHeight)
 			assert.Equal(c.expectedLastBlockHeight, agg.lastState.LastBlockHeight)
+			assert.Equal(c.expectedStoreHeight, c.store.Height())
 		})
 	}
 }
@@ -88,3 +109,59 @@ func getMockDALC(logger log.Logger) da.DataAvailabilityLayerClient {
 	_ = dalc.Start()
 	return dalc
 }
+
+func TestVerifyHeader(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	sequencerKey := ed25519.GenPrivKey()
+	otherKey := ed25519.GenPrivKey()
+
+	signedHeader := func(key tmcrypto.PrivKey) *optypes.SignedHeader {
+		header := optypes.Header{Height: 1, ProposerAddress: sequencerKey.PubKey().Address()}
+		headerBytes, err := header.MarshalBinary()
+		require.NoError(err)
+		sig, err := key.Sign(headerBytes)
+		require.NoError(err)
+		return &optypes.SignedHeader{
+			Header: header,
+			Commit: optypes.Commit{Height: 1, HeaderHash: header.Hash(), Signatures: []optypes.Signature{sig}},
+		}
+	}
+
+	validators := types.NewValidatorSet([]*types.Validator{types.NewValidator(sequencerKey.PubKey(), 1)})
+	m := &Manager{
+		lastState: state.State{Validators: validators, NextValidators: validators},
+		metrics:   NopMetrics(),
+		logger:    log.TestingLogger(),
+	}
+
+	assert.NoError(m.VerifyHeader(signedHeader(sequencerKey)))
+	assert.NoError(m.verifyRetrievedHeader(m.lastState, signedHeader(sequencerKey)))
+	assert.Error(m.VerifyHeader(signedHeader(otherKey)))
+	assert.Error(m.verifyRetrievedHeader(m.lastState, signedHeader(otherKey)))
+
+	// commit not matching the header is always rejected
+	invalid := signedHeader(sequencerKey)
+	invalid.Commit.HeaderHash = [32]byte{}
+	assert.Error(m.verifyRetrievedHeader(m.lastState, invalid))
+
+	m.conf.HeaderVerification = config.HeaderVerificationPermissive
+	assert.NoError(m.VerifyHeader(signedHeader(otherKey)))
+	assert.NoError(m.verifyRetrievedHeader(m.lastState, signedHeader(otherKey)))
+	assert.Error(m.verifyRetrievedHeader(m.lastState, invalid))
+}
+
+func TestRecordDAFee(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	m := &Manager{store: s, metrics: NopMetrics(), logger: log.TestingLogger()}
+	m.recordDAFee(3)
+	m.recordDAFee(5)
+
+	spend, err := s.LoadDASpend()
+	require.NoError(err)
+	assert.Equal(&optypes.DASpend{TotalFee: 8, Submissions: 2}, spend)
+}
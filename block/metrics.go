@@ -0,0 +1,121 @@
+package block
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	optmetrics "github.com/celestiaorg/optimint/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "block_manager"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Time between transaction admission to mempool and inclusion in a block, in seconds.
+	TxInclusionLatency metrics.Histogram
+	// Time between transaction admission to mempool and block submission to DA layer, in seconds.
+	TxFinalizationLatency metrics.Histogram
+	// Stalled is 1 if watchdog detected stalled block production or submission, 0 otherwise.
+	Stalled metrics.Gauge
+	// Number of headers with invalid sequencer signature, by source ("gossip" or "da").
+	HeaderSignatureFailures metrics.Counter
+	// Number of synced blocks with results not matching the next block header (see VerifyResults).
+	ResultsMismatches metrics.Counter
+	// Number of DA layer reorgs detected (blocks included in DA block with changed hash).
+	DAReorgs metrics.Counter
+	// Total fees paid for block submissions to DA layer, in DA layer units.
+	DAFees metrics.Counter
+	// Fee paid for a single block submission to DA layer, in DA layer units.
+	DASubmissionFee metrics.Histogram
+	// Number of panics recovered in long-running goroutines of the node, by routine.
+	Panics metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library, registered in given registerer.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(registerer stdprometheus.Registerer, namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		TxInclusionLatency: optmetrics.NewHistogramFrom(registerer, stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "tx_inclusion_latency_seconds",
+			Help:      "Time between transaction admission to mempool and inclusion in a block.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.1, 2, 12),
+		}, labels).With(labelsAndValues...),
+		TxFinalizationLatency: optmetrics.NewHistogramFrom(registerer, stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "tx_finalization_latency_seconds",
+			Help:      "Time between transaction admission to mempool and block submission to DA layer.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.1, 2, 12),
+		}, labels).With(labelsAndValues...),
+		Stalled: optmetrics.NewGaugeFrom(registerer, stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "stalled",
+			Help:      "Whether block production or submission is stalled (1 if it is, 0 otherwise).",
+		}, labels).With(labelsAndValues...),
+		HeaderSignatureFailures: optmetrics.NewCounterFrom(registerer, stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "header_signature_failures",
+			Help:      "Number of headers with invalid sequencer signature, by source.",
+		}, append(labels, "source")).With(labelsAndValues...),
+		ResultsMismatches: optmetrics.NewCounterFrom(registerer, stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "results_mismatches",
+			Help:      "Number of synced blocks with results not matching the next block header.",
+		}, labels).With(labelsAndValues...),
+		DAReorgs: optmetrics.NewCounterFrom(registerer, stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_reorgs",
+			Help:      "Number of blocks affected by detected DA layer reorgs.",
+		}, labels).With(labelsAndValues...),
+		DAFees: optmetrics.NewCounterFrom(registerer, stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_fees",
+			Help:      "Total fees paid for block submissions to DA layer.",
+		}, labels).With(labelsAndValues...),
+		DASubmissionFee: optmetrics.NewHistogramFrom(registerer, stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_submission_fee",
+			Help:      "Fee paid for a single block submission to DA layer.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 4, 12),
+		}, labels).With(labelsAndValues...),
+		Panics: optmetrics.NewCounterFrom(registerer, stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "panics",
+			Help:      "Number of panics recovered in long-running goroutines, by routine.",
+		}, append(labels, "routine")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		TxInclusionLatency:      discard.NewHistogram(),
+		TxFinalizationLatency:   discard.NewHistogram(),
+		Stalled:                 discard.NewGauge(),
+		HeaderSignatureFailures: discard.NewCounter(),
+		ResultsMismatches:       discard.NewCounter(),
+		DAReorgs:                discard.NewCounter(),
+		DAFees:                  discard.NewCounter(),
+		DASubmissionFee:         discard.NewHistogram(),
+		Panics:                  discard.NewCounter(),
+	}
+}
@@ -0,0 +1,140 @@
+package block
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/mempool"
+	"github.com/celestiaorg/optimint/state"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// txOrdering assigns first-come-first-served sequence numbers to transactions added to the mempool, and issues
+// signed ordering receipts (see types.OrderingReceipt).
+type txOrdering struct {
+	chainID string
+	key     crypto.PrivKey
+
+	mtx      sync.Mutex
+	next     uint64
+	receipts map[[mempool.TxKeySize]byte]*types.OrderingReceipt
+}
+
+var _ state.TxSequencer = &txOrdering{}
+
+func newTxOrdering(chainID string, key crypto.PrivKey) *txOrdering {
+	return &txOrdering{
+		chainID:  chainID,
+		key:      key,
+		next:     1,
+		receipts: make(map[[mempool.TxKeySize]byte]*types.OrderingReceipt),
+	}
+}
+
+// add assigns the next sequence number (greater than the last one included in the chain) to transaction
+// and returns signed receipt.
+func (o *txOrdering) add(tx tmtypes.Tx, now time.Time, last uint64) (*types.OrderingReceipt, error) {
+	receipt := &types.OrderingReceipt{
+		ChainID:   o.chainID,
+		TxHash:    tx.Hash(),
+		Timestamp: now,
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if o.next <= last {
+		o.next = last + 1
+	}
+	receipt.Sequence = o.next
+	sig, err := o.key.Sign(receipt.SignBytes())
+	if err != nil {
+		return nil, err
+	}
+	receipt.Signature = sig
+	o.next++
+	o.receipts[mempool.TxKey(tx)] = receipt
+	return receipt, nil
+}
+
+// receipt returns ordering receipt of transaction that is pending in the mempool (nil if it's not known).
+func (o *txOrdering) receipt(tx tmtypes.Tx) *types.OrderingReceipt {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	return o.receipts[mempool.TxKey(tx)]
+}
+
+// TxSequence implements state.TxSequencer.
+func (o *txOrdering) TxSequence(tx types.Tx) uint64 {
+	if receipt := o.receipt(tmtypes.Tx(tx)); receipt != nil {
+		return receipt.Sequence
+	}
+	return 0
+}
+
+// refresh removes receipts of transactions that are not in the mempool anymore (included in the chain or evicted),
+// and issues new receipts for pending transactions without a valid one. Receipts are kept only in memory, so
+// transactions pending in the mempool when the ordering is started don't have receipts. Transactions skipped by
+// block production (with sequence number not greater than last one included in the chain) would never be included
+// with their old sequence numbers.
+func (o *txOrdering) refresh(mp mempool.Mempool, now time.Time, last uint64) error {
+	o.mtx.Lock()
+	for key := range o.receipts {
+		if !mp.HasTxByKey(key) {
+			delete(o.receipts, key)
+		}
+	}
+	o.mtx.Unlock()
+
+	for _, tx := range mp.ReapMaxTxs(-1) {
+		if receipt := o.receipt(tx); receipt != nil && receipt.Sequence > last {
+			continue
+		}
+		if _, err := o.add(tx, now, last); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TxAdded issues ordering receipt for transaction added to the mempool, if FCFS ordering is enabled.
+// It should be called only by the aggregator.
+func (m *Manager) TxAdded(tx tmtypes.Tx) {
+	if m.ordering == nil {
+		return
+	}
+	m.lastStateMtx.RLock()
+	last := m.lastState.LastTxSequence
+	m.lastStateMtx.RUnlock()
+	receipt, err := m.ordering.add(tx, m.clock.Now(), last)
+	if err != nil {
+		m.logger.Error("failed to issue ordering receipt", "tx", tx.Hash(), "error", err)
+		return
+	}
+	m.logger.Debug("issued ordering receipt", "tx", tx.Hash(), "sequence", receipt.Sequence)
+}
+
+// refreshOrderingReceipts issues ordering receipts for pending transactions without valid one (see
+// txOrdering.refresh), if FCFS ordering is enabled.
+func (m *Manager) refreshOrderingReceipts() {
+	if m.ordering == nil {
+		return
+	}
+	m.lastStateMtx.RLock()
+	last := m.lastState.LastTxSequence
+	m.lastStateMtx.RUnlock()
+	if err := m.ordering.refresh(m.mempool, m.clock.Now(), last); err != nil {
+		m.logger.Error("failed to issue ordering receipts", "error", err)
+	}
+}
+
+// OrderingReceipt returns ordering receipt of transaction pending in the mempool, or nil if it's not known (FCFS
+// ordering is disabled, node is not the aggregator or transaction is already included in a block).
+func (m *Manager) OrderingReceipt(tx tmtypes.Tx) *types.OrderingReceipt {
+	if m.ordering == nil {
+		return nil
+	}
+	return m.ordering.receipt(tx)
+}
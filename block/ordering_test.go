@@ -0,0 +1,74 @@
+package block
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/proxy"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/mempool"
+	"github.com/celestiaorg/optimint/mocks"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestTxOrdering(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(err)
+	raw, err := key.GetPublic().Raw()
+	require.NoError(err)
+	pubKey := ed25519.PubKey(raw)
+
+	ordering := newTxOrdering("test", key)
+	now := time.Unix(1000, 0)
+
+	r1, err := ordering.add(tmtypes.Tx("tx1"), now, 0)
+	require.NoError(err)
+	assert.EqualValues(1, r1.Sequence)
+	assert.NoError(r1.VerifySignature(pubKey))
+
+	// sequence numbers have to be greater than the last one included in the chain
+	r2, err := ordering.add(tmtypes.Tx("tx2"), now, 5)
+	require.NoError(err)
+	assert.EqualValues(6, r2.Sequence)
+	assert.NoError(r2.VerifySignature(pubKey))
+
+	assert.Equal(r1, ordering.receipt(tmtypes.Tx("tx1")))
+	assert.EqualValues(6, ordering.TxSequence(types.Tx("tx2")))
+	assert.EqualValues(0, ordering.TxSequence(types.Tx("tx3")))
+
+	app := &mocks.Application{}
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
+	for _, tx := range []tmtypes.Tx{tmtypes.Tx("tx1"), tmtypes.Tx("tx3"), tmtypes.Tx("tx4")} {
+		require.NoError(mpool.CheckTx(tx, nil, mempool.TxInfo{}))
+	}
+	r4, err := ordering.add(tmtypes.Tx("tx4"), now, 5)
+	require.NoError(err)
+	assert.EqualValues(7, r4.Sequence)
+
+	// tx2 is not in the mempool anymore, tx1 was skipped (sequence number 6 is included), tx3 has no receipt
+	require.NoError(ordering.refresh(mpool, now.Add(time.Second), 6))
+	assert.Nil(ordering.receipt(tmtypes.Tx("tx2")))
+	assert.Equal(r4, ordering.receipt(tmtypes.Tx("tx4")))
+	for _, tx := range []tmtypes.Tx{tmtypes.Tx("tx1"), tmtypes.Tx("tx3")} {
+		receipt := ordering.receipt(tx)
+		require.NotNil(receipt)
+		assert.Greater(receipt.Sequence, r4.Sequence)
+		assert.Equal(now.Add(time.Second), receipt.Timestamp)
+		assert.NoError(receipt.VerifySignature(pubKey))
+	}
+}
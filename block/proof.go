@@ -0,0 +1,119 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/optimint/types"
+)
+
+var (
+	// ErrProofNotAvailable is returned by ValidityProver if proof of a block is not generated yet.
+	ErrProofNotAvailable = errors.New("validity proof not available")
+
+	errInvalidProof = errors.New("invalid validity proof")
+)
+
+// ValidityProver generates validity proofs of blocks, usually by calling external prover service. Proofs are not
+// part of blocks - they're stored separately (see store.Store.SaveValidityProof).
+//
+// Aggregator requests proofs of produced blocks. Full nodes verifying proofs (see SetValidityVerifier) use
+// ValidityProver to fetch proofs of synced blocks, e.g. from the same prover service.
+type ValidityProver interface {
+	// ProveBlock returns validity proof of given block. It may take long time.
+	ProveBlock(ctx context.Context, block *types.Block) ([]byte, error)
+}
+
+// ValidityVerifier verifies validity proofs of blocks.
+type ValidityVerifier interface {
+	// VerifyProof returns error if proof doesn't prove validity of given block.
+	VerifyProof(block *types.Block, proof []byte) error
+}
+
+// SetValidityProver sets ValidityProver used to generate (by aggregator) or fetch (by full nodes) proofs of blocks.
+// It has to be called before the manager is started.
+func (m *Manager) SetValidityProver(prover ValidityProver) {
+	m.prover = prover
+}
+
+// SetValidityVerifier enables verification of validity proofs of synced blocks. Blocks without valid proof are not
+// applied. It requires ValidityProver (see SetValidityProver) and has to be called before the manager is started.
+func (m *Manager) SetValidityVerifier(verifier ValidityVerifier) {
+	m.verifier = verifier
+}
+
+// ProofLoop requests validity proofs of produced blocks every block time and saves them in the store. Blocks are
+// proven in order; proving starts after the latest block with a proof saved in the store.
+func (m *Manager) ProofLoop(ctx context.Context) {
+	ticker := m.clock.NewTicker(m.conf.BlockTime)
+	defer ticker.Stop()
+
+	proven := m.lastProvenHeight()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			for h := proven + 1; h <= m.store.Height(); h++ {
+				err := m.proveBlock(ctx, h)
+				if errors.Is(err, ErrProofNotAvailable) {
+					m.logger.Debug("validity proof not available yet", "height", h)
+					break
+				}
+				if err != nil {
+					if ctx.Err() == nil {
+						m.logger.Error("failed to generate validity proof", "height", h, "error", err)
+					}
+					break
+				}
+				proven = h
+			}
+		}
+	}
+}
+
+// lastProvenHeight returns height of the latest block with validity proof saved in the store, or the height
+// preceding the lowest block in the store, if there is no such block.
+func (m *Manager) lastProvenHeight() uint64 {
+	height := m.store.Height()
+	base := m.store.Base()
+	for ; height >= base && height > 0; height-- {
+		if _, err := m.store.LoadValidityProof(height); err == nil {
+			return height
+		}
+	}
+	return height
+}
+
+func (m *Manager) proveBlock(ctx context.Context, height uint64) error {
+	block, err := m.store.LoadBlock(height)
+	if err != nil {
+		return err
+	}
+	proof, err := m.prover.ProveBlock(ctx, block)
+	if err != nil {
+		return err
+	}
+	if err := m.store.SaveValidityProof(height, proof); err != nil {
+		return fmt.Errorf("failed to save validity proof: %w", err)
+	}
+	m.logger.Debug("validity proof saved", "height", height, "size", len(proof))
+	return nil
+}
+
+// verifyValidityProof fetches and verifies validity proof of synced block, if verification is enabled.
+// Verified proof is saved in the store.
+func (m *Manager) verifyValidityProof(ctx context.Context, block *types.Block) error {
+	if m.verifier == nil {
+		return nil
+	}
+	proof, err := m.prover.ProveBlock(ctx, block)
+	if err != nil {
+		return err
+	}
+	if err := m.verifier.VerifyProof(block, proof); err != nil {
+		return fmt.Errorf("%w: %s", errInvalidProof, err)
+	}
+	return m.store.SaveValidityProof(block.Header.Height, proof)
+}
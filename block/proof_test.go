@@ -0,0 +1,98 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// hashProver "proves" blocks by returning hash of the header; blocks above maxHeight are not proven yet.
+type hashProver struct {
+	maxHeight uint64
+}
+
+func (p *hashProver) ProveBlock(_ context.Context, block *types.Block) ([]byte, error) {
+	if block.Header.Height > p.maxHeight {
+		return nil, ErrProofNotAvailable
+	}
+	hash := block.Header.Hash()
+	return hash[:], nil
+}
+
+type hashVerifier struct{}
+
+func (hashVerifier) VerifyProof(block *types.Block, proof []byte) error {
+	if hash := block.Header.Hash(); !bytes.Equal(hash[:], proof) {
+		return fmt.Errorf("proof doesn't match block %d", block.Header.Height)
+	}
+	return nil
+}
+
+func TestProveBlocks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	for h := uint64(1); h <= 4; h++ {
+		block := &types.Block{Header: types.Header{Height: h}}
+		require.NoError(s.SaveBlock(block, &types.Commit{Height: h, HeaderHash: block.Header.Hash()}))
+	}
+	prover := &hashProver{maxHeight: 2}
+	m := &Manager{store: s, logger: log.TestingLogger()}
+	m.SetValidityProver(prover)
+	assert.EqualValues(0, m.lastProvenHeight())
+
+	require.NoError(m.proveBlock(context.Background(), 1))
+	require.NoError(m.proveBlock(context.Background(), 2))
+	assert.ErrorIs(m.proveBlock(context.Background(), 3), ErrProofNotAvailable)
+	assert.EqualValues(2, m.lastProvenHeight())
+
+	block, err := s.LoadBlock(2)
+	require.NoError(err)
+	proof, err := s.LoadValidityProof(2)
+	require.NoError(err)
+	assert.NoError(hashVerifier{}.VerifyProof(block, proof))
+}
+
+func TestVerifyValidityProof(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	m := &Manager{store: s, logger: log.TestingLogger()}
+	block := &types.Block{Header: types.Header{Height: 1}}
+
+	// verification is disabled by default
+	assert.NoError(m.verifyValidityProof(context.Background(), block))
+
+	m.SetValidityProver(&hashProver{maxHeight: 1})
+	m.SetValidityVerifier(hashVerifier{})
+	require.NoError(m.verifyValidityProof(context.Background(), block))
+	_, err := s.LoadValidityProof(1)
+	assert.NoError(err)
+
+	err = m.verifyValidityProof(context.Background(), &types.Block{Header: types.Header{Height: 2}})
+	assert.ErrorIs(err, ErrProofNotAvailable)
+	assert.False(errors.Is(err, errInvalidProof))
+
+	m.SetValidityProver(proverFunc(func(context.Context, *types.Block) ([]byte, error) { return []byte("invalid"), nil }))
+	err = m.verifyValidityProof(context.Background(), &types.Block{Header: types.Header{Height: 2}})
+	assert.ErrorIs(err, errInvalidProof)
+	_, err = s.LoadValidityProof(2)
+	assert.ErrorIs(err, store.ErrKeyNotFound)
+}
+
+type proverFunc func(ctx context.Context, block *types.Block) ([]byte, error)
+
+func (f proverFunc) ProveBlock(ctx context.Context, block *types.Block) ([]byte, error) {
+	return f(ctx, block)
+}
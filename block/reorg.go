@@ -0,0 +1,137 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// checkReorgs compares hashes of DA blocks containing the latest DAReorgWindow blocks with hashes seen previously.
+// Hash of DA block is recorded in DAInfo when block is checked for the first time.
+func (m *Manager) checkReorgs(ctx context.Context) {
+	height := m.store.Height()
+	from := m.store.Base()
+	if height >= m.conf.DAReorgWindow && height-m.conf.DAReorgWindow+1 > from {
+		from = height - m.conf.DAReorgWindow + 1
+	}
+	if from == 0 {
+		from = 1
+	}
+	for h := from; h <= height; h++ {
+		info, err := m.store.LoadDAInfo(h)
+		if err != nil {
+			// block is not included in DA layer (yet)
+			continue
+		}
+		res := m.hashReader.BlockHash(info.DAHeight)
+		if res.Code != da.StatusSuccess {
+			m.logger.Error("failed to get DA block hash", "daHeight", info.DAHeight, "error", res.Message)
+			return
+		}
+		if len(res.Hash) > 0 && bytes.Equal(info.DAHash, res.Hash) {
+			continue
+		}
+		if len(res.Hash) > 0 && len(info.DAHash) == 0 {
+			info.DAHash = res.Hash
+			if err := m.store.SaveDAInfo(h, info); err != nil {
+				m.logger.Error("failed to save DA info", "height", h, "error", err)
+			}
+			continue
+		}
+		m.handleDAReorg(ctx, h, info, res.Hash)
+	}
+}
+
+// handleDAReorg handles replacement of DA block containing block at given height. All blocks included in the replaced
+// DA block are affected - blocks below given height (possibly outside of reorg window) are handled first.
+func (m *Manager) handleDAReorg(ctx context.Context, height uint64, info *types.DAInfo, newHash []byte) {
+	heights, err := m.store.LoadHeightsByDAHeight(info.DAHeight)
+	if err != nil {
+		m.logger.Error("failed to load blocks included in DA block", "daHeight", info.DAHeight, "error", err)
+	}
+	for _, h := range heights {
+		// blocks above are checked by checkReorgs
+		if h >= height {
+			break
+		}
+		other, err := m.store.LoadDAInfo(h)
+		if err != nil || other.DAHeight != info.DAHeight || bytes.Equal(other.DAHash, newHash) {
+			continue
+		}
+		m.handleReorg(ctx, h, other, newHash)
+	}
+	m.handleReorg(ctx, height, info, newHash)
+}
+
+// handleReorg re-verifies inclusion of block at given height, after DA block containing it was replaced.
+//
+// If block is still available in DA layer, its DAInfo is updated. Otherwise, DAInfo is removed and the block is
+// re-submitted (if node is the sequencer). Firm height is rolled back below the block, unless the block is still
+// available and blocks are finalized once included.
+func (m *Manager) handleReorg(ctx context.Context, height uint64, info *types.DAInfo, newHash []byte) {
+	header, err := m.store.LoadHeader(height)
+	if err != nil {
+		m.logger.Error("failed to load block header", "height", height, "error", err)
+		return
+	}
+	check := m.dalc.CheckBlockAvailability(header)
+	if check.Code != da.StatusSuccess {
+		// reorg will be detected (and handled) again in next round
+		m.logger.Error("failed to check block availability", "height", height, "error", check.Message)
+		return
+	}
+	orphaned := !check.DataAvailable
+	m.logger.Error("DA layer reorg detected", "height", height, "daHeight", info.DAHeight,
+		"daHash", fmt.Sprintf("%X", info.DAHash), "newDAHash", fmt.Sprintf("%X", newHash), "orphaned", orphaned)
+	m.metrics.DAReorgs.Add(1)
+	m.publishDAReorgEvent(height, info, newHash, orphaned)
+
+	if orphaned || m.conf.DAConfirmDepth > 0 {
+		m.rollbackFirmHeight(height)
+	}
+
+	if !orphaned {
+		info.DAHash = newHash
+		if check.DAHeight > 0 && check.DAHeight != info.DAHeight {
+			// block was included in another DA block, its hash will be recorded in next round
+			info.DAHeight = check.DAHeight
+			info.DAHash = nil
+		}
+		if err := m.store.SaveDAInfo(height, info); err != nil {
+			m.logger.Error("failed to save DA info", "height", height, "error", err)
+		}
+		return
+	}
+
+	if err := m.store.DeleteDAInfo(height); err != nil {
+		m.logger.Error("failed to delete DA info", "height", height, "error", err)
+		return
+	}
+	m.recordOrphaned(height)
+	m.lastStateMtx.RLock()
+	sequencer := m.isSequencer()
+	m.lastStateMtx.RUnlock()
+	if sequencer {
+		m.resubmitBlocks(ctx, height, height)
+	}
+}
+
+// publishDAReorgEvent publishes EventDAReorg for block at given height.
+func (m *Manager) publishDAReorgEvent(height uint64, info *types.DAInfo, newHash []byte, orphaned bool) {
+	if m.eventBus == nil {
+		return
+	}
+	err := m.eventBus.Publish(types.EventDAReorg, types.EventDataDAReorg{
+		Height:    height,
+		DAHeight:  info.DAHeight,
+		DAHash:    info.DAHash,
+		NewDAHash: newHash,
+		Orphaned:  orphaned,
+	})
+	if err != nil {
+		m.logger.Error("failed to publish DA reorg event", "height", height, "error", err)
+	}
+}
@@ -0,0 +1,157 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/config"
+	mockda "github.com/celestiaorg/optimint/da/mock"
+	"github.com/celestiaorg/optimint/state"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestCheckReorgs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	logger := log.TestingLogger()
+	dalc := &mockda.MockDataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(nil, store.NewDefaultInMemoryKVStore(), logger))
+
+	eventBus := tmtypes.NewEventBus()
+	require.NoError(eventBus.Start())
+	defer func() { _ = eventBus.Stop() }()
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryDAReorg, 10)
+	require.NoError(err)
+
+	// blocks 1-4 are included in DA blocks 1-4
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	for h := uint64(1); h <= 4; h++ {
+		block := &types.Block{Header: types.Header{Height: h}}
+		require.NoError(s.SaveBlock(block, &types.Commit{Height: h, HeaderHash: block.Header.Hash()}))
+		res := dalc.SubmitBlock(block)
+		require.NoError(s.SaveDAInfo(h, &types.DAInfo{DAHeight: res.DAHeight}))
+	}
+	s.SetHeight(4)
+
+	m := &Manager{
+		conf:        config.BlockManagerConfig{DAReorgWindow: 3},
+		genesis:     &tmtypes.GenesisDoc{ChainID: "test"},
+		lastState:   state.State{Validators: tmtypes.NewValidatorSet(nil)},
+		store:       s,
+		dalc:        dalc,
+		hashReader:  dalc,
+		HeaderOutCh: make(chan *types.SignedHeader, 10),
+		firmHeight:  4,
+		eventBus:    eventBus,
+		metrics:     NopMetrics(),
+		watchdog:    newWatchdog(4, time.Now()),
+		clock:       realClock{},
+		logger:      logger,
+	}
+
+	// hashes of DA blocks are recorded for blocks in reorg window only
+	m.checkReorgs(context.Background())
+	info, err := s.LoadDAInfo(1)
+	require.NoError(err)
+	assert.Empty(info.DAHash)
+	for h := uint64(2); h <= 4; h++ {
+		info, err := s.LoadDAInfo(h)
+		require.NoError(err)
+		assert.Equal(dalc.BlockHash(h).Hash, info.DAHash)
+	}
+	assert.Empty(sub.Out())
+
+	// blocks 3 and 4 are orphaned by reorg and re-submitted
+	require.NoError(dalc.Reorg(3))
+	m.checkReorgs(context.Background())
+	for h := uint64(3); h <= 4; h++ {
+		data := (<-sub.Out()).Data().(types.EventDataDAReorg)
+		assert.Equal(h, data.Height)
+		assert.Equal(h, data.DAHeight)
+		assert.NotEqual(data.DAHash, data.NewDAHash)
+		assert.True(data.Orphaned)
+
+		info, err := s.LoadDAInfo(h)
+		require.NoError(err)
+		assert.Equal(h+2, info.DAHeight)
+		assert.Empty(info.DAHash)
+	}
+	assert.Len(m.HeaderOutCh, 2)
+	assert.EqualValues(4, m.FirmHeight())
+
+	m.checkReorgs(context.Background())
+	assert.Empty(sub.Out())
+	info, err = s.LoadDAInfo(3)
+	require.NoError(err)
+	assert.Equal(dalc.BlockHash(5).Hash, info.DAHash)
+}
+
+func TestCheckReorgsBelowWindow(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	logger := log.TestingLogger()
+	dalc := &mockda.MockDataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(nil, store.NewDefaultInMemoryKVStore(), logger))
+
+	eventBus := tmtypes.NewEventBus()
+	require.NoError(eventBus.Start())
+	defer func() { _ = eventBus.Stop() }()
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryDAReorg, 10)
+	require.NoError(err)
+
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	for h := uint64(1); h <= 2; h++ {
+		block := &types.Block{Header: types.Header{Height: h}}
+		require.NoError(s.SaveBlock(block, &types.Commit{Height: h, HeaderHash: block.Header.Hash()}))
+		dalc.SubmitBlock(block)
+	}
+	// both blocks are recorded as included in DA block 2, but only block 2 is in reorg window
+	require.NoError(s.SaveDAInfo(1, &types.DAInfo{DAHeight: 2}))
+	require.NoError(s.SaveDAInfo(2, &types.DAInfo{DAHeight: 2}))
+	s.SetHeight(2)
+
+	m := &Manager{
+		conf:        config.BlockManagerConfig{DAReorgWindow: 1},
+		genesis:     &tmtypes.GenesisDoc{ChainID: "test"},
+		lastState:   state.State{Validators: tmtypes.NewValidatorSet(nil)},
+		store:       s,
+		dalc:        dalc,
+		hashReader:  dalc,
+		HeaderOutCh: make(chan *types.SignedHeader, 10),
+		firmHeight:  2,
+		eventBus:    eventBus,
+		metrics:     NopMetrics(),
+		watchdog:    newWatchdog(2, time.Now()),
+		clock:       realClock{},
+		logger:      logger,
+	}
+	m.checkReorgs(context.Background())
+	assert.Empty(sub.Out())
+
+	// reorg of DA block 2 affects block 1 as well
+	require.NoError(dalc.Reorg(2))
+	m.checkReorgs(context.Background())
+	data := (<-sub.Out()).Data().(types.EventDataDAReorg)
+	assert.EqualValues(1, data.Height)
+	assert.False(data.Orphaned)
+	data = (<-sub.Out()).Data().(types.EventDataDAReorg)
+	assert.EqualValues(2, data.Height)
+	assert.True(data.Orphaned)
+
+	// block 1 is still available, block 2 is removed from DA height index
+	info, err := s.LoadDAInfo(1)
+	require.NoError(err)
+	assert.Equal([]byte(data.NewDAHash), info.DAHash)
+	heights, err := s.LoadHeightsByDAHeight(2)
+	require.NoError(err)
+	assert.Equal([]uint64{1}, heights)
+}
@@ -0,0 +1,223 @@
+package block
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// StatsWindows are the windows of rolling chain statistics.
+var StatsWindows = []time.Duration{time.Minute, 5 * time.Minute, time.Hour}
+
+const (
+	// statsBuckets is the number of one second buckets, covering the longest window.
+	statsBuckets = 3600
+	// maxPendingDA limits number of applied blocks waiting for DA inclusion, tracked to measure DA latency.
+	maxPendingDA = 1000
+)
+
+var statsTotalsKey = []byte("totals")
+
+// ChainTotals are cumulative chain statistics.
+type ChainTotals struct {
+	// Since is the height of the first block counted in totals. It's greater than initial height if statistics were
+	// enabled on existing node.
+	Since uint64
+	// Height is the height of the last block counted in totals.
+	Height uint64
+	Blocks uint64
+	Txs    uint64
+	// Bytes is the total size of serialized blocks.
+	Bytes uint64
+}
+
+// StatsWindow contains chain statistics over a period of time, ending at the time of the latest block.
+type StatsWindow struct {
+	Window time.Duration
+	Blocks uint64
+	Txs    uint64
+	Bytes  uint64
+	// TPS is the average number of transactions per second.
+	TPS float64
+	// AvgBlockSize is the average size of serialized block, in bytes.
+	AvgBlockSize float64
+	// AvgDALatency is the average time from block production to DA inclusion. It's measured only by aggregator.
+	AvgDALatency time.Duration
+}
+
+// ChainStats computes chain statistics incrementally, from applied blocks. Rolling statistics are aggregated in one
+// second buckets by block time, so they don't depend on the time when blocks are synced.
+type ChainStats struct {
+	mtx    sync.Mutex
+	kv     store.KVStore
+	logger log.Logger
+	now    func() time.Time
+
+	totals ChainTotals
+	// latest is the time of the latest block, in seconds
+	latest  int64
+	buckets [statsBuckets]statsBucket
+	// applied contains blocks waiting for DA inclusion, by height
+	applied map[uint64]appliedBlock
+}
+
+type statsBucket struct {
+	sec        int64
+	blocks     uint64
+	txs        uint64
+	bytes      uint64
+	daIncluded uint64
+	daLatency  time.Duration
+}
+
+type appliedBlock struct {
+	sec  int64
+	time time.Time
+}
+
+// NewChainStats creates ChainStats. Totals are persisted in given KV store.
+func NewChainStats(kv store.KVStore, logger log.Logger) (*ChainStats, error) {
+	s := &ChainStats{
+		kv:      kv,
+		logger:  logger,
+		now:     time.Now,
+		applied: make(map[uint64]appliedBlock),
+	}
+	blob, err := kv.Get(statsTotalsKey)
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(blob, &s.totals); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// BlockApplied adds block to statistics. It's intended to be used as Hooks.OnBlockApplied.
+func (s *ChainStats) BlockApplied(block *types.Block) {
+	if err := s.add(block); err != nil {
+		s.logger.Error("failed to update chain statistics", "height", block.Header.Height, "error", err)
+	}
+}
+
+func (s *ChainStats) add(block *types.Block) error {
+	height := block.Header.Height
+	blob, err := block.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	size := uint64(len(blob))
+	sec := int64(block.Header.Time)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if height <= s.totals.Height {
+		// block was already counted
+		return nil
+	}
+	totals := s.totals
+	if totals.Since == 0 {
+		totals.Since = height
+	}
+	totals.Height = height
+	totals.Blocks++
+	totals.Txs += uint64(len(block.Data.Txs))
+	totals.Bytes += size
+	blob, err = json.Marshal(&totals)
+	if err != nil {
+		return err
+	}
+	if err := s.kv.Set(statsTotalsKey, blob); err != nil {
+		return err
+	}
+	s.totals = totals
+
+	if sec > s.latest {
+		s.latest = sec
+	}
+	if s.latest-sec >= statsBuckets {
+		// block is older than the longest window
+		return nil
+	}
+	b := s.bucket(sec)
+	b.blocks++
+	b.txs += uint64(len(block.Data.Txs))
+	b.bytes += size
+	s.applied[height] = appliedBlock{sec: sec, time: s.now()}
+	delete(s.applied, height-maxPendingDA)
+	return nil
+}
+
+// DAIncluded records DA latency of the block. It's intended to be used as Hooks.OnDAIncluded.
+func (s *ChainStats) DAIncluded(block *types.Block, _ uint64) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	applied, ok := s.applied[block.Header.Height]
+	if !ok {
+		return
+	}
+	delete(s.applied, block.Header.Height)
+	if s.latest-applied.sec >= statsBuckets {
+		return
+	}
+	b := s.bucket(applied.sec)
+	b.daIncluded++
+	b.daLatency += s.now().Sub(applied.time)
+}
+
+// Totals returns cumulative chain statistics.
+func (s *ChainStats) Totals() ChainTotals {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.totals
+}
+
+// Windows returns rolling chain statistics over StatsWindows.
+func (s *ChainStats) Windows() []StatsWindow {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	windows := make([]StatsWindow, len(StatsWindows))
+	for i, w := range StatsWindows {
+		var daIncluded uint64
+		var daLatency time.Duration
+		windows[i].Window = w
+		from := s.latest - int64(w/time.Second)
+		for j := range s.buckets {
+			b := &s.buckets[j]
+			if b.sec <= from || b.sec > s.latest {
+				continue
+			}
+			windows[i].Blocks += b.blocks
+			windows[i].Txs += b.txs
+			windows[i].Bytes += b.bytes
+			daIncluded += b.daIncluded
+			daLatency += b.daLatency
+		}
+		windows[i].TPS = float64(windows[i].Txs) / w.Seconds()
+		if windows[i].Blocks > 0 {
+			windows[i].AvgBlockSize = float64(windows[i].Bytes) / float64(windows[i].Blocks)
+		}
+		if daIncluded > 0 {
+			windows[i].AvgDALatency = daLatency / time.Duration(daIncluded)
+		}
+	}
+	return windows
+}
+
+// bucket returns bucket for given second, resetting it if it contains stale data.
+func (s *ChainStats) bucket(sec int64) *statsBucket {
+	b := &s.buckets[uint64(sec)%statsBuckets]
+	if b.sec != sec {
+		*b = statsBucket{sec: sec}
+	}
+	return b
+}
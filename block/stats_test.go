@@ -0,0 +1,78 @@
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/optimint/log/test"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestChainStats(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kv := store.NewDefaultInMemoryKVStore()
+	stats, err := NewChainStats(kv, &test.TestLogger{T: t})
+	require.NoError(err)
+	now := time.Unix(10000, 0)
+	stats.now = func() time.Time { return now }
+
+	newBlock := func(height uint64, sec int64, txs int) *types.Block {
+		b := &types.Block{Header: types.Header{Height: height, Time: uint64(sec)}}
+		for i := 0; i < txs; i++ {
+			b.Data.Txs = append(b.Data.Txs, types.Tx{byte(i)})
+		}
+		return b
+	}
+
+	// one block every 10 seconds, with 3 transactions, over 2 hours
+	var blocks []*types.Block
+	for h := uint64(1); h <= 720; h++ {
+		blocks = append(blocks, newBlock(h, 10000+int64(h)*10, 3))
+		stats.BlockApplied(blocks[h-1])
+	}
+	// blocks are counted once
+	stats.BlockApplied(blocks[719])
+
+	totals := stats.Totals()
+	assert.Equal(uint64(1), totals.Since)
+	assert.Equal(uint64(720), totals.Height)
+	assert.Equal(uint64(720), totals.Blocks)
+	assert.Equal(uint64(2160), totals.Txs)
+
+	windows := stats.Windows()
+	require.Len(windows, 3)
+	assert.Equal(time.Minute, windows[0].Window)
+	assert.Equal(uint64(6), windows[0].Blocks)
+	assert.Equal(uint64(18), windows[0].Txs)
+	assert.InDelta(0.3, windows[0].TPS, 1e-9)
+	assert.Equal(uint64(360), windows[2].Blocks)
+	assert.InDelta(0.3, windows[2].TPS, 1e-9)
+	assert.InDelta(float64(windows[2].Bytes)/360, windows[2].AvgBlockSize, 1e-9)
+	assert.Zero(windows[0].AvgDALatency)
+
+	// DA latency is measured from the time block is applied
+	for h := uint64(721); h <= 724; h++ {
+		blocks = append(blocks, newBlock(h, 10000+int64(h)*10, 0))
+		stats.BlockApplied(blocks[h-1])
+	}
+	now = now.Add(2 * time.Second)
+	stats.DAIncluded(blocks[720], 1)
+	stats.DAIncluded(blocks[721], 1)
+	now = now.Add(2 * time.Second)
+	stats.DAIncluded(blocks[722], 2)
+	windows = stats.Windows()
+	assert.Equal(uint64(6), windows[0].Blocks)
+	assert.Equal(uint64(6), windows[0].Txs)
+	assert.Equal(time.Duration(8*time.Second/3), windows[0].AvgDALatency)
+
+	// totals are persisted
+	restarted, err := NewChainStats(kv, &test.TestLogger{T: t})
+	require.NoError(err)
+	assert.Equal(stats.Totals(), restarted.Totals())
+}
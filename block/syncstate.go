@@ -0,0 +1,68 @@
+package block
+
+import (
+	"github.com/celestiaorg/optimint/types"
+)
+
+// initSyncState loads sync state persisted in store. If it's not available (e.g. store was created by older version
+// of the node), it's derived from the block store.
+func (m *Manager) initSyncState() {
+	if syncState, err := m.store.LoadSyncState(); err == nil {
+		m.syncState = *syncState
+		return
+	}
+	height := m.store.Height()
+	m.syncState = types.SyncState{
+		Height:          height,
+		IncludedHeight:  m.lastIncludedHeight(),
+		SubmittedHeight: height,
+	}
+	if info, err := m.store.LoadDAInfo(m.syncState.IncludedHeight); err == nil {
+		m.syncState.DAHeight = info.DAHeight
+	}
+}
+
+// getSyncState returns current sync state.
+func (m *Manager) getSyncState() types.SyncState {
+	m.syncStateMtx.Lock()
+	defer m.syncStateMtx.Unlock()
+	return m.syncState
+}
+
+// updateSyncState modifies sync state with given function and persists it.
+func (m *Manager) updateSyncState(update func(s *types.SyncState)) {
+	m.syncStateMtx.Lock()
+	defer m.syncStateMtx.Unlock()
+	update(&m.syncState)
+	if err := m.store.SaveSyncState(&m.syncState); err != nil {
+		m.logger.Error("failed to save sync state", "error", err)
+	}
+}
+
+// recordApplied records application of block at given height.
+func (m *Manager) recordApplied(height uint64) {
+	m.updateSyncState(func(s *types.SyncState) {
+		s.Height = height
+	})
+}
+
+// recordIncluded records inclusion of block at given height in DA block at given height.
+func (m *Manager) recordIncluded(height, daHeight uint64) {
+	m.updateSyncState(func(s *types.SyncState) {
+		if height > s.IncludedHeight {
+			s.IncludedHeight = height
+		}
+		if daHeight > s.DAHeight {
+			s.DAHeight = daHeight
+		}
+	})
+}
+
+// recordOrphaned records removal of block at given height from DA layer.
+func (m *Manager) recordOrphaned(height uint64) {
+	m.updateSyncState(func(s *types.SyncState) {
+		if s.IncludedHeight >= height {
+			s.IncludedHeight = height - 1
+		}
+	})
+}
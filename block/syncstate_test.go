@@ -0,0 +1,86 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	mockda "github.com/celestiaorg/optimint/da/mock"
+	"github.com/celestiaorg/optimint/state"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestInitSyncState(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	for h := uint64(1); h <= 3; h++ {
+		block := &types.Block{Header: types.Header{Height: h}}
+		require.NoError(s.SaveBlock(block, &types.Commit{Height: h, HeaderHash: block.Header.Hash()}))
+	}
+	require.NoError(s.SaveDAInfo(1, &types.DAInfo{DAHeight: 5}))
+	require.NoError(s.SaveDAInfo(2, &types.DAInfo{DAHeight: 6}))
+
+	// sync state is derived from block store, if it wasn't persisted
+	m := &Manager{store: s, logger: log.TestingLogger()}
+	m.initSyncState()
+	assert.Equal(types.SyncState{DAHeight: 6, Height: 3, IncludedHeight: 2, SubmittedHeight: 3}, m.getSyncState())
+
+	m.recordApplied(4)
+	m.recordIncluded(3, 7)
+	m.recordOrphaned(3)
+
+	// persisted sync state is used as is
+	m = &Manager{store: s, logger: log.TestingLogger()}
+	m.initSyncState()
+	assert.Equal(types.SyncState{DAHeight: 7, Height: 4, IncludedHeight: 2, SubmittedHeight: 3}, m.getSyncState())
+}
+
+func TestResubmitPending(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	logger := log.TestingLogger()
+	dalc := &mockda.MockDataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(nil, store.NewDefaultInMemoryKVStore(), logger))
+
+	// block 1 was submitted before restart, blocks 2 and 3 are pending
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	for h := uint64(1); h <= 3; h++ {
+		block := &types.Block{Header: types.Header{Height: h}}
+		require.NoError(s.SaveBlock(block, &types.Commit{Height: h, HeaderHash: block.Header.Hash()}))
+	}
+	require.NoError(s.SaveDAInfo(1, &types.DAInfo{DAHeight: 1}))
+	require.NoError(s.SaveSyncState(&types.SyncState{DAHeight: 1, Height: 3, IncludedHeight: 1, SubmittedHeight: 1}))
+
+	m := &Manager{
+		lastState:   state.State{Validators: tmtypes.NewValidatorSet(nil)},
+		store:       s,
+		dalc:        dalc,
+		HeaderOutCh: make(chan *types.SignedHeader, 2),
+		metrics:     NopMetrics(),
+		clock:       NewRealClock(),
+		logger:      logger,
+	}
+	m.initSyncState()
+	m.watchdog = newWatchdog(m.syncState.SubmittedHeight, time.Now())
+
+	m.resubmitPending(context.Background())
+	for h := uint64(2); h <= 3; h++ {
+		_, err := s.LoadDAInfo(h)
+		assert.NoError(err, "block %d not submitted", h)
+		assert.Equal(h, (<-m.HeaderOutCh).Header.Height)
+	}
+
+	syncState, err := s.LoadSyncState()
+	require.NoError(err)
+	assert.EqualValues(3, syncState.IncludedHeight)
+	assert.EqualValues(3, syncState.SubmittedHeight)
+}
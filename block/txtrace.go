@@ -0,0 +1,151 @@
+package block
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/optimint/types"
+)
+
+// DefaultTxTraceSize is the default number of transactions tracked by TxTracer.
+const DefaultTxTraceSize = 10000
+
+// TxTrace describes the lifecycle of a single transaction.
+// Zero value of timestamp means that given stage was not reached (yet).
+type TxTrace struct {
+	// Accepted is the time of admission to the mempool.
+	Accepted time.Time
+	// Included is the time when block containing transaction was applied.
+	Included time.Time
+	// Finalized is the time when block containing transaction was available in DA layer.
+	Finalized time.Time
+	// Height of the block containing transaction.
+	Height uint64
+}
+
+// TxTracer keeps track of lifecycle timestamps of the most recent transactions,
+// and reports latencies to metrics.
+//
+// All methods are safe to call on nil TxTracer.
+type TxTracer struct {
+	mtx     sync.Mutex
+	size    int
+	traces  map[[32]byte]*list.Element
+	order   *list.List
+	metrics *Metrics
+	now     func() time.Time
+}
+
+type traceEntry struct {
+	key   [32]byte
+	trace TxTrace
+}
+
+// NewTxTracer creates new TxTracer, tracking up to size transactions.
+func NewTxTracer(size int, metrics *Metrics) *TxTracer {
+	if size <= 0 {
+		size = DefaultTxTraceSize
+	}
+	if metrics == nil {
+		metrics = NopMetrics()
+	}
+	return &TxTracer{
+		size:    size,
+		traces:  make(map[[32]byte]*list.Element, size),
+		order:   list.New(),
+		metrics: metrics,
+		now:     time.Now,
+	}
+}
+
+// Accepted records admission of transaction to the mempool.
+func (t *TxTracer) Accepted(tx []byte) {
+	if t == nil {
+		return
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.get(sha256.Sum256(tx)).Accepted = t.now()
+}
+
+// Included records inclusion of transactions in block at given height.
+func (t *TxTracer) Included(txs types.Txs, height uint64) {
+	if t == nil {
+		return
+	}
+	// hash outside of the critical section - blocks can contain thousands of transactions
+	hashes := txs.Hashes()
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	now := t.now()
+	for _, hash := range hashes {
+		trace := t.get(hash)
+		trace.Included = now
+		trace.Height = height
+		if !trace.Accepted.IsZero() {
+			t.metrics.TxInclusionLatency.Observe(now.Sub(trace.Accepted).Seconds())
+		}
+	}
+}
+
+// Finalized records availability of transactions in DA layer.
+func (t *TxTracer) Finalized(txs types.Txs, height uint64) {
+	if t == nil {
+		return
+	}
+	// hash outside of the critical section - blocks can contain thousands of transactions
+	hashes := txs.Hashes()
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	now := t.now()
+	for _, hash := range hashes {
+		trace := t.get(hash)
+		trace.Finalized = now
+		trace.Height = height
+		if !trace.Accepted.IsZero() {
+			t.metrics.TxFinalizationLatency.Observe(now.Sub(trace.Accepted).Seconds())
+		}
+	}
+}
+
+// Get returns trace of transaction with given hash.
+func (t *TxTracer) Get(hash []byte) (TxTrace, bool) {
+	if t == nil || len(hash) != sha256.Size {
+		return TxTrace{}, false
+	}
+	var key [32]byte
+	copy(key[:], hash)
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	e, ok := t.traces[key]
+	if !ok {
+		return TxTrace{}, false
+	}
+	return e.Value.(*traceEntry).trace, true
+}
+
+// get returns trace for given key, creating it if necessary.
+// Oldest trace is evicted if tracer is full.
+// Caller must hold the lock.
+func (t *TxTracer) get(key [32]byte) *TxTrace {
+	if e, ok := t.traces[key]; ok {
+		return &e.Value.(*traceEntry).trace
+	}
+	if t.order.Len() >= t.size {
+		oldest := t.order.Front()
+		delete(t.traces, oldest.Value.(*traceEntry).key)
+		t.order.Remove(oldest)
+	}
+	entry := &traceEntry{key: key}
+	t.traces[key] = t.order.PushBack(entry)
+	return &entry.trace
+}
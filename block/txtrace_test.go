@@ -0,0 +1,54 @@
+package block
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestTxTracer(t *testing.T) {
+	assert := assert.New(t)
+
+	tracer := NewTxTracer(2, nil)
+	now := time.Unix(1000, 0)
+	tracer.now = func() time.Time { return now }
+
+	tx1 := []byte("tx1")
+	tx2 := []byte("tx2")
+	tx3 := []byte("tx3")
+	hash1 := sha256.Sum256(tx1)
+	hash3 := sha256.Sum256(tx3)
+
+	tracer.Accepted(tx1)
+	now = now.Add(time.Second)
+	tracer.Included(types.Txs{tx1, tx2}, 5)
+	now = now.Add(time.Second)
+	tracer.Finalized(types.Txs{tx1, tx2}, 5)
+
+	trace, ok := tracer.Get(hash1[:])
+	assert.True(ok)
+	assert.Equal(time.Unix(1000, 0), trace.Accepted)
+	assert.Equal(time.Unix(1001, 0), trace.Included)
+	assert.Equal(time.Unix(1002, 0), trace.Finalized)
+	assert.EqualValues(5, trace.Height)
+
+	// tx1 is the oldest entry - it should be evicted
+	tracer.Accepted(tx3)
+	_, ok = tracer.Get(hash1[:])
+	assert.False(ok)
+	trace, ok = tracer.Get(hash3[:])
+	assert.True(ok)
+	assert.True(trace.Included.IsZero())
+
+	_, ok = tracer.Get([]byte("invalid hash"))
+	assert.False(ok)
+
+	var nilTracer *TxTracer
+	nilTracer.Accepted(tx1)
+	_, ok = nilTracer.Get(hash1[:])
+	assert.False(ok)
+}
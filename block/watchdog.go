@@ -0,0 +1,196 @@
+package block
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/optimint/types"
+)
+
+// watchdog keeps track of block production and submission progress.
+type watchdog struct {
+	mtx sync.Mutex
+	// lastProduced is the time of last block production (or skipped production, if node is not the sequencer)
+	lastProduced time.Time
+	// lastSubmitted is the time of last successful block submission to DA layer
+	lastSubmitted time.Time
+	// submittedHeight is the height of last block submitted to DA layer, all blocks below are submitted as well
+	submittedHeight uint64
+	lastErr         error
+	stalled         bool
+	// restart cancels currently running aggregation loop
+	restart context.CancelFunc
+	// submitMtx serializes block submissions of aggregation loop and watchdog
+	submitMtx sync.Mutex
+}
+
+func newWatchdog(height uint64, now time.Time) *watchdog {
+	return &watchdog{
+		lastProduced:    now,
+		lastSubmitted:   now,
+		submittedHeight: height,
+	}
+}
+
+// Stalled returns true if watchdog detected that block production or submission is stalled.
+func (m *Manager) Stalled() bool {
+	m.watchdog.mtx.Lock()
+	defer m.watchdog.mtx.Unlock()
+	return m.watchdog.stalled
+}
+
+// WatchdogLoop checks progress of block production and submission every block time.
+// If no block was produced or submitted within WatchdogMultiplier block times, diagnostics are logged,
+// pending blocks are re-submitted to DA layer and aggregation loop is restarted.
+func (m *Manager) WatchdogLoop(ctx context.Context) {
+	timeout := m.conf.BlockTime * time.Duration(m.conf.WatchdogMultiplier)
+	ticker := m.clock.NewTicker(m.conf.BlockTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			m.checkProgress(ctx, timeout)
+		}
+	}
+}
+
+func (m *Manager) checkProgress(ctx context.Context, timeout time.Duration) {
+	height := m.store.Height()
+
+	w := m.watchdog
+	w.mtx.Lock()
+	now := m.clock.Now()
+	sinceProduced := now.Sub(w.lastProduced)
+	sinceSubmitted := now.Sub(w.lastSubmitted)
+	submittedHeight := w.submittedHeight
+	lastErr := w.lastErr
+	stalled := sinceProduced > timeout || (submittedHeight < height && sinceSubmitted > timeout)
+	wasStalled := w.stalled
+	w.stalled = stalled
+	w.mtx.Unlock()
+
+	if !stalled {
+		if wasStalled {
+			m.logger.Info("block production recovered", "height", height)
+			m.metrics.Stalled.Set(0)
+		}
+		return
+	}
+	m.metrics.Stalled.Set(1)
+
+	keyvals := []interface{}{
+		"height", height,
+		"submittedHeight", submittedHeight,
+		"sinceLastBlock", sinceProduced,
+		"sinceLastSubmission", sinceSubmitted,
+		"lastError", lastErr,
+		"daStatus", m.daStatus(submittedHeight),
+	}
+	if m.mempool != nil {
+		keyvals = append(keyvals, "mempoolSize", m.mempool.Size(), "mempoolBytes", m.mempool.TxsBytes())
+	}
+	if m.daAccount != nil {
+		keyvals = append(keyvals, "daAccountBalance", m.daAccount.Balance())
+	}
+	m.logger.Error("block production stalled", keyvals...)
+
+	m.resubmitBlocks(ctx, submittedHeight+1, height)
+	m.restartAggregation()
+}
+
+// daStatus checks connectivity with DA layer, by checking availability of last submitted block.
+func (m *Manager) daStatus(height uint64) string {
+	if height == 0 {
+		return "unknown"
+	}
+	header, err := m.store.LoadHeader(height)
+	if err != nil {
+		return "unknown"
+	}
+	res := m.dalc.CheckBlockAvailability(header)
+	return fmt.Sprintf("code=%d available=%t message=%q", res.Code, res.DataAvailable, res.Message)
+}
+
+// resubmitBlocks submits blocks from given range, that are not yet included in DA layer.
+func (m *Manager) resubmitBlocks(ctx context.Context, from, to uint64) {
+	for height := from; height <= to; height++ {
+		if _, err := m.store.LoadDAInfo(height); err == nil {
+			m.recordSubmitted(height)
+			continue
+		}
+		block, err := m.store.LoadBlock(height)
+		if err != nil {
+			m.logger.Error("failed to load block for re-submission", "height", height, "error", err)
+			return
+		}
+		commit, err := m.store.LoadCommit(height)
+		if err != nil {
+			m.logger.Error("failed to load commit for re-submission", "height", height, "error", err)
+			return
+		}
+		m.logger.Info("re-submitting block to DA layer", "height", height)
+		if err := m.broadcastBlock(ctx, block, commit); err != nil {
+			m.logger.Error("failed to re-submit block", "height", height, "error", err)
+			return
+		}
+	}
+}
+
+// resubmitPending submits blocks produced, but not submitted to DA layer before restart of the node.
+func (m *Manager) resubmitPending(ctx context.Context) {
+	m.lastStateMtx.RLock()
+	sequencer := m.isSequencer()
+	m.lastStateMtx.RUnlock()
+	if !sequencer {
+		return
+	}
+	if submitted, height := m.getSyncState().SubmittedHeight, m.store.Height(); submitted < height {
+		m.logger.Info("submitting pending blocks to DA layer", "from", submitted+1, "to", height)
+		m.resubmitBlocks(ctx, submitted+1, height)
+	}
+}
+
+// restartAggregation cancels currently running aggregation loop, so it's started again.
+func (m *Manager) restartAggregation() {
+	m.watchdog.mtx.Lock()
+	defer m.watchdog.mtx.Unlock()
+	if m.watchdog.restart != nil {
+		m.logger.Info("restarting aggregation loop")
+		m.watchdog.restart()
+		m.watchdog.restart = nil
+	}
+}
+
+func (m *Manager) setAggregationCancel(cancel context.CancelFunc) {
+	m.watchdog.mtx.Lock()
+	defer m.watchdog.mtx.Unlock()
+	m.watchdog.restart = cancel
+}
+
+func (m *Manager) recordProduced() {
+	m.watchdog.mtx.Lock()
+	defer m.watchdog.mtx.Unlock()
+	m.watchdog.lastProduced = m.clock.Now()
+}
+
+func (m *Manager) recordSubmitted(height uint64) {
+	m.watchdog.mtx.Lock()
+	defer m.watchdog.mtx.Unlock()
+	m.watchdog.lastSubmitted = m.clock.Now()
+	if height == m.watchdog.submittedHeight+1 {
+		m.watchdog.submittedHeight = height
+		m.updateSyncState(func(s *types.SyncState) {
+			s.SubmittedHeight = height
+		})
+	}
+}
+
+func (m *Manager) recordError(err error) {
+	m.watchdog.mtx.Lock()
+	defer m.watchdog.mtx.Unlock()
+	m.watchdog.lastErr = err
+}
@@ -0,0 +1,79 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	mockda "github.com/celestiaorg/optimint/da/mock"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestWatchdog(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	logger := log.TestingLogger()
+	dalc := &mockda.MockDataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(nil, store.NewDefaultInMemoryKVStore(), logger))
+
+	// blocks 1 and 2 are stored, but were never submitted to DA layer
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	for h := uint64(1); h <= 2; h++ {
+		block := &types.Block{Header: types.Header{Height: h}}
+		require.NoError(s.SaveBlock(block, &types.Commit{Height: h, HeaderHash: block.Header.Hash()}))
+	}
+
+	m := &Manager{
+		store:       s,
+		dalc:        dalc,
+		HeaderOutCh: make(chan *types.SignedHeader, 2),
+		metrics:     NopMetrics(),
+		watchdog:    newWatchdog(0, time.Now()),
+		clock:       NewRealClock(),
+		logger:      logger,
+	}
+	restarted := false
+	m.setAggregationCancel(func() { restarted = true })
+
+	timeout := 100 * time.Millisecond
+	m.checkProgress(context.Background(), timeout)
+	assert.False(m.Stalled())
+
+	time.Sleep(timeout)
+	m.checkProgress(context.Background(), timeout)
+	assert.True(m.Stalled())
+	assert.True(restarted)
+	for h := uint64(1); h <= 2; h++ {
+		_, err := s.LoadDAInfo(h)
+		assert.NoError(err, "block %d not re-submitted", h)
+		assert.Equal(h, (<-m.HeaderOutCh).Header.Height)
+	}
+
+	// blocks are submitted, but none were produced recently
+	m.checkProgress(context.Background(), timeout)
+	assert.True(m.Stalled())
+
+	m.recordProduced()
+	m.checkProgress(context.Background(), timeout)
+	assert.False(m.Stalled())
+}
+
+func TestSetClockKeepsSubmittedHeight(t *testing.T) {
+	assert := assert.New(t)
+
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	s.SetHeight(5)
+	m := &Manager{store: s, watchdog: newWatchdog(3, time.Now())}
+
+	clock := NewManualClock(time.Unix(1000, 0))
+	m.SetClock(clock)
+	assert.Equal(uint64(3), m.watchdog.submittedHeight)
+	assert.Equal(clock.Now(), m.watchdog.lastProduced)
+	assert.Equal(clock.Now(), m.watchdog.lastSubmitted)
+}
@@ -0,0 +1,214 @@
+package bridge
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// EndBlockIndex is the TxIndex of events emitted in EndBlock.
+const EndBlockIndex = math.MaxUint32
+
+// ErrIndexOutOfRange is returned if proof of non-existent event is requested.
+var ErrIndexOutOfRange = errors.New("event index out of range")
+
+// Attribute is a key-value pair of event.
+type Attribute struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Event is an event selected by filters, committed to by Merkle root of all selected events in a block.
+type Event struct {
+	Height uint64 `json:"height"`
+	// TxIndex is the index of transaction that emitted the event, or EndBlockIndex.
+	TxIndex    uint32      `json:"tx_index"`
+	Type       string      `json:"type"`
+	Attributes []Attribute `json:"attributes"`
+}
+
+// BlockEvents contains events selected from a block and their Merkle root.
+type BlockEvents struct {
+	Height uint64  `json:"height"`
+	Root   []byte  `json:"root"`
+	Events []Event `json:"events"`
+}
+
+// EventProof proves that event is committed to by Merkle root of events selected from a block.
+type EventProof struct {
+	Root  []byte
+	Event Event
+	Proof *merkle.Proof
+}
+
+// Verify checks if proof is valid.
+func (p *EventProof) Verify() error {
+	return p.Proof.Verify(p.Root, p.Event.Bytes())
+}
+
+// Bytes returns encoding of the event used as Merkle tree leaf. Height and TxIndex are encoded as big-endian
+// integers, strings and byte slices are prefixed with uvarint length, and attributes are prefixed with their count.
+func (e *Event) Bytes() []byte {
+	buf := make([]byte, 12, 64)
+	binary.BigEndian.PutUint64(buf, e.Height)
+	binary.BigEndian.PutUint32(buf[8:], e.TxIndex)
+	buf = appendBytes(buf, []byte(e.Type))
+	buf = appendUvarint(buf, uint64(len(e.Attributes)))
+	for _, attr := range e.Attributes {
+		buf = appendBytes(buf, attr.Key)
+		buf = appendBytes(buf, attr.Value)
+	}
+	return buf
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var prefix [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(prefix[:], v)
+	return append(buf, prefix[:n]...)
+}
+
+func appendBytes(buf []byte, b []byte) []byte {
+	return append(appendUvarint(buf, uint64(len(b))), b...)
+}
+
+// Extractor selects events emitted by DeliverTx and EndBlock that match configured filters, and saves them together
+// with their Merkle root for every applied block. Proofs of events can be used by settlement bridges.
+type Extractor struct {
+	filters []Filter
+	kv      store.KVStore
+	store   store.Store
+	logger  log.Logger
+}
+
+// NewExtractor creates Extractor saving selected events in given KVStore. Block responses are loaded from the store.
+func NewExtractor(filters []Filter, kv store.KVStore, store store.Store, logger log.Logger) *Extractor {
+	return &Extractor{
+		filters: filters,
+		kv:      kv,
+		store:   store,
+		logger:  logger,
+	}
+}
+
+// BlockApplied extracts and saves events of applied block. It's intended to be used as block.Hooks.OnBlockApplied.
+func (e *Extractor) BlockApplied(block *types.Block) {
+	height := block.Header.Height
+	responses, err := e.store.LoadBlockResponses(height)
+	if err != nil {
+		e.logger.Error("failed to load block responses", "height", height, "error", err)
+		return
+	}
+	events := e.Extract(height, responses)
+	if err := e.save(height, events); err != nil {
+		e.logger.Error("failed to save bridge events", "height", height, "error", err)
+		return
+	}
+	if len(events) > 0 {
+		e.logger.Debug("extracted bridge events", "height", height, "count", len(events))
+	}
+}
+
+// Extract returns events matching any of the filters, in order of emission.
+func (e *Extractor) Extract(height uint64, responses *tmstate.ABCIResponses) []Event {
+	var events []Event
+	for i, tx := range responses.DeliverTxs {
+		if tx == nil || tx.Code != 0 {
+			// events of failed transactions are not emitted
+			continue
+		}
+		for _, ev := range tx.Events {
+			if e.matches(ev) {
+				events = append(events, newEvent(height, uint32(i), ev))
+			}
+		}
+	}
+	if responses.EndBlock != nil {
+		for _, ev := range responses.EndBlock.Events {
+			if e.matches(ev) {
+				events = append(events, newEvent(height, EndBlockIndex, ev))
+			}
+		}
+	}
+	return events
+}
+
+// Events returns events selected from block at given height and their Merkle root, or error if block was not
+// processed.
+func (e *Extractor) Events(height uint64) (*BlockEvents, error) {
+	blob, err := e.kv.Get(getEventsKey(height))
+	if err != nil {
+		return nil, err
+	}
+	var res BlockEvents
+	if err := json.Unmarshal(blob, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Proof returns Merkle proof of event with given index (in BlockEvents.Events) in block at given height.
+func (e *Extractor) Proof(height uint64, index int) (*EventProof, error) {
+	events, err := e.Events(height)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(events.Events) {
+		return nil, fmt.Errorf("%w: block %d has %d events", ErrIndexOutOfRange, height, len(events.Events))
+	}
+	_, proofs := merkle.ProofsFromByteSlices(leaves(events.Events))
+	return &EventProof{Root: events.Root, Event: events.Events[index], Proof: proofs[index]}, nil
+}
+
+func (e *Extractor) matches(event abci.Event) bool {
+	for _, f := range e.filters {
+		if f.Matches(event) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Extractor) save(height uint64, events []Event) error {
+	blob, err := json.Marshal(&BlockEvents{Height: height, Root: root(events), Events: events})
+	if err != nil {
+		return err
+	}
+	return e.kv.Set(getEventsKey(height), blob)
+}
+
+func newEvent(height uint64, txIndex uint32, event abci.Event) Event {
+	res := Event{Height: height, TxIndex: txIndex, Type: event.Type, Attributes: make([]Attribute, len(event.Attributes))}
+	for i, attr := range event.Attributes {
+		res.Attributes[i] = Attribute{Key: attr.Key, Value: attr.Value}
+	}
+	return res
+}
+
+func leaves(events []Event) [][]byte {
+	res := make([][]byte, len(events))
+	for i := range events {
+		res[i] = events[i].Bytes()
+	}
+	return res
+}
+
+// root returns Merkle root of events (hash of empty input, if there are no events).
+func root(events []Event) []byte {
+	return merkle.HashFromByteSlices(leaves(events))
+}
+
+func getEventsKey(height uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, height)
+	return key
+}
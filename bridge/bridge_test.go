@@ -0,0 +1,103 @@
+package bridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+
+	"github.com/celestiaorg/optimint/log/test"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestParseFilters(t *testing.T) {
+	cases := []struct {
+		name     string
+		filters  string
+		expected []Filter
+		err      bool
+	}{
+		{"empty", "", nil, false},
+		{"types", "burn, lock", []Filter{{Type: "burn"}, {Type: "lock"}}, false},
+		{"attribute", "lock.module=bridge", []Filter{{Type: "lock", Key: "module", Value: "bridge"}}, false},
+		{"typed event", "cosmos.bank.v1beta1.EventBurn.denom=uatom",
+			[]Filter{{Type: "cosmos.bank.v1beta1.EventBurn", Key: "denom", Value: "uatom"}}, false},
+		{"missing key", "lock=bridge", nil, true},
+		{"empty key", "lock.=bridge", nil, true},
+		{"missing type", ".module=bridge", nil, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			filters, err := ParseFilters(c.filters)
+			if c.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, filters)
+		})
+	}
+}
+
+func TestExtractor(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	filters, err := ParseFilters("burn,lock.module=bridge")
+	require.NoError(err)
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	extractor := NewExtractor(filters, store.NewDefaultInMemoryKVStore(), s, &test.TestLogger{T: t})
+
+	responses := &tmstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{
+			{Events: []abci.Event{newABCIEvent("burn", "amount", "10"), newABCIEvent("transfer", "amount", "5")}},
+			// events of failed transaction are ignored
+			{Code: 1, Events: []abci.Event{newABCIEvent("burn", "amount", "20")}},
+			{Events: []abci.Event{newABCIEvent("lock", "module", "bank"), newABCIEvent("lock", "module", "bridge")}},
+		},
+		BeginBlock: &abci.ResponseBeginBlock{},
+		EndBlock:   &abci.ResponseEndBlock{Events: []abci.Event{newABCIEvent("burn", "amount", "30")}},
+	}
+	require.NoError(s.SaveBlockResponses(1, responses))
+	require.NoError(s.SaveBlockResponses(2, &tmstate.ABCIResponses{BeginBlock: &abci.ResponseBeginBlock{}, EndBlock: &abci.ResponseEndBlock{}}))
+	extractor.BlockApplied(&types.Block{Header: types.Header{Height: 1}})
+	extractor.BlockApplied(&types.Block{Header: types.Header{Height: 2}})
+
+	events, err := extractor.Events(1)
+	require.NoError(err)
+	require.Len(events.Events, 3)
+	assert.Equal(Event{Height: 1, TxIndex: 0, Type: "burn", Attributes: []Attribute{{Key: []byte("amount"), Value: []byte("10")}}}, events.Events[0])
+	assert.Equal(uint32(2), events.Events[1].TxIndex)
+	assert.Equal([]byte("bridge"), events.Events[1].Attributes[0].Value)
+	assert.Equal(uint32(EndBlockIndex), events.Events[2].TxIndex)
+
+	for i := range events.Events {
+		proof, err := extractor.Proof(1, i)
+		require.NoError(err)
+		assert.Equal(events.Root, proof.Root)
+		assert.Equal(events.Events[i], proof.Event)
+		assert.NoError(proof.Verify())
+
+		// proof is not valid for modified event
+		proof.Event.Attributes[0].Value = []byte("1000")
+		assert.Error(proof.Verify())
+	}
+	_, err = extractor.Proof(1, 3)
+	assert.ErrorIs(err, ErrIndexOutOfRange)
+
+	// blocks without selected events are processed too
+	events, err = extractor.Events(2)
+	require.NoError(err)
+	assert.Empty(events.Events)
+	assert.NotEmpty(events.Root)
+
+	_, err = extractor.Events(3)
+	assert.ErrorIs(err, store.ErrKeyNotFound)
+}
+
+func newABCIEvent(eventType, key, value string) abci.Event {
+	return abci.Event{Type: eventType, Attributes: []abci.EventAttribute{{Key: []byte(key), Value: []byte(value)}}}
+}
@@ -0,0 +1,57 @@
+package bridge
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// Filter selects events of given type. If Key is set, event has to contain attribute Key with value Value.
+type Filter struct {
+	Type  string
+	Key   string
+	Value string
+}
+
+// ParseFilters parses comma separated list of filters. Every filter has form "type" or "type.key=value",
+// e.g. "burn,lock.module=bridge". Event type may contain dots - key follows the last dot before "=".
+func ParseFilters(filters string) ([]Filter, error) {
+	var res []Filter
+	for _, f := range strings.Split(filters, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		filter := Filter{Type: f}
+		if i := strings.Index(f, "="); i >= 0 {
+			j := strings.LastIndex(f[:i], ".")
+			if j < 0 || j+1 == i {
+				return nil, fmt.Errorf("invalid event filter %q: expected type.key=value", f)
+			}
+			filter = Filter{Type: f[:j], Key: f[j+1 : i], Value: f[i+1:]}
+		}
+		if filter.Type == "" {
+			return nil, fmt.Errorf("invalid event filter %q: missing event type", f)
+		}
+		res = append(res, filter)
+	}
+	return res, nil
+}
+
+// Matches returns true if event is selected by the filter.
+func (f Filter) Matches(event abci.Event) bool {
+	if event.Type != f.Type {
+		return false
+	}
+	if f.Key == "" {
+		return true
+	}
+	for _, attr := range event.Attributes {
+		if bytes.Equal(attr.Key, []byte(f.Key)) && bytes.Equal(attr.Value, []byte(f.Value)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// outBufferSize is the number of produced checkpoints waiting to be gossiped. Checkpoints are also stored, so a
+// checkpoint dropped because of full buffer is still available via RPC.
+const outBufferSize = 16
+
+// Service produces signed checkpoints of the chain state every interval blocks (on aggregator), and verifies
+// checkpoints received from the sequencer (on full nodes). Checkpoints are saved in the store.
+//
+// Checkpoint of block at given height is produced after the block is applied and included in DA layer.
+type Service struct {
+	chainID  string
+	interval uint64
+	store    store.Store
+	logger   log.Logger
+
+	// signer is set only on aggregator
+	signer crypto.PrivKey
+
+	mtx sync.Mutex
+	// pending contains checkpoints of applied blocks, by height, waiting for DA inclusion
+	pending map[uint64]*types.Checkpoint
+	// daHeights contains DA heights of blocks included in DA layer before they were applied, by block height
+	daHeights map[uint64]uint64
+
+	out chan *types.Checkpoint
+}
+
+// NewService creates new checkpointing Service, producing checkpoints every interval blocks.
+func NewService(chainID string, interval uint64, store store.Store, logger log.Logger) *Service {
+	return &Service{
+		chainID:   chainID,
+		interval:  interval,
+		store:     store,
+		logger:    logger,
+		pending:   make(map[uint64]*types.Checkpoint),
+		daHeights: make(map[uint64]uint64),
+		out:       make(chan *types.Checkpoint, outBufferSize),
+	}
+}
+
+// SetSigner sets the key used to sign checkpoints. Checkpoints are produced only if signer is set.
+func (s *Service) SetSigner(key crypto.PrivKey) {
+	s.signer = key
+}
+
+// Out returns channel of produced checkpoints, to be gossiped to other nodes.
+func (s *Service) Out() <-chan *types.Checkpoint {
+	return s.out
+}
+
+// BlockApplied records the state after block at checkpoint height is applied. It's intended to be used as
+// block.Hooks.OnBlockApplied.
+func (s *Service) BlockApplied(block *types.Block) {
+	height := block.Header.Height
+	if s.signer == nil || height%s.interval != 0 {
+		return
+	}
+	st, err := s.store.LoadState()
+	if err != nil {
+		s.logger.Error("failed to load state for checkpoint", "height", height, "error", err)
+		return
+	}
+	checkpoint := &types.Checkpoint{
+		ChainID:   s.chainID,
+		Height:    height,
+		BlockHash: block.Hash(),
+		AppHash:   st.AppHash,
+	}
+
+	s.mtx.Lock()
+	daHeight, included := s.daHeights[height]
+	if included {
+		delete(s.daHeights, height)
+	} else {
+		s.pending[height] = checkpoint
+	}
+	s.mtx.Unlock()
+
+	if included {
+		checkpoint.DAHeight = daHeight
+		s.produce(checkpoint)
+	}
+}
+
+// DAIncluded produces checkpoint, if block at checkpoint height is already applied. It's intended to be used as
+// block.Hooks.OnDAIncluded.
+func (s *Service) DAIncluded(block *types.Block, daHeight uint64) {
+	height := block.Header.Height
+	if s.signer == nil || height%s.interval != 0 {
+		return
+	}
+
+	s.mtx.Lock()
+	checkpoint, applied := s.pending[height]
+	if applied {
+		delete(s.pending, height)
+	} else {
+		s.daHeights[height] = daHeight
+	}
+	s.mtx.Unlock()
+
+	if applied {
+		checkpoint.DAHeight = daHeight
+		s.produce(checkpoint)
+	}
+}
+
+func (s *Service) produce(checkpoint *types.Checkpoint) {
+	var err error
+	checkpoint.Signature, err = s.signer.Sign(checkpoint.SignBytes())
+	if err != nil {
+		s.logger.Error("failed to sign checkpoint", "height", checkpoint.Height, "error", err)
+		return
+	}
+	if err := s.store.SaveCheckpoint(checkpoint); err != nil {
+		s.logger.Error("failed to save checkpoint", "height", checkpoint.Height, "error", err)
+		return
+	}
+	s.logger.Info("produced checkpoint", "height", checkpoint.Height, "daHeight", checkpoint.DAHeight)
+	select {
+	case s.out <- checkpoint:
+	default:
+		s.logger.Error("checkpoint not gossiped: buffer is full", "height", checkpoint.Height)
+	}
+}
+
+// Receive verifies checkpoint received from other node and saves it. Checkpoint has to be signed by the current
+// sequencer.
+func (s *Service) Receive(checkpoint *types.Checkpoint) error {
+	if checkpoint.ChainID != s.chainID {
+		return fmt.Errorf("invalid chain ID %q", checkpoint.ChainID)
+	}
+	if checkpoint.Height == 0 || checkpoint.Height%s.interval != 0 {
+		return fmt.Errorf("invalid checkpoint height %d", checkpoint.Height)
+	}
+	st, err := s.store.LoadState()
+	if err != nil {
+		return err
+	}
+	if st.Validators.Size() == 0 {
+		return errors.New("sequencer is not known")
+	}
+	if err := checkpoint.VerifySignature(st.Validators.GetProposer().PubKey); err != nil {
+		return err
+	}
+	return s.store.SaveCheckpoint(checkpoint)
+}
+
+// Checkpoint returns checkpoint at given height.
+func (s *Service) Checkpoint(height uint64) (*types.Checkpoint, error) {
+	return s.store.LoadCheckpoint(height)
+}
+
+// Latest returns checkpoint with the greatest height.
+func (s *Service) Latest() (*types.Checkpoint, error) {
+	return s.store.LoadLatestCheckpoint()
+}
+
+// Marshal serializes checkpoint for gossiping.
+func Marshal(checkpoint *types.Checkpoint) ([]byte, error) {
+	return json.Marshal(checkpoint)
+}
+
+// Unmarshal deserializes gossiped checkpoint.
+func Unmarshal(data []byte) (*types.Checkpoint, error) {
+	var checkpoint types.Checkpoint
+	err := json.Unmarshal(data, &checkpoint)
+	return &checkpoint, err
+}
@@ -0,0 +1,102 @@
+package checkpoint
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/log/test"
+	"github.com/celestiaorg/optimint/state"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestCheckpoints(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(err)
+	raw, err := key.GetPublic().Raw()
+	require.NoError(err)
+	validators := tmtypes.NewValidatorSet([]*tmtypes.Validator{tmtypes.NewValidator(ed25519.PubKey(raw), 1)})
+
+	logger := &test.TestLogger{T: t}
+	aggStore := store.New(store.NewDefaultInMemoryKVStore())
+	aggregator := NewService("test", 5, aggStore, logger)
+	aggregator.SetSigner(key)
+	fullStore := store.New(store.NewDefaultInMemoryKVStore())
+	fullNode := NewService("test", 5, fullStore, logger)
+	require.NoError(fullStore.UpdateState(state.State{Validators: validators}))
+
+	blocks := make(map[uint64]*types.Block)
+	for h := uint64(1); h <= 10; h++ {
+		blocks[h] = &types.Block{Header: types.Header{Height: h}}
+		require.NoError(aggStore.UpdateState(state.State{LastBlockHeight: int64(h), AppHash: [32]byte{byte(h)}}))
+		aggregator.BlockApplied(blocks[h])
+	}
+	// DA inclusion may be reported before block is applied
+	aggregator.DAIncluded(&types.Block{Header: types.Header{Height: 15}}, 4)
+
+	// checkpoints are produced after blocks are included in DA layer
+	_, err = aggregator.Latest()
+	assert.ErrorIs(err, store.ErrKeyNotFound)
+	aggregator.DAIncluded(blocks[3], 1)
+	aggregator.DAIncluded(blocks[5], 2)
+	aggregator.DAIncluded(blocks[10], 3)
+
+	for _, h := range []uint64{5, 10} {
+		cp := <-aggregator.Out()
+		assert.Equal(h, cp.Height)
+		assert.Equal(blocks[h].Hash(), cp.BlockHash)
+		assert.Equal([32]byte{byte(h)}, cp.AppHash)
+		assert.NoError(cp.VerifySignature(ed25519.PubKey(raw)))
+
+		blob, err := Marshal(cp)
+		require.NoError(err)
+		received, err := Unmarshal(blob)
+		require.NoError(err)
+		assert.NoError(fullNode.Receive(received))
+	}
+	for h := uint64(11); h <= 15; h++ {
+		blocks[h] = &types.Block{Header: types.Header{Height: h}}
+		require.NoError(aggStore.UpdateState(state.State{LastBlockHeight: int64(h), AppHash: [32]byte{byte(h)}}))
+		aggregator.BlockApplied(blocks[h])
+	}
+	cp := <-aggregator.Out()
+	assert.Equal(uint64(15), cp.Height)
+	assert.Equal(uint64(4), cp.DAHeight)
+
+	latest, err := fullNode.Latest()
+	require.NoError(err)
+	assert.Equal(uint64(10), latest.Height)
+	assert.Equal(uint64(3), latest.DAHeight)
+	first, err := fullNode.Checkpoint(5)
+	require.NoError(err)
+	assert.Equal(uint64(2), first.DAHeight)
+
+	// checkpoints not signed by the sequencer are rejected
+	forged := *cp
+	forged.AppHash = [32]byte{0xff}
+	assert.Error(fullNode.Receive(&forged))
+	forged = *cp
+	forged.ChainID = "other"
+	assert.Error(fullNode.Receive(&forged))
+	forged = *cp
+	forged.Height = 16
+	assert.Error(fullNode.Receive(&forged))
+
+	otherKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(err)
+	forged = *cp
+	forged.Signature, err = otherKey.Sign(forged.SignBytes())
+	require.NoError(err)
+	assert.Error(fullNode.Receive(&forged))
+
+	assert.NoError(fullNode.Receive(cp))
+}
@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+// ABCIConfig limits the number of concurrent requests sent to the ABCI application, per connection
+// (value of 0 means no limit), and configures reconnection to the application.
+type ABCIConfig struct {
+	// QueryConcurrency limits requests on query connection.
+	// Queries over the limit are rejected immediately, so queries can't degrade block production.
+	// Info and Echo requests (used by the node itself) wait for a free slot.
+	QueryConcurrency int `mapstructure:"abci_query_concurrency"`
+	// MempoolConcurrency limits requests on mempool connection.
+	// Requests over the limit wait for a free slot.
+	MempoolConcurrency int `mapstructure:"abci_mempool_concurrency"`
+	// ConsensusConcurrency limits requests on consensus connection.
+	// Requests over the limit wait for a free slot.
+	ConsensusConcurrency int `mapstructure:"abci_consensus_concurrency"`
+
+	// ReconnectRetries is the number of attempts to reconnect to the application if connection is terminated.
+	// Node process is killed if application can't be reconnected (0 - reconnection disabled).
+	ReconnectRetries int `mapstructure:"abci_reconnect_retries"`
+	// ReconnectInterval is the time to wait before every reconnection attempt.
+	ReconnectInterval time.Duration `mapstructure:"abci_reconnect_interval"`
+}
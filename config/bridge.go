@@ -0,0 +1,8 @@
+package config
+
+// BridgeConfig configures extraction of events for settlement bridges.
+type BridgeConfig struct {
+	// EventFilters is a comma separated list of filters selecting events (emitted by DeliverTx and EndBlock), in form
+	// "type" or "type.key=value" (empty - disabled). Merkle roots of selected events and their proofs are served via RPC.
+	EventFilters string `mapstructure:"bridge_event_filters"`
+}
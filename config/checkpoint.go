@@ -0,0 +1,8 @@
+package config
+
+// CheckpointConfig configures periodic signed checkpoints of the chain state.
+type CheckpointConfig struct {
+	// Interval is the interval (in blocks) of checkpoints (0 - disabled). Aggregator produces and gossips
+	// checkpoints, full nodes verify and store received ones.
+	Interval uint64 `mapstructure:"checkpoint_interval"`
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/hex"
+	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -9,11 +10,103 @@ import (
 )
 
 const (
+	flagLogFormat   = "optimint.log_format"
 	flagAggregator  = "optimint.aggregator"
 	flagDALayer     = "optimint.da_layer"
 	flagDAConfig    = "optimint.da_config"
 	flagBlockTime   = "optimint.block_time"
 	flagNamespaceID = "optimint.namespace_id"
+
+	flagLight     = "optimint.light"
+	flagDASamples = "optimint.das_samples"
+
+	flagWatchdogMultiplier = "optimint.watchdog_multiplier"
+	flagDAEpoch            = "optimint.da_epoch"
+	flagHeaderVerification = "optimint.header_verification"
+	flagDevMode            = "optimint.dev_mode"
+	flagVerifyResults      = "optimint.verify_results"
+	flagDAConfirmInterval  = "optimint.da_confirm_interval"
+	flagDAConfirmDepth     = "optimint.da_confirm_depth"
+	flagDAReorgWindow      = "optimint.da_reorg_window"
+	flagDAMaxRetrieveSize  = "optimint.da_max_retrieve_size"
+
+	flagForcedInclusionWindow      = "optimint.forced_inclusion_window"
+	flagForcedInclusionNamespaceID = "optimint.forced_inclusion_namespace_id"
+
+	flagSystemLaneMaxBytes  = "optimint.system_lane_max_bytes"
+	flagForcedLaneMaxBytes  = "optimint.forced_lane_max_bytes"
+	flagMempoolLaneMaxBytes = "optimint.mempool_lane_max_bytes"
+	flagMempoolLaneMaxGas   = "optimint.mempool_lane_max_gas"
+	flagFCFSOrdering        = "optimint.fcfs_ordering"
+
+	flagMempoolSenderLanes = "optimint.mempool_sender_lanes"
+	flagMempoolFeePriority = "optimint.mempool_fee_priority"
+	flagMinGasPrice        = "optimint.min_gas_price"
+
+	flagDAAccountKeyFile       = "optimint.da_account_key_file"
+	flagDAAccountMinBalance    = "optimint.da_account_min_balance"
+	flagDAAccountCheckInterval = "optimint.da_account_check_interval"
+
+	flagTxIndexRetainBlocks       = "optimint.tx_index_retain_blocks"
+	flagTxIndexCompactionInterval = "optimint.tx_index_compaction_interval"
+
+	flagEventJournal             = "optimint.event_journal"
+	flagEventJournalRetainBlocks = "optimint.event_journal_retain_blocks"
+
+	flagStoreCacheSize = "optimint.store_cache_size"
+	flagArchive        = "optimint.archive"
+
+	flagReplicationListenAddress = "optimint.replication_listen_address"
+	flagReplicationSource        = "optimint.replication_source"
+
+	flagDiagnosticsListenAddress = "optimint.diagnostics_listen_address"
+	flagPanicPolicy              = "optimint.panic_policy"
+
+	flagSnapshotPublishInterval = "optimint.snapshot_publish_interval"
+	flagSnapshotSync            = "optimint.snapshot_sync"
+
+	flagBridgeEventFilters = "optimint.bridge_event_filters"
+
+	flagSettlementLayer  = "optimint.settlement_layer"
+	flagSettlementConfig = "optimint.settlement_config"
+	flagSettlementEpoch  = "optimint.settlement_epoch"
+
+	flagCheckpointInterval = "optimint.checkpoint_interval"
+
+	flagABCIQueryConcurrency     = "optimint.abci_query_concurrency"
+	flagABCIMempoolConcurrency   = "optimint.abci_mempool_concurrency"
+	flagABCIConsensusConcurrency = "optimint.abci_consensus_concurrency"
+	flagABCIReconnectRetries     = "optimint.abci_reconnect_retries"
+	flagABCIReconnectInterval    = "optimint.abci_reconnect_interval"
+
+	flagP2PMaxInboundConns   = "optimint.p2p_max_inbound_conns"
+	flagP2PMaxOutboundConns  = "optimint.p2p_max_outbound_conns"
+	flagP2PMaxStreamsPerPeer = "optimint.p2p_max_streams_per_peer"
+	flagP2PMaxMemory         = "optimint.p2p_max_memory"
+	flagP2PNATPortMap        = "optimint.p2p_nat_port_map"
+	flagP2PAutoNATService    = "optimint.p2p_autonat_service"
+	flagP2PRelayService      = "optimint.p2p_relay_service"
+	flagP2PRelays            = "optimint.p2p_relays"
+	flagP2PTransports        = "optimint.p2p_transports"
+	flagP2PWSListenAddress   = "optimint.p2p_ws_listen_address"
+	flagP2PTLSCertFile       = "optimint.p2p_tls_cert_file"
+	flagP2PTLSKeyFile        = "optimint.p2p_tls_key_file"
+	flagP2PMaxMessageSize    = "optimint.p2p_max_message_size"
+
+	flagP2PTxBatchSize        = "optimint.p2p_tx_batch_size"
+	flagP2PTxBatchMaxBytes    = "optimint.p2p_tx_batch_max_bytes"
+	flagP2PTxBatchTimeout     = "optimint.p2p_tx_batch_timeout"
+	flagP2PTxBatchCompression = "optimint.p2p_tx_batch_compression"
+	flagP2PBlockGossip        = "optimint.p2p_block_gossip"
+	flagP2PBlockPartSize      = "optimint.p2p_block_part_size"
+
+	flagRPCCompatVersion = "optimint.rpc_compat_version"
+	flagRPCEventEncoding = "optimint.rpc_event_encoding"
+	flagRPCEthNamespace  = "optimint.rpc_eth_namespace"
+	flagRPCReadOnly      = "optimint.rpc_read_only"
+	flagRPCSlowQuery     = "optimint.rpc_slow_query_threshold"
+	flagRPCMaxInvalidTxs = "optimint.rpc_max_invalid_txs_per_minute"
+	flagRPCMaxCommits    = "optimint.rpc_max_pending_commits"
 )
 
 // NodeConfig stores Optimint node configuration.
@@ -21,40 +114,293 @@ type NodeConfig struct {
 	// parameters below are translated from existing config
 	RootDir string
 	DBPath  string
-	P2P     P2PConfig
-	RPC     RPCConfig
+	// GenesisFile is a path to genesis file, used to read Optimint specific genesis extension.
+	GenesisFile string
+	P2P         P2PConfig
+	RPC         RPCConfig
+	// Instrumentation configures metrics reporting.
+	Instrumentation InstrumentationConfig
+	// Diagnostics configures the debugging endpoint (pprof, goroutine dumps and node state).
+	Diagnostics DiagnosticsConfig `mapstructure:",squash"`
+	// PanicPolicy decides what happens after a panic is recovered in long-running goroutine of the node
+	// (PanicPolicyRestart or PanicPolicyShutdown).
+	PanicPolicy string `mapstructure:"panic_policy"`
+	// LogLevel uses Tendermint's `log_level` syntax, e.g. "p2p:debug,*:info".
+	// Module names used by Optimint: proxy, events, p2p, da_client, txindex, mempool, BlockManager.
+	LogLevel string
+	// LogFormat selects format of node logs ("plain" or "json"). Node always writes to the logger it's given, so
+	// LogFormat is used by the command creating that logger (see log.NewLogger).
+	LogFormat string `mapstructure:"log_format"`
 	// parameters below are optimint specific and read from config
 	Aggregator         bool `mapstructure:"aggregator"`
 	BlockManagerConfig `mapstructure:",squash"`
-	DALayer            string `mapstructure:"da_layer"`
-	DAConfig           string `mapstructure:"da_config"`
+	DALayer            string          `mapstructure:"da_layer"`
+	DAConfig           string          `mapstructure:"da_config"`
+	DAAccount          DAAccountConfig `mapstructure:",squash"`
+	ABCI               ABCIConfig      `mapstructure:",squash"`
+	// Light enables light node mode: node follows headers gossiped via P2P and verifies availability of their data
+	// with data availability sampling, without downloading and executing blocks.
+	Light bool `mapstructure:"light"`
+	// DASamples is the number of shares sampled by light node for every header.
+	DASamples int `mapstructure:"das_samples"`
+	// DAMaxRetrieveSize limits the size of a single blob (block or forced inclusion transaction) retrieved from DA
+	// layer, so that malicious writers to the namespace can't trigger huge allocations (0 - no limit). Oversized
+	// forced inclusion transactions are skipped, so it has to be the same on all nodes.
+	DAMaxRetrieveSize uint64 `mapstructure:"da_max_retrieve_size"`
+	// MempoolSenderLanes enables ordering of mempool transactions by sender and nonce reported by the app.
+	MempoolSenderLanes bool `mapstructure:"mempool_sender_lanes"`
+	// MempoolFeePriority enables ordering of mempool transactions by gas price (fee reported by the app in CheckTx
+	// events, see mempool.WithFeePriority).
+	MempoolFeePriority bool `mapstructure:"mempool_fee_priority"`
+	// MinGasPrice is the minimal price of gas (e.g. "0.025stake") paid by transactions accepted to mempool.
+	// Fee is reported by the app in CheckTx events (see mempool.PostCheckMinGasPrice). Empty value disables the check.
+	MinGasPrice string        `mapstructure:"min_gas_price"`
+	TxIndex     TxIndexConfig `mapstructure:",squash"`
+	// EventJournal configures durable journal of block events for downstream consumers.
+	EventJournal EventJournalConfig `mapstructure:",squash"`
+	// StoreCacheSize is the number of recent blocks (with commits and block results) cached in memory (0 - disabled).
+	StoreCacheSize int `mapstructure:"store_cache_size"`
+	// Archive disables pruning of node data and advertises that ABCI queries at any historical height are served.
+	// Application has to be configured to keep historical state as well.
+	Archive bool `mapstructure:"archive"`
+	// Replication configures streaming of blocks to read replicas, or replication of other node.
+	Replication ReplicationConfig `mapstructure:",squash"`
+	// Snapshot configures publishing of application snapshots to DA layer and state sync from them.
+	Snapshot SnapshotConfig `mapstructure:",squash"`
+	// Bridge configures extraction of events for settlement bridges.
+	Bridge BridgeConfig `mapstructure:",squash"`
+	// Settlement configures posting of state commitments to settlement layer.
+	Settlement SettlementConfig `mapstructure:",squash"`
+	// Checkpoint configures periodic signed checkpoints of the chain state.
+	Checkpoint CheckpointConfig `mapstructure:",squash"`
 }
 
 // BlockManagerConfig consists of all parameters required by BlockManagerConfig
 type BlockManagerConfig struct {
+	// BlockTime is the interval of block production. If DAEpoch is set, it's the interval of DA layer height checks.
 	BlockTime   time.Duration `mapstructure:"block_time"`
 	NamespaceID [8]byte       `mapstructure:"namespace_id"`
+	// DAStartHeight is the first DA layer height that can contain blocks (read from genesis extension).
+	DAStartHeight uint64 `mapstructure:"da_start_height"`
+	// ForcedInclusionWindow is the number of blocks, after which transactions posted directly to DA layer
+	// have to be included in a block (0 - forced inclusion is disabled).
+	ForcedInclusionWindow uint64 `mapstructure:"forced_inclusion_window"`
+	// ForcedInclusionNamespaceID identifies DA layer namespace used to post forced inclusion transactions.
+	ForcedInclusionNamespaceID [8]byte `mapstructure:"forced_inclusion_namespace_id"`
+	// SystemLaneMaxBytes limits total size of system transactions (see state.TxInjector) in a block (0 - no limit).
+	// Blocks exceeding the limit are rejected, so it has to be the same on all nodes.
+	SystemLaneMaxBytes int64 `mapstructure:"system_lane_max_bytes"`
+	// ForcedLaneMaxBytes limits total size of forced inclusion transactions in a block (0 - no limit). Transactions
+	// exceeding the limit are deferred to next blocks, so it has to be the same on all nodes.
+	ForcedLaneMaxBytes int64 `mapstructure:"forced_lane_max_bytes"`
+	// MempoolLaneMaxBytes limits total size of mempool transactions in produced blocks (0 - no limit).
+	MempoolLaneMaxBytes int64 `mapstructure:"mempool_lane_max_bytes"`
+	// MempoolLaneMaxGas limits total gas wanted by mempool transactions in produced blocks (0 - no limit).
+	MempoolLaneMaxGas int64 `mapstructure:"mempool_lane_max_gas"`
+	// FCFSOrdering enables first-come-first-served ordering of mempool transactions: aggregator issues signed
+	// ordering receipts and full nodes verify that sequence numbers in blocks are increasing (omitted transactions are
+	// detected only by holders of their receipts). It has to be the same on all nodes.
+	FCFSOrdering bool `mapstructure:"fcfs_ordering"`
+	// WatchdogMultiplier is the number of block times without block production or submission, after which
+	// block production is considered stalled and recovery is attempted (0 - watchdog is disabled).
+	WatchdogMultiplier uint64 `mapstructure:"watchdog_multiplier"`
+	// DAEpoch is the number of DA layer blocks per rollup block. If set, exactly one block is produced for every
+	// DA epoch (counted from DAStartHeight), instead of every block time (0 - use block time).
+	DAEpoch uint64 `mapstructure:"da_epoch"`
+	// HeaderVerification is the mode of sequencer signature verification of synced headers
+	// (HeaderVerificationStrict or HeaderVerificationPermissive).
+	HeaderVerification string `mapstructure:"header_verification"`
+	// DevMode makes aggregator produce a block as soon as transactions are available in the mempool, for fast local
+	// development. BlockTime is used as the idle interval, after which a block is produced even without transactions.
+	DevMode bool `mapstructure:"dev_mode"`
+	// VerifyResults makes full node compare results of executing every block synced from DA layer (app hash and
+	// results hash) with the header of the next block, as soon as it's known. Syncing is halted on mismatch.
+	VerifyResults bool `mapstructure:"verify_results"`
+	// DAConfirmInterval is the interval of checking if blocks submitted to (or retrieved from) DA layer are
+	// available there; confirmation status is stored for every block (0 - confirmation is disabled).
+	DAConfirmInterval time.Duration `mapstructure:"da_confirm_interval"`
+	// DAConfirmDepth is the number of DA layer blocks that have to be built on top of DA block containing a block,
+	// before the block is considered final (firm), to protect against DA layer reorgs (0 - final once included).
+	DAConfirmDepth uint64 `mapstructure:"da_confirm_depth"`
+	// DAReorgWindow is the number of latest blocks checked every block time for DA layer reorgs, i.e. changed hash
+	// of DA block that included them (0 - reorg detection is disabled).
+	DAReorgWindow uint64 `mapstructure:"da_reorg_window"`
 }
 
 func (nc *NodeConfig) GetViperConfig(v *viper.Viper) error {
+	nc.LogFormat = v.GetString(flagLogFormat)
 	nc.Aggregator = v.GetBool(flagAggregator)
+	nc.Light = v.GetBool(flagLight)
+	nc.DASamples = v.GetInt(flagDASamples)
 	nc.DALayer = v.GetString(flagDALayer)
 	nc.DAConfig = v.GetString(flagDAConfig)
 	nc.BlockTime = v.GetDuration(flagBlockTime)
-	nsID := v.GetString(flagNamespaceID)
-	bytes, err := hex.DecodeString(nsID)
-	if err != nil {
+	nc.WatchdogMultiplier = v.GetUint64(flagWatchdogMultiplier)
+	nc.DAEpoch = v.GetUint64(flagDAEpoch)
+	nc.HeaderVerification = v.GetString(flagHeaderVerification)
+	nc.DevMode = v.GetBool(flagDevMode)
+	nc.VerifyResults = v.GetBool(flagVerifyResults)
+	nc.DAConfirmInterval = v.GetDuration(flagDAConfirmInterval)
+	nc.DAConfirmDepth = v.GetUint64(flagDAConfirmDepth)
+	nc.DAReorgWindow = v.GetUint64(flagDAReorgWindow)
+	nc.DAMaxRetrieveSize = v.GetUint64(flagDAMaxRetrieveSize)
+	nc.DAAccount.KeyFile = v.GetString(flagDAAccountKeyFile)
+	nc.DAAccount.MinBalance = v.GetUint64(flagDAAccountMinBalance)
+	nc.DAAccount.CheckInterval = v.GetDuration(flagDAAccountCheckInterval)
+	nc.MempoolSenderLanes = v.GetBool(flagMempoolSenderLanes)
+	nc.MempoolFeePriority = v.GetBool(flagMempoolFeePriority)
+	nc.MinGasPrice = v.GetString(flagMinGasPrice)
+	nc.TxIndex.RetainBlocks = v.GetUint64(flagTxIndexRetainBlocks)
+	nc.TxIndex.CompactionInterval = v.GetDuration(flagTxIndexCompactionInterval)
+	nc.EventJournal.Enabled = v.GetBool(flagEventJournal)
+	nc.EventJournal.RetainBlocks = v.GetUint64(flagEventJournalRetainBlocks)
+	nc.StoreCacheSize = v.GetInt(flagStoreCacheSize)
+	nc.Archive = v.GetBool(flagArchive)
+	nc.Replication.ListenAddress = v.GetString(flagReplicationListenAddress)
+	nc.Replication.Source = v.GetString(flagReplicationSource)
+	nc.Diagnostics.ListenAddress = v.GetString(flagDiagnosticsListenAddress)
+	nc.PanicPolicy = v.GetString(flagPanicPolicy)
+	nc.Snapshot.PublishInterval = v.GetUint64(flagSnapshotPublishInterval)
+	nc.Snapshot.Sync = v.GetBool(flagSnapshotSync)
+	nc.Bridge.EventFilters = v.GetString(flagBridgeEventFilters)
+	nc.Settlement.Layer = v.GetString(flagSettlementLayer)
+	nc.Settlement.Config = v.GetString(flagSettlementConfig)
+	nc.Settlement.Epoch = v.GetUint64(flagSettlementEpoch)
+	nc.Checkpoint.Interval = v.GetUint64(flagCheckpointInterval)
+	nc.ABCI.QueryConcurrency = v.GetInt(flagABCIQueryConcurrency)
+	nc.ABCI.MempoolConcurrency = v.GetInt(flagABCIMempoolConcurrency)
+	nc.ABCI.ConsensusConcurrency = v.GetInt(flagABCIConsensusConcurrency)
+	nc.ABCI.ReconnectRetries = v.GetInt(flagABCIReconnectRetries)
+	nc.ABCI.ReconnectInterval = v.GetDuration(flagABCIReconnectInterval)
+	nc.P2P.MaxInboundConns = v.GetInt(flagP2PMaxInboundConns)
+	nc.P2P.MaxOutboundConns = v.GetInt(flagP2PMaxOutboundConns)
+	nc.P2P.MaxStreamsPerPeer = v.GetInt(flagP2PMaxStreamsPerPeer)
+	nc.P2P.MaxMemory = v.GetInt(flagP2PMaxMemory)
+	nc.P2P.NATPortMap = v.GetBool(flagP2PNATPortMap)
+	nc.P2P.AutoNATService = v.GetBool(flagP2PAutoNATService)
+	nc.P2P.RelayService = v.GetBool(flagP2PRelayService)
+	nc.P2P.Relays = v.GetString(flagP2PRelays)
+	nc.P2P.Transports = v.GetString(flagP2PTransports)
+	nc.P2P.WSListenAddress = v.GetString(flagP2PWSListenAddress)
+	nc.P2P.TLSCertFile = v.GetString(flagP2PTLSCertFile)
+	nc.P2P.TLSKeyFile = v.GetString(flagP2PTLSKeyFile)
+	nc.P2P.MaxMessageSize = v.GetInt(flagP2PMaxMessageSize)
+	nc.P2P.TxBatchSize = v.GetInt(flagP2PTxBatchSize)
+	nc.P2P.TxBatchMaxBytes = v.GetInt(flagP2PTxBatchMaxBytes)
+	nc.P2P.TxBatchTimeout = v.GetDuration(flagP2PTxBatchTimeout)
+	nc.P2P.TxBatchCompression = v.GetBool(flagP2PTxBatchCompression)
+	nc.P2P.BlockGossip = v.GetBool(flagP2PBlockGossip)
+	nc.P2P.BlockPartSize = v.GetInt(flagP2PBlockPartSize)
+	nc.RPC.CompatVersion = v.GetString(flagRPCCompatVersion)
+	nc.RPC.EventEncoding = v.GetString(flagRPCEventEncoding)
+	nc.RPC.EthNamespace = v.GetBool(flagRPCEthNamespace)
+	nc.RPC.ReadOnly = v.GetBool(flagRPCReadOnly)
+	nc.RPC.SlowQueryThreshold = v.GetDuration(flagRPCSlowQuery)
+	nc.RPC.MaxInvalidTxsPerMinute = v.GetInt(flagRPCMaxInvalidTxs)
+	nc.RPC.MaxPendingCommits = v.GetInt(flagRPCMaxCommits)
+	nc.ForcedInclusionWindow = v.GetUint64(flagForcedInclusionWindow)
+	nc.SystemLaneMaxBytes = v.GetInt64(flagSystemLaneMaxBytes)
+	nc.ForcedLaneMaxBytes = v.GetInt64(flagForcedLaneMaxBytes)
+	nc.MempoolLaneMaxBytes = v.GetInt64(flagMempoolLaneMaxBytes)
+	nc.MempoolLaneMaxGas = v.GetInt64(flagMempoolLaneMaxGas)
+	nc.FCFSOrdering = v.GetBool(flagFCFSOrdering)
+	if err := parseNamespaceID(nc.NamespaceID[:], v.GetString(flagNamespaceID), flagNamespaceID); err != nil {
 		return err
 	}
-	copy(nc.NamespaceID[:], bytes)
+	return parseNamespaceID(nc.ForcedInclusionNamespaceID[:], v.GetString(flagForcedInclusionNamespaceID), flagForcedInclusionNamespaceID)
+}
+
+// parseNamespaceID decodes hex encoded namespace ID into dst. Empty string leaves dst unchanged.
+func parseNamespaceID(dst []byte, nsID string, flag string) error {
+	if nsID == "" {
+		return nil
+	}
+	bytes, err := hex.DecodeString(nsID)
+	if err != nil || len(bytes) != len(dst) {
+		return fmt.Errorf("invalid namespace ID %q: set %s to %d bytes in hex (%d characters)", nsID, flag, len(dst), 2*len(dst))
+	}
+	copy(dst, bytes)
 	return nil
 }
 
 func AddFlags(cmd *cobra.Command) {
 	def := DefaultNodeConfig
+	cmd.Flags().String(flagLogFormat, def.LogFormat, "format of node logs: plain or json")
 	cmd.Flags().Bool(flagAggregator, def.Aggregator, "run node in aggregator mode")
+	cmd.Flags().Bool(flagLight, def.Light, "run node in light mode (follow headers and sample data availability, requires DA layer supporting sampling)")
+	cmd.Flags().Int(flagDASamples, def.DASamples, "number of shares sampled by light node for every header")
 	cmd.Flags().String(flagDALayer, def.DALayer, "Data Availability Layer Client name (mock or grpc")
 	cmd.Flags().String(flagDAConfig, def.DAConfig, "Data Availability Layer Client config")
+	cmd.Flags().String(flagDAAccountKeyFile, def.DAAccount.KeyFile, "path to private key of DA layer account paying fees for block submissions")
+	cmd.Flags().Uint64(flagDAAccountMinBalance, def.DAAccount.MinBalance, "DA layer account balance below which low balance alerts are raised")
+	cmd.Flags().Duration(flagDAAccountCheckInterval, def.DAAccount.CheckInterval, "interval of DA layer account balance checks")
 	cmd.Flags().Duration(flagBlockTime, def.BlockTime, "block time (for aggregator mode)")
+	cmd.Flags().Uint64(flagWatchdogMultiplier, def.WatchdogMultiplier, "number of block times without progress, after which block production is considered stalled (0 - disabled)")
+	cmd.Flags().Uint64(flagDAEpoch, def.DAEpoch, "number of DA layer blocks per block, enables block production by DA epoch (0 - use block time)")
+	cmd.Flags().String(flagHeaderVerification, def.HeaderVerification, "sequencer signature verification of synced headers (strict or permissive - only for devnets)")
+	cmd.Flags().Bool(flagDevMode, def.DevMode, "produce block as soon as transactions are available, block time is the idle interval (for local development)")
+	cmd.Flags().Bool(flagVerifyResults, def.VerifyResults, "compare results of executed blocks with the next block header and halt sync on mismatch")
+	cmd.Flags().Duration(flagDAConfirmInterval, def.DAConfirmInterval, "interval of confirming availability of blocks in DA layer (0 - disabled)")
+	cmd.Flags().Uint64(flagDAConfirmDepth, def.DAConfirmDepth, "number of DA layer blocks on top of DA block containing a block, before the block is final")
+	cmd.Flags().Uint64(flagDAReorgWindow, def.DAReorgWindow, "number of latest blocks checked for DA layer reorgs (0 - disabled)")
+	cmd.Flags().Uint64(flagDAMaxRetrieveSize, def.DAMaxRetrieveSize, "max size of a blob retrieved from DA layer, larger blobs are rejected before decoding (0 - no limit, has to be the same on all nodes)")
 	cmd.Flags().BytesHex(flagNamespaceID, def.NamespaceID[:], "namespace identifies (8 bytes in hex)")
+	cmd.Flags().Uint64(flagForcedInclusionWindow, def.ForcedInclusionWindow, "number of blocks after which transactions posted directly to DA layer are included (0 - disabled)")
+	cmd.Flags().BytesHex(flagForcedInclusionNamespaceID, def.ForcedInclusionNamespaceID[:], "namespace of transactions posted directly to DA layer (8 bytes in hex)")
+	cmd.Flags().Int64(flagSystemLaneMaxBytes, def.SystemLaneMaxBytes, "max total size of system transactions in a block (0 - no limit, has to be the same on all nodes)")
+	cmd.Flags().Int64(flagForcedLaneMaxBytes, def.ForcedLaneMaxBytes, "max total size of forced inclusion transactions in a block, excess is deferred (0 - no limit, has to be the same on all nodes)")
+	cmd.Flags().Int64(flagMempoolLaneMaxBytes, def.MempoolLaneMaxBytes, "max total size of mempool transactions in produced blocks (0 - no limit)")
+	cmd.Flags().Int64(flagMempoolLaneMaxGas, def.MempoolLaneMaxGas, "max total gas wanted by mempool transactions in produced blocks (0 - no limit)")
+	cmd.Flags().Bool(flagFCFSOrdering, def.FCFSOrdering, "first-come-first-served ordering of mempool transactions with signed receipts (has to be the same on all nodes)")
+	cmd.Flags().Bool(flagMempoolSenderLanes, def.MempoolSenderLanes, "order mempool transactions of the same sender by nonce (reported by app in CheckTx events)")
+	cmd.Flags().Bool(flagMempoolFeePriority, def.MempoolFeePriority, "order mempool transactions by gas price (fee reported by app in CheckTx events)")
+	cmd.Flags().String(flagMinGasPrice, def.MinGasPrice, "minimal gas price of transactions accepted to mempool, e.g. 0.025stake (fee reported by app in CheckTx events)")
+	cmd.Flags().Uint64(flagTxIndexRetainBlocks, def.TxIndex.RetainBlocks, "number of most recent blocks with indexed transactions (0 - keep all)")
+	cmd.Flags().Duration(flagTxIndexCompactionInterval, def.TxIndex.CompactionInterval, "interval of transaction index compaction (0 - disabled)")
+	cmd.Flags().Bool(flagEventJournal, def.EventJournal.Enabled, "enable durable journal of block events, read by downstream consumers via RPC")
+	cmd.Flags().Uint64(flagEventJournalRetainBlocks, def.EventJournal.RetainBlocks, "number of most recent blocks with journaled events (0 - keep all)")
+	cmd.Flags().Int(flagStoreCacheSize, def.StoreCacheSize, "number of recent blocks cached in memory (0 - disabled)")
+	cmd.Flags().Bool(flagArchive, def.Archive, "archive mode: never prune data and serve ABCI queries at historical heights")
+	cmd.Flags().String(flagReplicationListenAddress, def.Replication.ListenAddress, "address (host:port) of gRPC server streaming blocks to read replicas (empty - disabled)")
+	cmd.Flags().String(flagReplicationSource, def.Replication.Source, "address (host:port) of replication server of the node replicated by this read replica")
+	cmd.Flags().String(flagDiagnosticsListenAddress, def.Diagnostics.ListenAddress, "address (host:port) of HTTP server exposing pprof, goroutine dumps and node state (empty - disabled)")
+	cmd.Flags().String(flagPanicPolicy, def.PanicPolicy, "action after panic is recovered in node goroutine (restart - restart the goroutine, shutdown - stop the node)")
+	cmd.Flags().Uint64(flagSnapshotPublishInterval, def.Snapshot.PublishInterval, "interval (in blocks) of application snapshots published to DA layer, has to match app snapshot interval (0 - disabled)")
+	cmd.Flags().Bool(flagSnapshotSync, def.Snapshot.Sync, "restore application state from the latest snapshot published to DA layer, when node starts with empty store")
+	cmd.Flags().String(flagBridgeEventFilters, def.Bridge.EventFilters, "comma separated list of event filters (type or type.key=value) selecting events proven to settlement bridges (empty - disabled)")
+	cmd.Flags().String(flagSettlementLayer, def.Settlement.Layer, "Settlement Layer Client name (mock or grpc, empty - disabled)")
+	cmd.Flags().String(flagSettlementConfig, def.Settlement.Config, "Settlement Layer Client config")
+	cmd.Flags().Uint64(flagSettlementEpoch, def.Settlement.Epoch, "number of blocks covered by a single state commitment posted to settlement layer")
+	cmd.Flags().Uint64(flagCheckpointInterval, def.Checkpoint.Interval, "interval (in blocks) of signed checkpoints of chain state, produced by aggregator and gossiped to full nodes (0 - disabled)")
+	cmd.Flags().Int(flagABCIQueryConcurrency, def.ABCI.QueryConcurrency, "max concurrent ABCI queries, excess queries are rejected (0 - unlimited)")
+	cmd.Flags().Int(flagABCIMempoolConcurrency, def.ABCI.MempoolConcurrency, "max concurrent ABCI mempool requests (0 - unlimited)")
+	cmd.Flags().Int(flagABCIConsensusConcurrency, def.ABCI.ConsensusConcurrency, "max concurrent ABCI consensus requests (0 - unlimited)")
+	cmd.Flags().Int(flagABCIReconnectRetries, def.ABCI.ReconnectRetries, "number of attempts to reconnect to ABCI application after connection is lost (0 - disabled)")
+	cmd.Flags().Duration(flagABCIReconnectInterval, def.ABCI.ReconnectInterval, "time to wait before every attempt to reconnect to ABCI application")
+	cmd.Flags().Int(flagP2PMaxInboundConns, def.P2P.MaxInboundConns, "max number of peers connected by inbound P2P connections (0 - unlimited)")
+	cmd.Flags().Int(flagP2PMaxOutboundConns, def.P2P.MaxOutboundConns, "max number of peers connected by outbound P2P connections (0 - unlimited)")
+	cmd.Flags().Int(flagP2PMaxStreamsPerPeer, def.P2P.MaxStreamsPerPeer, "max number of streams per P2P peer (0 - unlimited)")
+	cmd.Flags().Int(flagP2PMaxMemory, def.P2P.MaxMemory, "max memory (in bytes) used by receive buffers of P2P streams; enables yamux as the only stream multiplexer (0 - unlimited)")
+	cmd.Flags().Bool(flagP2PNATPortMap, def.P2P.NATPortMap, "try to open P2P port in NAT using UPnP")
+	cmd.Flags().Bool(flagP2PAutoNATService, def.P2P.AutoNATService, "help other peers to determine if they are reachable (AutoNAT service)")
+	cmd.Flags().Bool(flagP2PRelayService, def.P2P.RelayService, "relay traffic on behalf of peers behind NAT")
+	cmd.Flags().String(flagP2PRelays, def.P2P.Relays, "comma separated list of relays used if node is not reachable")
+	cmd.Flags().String(flagP2PTransports, def.P2P.Transports, "comma separated list of P2P transports (tcp, ws, wss)")
+	cmd.Flags().String(flagP2PWSListenAddress, def.P2P.WSListenAddress, "additional address to listen for WebSocket P2P connections (Multiaddr format, /ws or /wss)")
+	cmd.Flags().String(flagP2PTLSCertFile, def.P2P.TLSCertFile, "path to TLS certificate (PEM) used to accept secure WebSocket P2P connections")
+	cmd.Flags().String(flagP2PTLSKeyFile, def.P2P.TLSKeyFile, "path to TLS key (PEM) used to accept secure WebSocket P2P connections")
+	cmd.Flags().Int(flagP2PMaxMessageSize, def.P2P.MaxMessageSize, "max size of a gossiped message, larger messages are rejected before decoding")
+	cmd.Flags().Int(flagP2PTxBatchSize, def.P2P.TxBatchSize, "max number of transactions gossiped in a single batch (0 - batching disabled)")
+	cmd.Flags().Int(flagP2PTxBatchMaxBytes, def.P2P.TxBatchMaxBytes, "max total size of transactions gossiped in a single batch")
+	cmd.Flags().Duration(flagP2PTxBatchTimeout, def.P2P.TxBatchTimeout, "max time transaction waits for a gossip batch to fill up")
+	cmd.Flags().Bool(flagP2PTxBatchCompression, def.P2P.TxBatchCompression, "compress gossiped transaction batches")
+	cmd.Flags().Bool(flagP2PBlockGossip, def.P2P.BlockGossip, "gossip block bodies split into erasure-coded parts")
+	cmd.Flags().Int(flagP2PBlockPartSize, def.P2P.BlockPartSize, "size of a single gossiped block part")
+	cmd.Flags().String(flagRPCCompatVersion, def.RPC.CompatVersion, "shape of JSON-RPC responses (0.34 - Tendermint, 0.37 or 0.38 - CometBFT)")
+	cmd.Flags().String(flagRPCEventEncoding, def.RPC.EventEncoding, "encoding of event attributes in RPC responses (base64 or raw, empty - implied by compatibility version)")
+	cmd.Flags().Bool(flagRPCEthNamespace, def.RPC.EthNamespace, "enable Ethereum JSON-RPC facade (eth_* methods)")
+	cmd.Flags().Bool(flagRPCReadOnly, def.RPC.ReadOnly, "disable transaction broadcasting and admin RPC methods (query-only replica)")
+	cmd.Flags().Duration(flagRPCSlowQuery, def.RPC.SlowQueryThreshold, "log RPC method calls slower than threshold, with their arguments (0 - disabled)")
+	cmd.Flags().Int(flagRPCMaxInvalidTxs, def.RPC.MaxInvalidTxsPerMinute, "max invalid transactions submitted via RPC by a single host per minute, excess transactions are rejected (0 - no limit)")
+	cmd.Flags().Int(flagRPCMaxCommits, def.RPC.MaxPendingCommits, "max concurrent broadcast_tx_commit calls, excess calls are queued (0 - no limit)")
 }
@@ -19,18 +19,152 @@ func TestViperAndCobra(t *testing.T) {
 	v := viper.GetViper()
 	assert.NoError(v.BindPFlags(cmd.Flags()))
 
+	assert.NoError(cmd.Flags().Set(flagLogFormat, "json"))
 	assert.NoError(cmd.Flags().Set(flagAggregator, "true"))
+	assert.NoError(cmd.Flags().Set(flagLight, "true"))
+	assert.NoError(cmd.Flags().Set(flagDASamples, "20"))
 	assert.NoError(cmd.Flags().Set(flagDALayer, "foobar"))
 	assert.NoError(cmd.Flags().Set(flagDAConfig, `{"json":true}`))
 	assert.NoError(cmd.Flags().Set(flagBlockTime, "1234s"))
 	assert.NoError(cmd.Flags().Set(flagNamespaceID, "0102030405060708"))
+	assert.NoError(cmd.Flags().Set(flagWatchdogMultiplier, "5"))
+	assert.NoError(cmd.Flags().Set(flagDAEpoch, "2"))
+	assert.NoError(cmd.Flags().Set(flagHeaderVerification, "permissive"))
+	assert.NoError(cmd.Flags().Set(flagDevMode, "true"))
+	assert.NoError(cmd.Flags().Set(flagVerifyResults, "true"))
+	assert.NoError(cmd.Flags().Set(flagDAConfirmInterval, "15s"))
+	assert.NoError(cmd.Flags().Set(flagDAConfirmDepth, "6"))
+	assert.NoError(cmd.Flags().Set(flagDAReorgWindow, "50"))
+	assert.NoError(cmd.Flags().Set(flagDAMaxRetrieveSize, "2000000"))
+	assert.NoError(cmd.Flags().Set(flagForcedInclusionWindow, "10"))
+	assert.NoError(cmd.Flags().Set(flagForcedInclusionNamespaceID, "0807060504030201"))
+	assert.NoError(cmd.Flags().Set(flagSystemLaneMaxBytes, "1000"))
+	assert.NoError(cmd.Flags().Set(flagForcedLaneMaxBytes, "2000"))
+	assert.NoError(cmd.Flags().Set(flagMempoolLaneMaxBytes, "3000"))
+	assert.NoError(cmd.Flags().Set(flagMempoolLaneMaxGas, "4000"))
+	assert.NoError(cmd.Flags().Set(flagFCFSOrdering, "true"))
+	assert.NoError(cmd.Flags().Set(flagDAAccountKeyFile, "da_key.json"))
+	assert.NoError(cmd.Flags().Set(flagDAAccountMinBalance, "5000"))
+	assert.NoError(cmd.Flags().Set(flagMempoolSenderLanes, "true"))
+	assert.NoError(cmd.Flags().Set(flagMempoolFeePriority, "true"))
+	assert.NoError(cmd.Flags().Set(flagMinGasPrice, "0.025stake"))
+	assert.NoError(cmd.Flags().Set(flagTxIndexRetainBlocks, "1000"))
+	assert.NoError(cmd.Flags().Set(flagEventJournal, "true"))
+	assert.NoError(cmd.Flags().Set(flagEventJournalRetainBlocks, "2000"))
+	assert.NoError(cmd.Flags().Set(flagStoreCacheSize, "100"))
+	assert.NoError(cmd.Flags().Set(flagArchive, "true"))
+	assert.NoError(cmd.Flags().Set(flagReplicationListenAddress, "0.0.0.0:26660"))
+	assert.NoError(cmd.Flags().Set(flagReplicationSource, "10.0.0.1:26660"))
+	assert.NoError(cmd.Flags().Set(flagDiagnosticsListenAddress, "127.0.0.1:6060"))
+	assert.NoError(cmd.Flags().Set(flagPanicPolicy, "shutdown"))
+	assert.NoError(cmd.Flags().Set(flagSnapshotPublishInterval, "500"))
+	assert.NoError(cmd.Flags().Set(flagSnapshotSync, "true"))
+	assert.NoError(cmd.Flags().Set(flagBridgeEventFilters, "burn,lock.module=bridge"))
+	assert.NoError(cmd.Flags().Set(flagSettlementLayer, "grpc"))
+	assert.NoError(cmd.Flags().Set(flagSettlementConfig, `{"port":7981}`))
+	assert.NoError(cmd.Flags().Set(flagSettlementEpoch, "10"))
+	assert.NoError(cmd.Flags().Set(flagCheckpointInterval, "100"))
+	assert.NoError(cmd.Flags().Set(flagABCIQueryConcurrency, "3"))
+	assert.NoError(cmd.Flags().Set(flagABCIMempoolConcurrency, "5"))
+	assert.NoError(cmd.Flags().Set(flagABCIReconnectInterval, "3s"))
+	assert.NoError(cmd.Flags().Set(flagP2PMaxInboundConns, "7"))
+	assert.NoError(cmd.Flags().Set(flagP2PMaxStreamsPerPeer, "0"))
+	assert.NoError(cmd.Flags().Set(flagP2PMaxMemory, "1073741824"))
+	assert.NoError(cmd.Flags().Set(flagP2PRelayService, "true"))
+	assert.NoError(cmd.Flags().Set(flagP2PRelays, "/ip4/127.0.0.1/tcp/7676/p2p/relay"))
+	assert.NoError(cmd.Flags().Set(flagP2PWSListenAddress, "/ip4/0.0.0.0/tcp/7677/ws"))
+	assert.NoError(cmd.Flags().Set(flagP2PTLSCertFile, "/etc/optimint/cert.pem"))
+	assert.NoError(cmd.Flags().Set(flagP2PTLSKeyFile, "/etc/optimint/key.pem"))
+	assert.NoError(cmd.Flags().Set(flagP2PMaxMessageSize, "2097152"))
+	assert.NoError(cmd.Flags().Set(flagP2PTxBatchSize, "100"))
+	assert.NoError(cmd.Flags().Set(flagP2PTxBatchCompression, "true"))
+	assert.NoError(cmd.Flags().Set(flagP2PBlockGossip, "true"))
+	assert.NoError(cmd.Flags().Set(flagRPCCompatVersion, "0.38"))
+	assert.NoError(cmd.Flags().Set(flagRPCEventEncoding, "base64"))
+	assert.NoError(cmd.Flags().Set(flagRPCEthNamespace, "true"))
+	assert.NoError(cmd.Flags().Set(flagRPCReadOnly, "true"))
+	assert.NoError(cmd.Flags().Set(flagRPCSlowQuery, "250ms"))
+	assert.NoError(cmd.Flags().Set(flagRPCMaxInvalidTxs, "20"))
+	assert.NoError(cmd.Flags().Set(flagRPCMaxCommits, "7"))
 
 	nc := DefaultNodeConfig
 	assert.NoError(nc.GetViperConfig(v))
 
+	assert.Equal("json", nc.LogFormat)
 	assert.Equal(true, nc.Aggregator)
+	assert.Equal(true, nc.Light)
+	assert.Equal(20, nc.DASamples)
 	assert.Equal("foobar", nc.DALayer)
 	assert.Equal(`{"json":true}`, nc.DAConfig)
 	assert.Equal(1234*time.Second, nc.BlockTime)
 	assert.Equal([8]byte{1, 2, 3, 4, 5, 6, 7, 8}, nc.NamespaceID)
+	assert.Equal(uint64(5), nc.WatchdogMultiplier)
+	assert.Equal(uint64(2), nc.DAEpoch)
+	assert.Equal(HeaderVerificationPermissive, nc.HeaderVerification)
+	assert.True(nc.DevMode)
+	assert.True(nc.VerifyResults)
+	assert.Equal(15*time.Second, nc.DAConfirmInterval)
+	assert.Equal(uint64(6), nc.DAConfirmDepth)
+	assert.Equal(uint64(50), nc.DAReorgWindow)
+	assert.Equal(uint64(2000000), nc.DAMaxRetrieveSize)
+	assert.Equal(uint64(10), nc.ForcedInclusionWindow)
+	assert.Equal([8]byte{8, 7, 6, 5, 4, 3, 2, 1}, nc.ForcedInclusionNamespaceID)
+	assert.Equal(int64(1000), nc.SystemLaneMaxBytes)
+	assert.Equal(int64(2000), nc.ForcedLaneMaxBytes)
+	assert.Equal(int64(3000), nc.MempoolLaneMaxBytes)
+	assert.Equal(int64(4000), nc.MempoolLaneMaxGas)
+	assert.True(nc.FCFSOrdering)
+	assert.Equal("da_key.json", nc.DAAccount.KeyFile)
+	assert.Equal(uint64(5000), nc.DAAccount.MinBalance)
+	assert.Equal(time.Minute, nc.DAAccount.CheckInterval)
+	assert.True(nc.MempoolSenderLanes)
+	assert.True(nc.MempoolFeePriority)
+	assert.Equal("0.025stake", nc.MinGasPrice)
+	assert.Equal(uint64(1000), nc.TxIndex.RetainBlocks)
+	assert.Equal(time.Hour, nc.TxIndex.CompactionInterval)
+	assert.True(nc.EventJournal.Enabled)
+	assert.Equal(uint64(2000), nc.EventJournal.RetainBlocks)
+	assert.Equal(100, nc.StoreCacheSize)
+	assert.True(nc.Archive)
+	assert.Equal("0.0.0.0:26660", nc.Replication.ListenAddress)
+	assert.Equal("10.0.0.1:26660", nc.Replication.Source)
+	assert.Equal("127.0.0.1:6060", nc.Diagnostics.ListenAddress)
+	assert.Equal(PanicPolicyShutdown, nc.PanicPolicy)
+	assert.Equal(uint64(500), nc.Snapshot.PublishInterval)
+	assert.True(nc.Snapshot.Sync)
+	assert.Equal("burn,lock.module=bridge", nc.Bridge.EventFilters)
+	assert.Equal("grpc", nc.Settlement.Layer)
+	assert.Equal(`{"port":7981}`, nc.Settlement.Config)
+	assert.Equal(uint64(10), nc.Settlement.Epoch)
+	assert.Equal(uint64(100), nc.Checkpoint.Interval)
+	assert.Equal(3, nc.ABCI.QueryConcurrency)
+	assert.Equal(5, nc.ABCI.MempoolConcurrency)
+	assert.Equal(0, nc.ABCI.ConsensusConcurrency)
+	assert.Equal(10, nc.ABCI.ReconnectRetries)
+	assert.Equal(3*time.Second, nc.ABCI.ReconnectInterval)
+	assert.Equal(7, nc.P2P.MaxInboundConns)
+	assert.Equal(128, nc.P2P.MaxOutboundConns)
+	assert.Equal(0, nc.P2P.MaxStreamsPerPeer)
+	assert.Equal(1073741824, nc.P2P.MaxMemory)
+	assert.False(nc.P2P.NATPortMap)
+	assert.True(nc.P2P.RelayService)
+	assert.Equal("/ip4/127.0.0.1/tcp/7676/p2p/relay", nc.P2P.Relays)
+	assert.Equal(DefaultTransports, nc.P2P.Transports)
+	assert.Equal("/ip4/0.0.0.0/tcp/7677/ws", nc.P2P.WSListenAddress)
+	assert.Equal("/etc/optimint/cert.pem", nc.P2P.TLSCertFile)
+	assert.Equal("/etc/optimint/key.pem", nc.P2P.TLSKeyFile)
+	assert.Equal(2097152, nc.P2P.MaxMessageSize)
+	assert.Equal(100, nc.P2P.TxBatchSize)
+	assert.Equal(DefaultTxBatchMaxBytes, nc.P2P.TxBatchMaxBytes)
+	assert.Equal(DefaultTxBatchTimeout, nc.P2P.TxBatchTimeout)
+	assert.True(nc.P2P.TxBatchCompression)
+	assert.True(nc.P2P.BlockGossip)
+	assert.Equal(DefaultBlockPartSize, nc.P2P.BlockPartSize)
+	assert.Equal(RPCCompat038, nc.RPC.CompatVersion)
+	assert.Equal(EventEncodingBase64, nc.RPC.EventEncoding)
+	assert.True(nc.RPC.EthNamespace)
+	assert.True(nc.RPC.ReadOnly)
+	assert.Equal(250*time.Millisecond, nc.RPC.SlowQueryThreshold)
+	assert.Equal(20, nc.RPC.MaxInvalidTxsPerMinute)
+	assert.Equal(7, nc.RPC.MaxPendingCommits)
 }
@@ -0,0 +1,14 @@
+package config
+
+import "time"
+
+// DAAccountConfig configures the DA layer account paying fees for block submissions.
+type DAAccountConfig struct {
+	// KeyFile is a path to file with private key of the account, in Tendermint node key format.
+	// Empty value disables account management (DA layer client is responsible for fees).
+	KeyFile string `mapstructure:"da_account_key_file"`
+	// MinBalance is the balance below which low balance alerts are raised (via logs, metrics and events).
+	MinBalance uint64 `mapstructure:"da_account_min_balance"`
+	// CheckInterval is the interval of account balance checks.
+	CheckInterval time.Duration `mapstructure:"da_account_check_interval"`
+}
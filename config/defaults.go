@@ -5,6 +5,44 @@ import "time"
 const (
 	// DefaultListenAddress is a default listen address for P2P client.
 	DefaultListenAddress = "/ip4/0.0.0.0/tcp/7676"
+
+	// DefaultTransports is a default list of transports used by P2P client.
+	DefaultTransports = "tcp,ws"
+
+	// DefaultTxBatchMaxBytes is a default max total size of transactions gossiped in a single batch.
+	DefaultTxBatchMaxBytes = 64 * 1024
+	// DefaultTxBatchTimeout is a default max time transaction waits for a gossip batch to fill up.
+	DefaultTxBatchTimeout = 50 * time.Millisecond
+	// DefaultMaxMessageSize is a default max size of a gossiped message.
+	DefaultMaxMessageSize = 1 << 20
+	// DefaultDAMaxRetrieveSize is a default max size of a blob retrieved from DA layer.
+	DefaultDAMaxRetrieveSize = 64 << 20
+	// DefaultBlockPartSize is a default size of a single gossiped block part.
+	DefaultBlockPartSize = 64 * 1024
+	// MaxBlockPartSize is the max size of a single gossiped block part accepted by peers.
+	MaxBlockPartSize = 512 * 1024
+
+	// HeaderVerificationStrict rejects headers with invalid sequencer signature.
+	HeaderVerificationStrict = "strict"
+	// HeaderVerificationPermissive accepts headers with invalid sequencer signature (failures are logged and counted).
+	HeaderVerificationPermissive = "permissive"
+
+	// RPCCompat034 makes JSON-RPC responses compatible with Tendermint 0.34.
+	RPCCompat034 = "0.34"
+	// RPCCompat037 makes JSON-RPC responses compatible with CometBFT 0.37.
+	RPCCompat037 = "0.37"
+	// RPCCompat038 makes JSON-RPC responses compatible with CometBFT 0.38.
+	RPCCompat038 = "0.38"
+
+	// EventEncodingBase64 returns event attribute keys and values base64 encoded (like Tendermint 0.34).
+	EventEncodingBase64 = "base64"
+	// EventEncodingRaw returns event attribute keys and values as strings (like CometBFT 0.37+).
+	EventEncodingRaw = "raw"
+
+	// PanicPolicyRestart restarts long-running goroutine of the node after a panic is recovered.
+	PanicPolicyRestart = "restart"
+	// PanicPolicyShutdown stops the node after a panic is recovered in any of its long-running goroutines.
+	PanicPolicyShutdown = "shutdown"
 )
 
 // DefaultNodeConfig keeps default values of NodeConfig
@@ -12,12 +50,116 @@ var DefaultNodeConfig = NodeConfig{
 	P2P: P2PConfig{
 		ListenAddress: DefaultListenAddress,
 		Seeds:         "",
+		SeedMode:      false,
+
+		MaxInboundConns:   64,
+		MaxOutboundConns:  128,
+		MaxStreamsPerPeer: 64,
+		MaxMemory:         0,
+
+		NATPortMap:     false,
+		AutoNATService: false,
+		RelayService:   false,
+		Relays:         "",
+
+		Transports:      DefaultTransports,
+		WSListenAddress: "",
+		TLSCertFile:     "",
+		TLSKeyFile:      "",
+
+		MaxMessageSize: DefaultMaxMessageSize,
+
+		TxBatchSize:        0,
+		TxBatchMaxBytes:    DefaultTxBatchMaxBytes,
+		TxBatchTimeout:     DefaultTxBatchTimeout,
+		TxBatchCompression: false,
+
+		BlockGossip:   false,
+		BlockPartSize: DefaultBlockPartSize,
+	},
+	RPC: RPCConfig{
+		CompatVersion:      RPCCompat034,
+		EthNamespace:       false,
+		ReadOnly:           false,
+		SlowQueryThreshold: time.Second,
+
+		MaxInvalidTxsPerMinute: 0,
+		MaxPendingCommits:      20,
 	},
+	LogFormat:  "",
 	Aggregator: false,
+	Light:      false,
+	DASamples:  16,
 	BlockManagerConfig: BlockManagerConfig{
 		BlockTime:   30 * time.Second,
 		NamespaceID: [8]byte{},
+
+		ForcedInclusionWindow:      0,
+		ForcedInclusionNamespaceID: [8]byte{},
+		SystemLaneMaxBytes:         0,
+		ForcedLaneMaxBytes:         0,
+		MempoolLaneMaxBytes:        0,
+		MempoolLaneMaxGas:          0,
+		FCFSOrdering:               false,
+
+		WatchdogMultiplier: 10,
+		DAEpoch:            0,
+		HeaderVerification: HeaderVerificationStrict,
+		DevMode:            false,
+		VerifyResults:      false,
+		DAConfirmInterval:  0,
+		DAConfirmDepth:     0,
+		DAReorgWindow:      0,
 	},
 	DALayer:  "mock",
 	DAConfig: "",
+	DAAccount: DAAccountConfig{
+		KeyFile:       "",
+		MinBalance:    0,
+		CheckInterval: time.Minute,
+	},
+	DAMaxRetrieveSize:  DefaultDAMaxRetrieveSize,
+	MempoolSenderLanes: false,
+	MempoolFeePriority: false,
+	MinGasPrice:        "",
+	TxIndex: TxIndexConfig{
+		RetainBlocks:       0,
+		CompactionInterval: time.Hour,
+	},
+	EventJournal: EventJournalConfig{
+		Enabled:      false,
+		RetainBlocks: 0,
+	},
+	StoreCacheSize: 32,
+	Archive:        false,
+	Replication: ReplicationConfig{
+		ListenAddress: "",
+		Source:        "",
+	},
+	Diagnostics: DiagnosticsConfig{
+		ListenAddress: "",
+	},
+	PanicPolicy: PanicPolicyRestart,
+	Snapshot: SnapshotConfig{
+		PublishInterval: 0,
+		Sync:            false,
+	},
+	Bridge: BridgeConfig{
+		EventFilters: "",
+	},
+	Settlement: SettlementConfig{
+		Layer:  "",
+		Config: "",
+		Epoch:  1,
+	},
+	Checkpoint: CheckpointConfig{
+		Interval: 0,
+	},
+	ABCI: ABCIConfig{
+		QueryConcurrency:     32,
+		MempoolConcurrency:   64,
+		ConsensusConcurrency: 0,
+		ReconnectRetries:     10,
+		ReconnectInterval:    time.Second,
+	},
 }
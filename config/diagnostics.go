@@ -0,0 +1,8 @@
+package config
+
+// DiagnosticsConfig configures HTTP endpoint used for debugging of running node.
+type DiagnosticsConfig struct {
+	// ListenAddress is the address (host:port) of HTTP server exposing net/http/pprof, goroutine dumps and node state
+	// (empty - disabled). It shouldn't be exposed publicly.
+	ListenAddress string `mapstructure:"diagnostics_listen_address"`
+}
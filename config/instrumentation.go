@@ -0,0 +1,18 @@
+package config
+
+// InstrumentationConfig defines the configuration for metrics reporting.
+type InstrumentationConfig struct {
+	// When true, Prometheus metrics are served under /metrics on
+	// PrometheusListenAddr.
+	Prometheus bool
+
+	// Address to listen for Prometheus collector(s) connections.
+	PrometheusListenAddr string
+
+	// Maximum number of simultaneous connections.
+	// 0 - unlimited.
+	MaxOpenConnections int
+
+	// Instrumentation namespace.
+	Namespace string
+}
@@ -0,0 +1,11 @@
+package config
+
+// EventJournalConfig configures durable journal of block events, read by downstream consumers (bridges, indexers) via
+// RPC.
+type EventJournalConfig struct {
+	// Enabled enables journaling of events of blocks applied after the journal is enabled.
+	Enabled bool `mapstructure:"event_journal"`
+	// RetainBlocks is the number of most recent blocks with journaled events. Events of older blocks are removed from
+	// the journal, even if consumers didn't read them yet (0 - keep all).
+	RetainBlocks uint64 `mapstructure:"event_journal_retain_blocks"`
+}
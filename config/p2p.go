@@ -1,7 +1,67 @@
 package config
 
+import "time"
+
+// MinStreamBufferSize is the minimal size of a stream receive buffer (see P2PConfig.MaxMemory).
+const MinStreamBufferSize = 256 * 1024
+
 // P2PConfig stores configuration related to peer-to-peer networking.
 type P2PConfig struct {
 	ListenAddress string // Address to listen for incoming connections
 	Seeds         string // Comma separated list of seed nodes to connect to
+	SeedMode      bool   // If true, node only serves peer discovery (DHT) and doesn't gossip, sync blocks or run an app
+
+	// Connection and stream limits, 0 means unlimited. Connection limits apply to peers: all connections of a peer in
+	// the same direction count as one.
+	MaxInboundConns   int `mapstructure:"p2p_max_inbound_conns"`
+	MaxOutboundConns  int `mapstructure:"p2p_max_outbound_conns"`
+	MaxStreamsPerPeer int `mapstructure:"p2p_max_streams_per_peer"`
+	// MaxMemory limits memory (in bytes) used by receive buffers of streams. It's split evenly between all streams
+	// allowed by connection and stream limits, so these limits have to be set. 0 means unlimited.
+	//
+	// Setting MaxMemory replaces default stream multiplexers (yamux and mplex) with yamux only, as mplex can't bound
+	// receive buffers, so peers supporting only mplex can't connect. Window of every yamux stream is set to
+	// MaxMemory / ((MaxInboundConns + MaxOutboundConns) * MaxStreamsPerPeer) (see StreamBufferSize).
+	MaxMemory int `mapstructure:"p2p_max_memory"`
+
+	// NAT traversal.
+	NATPortMap     bool   `mapstructure:"p2p_nat_port_map"`    // Try to open port in NAT using UPnP
+	AutoNATService bool   `mapstructure:"p2p_autonat_service"` // Help other peers to determine their reachability
+	RelayService   bool   `mapstructure:"p2p_relay_service"`   // Relay traffic on behalf of peers behind NAT
+	Relays         string `mapstructure:"p2p_relays"`          // Comma separated list of relays used if node is not reachable
+
+	// Transports is a comma separated list of enabled transports. Supported values: "tcp", "ws", "wss".
+	// QUIC is not supported, as QUIC implementation used by this version of libp2p doesn't build with Go 1.18+.
+	Transports string `mapstructure:"p2p_transports"`
+	// WSListenAddress is an additional address (in Multiaddr format) to listen for WebSocket connections.
+	// Secure WebSocket address (ending with /wss) requires "wss" transport and TLS certificate.
+	WSListenAddress string `mapstructure:"p2p_ws_listen_address"`
+	// TLSCertFile and TLSKeyFile are paths to PEM encoded TLS certificate and key, used to accept secure WebSocket
+	// connections.
+	TLSCertFile string `mapstructure:"p2p_tls_cert_file"`
+	TLSKeyFile  string `mapstructure:"p2p_tls_key_file"`
+
+	// MaxMessageSize limits the size of gossiped messages; larger messages are dropped before decoding
+	// (0 - libp2p default of 1 MiB). It has to be the same on all nodes, as larger messages are not propagated.
+	MaxMessageSize int `mapstructure:"p2p_max_message_size"`
+
+	// Transaction gossip batching. Batching is disabled if TxBatchSize is 0.
+	TxBatchSize        int           `mapstructure:"p2p_tx_batch_size"`        // Max number of transactions in a batch
+	TxBatchMaxBytes    int           `mapstructure:"p2p_tx_batch_max_bytes"`   // Max total size of transactions in a batch
+	TxBatchTimeout     time.Duration `mapstructure:"p2p_tx_batch_timeout"`     // Max time transaction waits for a batch to fill up
+	TxBatchCompression bool          `mapstructure:"p2p_tx_batch_compression"` // Compress batches before gossiping
+
+	// Block gossip. Blocks are split into content-addressed, erasure-coded parts of BlockPartSize bytes. Only blocks
+	// announced by the sequencer (with signed manifest) are accepted.
+	BlockGossip   bool `mapstructure:"p2p_block_gossip"`    // Gossip block bodies, not only headers
+	BlockPartSize int  `mapstructure:"p2p_block_part_size"` // Size of a single block part
+}
+
+// StreamBufferSize returns the max size of a receive buffer of a single stream, or 0 if memory is not limited.
+func (c *P2PConfig) StreamBufferSize() int {
+	streams := (c.MaxInboundConns + c.MaxOutboundConns) * c.MaxStreamsPerPeer
+	if c.MaxMemory <= 0 || streams <= 0 {
+		return 0
+	}
+	return c.MaxMemory / streams
 }
@@ -0,0 +1,11 @@
+package config
+
+// ReplicationConfig configures streaming replication of blocks to read replicas.
+type ReplicationConfig struct {
+	// ListenAddress is the address (host:port) of gRPC server streaming blocks to replicas (empty - disabled).
+	ListenAddress string `mapstructure:"replication_listen_address"`
+	// Source is the address (host:port) of replication server of the node replicated by this node. If set, node works
+	// as a read replica: blocks received from the source are saved without execution, and blocks are not synced from
+	// P2P network nor DA layer.
+	Source string `mapstructure:"replication_source"`
+}
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 type RPCConfig struct {
 	ListenAddress string
 
@@ -34,4 +36,32 @@ type RPCConfig struct {
 	// NOTE: both tls-cert-file and tls-key-file must be present for Tendermint to create HTTPS server.
 	// Otherwise, HTTP server is run.
 	TLSKeyFile string `mapstructure:"tls-key-file"`
+
+	// CompatVersion selects the shape of JSON-RPC responses: Tendermint 0.34 (RPCCompat034) or CometBFT 0.37/0.38
+	// (RPCCompat037, RPCCompat038), so clients built against newer versions can connect.
+	CompatVersion string `mapstructure:"rpc_compat_version"`
+
+	// EventEncoding overrides encoding of event attribute keys and values implied by CompatVersion: EventEncodingBase64
+	// or EventEncodingRaw. Empty value keeps the encoding of selected version.
+	EventEncoding string `mapstructure:"rpc_event_encoding"`
+
+	// EthNamespace enables Ethereum JSON-RPC facade (eth_* methods), for rollups running an EVM as ABCI application.
+	EthNamespace bool `mapstructure:"rpc_eth_namespace"`
+
+	// ReadOnly disables transaction broadcasting and admin methods, so query-only replicas can be exposed publicly.
+	ReadOnly bool `mapstructure:"rpc_read_only"`
+
+	// SlowQueryThreshold is the duration of RPC method call, above which the call is logged together with its
+	// arguments (0 - disabled).
+	SlowQueryThreshold time.Duration `mapstructure:"rpc_slow_query_threshold"`
+
+	// MaxInvalidTxsPerMinute limits the number of invalid transactions (rejected by the mempool) submitted by a single
+	// host per minute. Once the limit is reached, further transactions from the host are rejected until the end of the
+	// minute (0 - no limit).
+	MaxInvalidTxsPerMinute int `mapstructure:"rpc_max_invalid_txs_per_minute"`
+
+	// MaxPendingCommits limits the number of concurrent broadcast_tx_commit calls, as each of them holds an event bus
+	// subscription until the transaction is committed. Calls over the limit wait in a FIFO queue, and are rejected if
+	// the queue is full (0 - no limit).
+	MaxPendingCommits int `mapstructure:"rpc_max_pending_commits"`
 }
@@ -0,0 +1,12 @@
+package config
+
+// SettlementConfig configures posting of state commitments to settlement layer (e.g. L1 smart contract or settlement
+// chain).
+type SettlementConfig struct {
+	// Layer is the name of settlement layer client (empty - disabled).
+	Layer string `mapstructure:"settlement_layer"`
+	// Config is the configuration of settlement layer client.
+	Config string `mapstructure:"settlement_config"`
+	// Epoch is the number of blocks covered by a single state commitment.
+	Epoch uint64 `mapstructure:"settlement_epoch"`
+}
@@ -0,0 +1,11 @@
+package config
+
+// SnapshotConfig configures distribution of application state snapshots via snapshot store (DA layer).
+type SnapshotConfig struct {
+	// PublishInterval is the interval (in blocks) of application snapshots published to the snapshot store
+	// (0 - disabled). It has to match the snapshot interval configured in the application.
+	PublishInterval uint64 `mapstructure:"snapshot_publish_interval"`
+	// Sync enables restoring application state from the latest snapshot available in the snapshot store, when node
+	// starts with empty store.
+	Sync bool `mapstructure:"snapshot_sync"`
+}
@@ -0,0 +1,12 @@
+package config
+
+import "time"
+
+// TxIndexConfig configures retention of transaction index data.
+type TxIndexConfig struct {
+	// RetainBlocks is the number of most recent blocks with indexed transactions.
+	// Transactions from older blocks are removed from index (0 - keep all).
+	RetainBlocks uint64 `mapstructure:"tx_index_retain_blocks"`
+	// CompactionInterval is the interval of index store compaction, reclaiming space after pruning (0 - disabled).
+	CompactionInterval time.Duration `mapstructure:"tx_index_compaction_interval"`
+}
@@ -0,0 +1,263 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"go.uber.org/multierr"
+
+	"github.com/celestiaorg/optimint/da/registry"
+	optlog "github.com/celestiaorg/optimint/log"
+	slregistry "github.com/celestiaorg/optimint/settlement/registry"
+)
+
+const (
+	// MinBlockTime is the shortest supported block time.
+	MinBlockTime = time.Millisecond
+	// MaxBlockTime is the longest supported block time.
+	MaxBlockTime = 24 * time.Hour
+)
+
+// Validate checks configuration for invalid values and conflicting options, so that misconfiguration is reported
+// before any service is started. All problems are reported at once, each with a hint how to fix it.
+//
+// Empty DA layer name is accepted, as DA layer client can be provided programmatically.
+func (nc *NodeConfig) Validate() error {
+	var errs error
+	fail := func(format string, args ...interface{}) {
+		errs = multierr.Append(errs, fmt.Errorf(format, args...))
+	}
+
+	if nc.P2P.SeedMode {
+		// seed node only participates in peer discovery
+		if nc.Aggregator {
+			fail("aggregator mode can't be used together with seed mode: unset %s or run aggregator as a regular node", flagAggregator)
+		}
+		if nc.Light {
+			fail("light mode can't be used together with seed mode: unset %s or p2p.seed_mode", flagLight)
+		}
+		return multierr.Append(errs, nc.P2P.validate())
+	}
+
+	if nc.DALayer != "" && registry.GetClient(nc.DALayer) == nil {
+		clients := registry.RegisteredClients()
+		sort.Strings(clients)
+		fail("unknown DA layer %q: set %s to one of: %s", nc.DALayer, flagDALayer, strings.Join(clients, ", "))
+	}
+
+	if nc.Settlement.Layer != "" {
+		if slregistry.GetClient(nc.Settlement.Layer) == nil {
+			clients := slregistry.RegisteredClients()
+			sort.Strings(clients)
+			fail("unknown settlement layer %q: set %s to one of: %s", nc.Settlement.Layer, flagSettlementLayer, strings.Join(clients, ", "))
+		}
+		if nc.Settlement.Epoch == 0 {
+			fail("invalid settlement epoch 0: set %s to a positive number of blocks", flagSettlementEpoch)
+		}
+	}
+
+	// block time is used as interval of block production, stall detection, DA layer and snapshot checks
+	if nc.BlockTime < 0 || nc.BlockTime > MaxBlockTime ||
+		(nc.BlockTime < MinBlockTime && (nc.Aggregator || nc.Light || nc.DAConfirmDepth > 0 || nc.DAReorgWindow > 0 || nc.Snapshot.PublishInterval > 0)) {
+		fail("invalid block time %s: set %s to a duration between %s and %s", nc.BlockTime, flagBlockTime, MinBlockTime, MaxBlockTime)
+	}
+	switch nc.HeaderVerification {
+	case "", HeaderVerificationStrict, HeaderVerificationPermissive:
+	default:
+		fail("unknown header verification mode %q: set %s to %q or %q", nc.HeaderVerification, flagHeaderVerification,
+			HeaderVerificationStrict, HeaderVerificationPermissive)
+	}
+	switch nc.PanicPolicy {
+	case "", PanicPolicyRestart, PanicPolicyShutdown:
+	default:
+		fail("unknown panic policy %q: set %s to %q or %q", nc.PanicPolicy, flagPanicPolicy, PanicPolicyRestart, PanicPolicyShutdown)
+	}
+	if nc.Light {
+		if nc.Aggregator {
+			fail("aggregator mode can't be used together with light mode: unset %s or %s", flagAggregator, flagLight)
+		}
+		if nc.DASamples <= 0 {
+			fail("invalid number of DAS samples %d: set %s to a positive number", nc.DASamples, flagDASamples)
+		}
+	}
+	if nc.DevMode && nc.DAEpoch > 0 {
+		fail("dev mode can't be used with block production by DA epoch: unset %s or %s", flagDevMode, flagDAEpoch)
+	}
+	if nc.FCFSOrdering && (nc.MempoolSenderLanes || nc.MempoolFeePriority) {
+		fail("FCFS ordering can't be used with mempool sender lanes or fee priority: unset %s, or %s and %s",
+			flagFCFSOrdering, flagMempoolSenderLanes, flagMempoolFeePriority)
+	}
+	if nc.StoreCacheSize < 0 {
+		fail("invalid store cache size %d: set %s to a non-negative number", nc.StoreCacheSize, flagStoreCacheSize)
+	}
+	if nc.Archive && nc.TxIndex.RetainBlocks > 0 {
+		fail("archive mode can't be used with pruning of transaction index: unset %s or %s", flagArchive, flagTxIndexRetainBlocks)
+	}
+	if nc.Archive && nc.EventJournal.RetainBlocks > 0 {
+		fail("archive mode can't be used with pruning of event journal: unset %s or %s", flagArchive, flagEventJournalRetainBlocks)
+	}
+	if addr := nc.Replication.ListenAddress; addr != "" {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			fail("invalid replication listen address %q: %w: set %s in host:port format", addr, err, flagReplicationListenAddress)
+		}
+	}
+	if addr := nc.Diagnostics.ListenAddress; addr != "" {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			fail("invalid diagnostics listen address %q: %w: set %s in host:port format", addr, err, flagDiagnosticsListenAddress)
+		}
+	}
+	if addr := nc.Replication.Source; addr != "" {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			fail("invalid replication source %q: %w: set %s in host:port format", addr, err, flagReplicationSource)
+		}
+		if nc.Aggregator {
+			fail("aggregator mode can't be used together with replication: unset %s or %s", flagAggregator, flagReplicationSource)
+		}
+		if nc.Light {
+			fail("light mode can't be used together with replication: unset %s or %s", flagLight, flagReplicationSource)
+		}
+		if nc.Snapshot.Sync {
+			fail("state sync can't be used together with replication, as replica doesn't execute blocks: unset %s or %s",
+				flagSnapshotSync, flagReplicationSource)
+		}
+	}
+
+	switch nc.RPC.CompatVersion {
+	case "", RPCCompat034, RPCCompat037, RPCCompat038:
+	default:
+		fail("unknown RPC compatibility version %q: set %s to %q, %q or %q", nc.RPC.CompatVersion, flagRPCCompatVersion,
+			RPCCompat034, RPCCompat037, RPCCompat038)
+	}
+	switch nc.LogFormat {
+	case "", optlog.FormatPlain, optlog.FormatJSON:
+	default:
+		fail("unknown log format %q: set %s to %q or %q", nc.LogFormat, flagLogFormat, optlog.FormatPlain, optlog.FormatJSON)
+	}
+	switch nc.RPC.EventEncoding {
+	case "", EventEncodingBase64, EventEncodingRaw:
+	default:
+		fail("unknown RPC event encoding %q: set %s to %q or %q", nc.RPC.EventEncoding, flagRPCEventEncoding,
+			EventEncodingBase64, EventEncodingRaw)
+	}
+	if nc.RPC.ListenAddress != "" {
+		if err := validateRPCAddress(nc.RPC.ListenAddress); err != nil {
+			fail("invalid RPC listen address %q: %w (expected format: tcp://host:port)", nc.RPC.ListenAddress, err)
+		}
+	}
+	if nc.RPC.MaxInvalidTxsPerMinute < 0 {
+		fail("invalid max invalid transactions per minute %d: set %s to non-negative value", nc.RPC.MaxInvalidTxsPerMinute, flagRPCMaxInvalidTxs)
+	}
+	if nc.RPC.MaxPendingCommits < 0 {
+		fail("invalid max pending commits %d: set %s to non-negative value", nc.RPC.MaxPendingCommits, flagRPCMaxCommits)
+	}
+
+	return multierr.Append(errs, nc.P2P.validate())
+}
+
+// validate checks P2P configuration.
+func (c *P2PConfig) validate() error {
+	var errs error
+	fail := func(format string, args ...interface{}) {
+		errs = multierr.Append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.ListenAddress != "" {
+		if _, err := multiaddr.NewMultiaddr(c.ListenAddress); err != nil {
+			fail("invalid P2P listen address %q: %w (expected Multiaddr format, e.g. %s)", c.ListenAddress, err, DefaultListenAddress)
+		}
+	}
+	transports := make(map[string]bool)
+	for _, transport := range splitList(c.Transports) {
+		transports[transport] = true
+	}
+	if c.WSListenAddress != "" {
+		if addr, err := multiaddr.NewMultiaddr(c.WSListenAddress); err != nil {
+			fail("invalid WebSocket listen address %q: %w: set %s in Multiaddr format, e.g. /ip4/0.0.0.0/tcp/7677/ws",
+				c.WSListenAddress, err, flagP2PWSListenAddress)
+		} else if _, err := addr.ValueForProtocol(multiaddr.P_WSS); err == nil && (!transports["wss"] || c.TLSCertFile == "") {
+			fail("secure WebSocket listen address %q requires wss transport and TLS certificate: add wss to %s and set %s",
+				c.WSListenAddress, flagP2PTransports, flagP2PTLSCertFile)
+		}
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		fail("TLS certificate and key have to be set together: set %s and %s", flagP2PTLSCertFile, flagP2PTLSKeyFile)
+	}
+	for _, addr := range splitList(c.Seeds) {
+		if err := validatePeerAddress(addr); err != nil {
+			fail("invalid seed address %q: %w (expected Multiaddr format with peer ID, e.g. /ip4/1.2.3.4/tcp/7676/p2p/<ID>)", addr, err)
+		}
+	}
+	for _, addr := range splitList(c.Relays) {
+		if err := validatePeerAddress(addr); err != nil {
+			fail("invalid relay address %q: %w: set %s to Multiaddrs with peer ID, e.g. /ip4/1.2.3.4/tcp/7676/p2p/<ID>",
+				addr, err, flagP2PRelays)
+		}
+	}
+	for _, transport := range splitList(c.Transports) {
+		if transport != "tcp" && transport != "ws" && transport != "wss" {
+			fail("unsupported P2P transport %q: set %s to a comma separated list of: tcp, ws, wss", transport, flagP2PTransports)
+		}
+	}
+	if c.MaxMemory < 0 {
+		fail("invalid max memory %d: set %s to a non-negative number", c.MaxMemory, flagP2PMaxMemory)
+	} else if c.MaxMemory > 0 && (c.MaxInboundConns <= 0 || c.MaxOutboundConns <= 0 || c.MaxStreamsPerPeer <= 0) {
+		fail("memory limit requires connection and stream limits: set %s, %s and %s",
+			flagP2PMaxInboundConns, flagP2PMaxOutboundConns, flagP2PMaxStreamsPerPeer)
+	} else if c.MaxMemory > 0 && c.StreamBufferSize() < MinStreamBufferSize {
+		fail("max memory %d is too low for %d streams, every stream needs at least %d bytes: increase %s or decrease connection and stream limits",
+			c.MaxMemory, (c.MaxInboundConns+c.MaxOutboundConns)*c.MaxStreamsPerPeer, MinStreamBufferSize, flagP2PMaxMemory)
+	}
+	if c.BlockGossip && (c.BlockPartSize < 0 || c.BlockPartSize > MaxBlockPartSize) {
+		fail("invalid block part size %d: set %s to at most %d bytes", c.BlockPartSize, flagP2PBlockPartSize, MaxBlockPartSize)
+	} else if c.BlockGossip && c.MaxMessageSize > 0 && c.BlockPartSize >= c.MaxMessageSize {
+		fail("block part size %d doesn't fit in max message size %d: increase %s", c.BlockPartSize, c.MaxMessageSize, flagP2PMaxMessageSize)
+	}
+	if c.TxBatchSize < 0 {
+		fail("invalid transaction batch size %d: set %s to a non-negative number", c.TxBatchSize, flagP2PTxBatchSize)
+	}
+	if c.MaxMessageSize < 0 {
+		fail("invalid max message size %d: set %s to a non-negative number", c.MaxMessageSize, flagP2PMaxMessageSize)
+	}
+	if c.MaxMessageSize > 0 && c.TxBatchSize > 0 && c.TxBatchMaxBytes >= c.MaxMessageSize {
+		fail("transaction batch max bytes %d doesn't fit in max message size %d: increase %s", c.TxBatchMaxBytes, c.MaxMessageSize, flagP2PMaxMessageSize)
+	}
+	return errs
+}
+
+func validateRPCAddress(addr string) error {
+	parts := strings.SplitN(addr, "://", 2)
+	if len(parts) != 2 {
+		return errors.New("missing protocol")
+	}
+	if parts[0] == "unix" {
+		return nil
+	}
+	_, _, err := net.SplitHostPort(parts[1])
+	return err
+}
+
+func validatePeerAddress(addr string) error {
+	maddr, err := multiaddr.NewMultiaddr(addr)
+	if err != nil {
+		return err
+	}
+	_, err = peer.AddrInfoFromP2pAddr(maddr)
+	return err
+}
+
+// splitList splits comma separated list, ignoring empty elements.
+func splitList(list string) []string {
+	var res []string
+	for _, elem := range strings.Split(list, ",") {
+		if elem = strings.TrimSpace(elem); elem != "" {
+			res = append(res, elem)
+		}
+	}
+	return res
+}
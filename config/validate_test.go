@@ -0,0 +1,112 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/multierr"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		modify func(nc *NodeConfig)
+		// expected contains fragments of reported errors, one per error
+		expected []string
+	}{
+		{"default", func(nc *NodeConfig) {}, nil},
+		{"programmatic DA layer", func(nc *NodeConfig) { nc.DALayer = "" }, nil},
+		{"unknown DA layer", func(nc *NodeConfig) { nc.DALayer = "celestia" }, []string{"set optimint.da_layer to one of: grpc, mock"}},
+		{"zero block time of full node", func(nc *NodeConfig) { nc.BlockTime = 0 }, nil},
+		{"zero block time of aggregator", func(nc *NodeConfig) { nc.Aggregator, nc.BlockTime = true, 0 }, []string{"invalid block time 0s"}},
+		{"too long block time", func(nc *NodeConfig) { nc.BlockTime = 48 * time.Hour }, []string{"invalid block time 48h0m0s"}},
+		{"header verification", func(nc *NodeConfig) { nc.HeaderVerification = "lax" }, []string{`unknown header verification mode "lax"`}},
+		{"dev mode with DA epoch", func(nc *NodeConfig) { nc.DevMode, nc.DAEpoch = true, 2 }, []string{"dev mode can't be used with block production by DA epoch"}},
+		{"FCFS with fee priority", func(nc *NodeConfig) { nc.FCFSOrdering, nc.MempoolFeePriority = true, true }, []string{"FCFS ordering can't be used"}},
+		{"seed aggregator", func(nc *NodeConfig) { nc.P2P.SeedMode, nc.Aggregator = true, true }, []string{"aggregator mode can't be used together with seed mode"}},
+		{"light node", func(nc *NodeConfig) { nc.Light = true }, nil},
+		{"light aggregator", func(nc *NodeConfig) { nc.Light, nc.Aggregator = true, true }, []string{"aggregator mode can't be used together with light mode"}},
+		{"light seed", func(nc *NodeConfig) { nc.Light, nc.P2P.SeedMode = true, true }, []string{"light mode can't be used together with seed mode"}},
+		{"light replica", func(nc *NodeConfig) { nc.Light, nc.Replication.Source = true, "10.0.0.1:26660" }, []string{"light mode can't be used together with replication"}},
+		{"DAS samples", func(nc *NodeConfig) { nc.Light, nc.DASamples = true, 0 }, []string{"invalid number of DAS samples 0"}},
+		{"zero block time of light node", func(nc *NodeConfig) { nc.Light, nc.BlockTime = true, 0 }, []string{"invalid block time 0s"}},
+		{"RPC compat version", func(nc *NodeConfig) { nc.RPC.CompatVersion = "0.35" }, []string{`unknown RPC compatibility version "0.35"`}},
+		{"log format", func(nc *NodeConfig) { nc.LogFormat = "xml" }, []string{`unknown log format "xml"`}},
+		{"panic policy", func(nc *NodeConfig) { nc.PanicPolicy = "ignore" }, []string{`unknown panic policy "ignore"`}},
+		{"RPC event encoding", func(nc *NodeConfig) { nc.RPC.EventEncoding = "hex" }, []string{`unknown RPC event encoding "hex"`}},
+		{"RPC listen address", func(nc *NodeConfig) { nc.RPC.ListenAddress = "tcp://127.0.0.1:26657" }, nil},
+		{"invalid RPC listen address", func(nc *NodeConfig) { nc.RPC.ListenAddress = "127.0.0.1:26657" }, []string{"invalid RPC listen address"}},
+		{"negative RPC invalid txs limit", func(nc *NodeConfig) { nc.RPC.MaxInvalidTxsPerMinute = -1 }, []string{"invalid max invalid transactions per minute -1"}},
+		{"negative RPC pending commits limit", func(nc *NodeConfig) { nc.RPC.MaxPendingCommits = -1 }, []string{"invalid max pending commits -1"}},
+		{"invalid P2P listen address", func(nc *NodeConfig) { nc.P2P.ListenAddress = "tcp://0.0.0.0:26656" }, []string{"invalid P2P listen address"}},
+		{"seed without peer ID", func(nc *NodeConfig) { nc.P2P.Seeds = "/ip4/1.2.3.4/tcp/7676" }, []string{"invalid seed address"}},
+		{"transport", func(nc *NodeConfig) { nc.P2P.Transports = "tcp,sctp" }, []string{`unsupported P2P transport "sctp"`}},
+		{"wss without certificate", func(nc *NodeConfig) { nc.P2P.Transports, nc.P2P.WSListenAddress = "tcp,wss", "/ip4/0.0.0.0/tcp/7677/wss" }, []string{"requires wss transport and TLS certificate"}},
+		{"QUIC transport", func(nc *NodeConfig) { nc.P2P.Transports = "tcp,quic" }, []string{`unsupported P2P transport "quic"`}},
+		{"TLS key without certificate", func(nc *NodeConfig) { nc.P2P.TLSKeyFile = "key.pem" }, []string{"TLS certificate and key have to be set together"}},
+		{"block part size", func(nc *NodeConfig) { nc.P2P.BlockGossip, nc.P2P.BlockPartSize = true, 1<<20 }, []string{"invalid block part size"}},
+		{"memory limit without stream limit", func(nc *NodeConfig) { nc.P2P.MaxMemory, nc.P2P.MaxStreamsPerPeer = 1<<30, 0 }, []string{"memory limit requires connection and stream limits"}},
+		{"memory limit too low", func(nc *NodeConfig) { nc.P2P.MaxMemory = 1 << 20 }, []string{"max memory 1048576 is too low for 12288 streams"}},
+		{"max message size", func(nc *NodeConfig) { nc.P2P.MaxMessageSize = -1 }, []string{"invalid max message size -1"}},
+		{"block part exceeds max message size", func(nc *NodeConfig) { nc.P2P.BlockGossip, nc.P2P.MaxMessageSize = true, 1024 }, []string{"block part size 65536 doesn't fit"}},
+		{"tx batch exceeds max message size", func(nc *NodeConfig) { nc.P2P.TxBatchSize, nc.P2P.MaxMessageSize = 10, 1024 }, []string{"transaction batch max bytes 65536 doesn't fit"}},
+		{"archive", func(nc *NodeConfig) { nc.Archive = true }, nil},
+		{"archive with pruning", func(nc *NodeConfig) { nc.Archive, nc.TxIndex.RetainBlocks = true, 100 }, []string{"archive mode can't be used with pruning"}},
+		{"archive with journal pruning", func(nc *NodeConfig) { nc.Archive, nc.EventJournal.RetainBlocks = true, 100 }, []string{"pruning of event journal"}},
+		{"replication", func(nc *NodeConfig) { nc.Replication.ListenAddress = ":26660" }, nil},
+		{"replica aggregator", func(nc *NodeConfig) { nc.Replication.Source, nc.Aggregator = "10.0.0.1:26660", true }, []string{"aggregator mode can't be used together with replication"}},
+		{"replication source", func(nc *NodeConfig) { nc.Replication.Source = "10.0.0.1" }, []string{"invalid replication source"}},
+		{"snapshot publishing without block time", func(nc *NodeConfig) { nc.Snapshot.PublishInterval, nc.BlockTime = 100, 0 }, []string{"invalid block time 0s"}},
+		{"diagnostics", func(nc *NodeConfig) { nc.Diagnostics.ListenAddress = "localhost:6060" }, nil},
+		{"diagnostics listen address", func(nc *NodeConfig) { nc.Diagnostics.ListenAddress = "6060" }, []string{"invalid diagnostics listen address"}},
+		{"replica state sync", func(nc *NodeConfig) { nc.Replication.Source, nc.Snapshot.Sync = "10.0.0.1:26660", true }, []string{"state sync can't be used together with replication"}},
+		{"settlement layer", func(nc *NodeConfig) { nc.Settlement.Layer = "mock" }, nil},
+		{"unknown settlement layer", func(nc *NodeConfig) { nc.Settlement.Layer = "ethereum" }, []string{"set optimint.settlement_layer to one of: grpc, mock"}},
+		{"settlement epoch", func(nc *NodeConfig) { nc.Settlement.Layer, nc.Settlement.Epoch = "mock", 0 }, []string{"invalid settlement epoch 0"}},
+		{"multiple errors", func(nc *NodeConfig) {
+			nc.DALayer = "celestia"
+			nc.Aggregator, nc.BlockTime = true, -time.Second
+			nc.P2P.Relays = "foo"
+		}, []string{"unknown DA layer", "invalid block time", "invalid relay address"}},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			nc := DefaultNodeConfig
+			c.modify(&nc)
+			err := nc.Validate()
+			if len(c.expected) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			errs := multierr.Errors(err)
+			if assert.Len(t, errs, len(c.expected)) {
+				for i := range errs {
+					assert.Contains(t, errs[i].Error(), c.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidNamespaceID(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	for _, nsID := range []string{"0102", "010203040506070809", "not hex"} {
+		v := viper.New()
+		v.Set(flagNamespaceID, nsID)
+		var nc NodeConfig
+		err := nc.GetViperConfig(v)
+		if assert.Error(err) {
+			assert.True(strings.Contains(err.Error(), "set "+flagNamespaceID+" to 8 bytes in hex"), err.Error())
+		}
+	}
+}
@@ -14,9 +14,14 @@ func GetNodeConfig(nodeConf *config.NodeConfig, tmConf *tmcfg.Config) {
 	if tmConf != nil {
 		nodeConf.RootDir = tmConf.RootDir
 		nodeConf.DBPath = tmConf.DBPath
+		if tmConf.Genesis != "" {
+			nodeConf.GenesisFile = tmConf.GenesisFile()
+		}
+		nodeConf.LogLevel = tmConf.LogLevel
 		if tmConf.P2P != nil {
 			nodeConf.P2P.ListenAddress = tmConf.P2P.ListenAddress
 			nodeConf.P2P.Seeds = tmConf.P2P.Seeds
+			nodeConf.P2P.SeedMode = tmConf.P2P.SeedMode
 		}
 		if tmConf.RPC != nil {
 			nodeConf.RPC.ListenAddress = tmConf.RPC.ListenAddress
@@ -26,6 +31,16 @@ func GetNodeConfig(nodeConf *config.NodeConfig, tmConf *tmcfg.Config) {
 			nodeConf.RPC.MaxOpenConnections = tmConf.RPC.MaxOpenConnections
 			nodeConf.RPC.TLSCertFile = tmConf.RPC.TLSCertFile
 			nodeConf.RPC.TLSKeyFile = tmConf.RPC.TLSKeyFile
+			// pprof listen address is used only if diagnostics endpoint is not configured explicitly
+			if nodeConf.Diagnostics.ListenAddress == "" {
+				nodeConf.Diagnostics.ListenAddress = tmConf.RPC.PprofListenAddress
+			}
+		}
+		if tmConf.Instrumentation != nil {
+			nodeConf.Instrumentation.Prometheus = tmConf.Instrumentation.Prometheus
+			nodeConf.Instrumentation.PrometheusListenAddr = tmConf.Instrumentation.PrometheusListenAddr
+			nodeConf.Instrumentation.MaxOpenConnections = tmConf.Instrumentation.MaxOpenConnections
+			nodeConf.Instrumentation.Namespace = tmConf.Instrumentation.Namespace
 		}
 	}
 }
@@ -19,9 +19,17 @@ func TestGetNodeConfig(t *testing.T) {
 	}{
 		{"empty", nil, config.NodeConfig{}},
 		{"Seeds", &tmcfg.Config{P2P: &tmcfg.P2PConfig{Seeds: "seeds"}}, config.NodeConfig{P2P: config.P2PConfig{Seeds: "seeds"}}},
+		{"SeedMode", &tmcfg.Config{P2P: &tmcfg.P2PConfig{SeedMode: true}}, config.NodeConfig{P2P: config.P2PConfig{SeedMode: true}}},
 		{"ListenAddress", &tmcfg.Config{P2P: &tmcfg.P2PConfig{ListenAddress: "127.0.0.1:7676"}}, config.NodeConfig{P2P: config.P2PConfig{ListenAddress: "127.0.0.1:7676"}}},
 		{"RootDir", &tmcfg.Config{BaseConfig: tmcfg.BaseConfig{RootDir: "~/root"}}, config.NodeConfig{RootDir: "~/root"}},
 		{"DBPath", &tmcfg.Config{BaseConfig: tmcfg.BaseConfig{DBPath: "./database"}}, config.NodeConfig{DBPath: "./database"}},
+		{"GenesisFile", &tmcfg.Config{BaseConfig: tmcfg.BaseConfig{RootDir: "/root", Genesis: "config/genesis.json"}},
+			config.NodeConfig{RootDir: "/root", GenesisFile: "/root/config/genesis.json"}},
+		{"Prometheus", &tmcfg.Config{Instrumentation: &tmcfg.InstrumentationConfig{Prometheus: true, PrometheusListenAddr: ":26660", Namespace: "optimint"}},
+			config.NodeConfig{Instrumentation: config.InstrumentationConfig{Prometheus: true, PrometheusListenAddr: ":26660", Namespace: "optimint"}}},
+		{"PprofListenAddress", &tmcfg.Config{RPC: &tmcfg.RPCConfig{PprofListenAddress: "localhost:6060"}},
+			config.NodeConfig{Diagnostics: config.DiagnosticsConfig{ListenAddress: "localhost:6060"}}},
+		{"LogLevel", &tmcfg.Config{BaseConfig: tmcfg.BaseConfig{LogLevel: "p2p:debug,*:info"}}, config.NodeConfig{LogLevel: "p2p:debug,*:info"}},
 	}
 
 	for _, c := range cases {
@@ -0,0 +1,185 @@
+package account
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/p2p"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// ErrLowBalance is returned when balance of the account is below configured minimum.
+var ErrLowBalance = errors.New("DA layer account balance is too low")
+
+// Account manages the key, sequence numbers and balance of DA layer account paying fees for block submissions.
+//
+// Sequence number is tracked locally (incremented with every signed transaction) and re-synchronized with
+// DA layer on demand. Balance is checked periodically; when it drops below configured minimum, error is logged,
+// LowBalance metric is set and EventDALowBalance is published.
+type Account struct {
+	key        tmcrypto.PrivKey
+	client     da.FeePayer
+	minBalance uint64
+
+	mtx      sync.Mutex
+	sequence uint64
+	balance  uint64
+	low      bool
+
+	metrics  *Metrics
+	eventBus *tmtypes.EventBus
+	logger   log.Logger
+}
+
+var _ da.Signer = &Account{}
+
+// NewAccount creates Account using given key, querying account state using DA layer client.
+func NewAccount(key tmcrypto.PrivKey, client da.FeePayer, minBalance uint64, logger log.Logger) *Account {
+	return &Account{
+		key:        key,
+		client:     client,
+		minBalance: minBalance,
+		metrics:    NopMetrics(),
+		logger:     logger,
+	}
+}
+
+// LoadKey reads account key from file in Tendermint node key format.
+func LoadKey(path string) (tmcrypto.PrivKey, error) {
+	nodeKey, err := p2p.LoadNodeKey(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load DA layer account key: %w", err)
+	}
+	return nodeKey.PrivKey, nil
+}
+
+// SetMetrics sets metrics used to report account balance.
+func (a *Account) SetMetrics(metrics *Metrics) {
+	a.metrics = metrics
+}
+
+// SetEventBus sets event bus used to publish low balance alerts.
+func (a *Account) SetEventBus(eventBus *tmtypes.EventBus) {
+	a.eventBus = eventBus
+}
+
+// Address returns address of the account.
+func (a *Account) Address() []byte {
+	return a.key.PubKey().Address()
+}
+
+// Sign signs data together with next sequence number, and increments the sequence number.
+func (a *Account) Sign(data []byte) ([]byte, uint64, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	sequence := a.sequence
+	sig, err := a.key.Sign(signBytes(data, sequence))
+	if err != nil {
+		return nil, 0, err
+	}
+	a.sequence++
+	return sig, sequence, nil
+}
+
+// Balance returns last known balance of the account.
+func (a *Account) Balance() uint64 {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.balance
+}
+
+// Sequence returns sequence number that will be used for next transaction.
+func (a *Account) Sequence() uint64 {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.sequence
+}
+
+// CheckBalance returns ErrLowBalance if last known balance is below configured minimum.
+func (a *Account) CheckBalance() error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if a.low {
+		return fmt.Errorf("%w: balance %d, minimum %d", ErrLowBalance, a.balance, a.minBalance)
+	}
+	return nil
+}
+
+// Sync reads sequence number and balance of the account from DA layer.
+func (a *Account) Sync() error {
+	res := a.client.QueryAccount(a.Address())
+	if res.Code != da.StatusSuccess {
+		return fmt.Errorf("failed to query DA layer account: %s", res.Message)
+	}
+
+	a.mtx.Lock()
+	a.sequence = res.Sequence
+	a.balance = res.Balance
+	wasLow := a.low
+	a.low = res.Balance < a.minBalance
+	low := a.low
+	a.mtx.Unlock()
+
+	a.metrics.Balance.Set(float64(res.Balance))
+	if low {
+		a.metrics.LowBalance.Set(1)
+	} else {
+		a.metrics.LowBalance.Set(0)
+	}
+	if low && !wasLow {
+		a.logger.Error("DA layer account balance is too low, top up the account to keep submitting blocks",
+			"address", fmt.Sprintf("%X", a.Address()), "balance", res.Balance, "minimum", a.minBalance)
+		a.publishLowBalanceEvent(res.Balance)
+	}
+	if !low && wasLow {
+		a.logger.Info("DA layer account balance restored", "balance", res.Balance)
+	}
+	return nil
+}
+
+// MonitorLoop periodically synchronizes account state with DA layer, until context is canceled.
+func (a *Account) MonitorLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := a.Sync(); err != nil {
+				a.logger.Error("failed to check DA layer account", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (a *Account) publishLowBalanceEvent(balance uint64) {
+	if a.eventBus == nil {
+		return
+	}
+	err := a.eventBus.Publish(types.EventDALowBalance, types.EventDataDALowBalance{
+		Address:    a.Address(),
+		Balance:    balance,
+		MinBalance: a.minBalance,
+	})
+	if err != nil {
+		a.logger.Error("failed to publish low balance event", "error", err)
+	}
+}
+
+// signBytes returns bytes signed by the account - data followed by big-endian encoded sequence number.
+func signBytes(data []byte, sequence uint64) []byte {
+	b := make([]byte, len(data)+8)
+	copy(b, data)
+	binary.BigEndian.PutUint64(b[len(data):], sequence)
+	return b
+}
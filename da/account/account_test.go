@@ -0,0 +1,76 @@
+package account
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/da"
+	mockda "github.com/celestiaorg/optimint/da/mock"
+	"github.com/celestiaorg/optimint/log/test"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestAccount(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	logger := &test.TestLogger{T: t}
+	dalc := &mockda.MockDataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(nil, store.NewDefaultInMemoryKVStore(), logger))
+	require.NoError(dalc.Start())
+
+	eventBus := tmtypes.NewEventBus()
+	require.NoError(eventBus.Start())
+	defer func() {
+		require.NoError(eventBus.Stop())
+	}()
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryDALowBalance)
+	require.NoError(err)
+
+	acc := NewAccount(secp256k1.GenPrivKey(), dalc, 2, logger)
+	acc.SetEventBus(eventBus)
+	dalc.SetSigner(acc)
+	dalc.Fund(acc.Address(), 3)
+	require.NoError(acc.Sync())
+	assert.Equal(uint64(3), acc.Balance())
+	assert.NoError(acc.CheckBalance())
+
+	submit := func(height uint64) da.ResultSubmitBlock {
+		return dalc.SubmitBlock(&types.Block{Header: types.Header{Height: height}})
+	}
+	for h := uint64(1); h <= 3; h++ {
+		res := submit(h)
+		assert.Equal(da.StatusSuccess, res.Code)
+		assert.EqualValues(mockda.SubmissionFee, res.Fee)
+	}
+	assert.Equal(uint64(3), acc.Sequence())
+
+	// insufficient funds
+	assert.Equal(da.StatusError, submit(4).Code)
+	require.NoError(acc.Sync())
+	assert.Equal(uint64(3), acc.Sequence())
+	assert.Equal(uint64(0), acc.Balance())
+	assert.ErrorIs(acc.CheckBalance(), ErrLowBalance)
+
+	select {
+	case msg := <-sub.Out():
+		data := msg.Data().(types.EventDataDALowBalance)
+		assert.Equal(acc.Address(), []byte(data.Address))
+		assert.Equal(uint64(0), data.Balance)
+		assert.Equal(uint64(2), data.MinBalance)
+	case <-time.After(time.Second):
+		t.Fatal("low balance event not published")
+	}
+
+	dalc.Fund(acc.Address(), 10)
+	require.NoError(acc.Sync())
+	assert.NoError(acc.CheckBalance())
+	assert.Equal(da.StatusSuccess, submit(4).Code)
+}
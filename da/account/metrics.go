@@ -0,0 +1,55 @@
+package account
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	optmetrics "github.com/celestiaorg/optimint/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "da_account"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Balance of DA layer account paying fees for block submissions.
+	Balance metrics.Gauge
+	// LowBalance is 1 if account balance is below configured minimum, 0 otherwise.
+	LowBalance metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library, registered in given registerer.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(registerer stdprometheus.Registerer, namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		Balance: optmetrics.NewGaugeFrom(registerer, stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "balance",
+			Help:      "Balance of DA layer account paying fees for block submissions.",
+		}, labels).With(labelsAndValues...),
+		LowBalance: optmetrics.NewGaugeFrom(registerer, stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "low_balance",
+			Help:      "Whether DA layer account balance is below configured minimum (1 if it is, 0 otherwise).",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Balance:    discard.NewGauge(),
+		LowBalance: discard.NewGauge(),
+	}
+}
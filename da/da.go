@@ -25,14 +25,17 @@ type DAResult struct {
 	Code StatusCode
 	// Message may contain DA layer specific information (like DA block height/hash, detailed error message, etc)
 	Message string
+	// DAHeight informs about a height on Data Availability Layer for given result.
+	DAHeight uint64
 }
 
 // ResultSubmitBlock contains information returned from DA layer after block submission.
 type ResultSubmitBlock struct {
 	DAResult
-	// Not sure if this needs to be bubbled up to other
-	// parts of Optimint.
-	// Hash hash.Hash
+	// TxHash is the hash of DA layer transaction that included the block (if available).
+	TxHash []byte
+	// Fee is the fee paid for the submission, in DA layer units (0 if there was no fee or it's unknown).
+	Fee uint64
 }
 
 // ResultCheckBlock contains information about block availability, returned from DA layer client.
@@ -50,6 +53,43 @@ type ResultRetrieveBlock struct {
 	Block *types.Block
 }
 
+// ResultRetrieveForcedTxs contains transactions posted directly to DA layer, returned from DA layer client.
+type ResultRetrieveForcedTxs struct {
+	DAResult
+	// Txs are transactions posted to forced inclusion namespace at given DA height, in order of inclusion.
+	Txs []types.Tx
+}
+
+// ResultQueryAccount contains state of DA layer account.
+type ResultQueryAccount struct {
+	DAResult
+	// Sequence is the sequence number expected in next transaction signed by the account.
+	Sequence uint64
+	// Balance is the amount of funds available for paying fees.
+	Balance uint64
+}
+
+// ResultBlockHash contains hash of DA layer block, returned from DA layer client.
+type ResultBlockHash struct {
+	DAResult
+	// Hash is the hash of DA layer block at given height. It's empty if there is no block at given height.
+	Hash []byte
+}
+
+// ResultListSnapshots contains snapshots available in DA layer, returned from DA layer client.
+type ResultListSnapshots struct {
+	DAResult
+	// Snapshots are published snapshots (with all chunks submitted), in any order.
+	Snapshots []*types.Snapshot
+}
+
+// ResultRetrieveSnapshotChunk contains chunk of snapshot, returned from DA layer client.
+type ResultRetrieveSnapshotChunk struct {
+	DAResult
+	// Chunk is the content of snapshot chunk. If Code is not equal to StatusSuccess, it has to be nil.
+	Chunk []byte
+}
+
 // DataAvailabilityLayerClient defines generic interface for DA layer block submission.
 // It also contains life-cycle methods.
 type DataAvailabilityLayerClient interface {
@@ -74,3 +114,89 @@ type BlockRetriever interface {
 	// RetrieveBlock returns block at given height from data availability layer.
 	RetrieveBlock(height uint64) ResultRetrieveBlock
 }
+
+// AvailabilitySampler is additional interface that can be implemented by Data Availability Layer Client that
+// supports data availability sampling. It's required by light nodes.
+type AvailabilitySampler interface {
+	// SampleAvailability downloads given number of random shares of data committed to by the header (together
+	// with inclusion proofs) and verifies them. DataAvailable is set if all samples were verified.
+	SampleAvailability(header *types.Header, samples int) ResultCheckBlock
+}
+
+// ForcedTxRetriever is additional interface that can be implemented by Data Availability Layer Client that is able to
+// retrieve transactions posted by users directly to DA layer. This gives the ability to force inclusion of transactions
+// in blocks, even if they are censored by the sequencer.
+type ForcedTxRetriever interface {
+	// RetrieveForcedTxs returns transactions posted to given namespace at given DA layer height.
+	RetrieveForcedTxs(namespaceID [8]byte, dataLayerHeight uint64) ResultRetrieveForcedTxs
+}
+
+// NamespaceScoper is additional interface that can be implemented by Data Availability Layer Client that is able to
+// serve multiple chains using single connection to DA layer.
+type NamespaceScoper interface {
+	// WithNamespace returns client scoped to given namespace, sharing connection with this client.
+	// Scoped client doesn't require initialization and its life-cycle is bound to this client.
+	WithNamespace(namespaceID [8]byte) DataAvailabilityLayerClient
+}
+
+// Signer signs DA layer transactions on behalf of the account paying fees.
+type Signer interface {
+	// Address returns address of the account.
+	Address() []byte
+	// Sign signs data with account key. Signature is returned together with sequence number assigned to transaction.
+	Sign(data []byte) (signature []byte, sequence uint64, err error)
+}
+
+// FeePayer is additional interface that can be implemented by Data Availability Layer Client that pays fees for
+// submitted blocks using account managed by the node.
+type FeePayer interface {
+	// SetSigner sets signer of DA layer transactions. It has to be called before Start.
+	SetSigner(signer Signer)
+	// QueryAccount returns state of account with given address.
+	QueryAccount(address []byte) ResultQueryAccount
+}
+
+// HeightReader is additional interface that can be implemented by Data Availability Layer Client that is able to
+// report current height of DA layer.
+type HeightReader interface {
+	// LatestHeight returns height of the latest DA layer block (in DAHeight field of result).
+	LatestHeight() DAResult
+}
+
+// HashReader is additional interface that can be implemented by Data Availability Layer Client that is able to
+// report hashes of DA layer blocks. It's used to detect DA layer reorganizations.
+type HashReader interface {
+	// BlockHash returns hash of DA layer block at given height.
+	BlockHash(daHeight uint64) ResultBlockHash
+}
+
+// BlobSizeLimiter is additional interface that can be implemented by Data Availability Layer Client that limits
+// the size of submitted blobs (serialized blocks).
+type BlobSizeLimiter interface {
+	// MaxBlobSize returns maximum size of a single blob in bytes (0 - no limit).
+	MaxBlobSize() uint64
+}
+
+// RetrieveSizeLimiter is additional interface that can be implemented by Data Availability Layer Client that is able
+// to reject oversized blobs retrieved from DA layer before decoding them, to protect against huge allocations caused
+// by malicious writers to the namespace.
+type RetrieveSizeLimiter interface {
+	// SetMaxRetrieveSize sets maximum size of a single retrieved blob (block or forced inclusion transaction) in bytes
+	// (0 - no limit). Retrieval of oversized blocks fails; oversized forced inclusion transactions are skipped.
+	// It has to be called before Start.
+	SetMaxRetrieveSize(size uint64)
+}
+
+// SnapshotStore is additional interface that can be implemented by Data Availability Layer Client (or other storage,
+// e.g. object store) that is able to store application state snapshots. It allows nodes to state sync without
+// snapshot-serving peers. Snapshots can be listed and retrieved before Start is called.
+type SnapshotStore interface {
+	// SubmitSnapshotChunk stores chunk of snapshot at given height, in given format.
+	SubmitSnapshotChunk(height uint64, format uint32, index uint32, chunk []byte) DAResult
+	// SubmitSnapshot publishes snapshot. It's called after all chunks of the snapshot are submitted.
+	SubmitSnapshot(snapshot *types.Snapshot) DAResult
+	// ListSnapshots returns published snapshots.
+	ListSnapshots() ResultListSnapshots
+	// RetrieveSnapshotChunk returns chunk of snapshot at given height, in given format.
+	RetrieveSnapshotChunk(height uint64, format uint32, index uint32) ResultRetrieveSnapshotChunk
+}
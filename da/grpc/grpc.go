@@ -2,11 +2,13 @@ package grpc
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"strconv"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/celestiaorg/optimint/da"
 	"github.com/celestiaorg/optimint/log"
@@ -21,9 +23,24 @@ type DataAvailabilityLayerClient struct {
 	conn   *grpc.ClientConn
 	client dalc.DALCServiceClient
 
+	// parent owns the connection shared by clients scoped to namespaces (nil for unscoped client)
+	parent *DataAvailabilityLayerClient
+	// namespaceID is hex encoded namespace ID sent with every request of scoped client
+	namespaceID string
+
+	// maxRetrieveSize limits size of retrieved blocks (0 - gRPC default limit)
+	maxRetrieveSize uint64
+
 	logger log.Logger
 }
 
+// retrieveResponseOverhead is the space reserved for fields of RetrieveBlock response other than the block.
+const retrieveResponseOverhead = 1024
+
+// NamespaceMetadataKey is the gRPC metadata key of hex encoded namespace ID, sent by clients scoped to namespace
+// (see WithNamespace). Requests without namespace ID should be handled in the default namespace of the server.
+const NamespaceMetadataKey = "optimint-namespace-id"
+
 type Config struct {
 	// TODO(tzdybal): add more options!
 	Host string `json:"host"`
@@ -37,6 +54,8 @@ var DefaultConfig = Config{
 
 var _ da.DataAvailabilityLayerClient = &DataAvailabilityLayerClient{}
 var _ da.BlockRetriever = &DataAvailabilityLayerClient{}
+var _ da.RetrieveSizeLimiter = &DataAvailabilityLayerClient{}
+var _ da.NamespaceScoper = &DataAvailabilityLayerClient{}
 
 func (d *DataAvailabilityLayerClient) Init(config []byte, _ store.KVStore, logger log.Logger) error {
 	d.logger = logger
@@ -48,6 +67,10 @@ func (d *DataAvailabilityLayerClient) Init(config []byte, _ store.KVStore, logge
 }
 
 func (d *DataAvailabilityLayerClient) Start() error {
+	if d.parent != nil {
+		// connection is managed by parent client
+		return nil
+	}
 	d.logger.Info("starting GRPC DALC", "host", d.config.Host, "port", d.config.Port)
 	var err error
 	var opts []grpc.DialOption
@@ -63,25 +86,70 @@ func (d *DataAvailabilityLayerClient) Start() error {
 	return nil
 }
 
+// SetMaxRetrieveSize limits size of blocks retrieved from DA layer.
+func (d *DataAvailabilityLayerClient) SetMaxRetrieveSize(size uint64) {
+	d.maxRetrieveSize = size
+}
+
+// WithNamespace returns client scoped to given namespace, sharing gRPC connection with this client.
+// Namespace ID is sent to the server as gRPC metadata (see NamespaceMetadataKey).
+func (d *DataAvailabilityLayerClient) WithNamespace(namespaceID [8]byte) da.DataAvailabilityLayerClient {
+	parent := d
+	if d.parent != nil {
+		parent = d.parent
+	}
+	return &DataAvailabilityLayerClient{
+		config:          d.config,
+		parent:          parent,
+		namespaceID:     hex.EncodeToString(namespaceID[:]),
+		maxRetrieveSize: d.maxRetrieveSize,
+		logger:          d.logger,
+	}
+}
+
+// serviceClient returns gRPC client of the (possibly shared) connection.
+func (d *DataAvailabilityLayerClient) serviceClient() dalc.DALCServiceClient {
+	if d.parent != nil {
+		return d.parent.client
+	}
+	return d.client
+}
+
+// context returns request context with namespace ID of scoped client.
+func (d *DataAvailabilityLayerClient) context() context.Context {
+	if d.namespaceID == "" {
+		return context.TODO()
+	}
+	return metadata.AppendToOutgoingContext(context.TODO(), NamespaceMetadataKey, d.namespaceID)
+}
+
 func (d *DataAvailabilityLayerClient) Stop() error {
+	if d.parent != nil {
+		return nil
+	}
 	d.logger.Info("stopoing GRPC DALC")
 	return d.conn.Close()
 }
 
 func (d *DataAvailabilityLayerClient) SubmitBlock(block *types.Block) da.ResultSubmitBlock {
-	resp, err := d.client.SubmitBlock(context.TODO(), &dalc.SubmitBlockRequest{Block: block.ToProto()})
+	resp, err := d.serviceClient().SubmitBlock(d.context(), &dalc.SubmitBlockRequest{Block: block.ToProto()})
 	if err != nil {
 		return da.ResultSubmitBlock{
 			DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()},
 		}
 	}
 	return da.ResultSubmitBlock{
-		DAResult: da.DAResult{Code: da.StatusCode(resp.Result.Code), Message: resp.Result.Message},
+		DAResult: da.DAResult{
+			Code:     da.StatusCode(resp.Result.Code),
+			Message:  resp.Result.Message,
+			DAHeight: resp.Result.DataLayerHeight,
+		},
+		TxHash: resp.TxHash,
 	}
 }
 
 func (d *DataAvailabilityLayerClient) CheckBlockAvailability(header *types.Header) da.ResultCheckBlock {
-	resp, err := d.client.CheckBlockAvailability(context.TODO(), &dalc.CheckBlockAvailabilityRequest{Header: header.ToProto()})
+	resp, err := d.serviceClient().CheckBlockAvailability(d.context(), &dalc.CheckBlockAvailabilityRequest{Header: header.ToProto()})
 	if err != nil {
 		return da.ResultCheckBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
 	}
@@ -92,7 +160,12 @@ func (d *DataAvailabilityLayerClient) CheckBlockAvailability(header *types.Heade
 }
 
 func (d *DataAvailabilityLayerClient) RetrieveBlock(height uint64) da.ResultRetrieveBlock {
-	resp, err := d.client.RetrieveBlock(context.TODO(), &dalc.RetrieveBlockRequest{Height: height})
+	var opts []grpc.CallOption
+	if d.maxRetrieveSize > 0 {
+		// oversized responses are rejected by gRPC before decoding
+		opts = append(opts, grpc.MaxCallRecvMsgSize(int(d.maxRetrieveSize)+retrieveResponseOverhead))
+	}
+	resp, err := d.serviceClient().RetrieveBlock(d.context(), &dalc.RetrieveBlockRequest{Height: height}, opts...)
 	if err != nil {
 		return da.ResultRetrieveBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
 	}
@@ -103,7 +176,11 @@ func (d *DataAvailabilityLayerClient) RetrieveBlock(height uint64) da.ResultRetr
 		return da.ResultRetrieveBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
 	}
 	return da.ResultRetrieveBlock{
-		DAResult: da.DAResult{Code: da.StatusCode(resp.Result.Code), Message: resp.Result.Message},
-		Block:    &b,
+		DAResult: da.DAResult{
+			Code:     da.StatusCode(resp.Result.Code),
+			Message:  resp.Result.Message,
+			DAHeight: resp.Result.DataLayerHeight,
+		},
+		Block: &b,
 	}
 }
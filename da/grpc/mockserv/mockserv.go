@@ -2,6 +2,8 @@ package mockserv
 
 import (
 	"context"
+	"encoding/hex"
+	"fmt"
 	"os"
 
 	grpcda "github.com/celestiaorg/optimint/da/grpc"
@@ -11,8 +13,14 @@ import (
 	"github.com/celestiaorg/optimint/types/pb/dalc"
 	tmlog "github.com/tendermint/tendermint/libs/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
 )
 
+// GetServer returns gRPC server of mock DA layer. Standard health check and reflection services are registered, so
+// the server works with load balancers and tools like grpcurl.
 func GetServer(kv store.KVStore, conf grpcda.Config) *grpc.Server {
 	logger := tmlog.NewTMLogger(os.Stdout)
 
@@ -24,6 +32,14 @@ func GetServer(kv store.KVStore, conf grpcda.Config) *grpc.Server {
 		panic(err)
 	}
 	dalc.RegisterDALCServiceServer(srv, mockImpl)
+
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	for name := range srv.GetServiceInfo() {
+		healthSrv.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(srv, healthSrv)
+	reflection.Register(srv)
 	return srv
 }
 
@@ -31,28 +47,54 @@ type mockImpl struct {
 	mock mock.MockDataAvailabilityLayerClient
 }
 
-func (m *mockImpl) SubmitBlock(_ context.Context, request *dalc.SubmitBlockRequest) (*dalc.SubmitBlockResponse, error) {
+// client returns mock DALC scoped to namespace of the request (see grpcda.NamespaceMetadataKey).
+func (m *mockImpl) client(ctx context.Context) (*mock.MockDataAvailabilityLayerClient, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(grpcda.NamespaceMetadataKey)
+	if len(values) == 0 {
+		return &m.mock, nil
+	}
+	var namespaceID [8]byte
+	bytes, err := hex.DecodeString(values[0])
+	if err != nil || len(bytes) != len(namespaceID) {
+		return nil, fmt.Errorf("invalid namespace ID %q", values[0])
+	}
+	copy(namespaceID[:], bytes)
+	return m.mock.WithNamespace(namespaceID).(*mock.MockDataAvailabilityLayerClient), nil
+}
+
+func (m *mockImpl) SubmitBlock(ctx context.Context, request *dalc.SubmitBlockRequest) (*dalc.SubmitBlockResponse, error) {
+	client, err := m.client(ctx)
+	if err != nil {
+		return nil, err
+	}
 	var b types.Block
-	err := b.FromProto(request.Block)
+	err = b.FromProto(request.Block)
 	if err != nil {
 		return nil, err
 	}
-	resp := m.mock.SubmitBlock(&b)
+	resp := client.SubmitBlock(&b)
 	return &dalc.SubmitBlockResponse{
 		Result: &dalc.DAResponse{
-			Code:    dalc.StatusCode(resp.Code),
-			Message: resp.Message,
+			Code:            dalc.StatusCode(resp.Code),
+			Message:         resp.Message,
+			DataLayerHeight: resp.DAHeight,
 		},
+		TxHash: resp.TxHash,
 	}, nil
 }
 
-func (m *mockImpl) CheckBlockAvailability(_ context.Context, request *dalc.CheckBlockAvailabilityRequest) (*dalc.CheckBlockAvailabilityResponse, error) {
+func (m *mockImpl) CheckBlockAvailability(ctx context.Context, request *dalc.CheckBlockAvailabilityRequest) (*dalc.CheckBlockAvailabilityResponse, error) {
+	client, err := m.client(ctx)
+	if err != nil {
+		return nil, err
+	}
 	var h types.Header
-	err := h.FromProto(request.Header)
+	err = h.FromProto(request.Header)
 	if err != nil {
 		return nil, err
 	}
-	resp := m.mock.CheckBlockAvailability(&h)
+	resp := client.CheckBlockAvailability(&h)
 	return &dalc.CheckBlockAvailabilityResponse{
 		Result: &dalc.DAResponse{
 			Code:    dalc.StatusCode(resp.Code),
@@ -62,12 +104,17 @@ func (m *mockImpl) CheckBlockAvailability(_ context.Context, request *dalc.Check
 	}, nil
 }
 
-func (m *mockImpl) RetrieveBlock(context context.Context, request *dalc.RetrieveBlockRequest) (*dalc.RetrieveBlockResponse, error) {
-	resp := m.mock.RetrieveBlock(request.Height)
+func (m *mockImpl) RetrieveBlock(ctx context.Context, request *dalc.RetrieveBlockRequest) (*dalc.RetrieveBlockResponse, error) {
+	client, err := m.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := client.RetrieveBlock(request.Height)
 	return &dalc.RetrieveBlockResponse{
 		Result: &dalc.DAResponse{
-			Code:    dalc.StatusCode(resp.Code),
-			Message: resp.Message,
+			Code:            dalc.StatusCode(resp.Code),
+			Message:         resp.Message,
+			DataLayerHeight: resp.DAHeight,
 		},
 		Block: resp.Block.ToProto(),
 	}, nil
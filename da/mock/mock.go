@@ -1,8 +1,14 @@
 package mock
 
 import (
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/celestiaorg/optimint/da"
 	"github.com/celestiaorg/optimint/log"
@@ -10,35 +16,241 @@ import (
 	"github.com/celestiaorg/optimint/types"
 )
 
+// SubmissionFee is the fee charged for every block submission signed by DA layer account.
+const SubmissionFee = 1
+
 // MockDataAvailabilityLayerClient is intended only for usage in tests.
 // It does actually ensures DA - it stores data in-memory.
 type MockDataAvailabilityLayerClient struct {
-	logger log.Logger
-	dalcKV store.KVStore
+	config   Config
+	logger   log.Logger
+	dalcKV   store.KVStore
+	blockKV  store.KVStore
+	daHeight *uint64
+	accounts *accounts
+	reorgs   *reorgs
+	signer   da.Signer
+	quit     chan struct{}
+
+	// maxRetrieveSize limits size of retrieved blobs (0 - no limit)
+	maxRetrieveSize uint64
+}
+
+// Config is a configuration of mock DA layer client.
+type Config struct {
+	// BlockTime is the interval of (mocked) DA layer block production, e.g. "1s".
+	// If it's empty, DA layer height is increased only by submissions.
+	BlockTime string `json:"block_time"`
+	// MaxBlobSize is the maximum size of submitted block in bytes (0 - no limit).
+	MaxBlobSize uint64 `json:"max_blob_size"`
+}
+
+// reorgs keeps (mocked) DA layer heights at which reorganizations happened.
+type reorgs struct {
+	mtx     sync.Mutex
+	heights []uint64
+}
+
+// accounts keeps state of (mocked) DA layer accounts.
+type accounts struct {
+	mtx   sync.Mutex
+	state map[string]*da.ResultQueryAccount
 }
 
 var _ da.DataAvailabilityLayerClient = &MockDataAvailabilityLayerClient{}
 var _ da.BlockRetriever = &MockDataAvailabilityLayerClient{}
+var _ da.AvailabilitySampler = &MockDataAvailabilityLayerClient{}
+var _ da.ForcedTxRetriever = &MockDataAvailabilityLayerClient{}
+var _ da.NamespaceScoper = &MockDataAvailabilityLayerClient{}
+var _ da.FeePayer = &MockDataAvailabilityLayerClient{}
+var _ da.HeightReader = &MockDataAvailabilityLayerClient{}
+var _ da.HashReader = &MockDataAvailabilityLayerClient{}
+var _ da.BlobSizeLimiter = &MockDataAvailabilityLayerClient{}
+var _ da.SnapshotStore = &MockDataAvailabilityLayerClient{}
+var _ da.RetrieveSizeLimiter = &MockDataAvailabilityLayerClient{}
 
 // Init is called once to allow DA client to read configuration and initialize resources.
 func (m *MockDataAvailabilityLayerClient) Init(config []byte, dalcKV store.KVStore, logger log.Logger) error {
 	m.logger = logger
 	m.dalcKV = dalcKV
+	m.blockKV = dalcKV
+	m.daHeight = new(uint64)
+	m.accounts = &accounts{state: make(map[string]*da.ResultQueryAccount)}
+	m.reorgs = &reorgs{}
+	if len(config) > 0 {
+		return json.Unmarshal(config, &m.config)
+	}
+	return nil
+}
+
+// WithNamespace returns client scoped to given namespace.
+// Blocks of different namespaces are stored separately, but all scoped clients share the (mocked) DA layer height.
+func (m *MockDataAvailabilityLayerClient) WithNamespace(namespaceID [8]byte) da.DataAvailabilityLayerClient {
+	return &MockDataAvailabilityLayerClient{
+		config:          Config{MaxBlobSize: m.config.MaxBlobSize},
+		logger:          m.logger,
+		dalcKV:          m.dalcKV,
+		blockKV:         store.NewPrefixKV(m.dalcKV, append([]byte{'n'}, namespaceID[:]...)),
+		daHeight:        m.daHeight,
+		accounts:        m.accounts,
+		reorgs:          m.reorgs,
+		maxRetrieveSize: m.maxRetrieveSize,
+	}
+}
+
+// MaxBlobSize returns maximum size of submitted block, as configured.
+func (m *MockDataAvailabilityLayerClient) MaxBlobSize() uint64 {
+	return m.config.MaxBlobSize
+}
+
+// SetMaxRetrieveSize limits size of blobs returned by RetrieveBlock and RetrieveForcedTxs.
+func (m *MockDataAvailabilityLayerClient) SetMaxRetrieveSize(size uint64) {
+	m.maxRetrieveSize = size
+}
+
+// SetSigner sets signer of DA layer transactions. If signer is set, every block submission is charged SubmissionFee.
+func (m *MockDataAvailabilityLayerClient) SetSigner(signer da.Signer) {
+	m.signer = signer
+}
+
+// QueryAccount returns state of account with given address.
+func (m *MockDataAvailabilityLayerClient) QueryAccount(address []byte) da.ResultQueryAccount {
+	m.accounts.mtx.Lock()
+	defer m.accounts.mtx.Unlock()
+	res := da.ResultQueryAccount{DAResult: da.DAResult{Code: da.StatusSuccess, DAHeight: atomic.LoadUint64(m.daHeight)}}
+	if acc, ok := m.accounts.state[string(address)]; ok {
+		res.Sequence = acc.Sequence
+		res.Balance = acc.Balance
+	}
+	return res
+}
+
+// Fund adds funds to account with given address.
+func (m *MockDataAvailabilityLayerClient) Fund(address []byte, amount uint64) {
+	m.accounts.mtx.Lock()
+	defer m.accounts.mtx.Unlock()
+	acc, ok := m.accounts.state[string(address)]
+	if !ok {
+		acc = &da.ResultQueryAccount{}
+		m.accounts.state[string(address)] = acc
+	}
+	acc.Balance += amount
+}
+
+// chargeFee signs block submission and charges SubmissionFee from signer account.
+// Signature itself is not verified by mock.
+func (m *MockDataAvailabilityLayerClient) chargeFee(blob []byte) error {
+	_, sequence, err := m.signer.Sign(blob)
+	if err != nil {
+		return err
+	}
+	m.accounts.mtx.Lock()
+	defer m.accounts.mtx.Unlock()
+	acc, ok := m.accounts.state[string(m.signer.Address())]
+	if !ok {
+		return errors.New("account not found")
+	}
+	if sequence != acc.Sequence {
+		return fmt.Errorf("account sequence mismatch, expected %d, got %d", acc.Sequence, sequence)
+	}
+	if acc.Balance < SubmissionFee {
+		return fmt.Errorf("insufficient funds: balance %d, fee %d", acc.Balance, SubmissionFee)
+	}
+	acc.Sequence++
+	acc.Balance -= SubmissionFee
 	return nil
 }
 
 // Start implements DataAvailabilityLayerClient interface.
 func (m *MockDataAvailabilityLayerClient) Start() error {
 	m.logger.Debug("Mock Data Availability Layer Client starting")
+	if m.config.BlockTime == "" {
+		return nil
+	}
+	blockTime, err := time.ParseDuration(m.config.BlockTime)
+	if err != nil {
+		return fmt.Errorf("invalid block time: %w", err)
+	}
+	m.quit = make(chan struct{})
+	go m.produceBlocks(blockTime, m.quit)
 	return nil
 }
 
 // Stop implements DataAvailabilityLayerClient interface.
 func (m *MockDataAvailabilityLayerClient) Stop() error {
 	m.logger.Debug("Mock Data Availability Layer Client stopped")
+	if m.quit != nil {
+		close(m.quit)
+		m.quit = nil
+	}
+	return nil
+}
+
+// LatestHeight returns height of the latest (mocked) DA layer block.
+func (m *MockDataAvailabilityLayerClient) LatestHeight() da.DAResult {
+	return da.DAResult{Code: da.StatusSuccess, DAHeight: atomic.LoadUint64(m.daHeight)}
+}
+
+// BlockHash returns hash of (mocked) DA layer block at given height.
+// Hash depends on the height and the number of reorganizations that replaced the block.
+func (m *MockDataAvailabilityLayerClient) BlockHash(daHeight uint64) da.ResultBlockHash {
+	latest := atomic.LoadUint64(m.daHeight)
+	res := da.ResultBlockHash{DAResult: da.DAResult{Code: da.StatusSuccess, DAHeight: daHeight}}
+	if daHeight == 0 || daHeight > latest {
+		return res
+	}
+	m.reorgs.mtx.Lock()
+	defer m.reorgs.mtx.Unlock()
+	var replaced uint64
+	for _, h := range m.reorgs.heights {
+		if h <= daHeight {
+			replaced++
+		}
+	}
+	hash := sha256.Sum256(append(getKey(daHeight), getKey(replaced)...))
+	res.Hash = hash[:]
+	return res
+}
+
+// Reorg simulates DA layer reorganization: all blocks starting from given DA height are replaced, and rollup blocks
+// included in them (in the namespace of this client) are no longer available. DA layer height is not changed.
+func (m *MockDataAvailabilityLayerClient) Reorg(daHeight uint64) error {
+	m.reorgs.mtx.Lock()
+	m.reorgs.heights = append(m.reorgs.heights, daHeight)
+	m.reorgs.mtx.Unlock()
+
+	for h := daHeight; h <= atomic.LoadUint64(m.daHeight); h++ {
+		hash, err := m.blockKV.Get(getIncludedKey(h))
+		if errors.Is(err, store.ErrKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := m.blockKV.Delete(hash); err != nil {
+			return err
+		}
+		if err := m.blockKV.Delete(getIncludedKey(h)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// produceBlocks increases DA layer height every block time, until client is stopped.
+func (m *MockDataAvailabilityLayerClient) produceBlocks(blockTime time.Duration, quit <-chan struct{}) {
+	ticker := time.NewTicker(blockTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			atomic.AddUint64(m.daHeight, 1)
+		case <-quit:
+			return
+		}
+	}
+}
+
 // SubmitBlock submits the passed in block to the DA layer.
 // This should create a transaction which (potentially)
 // triggers a state transition in the DA layer.
@@ -46,32 +258,62 @@ func (m *MockDataAvailabilityLayerClient) SubmitBlock(block *types.Block) da.Res
 	m.logger.Debug("Submitting block to DA layer!", "height", block.Header.Height)
 
 	hash := block.Header.Hash()
-	blob, err := block.MarshalBinary()
+	b, err := block.MarshalBlob()
+	if err != nil {
+		return da.ResultSubmitBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
+	}
+	// KV store doesn't retain the value after Set returns, so buffer can be reused
+	defer b.Release()
+	blob := b.Bytes()
+	if m.config.MaxBlobSize > 0 && uint64(len(blob)) > m.config.MaxBlobSize {
+		return da.ResultSubmitBlock{DAResult: da.DAResult{Code: da.StatusError,
+			Message: fmt.Sprintf("blob size %d exceeds max blob size %d", len(blob), m.config.MaxBlobSize)}}
+	}
+
+	var fee uint64
+	if m.signer != nil {
+		if err := m.chargeFee(blob); err != nil {
+			return da.ResultSubmitBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
+		}
+		fee = SubmissionFee
+	}
+
+	// every block is included in separate (mocked) DA layer block
+	daHeight := atomic.AddUint64(m.daHeight, 1)
+	err = m.blockKV.Set(getDAHeightKey(block.Header.Height), getKey(daHeight))
 	if err != nil {
 		return da.ResultSubmitBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
 	}
 
-	err = m.dalcKV.Set(getKey(block.Header.Height), hash[:])
+	err = m.blockKV.Set(getKey(block.Header.Height), hash[:])
+	if err != nil {
+		return da.ResultSubmitBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
+	}
+	err = m.blockKV.Set(hash[:], blob)
 	if err != nil {
 		return da.ResultSubmitBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
 	}
-	err = m.dalcKV.Set(hash[:], blob)
+	err = m.blockKV.Set(getIncludedKey(daHeight), hash[:])
 	if err != nil {
 		return da.ResultSubmitBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
 	}
 
+	txHash := sha256.Sum256(blob)
 	return da.ResultSubmitBlock{
 		DAResult: da.DAResult{
-			Code:    da.StatusSuccess,
-			Message: "OK",
+			Code:     da.StatusSuccess,
+			Message:  "OK",
+			DAHeight: daHeight,
 		},
+		TxHash: txHash[:],
+		Fee:    fee,
 	}
 }
 
 // CheckBlockAvailability queries DA layer to check data availability of block corresponding to given header.
 func (m *MockDataAvailabilityLayerClient) CheckBlockAvailability(header *types.Header) da.ResultCheckBlock {
 	hash := header.Hash()
-	_, err := m.dalcKV.Get(hash[:])
+	_, err := m.blockKV.Get(hash[:])
 	if errors.Is(err, store.ErrKeyNotFound) {
 		return da.ResultCheckBlock{DAResult: da.DAResult{Code: da.StatusSuccess}, DataAvailable: false}
 	}
@@ -81,24 +323,186 @@ func (m *MockDataAvailabilityLayerClient) CheckBlockAvailability(header *types.H
 	return da.ResultCheckBlock{DAResult: da.DAResult{Code: da.StatusSuccess}, DataAvailable: true}
 }
 
+// SampleAvailability checks availability of data committed to by the header. Mock doesn't erasure code blocks, so
+// instead of sampling shares, the whole block is looked up and its data is compared with the header.
+func (m *MockDataAvailabilityLayerClient) SampleAvailability(header *types.Header, samples int) da.ResultCheckBlock {
+	if samples <= 0 {
+		return da.ResultCheckBlock{DAResult: da.DAResult{Code: da.StatusError, Message: fmt.Sprintf("invalid number of samples: %d", samples)}}
+	}
+	hash := header.Hash()
+	blob, err := m.blockKV.Get(hash[:])
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return da.ResultCheckBlock{DAResult: da.DAResult{Code: da.StatusSuccess}, DataAvailable: false}
+	}
+	if err != nil {
+		return da.ResultCheckBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
+	}
+	block := new(types.Block)
+	if err := block.UnmarshalBinary(blob); err != nil {
+		return da.ResultCheckBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
+	}
+	return da.ResultCheckBlock{DAResult: da.DAResult{Code: da.StatusSuccess}, DataAvailable: block.Data.Hash() == header.DataHash}
+}
+
+// Withhold simulates data withholding: block with given header is no longer available, but DA layer heights are
+// not changed.
+func (m *MockDataAvailabilityLayerClient) Withhold(header *types.Header) error {
+	hash := header.Hash()
+	return m.blockKV.Delete(hash[:])
+}
+
 // RetrieveBlock returns block at given height from data availability layer.
 func (m *MockDataAvailabilityLayerClient) RetrieveBlock(height uint64) da.ResultRetrieveBlock {
-	hash, err := m.dalcKV.Get(getKey(height))
+	hash, err := m.blockKV.Get(getKey(height))
 	if err != nil {
 		return da.ResultRetrieveBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
 	}
-	blob, err := m.dalcKV.Get(hash)
+	blob, err := m.blockKV.Get(hash)
 	if err != nil {
 		return da.ResultRetrieveBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
 	}
 
+	if m.maxRetrieveSize > 0 && uint64(len(blob)) > m.maxRetrieveSize {
+		return da.ResultRetrieveBlock{DAResult: da.DAResult{Code: da.StatusError,
+			Message: fmt.Sprintf("blob size %d exceeds max retrieve size %d", len(blob), m.maxRetrieveSize)}}
+	}
+
 	block := &types.Block{}
 	err = block.UnmarshalBinary(blob)
 	if err != nil {
 		return da.ResultRetrieveBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
 	}
+	daHeight, err := m.blockKV.Get(getDAHeightKey(height))
+	if err != nil {
+		return da.ResultRetrieveBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
+	}
+
+	return da.ResultRetrieveBlock{
+		DAResult: da.DAResult{Code: da.StatusSuccess, DAHeight: binary.BigEndian.Uint64(daHeight)},
+		Block:    block,
+	}
+}
+
+// SubmitForcedTx posts transaction directly to given namespace of DA layer, bypassing the sequencer.
+func (m *MockDataAvailabilityLayerClient) SubmitForcedTx(namespaceID [8]byte, tx types.Tx) da.ResultSubmitBlock {
+	// every transaction is included in separate (mocked) DA layer block
+	daHeight := atomic.AddUint64(m.daHeight, 1)
+	err := m.dalcKV.Set(getForcedTxKey(namespaceID, daHeight), tx)
+	if err != nil {
+		return da.ResultSubmitBlock{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
+	}
+	return da.ResultSubmitBlock{DAResult: da.DAResult{Code: da.StatusSuccess, Message: "OK", DAHeight: daHeight}}
+}
+
+// RetrieveForcedTxs returns transactions posted to given namespace at given DA layer height.
+func (m *MockDataAvailabilityLayerClient) RetrieveForcedTxs(namespaceID [8]byte, daHeight uint64) da.ResultRetrieveForcedTxs {
+	tx, err := m.dalcKV.Get(getForcedTxKey(namespaceID, daHeight))
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return da.ResultRetrieveForcedTxs{DAResult: da.DAResult{Code: da.StatusSuccess, DAHeight: daHeight}}
+	}
+	if err != nil {
+		return da.ResultRetrieveForcedTxs{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
+	}
+	if m.maxRetrieveSize > 0 && uint64(len(tx)) > m.maxRetrieveSize {
+		m.logger.Info("skipping oversized forced inclusion transaction", "daHeight", daHeight, "size", len(tx))
+		return da.ResultRetrieveForcedTxs{DAResult: da.DAResult{Code: da.StatusSuccess, DAHeight: daHeight}}
+	}
+	return da.ResultRetrieveForcedTxs{
+		DAResult: da.DAResult{Code: da.StatusSuccess, DAHeight: daHeight},
+		Txs:      []types.Tx{tx},
+	}
+}
+
+// snapshotRecord is a serialized form of published snapshot.
+type snapshotRecord struct {
+	Height   uint64
+	Format   uint32
+	Chunks   uint32
+	Hash     []byte
+	Metadata []byte
+	Block    []byte
+	Commit   []byte
+	State    []byte
+}
+
+// SubmitSnapshotChunk stores chunk of snapshot. Snapshots are stored outside of (mocked) DA layer blocks.
+func (m *MockDataAvailabilityLayerClient) SubmitSnapshotChunk(height uint64, format uint32, index uint32, chunk []byte) da.DAResult {
+	if err := m.blockKV.Set(getSnapshotChunkKey(height, format, index), chunk); err != nil {
+		return da.DAResult{Code: da.StatusError, Message: err.Error()}
+	}
+	return da.DAResult{Code: da.StatusSuccess, Message: "OK"}
+}
+
+// SubmitSnapshot publishes snapshot.
+func (m *MockDataAvailabilityLayerClient) SubmitSnapshot(snapshot *types.Snapshot) da.DAResult {
+	record := snapshotRecord{
+		Height:   snapshot.Height,
+		Format:   snapshot.Format,
+		Chunks:   snapshot.Chunks,
+		Hash:     snapshot.Hash,
+		Metadata: snapshot.Metadata,
+		State:    snapshot.State,
+	}
+	var err error
+	record.Block, err = snapshot.Block.MarshalBinary()
+	if err != nil {
+		return da.DAResult{Code: da.StatusError, Message: err.Error()}
+	}
+	record.Commit, err = snapshot.Commit.MarshalBinary()
+	if err != nil {
+		return da.DAResult{Code: da.StatusError, Message: err.Error()}
+	}
+	blob, err := json.Marshal(record)
+	if err != nil {
+		return da.DAResult{Code: da.StatusError, Message: err.Error()}
+	}
+	if err := m.blockKV.Set(getSnapshotKey(snapshot.Height, snapshot.Format), blob); err != nil {
+		return da.DAResult{Code: da.StatusError, Message: err.Error()}
+	}
+	return da.DAResult{Code: da.StatusSuccess, Message: "OK"}
+}
+
+// ListSnapshots returns published snapshots, ordered by height.
+func (m *MockDataAvailabilityLayerClient) ListSnapshots() da.ResultListSnapshots {
+	it := m.blockKV.PrefixIterator(snapshotPrefix)
+	defer it.Discard()
+	var snapshots []*types.Snapshot
+	for ; it.Valid(); it.Next() {
+		var record snapshotRecord
+		if err := json.Unmarshal(it.Value(), &record); err != nil {
+			return da.ResultListSnapshots{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
+		}
+		snapshot := &types.Snapshot{
+			Height:   record.Height,
+			Format:   record.Format,
+			Chunks:   record.Chunks,
+			Hash:     record.Hash,
+			Metadata: record.Metadata,
+			Block:    new(types.Block),
+			Commit:   new(types.Commit),
+			State:    record.State,
+		}
+		if err := snapshot.Block.UnmarshalBinary(record.Block); err != nil {
+			return da.ResultListSnapshots{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
+		}
+		if err := snapshot.Commit.UnmarshalBinary(record.Commit); err != nil {
+			return da.ResultListSnapshots{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := it.Error(); err != nil {
+		return da.ResultListSnapshots{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
+	}
+	return da.ResultListSnapshots{DAResult: da.DAResult{Code: da.StatusSuccess}, Snapshots: snapshots}
+}
 
-	return da.ResultRetrieveBlock{DAResult: da.DAResult{Code: da.StatusSuccess}, Block: block}
+// RetrieveSnapshotChunk returns chunk of snapshot.
+func (m *MockDataAvailabilityLayerClient) RetrieveSnapshotChunk(height uint64, format uint32, index uint32) da.ResultRetrieveSnapshotChunk {
+	chunk, err := m.blockKV.Get(getSnapshotChunkKey(height, format, index))
+	if err != nil {
+		return da.ResultRetrieveSnapshotChunk{DAResult: da.DAResult{Code: da.StatusError, Message: err.Error()}}
+	}
+	return da.ResultRetrieveSnapshotChunk{DAResult: da.DAResult{Code: da.StatusSuccess}, Chunk: chunk}
 }
 
 func getKey(height uint64) []byte {
@@ -106,3 +510,33 @@ func getKey(height uint64) []byte {
 	binary.BigEndian.PutUint64(b, height)
 	return b
 }
+
+func getDAHeightKey(height uint64) []byte {
+	return append([]byte{'d'}, getKey(height)...)
+}
+
+func getIncludedKey(daHeight uint64) []byte {
+	return append([]byte{'i'}, getKey(daHeight)...)
+}
+
+func getForcedTxKey(namespaceID [8]byte, daHeight uint64) []byte {
+	return append(append([]byte{'f'}, namespaceID[:]...), getKey(daHeight)...)
+}
+
+// snapshotPrefix is long enough to not match keys of blocks, which are indexed by hash.
+var snapshotPrefix = []byte("snapshot/")
+
+func getSnapshotKey(height uint64, format uint32) []byte {
+	b := make([]byte, 12)
+	binary.BigEndian.PutUint64(b, height)
+	binary.BigEndian.PutUint32(b[8:], format)
+	return append(append([]byte{}, snapshotPrefix...), b...)
+}
+
+func getSnapshotChunkKey(height uint64, format uint32, index uint32) []byte {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b, height)
+	binary.BigEndian.PutUint32(b[8:], format)
+	binary.BigEndian.PutUint32(b[12:], index)
+	return append([]byte("chunk/"), b...)
+}
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"math/rand"
 	"net"
 	"strconv"
@@ -10,11 +11,14 @@ import (
 	"github.com/celestiaorg/optimint/da/grpc/mockserv"
 	"github.com/celestiaorg/optimint/store"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/celestiaorg/optimint/da"
+	mockda "github.com/celestiaorg/optimint/da/mock"
 	"github.com/celestiaorg/optimint/da/registry"
 	"github.com/celestiaorg/optimint/log/test"
 	"github.com/celestiaorg/optimint/types"
@@ -131,13 +135,181 @@ func doTestRetrieve(t *testing.T, dalc da.DataAvailabilityLayerClient) {
 		b := getRandomBlock(i, rand.Int()%20)
 		resp := dalc.SubmitBlock(b)
 		assert.Equal(da.StatusSuccess, resp.Code)
+		assert.NotZero(resp.DAHeight)
+		assert.NotEmpty(resp.TxHash)
 
 		ret := retriever.RetrieveBlock(i)
 		assert.Equal(da.StatusSuccess, ret.Code)
 		assert.Equal(b, ret.Block)
+		assert.Equal(resp.DAHeight, ret.DAHeight)
 	}
 }
 
+func TestSampleAvailability(t *testing.T) {
+	srv := startMockServ(t)
+	defer srv.GracefulStop()
+	for _, client := range registry.RegisteredClients() {
+		t.Run(client, func(t *testing.T) {
+			dalc := registry.GetClient(client)
+			if _, ok := dalc.(da.AvailabilitySampler); ok {
+				doTestSampleAvailability(t, dalc)
+			}
+		})
+	}
+}
+
+func doTestSampleAvailability(t *testing.T, dalc da.DataAvailabilityLayerClient) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	require.NoError(dalc.Init([]byte{}, store.NewDefaultInMemoryKVStore(), &test.TestLogger{T: t}))
+	require.NoError(dalc.Start())
+	defer func() {
+		require.NoError(dalc.Stop())
+	}()
+	sampler := dalc.(da.AvailabilitySampler)
+
+	blocks := make([]*types.Block, 3)
+	for i := range blocks {
+		blocks[i] = getRandomBlock(uint64(i+1), 5)
+		blocks[i].Header.DataHash = blocks[i].Data.Hash()
+	}
+	// only blocks 1 and 2 are submitted to DA
+	for _, b := range blocks[:2] {
+		resp := dalc.SubmitBlock(b)
+		require.Equal(da.StatusSuccess, resp.Code, resp.Message)
+	}
+
+	for i, b := range blocks {
+		res := sampler.SampleAvailability(&b.Header, 16)
+		assert.Equal(da.StatusSuccess, res.Code, res.Message)
+		assert.Equal(i < 2, res.DataAvailable)
+	}
+	res := sampler.SampleAvailability(&blocks[0].Header, 0)
+	assert.Equal(da.StatusError, res.Code)
+	assert.False(res.DataAvailable)
+
+	if m, ok := dalc.(*mockda.MockDataAvailabilityLayerClient); ok {
+		require.NoError(m.Withhold(&blocks[1].Header))
+		res = sampler.SampleAvailability(&blocks[1].Header, 16)
+		assert.Equal(da.StatusSuccess, res.Code, res.Message)
+		assert.False(res.DataAvailable)
+	}
+}
+
+func TestRetrieveSizeLimit(t *testing.T) {
+	srv := startMockServ(t)
+	defer srv.GracefulStop()
+	for _, client := range registry.RegisteredClients() {
+		t.Run(client, func(t *testing.T) {
+			dalc := registry.GetClient(client)
+			if _, ok := dalc.(da.RetrieveSizeLimiter); ok {
+				doTestRetrieveSizeLimit(t, dalc)
+			}
+		})
+	}
+}
+
+func doTestRetrieveSizeLimit(t *testing.T, dalc da.DataAvailabilityLayerClient) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const maxSize = 1024
+	require.NoError(dalc.Init([]byte{}, store.NewDefaultInMemoryKVStore(), &test.TestLogger{T: t}))
+	dalc.(da.RetrieveSizeLimiter).SetMaxRetrieveSize(maxSize)
+	require.NoError(dalc.Start())
+	defer func() {
+		require.NoError(dalc.Stop())
+	}()
+	retriever := dalc.(da.BlockRetriever)
+
+	small := getRandomBlock(1001, 1)
+	large := getRandomBlock(1002, 20)
+	require.Less(small.Size(), maxSize)
+	require.Greater(large.Size(), maxSize)
+
+	for _, b := range []*types.Block{small, large} {
+		resp := dalc.SubmitBlock(b)
+		require.Equal(da.StatusSuccess, resp.Code, resp.Message)
+	}
+
+	ret := retriever.RetrieveBlock(small.Header.Height)
+	assert.Equal(da.StatusSuccess, ret.Code)
+	assert.Equal(small, ret.Block)
+
+	ret = retriever.RetrieveBlock(large.Header.Height)
+	assert.Equal(da.StatusError, ret.Code)
+	assert.Nil(ret.Block)
+
+	// oversized forced inclusion transactions are skipped
+	if m, ok := dalc.(*mockda.MockDataAvailabilityLayerClient); ok {
+		var ns [8]byte
+		for _, tx := range []types.Tx{getRandomTx(), getRandomBytes(2 * maxSize)} {
+			resp := m.SubmitForcedTx(ns, tx)
+			require.Equal(da.StatusSuccess, resp.Code)
+			txs := m.RetrieveForcedTxs(ns, resp.DAHeight)
+			assert.Equal(da.StatusSuccess, txs.Code)
+			if len(tx) > maxSize {
+				assert.Empty(txs.Txs)
+			} else {
+				assert.Equal([]types.Tx{tx}, txs.Txs)
+			}
+		}
+	}
+}
+
+func TestNamespaceScoping(t *testing.T) {
+	srv := startMockServ(t)
+	defer srv.GracefulStop()
+	for _, client := range registry.RegisteredClients() {
+		t.Run(client, func(t *testing.T) {
+			dalc := registry.GetClient(client)
+			if _, ok := dalc.(da.NamespaceScoper); ok {
+				doTestNamespaceScoping(t, dalc)
+			}
+		})
+	}
+}
+
+func doTestNamespaceScoping(t *testing.T, dalc da.DataAvailabilityLayerClient) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	require.NoError(dalc.Init([]byte{}, store.NewDefaultInMemoryKVStore(), &test.TestLogger{T: t}))
+	require.NoError(dalc.Start())
+	defer func() {
+		require.NoError(dalc.Stop())
+	}()
+
+	// blocks of the same height are stored separately in every namespace
+	clients := []da.DataAvailabilityLayerClient{
+		dalc.(da.NamespaceScoper).WithNamespace([8]byte{1}),
+		dalc.(da.NamespaceScoper).WithNamespace([8]byte{2}),
+	}
+	blocks := make([]*types.Block, len(clients))
+	for i, c := range clients {
+		require.NoError(c.Start())
+		blocks[i] = getRandomBlock(2001, 5)
+		resp := c.SubmitBlock(blocks[i])
+		require.Equal(da.StatusSuccess, resp.Code, resp.Message)
+	}
+	for i, c := range clients {
+		ret := c.(da.BlockRetriever).RetrieveBlock(2001)
+		assert.Equal(da.StatusSuccess, ret.Code, ret.Message)
+		assert.Equal(blocks[i], ret.Block)
+
+		check := c.CheckBlockAvailability(&blocks[1-i].Header)
+		assert.Equal(da.StatusSuccess, check.Code)
+		assert.False(check.DataAvailable)
+		require.NoError(c.Stop())
+	}
+
+	// stopping scoped client doesn't affect the shared connection
+	check := clients[0].CheckBlockAvailability(&blocks[0].Header)
+	assert.Equal(da.StatusSuccess, check.Code, check.Message)
+	assert.True(check.DataAvailable)
+}
+
 // copy-pasted from store/store_test.go
 func getRandomBlock(height uint64, nTxs int) *types.Block {
 	block := &types.Block{
@@ -177,3 +349,39 @@ func getRandomBytes(n int) []byte {
 	_, _ = rand.Read(data)
 	return data
 }
+
+func TestMockServStandardServices(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	srv := startMockServ(t)
+	defer srv.GracefulStop()
+
+	conf := grpcda.DefaultConfig
+	conn, err := grpc.Dial(conf.Host+":"+strconv.Itoa(conf.Port), grpc.WithInsecure())
+	require.NoError(err)
+	defer conn.Close()
+
+	ctx := context.Background()
+	healthClient := healthpb.NewHealthClient(conn)
+	for _, service := range []string{"", "dalc.DALCService"} {
+		resp, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(err)
+		assert.Equal(healthpb.HealthCheckResponse_SERVING, resp.Status)
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(err)
+	err = stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	require.NoError(err)
+	resp, err := stream.Recv()
+	require.NoError(err)
+	var services []string
+	for _, service := range resp.GetListServicesResponse().Service {
+		services = append(services, service.Name)
+	}
+	assert.Contains(services, "dalc.DALCService")
+	assert.Contains(services, "grpc.health.v1.Health")
+}
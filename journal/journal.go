@@ -0,0 +1,359 @@
+package journal
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// Sources of journaled events.
+const (
+	SourceBeginBlock = "begin_block"
+	SourceTx         = "tx"
+	SourceEndBlock   = "end_block"
+)
+
+// maxReadHeights is the max number of heights scanned by a single Read call, so that reading long ranges of blocks
+// without events is bounded.
+const maxReadHeights = 1000
+
+var (
+	// ErrPruned is returned if entries following the cursor were already removed from the journal.
+	ErrPruned = errors.New("journal entries following the cursor were pruned")
+	// ErrInvalidCursor is returned if cursor points beyond the end of the journal.
+	ErrInvalidCursor = errors.New("cursor points beyond the end of the journal")
+
+	countPrefix    = []byte{1}
+	entryPrefix    = []byte{2}
+	consumerPrefix = []byte{3}
+	metaKey        = []byte{4}
+)
+
+// Attribute is a key-value pair of event.
+type Attribute struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Entry is an event of applied block, identified by block height and sequence number of the event within the block.
+type Entry struct {
+	Height   uint64 `json:"height"`
+	Sequence uint32 `json:"sequence"`
+	// Source is SourceBeginBlock, SourceTx or SourceEndBlock.
+	Source string `json:"source"`
+	// TxIndex and TxHash identify transaction that emitted the event (only if Source is SourceTx).
+	TxIndex    uint32           `json:"tx_index,omitempty"`
+	TxHash     tmbytes.HexBytes `json:"tx_hash,omitempty"`
+	Type       string           `json:"type"`
+	Attributes []Attribute      `json:"attributes"`
+}
+
+// Cursor points to the next entry to read. Zero cursor points to the oldest retained entry.
+type Cursor struct {
+	Height   uint64 `json:"height"`
+	Sequence uint32 `json:"sequence"`
+}
+
+// Batch is a result of Read.
+type Batch struct {
+	Entries []Entry
+	// Next points to the entry following returned entries. Consumers acknowledge it after processing the entries.
+	Next Cursor
+}
+
+// Journal is a durable, append-only log of events emitted by BeginBlock, DeliverTx (of successful transactions) and
+// EndBlock of applied blocks. Unlike event subscriptions, it can't lose events: consumers read entries following
+// their cursor, and acknowledge the cursor after processing them, so after restart they continue exactly where they
+// stopped.
+//
+// Events are journaled from blocks and block responses saved in the store, so blocks that were applied, but not
+// journaled (e.g. due to crash) are journaled later. Journal starts at the height following the store height at the
+// time it was enabled.
+type Journal struct {
+	kv           store.KVStore
+	store        store.Store
+	retainBlocks uint64
+	logger       log.Logger
+
+	mtx sync.RWMutex
+	// base is the lowest retained height, head is the highest journaled height
+	base uint64
+	head uint64
+}
+
+// NewJournal creates Journal saving entries in given KVStore. Blocks and block responses are loaded from the store.
+// Entries of blocks more than retainBlocks below the head are removed (0 - keep all).
+func NewJournal(kv store.KVStore, s store.Store, retainBlocks uint64, logger log.Logger) (*Journal, error) {
+	j := &Journal{
+		kv:           kv,
+		store:        s,
+		retainBlocks: retainBlocks,
+		logger:       logger,
+	}
+	blob, err := kv.Get(metaKey)
+	if errors.Is(err, store.ErrKeyNotFound) {
+		j.head = s.Height()
+		j.base = j.head + 1
+		return j, kv.Set(metaKey, encodeMeta(j.base, j.head))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) != 16 {
+		return nil, errors.New("invalid event journal metadata")
+	}
+	j.base = binary.BigEndian.Uint64(blob)
+	j.head = binary.BigEndian.Uint64(blob[8:])
+	return j, nil
+}
+
+// BlockApplied journals events of applied block (and of preceding blocks that were not journaled yet). It's intended
+// to be used as block.Hooks.OnBlockApplied.
+func (j *Journal) BlockApplied(block *types.Block) {
+	if err := j.Sync(block.Header.Height); err != nil {
+		j.logger.Error("failed to journal events", "height", block.Header.Height, "error", err)
+	}
+}
+
+// Sync journals events of blocks saved in the store, up to given height.
+func (j *Journal) Sync(height uint64) error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	for h := j.head + 1; h <= height; h++ {
+		if err := j.append(h); err != nil {
+			return fmt.Errorf("failed to journal events of block %d: %w", h, err)
+		}
+	}
+	return nil
+}
+
+// Heights returns the lowest retained height and the highest journaled height.
+func (j *Journal) Heights() (base uint64, head uint64) {
+	j.mtx.RLock()
+	defer j.mtx.RUnlock()
+	return j.base, j.head
+}
+
+// Read returns up to limit entries, starting from the cursor. Less entries are returned if the end of the journal is
+// reached, or maxReadHeights heights were scanned.
+func (j *Journal) Read(cursor Cursor, limit int) (*Batch, error) {
+	j.mtx.RLock()
+	defer j.mtx.RUnlock()
+
+	if cursor.Height == 0 {
+		cursor = Cursor{Height: j.base}
+	}
+	if cursor.Height < j.base {
+		return nil, fmt.Errorf("%w: cursor height %d, lowest retained height %d", ErrPruned, cursor.Height, j.base)
+	}
+	if cursor.Height > j.head+1 {
+		return nil, fmt.Errorf("%w: cursor height %d, journal height %d", ErrInvalidCursor, cursor.Height, j.head)
+	}
+
+	res := &Batch{Entries: []Entry{}, Next: cursor}
+	for h := cursor.Height; h <= j.head && h < cursor.Height+maxReadHeights; h++ {
+		count, err := j.count(h)
+		if err != nil {
+			return nil, err
+		}
+		seq := uint32(0)
+		if h == cursor.Height {
+			seq = cursor.Sequence
+		}
+		for ; seq < count; seq++ {
+			if len(res.Entries) >= limit {
+				res.Next = Cursor{Height: h, Sequence: seq}
+				return res, nil
+			}
+			blob, err := j.kv.Get(getEntryKey(h, seq))
+			if err != nil {
+				return nil, err
+			}
+			var entry Entry
+			if err := json.Unmarshal(blob, &entry); err != nil {
+				return nil, err
+			}
+			res.Entries = append(res.Entries, entry)
+		}
+		res.Next = Cursor{Height: h + 1}
+	}
+	return res, nil
+}
+
+// Cursor returns cursor acknowledged by the consumer (zero cursor for unknown consumers).
+func (j *Journal) Cursor(consumer string) (Cursor, error) {
+	var cursor Cursor
+	blob, err := j.kv.Get(getConsumerKey(consumer))
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return cursor, nil
+	}
+	if err != nil {
+		return cursor, err
+	}
+	err = json.Unmarshal(blob, &cursor)
+	return cursor, err
+}
+
+// Ack saves cursor of the consumer, after it processed entries preceding the cursor.
+func (j *Journal) Ack(consumer string, cursor Cursor) error {
+	if consumer == "" {
+		return errors.New("consumer name is required")
+	}
+	_, head := j.Heights()
+	if cursor.Height > head+1 || (cursor.Height == head+1 && cursor.Sequence > 0) {
+		return fmt.Errorf("%w: cursor height %d, journal height %d", ErrInvalidCursor, cursor.Height, head)
+	}
+	blob, err := json.Marshal(&cursor)
+	if err != nil {
+		return err
+	}
+	return j.kv.Set(getConsumerKey(consumer), blob)
+}
+
+// append saves entries of block at given height and prunes entries of old blocks, in a single batch.
+// Caller must hold the lock.
+func (j *Journal) append(height uint64) error {
+	block, err := j.store.LoadBlock(height)
+	if err != nil {
+		return err
+	}
+	responses, err := j.store.LoadBlockResponses(height)
+	if err != nil {
+		return err
+	}
+
+	var entries []Entry
+	add := func(source string, txIndex uint32, txHash []byte, events []abci.Event) {
+		for _, ev := range events {
+			entries = append(entries, newEntry(height, uint32(len(entries)), source, txIndex, txHash, ev))
+		}
+	}
+	if responses.BeginBlock != nil {
+		add(SourceBeginBlock, 0, nil, responses.BeginBlock.Events)
+	}
+	for i, tx := range responses.DeliverTxs {
+		if tx == nil || tx.Code != 0 || i >= len(block.Data.Txs) {
+			// events of failed transactions are not emitted
+			continue
+		}
+		add(SourceTx, uint32(i), tmtypes.Tx(block.Data.Txs[i]).Hash(), tx.Events)
+	}
+	if responses.EndBlock != nil {
+		add(SourceEndBlock, 0, nil, responses.EndBlock.Events)
+	}
+
+	batch := j.kv.NewBatch()
+	defer batch.Discard()
+	for i := range entries {
+		blob, err := json.Marshal(&entries[i])
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(getEntryKey(height, entries[i].Sequence), blob); err != nil {
+			return err
+		}
+	}
+	count := make([]byte, 4)
+	binary.BigEndian.PutUint32(count, uint32(len(entries)))
+	if err := batch.Set(getCountKey(height), count); err != nil {
+		return err
+	}
+
+	base := j.base
+	for ; j.retainBlocks > 0 && base+j.retainBlocks <= height; base++ {
+		if err := j.prune(batch, base); err != nil {
+			return err
+		}
+	}
+	if err := batch.Set(metaKey, encodeMeta(base, height)); err != nil {
+		return err
+	}
+	if err := batch.Commit(); err != nil {
+		return err
+	}
+	j.base, j.head = base, height
+	return nil
+}
+
+// prune adds removal of entries of block at given height to the batch.
+func (j *Journal) prune(batch store.Batch, height uint64) error {
+	count, err := j.count(height)
+	if errors.Is(err, store.ErrKeyNotFound) {
+		// nothing was journaled at this height
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for seq := uint32(0); seq < count; seq++ {
+		if err := batch.Delete(getEntryKey(height, seq)); err != nil {
+			return err
+		}
+	}
+	return batch.Delete(getCountKey(height))
+}
+
+// count returns the number of entries of block at given height.
+func (j *Journal) count(height uint64) (uint32, error) {
+	blob, err := j.kv.Get(getCountKey(height))
+	if err != nil {
+		return 0, err
+	}
+	if len(blob) != 4 {
+		return 0, errors.New("invalid event journal entry count")
+	}
+	return binary.BigEndian.Uint32(blob), nil
+}
+
+func newEntry(height uint64, sequence uint32, source string, txIndex uint32, txHash []byte, event abci.Event) Entry {
+	res := Entry{
+		Height:     height,
+		Sequence:   sequence,
+		Source:     source,
+		TxIndex:    txIndex,
+		TxHash:     txHash,
+		Type:       event.Type,
+		Attributes: make([]Attribute, len(event.Attributes)),
+	}
+	for i, attr := range event.Attributes {
+		res.Attributes[i] = Attribute{Key: attr.Key, Value: attr.Value}
+	}
+	return res
+}
+
+func encodeMeta(base, head uint64) []byte {
+	blob := make([]byte, 16)
+	binary.BigEndian.PutUint64(blob, base)
+	binary.BigEndian.PutUint64(blob[8:], head)
+	return blob
+}
+
+func getCountKey(height uint64) []byte {
+	key := make([]byte, 9)
+	copy(key, countPrefix)
+	binary.BigEndian.PutUint64(key[1:], height)
+	return key
+}
+
+func getEntryKey(height uint64, sequence uint32) []byte {
+	key := make([]byte, 13)
+	copy(key, entryPrefix)
+	binary.BigEndian.PutUint64(key[1:], height)
+	binary.BigEndian.PutUint32(key[9:], sequence)
+	return key
+}
+
+func getConsumerKey(consumer string) []byte {
+	return append(append([]byte{}, consumerPrefix...), consumer...)
+}
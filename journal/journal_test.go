@@ -0,0 +1,153 @@
+package journal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/log/test"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestJournal(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kv := store.NewDefaultInMemoryKVStore()
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	saveBlock(t, s, 1, &tmstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{
+			{Events: []abci.Event{newABCIEvent("transfer", "amount", "10")}},
+			// events of failed transaction are ignored
+			{Code: 1, Events: []abci.Event{newABCIEvent("transfer", "amount", "20")}},
+			{Events: []abci.Event{newABCIEvent("burn", "amount", "5"), newABCIEvent("transfer", "amount", "30")}},
+		},
+		BeginBlock: &abci.ResponseBeginBlock{Events: []abci.Event{newABCIEvent("mint", "amount", "1")}},
+		EndBlock:   &abci.ResponseEndBlock{Events: []abci.Event{newABCIEvent("rewards", "amount", "2")}},
+	})
+
+	j, err := NewJournal(kv, s, 0, &test.TestLogger{T: t})
+	require.NoError(err)
+	// journal starts after the blocks stored before it was enabled
+	base, head := j.Heights()
+	assert.EqualValues(2, base)
+	assert.EqualValues(1, head)
+
+	saveBlock(t, s, 2, &tmstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{
+			{Events: []abci.Event{newABCIEvent("transfer", "amount", "10")}},
+			{Code: 1, Events: []abci.Event{newABCIEvent("transfer", "amount", "20")}},
+			{Events: []abci.Event{newABCIEvent("burn", "amount", "5"), newABCIEvent("transfer", "amount", "30")}},
+		},
+		BeginBlock: &abci.ResponseBeginBlock{Events: []abci.Event{newABCIEvent("mint", "amount", "1")}},
+		EndBlock:   &abci.ResponseEndBlock{Events: []abci.Event{newABCIEvent("rewards", "amount", "2")}},
+	})
+	saveBlock(t, s, 3, &tmstate.ABCIResponses{BeginBlock: &abci.ResponseBeginBlock{}, EndBlock: &abci.ResponseEndBlock{}})
+	saveBlock(t, s, 4, &tmstate.ABCIResponses{
+		BeginBlock: &abci.ResponseBeginBlock{},
+		EndBlock:   &abci.ResponseEndBlock{Events: []abci.Event{newABCIEvent("rewards", "amount", "3")}},
+	})
+	// block 3 wasn't journaled, so it's journaled together with block 4
+	j.BlockApplied(&types.Block{Header: types.Header{Height: 2}})
+	j.BlockApplied(&types.Block{Header: types.Header{Height: 4}})
+	j.BlockApplied(&types.Block{Header: types.Header{Height: 4}})
+	base, head = j.Heights()
+	assert.EqualValues(2, base)
+	assert.EqualValues(4, head)
+
+	batch, err := j.Read(Cursor{}, 3)
+	require.NoError(err)
+	require.Len(batch.Entries, 3)
+	assert.Equal(Entry{Height: 2, Sequence: 0, Source: SourceBeginBlock, Type: "mint",
+		Attributes: []Attribute{{Key: []byte("amount"), Value: []byte("1")}}}, batch.Entries[0])
+	assert.Equal(SourceTx, batch.Entries[1].Source)
+	assert.EqualValues(0, batch.Entries[1].TxIndex)
+	assert.Equal(tmtypes.Tx("tx2-0").Hash(), []byte(batch.Entries[1].TxHash))
+	assert.EqualValues(2, batch.Entries[2].TxIndex)
+	assert.Equal("burn", batch.Entries[2].Type)
+	assert.Equal(Cursor{Height: 2, Sequence: 3}, batch.Next)
+
+	// consumer acknowledges processed entries, and continues after restart
+	require.NoError(j.Ack("indexer", batch.Next))
+	j, err = NewJournal(kv, s, 0, &test.TestLogger{T: t})
+	require.NoError(err)
+	cursor, err := j.Cursor("indexer")
+	require.NoError(err)
+	assert.Equal(batch.Next, cursor)
+
+	batch, err = j.Read(cursor, 10)
+	require.NoError(err)
+	require.Len(batch.Entries, 3)
+	assert.Equal(Cursor{Height: 2, Sequence: 3}, Cursor{Height: batch.Entries[0].Height, Sequence: batch.Entries[0].Sequence})
+	assert.Equal(SourceEndBlock, batch.Entries[1].Source)
+	assert.Equal(Cursor{Height: 4, Sequence: 0}, Cursor{Height: batch.Entries[2].Height, Sequence: batch.Entries[2].Sequence})
+	assert.Equal(Cursor{Height: 5}, batch.Next)
+
+	// end of the journal
+	batch, err = j.Read(batch.Next, 10)
+	require.NoError(err)
+	assert.Empty(batch.Entries)
+	assert.Equal(Cursor{Height: 5}, batch.Next)
+
+	unknown, err := j.Cursor("unknown")
+	require.NoError(err)
+	assert.Equal(Cursor{}, unknown)
+
+	_, err = j.Read(Cursor{Height: 6}, 10)
+	assert.ErrorIs(err, ErrInvalidCursor)
+	assert.ErrorIs(j.Ack("indexer", Cursor{Height: 6}), ErrInvalidCursor)
+	assert.Error(j.Ack("", Cursor{Height: 2}))
+}
+
+func TestJournalRetention(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kv := store.NewDefaultInMemoryKVStore()
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	j, err := NewJournal(kv, s, 2, &test.TestLogger{T: t})
+	require.NoError(err)
+
+	for h := uint64(1); h <= 4; h++ {
+		saveBlock(t, s, h, &tmstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{Events: []abci.Event{newABCIEvent("rewards", "height", "x")}},
+		})
+		j.BlockApplied(&types.Block{Header: types.Header{Height: h}})
+	}
+	base, head := j.Heights()
+	assert.EqualValues(3, base)
+	assert.EqualValues(4, head)
+
+	_, err = j.Read(Cursor{Height: 2}, 10)
+	assert.ErrorIs(err, ErrPruned)
+
+	batch, err := j.Read(Cursor{}, 10)
+	require.NoError(err)
+	require.Len(batch.Entries, 2)
+	assert.EqualValues(3, batch.Entries[0].Height)
+
+	// pruned entries are removed from the store
+	_, err = kv.Get(getEntryKey(2, 0))
+	assert.ErrorIs(err, store.ErrKeyNotFound)
+}
+
+func saveBlock(t *testing.T, s store.Store, height uint64, responses *tmstate.ABCIResponses) {
+	t.Helper()
+	block := &types.Block{Header: types.Header{Height: height}}
+	for i := range responses.DeliverTxs {
+		block.Data.Txs = append(block.Data.Txs, types.Tx(fmt.Sprintf("tx%d-%d", height, i)))
+	}
+	require.NoError(t, s.SaveBlock(block, &types.Commit{Height: height}))
+	require.NoError(t, s.SaveBlockResponses(height, responses))
+}
+
+func newABCIEvent(typ, key, value string) abci.Event {
+	return abci.Event{Type: typ, Attributes: []abci.EventAttribute{{Key: []byte(key), Value: []byte(value)}}}
+}
@@ -0,0 +1,47 @@
+package log
+
+import (
+	"fmt"
+	"io"
+
+	tmflags "github.com/tendermint/tendermint/libs/cli/flags"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+// Supported log output formats.
+const (
+	FormatPlain = "plain"
+	FormatJSON  = "json"
+)
+
+// DefaultLogLevel is used for all modules that are not explicitly configured.
+const DefaultLogLevel = "info"
+
+// NewLogger creates Tendermint compatible logger writing to w in given format.
+// Empty format is treated as FormatPlain.
+func NewLogger(w io.Writer, format string) (tmlog.Logger, error) {
+	switch format {
+	case FormatPlain, "":
+		return tmlog.NewTMLogger(tmlog.NewSyncWriter(w)), nil
+	case FormatJSON:
+		return tmlog.NewTMJSONLogger(tmlog.NewSyncWriter(w)), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format: %q", format)
+	}
+}
+
+// FilterByModule wraps logger with a filter configured using Tendermint's `log_level` syntax,
+// which is a comma-separated list of module:level pairs, with optional *:level pair, for example:
+// "p2p:debug,BlockManager:info,*:error". Module is matched against "module" key of the logger.
+//
+// If logLevel is empty, logger is returned unchanged.
+func FilterByModule(logger tmlog.Logger, logLevel string) (tmlog.Logger, error) {
+	if logLevel == "" {
+		return logger, nil
+	}
+	filtered, err := tmflags.ParseLogLevel(logLevel, logger, DefaultLogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse log level: %w", err)
+	}
+	return filtered, nil
+}
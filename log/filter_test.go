@@ -0,0 +1,71 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogger(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		format   string
+		expected string
+		err      bool
+	}{
+		{"default", "", "hello", false},
+		{"plain", FormatPlain, "hello", false},
+		{"json", FormatJSON, `"_msg":"hello"`, false},
+		{"unknown", "xml", "", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := NewLogger(&buf, c.format)
+			if c.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			logger.Info("hello")
+			assert.Contains(t, buf.String(), c.expected)
+		})
+	}
+}
+
+func TestFilterByModule(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	base, err := NewLogger(&buf, FormatPlain)
+	require.NoError(err)
+
+	logger, err := FilterByModule(base, "p2p:debug,mempool:none,*:error")
+	require.NoError(err)
+
+	logger.With("module", "p2p").Debug("p2p debug")
+	logger.With("module", "mempool").Error("mempool error")
+	logger.With("module", "BlockManager").Info("block manager info")
+	logger.With("module", "BlockManager").Error("block manager error")
+
+	out := buf.String()
+	assert.True(strings.Contains(out, "p2p debug"))
+	assert.False(strings.Contains(out, "mempool error"))
+	assert.False(strings.Contains(out, "block manager info"))
+	assert.True(strings.Contains(out, "block manager error"))
+
+	same, err := FilterByModule(base, "")
+	assert.NoError(err)
+	assert.Equal(base, same)
+
+	_, err = FilterByModule(base, "p2p:verbose")
+	assert.Error(err)
+}
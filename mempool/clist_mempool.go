@@ -5,6 +5,7 @@ import (
 	"container/list"
 	"crypto/sha256"
 	"fmt"
+	"math/big"
 	"sync"
 	"sync/atomic"
 
@@ -16,7 +17,6 @@ import (
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
-	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/types"
 )
@@ -71,6 +71,18 @@ type CListMempool struct {
 	logger log.Logger
 
 	metrics *Metrics
+
+	// called every time new transaction is added to the mempool
+	txAddedCb func(types.Tx)
+	// called every time transaction is rejected as invalid
+	invalidTxCb func(types.Tx, TxInfo)
+
+	// lanes are used to order transactions of the same sender (if enabled, see WithSenderLanes)
+	lanes *senderLanes
+	// feePriority enables ordering of transactions by gas price (see WithFeePriority)
+	feePriority bool
+	// outerTx returns the part of encrypted transaction checked by the application (see WithEncryptedTxs)
+	outerTx func(types.Tx) (types.Tx, error)
 }
 
 var _ Mempool = &CListMempool{}
@@ -131,6 +143,20 @@ func WithPostCheck(f PostCheckFunc) CListMempoolOption {
 	return func(mem *CListMempool) { mem.postCheck = f }
 }
 
+// WithTxAddedCallback sets a function that is called every time a new
+// transaction is added to the mempool.
+func WithTxAddedCallback(f func(types.Tx)) CListMempoolOption {
+	return func(mem *CListMempool) { mem.txAddedCb = f }
+}
+
+// WithInvalidTxCallback sets a function that is called every time a
+// transaction is rejected by pre-check, CheckTx or post-check, with the
+// information about its origin. It's not called when transaction becomes
+// invalid during recheck.
+func WithInvalidTxCallback(f func(types.Tx, TxInfo)) CListMempoolOption {
+	return func(mem *CListMempool) { mem.invalidTxCb = f }
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
@@ -205,6 +231,10 @@ func (mem *CListMempool) Flush() {
 		mem.txsMap.Delete(key)
 		return true
 	})
+
+	if mem.lanes != nil {
+		mem.lanes.reset()
+	}
 }
 
 // TxsFront returns the first transaction in the ordered list for peer
@@ -248,10 +278,22 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 
 	if mem.preCheck != nil {
 		if err := mem.preCheck(tx); err != nil {
+			if mem.invalidTxCb != nil {
+				mem.invalidTxCb(tx, txInfo)
+			}
 			return ErrPreCheck{err}
 		}
 	}
 
+	// encrypted transactions are checked by the application using their outer transaction
+	checked, err := mem.checkedTx(tx)
+	if err != nil {
+		if mem.invalidTxCb != nil {
+			mem.invalidTxCb(tx, txInfo)
+		}
+		return ErrPreCheck{err}
+	}
+
 	// NOTE: writing to the WAL and calling proxy must be done before adding tx
 	// to the cache. otherwise, if either of them fails, next time CheckTx is
 	// called with tx, ErrTxInCache will be returned without tx being checked at
@@ -285,8 +327,8 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 		return ErrTxInCache
 	}
 
-	reqRes := mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{Tx: tx})
-	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, txInfo.SenderP2PID, cb))
+	reqRes := mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{Tx: checked})
+	reqRes.SetCallback(mem.reqResCb(tx, txInfo, cb))
 
 	return nil
 }
@@ -323,8 +365,7 @@ func (mem *CListMempool) globalCb(req *abci.Request, res *abci.Response) {
 // Used in CheckTx to record PeerID who sent us the tx.
 func (mem *CListMempool) reqResCb(
 	tx []byte,
-	peerID uint16,
-	peerP2PID p2p.ID,
+	txInfo TxInfo,
 	externalCb func(*abci.Response),
 ) func(res *abci.Response) {
 	return func(res *abci.Response) {
@@ -333,7 +374,7 @@ func (mem *CListMempool) reqResCb(
 			panic("recheck cursor is not nil in reqResCb")
 		}
 
-		mem.resCbFirstTime(tx, peerID, peerP2PID, res)
+		mem.resCbFirstTime(tx, txInfo, res)
 
 		// update metrics
 		mem.metrics.Size.Set(float64(mem.Size()))
@@ -351,7 +392,13 @@ func (mem *CListMempool) addTx(memTx *MempoolTx) {
 	e := mem.txs.PushBack(memTx)
 	mem.txsMap.Store(TxKey(memTx.Tx), e)
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.Tx)))
+	if mem.lanes != nil {
+		mem.lanes.added(memTx)
+	}
 	mem.metrics.TxSizeBytes.Observe(float64(len(memTx.Tx)))
+	if mem.txAddedCb != nil {
+		mem.txAddedCb(memTx.Tx)
+	}
 }
 
 // Called from:
@@ -362,6 +409,9 @@ func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromC
 	elem.DetachPrev()
 	mem.txsMap.Delete(TxKey(tx))
 	atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
+	if mem.lanes != nil {
+		mem.lanes.removed(elem.Value.(*MempoolTx))
+	}
 
 	if removeFromCache {
 		mem.cache.Remove(tx)
@@ -378,6 +428,12 @@ func (mem *CListMempool) RemoveTxByKey(txKey [TxKeySize]byte, removeFromCache bo
 	}
 }
 
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) HasTxByKey(txKey [TxKeySize]byte) bool {
+	_, ok := mem.txsMap.Load(txKey)
+	return ok
+}
+
 func (mem *CListMempool) isFull(txSize int) error {
 	var (
 		memSize  = mem.Size()
@@ -400,8 +456,7 @@ func (mem *CListMempool) isFull(txSize int) error {
 // handled by the resCbRecheck callback.
 func (mem *CListMempool) resCbFirstTime(
 	tx []byte,
-	peerID uint16,
-	peerP2PID p2p.ID,
+	txInfo TxInfo,
 	res *abci.Response,
 ) {
 	switch r := res.Value.(type) {
@@ -425,7 +480,13 @@ func (mem *CListMempool) resCbFirstTime(
 				gasWanted: r.CheckTx.GasWanted,
 				Tx:        tx,
 			}
-			memTx.senders.Store(peerID, true)
+			if mem.lanes != nil {
+				memTx.sender, memTx.nonce = parseSenderLane(r.CheckTx)
+			}
+			if mem.feePriority {
+				memTx.gasPrice = txGasPrice(r.CheckTx)
+			}
+			memTx.senders.Store(txInfo.SenderID, true)
 			mem.addTx(memTx)
 			mem.logger.Debug("added good transaction",
 				"tx", txID(tx),
@@ -437,8 +498,11 @@ func (mem *CListMempool) resCbFirstTime(
 		} else {
 			// ignore bad transaction
 			mem.logger.Debug("rejected bad transaction",
-				"tx", txID(tx), "peerID", peerP2PID, "res", r, "err", postCheckErr)
+				"tx", txID(tx), "peerID", txInfo.SenderP2PID, "origin", txInfo.Origin(), "res", r, "err", postCheckErr)
 			mem.metrics.FailedTxs.Add(1)
+			if mem.invalidTxCb != nil {
+				mem.invalidTxCb(tx, txInfo)
+			}
 			if !mem.config.KeepInvalidTxsInCache {
 				// remove from cache (it might be good later)
 				mem.cache.Remove(tx)
@@ -456,13 +520,15 @@ func (mem *CListMempool) resCbFirstTime(
 func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 	switch r := res.Value.(type) {
 	case *abci.Response_CheckTx:
-		tx := req.GetCheckTx().Tx
 		memTx := mem.recheckCursor.Value.(*MempoolTx)
-		if !bytes.Equal(tx, memTx.Tx) {
+		tx := memTx.Tx
+		// encrypted transactions were accepted, so they can't fail here
+		checked, _ := mem.checkedTx(tx)
+		if !bytes.Equal(req.GetCheckTx().Tx, checked) {
 			panic(fmt.Sprintf(
 				"Unexpected tx response from proxy during recheck\nExpected %X, got %X",
-				memTx.Tx,
-				tx))
+				checked,
+				req.GetCheckTx().Tx))
 		}
 		var postCheckErr error
 		if mem.postCheck != nil {
@@ -525,9 +591,7 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	// size per tx, and set the initial capacity based off of that.
 	// txs := make([]types.Tx, 0, tmmath.MinInt(mem.txs.Len(), max/mem.avgTxSize))
 	txs := make([]types.Tx, 0, mem.txs.Len())
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*MempoolTx)
-
+	for _, memTx := range mem.reapable() {
 		dataSize := types.ComputeProtoSizeForTxs(append(txs, memTx.Tx))
 
 		// Check total size requirement
@@ -558,13 +622,31 @@ func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 	}
 
 	txs := make([]types.Tx, 0, tmmath.MinInt(mem.txs.Len(), max))
-	for e := mem.txs.Front(); e != nil && len(txs) <= max; e = e.Next() {
-		memTx := e.Value.(*MempoolTx)
+	for _, memTx := range mem.reapable() {
+		if len(txs) > max {
+			break
+		}
 		txs = append(txs, memTx.Tx)
 	}
 	return txs
 }
 
+// reapable returns transactions that can be reaped, in order of reaping.
+// Without sender lanes and fee priority, these are all transactions in mempool, in order of arrival.
+func (mem *CListMempool) reapable() []*MempoolTx {
+	memTxs := make([]*MempoolTx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTxs = append(memTxs, e.Value.(*MempoolTx))
+	}
+	if mem.feePriority {
+		sortByGasPrice(memTxs)
+	}
+	if mem.lanes != nil {
+		memTxs = mem.lanes.order(memTxs)
+	}
+	return memTxs
+}
+
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) Update(
 	height int64,
@@ -604,9 +686,15 @@ func (mem *CListMempool) Update(
 		//   100
 		// https://github.com/tendermint/tendermint/issues/3322.
 		if e, ok := mem.txsMap.Load(TxKey(tx)); ok {
+			if mem.lanes != nil && deliverTxResponses[i].Code == abci.CodeTypeOK {
+				mem.lanes.committed(e.(*clist.CElement).Value.(*MempoolTx))
+			}
 			mem.removeTx(tx, e.(*clist.CElement), false)
 		}
 	}
+	if mem.lanes != nil {
+		mem.lanes.prune(mem.config.Size)
+	}
 
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
@@ -640,8 +728,9 @@ func (mem *CListMempool) recheckTxs() {
 	// NOTE: globalCb may be called concurrently.
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
 		memTx := e.Value.(*MempoolTx)
+		checked, _ := mem.checkedTx(memTx.Tx)
 		mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{
-			Tx:   memTx.Tx,
+			Tx:   checked,
 			Type: abci.CheckTxType_Recheck,
 		})
 	}
@@ -657,6 +746,12 @@ type MempoolTx struct {
 	gasWanted int64    // amount of gas this tx states it will require
 	Tx        types.Tx //
 
+	// sender and nonce reported by the app (used only if sender lanes are enabled)
+	sender string
+	nonce  uint64
+	// gasPrice is the price of gas paid by the transaction (used only if fee priority is enabled)
+	gasPrice *big.Rat
+
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
 	senders sync.Map
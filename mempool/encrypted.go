@@ -0,0 +1,22 @@
+package mempool
+
+import (
+	"github.com/tendermint/tendermint/types"
+)
+
+// WithEncryptedTxs enables encrypted mempool mode. Encrypted transactions are envelopes containing fee paying outer
+// transaction and encrypted inner transaction. Application can't check the inner transaction, so outerTx returns the
+// outer transaction, which is passed to CheckTx (also during recheck) instead of the envelope. Gas, fees and priority
+// of the envelope are those of the outer transaction. For other transactions, outerTx returns the transaction itself.
+// Envelopes for which outerTx returns an error are rejected before CheckTx.
+func WithEncryptedTxs(outerTx func(types.Tx) (types.Tx, error)) CListMempoolOption {
+	return func(mem *CListMempool) { mem.outerTx = outerTx }
+}
+
+// checkedTx returns transaction passed to CheckTx of the application.
+func (mem *CListMempool) checkedTx(tx types.Tx) (types.Tx, error) {
+	if mem.outerTx == nil {
+		return tx, nil
+	}
+	return mem.outerTx(tx)
+}
@@ -0,0 +1,79 @@
+package mempool
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+// plainApp accepts only transactions with "ok" prefix, that were not revoked.
+type plainApp struct {
+	abci.BaseApplication
+	checked [][]byte
+	revoked map[string]bool
+}
+
+func (app *plainApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	app.checked = append(app.checked, req.Tx)
+	if !bytes.HasPrefix(req.Tx, []byte("ok")) || app.revoked[string(req.Tx)] {
+		return abci.ResponseCheckTx{Code: 1}
+	}
+	return abci.ResponseCheckTx{GasWanted: 1}
+}
+
+func TestEncryptedTxs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &plainApp{revoked: make(map[string]bool)}
+	appConn, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+	require.NoError(appConn.Start())
+	// envelope is "enc:<outer>|<ciphertext>"
+	outerTx := func(tx types.Tx) (types.Tx, error) {
+		if !bytes.HasPrefix(tx, []byte("enc:")) {
+			return tx, nil
+		}
+		i := bytes.IndexByte(tx, '|')
+		if i < 0 {
+			return nil, errors.New("malformed envelope")
+		}
+		return tx[len("enc:"):i], nil
+	}
+	mp := NewCListMempool(cfg.TestMempoolConfig(), appConn, 0, WithEncryptedTxs(outerTx))
+
+	for _, tx := range []string{"ok1", "enc:ok-fee1|c1", "enc:ok-fee2|c2", "enc:bad-fee|c3", "bad"} {
+		require.NoError(mp.CheckTx(types.Tx(tx), nil, TxInfo{}))
+	}
+	assert.Error(mp.CheckTx(types.Tx("enc:malformed"), nil, TxInfo{}))
+	require.NoError(mp.FlushAppConn())
+	require.Equal(3, mp.Size())
+	// application checks outer transactions instead of envelopes
+	assert.Contains(app.checked, []byte("ok-fee1"))
+	assert.NotContains(app.checked, []byte("enc:ok-fee1|c1"))
+
+	update := func(height int64, tx string) {
+		mp.Lock()
+		defer mp.Unlock()
+		err := mp.Update(height, types.Txs{types.Tx(tx)}, []*abci.ResponseDeliverTx{{}}, nil, nil)
+		require.NoError(err)
+		require.NoError(mp.FlushAppConn())
+	}
+
+	// encrypted transactions are rechecked using outer transactions
+	update(1, "ok1")
+	assert.Equal(types.Txs{types.Tx("enc:ok-fee1|c1"), types.Tx("enc:ok-fee2|c2")}, mp.ReapMaxTxs(-1))
+	app.revoked["ok-fee1"] = true
+	update(2, "other")
+	assert.Equal(types.Txs{types.Tx("enc:ok-fee2|c2")}, mp.ReapMaxTxs(-1))
+	update(3, "enc:ok-fee2|c2")
+	assert.Empty(mp.ReapMaxTxs(-1))
+}
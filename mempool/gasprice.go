@@ -0,0 +1,102 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// TxFeeEventType is a type of CheckTx event used by applications to report fee paid by the transaction
+	// (see PostCheckMinGasPrice).
+	TxFeeEventType = "tx_fee"
+	// TxFeeAmountKey is an attribute key of fee event containing fee amount (non-negative integer).
+	TxFeeAmountKey = "amount"
+	// TxFeeDenomKey is an attribute key of fee event containing fee denomination.
+	TxFeeDenomKey = "denom"
+)
+
+// GasPrice is a price of a single unit of gas, e.g. "0.025stake".
+type GasPrice struct {
+	Amount *big.Rat
+	Denom  string
+}
+
+// ParseGasPrice parses gas price consisting of decimal amount followed by denomination, e.g. "0.025stake".
+func ParseGasPrice(s string) (GasPrice, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i == -1 {
+		i = len(s)
+	}
+	amount, ok := new(big.Rat).SetString(s[:i])
+	if i == 0 || !ok {
+		return GasPrice{}, fmt.Errorf("invalid gas price: %q", s)
+	}
+	return GasPrice{Amount: amount, Denom: s[i:]}, nil
+}
+
+// IsZero returns true if gas price is not set or equal to zero.
+func (p GasPrice) IsZero() bool {
+	return p.Amount == nil || p.Amount.Sign() == 0
+}
+
+func (p GasPrice) String() string {
+	if p.Amount == nil {
+		return ""
+	}
+	return strings.TrimRight(strings.TrimRight(p.Amount.FloatString(18), "0"), ".") + p.Denom
+}
+
+// PostCheckMinGasPrice rejects transactions paying less than minPrice per unit of gas wanted.
+//
+// Application reports fee paid by the transaction in CheckTx response, as an event of type TxFeeEventType.
+// Transactions without fee event, or paying fee in other denomination are rejected. Returns nil if minPrice is zero.
+func PostCheckMinGasPrice(minPrice GasPrice) PostCheckFunc {
+	if minPrice.IsZero() {
+		return nil
+	}
+	return func(tx types.Tx, res *abci.ResponseCheckTx) error {
+		fee, denom, err := parseTxFee(res)
+		if err != nil {
+			return err
+		}
+		if denom != minPrice.Denom {
+			return fmt.Errorf("fee denomination %q doesn't match min gas price %s", denom, minPrice)
+		}
+		required := new(big.Rat).Mul(minPrice.Amount, new(big.Rat).SetInt64(res.GasWanted))
+		if new(big.Rat).SetInt(fee).Cmp(required) < 0 {
+			return fmt.Errorf("insufficient fee: got %s%s, required %s%s (min gas price %s)",
+				fee, denom, required.FloatString(0), minPrice.Denom, minPrice)
+		}
+		return nil
+	}
+}
+
+// parseTxFee extracts fee amount and denomination from CheckTx response events.
+func parseTxFee(res *abci.ResponseCheckTx) (*big.Int, string, error) {
+	for _, ev := range res.Events {
+		if ev.Type != TxFeeEventType {
+			continue
+		}
+		var amount, denom string
+		for _, attr := range ev.Attributes {
+			switch string(attr.Key) {
+			case TxFeeAmountKey:
+				amount = string(attr.Value)
+			case TxFeeDenomKey:
+				denom = string(attr.Value)
+			}
+		}
+		fee, ok := new(big.Int).SetString(amount, 10)
+		if !ok || fee.Sign() < 0 {
+			return nil, "", fmt.Errorf("invalid transaction fee amount: %q", amount)
+		}
+		return fee, denom, nil
+	}
+	return nil, "", errors.New("transaction fee not reported by the application")
+}
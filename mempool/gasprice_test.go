@@ -0,0 +1,86 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestParseGasPrice(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+		denom    string
+		valid    bool
+	}{
+		{"0.025stake", "0.025stake", "stake", true},
+		{"1uatom", "1uatom", "uatom", true},
+		{" 10.50 ", "10.5", "", true},
+		{"0stake", "0stake", "stake", true},
+		{"stake", "", "", false},
+		{"1.2.3stake", "", "", false},
+		{"", "", "", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			price, err := ParseGasPrice(c.input)
+			if !c.valid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, price.String())
+			assert.Equal(t, c.denom, price.Denom)
+		})
+	}
+}
+
+func TestPostCheckMinGasPrice(t *testing.T) {
+	zero, err := ParseGasPrice("0stake")
+	require.NoError(t, err)
+	assert.Nil(t, PostCheckMinGasPrice(zero))
+	assert.Nil(t, PostCheckMinGasPrice(GasPrice{}))
+
+	price, err := ParseGasPrice("0.025stake")
+	require.NoError(t, err)
+	check := PostCheckMinGasPrice(price)
+	require.NotNil(t, check)
+
+	feeEvent := func(amount, denom string) []abci.Event {
+		return []abci.Event{{
+			Type: TxFeeEventType,
+			Attributes: []abci.EventAttribute{
+				{Key: []byte(TxFeeAmountKey), Value: []byte(amount)},
+				{Key: []byte(TxFeeDenomKey), Value: []byte(denom)},
+			},
+		}}
+	}
+
+	cases := []struct {
+		name  string
+		res   abci.ResponseCheckTx
+		valid bool
+	}{
+		{"exact fee", abci.ResponseCheckTx{GasWanted: 1000, Events: feeEvent("25", "stake")}, true},
+		{"higher fee", abci.ResponseCheckTx{GasWanted: 1000, Events: feeEvent("100", "stake")}, true},
+		{"insufficient fee", abci.ResponseCheckTx{GasWanted: 1000, Events: feeEvent("24", "stake")}, false},
+		{"other denom", abci.ResponseCheckTx{GasWanted: 1000, Events: feeEvent("100", "uatom")}, false},
+		{"invalid amount", abci.ResponseCheckTx{GasWanted: 1000, Events: feeEvent("-100", "stake")}, false},
+		{"no fee event", abci.ResponseCheckTx{GasWanted: 1000}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := check(types.Tx("tx"), &c.res)
+			if c.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
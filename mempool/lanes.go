@@ -0,0 +1,181 @@
+package mempool
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+const (
+	// SenderLaneEventType is a type of CheckTx event used by applications to report sender and nonce of the
+	// transaction (see WithSenderLanes).
+	SenderLaneEventType = "sender_lane"
+	// SenderLaneSenderKey is an attribute key of sender lane event containing transaction sender (account).
+	SenderLaneSenderKey = "sender"
+	// SenderLaneNonceKey is an attribute key of sender lane event containing transaction nonce (decimal number).
+	SenderLaneNonceKey = "nonce"
+)
+
+// WithSenderLanes enables sender-aware ordering of transactions.
+//
+// Application reports sender and nonce of the transaction in CheckTx response, as an event of type SenderLaneEventType.
+// Transactions of the same sender are reaped in order of nonces, and transactions after a nonce gap are kept in mempool
+// until missing transactions arrive. Transactions without sender lane event are not affected.
+func WithSenderLanes() CListMempoolOption {
+	return func(mem *CListMempool) { mem.lanes = newSenderLanes() }
+}
+
+// senderLanes keeps track of transactions of senders, to order transactions by nonces.
+type senderLanes struct {
+	mtx sync.Mutex
+	// pending is the number of sender transactions in mempool
+	pending map[string]int
+	// next is the next expected nonce of sender, learned from committed transactions
+	next map[string]uint64
+}
+
+func newSenderLanes() *senderLanes {
+	return &senderLanes{
+		pending: make(map[string]int),
+		next:    make(map[string]uint64),
+	}
+}
+
+// parseSenderLane extracts sender and nonce from CheckTx response events.
+// Empty sender is returned if response doesn't contain valid sender lane event.
+func parseSenderLane(res *abci.ResponseCheckTx) (string, uint64) {
+	for _, ev := range res.Events {
+		if ev.Type != SenderLaneEventType {
+			continue
+		}
+		var sender, nonce string
+		for _, attr := range ev.Attributes {
+			switch string(attr.Key) {
+			case SenderLaneSenderKey:
+				sender = string(attr.Value)
+			case SenderLaneNonceKey:
+				nonce = string(attr.Value)
+			}
+		}
+		n, err := strconv.ParseUint(nonce, 10, 64)
+		if sender == "" || err != nil {
+			return "", 0
+		}
+		return sender, n
+	}
+	return "", 0
+}
+
+func (l *senderLanes) added(memTx *MempoolTx) {
+	if memTx.sender == "" {
+		return
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.pending[memTx.sender]++
+}
+
+func (l *senderLanes) removed(memTx *MempoolTx) {
+	if memTx.sender == "" {
+		return
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.pending[memTx.sender]--
+	if l.pending[memTx.sender] <= 0 {
+		delete(l.pending, memTx.sender)
+	}
+}
+
+// committed records nonce of committed transaction of the sender.
+func (l *senderLanes) committed(memTx *MempoolTx) {
+	if memTx.sender == "" {
+		return
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if next, ok := l.next[memTx.sender]; !ok || memTx.nonce >= next {
+		l.next[memTx.sender] = memTx.nonce + 1
+	}
+}
+
+// prune forgets next nonces of senders without transactions in mempool, if there are more than max of them.
+func (l *senderLanes) prune(max int) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if len(l.next) <= max {
+		return
+	}
+	for sender := range l.next {
+		if l.pending[sender] == 0 {
+			delete(l.next, sender)
+		}
+	}
+}
+
+// reset forgets all the senders.
+func (l *senderLanes) reset() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.pending = make(map[string]int)
+	l.next = make(map[string]uint64)
+}
+
+// order returns transactions that can be reaped, in order of reaping.
+//
+// Transactions of every sender are sorted by nonce and only the sequence of consecutive nonces (starting from next
+// expected nonce, or the lowest nonce in mempool if next nonce is unknown) is returned. Positions of transactions
+// in mempool are preserved, i.e. n-th transaction of the sender in mempool is replaced with transaction with n-th
+// consecutive nonce.
+func (l *senderLanes) order(memTxs []*MempoolTx) []*MempoolTx {
+	bySender := make(map[string][]*MempoolTx)
+	for _, memTx := range memTxs {
+		if memTx.sender != "" {
+			bySender[memTx.sender] = append(bySender[memTx.sender], memTx)
+		}
+	}
+	if len(bySender) == 0 {
+		return memTxs
+	}
+
+	l.mtx.Lock()
+	for sender, txs := range bySender {
+		sort.SliceStable(txs, func(i, j int) bool { return txs[i].nonce < txs[j].nonce })
+		expected, ok := l.next[sender]
+		if !ok {
+			expected = txs[0].nonce
+		}
+		ready := txs[:0]
+		for _, memTx := range txs {
+			if memTx.nonce < expected {
+				// already used (or duplicated) nonce
+				continue
+			}
+			if memTx.nonce > expected {
+				// nonce gap
+				break
+			}
+			ready = append(ready, memTx)
+			expected++
+		}
+		bySender[sender] = ready
+	}
+	l.mtx.Unlock()
+
+	ordered := make([]*MempoolTx, 0, len(memTxs))
+	seen := make(map[string]int)
+	for _, memTx := range memTxs {
+		if memTx.sender == "" {
+			ordered = append(ordered, memTx)
+			continue
+		}
+		i := seen[memTx.sender]
+		seen[memTx.sender]++
+		if ready := bySender[memTx.sender]; i < len(ready) {
+			ordered = append(ordered, ready[i])
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,64 @@
+package mempool
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+// laneApp accepts transactions in "sender:nonce" format and reports sender lane events.
+type laneApp struct {
+	abci.BaseApplication
+}
+
+func (laneApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	parts := strings.SplitN(string(req.Tx), ":", 2)
+	if len(parts) != 2 {
+		return abci.ResponseCheckTx{}
+	}
+	return abci.ResponseCheckTx{Events: []abci.Event{{
+		Type: SenderLaneEventType,
+		Attributes: []abci.EventAttribute{
+			{Key: []byte(SenderLaneSenderKey), Value: []byte(parts[0])},
+			{Key: []byte(SenderLaneNonceKey), Value: []byte(parts[1])},
+		},
+	}}}
+}
+
+func TestSenderLanes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	appConn, err := proxy.NewLocalClientCreator(&laneApp{}).NewABCIClient()
+	require.NoError(err)
+	require.NoError(appConn.Start())
+	mp := NewCListMempool(cfg.TestMempoolConfig(), appConn, 0, WithSenderLanes())
+
+	for _, tx := range []string{"alice:2", "bob:5", "no-lane", "alice:1", "alice:4", "bob:7", "bob:6"} {
+		require.NoError(mp.CheckTx(types.Tx(tx), nil, TxInfo{}))
+	}
+	require.NoError(mp.FlushAppConn())
+	require.Equal(7, mp.Size())
+
+	// alice:4 waits for alice:3, bob's transactions are sorted
+	expected := types.Txs{types.Tx("alice:1"), types.Tx("bob:5"), types.Tx("no-lane"), types.Tx("alice:2"), types.Tx("bob:6"), types.Tx("bob:7")}
+	assert.Equal(expected, mp.ReapMaxBytesMaxGas(-1, -1))
+	assert.Equal(expected, mp.ReapMaxTxs(-1))
+
+	// after committing alice:1 and alice:2, alice's next nonce is known
+	mp.Lock()
+	require.NoError(mp.Update(1, types.Txs{types.Tx("alice:1"), types.Tx("alice:2")}, abciResponses(2, abci.CodeTypeOK), nil, nil))
+	mp.Unlock()
+	assert.Equal(types.Txs{types.Tx("bob:5"), types.Tx("no-lane"), types.Tx("bob:6"), types.Tx("bob:7")}, mp.ReapMaxBytesMaxGas(-1, -1))
+
+	require.NoError(mp.CheckTx(types.Tx("alice:3"), nil, TxInfo{}))
+	require.NoError(mp.FlushAppConn())
+	assert.Equal(types.Txs{types.Tx("bob:5"), types.Tx("no-lane"), types.Tx("alice:3"), types.Tx("bob:6"), types.Tx("bob:7"), types.Tx("alice:4")}, mp.ReapMaxBytesMaxGas(-1, -1))
+}
@@ -2,6 +2,7 @@ package mempool
 
 import (
 	"fmt"
+	"net"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/p2p"
@@ -80,6 +81,9 @@ type Mempool interface {
 	// RemoveTxByKey removes a transaction from the mempool using a transasction's
 	// key (sha256 hash of the tx bytes)
 	RemoveTxByKey(txKey [TxKeySize]byte, removeFromCache bool)
+
+	// HasTxByKey returns true if transaction with given key (sha256 hash of the tx bytes) is in the mempool.
+	HasTxByKey(txKey [TxKeySize]byte) bool
 }
 
 //--------------------------------------------------------------------------------
@@ -102,6 +106,28 @@ type TxInfo struct {
 	SenderID uint16
 	// SenderP2PID is the actual p2p.ID of the sender, used e.g. for logging.
 	SenderP2PID p2p.ID
+	// SenderRPCAddr is the remote address (host:port) of the RPC client that
+	// submitted the tx (empty for txs received from peers).
+	SenderRPCAddr string
+}
+
+// Origin identifies the source of the tx, so invalid txs can be accounted
+// to peers and RPC clients: "peer:<ID>" for txs received from peers,
+// "rpc:<host>" for txs submitted via RPC (port is omitted, so all
+// connections from the same host share the origin), or empty string if the
+// origin is unknown.
+func (info TxInfo) Origin() string {
+	switch {
+	case info.SenderP2PID != "":
+		return "peer:" + string(info.SenderP2PID)
+	case info.SenderRPCAddr != "":
+		host, _, err := net.SplitHostPort(info.SenderRPCAddr)
+		if err != nil {
+			host = info.SenderRPCAddr
+		}
+		return "rpc:" + host
+	}
+	return ""
 }
 
 //--------------------------------------------------------------------------------
@@ -119,6 +145,56 @@ func PreCheckMaxBytes(maxBytes int64) PreCheckFunc {
 	}
 }
 
+// ChainPreChecks returns a filter rejecting transactions rejected by any of given filters (nil filters are skipped).
+// Returns nil if there are no filters.
+func ChainPreChecks(checks ...PreCheckFunc) PreCheckFunc {
+	var nonNil []PreCheckFunc
+	for _, check := range checks {
+		if check != nil {
+			nonNil = append(nonNil, check)
+		}
+	}
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0]
+	}
+	return func(tx types.Tx) error {
+		for _, check := range nonNil {
+			if err := check(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// ChainPostChecks returns a filter rejecting transactions rejected by any of given filters (nil filters are skipped).
+// Returns nil if there are no filters.
+func ChainPostChecks(checks ...PostCheckFunc) PostCheckFunc {
+	var nonNil []PostCheckFunc
+	for _, check := range checks {
+		if check != nil {
+			nonNil = append(nonNil, check)
+		}
+	}
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0]
+	}
+	return func(tx types.Tx, res *abci.ResponseCheckTx) error {
+		for _, check := range nonNil {
+			if err := check(tx, res); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 // PostCheckMaxGas checks that the wanted gas is smaller or equal to the passed
 // maxGas. Returns nil if maxGas is -1.
 func PostCheckMaxGas(maxGas int64) PostCheckFunc {
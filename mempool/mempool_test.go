@@ -0,0 +1,65 @@
+package mempool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxInfoOrigin(t *testing.T) {
+	cases := []struct {
+		info     TxInfo
+		expected string
+	}{
+		{TxInfo{}, ""},
+		{TxInfo{SenderID: 1, SenderP2PID: "12D3KooW"}, "peer:12D3KooW"},
+		{TxInfo{SenderRPCAddr: "1.2.3.4:5678"}, "rpc:1.2.3.4"},
+		{TxInfo{SenderRPCAddr: "[::1]:5678"}, "rpc:::1"},
+		{TxInfo{SenderRPCAddr: "unix-socket"}, "rpc:unix-socket"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.info.Origin())
+	}
+}
+
+func TestInvalidTxCallback(t *testing.T) {
+	require := require.New(t)
+
+	var invalid []string
+	appConn, err := proxy.NewLocalClientCreator(kvstore.NewApplication()).NewABCIClient()
+	require.NoError(err)
+	require.NoError(appConn.Start())
+	mp := NewCListMempool(cfg.TestMempoolConfig(), appConn, 0,
+		WithPreCheck(func(tx types.Tx) error {
+			if string(tx) == "pre" {
+				return errors.New("rejected by pre-check")
+			}
+			return nil
+		}),
+		WithPostCheck(func(tx types.Tx, res *abci.ResponseCheckTx) error {
+			if string(tx) == "post" {
+				return errors.New("rejected by post-check")
+			}
+			return nil
+		}),
+		WithInvalidTxCallback(func(tx types.Tx, txInfo TxInfo) {
+			invalid = append(invalid, string(tx)+"@"+txInfo.Origin())
+		}))
+
+	rpcInfo := TxInfo{SenderRPCAddr: "1.2.3.4:5678"}
+	require.IsType(ErrPreCheck{}, mp.CheckTx(types.Tx("pre"), nil, rpcInfo))
+	require.NoError(mp.CheckTx(types.Tx("post"), nil, TxInfo{SenderID: 1, SenderP2PID: "peer1"}))
+	require.NoError(mp.CheckTx(types.Tx("valid"), nil, rpcInfo))
+	require.NoError(mp.FlushAppConn())
+
+	assert.Equal(t, []string{"pre@rpc:1.2.3.4", "post@peer:peer1"}, invalid)
+	assert.Equal(t, 1, mp.Size())
+}
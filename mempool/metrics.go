@@ -3,8 +3,9 @@ package mempool
 import (
 	"github.com/go-kit/kit/metrics"
 	"github.com/go-kit/kit/metrics/discard"
-	"github.com/go-kit/kit/metrics/prometheus"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	optmetrics "github.com/celestiaorg/optimint/metrics"
 )
 
 const (
@@ -26,35 +27,35 @@ type Metrics struct {
 	RecheckTimes metrics.Counter
 }
 
-// PrometheusMetrics returns Metrics build using Prometheus client library.
+// PrometheusMetrics returns Metrics build using Prometheus client library, registered in given registerer.
 // Optionally, labels can be provided along with their values ("foo",
 // "fooValue").
-func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+func PrometheusMetrics(registerer stdprometheus.Registerer, namespace string, labelsAndValues ...string) *Metrics {
 	labels := []string{}
 	for i := 0; i < len(labelsAndValues); i += 2 {
 		labels = append(labels, labelsAndValues[i])
 	}
 	return &Metrics{
-		Size: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Size: optmetrics.NewGaugeFrom(registerer, stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "size",
 			Help:      "Size of the mempool (number of uncommitted transactions).",
 		}, labels).With(labelsAndValues...),
-		TxSizeBytes: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		TxSizeBytes: optmetrics.NewHistogramFrom(registerer, stdprometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "tx_size_bytes",
 			Help:      "Transaction sizes in bytes.",
 			Buckets:   stdprometheus.ExponentialBuckets(1, 3, 17),
 		}, labels).With(labelsAndValues...),
-		FailedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		FailedTxs: optmetrics.NewCounterFrom(registerer, stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "failed_txs",
 			Help:      "Number of failed transactions.",
 		}, labels).With(labelsAndValues...),
-		RecheckTimes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		RecheckTimes: optmetrics.NewCounterFrom(registerer, stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "recheck_times",
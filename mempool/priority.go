@@ -0,0 +1,47 @@
+package mempool
+
+import (
+	"math/big"
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// WithFeePriority enables ordering of transactions by gas price, from the highest to the lowest.
+//
+// Application reports fee paid by the transaction in CheckTx response, as an event of type TxFeeEventType (see
+// PostCheckMinGasPrice). Gas price is the fee divided by gas wanted; denomination of the fee is not taken into
+// account. Transactions without fee event have zero gas price. Transactions with equal gas price are reaped in order
+// of arrival. If sender lanes are enabled, transactions of the same sender are still reaped in order of nonces.
+func WithFeePriority() CListMempoolOption {
+	return func(mem *CListMempool) { mem.feePriority = true }
+}
+
+// txGasPrice returns gas price of transaction, based on fee reported in CheckTx response events.
+// Nil is returned if fee is not reported.
+func txGasPrice(res *abci.ResponseCheckTx) *big.Rat {
+	fee, _, err := parseTxFee(res)
+	if err != nil {
+		return nil
+	}
+	gas := res.GasWanted
+	if gas < 1 {
+		gas = 1
+	}
+	return new(big.Rat).SetFrac(fee, big.NewInt(gas))
+}
+
+// sortByGasPrice sorts transactions by gas price (the highest first), preserving order of transactions
+// with equal gas price.
+func sortByGasPrice(memTxs []*MempoolTx) {
+	zero := new(big.Rat)
+	price := func(memTx *MempoolTx) *big.Rat {
+		if memTx.gasPrice == nil {
+			return zero
+		}
+		return memTx.gasPrice
+	}
+	sort.SliceStable(memTxs, func(i, j int) bool {
+		return price(memTxs[i]).Cmp(price(memTxs[j])) > 0
+	})
+}
@@ -0,0 +1,56 @@
+package mempool
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+// feeApp accepts transactions in "name:fee:gas" format and reports fee events.
+type feeApp struct {
+	abci.BaseApplication
+}
+
+func (feeApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	parts := strings.Split(string(req.Tx), ":")
+	if len(parts) != 3 {
+		return abci.ResponseCheckTx{}
+	}
+	gas, _ := strconv.ParseInt(parts[2], 10, 64)
+	return abci.ResponseCheckTx{GasWanted: gas, Events: []abci.Event{{
+		Type: TxFeeEventType,
+		Attributes: []abci.EventAttribute{
+			{Key: []byte(TxFeeAmountKey), Value: []byte(parts[1])},
+			{Key: []byte(TxFeeDenomKey), Value: []byte("stake")},
+		},
+	}}}
+}
+
+func TestFeePriority(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	appConn, err := proxy.NewLocalClientCreator(&feeApp{}).NewABCIClient()
+	require.NoError(err)
+	require.NoError(appConn.Start())
+	mp := NewCListMempool(cfg.TestMempoolConfig(), appConn, 0, WithFeePriority())
+
+	for _, tx := range []string{"a:10:10", "no-fee", "b:30:10", "c:20:5", "d:10:10"} {
+		require.NoError(mp.CheckTx(types.Tx(tx), nil, TxInfo{}))
+	}
+	require.NoError(mp.FlushAppConn())
+	require.Equal(5, mp.Size())
+
+	// c pays 4 per gas, b 3 per gas, a and d 1 per gas (in order of arrival)
+	expected := types.Txs{types.Tx("c:20:5"), types.Tx("b:30:10"), types.Tx("a:10:10"), types.Tx("d:10:10"), types.Tx("no-fee")}
+	assert.Equal(expected, mp.ReapMaxBytesMaxGas(-1, -1))
+	assert.Equal(expected[:2], mp.ReapMaxBytesMaxGas(-1, 15))
+}
@@ -0,0 +1,43 @@
+// Package metrics creates go-kit metrics backed by Prometheus collectors registered in a given registry.
+//
+// go-kit constructors (NewCounterFrom etc.) register collectors in the global Prometheus registry, so creating metrics
+// of a second node in the same process (e.g. in tests, or in node.MultiNode) panics with duplicate registration.
+// Every node uses its own registry instead.
+package metrics
+
+import (
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// NewRegistry returns Prometheus registry with Go runtime and process collectors (like the global registry).
+func NewRegistry() *stdprometheus.Registry {
+	registry := stdprometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// NewCounterFrom constructs and registers Prometheus CounterVec, and returns usable Counter object.
+func NewCounterFrom(registerer stdprometheus.Registerer, opts stdprometheus.CounterOpts, labelNames []string) *prometheus.Counter {
+	cv := stdprometheus.NewCounterVec(opts, labelNames)
+	registerer.MustRegister(cv)
+	return prometheus.NewCounter(cv)
+}
+
+// NewGaugeFrom constructs and registers Prometheus GaugeVec, and returns usable Gauge object.
+func NewGaugeFrom(registerer stdprometheus.Registerer, opts stdprometheus.GaugeOpts, labelNames []string) *prometheus.Gauge {
+	gv := stdprometheus.NewGaugeVec(opts, labelNames)
+	registerer.MustRegister(gv)
+	return prometheus.NewGauge(gv)
+}
+
+// NewHistogramFrom constructs and registers Prometheus HistogramVec, and returns usable Histogram object.
+func NewHistogramFrom(registerer stdprometheus.Registerer, opts stdprometheus.HistogramOpts, labelNames []string) *prometheus.Histogram {
+	hv := stdprometheus.NewHistogramVec(opts, labelNames)
+	registerer.MustRegister(hv)
+	return prometheus.NewHistogram(hv)
+}
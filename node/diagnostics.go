@@ -0,0 +1,79 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	runtimepprof "runtime/pprof"
+
+	"github.com/celestiaorg/optimint/block"
+)
+
+// DebugState is a snapshot of node state, served by diagnostics endpoint.
+type DebugState struct {
+	ChainID    string
+	Aggregator bool
+	SeedMode   bool
+	Goroutines int
+	Peers      int
+	// MempoolTxs and MempoolBytes are the number and total size of transactions in the mempool.
+	MempoolTxs   int
+	MempoolBytes int64
+	// Block is the state of block manager (nil in seed mode).
+	Block *block.DebugState `json:",omitempty"`
+}
+
+// DebugState returns a snapshot of node state, including sync status and pending DA submissions. Node has to be
+// started.
+func (n *Node) DebugState() DebugState {
+	s := DebugState{
+		ChainID:      n.genesis.ChainID,
+		Aggregator:   n.conf.Aggregator,
+		SeedMode:     n.conf.P2P.SeedMode,
+		Goroutines:   runtime.NumGoroutine(),
+		Peers:        len(n.P2P.Peers()),
+		MempoolTxs:   n.Mempool.Size(),
+		MempoolBytes: n.Mempool.TxsBytes(),
+	}
+	if n.blockManager != nil {
+		bs := n.blockManager.DebugState()
+		s.Block = &bs
+	}
+	return s
+}
+
+// startDiagnosticsServer starts HTTP server used for debugging of running node. It serves net/http/pprof under
+// /debug/pprof/, full goroutine dump under /debug/goroutines and node state (as JSON) under /debug/state.
+func (n *Node) startDiagnosticsServer() *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/goroutines", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err := runtimepprof.Lookup("goroutine").WriteTo(w, 2); err != nil {
+			n.Logger.Error("failed to write goroutine dump", "error", err)
+		}
+	})
+	mux.HandleFunc("/debug/state", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(n.DebugState()); err != nil {
+			n.Logger.Error("failed to encode node state", "error", err)
+		}
+	})
+	srv := &http.Server{
+		Addr:    n.conf.Diagnostics.ListenAddress,
+		Handler: mux,
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			n.Logger.Error("diagnostics HTTP server ListenAndServe", "error", err)
+		}
+	}()
+	return srv
+}
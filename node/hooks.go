@@ -0,0 +1,49 @@
+package node
+
+import (
+	"github.com/celestiaorg/optimint/block"
+)
+
+// Hooks are callbacks invoked on node lifecycle events. They allow embedders to run custom logic, without
+// modifying block manager. Any of them can be nil.
+//
+// Block related hooks are called synchronously by block manager (see block.Hooks), so they should return quickly.
+type Hooks struct {
+	block.Hooks
+
+	// OnStart is called after all node services are started.
+	OnStart func()
+	// OnStop is called when node is stopping, before any service is stopped.
+	OnStop func()
+}
+
+// RegisterHooks registers callbacks invoked on node lifecycle events. Hooks should be registered before the node
+// is started. Block related hooks are ignored by seed nodes.
+func (n *Node) RegisterHooks(hooks Hooks) {
+	n.hooksMtx.Lock()
+	n.hooks = append(n.hooks, hooks)
+	n.hooksMtx.Unlock()
+	if n.blockManager != nil {
+		n.blockManager.AddHooks(hooks.Hooks)
+	}
+}
+
+func (n *Node) onStart() {
+	n.hooksMtx.RLock()
+	defer n.hooksMtx.RUnlock()
+	for _, h := range n.hooks {
+		if h.OnStart != nil {
+			h.OnStart()
+		}
+	}
+}
+
+func (n *Node) onStop() {
+	n.hooksMtx.RLock()
+	defer n.hooksMtx.RUnlock()
+	for _, h := range n.hooks {
+		if h.OnStop != nil {
+			h.OnStop()
+		}
+	}
+}
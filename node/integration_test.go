@@ -3,10 +3,14 @@ package node
 import (
 	"context"
 	"crypto/rand"
+	"io"
 	mrand "math/rand"
+	"net"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -20,13 +24,17 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/types"
 
+	"github.com/celestiaorg/optimint/block"
 	"github.com/celestiaorg/optimint/config"
 	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/mempool"
 	"github.com/celestiaorg/optimint/mocks"
+	optypes "github.com/celestiaorg/optimint/types"
 )
 
 func TestAggregatorMode(t *testing.T) {
@@ -35,6 +43,7 @@ func TestAggregatorMode(t *testing.T) {
 
 	app := &mocks.Application{}
 	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
 	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
 	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
 	app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{})
@@ -48,7 +57,7 @@ func TestAggregatorMode(t *testing.T) {
 		BlockTime:   1 * time.Second,
 		NamespaceID: [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
 	}
-	node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock", Aggregator: true, BlockManagerConfig: blockManagerConfig}, key, proxy.NewLocalClientCreator(app), &types.GenesisDoc{ChainID: "test"}, log.TestingLogger())
+	node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock", Aggregator: true, BlockManagerConfig: blockManagerConfig}, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger())
 	require.NoError(err)
 	require.NotNil(node)
 
@@ -80,8 +89,540 @@ func TestAggregatorMode(t *testing.T) {
 	cancel()
 }
 
+func TestInitialHeight(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	// genesis of a chain restarted from state exported at height 1000
+	genesis := createGenesis(key, t)
+	genesis.InitialHeight = 1001
+	genesis.AppHash = make([]byte, 32)
+	_, _ = rand.Read(genesis.AppHash)
+
+	blockManagerConfig := config.BlockManagerConfig{
+		BlockTime:   100 * time.Millisecond,
+		NamespaceID: [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+	node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock", Aggregator: true, BlockManagerConfig: blockManagerConfig}, key, proxy.NewLocalClientCreator(app), genesis, log.TestingLogger())
+	require.NoError(err)
+	require.NotNil(node)
+
+	err = node.Start()
+	require.NoError(err)
+	time.Sleep(500 * time.Millisecond)
+	err = node.Stop()
+	require.NoError(err)
+
+	assert.Equal(uint64(1001), node.Store.Base())
+	assert.Greater(node.Store.Height(), uint64(1001))
+
+	first, err := node.Store.LoadBlock(1001)
+	require.NoError(err)
+	assert.Equal([]byte(genesis.AppHash), first.Header.AppHash[:])
+	assert.Equal(uint64(0), first.LastCommit.Height)
+
+	second, err := node.Store.LoadBlock(1002)
+	require.NoError(err)
+	assert.Equal(uint64(1001), second.LastCommit.Height)
+	assert.Equal(first.Header.Hash(), second.Header.LastHeaderHash)
+}
+
+func TestSoftAndFinalizedBlockEvents(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	blockManagerConfig := config.BlockManagerConfig{BlockTime: 100 * time.Millisecond}
+	genesis := createGenesis(key, t)
+	node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock", Aggregator: true, BlockManagerConfig: blockManagerConfig}, key, proxy.NewLocalClientCreator(app), genesis, log.TestingLogger())
+	require.NoError(err)
+
+	softSub, err := node.EventBus().Subscribe(context.Background(), "test", optypes.EventQueryNewSoftBlock, 100)
+	require.NoError(err)
+	finalizedSub, err := node.EventBus().Subscribe(context.Background(), "test", optypes.EventQueryNewFinalizedBlock, 100)
+	require.NoError(err)
+
+	require.NoError(node.Start())
+	defer func() {
+		assert.NoError(node.Stop())
+	}()
+
+	select {
+	case msg := <-softSub.Out():
+		data, ok := msg.Data().(optypes.EventDataNewSoftBlock)
+		require.True(ok)
+		assert.Equal(int64(1), data.Header.Height)
+		assert.Equal(genesis.ChainID, data.Header.ChainID)
+	case <-time.After(time.Second):
+		t.Fatal("no soft block event")
+	}
+
+	select {
+	case msg := <-finalizedSub.Out():
+		data, ok := msg.Data().(optypes.EventDataNewFinalizedBlock)
+		require.True(ok)
+		assert.Equal(int64(1), data.Header.Height)
+		assert.NotZero(data.DAHeight)
+	case <-time.After(time.Second):
+		t.Fatal("no finalized block event")
+	}
+}
+
 // TestTxGossipingAndAggregation setups a network of nodes, with single aggregator and multiple producers.
 // Nodes should gossip transactions and aggregator node should produce blocks.
+// epochDALC is a mock DA layer client with DA height controlled by test.
+type epochDALC struct {
+	*mockda.MockDataAvailabilityLayerClient
+	height uint64
+}
+
+func (d *epochDALC) LatestHeight() da.DAResult {
+	return da.DAResult{Code: da.StatusSuccess, DAHeight: atomic.LoadUint64(&d.height)}
+}
+
+func TestDAEpochAggregation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	dalc := &epochDALC{MockDataAvailabilityLayerClient: &mockda.MockDataAvailabilityLayerClient{}, height: 1}
+	require.NoError(dalc.Init(nil, store.NewDefaultInMemoryKVStore(), log.TestingLogger()))
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	conf := config.NodeConfig{
+		Aggregator: true,
+		BlockManagerConfig: config.BlockManagerConfig{
+			BlockTime:     10 * time.Millisecond,
+			DAEpoch:       2,
+			DAStartHeight: 1,
+		},
+	}
+	node, err := NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger(), WithDALayerClient(dalc))
+	require.NoError(err)
+	require.NoError(node.Start())
+	defer func() {
+		assert.NoError(node.Stop())
+	}()
+
+	// DA height 2 is still in the initial epoch
+	atomic.StoreUint64(&dalc.height, 2)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(uint64(0), node.Store.Height())
+
+	atomic.StoreUint64(&dalc.height, 3)
+	require.Eventually(func() bool { return node.Store.Height() == 1 }, time.Second, 10*time.Millisecond)
+
+	// two epochs passed (starting at DA heights 5 and 7)
+	atomic.StoreUint64(&dalc.height, 7)
+	require.Eventually(func() bool { return node.Store.Height() == 3 }, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(uint64(3), node.Store.Height())
+}
+
+func TestManualBlockProduction(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	conf := config.NodeConfig{
+		DALayer:            "mock",
+		Aggregator:         true,
+		BlockManagerConfig: config.BlockManagerConfig{BlockTime: time.Second},
+	}
+	clock := block.NewManualClock(time.Now())
+	node, err := NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger(), WithClock(clock))
+	require.NoError(err)
+	assert.Error(node.ProduceBlockNow(context.Background()))
+	require.NoError(node.Start())
+	defer func() {
+		assert.NoError(node.Stop())
+	}()
+
+	// first block is produced right after start, next one after block time
+	clock.BlockUntil(1)
+	assert.Equal(uint64(1), node.Store.Height())
+	clock.Advance(time.Second - time.Millisecond)
+	clock.BlockUntil(1)
+	assert.Equal(uint64(1), node.Store.Height())
+	clock.Advance(time.Millisecond)
+	clock.BlockUntil(1)
+	assert.Equal(uint64(2), node.Store.Height())
+
+	// blocks produced on demand
+	for h := uint64(3); h <= 5; h++ {
+		require.NoError(node.ProduceBlockNow(context.Background()))
+		assert.Equal(h, node.Store.Height())
+	}
+}
+
+func TestNodeHooks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	var mtx sync.Mutex
+	var events []string
+	record := func(event string) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		events = append(events, event)
+	}
+	recorded := func() []string {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return append([]string{}, events...)
+	}
+	hooks := Hooks{
+		Hooks: block.Hooks{
+			OnBlockProduced: func(b *optypes.Block) { record("produced " + strconv.FormatUint(b.Header.Height, 10)) },
+			OnBlockApplied:  func(b *optypes.Block) { record("applied " + strconv.FormatUint(b.Header.Height, 10)) },
+			OnDAIncluded: func(b *optypes.Block, daHeight uint64) {
+				record("included " + strconv.FormatUint(b.Header.Height, 10))
+			},
+		},
+		OnStop: func() { record("stop") },
+	}
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	conf := config.NodeConfig{
+		DALayer:            "mock",
+		Aggregator:         true,
+		BlockManagerConfig: config.BlockManagerConfig{BlockTime: time.Second},
+	}
+	clock := block.NewManualClock(time.Now())
+	node, err := NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger(), WithClock(clock), WithHooks(hooks))
+	require.NoError(err)
+	// block production starts concurrently with OnStart hooks, so start is tracked separately
+	var started int32
+	node.RegisterHooks(Hooks{OnStart: func() { atomic.AddInt32(&started, 1) }})
+
+	require.NoError(node.Start())
+	clock.BlockUntil(1)
+	assert.Equal([]string{"produced 1", "applied 1", "included 1"}, recorded())
+	assert.Equal(int32(1), atomic.LoadInt32(&started))
+
+	require.NoError(node.ProduceBlockNow(context.Background()))
+	require.NoError(node.Stop())
+	assert.Equal([]string{"produced 1", "applied 1", "included 1", "produced 2", "applied 2", "included 2", "stop"}, recorded())
+}
+
+func TestReplicaNode(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Twice()
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	replicationAddr := listener.Addr().String()
+	require.NoError(listener.Close())
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	genesis := createGenesis(key, t)
+	blockManagerConfig := config.BlockManagerConfig{BlockTime: 100 * time.Millisecond}
+	aggregator, err := NewNode(context.Background(), config.NodeConfig{
+		DALayer:            "mock",
+		Aggregator:         true,
+		BlockManagerConfig: blockManagerConfig,
+		Replication:        config.ReplicationConfig{ListenAddress: replicationAddr},
+	}, key, proxy.NewLocalClientCreator(app), genesis, log.TestingLogger())
+	require.NoError(err)
+
+	replicaKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	replica, err := NewNode(context.Background(), config.NodeConfig{
+		DALayer:            "mock",
+		BlockManagerConfig: blockManagerConfig,
+		Replication:        config.ReplicationConfig{Source: replicationAddr},
+	}, replicaKey, proxy.NewLocalClientCreator(app), genesis, log.TestingLogger())
+	require.NoError(err)
+	newBlockSub, err := replica.EventBus().Subscribe(context.Background(), "test", types.EventQueryNewBlock, 100)
+	require.NoError(err)
+
+	require.NoError(aggregator.Start())
+	require.NoError(replica.Start())
+	defer func() {
+		assert.NoError(replica.Stop())
+		assert.NoError(aggregator.Stop())
+	}()
+
+	// blocks are replicated without execution, events are published by replica
+	require.Eventually(func() bool { return replica.Store.Height() >= 3 }, 5*time.Second, 10*time.Millisecond)
+	for h := uint64(1); h <= 3; h++ {
+		expected, err := aggregator.Store.LoadBlock(h)
+		require.NoError(err)
+		replicated, err := replica.Store.LoadBlock(h)
+		require.NoError(err)
+		assert.Equal(expected.Hash(), replicated.Hash())
+	}
+	select {
+	case msg := <-newBlockSub.Out():
+		data, ok := msg.Data().(types.EventDataNewBlock)
+		require.True(ok)
+		assert.Equal(int64(1), data.Block.Height)
+	case <-time.After(time.Second):
+		t.Fatal("no new block event")
+	}
+}
+
+func TestPauseAggregation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	conf := config.NodeConfig{
+		DALayer:            "mock",
+		Aggregator:         true,
+		BlockManagerConfig: config.BlockManagerConfig{BlockTime: time.Second},
+	}
+	clock := block.NewManualClock(time.Now())
+	node, err := NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger(), WithClock(clock))
+	require.NoError(err)
+	require.NoError(node.Start())
+	defer func() {
+		assert.NoError(node.Stop())
+	}()
+	clock.BlockUntil(1)
+	require.Equal(uint64(1), node.Store.Height())
+
+	require.NoError(node.StopAggregating())
+	assert.False(node.Aggregating())
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Second)
+		clock.BlockUntil(1)
+	}
+	assert.Equal(uint64(1), node.Store.Height())
+	assert.Error(node.ProduceBlockNow(context.Background()))
+
+	// mempool is preserved while block production is paused
+	require.NoError(node.Mempool.CheckTx([]byte("tx"), nil, mempool.TxInfo{}))
+	assert.Equal(1, node.Mempool.Size())
+
+	require.NoError(node.StartAggregating())
+	assert.True(node.Aggregating())
+	clock.Advance(time.Second)
+	clock.BlockUntil(1)
+	assert.Equal(uint64(2), node.Store.Height())
+	assert.Equal(0, node.Mempool.Size())
+}
+
+func TestDevMode(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	conf := config.NodeConfig{
+		DALayer:            "mock",
+		Aggregator:         true,
+		BlockManagerConfig: config.BlockManagerConfig{BlockTime: time.Hour, DevMode: true},
+	}
+	clock := block.NewManualClock(time.Now())
+	node, err := NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger(), WithClock(clock))
+	require.NoError(err)
+	require.NoError(node.Start())
+	defer func() {
+		assert.NoError(node.Stop())
+	}()
+	clock.BlockUntil(1)
+	require.Equal(uint64(1), node.Store.Height())
+
+	// block is produced as soon as transaction is added to mempool
+	for h := uint64(2); h <= 3; h++ {
+		tx := []byte("tx" + strconv.FormatUint(h, 10))
+		require.NoError(node.Mempool.CheckTx(tx, nil, mempool.TxInfo{}))
+		require.Eventually(func() bool { return node.Store.Height() == h }, time.Second, 10*time.Millisecond)
+		b, err := node.Store.LoadBlock(h)
+		require.NoError(err)
+		require.Len(b.Data.Txs, 1)
+		assert.EqualValues(tx, b.Data.Txs[0])
+	}
+
+	// empty block is produced after idle interval
+	require.Eventually(func() bool {
+		clock.Advance(time.Hour)
+		return node.Store.Height() == 4
+	}, time.Second, 10*time.Millisecond)
+
+	conf.DAEpoch = 2
+	_, err = NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger())
+	require.Error(err)
+	assert.Contains(err.Error(), "dev mode")
+}
+
+func TestDAConfirmDepth(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	conf := config.NodeConfig{
+		DALayer:            "mock",
+		Aggregator:         true,
+		BlockManagerConfig: config.BlockManagerConfig{BlockTime: 50 * time.Millisecond, DAConfirmDepth: 2},
+	}
+	node, err := NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger())
+	require.NoError(err)
+	require.NoError(node.Start())
+	defer func() {
+		assert.NoError(node.Stop())
+	}()
+
+	// every block is submitted in separate DA block, so the last 2 blocks are never final
+	require.Eventually(func() bool { return node.FirmHeight() >= 3 }, 5*time.Second, 10*time.Millisecond)
+	firm := node.FirmHeight()
+	assert.LessOrEqual(firm, node.Store.Height()-2)
+	assert.True(node.IsFinal(firm))
+	assert.False(node.IsFinal(node.Store.Height()))
+}
+
+func TestMultiNode(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	prometheusAddr := listener.Addr().String()
+	require.NoError(listener.Close())
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	sharedConf := config.NodeConfig{
+		DALayer:         "mock",
+		P2P:             config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0"},
+		Instrumentation: config.InstrumentationConfig{Prometheus: true, PrometheusListenAddr: prometheusAddr, Namespace: "optimint"},
+	}
+	mn, err := NewMultiNode(context.Background(), sharedConf, key, log.TestingLogger())
+	require.NoError(err)
+
+	addChain := func(chainID string, namespaceID [8]byte) (*Node, error) {
+		app := &mocks.Application{}
+		app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+		app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+		app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+		app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+		app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+		genesis := createGenesis(key, t)
+		genesis.ChainID = chainID
+		conf := config.NodeConfig{
+			Aggregator:         true,
+			BlockManagerConfig: config.BlockManagerConfig{BlockTime: 100 * time.Millisecond, NamespaceID: namespaceID},
+			Instrumentation:    sharedConf.Instrumentation,
+		}
+		return mn.AddChain(conf, proxy.NewLocalClientCreator(app), genesis)
+	}
+
+	nodeA, err := addChain("chain-a", [8]byte{1})
+	require.NoError(err)
+	_, err = addChain("chain-a", [8]byte{2})
+	assert.Error(err)
+	_, err = addChain("chain-b", [8]byte{1})
+	assert.Error(err)
+
+	require.NoError(mn.Start())
+	defer func() {
+		assert.NoError(mn.Stop())
+	}()
+
+	// chains can be added to running MultiNode
+	nodeB, err := addChain("chain-b", [8]byte{2})
+	require.NoError(err)
+	assert.True(nodeB.IsRunning())
+	assert.ElementsMatch([]string{"chain-a", "chain-b"}, mn.ChainIDs())
+	assert.Equal(nodeA.P2P.Addrs(), nodeB.P2P.Addrs())
+
+	require.Eventually(func() bool {
+		return nodeA.Store.Height() >= 3 && nodeB.Store.Height() >= 3
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// blocks of both chains are stored in the same DA layer, but in separate namespaces
+	for _, n := range []*Node{nodeA, nodeB} {
+		res := n.dalc.(da.BlockRetriever).RetrieveBlock(1)
+		require.Equal(da.StatusSuccess, res.Code)
+		assert.Equal(n.conf.NamespaceID, res.Block.Header.NamespaceID)
+	}
+
+	// metrics of all the chains are served by MultiNode
+	resp, err := http.Get("http://" + prometheusAddr + "/metrics")
+	require.NoError(err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(err)
+	require.NoError(resp.Body.Close())
+	require.Equal(http.StatusOK, resp.StatusCode, string(body))
+	assert.Contains(string(body), `optimint_mempool_size{chain_id="chain-a"}`)
+	assert.Contains(string(body), `optimint_mempool_size{chain_id="chain-b"}`)
+	assert.Equal(1, strings.Count(string(body), "\ngo_goroutines "))
+
+	require.NoError(mn.RemoveChain("chain-b"))
+	assert.False(nodeB.IsRunning())
+	assert.Nil(mn.Node("chain-b"))
+	assert.Error(mn.RemoveChain("chain-b"))
+}
+
 func TestTxGossipingAndAggregation(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -158,6 +699,144 @@ func TestTxGossipingAndAggregation(t *testing.T) {
 	}
 }
 
+func TestBlockGossip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	keys := make([]crypto.PrivKey, 2)
+	for i := range keys {
+		keys[i], _, _ = crypto.GenerateEd25519Key(rand.Reader)
+	}
+	genesis := createGenesis(keys[0], t)
+	aggID, err := peer.IDFromPrivateKey(keys[0])
+	require.NoError(err)
+
+	nodes := make([]*Node, 2)
+	for i := range nodes {
+		app := &mocks.Application{}
+		app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+		app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+		app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+		app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+		app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+		p2pConfig := config.P2PConfig{
+			ListenAddress: "/ip4/127.0.0.1/tcp/" + strconv.Itoa(10200+i),
+			BlockGossip:   true,
+		}
+		if i > 0 {
+			p2pConfig.Seeds = "/ip4/127.0.0.1/tcp/10200/p2p/" + aggID.Pretty()
+		}
+		node, err := NewNode(context.Background(), config.NodeConfig{
+			P2P:                p2pConfig,
+			DALayer:            "mock",
+			Aggregator:         i == 0,
+			BlockManagerConfig: config.BlockManagerConfig{BlockTime: 200 * time.Millisecond},
+		}, keys[i], proxy.NewLocalClientCreator(app), genesis, log.TestingLogger().With("node", i))
+		require.NoError(err)
+		nodes[i] = node
+	}
+
+	// full node doesn't share DA layer with aggregator, so blocks can be received only via P2P
+	// block production starts when gossip mesh is formed
+	nodes[0].blockManager.StopAggregating()
+	for _, n := range nodes {
+		require.NoError(n.Start())
+	}
+	defer func() {
+		for _, n := range nodes {
+			assert.NoError(n.Stop())
+		}
+	}()
+	time.Sleep(time.Second)
+	nodes[0].blockManager.StartAggregating()
+
+	require.Eventually(func() bool { return nodes[1].Store.Height() >= 3 }, 10*time.Second, 50*time.Millisecond)
+	for h := uint64(1); h <= nodes[1].Store.Height(); h++ {
+		nodeBlock, err := nodes[1].Store.LoadBlock(h)
+		require.NoError(err)
+		aggBlock, err := nodes[0].Store.LoadBlock(h)
+		require.NoError(err)
+		assert.Equal(aggBlock, nodeBlock)
+	}
+	// blocks are not retrieved from DA layer, so they are not final
+	assert.Zero(nodes[1].FirmHeight())
+}
+
+func TestLightNode(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	keys := make([]crypto.PrivKey, 2)
+	for i := range keys {
+		keys[i], _, _ = crypto.GenerateEd25519Key(rand.Reader)
+	}
+	genesis := createGenesis(keys[0], t)
+	aggID, err := peer.IDFromPrivateKey(keys[0])
+	require.NoError(err)
+	dalc := &mockda.MockDataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(nil, store.NewDefaultInMemoryKVStore(), log.TestingLogger()))
+	require.NoError(dalc.Start())
+
+	nodes := make([]*Node, 2)
+	for i := range nodes {
+		app := &mocks.Application{}
+		app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+		app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+		app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+		app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+		app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+		p2pConfig := config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/" + strconv.Itoa(10300+i)}
+		if i > 0 {
+			p2pConfig.Seeds = "/ip4/127.0.0.1/tcp/10300/p2p/" + aggID.Pretty()
+		}
+		node, err := NewNode(context.Background(), config.NodeConfig{
+			P2P:                p2pConfig,
+			DALayer:            "mock",
+			Aggregator:         i == 0,
+			Light:              i > 0,
+			DASamples:          16,
+			BlockManagerConfig: config.BlockManagerConfig{BlockTime: 200 * time.Millisecond},
+		}, keys[i], proxy.NewLocalClientCreator(app), genesis, log.TestingLogger().With("node", i))
+		require.NoError(err)
+		node.dalc = dalc
+		node.blockManager.SetDALC(dalc)
+		nodes[i] = node
+	}
+	light := nodes[1]
+	headerSub, err := light.EventBus().Subscribe(context.Background(), "test", types.EventQueryNewBlockHeader, 100)
+	require.NoError(err)
+
+	// block production starts when gossip mesh is formed
+	nodes[0].blockManager.StopAggregating()
+	for _, n := range nodes {
+		require.NoError(n.Start())
+	}
+	defer func() {
+		for _, n := range nodes {
+			assert.NoError(n.Stop())
+		}
+	}()
+	time.Sleep(time.Second)
+	nodes[0].blockManager.StartAggregating()
+
+	require.Eventually(func() bool {
+		header := light.blockManager.LightHeader()
+		return header != nil && header.Height >= 3
+	}, 10*time.Second, 50*time.Millisecond)
+	aggBlock, err := nodes[0].Store.LoadBlock(light.blockManager.LightHeader().Height)
+	require.NoError(err)
+	assert.Equal(aggBlock.Header, *light.blockManager.LightHeader())
+	// light node doesn't retrieve nor execute blocks
+	assert.Zero(light.Store.Height())
+	select {
+	case msg := <-headerSub.Out():
+		_, ok := msg.Data().(types.EventDataNewBlockHeader)
+		assert.True(ok)
+	case <-time.After(time.Second):
+		t.Fatal("no new block header event")
+	}
+}
+
 func createNodes(num int, wg *sync.WaitGroup, t *testing.T) ([]*Node, []*mocks.Application) {
 	t.Helper()
 
@@ -167,20 +846,39 @@ func createNodes(num int, wg *sync.WaitGroup, t *testing.T) ([]*Node, []*mocks.A
 		keys[i], _, _ = crypto.GenerateEd25519Key(rand.Reader)
 	}
 
+	// first node is the aggregator - its key is used by other nodes to verify gossiped headers
+	genesis := createGenesis(keys[0], t)
+
 	nodes := make([]*Node, num)
 	apps := make([]*mocks.Application, num)
 	dalc := &mockda.MockDataAvailabilityLayerClient{}
 	_ = dalc.Init(nil, store.NewDefaultInMemoryKVStore(), log.TestingLogger())
 	_ = dalc.Start()
-	nodes[0], apps[0] = createNode(0, true, dalc, keys, wg, t)
+	nodes[0], apps[0] = createNode(0, true, dalc, keys, genesis, wg, t)
 	for i := 1; i < num; i++ {
-		nodes[i], apps[i] = createNode(i, false, dalc, keys, wg, t)
+		nodes[i], apps[i] = createNode(i, false, dalc, keys, genesis, wg, t)
 	}
 
 	return nodes, apps
 }
 
-func createNode(n int, aggregator bool, dalc da.DataAvailabilityLayerClient, keys []crypto.PrivKey, wg *sync.WaitGroup, t *testing.T) (*Node, *mocks.Application) {
+func createGenesis(sequencerKey crypto.PrivKey, t *testing.T) *types.GenesisDoc {
+	t.Helper()
+	rawKey, err := sequencerKey.GetPublic().Raw()
+	require.NoError(t, err)
+	pubKey := ed25519.PubKey(rawKey)
+	return &types.GenesisDoc{
+		ChainID: "test",
+		Validators: []types.GenesisValidator{{
+			Address: pubKey.Address(),
+			PubKey:  pubKey,
+			Power:   1,
+			Name:    "sequencer",
+		}},
+	}
+}
+
+func createNode(n int, aggregator bool, dalc da.DataAvailabilityLayerClient, keys []crypto.PrivKey, genesis *types.GenesisDoc, wg *sync.WaitGroup, t *testing.T) (*Node, *mocks.Application) {
 	t.Helper()
 	require := require.New(t)
 	// nodes will listen on consecutive ports on local interface
@@ -206,6 +904,7 @@ func createNode(n int, aggregator bool, dalc da.DataAvailabilityLayerClient, key
 
 	app := &mocks.Application{}
 	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
 	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
 	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
 	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
@@ -224,7 +923,7 @@ func createNode(n int, aggregator bool, dalc da.DataAvailabilityLayerClient, key
 		},
 		keys[n],
 		proxy.NewLocalClientCreator(app),
-		&types.GenesisDoc{ChainID: "test"},
+		genesis,
 		log.TestingLogger().With("node", n))
 	require.NoError(err)
 	require.NotNil(node)
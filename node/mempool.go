@@ -6,6 +6,9 @@ import (
 
 	"github.com/libp2p/go-libp2p-core/peer"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
+
+	"github.com/celestiaorg/optimint/mempool"
+	"github.com/celestiaorg/optimint/p2p"
 )
 
 const (
@@ -81,3 +84,25 @@ func newMempoolIDs() *mempoolIDs {
 		nextID:    1, // reserve unknownPeerID(0) for mempoolReactor.BroadcastTx
 	}
 }
+
+// mempoolSource exposes pending transactions of the mempool to P2P mempool sync protocol.
+type mempoolSource struct {
+	mempool mempool.Mempool
+}
+
+var _ p2p.MempoolSource = mempoolSource{}
+
+// PendingTxs implements p2p.MempoolSource interface.
+func (s mempoolSource) PendingTxs(max int) [][]byte {
+	txs := s.mempool.ReapMaxTxs(max)
+	pending := make([][]byte, len(txs))
+	for i := range txs {
+		pending[i] = txs[i]
+	}
+	return pending
+}
+
+// HasTx implements p2p.MempoolSource interface.
+func (s mempoolSource) HasTx(key p2p.TxKey) bool {
+	return s.mempool.HasTxByKey(key)
+}
@@ -0,0 +1,226 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/proxy"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"go.uber.org/multierr"
+
+	"github.com/celestiaorg/optimint/config"
+	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/da/registry"
+	optmetrics "github.com/celestiaorg/optimint/metrics"
+	"github.com/celestiaorg/optimint/p2p"
+	"github.com/celestiaorg/optimint/store"
+)
+
+// MultiNode runs multiple independent Optimint nodes (one per chain) in a single process.
+//
+// All the nodes share libp2p host, connection to data availability layer and Prometheus server. Every node has its own
+// store, mempool, application connection, DA namespace and metrics registry.
+type MultiNode struct {
+	service.BaseService
+
+	conf    config.NodeConfig
+	nodeKey crypto.PrivKey
+
+	host *p2p.Host
+	dalc da.DataAvailabilityLayerClient
+
+	prometheusSrv *http.Server
+	// promRegistry contains Go runtime and process metrics, served together with metrics of all the chains
+	promRegistry *prometheus.Registry
+
+	mtx   sync.Mutex
+	nodes map[string]*Node
+
+	ctx context.Context
+}
+
+// NewMultiNode creates new MultiNode.
+//
+// P2P and data availability layer settings from given configuration are shared by all the chains.
+// Data availability layer client has to support namespace scoping (see da.NamespaceScoper).
+func NewMultiNode(ctx context.Context, conf config.NodeConfig, nodeKey crypto.PrivKey, logger log.Logger) (*MultiNode, error) {
+	dalc := registry.GetClient(conf.DALayer)
+	if dalc == nil {
+		return nil, fmt.Errorf("couldn't get data availability client named '%s'", conf.DALayer)
+	}
+	if _, ok := dalc.(da.NamespaceScoper); !ok {
+		return nil, fmt.Errorf("data availability client '%s' can't be shared by multiple chains", conf.DALayer)
+	}
+
+	var kv store.KVStore
+	if conf.RootDir == "" && conf.DBPath == "" { // this is used for testing
+		logger.Info("WARNING: working in in-memory mode")
+		kv = store.NewDefaultInMemoryKVStore()
+	} else {
+		kv = store.NewDefaultKVStore(conf.RootDir, conf.DBPath, "optimint-shared")
+	}
+	err := dalc.Init([]byte(conf.DAConfig), store.NewPrefixKV(kv, dalcPrefix), logger.With("module", "da_client"))
+	if err != nil {
+		return nil, fmt.Errorf("data availability layer client initialization error: %w", err)
+	}
+
+	host, err := p2p.NewHost(conf.P2P, nodeKey, logger.With("module", "p2p"))
+	if err != nil {
+		return nil, err
+	}
+
+	mn := &MultiNode{
+		conf:         conf,
+		nodeKey:      nodeKey,
+		host:         host,
+		dalc:         dalc,
+		promRegistry: optmetrics.NewRegistry(),
+		nodes:        make(map[string]*Node),
+		ctx:          ctx,
+	}
+	mn.BaseService = *service.NewBaseService(logger, "MultiNode", mn)
+
+	return mn, nil
+}
+
+// AddChain creates a node for given chain. If MultiNode is already running, the node is started immediately.
+//
+// Chain ID and namespace ID have to be unique. P2P, data availability layer and Prometheus listen address settings
+// from chain configuration are ignored. Metrics of the chain are labeled with chain ID.
+func (mn *MultiNode) AddChain(conf config.NodeConfig, clientCreator proxy.ClientCreator, genesis *tmtypes.GenesisDoc, opts ...Option) (*Node, error) {
+	if conf.P2P.SeedMode {
+		return nil, fmt.Errorf("chain %s: seed mode is not supported by MultiNode", genesis.ChainID)
+	}
+	err := applyGenesisExtension(&conf)
+	if err != nil {
+		return nil, err
+	}
+
+	mn.mtx.Lock()
+	defer mn.mtx.Unlock()
+
+	if _, ok := mn.nodes[genesis.ChainID]; ok {
+		return nil, fmt.Errorf("chain %s is already running", genesis.ChainID)
+	}
+	for chainID, n := range mn.nodes {
+		if n.conf.NamespaceID == conf.NamespaceID {
+			return nil, fmt.Errorf("chain %s: namespace ID %X is already used by chain %s", genesis.ChainID, conf.NamespaceID, chainID)
+		}
+	}
+
+	dalc := mn.dalc.(da.NamespaceScoper).WithNamespace(conf.NamespaceID)
+	// metrics of all the chains are served by MultiNode
+	conf.Instrumentation.PrometheusListenAddr = ""
+	opts = append(opts, WithP2PHost(mn.host), WithDALayerClient(dalc), WithMetricsRegistry(prometheus.NewRegistry()))
+	n, err := NewNode(mn.ctx, conf, mn.nodeKey, clientCreator, genesis, mn.Logger.With("chain", genesis.ChainID), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if mn.IsRunning() {
+		if err := n.Start(); err != nil {
+			return nil, fmt.Errorf("error while starting chain %s: %w", genesis.ChainID, err)
+		}
+	}
+	mn.nodes[genesis.ChainID] = n
+
+	return n, nil
+}
+
+// RemoveChain stops and removes the node for given chain.
+func (mn *MultiNode) RemoveChain(chainID string) error {
+	mn.mtx.Lock()
+	defer mn.mtx.Unlock()
+
+	n, ok := mn.nodes[chainID]
+	if !ok {
+		return fmt.Errorf("chain %s is not running", chainID)
+	}
+	delete(mn.nodes, chainID)
+	if n.IsRunning() {
+		return n.Stop()
+	}
+	return nil
+}
+
+// Node returns the node for given chain, or nil if there is no such chain.
+func (mn *MultiNode) Node(chainID string) *Node {
+	mn.mtx.Lock()
+	defer mn.mtx.Unlock()
+	return mn.nodes[chainID]
+}
+
+// ChainIDs returns IDs of all chains hosted by MultiNode.
+func (mn *MultiNode) ChainIDs() []string {
+	mn.mtx.Lock()
+	defer mn.mtx.Unlock()
+	ids := make([]string, 0, len(mn.nodes))
+	for chainID := range mn.nodes {
+		ids = append(ids, chainID)
+	}
+	return ids
+}
+
+// gatherMetrics collects metrics of all the chains.
+func (mn *MultiNode) gatherMetrics() ([]*dto.MetricFamily, error) {
+	mn.mtx.Lock()
+	gatherers := prometheus.Gatherers{mn.promRegistry}
+	for _, n := range mn.nodes {
+		gatherers = append(gatherers, n.MetricsRegistry())
+	}
+	mn.mtx.Unlock()
+	return gatherers.Gather()
+}
+
+// OnStart is a part of Service interface.
+func (mn *MultiNode) OnStart() error {
+	if mn.conf.Instrumentation.Prometheus && mn.conf.Instrumentation.PrometheusListenAddr != "" {
+		mn.prometheusSrv = startPrometheusServer(mn.conf.Instrumentation, mn.promRegistry, prometheus.GathererFunc(mn.gatherMetrics), mn.Logger)
+	}
+	mn.Logger.Info("starting shared P2P host")
+	err := mn.host.Start(mn.ctx)
+	if err != nil {
+		return fmt.Errorf("error while starting P2P host: %w", err)
+	}
+	err = mn.dalc.Start()
+	if err != nil {
+		return fmt.Errorf("error while starting data availability layer client: %w", err)
+	}
+
+	mn.mtx.Lock()
+	defer mn.mtx.Unlock()
+	for chainID, n := range mn.nodes {
+		if err := n.Start(); err != nil {
+			return fmt.Errorf("error while starting chain %s: %w", chainID, err)
+		}
+	}
+	return nil
+}
+
+// OnStop is a part of Service interface.
+func (mn *MultiNode) OnStop() {
+	var err error
+	mn.mtx.Lock()
+	for _, n := range mn.nodes {
+		if n.IsRunning() {
+			err = multierr.Append(err, n.Stop())
+		}
+	}
+	mn.mtx.Unlock()
+
+	err = multierr.Append(err, mn.dalc.Stop())
+	err = multierr.Append(err, mn.host.Close())
+	if mn.prometheusSrv != nil {
+		err = multierr.Append(err, mn.prometheusSrv.Shutdown(context.Background()))
+	}
+	if err != nil {
+		mn.Logger.Error("errors while stopping multi node:", "errors", err)
+	}
+}
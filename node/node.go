@@ -2,30 +2,53 @@ package node
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync"
 
+	"github.com/go-kit/kit/metrics"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/multierr"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	llcfg "github.com/tendermint/tendermint/config"
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 	corep2p "github.com/tendermint/tendermint/p2p"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	"github.com/tendermint/tendermint/proxy"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/celestiaorg/optimint/block"
+	"github.com/celestiaorg/optimint/bridge"
+	"github.com/celestiaorg/optimint/checkpoint"
 	"github.com/celestiaorg/optimint/config"
 	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/da/account"
 	"github.com/celestiaorg/optimint/da/registry"
+	"github.com/celestiaorg/optimint/journal"
+	optlog "github.com/celestiaorg/optimint/log"
 	"github.com/celestiaorg/optimint/mempool"
+	optmetrics "github.com/celestiaorg/optimint/metrics"
 	"github.com/celestiaorg/optimint/p2p"
+	optproxy "github.com/celestiaorg/optimint/proxy"
+	"github.com/celestiaorg/optimint/replication"
+	"github.com/celestiaorg/optimint/settlement"
+	slregistry "github.com/celestiaorg/optimint/settlement/registry"
+	"github.com/celestiaorg/optimint/state"
 	"github.com/celestiaorg/optimint/state/indexer"
 	blockidxkv "github.com/celestiaorg/optimint/state/indexer/block/kv"
 	"github.com/celestiaorg/optimint/state/txindex"
 	"github.com/celestiaorg/optimint/state/txindex/kv"
+	"github.com/celestiaorg/optimint/statesync"
 	"github.com/celestiaorg/optimint/store"
 	"github.com/celestiaorg/optimint/types"
 )
@@ -35,6 +58,12 @@ var (
 	mainPrefix    = []byte{0}
 	dalcPrefix    = []byte{1}
 	indexerPrefix = []byte{2}
+	p2pPrefix     = []byte{3}
+	bridgePrefix  = []byte{4}
+	slPrefix      = []byte{5}
+	statsPrefix   = []byte{6}
+	originsPrefix = []byte{7}
+	journalPrefix = []byte{8}
 )
 
 // Node represents a client node in Optimint network.
@@ -43,6 +72,8 @@ type Node struct {
 	service.BaseService
 	eventBus *tmtypes.EventBus
 	proxyApp proxy.AppConns
+	// appInfo contains versions of the application, queried at startup
+	appInfo abci.ResponseInfo
 
 	genesis *tmtypes.GenesisDoc
 
@@ -52,36 +83,80 @@ type Node struct {
 	// TODO(tzdybal): consider extracting "mempool reactor"
 	Mempool      mempool.Mempool
 	mempoolIDs   *mempoolIDs
+	minGasPrice  mempool.GasPrice
 	incomingTxCh chan *p2p.GossipMessage
 
 	Store        store.Store
 	blockManager *block.Manager
 	dalc         da.DataAvailabilityLayerClient
+	daAccount    *account.Account
+	// slc is set if state commitments are posted to settlement layer
+	slc         settlement.SettlementLayerClient
+	slSubmitter *settlement.Submitter
 
 	TxIndexer      txindex.TxIndexer
 	BlockIndexer   indexer.BlockIndexer
 	IndexerService *txindex.IndexerService
 
+	TxTracer   *block.TxTracer
+	ChainStats *block.ChainStats
+	// TxOrigins counts invalid transactions per peer and RPC client
+	TxOrigins *TxOrigins
+	// BridgeEvents is set if extraction of events for settlement bridges is enabled
+	BridgeEvents *bridge.Extractor
+	// EventJournal is set if durable journal of block events is enabled
+	EventJournal *journal.Journal
+	// Checkpoints is set if periodic checkpoints of the chain state are enabled
+	Checkpoints   *checkpoint.Service
+	prometheusSrv *http.Server
+	// promRegistry contains Prometheus metrics of the node, served by prometheusSrv
+	promRegistry *prometheus.Registry
+	// panics counts panics recovered in goroutines of the node (see goLoop)
+	panics metrics.Counter
+	// diagnosticsSrv is set if diagnostics endpoint is enabled (see config.DiagnosticsConfig)
+	diagnosticsSrv *http.Server
+
+	replicationSrv *replication.Server
+	replica        *replication.Replica
+
+	snapshotPublisher *statesync.Publisher
+	// validityProofs is set if aggregator generates validity proofs of blocks
+	validityProofs bool
+
+	hooks    []Hooks
+	hooksMtx sync.RWMutex
+
 	// keep context here only because of API compatibility
 	// - it's used in `OnStart` (defined in service.Service interface)
 	ctx context.Context
 }
 
 // NewNode creates new Optimint node.
-func NewNode(ctx context.Context, conf config.NodeConfig, nodeKey crypto.PrivKey, clientCreator proxy.ClientCreator, genesis *tmtypes.GenesisDoc, logger log.Logger) (*Node, error) {
-	proxyApp := proxy.NewAppConns(clientCreator)
-	proxyApp.SetLogger(logger.With("module", "proxy"))
-	if err := proxyApp.Start(); err != nil {
-		return nil, fmt.Errorf("error starting proxy app connections: %w", err)
+// If nodeKey is nil, P2P identity key is loaded from node data directory (and generated, if it doesn't exist).
+func NewNode(ctx context.Context, conf config.NodeConfig, nodeKey crypto.PrivKey, clientCreator proxy.ClientCreator, genesis *tmtypes.GenesisDoc, logger log.Logger, opts ...Option) (*Node, error) {
+	var nodeOpts options
+	for _, opt := range opts {
+		opt(&nodeOpts)
 	}
 
-	eventBus := tmtypes.NewEventBus()
-	eventBus.SetLogger(logger.With("module", "events"))
-	if err := eventBus.Start(); err != nil {
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if nodeOpts.verifier != nil && nodeOpts.prover == nil {
+		return nil, errors.New("validity proof verification requires validity prover")
+	}
+
+	logger, err := optlog.FilterByModule(logger, conf.LogLevel)
+	if err != nil {
 		return nil, err
 	}
 
-	client, err := p2p.NewClient(conf.P2P, nodeKey, genesis.ChainID, logger.With("module", "p2p"))
+	err = types.ValidateGenesis(genesis)
+	if err != nil {
+		return nil, err
+	}
+	err = applyGenesisExtension(&conf)
 	if err != nil {
 		return nil, err
 	}
@@ -96,16 +171,87 @@ func NewNode(ctx context.Context, conf config.NodeConfig, nodeKey crypto.PrivKey
 	mainKV := store.NewPrefixKV(baseKV, mainPrefix)
 	dalcKV := store.NewPrefixKV(baseKV, dalcPrefix)
 	indexerKV := store.NewPrefixKV(baseKV, indexerPrefix)
+	p2pKV := store.NewPrefixKV(baseKV, p2pPrefix)
+
+	if nodeKey == nil && (conf.RootDir != "" || conf.DBPath != "") {
+		nodeKey, err = p2p.LoadOrGenNodeKey(nodeKeyPath(conf))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load node key: %w", err)
+		}
+	}
+	client, err := createP2PClient(conf.P2P, nodeKey, genesis.ChainID, p2pKV, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if nodeOpts.p2pHost != nil {
+		client.SetHost(nodeOpts.p2pHost)
+	}
+
+	if conf.P2P.SeedMode {
+		node := newSeedNode(ctx, conf, client, genesis, logger)
+		for _, hooks := range nodeOpts.hooks {
+			node.RegisterHooks(hooks)
+		}
+		return node, nil
+	}
+
+	appConns := optproxy.NewSupervisedAppConns(clientCreator, conf.ABCI)
+	appConns.SetLogger(logger.With("module", "proxy"))
+	if err := appConns.Start(); err != nil {
+		return nil, fmt.Errorf("error starting proxy app connections: %w", err)
+	}
+	proxyApp := optproxy.NewLimitedAppConns(appConns, conf.ABCI)
+
+	eventBus := tmtypes.NewEventBus()
+	eventBus.SetLogger(logger.With("module", "events"))
+	if err := eventBus.Start(); err != nil {
+		return nil, err
+	}
 
 	s := store.New(mainKV)
+	if conf.StoreCacheSize > 0 {
+		s, err = store.NewCachedStore(s, conf.StoreCacheSize)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	dalc := registry.GetClient(conf.DALayer)
+	dalc := nodeOpts.dalc
 	if dalc == nil {
-		return nil, fmt.Errorf("couldn't get data availability client named '%s'", conf.DALayer)
+		dalc = registry.GetClient(conf.DALayer)
+		if dalc == nil {
+			return nil, fmt.Errorf("couldn't get data availability client named '%s'", conf.DALayer)
+		}
+		err = dalc.Init([]byte(conf.DAConfig), dalcKV, logger.With("module", "da_client"))
+		if err != nil {
+			return nil, fmt.Errorf("data availability layer client initialization error: %w", err)
+		}
 	}
-	err = dalc.Init([]byte(conf.DAConfig), dalcKV, logger.With("module", "da_client"))
-	if err != nil {
-		return nil, fmt.Errorf("data availability layer client initialization error: %w", err)
+
+	slc := nodeOpts.slc
+	if slc == nil && conf.Settlement.Layer != "" {
+		slc = slregistry.GetClient(conf.Settlement.Layer)
+		if slc == nil {
+			return nil, fmt.Errorf("couldn't get settlement layer client named '%s'", conf.Settlement.Layer)
+		}
+		err = slc.Init([]byte(conf.Settlement.Config), store.NewPrefixKV(baseKV, slPrefix), logger.With("module", "settlement_client"))
+		if err != nil {
+			return nil, fmt.Errorf("settlement layer client initialization error: %w", err)
+		}
+	}
+	if slc != nil && conf.Settlement.Epoch == 0 {
+		return nil, errors.New("settlement epoch has to be positive")
+	}
+
+	snapshotStore, _ := dalc.(da.SnapshotStore)
+	if (conf.Snapshot.Sync || conf.Snapshot.PublishInterval > 0) && snapshotStore == nil {
+		return nil, errors.New("snapshots are enabled, but data availability layer client doesn't store snapshots")
+	}
+	if conf.Snapshot.Sync && s.Height() == 0 {
+		if _, err := statesync.Restore(proxyApp.Snapshot(), proxyApp.Query(), snapshotStore, s, logger.With("module", "statesync")); err != nil {
+			return nil, fmt.Errorf("state sync error: %w", err)
+		}
 	}
 
 	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(conf, indexerKV, eventBus, logger)
@@ -113,13 +259,132 @@ func NewNode(ctx context.Context, conf config.NodeConfig, nodeKey crypto.PrivKey
 		return nil, err
 	}
 
-	mp := mempool.NewCListMempool(llcfg.DefaultMempoolConfig(), proxyApp.Mempool(), 0)
+	metricsRegistry := nodeOpts.metricsRegistry
+	if metricsRegistry == nil {
+		metricsRegistry = optmetrics.NewRegistry()
+	}
+	mempoolMetrics, blockMetrics, accountMetrics := metricsProvider(conf.Instrumentation, metricsRegistry, genesis.ChainID)
+	daAccount, err := createDAAccount(conf.DAAccount, dalc, accountMetrics, eventBus, logger)
+	if err != nil {
+		return nil, err
+	}
+	txTracer := block.NewTxTracer(block.DefaultTxTraceSize, blockMetrics)
+	txOrigins, err := NewTxOrigins(store.NewPrefixKV(baseKV, originsPrefix), uint64(conf.RPC.MaxInvalidTxsPerMinute), logger.With("module", "mempool"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transaction origins: %w", err)
+	}
+
+	// mempool checks are updated after every block, initial checks are based on last known state
+	lastState, err := s.LoadState()
+	if err != nil {
+		lastState, err = state.NewFromGenesisDoc(genesis)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var minGasPrice mempool.GasPrice
+	if conf.MinGasPrice != "" {
+		minGasPrice, err = mempool.ParseGasPrice(conf.MinGasPrice)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var maxBlobSize uint64
+	if limiter, ok := dalc.(da.BlobSizeLimiter); ok {
+		maxBlobSize = limiter.MaxBlobSize()
+	}
+	if conf.DAMaxRetrieveSize > 0 {
+		if maxBlobSize > conf.DAMaxRetrieveSize {
+			return nil, fmt.Errorf("max DA blob size %d exceeds max retrieve size %d", maxBlobSize, conf.DAMaxRetrieveSize)
+		}
+		if limiter, ok := dalc.(da.RetrieveSizeLimiter); ok {
+			limiter.SetMaxRetrieveSize(conf.DAMaxRetrieveSize)
+		} else {
+			logger.Info("data availability layer client doesn't limit size of retrieved blobs")
+		}
+	}
+	var blockManager *block.Manager
+	txPostCheck := mempool.ChainPostChecks(mempool.PostCheckMinGasPrice(minGasPrice), nodeOpts.txPostCheck)
+	mempoolOpts := []mempool.CListMempoolOption{
+		mempool.WithMetrics(mempoolMetrics),
+		mempool.WithTxAddedCallback(func(tx tmtypes.Tx) {
+			txTracer.Accepted(tx)
+			if conf.Aggregator {
+				// block manager is created before any transaction is added
+				blockManager.TxAdded(tx)
+			}
+		}),
+		mempool.WithInvalidTxCallback(func(tx tmtypes.Tx, txInfo mempool.TxInfo) {
+			txOrigins.InvalidTx(txInfo)
+			if txInfo.SenderP2PID != "" {
+				if id, err := peer.Decode(string(txInfo.SenderP2PID)); err == nil {
+					client.ReportInvalidTx(id)
+				}
+			}
+		}),
+		mempool.WithPreCheck(state.TxPreCheck(lastState, mempool.ChainPreChecks(state.TxPreCheckBlobSize(maxBlobSize), nodeOpts.txPreCheck))),
+		mempool.WithPostCheck(state.TxPostCheck(lastState, txPostCheck)),
+	}
+	if conf.MempoolSenderLanes {
+		mempoolOpts = append(mempoolOpts, mempool.WithSenderLanes())
+	}
+	if conf.MempoolFeePriority {
+		mempoolOpts = append(mempoolOpts, mempool.WithFeePriority())
+	}
+	if nodeOpts.txDecrypter != nil {
+		mempoolOpts = append(mempoolOpts, mempool.WithEncryptedTxs(func(tx tmtypes.Tx) (tmtypes.Tx, error) {
+			outer, err := state.OuterTx(types.Tx(tx))
+			return tmtypes.Tx(outer), err
+		}))
+	}
+	mp := mempool.NewCListMempool(llcfg.DefaultMempoolConfig(), proxyApp.Mempool(), 0, mempoolOpts...)
+	mp.SetLogger(logger.With("module", "mempool"))
 	mpIDs := newMempoolIDs()
 
-	blockManager, err := block.NewManager(nodeKey, conf.BlockManagerConfig, genesis, s, mp, proxyApp.Consensus(), dalc, eventBus, logger.With("module", "BlockManager"))
+	executor := nodeOpts.executor
+	if executor == nil {
+		executor = state.NewABCIExecutor(proxyApp.Consensus(), genesis.ChainID, logger.With("module", "executor"))
+	}
+	blockManager, err = block.NewManager(nodeKey, conf.BlockManagerConfig, genesis, s, mp, executor, dalc, eventBus, logger.With("module", "BlockManager"))
 	if err != nil {
 		return nil, fmt.Errorf("BlockManager initialization error: %w", err)
 	}
+	// validators may be provided by the app, so the sequencer can be checked only after InitChain
+	initState, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	err = types.ValidateSequencer(initState.Validators)
+	if err != nil {
+		return nil, err
+	}
+	blockManager.SetTxTracer(txTracer)
+	if nodeOpts.txDecrypter != nil {
+		blockManager.SetTxDecrypter(nodeOpts.txDecrypter)
+	}
+	if nodeOpts.prover != nil {
+		blockManager.SetValidityProver(nodeOpts.prover)
+	}
+	if nodeOpts.verifier != nil {
+		blockManager.SetValidityVerifier(nodeOpts.verifier)
+	}
+	blockManager.SetMetrics(blockMetrics)
+	if nodeOpts.clock != nil {
+		blockManager.SetClock(nodeOpts.clock)
+	}
+	if daAccount != nil {
+		blockManager.SetDAAccount(daAccount)
+	}
+	if nodeOpts.executor == nil {
+		// blocks are replayed only if they're executed by ABCI application
+		appConns.SetReconnectHandler(blockManager.Handshake)
+	}
+	blockManager.SetMempoolChecks(nodeOpts.txPreCheck, txPostCheck)
+	if conf.Light {
+		if err := blockManager.EnableLightMode(conf.DASamples); err != nil {
+			return nil, err
+		}
+	}
 
 	node := &Node{
 		proxyApp:       proxyApp,
@@ -129,31 +394,193 @@ func NewNode(ctx context.Context, conf config.NodeConfig, nodeKey crypto.PrivKey
 		P2P:            client,
 		blockManager:   blockManager,
 		dalc:           dalc,
+		daAccount:      daAccount,
+		slc:            slc,
 		Mempool:        mp,
 		mempoolIDs:     mpIDs,
+		minGasPrice:    minGasPrice,
 		incomingTxCh:   make(chan *p2p.GossipMessage),
 		Store:          s,
 		TxIndexer:      txIndexer,
 		IndexerService: indexerService,
 		BlockIndexer:   blockIndexer,
+		TxTracer:       txTracer,
+		TxOrigins:      txOrigins,
+		validityProofs: nodeOpts.prover != nil,
+		panics:         blockMetrics.Panics,
 		ctx:            ctx,
+		promRegistry:   metricsRegistry,
 	}
 
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
-	node.P2P.SetTxValidator(node.newTxValidator())
-	node.P2P.SetHeaderValidator(node.newHeaderValidator())
+	node.P2P.SetTxValidator(node.recoverValidator("tx validator", node.newTxValidator(nodeOpts.gossipTxPreCheck)))
+	node.P2P.SetMempoolSource(mempoolSource{mp})
+	if conf.Replication.Source != "" {
+		// replica doesn't sync blocks from P2P network
+		node.replica = replication.NewReplica(conf.Replication.Source, s, func(b *types.Block, responses *tmstate.ABCIResponses) {
+			if err := state.PublishBlockEvents(eventBus, responses, b); err != nil {
+				logger.Error("failed to publish events of replicated block", "height", b.Header.Height, "error", err)
+			}
+		}, logger.With("module", "replication"))
+	} else {
+		node.P2P.SetHeaderValidator(node.recoverValidator("header validator", node.newHeaderValidator()))
+		if conf.P2P.BlockGossip {
+			node.P2P.SetBlockValidator(node.recoverValidator("block validator", node.newBlockValidator()))
+			node.P2P.SetBlockManifestVerifier(blockManager.VerifySequencerSignature)
+			blockManager.EnableBlockGossip()
+		}
+	}
+	if conf.Replication.ListenAddress != "" {
+		node.replicationSrv = replication.NewServer(s, logger.With("module", "replication"))
+		blockManager.AddHooks(block.Hooks{OnBlockApplied: func(b *types.Block) {
+			node.replicationSrv.Notify(b.Header.Height)
+		}})
+	}
+	if conf.Bridge.EventFilters != "" {
+		filters, err := bridge.ParseFilters(conf.Bridge.EventFilters)
+		if err != nil {
+			return nil, err
+		}
+		node.BridgeEvents = bridge.NewExtractor(filters, store.NewPrefixKV(baseKV, bridgePrefix), s, logger.With("module", "bridge"))
+		blockManager.AddHooks(block.Hooks{OnBlockApplied: node.BridgeEvents.BlockApplied})
+	}
+	if conf.EventJournal.Enabled {
+		node.EventJournal, err = journal.NewJournal(store.NewPrefixKV(baseKV, journalPrefix), s, conf.EventJournal.RetainBlocks, logger.With("module", "journal"))
+		if err != nil {
+			return nil, err
+		}
+		// blocks applied, but not journaled before the node was stopped
+		if err := node.EventJournal.Sync(s.Height()); err != nil {
+			return nil, err
+		}
+		blockManager.AddHooks(block.Hooks{OnBlockApplied: node.EventJournal.BlockApplied})
+	}
+	if slc != nil && conf.Aggregator {
+		node.slSubmitter = settlement.NewSubmitter(slc, s, conf.Settlement.Epoch, logger.With("module", "settlement"))
+		blockManager.AddHooks(block.Hooks{
+			OnBlockApplied: node.slSubmitter.BlockApplied,
+			OnDAIncluded:   node.slSubmitter.DAIncluded,
+		})
+	}
+	node.ChainStats, err = block.NewChainStats(store.NewPrefixKV(baseKV, statsPrefix), logger.With("module", "stats"))
+	if err != nil {
+		return nil, err
+	}
+	blockManager.AddHooks(block.Hooks{OnBlockApplied: node.ChainStats.BlockApplied, OnDAIncluded: node.ChainStats.DAIncluded})
+	if conf.Checkpoint.Interval > 0 {
+		node.Checkpoints = checkpoint.NewService(genesis.ChainID, conf.Checkpoint.Interval, s, logger.With("module", "checkpoint"))
+		node.P2P.SetCheckpointValidator(node.recoverValidator("checkpoint validator", node.newCheckpointValidator()))
+		if conf.Aggregator {
+			node.Checkpoints.SetSigner(nodeKey)
+			blockManager.AddHooks(block.Hooks{
+				OnBlockApplied: node.Checkpoints.BlockApplied,
+				OnDAIncluded:   node.Checkpoints.DAIncluded,
+			})
+		}
+	}
+	if conf.Snapshot.PublishInterval > 0 {
+		node.snapshotPublisher = statesync.NewPublisher(proxyApp.Snapshot(), snapshotStore, s, conf.Snapshot.PublishInterval,
+			logger.With("module", "statesync"))
+		blockManager.AddHooks(block.Hooks{OnBlockApplied: node.snapshotPublisher.BlockApplied})
+	}
+	genesisHash, err := hashGenesis(genesis)
+	if err != nil {
+		return nil, err
+	}
+	node.P2P.SetHandshakeInfo(genesisHash, s.Height)
+	// application versions are cached, to be advertised to peers and reported in node status
+	appInfo, err := proxyApp.Query().InfoSync(proxy.RequestInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query application info: %w", err)
+	}
+	node.appInfo = abci.ResponseInfo{Data: appInfo.Data, Version: appInfo.Version, AppVersion: appInfo.AppVersion}
+	node.P2P.SetAppInfo(appInfo.AppVersion, appInfo.Version)
+	for _, hooks := range nodeOpts.hooks {
+		node.RegisterHooks(hooks)
+	}
 
 	return node, nil
 }
 
+// nodeKeyPath returns path of P2P identity key file in node data directory.
+func nodeKeyPath(conf config.NodeConfig) string {
+	if filepath.IsAbs(conf.DBPath) {
+		return filepath.Join(conf.DBPath, p2p.NodeKeyFile)
+	}
+	return filepath.Join(conf.RootDir, conf.DBPath, p2p.NodeKeyFile)
+}
+
+// hashGenesis returns SHA256 hash of JSON encoded genesis document, used to detect peers of a different chain.
+func hashGenesis(genesis *tmtypes.GenesisDoc) ([]byte, error) {
+	blob, err := tmjson.Marshal(genesis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode genesis: %w", err)
+	}
+	hash := sha256.Sum256(blob)
+	return hash[:], nil
+}
+
+// newSeedNode creates a node that only participates in peer discovery.
+// Seed node doesn't connect to the application, doesn't sync blocks and doesn't gossip.
+func newSeedNode(ctx context.Context, conf config.NodeConfig, client *p2p.Client, genesis *tmtypes.GenesisDoc, logger log.Logger) *Node {
+	node := &Node{
+		genesis:      genesis,
+		conf:         conf,
+		P2P:          client,
+		ctx:          ctx,
+		promRegistry: optmetrics.NewRegistry(),
+	}
+	node.BaseService = *service.NewBaseService(logger, "Node", node)
+
+	return node
+}
+
+// applyGenesisExtension reads Optimint specific genesis extension (if available) and updates block manager configuration.
+func applyGenesisExtension(conf *config.NodeConfig) error {
+	if conf.GenesisFile == "" {
+		return nil
+	}
+	ext, err := types.GenesisExtensionFromFile(conf.GenesisFile)
+	if err != nil || ext == nil {
+		return err
+	}
+	err = ext.ValidateBasic()
+	if err != nil {
+		return fmt.Errorf("invalid optimint genesis extension: %w", err)
+	}
+
+	nsID, _ := ext.NamespaceID()
+	if conf.NamespaceID != [8]byte{} && conf.NamespaceID != nsID {
+		return fmt.Errorf("namespace ID from configuration (%X) doesn't match namespace ID from genesis (%X)", conf.NamespaceID, nsID)
+	}
+	conf.NamespaceID = nsID
+	conf.DAStartHeight = ext.DAStartHeight
+	return nil
+}
+
+// createP2PClient creates P2P client with address book persisted in given KVStore.
+func createP2PClient(conf config.P2PConfig, nodeKey crypto.PrivKey, chainID string, kv store.KVStore, logger log.Logger) (*p2p.Client, error) {
+	client, err := p2p.NewClient(conf, nodeKey, chainID, logger.With("module", "p2p"))
+	if err != nil {
+		return nil, err
+	}
+	addrBook, err := p2p.NewAddrBook(kv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load address book: %w", err)
+	}
+	client.SetAddrBook(addrBook)
+	return client, nil
+}
+
 func (n *Node) headerPublishLoop(ctx context.Context) {
 	for {
 		select {
-		case header := <-n.blockManager.HeaderOutCh:
-			headerBytes, err := header.MarshalBinary()
+		case signedHeader := <-n.blockManager.HeaderOutCh:
+			headerBytes, err := signedHeader.MarshalBinary()
 			if err != nil {
-				n.Logger.Error("failed to serialize block header", "error", err)
+				n.Logger.Error("failed to serialize signed block header", "error", err)
+				continue
 			}
 			err = n.P2P.GossipHeader(ctx, headerBytes)
 			if err != nil {
@@ -165,24 +592,111 @@ func (n *Node) headerPublishLoop(ctx context.Context) {
 	}
 }
 
+func (n *Node) checkpointPublishLoop(ctx context.Context) {
+	for {
+		select {
+		case cp := <-n.Checkpoints.Out():
+			cpBytes, err := checkpoint.Marshal(cp)
+			if err != nil {
+				n.Logger.Error("failed to serialize checkpoint", "error", err)
+				continue
+			}
+			err = n.P2P.GossipCheckpoint(ctx, cpBytes)
+			if err != nil {
+				n.Logger.Error("failed to gossip checkpoint", "height", cp.Height, "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // OnStart is a part of Service interface.
 func (n *Node) OnStart() error {
+	if n.conf.Instrumentation.Prometheus && n.conf.Instrumentation.PrometheusListenAddr != "" {
+		n.prometheusSrv = startPrometheusServer(n.conf.Instrumentation, n.promRegistry, n.promRegistry, n.Logger)
+	}
+
 	n.Logger.Info("starting P2P client")
 	err := n.P2P.Start(n.ctx)
 	if err != nil {
 		return fmt.Errorf("error while starting P2P client: %w", err)
 	}
+	if n.conf.Diagnostics.ListenAddress != "" {
+		n.diagnosticsSrv = n.startDiagnosticsServer()
+	}
+	if n.conf.P2P.SeedMode {
+		n.Logger.Info("working in seed mode")
+		n.onStart()
+		return nil
+	}
 	err = n.dalc.Start()
 	if err != nil {
 		return fmt.Errorf("error while starting data availability layer client: %w", err)
 	}
+	if n.slc != nil {
+		if err := n.slc.Start(); err != nil {
+			return fmt.Errorf("error while starting settlement layer client: %w", err)
+		}
+		if n.slSubmitter != nil {
+			go n.slSubmitter.SubmitLoop(n.ctx, n.conf.BlockTime)
+		}
+	}
+	if n.daAccount != nil {
+		err = n.daAccount.Sync()
+		if err != nil {
+			return fmt.Errorf("error while syncing DA layer account: %w", err)
+		}
+		if n.conf.DAAccount.CheckInterval > 0 {
+			go n.daAccount.MonitorLoop(n.ctx, n.conf.DAAccount.CheckInterval)
+		}
+	}
+	if n.replicationSrv != nil {
+		if err := n.replicationSrv.Start(n.conf.Replication.ListenAddress); err != nil {
+			return fmt.Errorf("error while starting replication server: %w", err)
+		}
+	}
+	if n.snapshotPublisher != nil {
+		go n.snapshotPublisher.PublishLoop(n.ctx, n.conf.BlockTime)
+	}
+	if n.replica != nil {
+		n.Logger.Info("working in replica mode", "source", n.conf.Replication.Source)
+		go n.replica.Run(n.ctx)
+		n.onStart()
+		return nil
+	}
+	if n.conf.Light {
+		n.Logger.Info("working in light mode", "samples", n.conf.DASamples)
+		n.goLoop("light sync loop", n.blockManager.LightSyncLoop)
+		n.onStart()
+		return nil
+	}
 	if n.conf.Aggregator {
 		n.Logger.Info("working in aggregator mode", "block time", n.conf.BlockTime)
-		go n.blockManager.AggregationLoop(n.ctx)
-		go n.headerPublishLoop(n.ctx)
+		n.goLoop("aggregation loop", n.blockManager.AggregationLoop)
+		if n.conf.WatchdogMultiplier > 0 && n.conf.BlockTime > 0 {
+			n.goLoop("watchdog loop", n.blockManager.WatchdogLoop)
+		}
+		n.goLoop("header publish loop", n.headerPublishLoop)
+		if n.Checkpoints != nil {
+			n.goLoop("checkpoint publish loop", n.checkpointPublishLoop)
+		}
+		if n.conf.P2P.BlockGossip {
+			n.goLoop("block publish loop", n.blockPublishLoop)
+		}
+		if n.validityProofs && n.conf.BlockTime > 0 {
+			n.goLoop("proof loop", n.blockManager.ProofLoop)
+		}
 	}
-	go n.blockManager.RetrieveLoop(n.ctx)
-	go n.blockManager.SyncLoop(n.ctx)
+	n.goLoop("retrieve loop", n.blockManager.RetrieveLoop)
+	n.goLoop("sync loop", n.blockManager.SyncLoop)
+	if n.conf.DAConfirmInterval > 0 {
+		n.goLoop("confirmation loop", n.blockManager.ConfirmationLoop)
+	}
+	if n.conf.DAConfirmDepth > 0 || n.conf.DAReorgWindow > 0 {
+		n.goLoop("finality loop", n.blockManager.FinalityLoop)
+	}
+	n.onStart()
 
 	return nil
 }
@@ -193,8 +707,24 @@ func (n *Node) GetGenesis() *tmtypes.GenesisDoc {
 
 // OnStop is a part of Service interface.
 func (n *Node) OnStop() {
-	err := n.dalc.Stop()
+	n.onStop()
+	var err error
+	if !n.conf.P2P.SeedMode {
+		err = n.dalc.Stop()
+	}
+	if n.slc != nil {
+		err = multierr.Append(err, n.slc.Stop())
+	}
+	if n.replicationSrv != nil {
+		n.replicationSrv.Stop()
+	}
 	err = multierr.Append(err, n.P2P.Close())
+	if n.prometheusSrv != nil {
+		err = multierr.Append(err, n.prometheusSrv.Shutdown(context.Background()))
+	}
+	if n.diagnosticsSrv != nil {
+		err = multierr.Append(err, n.diagnosticsSrv.Shutdown(context.Background()))
+	}
 	n.Logger.Error("errors while stopping node:", "errors", err)
 }
 
@@ -223,28 +753,157 @@ func (n *Node) ProxyApp() proxy.AppConns {
 	return n.proxyApp
 }
 
+// AppInfo returns data, software version and protocol version of the application, reported via ABCI Info when the
+// node was created.
+func (n *Node) AppInfo() abci.ResponseInfo {
+	return n.appInfo
+}
+
+// RPCConfig returns RPC configuration of the node.
+func (n *Node) RPCConfig() config.RPCConfig {
+	return n.conf.RPC
+}
+
+// InstrumentationConfig returns configuration of metrics reporting.
+func (n *Node) InstrumentationConfig() config.InstrumentationConfig {
+	return n.conf.Instrumentation
+}
+
+// MetricsRegistry returns Prometheus registry of the node. Metrics of components created outside of the node (e.g. RPC
+// server) are registered in it, so they are served together with metrics of the node.
+func (n *Node) MetricsRegistry() *prometheus.Registry {
+	return n.promRegistry
+}
+
+// SeedMode returns true if node only participates in peer discovery. Seed node has no store, mempool nor event bus, so
+// it can't serve RPC.
+func (n *Node) SeedMode() bool {
+	return n.conf.P2P.SeedMode
+}
+
+// Archive returns true if node works in archive mode (data is never pruned, and ABCI queries at historical heights
+// are served).
+func (n *Node) Archive() bool {
+	return n.conf.Archive
+}
+
+// Stalled returns true if block production or submission is stalled (detected by watchdog, in aggregator mode).
+func (n *Node) Stalled() bool {
+	if n.blockManager == nil {
+		return false
+	}
+	return n.blockManager.Stalled()
+}
+
+// FirmHeight returns the height of the latest final block, included in DA layer deep enough (see
+// config.BlockManagerConfig.DAConfirmDepth). All blocks below are final too.
+func (n *Node) FirmHeight() uint64 {
+	if n.blockManager == nil {
+		return 0
+	}
+	return n.blockManager.FirmHeight()
+}
+
+// IsFinal returns true if block at given height, known to be included in DA layer, is final.
+// If DA confirmation depth is not set, every block included in DA layer is final.
+func (n *Node) IsFinal(height uint64) bool {
+	return n.conf.DAConfirmDepth == 0 || height <= n.FirmHeight()
+}
+
+// ProduceBlockNow produces a block immediately, regardless of block time. It's intended for development and testing.
+// Node has to be a running aggregator.
+func (n *Node) ProduceBlockNow(ctx context.Context) error {
+	if !n.conf.Aggregator {
+		return errors.New("on-demand block production requires aggregator mode")
+	}
+	if !n.IsRunning() {
+		return errors.New("node is not running")
+	}
+	return n.blockManager.ProduceBlockNow(ctx)
+}
+
+// MinGasPrice returns minimal gas price of transactions accepted to mempool (zero if not enforced).
+func (n *Node) MinGasPrice() mempool.GasPrice {
+	return n.minGasPrice
+}
+
+// StopAggregating pauses block production (e.g. during incident response or maintenance). Node keeps running:
+// transactions are accepted to mempool and pending blocks are submitted to DA layer.
+func (n *Node) StopAggregating() error {
+	if !n.conf.Aggregator {
+		return errors.New("node is not an aggregator")
+	}
+	n.blockManager.StopAggregating()
+	n.Logger.Info("block production paused")
+	return nil
+}
+
+// StartAggregating resumes block production paused by StopAggregating.
+func (n *Node) StartAggregating() error {
+	if !n.conf.Aggregator {
+		return errors.New("node is not an aggregator")
+	}
+	n.blockManager.StartAggregating()
+	n.Logger.Info("block production resumed")
+	return nil
+}
+
+// Aggregating returns true if node is an aggregator and block production is not paused.
+func (n *Node) Aggregating() bool {
+	return n.conf.Aggregator && n.blockManager.Aggregating()
+}
+
+// OrderingReceipt returns ordering receipt of transaction pending in the mempool, if first-come-first-served
+// ordering is enabled and node is the aggregator (nil otherwise).
+func (n *Node) OrderingReceipt(tx tmtypes.Tx) *types.OrderingReceipt {
+	return n.blockManager.OrderingReceipt(tx)
+}
+
+// SetTxInjector sets TxInjector used to add system transactions at the beginning and at the end of every block.
+// All nodes of the chain have to use the same injector. It has to be called before the node is started.
+func (n *Node) SetTxInjector(injector state.TxInjector) {
+	n.blockManager.SetTxInjector(injector)
+}
+
+// Reindex rebuilds transaction and block indexes for blocks from given height range (inclusive), using data saved in
+// the store (0 as the end of range means the latest block). It can be used to backfill indexes after indexing was
+// enabled late, or to repair corrupted indexes.
+func (n *Node) Reindex(ctx context.Context, from, to uint64) error {
+	n.Logger.Info("reindexing blocks", "from", from, "to", to)
+	if err := txindex.Reindex(ctx, n.Store, n.TxIndexer, n.BlockIndexer, from, to); err != nil {
+		return err
+	}
+	n.Logger.Info("reindexing finished", "from", from, "to", to)
+	return nil
+}
+
 // newTxValidator creates a pubsub validator that uses the node's mempool to check the
-// transaction. If the transaction is valid, then it is added to the mempool
-func (n *Node) newTxValidator() p2p.GossipValidator {
+// transaction. If the transaction is valid, then it is added to the mempool.
+// If preCheck is set, transactions rejected by it are dropped without calling CheckTx.
+func (n *Node) newTxValidator(preCheck mempool.PreCheckFunc) p2p.GossipValidator {
 	return func(m *p2p.GossipMessage) bool {
 		n.Logger.Debug("transaction received", "bytes", len(m.Data))
+		if preCheck != nil {
+			if err := preCheck(m.Data); err != nil {
+				n.Logger.Debug("transaction rejected by gossip pre-check", "from", m.From, "error", err)
+				return false
+			}
+		}
 		checkTxResCh := make(chan *abci.Response, 1)
 		err := n.Mempool.CheckTx(m.Data, func(resp *abci.Response) {
 			checkTxResCh <- resp
 		}, mempool.TxInfo{
 			SenderID:    n.mempoolIDs.GetForPeer(m.From),
-			SenderP2PID: corep2p.ID(m.From),
+			SenderP2PID: corep2p.ID(m.From.String()),
 		})
 		switch {
 		case errors.Is(err, mempool.ErrTxInCache):
 			return true
-		case errors.Is(err, mempool.ErrMempoolIsFull{}):
+		case errors.As(err, &mempool.ErrMempoolIsFull{}):
 			return true
-		case errors.Is(err, mempool.ErrTxTooLarge{}):
-			return false
-		case errors.Is(err, mempool.ErrPreCheck{}):
+		case err != nil:
+			// callback is not called if CheckTx fails (e.g. transaction is too large)
 			return false
-		default:
 		}
 		res := <-checkTxResCh
 		checkTxResp := res.GetCheckTx()
@@ -253,27 +912,208 @@ func (n *Node) newTxValidator() p2p.GossipValidator {
 	}
 }
 
-// newHeaderValidator returns a pubsub validator that runs basic checks and forwards
-// the deserialized header for further processing
+func (n *Node) blockPublishLoop(ctx context.Context) {
+	for {
+		select {
+		case block := <-n.blockManager.BlockOutCh:
+			commit, err := n.Store.LoadCommit(block.Header.Height)
+			if err != nil {
+				n.Logger.Error("failed to load commit", "height", block.Header.Height, "error", err)
+				continue
+			}
+			blockBytes, err := marshalGossipedBlock(block, commit)
+			if err != nil {
+				n.Logger.Error("failed to serialize block", "error", err)
+				continue
+			}
+			err = n.P2P.GossipBlock(ctx, blockBytes)
+			if err != nil {
+				n.Logger.Error("failed to gossip block", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// newHeaderValidator returns a pubsub validator that runs basic checks, verifies the signature of the current sequencer
+// (see block.Manager.VerifyHeader) and forwards the deserialized header for further processing.
+// Headers that fail verification are not relayed to other peers.
 func (n *Node) newHeaderValidator() p2p.GossipValidator {
 	return func(headerMsg *p2p.GossipMessage) bool {
 		n.Logger.Debug("header received", "from", headerMsg.From, "bytes", len(headerMsg.Data))
-		var header types.Header
-		err := header.UnmarshalBinary(headerMsg.Data)
+		var signedHeader types.SignedHeader
+		err := signedHeader.UnmarshalBinary(headerMsg.Data)
 		if err != nil {
 			n.Logger.Error("failed to deserialize header", "error", err)
 			return false
 		}
-		err = header.ValidateBasic()
+		err = signedHeader.ValidateBasic()
 		if err != nil {
 			n.Logger.Error("failed to validate header", "error", err)
 			return false
 		}
-		n.blockManager.HeaderInCh <- &header
+		err = n.blockManager.VerifyHeader(&signedHeader)
+		if err != nil {
+			n.Logger.Error("failed to verify header signature", "from", headerMsg.From, "error", err)
+			return false
+		}
+		n.blockManager.HeaderInCh <- &signedHeader.Header
 		return true
 	}
 }
 
+// newCheckpointValidator returns a validator of checkpoints gossiped via P2P. Checkpoints signed by the sequencer are
+// saved in the store.
+func (n *Node) newCheckpointValidator() p2p.GossipValidator {
+	return func(cpMsg *p2p.GossipMessage) bool {
+		n.Logger.Debug("checkpoint received", "from", cpMsg.From, "bytes", len(cpMsg.Data))
+		cp, err := checkpoint.Unmarshal(cpMsg.Data)
+		if err != nil {
+			n.Logger.Error("failed to deserialize checkpoint", "error", err)
+			return false
+		}
+		err = n.Checkpoints.Receive(cp)
+		if err != nil {
+			n.Logger.Error("failed to verify checkpoint", "from", cpMsg.From, "height", cp.Height, "error", err)
+			return false
+		}
+		return true
+	}
+}
+
+// newBlockValidator returns a validator of blocks reassembled from parts gossiped via P2P. Blocks are gossiped with
+// their commits (see marshalGossipedBlock). Blocks that pass basic checks and are signed by the current sequencer
+// (see block.Manager.VerifyHeader) are forwarded to the block manager.
+func (n *Node) newBlockValidator() p2p.GossipValidator {
+	return func(blockMsg *p2p.GossipMessage) bool {
+		n.Logger.Debug("block received", "from", blockMsg.From, "bytes", len(blockMsg.Data))
+		block, commit, err := unmarshalGossipedBlock(blockMsg.Data)
+		if err != nil {
+			n.Logger.Error("failed to deserialize block", "error", err)
+			return false
+		}
+		err = block.ValidateBasic()
+		if err != nil {
+			n.Logger.Error("failed to validate block", "error", err)
+			return false
+		}
+		signedHeader := &types.SignedHeader{Header: block.Header, Commit: *commit}
+		err = signedHeader.ValidateBasic()
+		if err != nil {
+			n.Logger.Error("failed to validate block commit", "error", err)
+			return false
+		}
+		err = n.blockManager.VerifyHeader(signedHeader)
+		if err != nil {
+			n.Logger.Error("failed to verify block signature", "from", blockMsg.From, "error", err)
+			return false
+		}
+		n.blockManager.BlockInCh <- block
+		return true
+	}
+}
+
+// marshalGossipedBlock encodes block and its commit into binary form gossiped via P2P. Both fields are prefixed with
+// their length.
+func marshalGossipedBlock(block *types.Block, commit *types.Commit) ([]byte, error) {
+	blockBytes, err := block.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	commitBytes, err := commit.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 0, 2*binary.MaxVarintLen64+len(blockBytes)+len(commitBytes))
+	for _, f := range [][]byte{blockBytes, commitBytes} {
+		var prefix [binary.MaxVarintLen64]byte
+		n := binary.PutUvarint(prefix[:], uint64(len(f)))
+		buf = append(buf, prefix[:n]...)
+		buf = append(buf, f...)
+	}
+	return buf, nil
+}
+
+// unmarshalGossipedBlock decodes block and its commit encoded by marshalGossipedBlock.
+func unmarshalGossipedBlock(data []byte) (*types.Block, *types.Commit, error) {
+	var fields [2][]byte
+	for i := range fields {
+		length, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < length {
+			return nil, nil, errors.New("malformed gossiped block")
+		}
+		fields[i] = data[n : n+int(length)]
+		data = data[n+int(length):]
+	}
+	if len(data) > 0 {
+		return nil, nil, errors.New("malformed gossiped block")
+	}
+
+	block := new(types.Block)
+	if err := block.UnmarshalBinary(fields[0]); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode block: %w", err)
+	}
+	commit := new(types.Commit)
+	if err := commit.UnmarshalBinary(fields[1]); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode commit: %w", err)
+	}
+	return block, commit, nil
+}
+
+// metricsProvider returns mempool, block manager and DA account metrics.
+// Prometheus metrics (registered in given registry) are returned if enabled in configuration, no-op metrics otherwise.
+func metricsProvider(conf config.InstrumentationConfig, registry *prometheus.Registry, chainID string) (*mempool.Metrics, *block.Metrics, *account.Metrics) {
+	if conf.Prometheus {
+		return mempool.PrometheusMetrics(registry, conf.Namespace, "chain_id", chainID),
+			block.PrometheusMetrics(registry, conf.Namespace, "chain_id", chainID),
+			account.PrometheusMetrics(registry, conf.Namespace, "chain_id", chainID)
+	}
+	return mempool.NopMetrics(), block.NopMetrics(), account.NopMetrics()
+}
+
+// createDAAccount creates DA layer account paying fees for block submissions, if it's enabled in configuration.
+func createDAAccount(conf config.DAAccountConfig, dalc da.DataAvailabilityLayerClient, metrics *account.Metrics, eventBus *tmtypes.EventBus, logger log.Logger) (*account.Account, error) {
+	if conf.KeyFile == "" {
+		return nil, nil
+	}
+	feePayer, ok := dalc.(da.FeePayer)
+	if !ok {
+		return nil, errors.New("DA layer account is configured, but DA layer client doesn't support it")
+	}
+	key, err := account.LoadKey(conf.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	acc := account.NewAccount(key, feePayer, conf.MinBalance, logger.With("module", "da_account"))
+	acc.SetMetrics(metrics)
+	acc.SetEventBus(eventBus)
+	feePayer.SetSigner(acc)
+	return acc, nil
+}
+
+// startPrometheusServer starts a Prometheus HTTP server, serving metrics from gatherer under /metrics.
+// Metrics of the handler itself are registered with registerer.
+func startPrometheusServer(conf config.InstrumentationConfig, registerer prometheus.Registerer, gatherer prometheus.Gatherer, logger log.Logger) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.InstrumentMetricHandler(
+		registerer, promhttp.HandlerFor(
+			gatherer,
+			promhttp.HandlerOpts{MaxRequestsInFlight: conf.MaxOpenConnections},
+		),
+	))
+	srv := &http.Server{
+		Addr:    conf.PrometheusListenAddr,
+		Handler: mux,
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			logger.Error("Prometheus HTTP server ListenAndServe", "error", err)
+		}
+	}()
+	return srv
+}
+
 func createAndStartIndexerService(
 	conf config.NodeConfig,
 	kvStore store.KVStore,
@@ -291,6 +1131,10 @@ func createAndStartIndexerService(
 
 	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus)
 	indexerService.SetLogger(logger.With("module", "txindex"))
+	indexerService.SetRetention(int64(conf.TxIndex.RetainBlocks))
+	if compactor, ok := kvStore.(store.Compactor); ok {
+		indexerService.SetCompaction(compactor, conf.TxIndex.CompactionInterval)
+	}
 
 	if err := indexerService.Start(); err != nil {
 		return nil, nil, nil, err
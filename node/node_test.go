@@ -1,8 +1,16 @@
 package node
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -14,12 +22,16 @@ import (
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/proxy"
-	"github.com/tendermint/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/celestiaorg/optimint/config"
 	"github.com/celestiaorg/optimint/mocks"
+	"github.com/celestiaorg/optimint/p2p"
+	"github.com/celestiaorg/optimint/store"
+	optypes "github.com/celestiaorg/optimint/types"
 )
 
 // simply check that node is starting and stopping without panicking
@@ -29,8 +41,9 @@ func TestStartup(t *testing.T) {
 
 	app := &mocks.Application{}
 	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
 	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
-	node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock"}, key, proxy.NewLocalClientCreator(app), &types.GenesisDoc{ChainID: "test"}, log.TestingLogger())
+	node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock"}, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger())
 	require.NoError(err)
 	require.NotNil(node)
 
@@ -45,17 +58,64 @@ func TestStartup(t *testing.T) {
 	assert.True(node.IsRunning())
 }
 
+func TestSeedModeStartup(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// seed node doesn't use the application at all
+	app := &mocks.Application{}
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	conf := config.NodeConfig{P2P: config.P2PConfig{SeedMode: true}}
+	node, err := NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger())
+	require.NoError(err)
+	require.NotNil(node)
+	assert.Nil(node.Mempool)
+	assert.True(node.SeedMode())
+
+	err = node.Start()
+	assert.NoError(err)
+	assert.True(node.IsRunning())
+	assert.Error(node.P2P.GossipTx(context.Background(), []byte("tx")))
+
+	err = node.Stop()
+	assert.NoError(err)
+	app.AssertExpectations(t)
+
+	conf.Aggregator = true
+	node, err = NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger())
+	assert.Error(err)
+	assert.Nil(node)
+}
+
+func TestApplyGenesisExtension(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "genesis.json")
+	err := os.WriteFile(path, []byte(`{"chain_id": "test", "optimint": {"da_namespace_id": "0102030405060708", "da_start_height": "7"}}`), 0600)
+	require.NoError(err)
+
+	conf := config.NodeConfig{GenesisFile: path}
+	require.NoError(applyGenesisExtension(&conf))
+	assert.Equal([8]byte{1, 2, 3, 4, 5, 6, 7, 8}, conf.NamespaceID)
+	assert.Equal(uint64(7), conf.DAStartHeight)
+
+	conf = config.NodeConfig{GenesisFile: path, BlockManagerConfig: config.BlockManagerConfig{NamespaceID: [8]byte{8, 7, 6, 5, 4, 3, 2, 1}}}
+	assert.Error(applyGenesisExtension(&conf))
+}
+
 func TestMempoolDirectly(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
 
 	app := &mocks.Application{}
 	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
 	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
 	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
 	anotherKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
 
-	node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock"}, key, proxy.NewLocalClientCreator(app), &types.GenesisDoc{ChainID: "test"}, log.TestingLogger())
+	node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock"}, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger())
 	require.NoError(err)
 	require.NotNil(node)
 
@@ -86,3 +146,377 @@ func TestMempoolDirectly(t *testing.T) {
 
 	assert.Equal(int64(4*len("tx*")), node.Mempool.TxsBytes())
 }
+
+func TestInvalidLogLevel(t *testing.T) {
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock", LogLevel: "p2p:verbose"}, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger())
+	require.Error(err)
+	require.Nil(node)
+}
+
+func TestValidatorsFromInitChain(t *testing.T) {
+	require := require.New(t)
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	genesis := createGenesis(key, t)
+	sequencer := abci.UpdateValidator(genesis.Validators[0].PubKey.Bytes(), 1, ed25519.KeyType)
+
+	// genesis doc without validators is accepted only if the app returns the sequencer
+	for _, validators := range [][]abci.ValidatorUpdate{nil, {sequencer}} {
+		app := &mocks.Application{}
+		app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{Validators: validators})
+		app.On("Info", mock.Anything).Return(abci.ResponseInfo{})
+		genesis := createGenesis(key, t)
+		genesis.Validators = nil
+		node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock"}, key, proxy.NewLocalClientCreator(app), genesis, log.TestingLogger())
+		if validators == nil {
+			require.Error(err)
+			require.Contains(err.Error(), "sequencer public key is missing")
+			continue
+		}
+		require.NoError(err)
+		require.NotNil(node)
+	}
+}
+
+func TestMempoolChecks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{GasWanted: 10})
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+
+	genesis := createGenesis(key, t)
+	genesis.ConsensusParams = tmtypes.DefaultConsensusParams()
+	genesis.ConsensusParams.Block.MaxBytes = 1000
+	genesis.ConsensusParams.Evidence.MaxBytes = 100
+
+	errRejected := errors.New("rejected")
+	node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock"}, key, proxy.NewLocalClientCreator(app), genesis, log.TestingLogger(),
+		WithTxPreCheck(func(tx tmtypes.Tx) error {
+			if bytes.HasPrefix(tx, []byte("pre")) {
+				return errRejected
+			}
+			return nil
+		}),
+		WithTxPostCheck(func(tx tmtypes.Tx, res *abci.ResponseCheckTx) error {
+			if bytes.HasPrefix(tx, []byte("post")) {
+				return errRejected
+			}
+			return nil
+		}),
+	)
+	require.NoError(err)
+
+	err = node.Mempool.CheckTx([]byte("pre"), nil, mempool.TxInfo{})
+	assert.Equal(mempool.ErrPreCheck{Reason: errRejected}, err)
+
+	// max block size from consensus params is enforced as well
+	err = node.Mempool.CheckTx(make([]byte, 1000), nil, mempool.TxInfo{})
+	assert.IsType(mempool.ErrPreCheck{}, err)
+
+	require.NoError(node.Mempool.CheckTx([]byte("post"), nil, mempool.TxInfo{}))
+	require.NoError(node.Mempool.CheckTx([]byte("ok"), nil, mempool.TxInfo{}))
+	require.NoError(node.Mempool.FlushAppConn())
+	assert.Equal(tmtypes.Txs{tmtypes.Tx("ok")}, node.Mempool.ReapMaxTxs(-1))
+}
+
+func TestGossipTxPreCheck(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("CheckTx", abci.RequestCheckTx{Tx: []byte("ok")}).Return(abci.ResponseCheckTx{}).Once()
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	anotherKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+
+	preCheck := func(tx tmtypes.Tx) error {
+		if bytes.HasPrefix(tx, []byte("garbage")) {
+			return errors.New("garbage")
+		}
+		return nil
+	}
+	node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock"}, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger(),
+		WithGossipTxPreCheck(preCheck))
+	require.NoError(err)
+
+	pid, err := peer.IDFromPrivateKey(anotherKey)
+	require.NoError(err)
+	validate := node.newTxValidator(preCheck)
+	assert.False(validate(&p2p.GossipMessage{Data: []byte("garbage"), From: pid}))
+	assert.True(validate(&p2p.GossipMessage{Data: []byte("ok"), From: pid}))
+
+	// rejected transaction never reaches the application
+	app.AssertNumberOfCalls(t, "CheckTx", 1)
+	assert.Equal(tmtypes.Txs{tmtypes.Tx("ok")}, node.Mempool.ReapMaxTxs(-1))
+}
+
+func TestTxOrigins(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kv := store.NewPrefixKV(store.NewDefaultInMemoryKVStore(), originsPrefix)
+	origins, err := NewTxOrigins(kv, 2, log.TestingLogger())
+	require.NoError(err)
+	now := time.Unix(1000, 0)
+	origins.now = func() time.Time { return now }
+
+	rpcInfo := mempool.TxInfo{SenderRPCAddr: "1.2.3.4:5678"}
+	origin := rpcInfo.Origin()
+	assert.NoError(origins.Check(origin))
+	origins.InvalidTx(rpcInfo)
+	// port doesn't matter
+	origins.InvalidTx(mempool.TxInfo{SenderRPCAddr: "1.2.3.4:9999"})
+	origins.InvalidTx(mempool.TxInfo{})
+	assert.ErrorIs(origins.Check(origin), ErrTooManyInvalidTxs)
+	assert.NoError(origins.Check("rpc:5.6.7.8"))
+	assert.NoError(origins.Check(""))
+
+	// limit is reset in next window
+	now = now.Add(invalidTxsWindow)
+	assert.NoError(origins.Check(origin))
+
+	// total counts are persisted
+	reloaded, err := NewTxOrigins(kv, 2, log.TestingLogger())
+	require.NoError(err)
+	assert.EqualValues(2, reloaded.InvalidTxs(origin))
+	assert.EqualValues(0, reloaded.InvalidTxs(""))
+	assert.NoError(reloaded.Check(origin))
+}
+
+func TestInvalidGossipedTx(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("CheckTx", abci.RequestCheckTx{Tx: []byte("bad")}).Return(abci.ResponseCheckTx{Code: 1})
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	anotherKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+
+	node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock"}, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger())
+	require.NoError(err)
+
+	pid, err := peer.IDFromPrivateKey(anotherKey)
+	require.NoError(err)
+	validate := node.newTxValidator(nil)
+	assert.False(validate(&p2p.GossipMessage{Data: []byte("bad"), From: pid}))
+	assert.EqualValues(1, node.TxOrigins.InvalidTxs("peer:"+pid.String()))
+}
+
+func TestInvalidGossipedBlock(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	anotherKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+
+	node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock"}, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger())
+	require.NoError(err)
+
+	rawKey, err := anotherKey.GetPublic().Raw()
+	require.NoError(err)
+	block := &optypes.Block{Header: optypes.Header{
+		Height:          1,
+		ProposerAddress: ed25519.PubKey(rawKey).Address(),
+		DataHash:        (&optypes.Data{}).Hash(),
+		LastCommitHash:  (&optypes.Commit{}).Hash(),
+	}}
+	headerBytes, err := block.Header.MarshalBinary()
+	require.NoError(err)
+	signature, err := anotherKey.Sign(headerBytes)
+	require.NoError(err)
+	commit := &optypes.Commit{Height: 1, HeaderHash: block.Header.Hash(), Signatures: []optypes.Signature{signature}}
+
+	data, err := marshalGossipedBlock(block, commit)
+	require.NoError(err)
+	decodedBlock, decodedCommit, err := unmarshalGossipedBlock(data)
+	require.NoError(err)
+	assert.Equal(block, decodedBlock)
+	assert.Equal(commit, decodedCommit)
+	_, _, err = unmarshalGossipedBlock(data[:len(data)-1])
+	assert.Error(err)
+
+	validate := node.newBlockValidator()
+	// block signed by other node than sequencer
+	assert.False(validate(&p2p.GossipMessage{Data: data}))
+	// commit doesn't match the block
+	commit.Height = 2
+	data, err = marshalGossipedBlock(block, commit)
+	require.NoError(err)
+	assert.False(validate(&p2p.GossipMessage{Data: data}))
+	assert.False(validate(&p2p.GossipMessage{Data: []byte("garbage")}))
+
+	assert.Error(node.blockManager.VerifySequencerSignature(headerBytes, signature))
+	signature, err = key.Sign(headerBytes)
+	require.NoError(err)
+	assert.NoError(node.blockManager.VerifySequencerSignature(headerBytes, signature))
+}
+
+func TestDiagnostics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	addr := listener.Addr().String()
+	require.NoError(listener.Close())
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	node, err := NewNode(context.Background(), config.NodeConfig{
+		DALayer:            "mock",
+		Aggregator:         true,
+		BlockManagerConfig: config.BlockManagerConfig{BlockTime: 50 * time.Millisecond},
+		Diagnostics:        config.DiagnosticsConfig{ListenAddress: addr},
+	}, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger())
+	require.NoError(err)
+	require.NoError(node.Start())
+	defer func() {
+		assert.NoError(node.Stop())
+	}()
+	require.Eventually(func() bool { return node.Store.Height() >= 2 }, 5*time.Second, 10*time.Millisecond)
+
+	get := func(path string) []byte {
+		var body []byte
+		require.Eventually(func() bool {
+			resp, err := http.Get("http://" + addr + path)
+			if err != nil {
+				return false
+			}
+			defer resp.Body.Close()
+			require.Equal(http.StatusOK, resp.StatusCode)
+			body, err = io.ReadAll(resp.Body)
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+		return body
+	}
+
+	var state DebugState
+	require.NoError(json.Unmarshal(get("/debug/state"), &state))
+	assert.Equal("test", state.ChainID)
+	assert.True(state.Aggregator)
+	assert.Positive(state.Goroutines)
+	require.NotNil(state.Block)
+	assert.GreaterOrEqual(state.Block.Height, uint64(2))
+	assert.True(state.Block.Aggregating)
+	assert.False(state.Block.Stalled)
+
+	assert.Contains(string(get("/debug/goroutines")), "goroutine")
+	assert.Contains(string(get("/debug/pprof/")), "goroutine")
+}
+
+func TestPanicRecovery(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	origDelay := panicRestartDelay
+	panicRestartDelay = 10 * time.Millisecond
+	defer func() { panicRestartDelay = origDelay }()
+
+	newNode := func(policy string) *Node {
+		app := &mocks.Application{}
+		app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+		app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+		key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+		node, err := NewNode(context.Background(), config.NodeConfig{DALayer: "mock", PanicPolicy: policy}, key, proxy.NewLocalClientCreator(app), createGenesis(key, t), log.TestingLogger())
+		require.NoError(err)
+		require.NoError(node.Start())
+		return node
+	}
+
+	t.Run("restart", func(t *testing.T) {
+		node := newNode(config.PanicPolicyRestart)
+		defer func() {
+			assert.NoError(node.Stop())
+		}()
+
+		runs := make(chan int, 2)
+		count := 0
+		node.goLoop("test loop", func(ctx context.Context) {
+			count++
+			runs <- count
+			if count == 1 {
+				panic("boom")
+			}
+		})
+		assert.Equal(1, <-runs)
+		assert.Equal(2, <-runs)
+		assert.True(node.IsRunning())
+
+		validator := node.recoverValidator("test validator", func(*p2p.GossipMessage) bool {
+			panic("boom")
+		})
+		assert.False(validator(&p2p.GossipMessage{Data: []byte("tx")}))
+		assert.True(node.IsRunning())
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		node := newNode(config.PanicPolicyShutdown)
+
+		runs := make(chan struct{}, 2)
+		node.goLoop("test loop", func(ctx context.Context) {
+			runs <- struct{}{}
+			panic("boom")
+		})
+		<-runs
+		require.Eventually(func() bool { return !node.IsRunning() }, time.Second, 10*time.Millisecond)
+		assert.Len(runs, 0)
+	})
+}
+
+// metrics of every node are registered in its own registry, so many nodes can be created in one process
+func TestPrometheusMetrics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	newNode := func(chainID string) *Node {
+		app := &mocks.Application{}
+		app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+		app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+		app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+		key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+		genesis := createGenesis(key, t)
+		genesis.ChainID = chainID
+		conf := config.NodeConfig{DALayer: "mock", Instrumentation: config.InstrumentationConfig{Prometheus: true, Namespace: "optimint"}}
+		node, err := NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), genesis, log.TestingLogger())
+		require.NoError(err)
+		return node
+	}
+
+	node1 := newNode("chain1")
+	node2 := newNode("chain2")
+	require.NotSame(node1.MetricsRegistry(), node2.MetricsRegistry())
+
+	require.NoError(node1.Mempool.CheckTx([]byte("tx"), func(r *abci.Response) {}, mempool.TxInfo{}))
+	families, err := node1.MetricsRegistry().Gather()
+	require.NoError(err)
+	found := false
+	for _, family := range families {
+		if family.GetName() == "optimint_mempool_size" {
+			found = true
+			require.Len(family.GetMetric(), 1)
+			assert.Equal("chain1", family.GetMetric()[0].GetLabel()[0].GetValue())
+		}
+	}
+	assert.True(found)
+}
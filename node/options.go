@@ -0,0 +1,108 @@
+package node
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/celestiaorg/optimint/block"
+	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/mempool"
+	"github.com/celestiaorg/optimint/p2p"
+	"github.com/celestiaorg/optimint/settlement"
+	"github.com/celestiaorg/optimint/state"
+)
+
+// Option sets optional parameter of the Node.
+type Option func(*options)
+
+type options struct {
+	txPreCheck       mempool.PreCheckFunc
+	txPostCheck      mempool.PostCheckFunc
+	gossipTxPreCheck mempool.PreCheckFunc
+	p2pHost          *p2p.Host
+	dalc             da.DataAvailabilityLayerClient
+	clock            block.Clock
+	txDecrypter      state.TxDecrypter
+	executor         state.Executor
+	hooks            []Hooks
+	prover           block.ValidityProver
+	verifier         block.ValidityVerifier
+	slc              settlement.SettlementLayerClient
+	metricsRegistry  *prometheus.Registry
+}
+
+// WithTxPreCheck sets a filter applied to transactions before CheckTx.
+// It's applied in addition to max transaction size check (based on consensus params).
+func WithTxPreCheck(f mempool.PreCheckFunc) Option {
+	return func(o *options) { o.txPreCheck = f }
+}
+
+// WithTxPostCheck sets a filter applied to transactions after CheckTx.
+// It's applied in addition to max gas check (based on consensus params).
+func WithTxPostCheck(f mempool.PostCheckFunc) Option {
+	return func(o *options) { o.txPostCheck = f }
+}
+
+// WithGossipTxPreCheck sets a fast filter applied to transactions received via P2P gossip, before they are passed to
+// mempool. Rejected transactions are not checked with CheckTx and are not propagated further. Filter is not applied
+// to transactions submitted via RPC.
+func WithGossipTxPreCheck(f mempool.PreCheckFunc) Option {
+	return func(o *options) { o.gossipTxPreCheck = f }
+}
+
+// WithP2PHost sets libp2p host shared with other nodes running in the same process.
+// Host has to be started before the node.
+func WithP2PHost(h *p2p.Host) Option {
+	return func(o *options) { o.p2pHost = h }
+}
+
+// WithDALayerClient sets already initialized data availability layer client.
+// DALayer and DAConfig from node configuration are ignored.
+func WithDALayerClient(dalc da.DataAvailabilityLayerClient) Option {
+	return func(o *options) { o.dalc = dalc }
+}
+
+// WithClock sets clock used by block manager, e.g. block.ManualClock to drive block production in tests.
+func WithClock(clock block.Clock) Option {
+	return func(o *options) { o.clock = clock }
+}
+
+// WithTxDecrypter enables encrypted mempool mode (see state.TxDecrypter). Only outer transactions of encrypted
+// envelopes are checked by the application in mempool, and envelopes are decrypted when block is executed. All nodes
+// of the chain have to use the same decrypter.
+func WithTxDecrypter(decrypter state.TxDecrypter) Option {
+	return func(o *options) { o.txDecrypter = decrypter }
+}
+
+// WithExecutor sets execution layer used to execute blocks, e.g. external execution engine. By default, blocks are
+// executed by ABCI application. ABCI application is still used by mempool (CheckTx) and queries.
+func WithExecutor(executor state.Executor) Option {
+	return func(o *options) { o.executor = executor }
+}
+
+// WithHooks registers callbacks invoked on node lifecycle events (see Node.RegisterHooks).
+func WithHooks(hooks Hooks) Option {
+	return func(o *options) { o.hooks = append(o.hooks, hooks) }
+}
+
+// WithValidityProver sets ValidityProver used by aggregator to generate validity proofs of produced blocks, and by
+// full nodes to fetch proofs to be verified (see WithValidityVerifier).
+func WithValidityProver(prover block.ValidityProver) Option {
+	return func(o *options) { o.prover = prover }
+}
+
+// WithValidityVerifier enables verification of validity proofs of synced blocks. It requires WithValidityProver.
+func WithValidityVerifier(verifier block.ValidityVerifier) Option {
+	return func(o *options) { o.verifier = verifier }
+}
+
+// WithSettlementLayerClient sets already initialized settlement layer client. SettlementLayer and SettlementConfig from
+// node configuration are ignored.
+func WithSettlementLayerClient(slc settlement.SettlementLayerClient) Option {
+	return func(o *options) { o.slc = slc }
+}
+
+// WithMetricsRegistry sets Prometheus registry in which metrics of the node are registered. By default, every node
+// creates its own registry with Go runtime and process metrics.
+func WithMetricsRegistry(registry *prometheus.Registry) Option {
+	return func(o *options) { o.metricsRegistry = registry }
+}
@@ -0,0 +1,124 @@
+package node
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/celestiaorg/optimint/mempool"
+	"github.com/celestiaorg/optimint/store"
+)
+
+const (
+	// maxTxOrigins limits the number of origins with persisted counts, so that store can't be flooded with random
+	// origins. Invalid transactions of other origins are still used for rate limiting.
+	maxTxOrigins = 10000
+
+	// invalidTxsWindow is the period over which invalid transactions are counted for rate limiting.
+	invalidTxsWindow = time.Minute
+)
+
+// ErrTooManyInvalidTxs is returned to RPC clients that submitted too many invalid transactions recently.
+var ErrTooManyInvalidTxs = errors.New("too many invalid transactions submitted recently, try again later")
+
+// TxOrigins counts transactions rejected by the mempool per origin (peer or RPC client host, see
+// mempool.TxInfo.Origin), so peers and RPC clients can be held accountable for spam.
+//
+// Total counts are persisted, so they survive restarts. Counts from the current one minute window are used to rate
+// limit RPC clients.
+type TxOrigins struct {
+	kv        store.KVStore
+	maxRecent uint64
+	now       func() time.Time
+	logger    log.Logger
+
+	mtx         sync.Mutex
+	totals      map[string]uint64
+	recent      map[string]uint64
+	windowStart time.Time
+}
+
+// NewTxOrigins creates TxOrigins and loads counts persisted in given KV store. Origins with more than maxRecent
+// invalid transactions in the current window are rejected by Check (0 - no limit).
+func NewTxOrigins(kv store.KVStore, maxRecent uint64, logger log.Logger) (*TxOrigins, error) {
+	o := &TxOrigins{
+		kv:        kv,
+		maxRecent: maxRecent,
+		now:       time.Now,
+		logger:    logger,
+		totals:    make(map[string]uint64),
+		recent:    make(map[string]uint64),
+	}
+	it := kv.PrefixIterator(nil)
+	defer it.Discard()
+	for ; it.Valid(); it.Next() {
+		if len(it.Value()) != 8 {
+			return nil, errors.New("invalid transaction origin counter")
+		}
+		o.totals[string(it.Key())] = binary.BigEndian.Uint64(it.Value())
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// InvalidTx counts invalid transaction of given origin. Transactions of unknown origin are ignored.
+func (o *TxOrigins) InvalidTx(txInfo mempool.TxInfo) {
+	origin := txInfo.Origin()
+	if origin == "" {
+		return
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	o.resetWindow()
+	o.recent[origin]++
+	total, ok := o.totals[origin]
+	if !ok && len(o.totals) >= maxTxOrigins {
+		return
+	}
+	total++
+	o.totals[origin] = total
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, total)
+	if err := o.kv.Set([]byte(origin), value); err != nil {
+		o.logger.Error("failed to persist invalid transactions count", "origin", origin, "error", err)
+	}
+}
+
+// InvalidTxs returns the total number of invalid transactions of given origin.
+func (o *TxOrigins) InvalidTxs(origin string) uint64 {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	return o.totals[origin]
+}
+
+// Check returns ErrTooManyInvalidTxs if origin exceeded the limit of invalid transactions in current window.
+func (o *TxOrigins) Check(origin string) error {
+	if o.maxRecent == 0 || origin == "" {
+		return nil
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	o.resetWindow()
+	if o.recent[origin] >= o.maxRecent {
+		return ErrTooManyInvalidTxs
+	}
+	return nil
+}
+
+// resetWindow starts new window of recent counts, if the current one elapsed.
+func (o *TxOrigins) resetWindow() {
+	now := o.now()
+	if now.Sub(o.windowStart) >= invalidTxsWindow {
+		o.windowStart = now
+		o.recent = make(map[string]uint64)
+	}
+}
@@ -0,0 +1,79 @@
+package node
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/celestiaorg/optimint/config"
+	"github.com/celestiaorg/optimint/p2p"
+)
+
+// panicRestartDelay is the time to wait before goroutine is restarted after a panic.
+var panicRestartDelay = time.Second
+
+// goLoop runs loop in a new goroutine. If loop panics, the panic is recovered, logged and counted; then, depending on
+// panic policy, loop is restarted (after panicRestartDelay) or the node is stopped.
+func (n *Node) goLoop(name string, loop func(ctx context.Context)) {
+	go func() {
+		for n.runRecovered(name, loop) {
+			if n.conf.PanicPolicy == config.PanicPolicyShutdown {
+				return
+			}
+			select {
+			case <-n.ctx.Done():
+				return
+			case <-time.After(panicRestartDelay):
+			}
+			n.Logger.Info("restarting goroutine after panic", "routine", name)
+		}
+	}()
+}
+
+// runRecovered runs loop until it returns. It returns true if loop panicked.
+func (n *Node) runRecovered(name string, loop func(ctx context.Context)) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			n.handlePanic(name, r)
+			panicked = true
+		}
+	}()
+	loop(n.ctx)
+	return false
+}
+
+// recoverValidator wraps gossip validator, so that messages causing panics are rejected, instead of crashing the node.
+func (n *Node) recoverValidator(name string, validator p2p.GossipValidator) p2p.GossipValidator {
+	return func(m *p2p.GossipMessage) (valid bool) {
+		defer func() {
+			if r := recover(); r != nil {
+				n.handlePanic(name, r)
+				valid = false
+			}
+		}()
+		return validator(m)
+	}
+}
+
+// RecoverPanic recovers from panic in goroutine serving the node (e.g. RPC subscription), and handles it according to
+// panic policy. It has to be deferred directly.
+func (n *Node) RecoverPanic(name string) {
+	if r := recover(); r != nil {
+		n.handlePanic(name, r)
+	}
+}
+
+// handlePanic logs and counts recovered panic. If panic policy is set to shutdown, node is stopped.
+func (n *Node) handlePanic(name string, r interface{}) {
+	n.Logger.Error("recovered from panic", "routine", name, "panic", r, "stack", string(debug.Stack()))
+	n.panics.With("routine", name).Add(1)
+	if n.conf.PanicPolicy == config.PanicPolicyShutdown {
+		n.Logger.Error("stopping node after panic", "routine", name)
+		// stopping from a separate goroutine, as Stop may wait for goroutine that panicked
+		go func() {
+			if err := n.Stop(); err != nil {
+				n.Logger.Error("failed to stop node after panic", "error", err)
+			}
+		}()
+	}
+}
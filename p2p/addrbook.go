@@ -0,0 +1,174 @@
+package p2p
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"github.com/celestiaorg/optimint/store"
+)
+
+const (
+	// maxAddrBookSize is the maximum number of peers stored in address book.
+	maxAddrBookSize = 1000
+
+	// maxDialFailures is the number of consecutive failed connection attempts after which peer is removed from address book.
+	maxDialFailures = 3
+
+	// maxAddrsPerSource is the maximum number of address book entries added from addresses shared by single peer.
+	maxAddrsPerSource = 32
+)
+
+type addrBookEntry struct {
+	info     peer.AddrInfo
+	source   peer.ID
+	failures int
+}
+
+// addrBookRecord is persisted form of address book entry.
+type addrBookRecord struct {
+	Info   peer.AddrInfo
+	Source peer.ID `json:",omitempty"`
+}
+
+// AddrBook keeps addresses of known peers.
+//
+// If AddrBook is backed by KVStore, addresses are persisted, so node can quickly reconnect to known peers after restart.
+// Every entry remembers the peer that shared the address (if any), so a single peer can't fill the address book.
+type AddrBook struct {
+	mtx   sync.RWMutex
+	addrs map[peer.ID]*addrBookEntry
+	// sources counts entries added from addresses shared by given peer
+	sources map[peer.ID]int
+	kv      store.KVStore
+}
+
+// NewAddrBook creates AddrBook and loads all addresses persisted in given KVStore.
+func NewAddrBook(kv store.KVStore) (*AddrBook, error) {
+	ab := newAddrBook()
+	ab.kv = kv
+
+	it := kv.PrefixIterator(nil)
+	defer it.Discard()
+	for ; it.Valid(); it.Next() {
+		var record addrBookRecord
+		err := json.Unmarshal(it.Value(), &record)
+		if err != nil {
+			return nil, err
+		}
+		ab.addrs[record.Info.ID] = &addrBookEntry{info: record.Info, source: record.Source}
+		if record.Source != "" {
+			ab.sources[record.Source]++
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	return ab, nil
+}
+
+// newAddrBook creates in-memory AddrBook.
+func newAddrBook() *AddrBook {
+	return &AddrBook{
+		addrs:   make(map[peer.ID]*addrBookEntry),
+		sources: make(map[peer.ID]int),
+	}
+}
+
+// Add adds (or updates) address of a peer. It also resets the peer's dial failure counter.
+// Addresses should be added only after successful connection to the peer.
+//
+// Source is the peer that shared the address (empty, if address wasn't received from other peer). New entries from
+// single source are limited to maxAddrsPerSource. Source of existing entry is not changed. Peers without addresses
+// are ignored.
+func (ab *AddrBook) Add(info peer.AddrInfo, source peer.ID) error {
+	if len(info.Addrs) == 0 {
+		return nil
+	}
+
+	ab.mtx.Lock()
+	defer ab.mtx.Unlock()
+
+	if entry, ok := ab.addrs[info.ID]; ok {
+		source = entry.source
+	} else {
+		if len(ab.addrs) >= maxAddrBookSize {
+			return nil
+		}
+		if source != "" {
+			if ab.sources[source] >= maxAddrsPerSource {
+				return nil
+			}
+			ab.sources[source]++
+		}
+	}
+	ab.addrs[info.ID] = &addrBookEntry{info: info, source: source}
+
+	if ab.kv == nil {
+		return nil
+	}
+	value, err := json.Marshal(addrBookRecord{Info: info, Source: source})
+	if err != nil {
+		return err
+	}
+	return ab.kv.Set([]byte(info.ID), value)
+}
+
+// MarkFailed records failed connection attempt. Peer is removed after maxDialFailures consecutive failures.
+func (ab *AddrBook) MarkFailed(id peer.ID) error {
+	ab.mtx.Lock()
+	defer ab.mtx.Unlock()
+
+	entry, ok := ab.addrs[id]
+	if !ok {
+		return nil
+	}
+	entry.failures++
+	if entry.failures < maxDialFailures {
+		return nil
+	}
+
+	delete(ab.addrs, id)
+	if entry.source != "" {
+		ab.sources[entry.source]--
+		if ab.sources[entry.source] == 0 {
+			delete(ab.sources, entry.source)
+		}
+	}
+	if ab.kv == nil {
+		return nil
+	}
+	return ab.kv.Delete([]byte(id))
+}
+
+// Peers returns up to limit known peer addresses.
+func (ab *AddrBook) Peers(limit int) []peer.AddrInfo {
+	ab.mtx.RLock()
+	defer ab.mtx.RUnlock()
+
+	peers := make([]peer.AddrInfo, 0, min(limit, len(ab.addrs)))
+	for _, entry := range ab.addrs {
+		if len(peers) >= limit {
+			break
+		}
+		peers = append(peers, entry.info)
+	}
+	return peers
+}
+
+// Size returns number of known peers.
+func (ab *AddrBook) Size() int {
+	ab.mtx.RLock()
+	defer ab.mtx.RUnlock()
+
+	return len(ab.addrs)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
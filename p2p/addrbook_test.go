@@ -0,0 +1,95 @@
+package p2p
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/optimint/store"
+)
+
+func TestAddrBookPersistence(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kv := store.NewDefaultInMemoryKVStore()
+	ab, err := NewAddrBook(kv)
+	require.NoError(err)
+	assert.Equal(0, ab.Size())
+
+	p1, p2 := randomAddrInfo(t), randomAddrInfo(t)
+	require.NoError(ab.Add(p1, ""))
+	require.NoError(ab.Add(p2, ""))
+	// peers without addresses are ignored
+	require.NoError(ab.Add(peer.AddrInfo{ID: p1.ID + "x"}, ""))
+	assert.Equal(2, ab.Size())
+
+	// addresses are loaded after restart
+	ab, err = NewAddrBook(kv)
+	require.NoError(err)
+	assert.ElementsMatch([]peer.AddrInfo{p1, p2}, ab.Peers(10))
+	assert.Len(ab.Peers(1), 1)
+
+	// peer is removed after too many failed connection attempts
+	for i := 0; i < maxDialFailures-1; i++ {
+		require.NoError(ab.MarkFailed(p1.ID))
+	}
+	assert.Equal(2, ab.Size())
+	require.NoError(ab.MarkFailed(p1.ID))
+	assert.Equal(1, ab.Size())
+
+	ab, err = NewAddrBook(kv)
+	require.NoError(err)
+	assert.Equal([]peer.AddrInfo{p2}, ab.Peers(10))
+}
+
+func TestAddrBookSourceLimit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kv := store.NewDefaultInMemoryKVStore()
+	ab, err := NewAddrBook(kv)
+	require.NoError(err)
+
+	source := randomAddrInfo(t).ID
+	shared := make([]peer.AddrInfo, maxAddrsPerSource+1)
+	for i := range shared {
+		shared[i] = randomAddrInfo(t)
+		require.NoError(ab.Add(shared[i], source))
+	}
+	assert.Equal(maxAddrsPerSource, ab.Size())
+
+	// limit is enforced after restart
+	ab, err = NewAddrBook(kv)
+	require.NoError(err)
+	require.NoError(ab.Add(randomAddrInfo(t), source))
+	assert.Equal(maxAddrsPerSource, ab.Size())
+
+	// other sources are not affected
+	require.NoError(ab.Add(randomAddrInfo(t), randomAddrInfo(t).ID))
+	require.NoError(ab.Add(randomAddrInfo(t), ""))
+	assert.Equal(maxAddrsPerSource+2, ab.Size())
+
+	// removed entry frees the slot of its source
+	for i := 0; i < maxDialFailures; i++ {
+		require.NoError(ab.MarkFailed(shared[0].ID))
+	}
+	require.NoError(ab.Add(shared[maxAddrsPerSource], source))
+	assert.Equal(maxAddrsPerSource+2, ab.Size())
+}
+
+func randomAddrInfo(t *testing.T) peer.AddrInfo {
+	t.Helper()
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	id, err := peer.IDFromPrivateKey(privKey)
+	require.NoError(t, err)
+	addr, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/7676")
+	require.NoError(t, err)
+	return peer.AddrInfo{ID: id, Addrs: []multiaddr.Multiaddr{addr}}
+}
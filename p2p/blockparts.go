@@ -0,0 +1,628 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+
+	"github.com/celestiaorg/optimint/config"
+)
+
+const (
+	// blockManifestTopicSuffix is added after namespace to create pubsub topic for block manifest gossiping.
+	blockManifestTopicSuffix = "-block-manifest"
+
+	// blockPartTopicSuffix is added after namespace to create pubsub topic for block part gossiping.
+	blockPartTopicSuffix = "-block-part"
+
+	// blockPartsProtocolPrefix is added before namespace to create protocol ID for fetching block parts.
+	blockPartsProtocolPrefix = "/optimint/block-parts/0.1.0/"
+
+	// blockPartsGroupSize is the number of data parts protected by a single parity part.
+	blockPartsGroupSize = 4
+
+	// maxBlockPartSize limits the size of a single block part.
+	maxBlockPartSize = config.MaxBlockPartSize
+
+	// maxGossipedBlockSize limits the size of a block propagated in parts.
+	maxGossipedBlockSize = 64 << 20
+
+	// blockPartsCacheSize is the number of recent blocks, which parts are kept to serve other peers.
+	blockPartsCacheSize = 16
+
+	// blockPartsFetchDelay defines how long to wait for gossiped parts, before fetching missing ones from peers.
+	blockPartsFetchDelay = 500 * time.Millisecond
+
+	// blockPartsFetchRetries defines how many times fetching of missing parts is retried.
+	blockPartsFetchRetries = 3
+
+	// blockPartsFetchTimeout defines how long fetching parts from a single peer can take.
+	blockPartsFetchTimeout = 10 * time.Second
+
+	// blockPartsMaxPeers limits the number of peers missing parts are fetched from at once.
+	blockPartsMaxPeers = 8
+
+	// blockPartsMaxPeerRequests limits the number of requests for block parts sent to a single peer at once.
+	blockPartsMaxPeerRequests = 2
+
+	// maxBlockPartsRequest limits the number of parts requested from a peer at once.
+	maxBlockPartsRequest = 64
+
+	// maxBlockPartsRequestSize limits size of JSON encoded block parts request.
+	maxBlockPartsRequestSize = maxBlockPartsRequest * 256
+
+	// maxBlockPartsResponseSize limits size of JSON encoded block parts response (base64 increases size by 1/3).
+	maxBlockPartsResponseSize = 2 * maxBlockPartsRequest * maxBlockPartSize
+)
+
+// PartKey identifies block part (SHA256 of part bytes).
+type PartKey = [sha256.Size]byte
+
+// blockManifest describes block split into parts. It's gossiped before the parts.
+//
+// Block bytes are split into data parts of PartSize bytes (the last part is padded with zeroes). Every group of
+// blockPartsGroupSize consecutive data parts is protected by a parity part (XOR of the group), so any single missing
+// part of a group can be recovered. All parts are content-addressed.
+//
+// Manifest is signed by the node gossiping the block (see BlockManifestVerifier).
+type blockManifest struct {
+	Hash      PartKey   `json:"hash"`
+	Size      int       `json:"size"`
+	PartSize  int       `json:"part_size"`
+	Parts     []PartKey `json:"parts"`
+	Parity    []PartKey `json:"parity"`
+	Signature []byte    `json:"signature,omitempty"`
+}
+
+// signBytes returns bytes of the manifest that are signed (manifest without signature).
+func (m *blockManifest) signBytes() ([]byte, error) {
+	unsigned := *m
+	unsigned.Signature = nil
+	return json.Marshal(&unsigned)
+}
+
+func (m *blockManifest) validate() error {
+	if m.Size <= 0 || m.Size > maxGossipedBlockSize {
+		return fmt.Errorf("invalid block size: %d", m.Size)
+	}
+	if m.PartSize <= 0 || m.PartSize > maxBlockPartSize {
+		return fmt.Errorf("invalid block part size: %d", m.PartSize)
+	}
+	if n := (m.Size + m.PartSize - 1) / m.PartSize; len(m.Parts) != n {
+		return fmt.Errorf("invalid number of block parts: %d, expected: %d", len(m.Parts), n)
+	}
+	if g := (len(m.Parts) + blockPartsGroupSize - 1) / blockPartsGroupSize; len(m.Parity) != g {
+		return fmt.Errorf("invalid number of parity parts: %d, expected: %d", len(m.Parity), g)
+	}
+	return nil
+}
+
+// splitBlock splits block bytes into data and parity parts, and returns manifest describing them.
+func splitBlock(block []byte, partSize int) (*blockManifest, [][]byte) {
+	n := (len(block) + partSize - 1) / partSize
+	g := (n + blockPartsGroupSize - 1) / blockPartsGroupSize
+	parts := make([][]byte, n+g)
+	for i := 0; i < n; i++ {
+		part := make([]byte, partSize)
+		copy(part, block[i*partSize:])
+		parts[i] = part
+	}
+	for j := 0; j < g; j++ {
+		parts[n+j] = xorParts(parts[j*blockPartsGroupSize:minInt((j+1)*blockPartsGroupSize, n)], partSize)
+	}
+
+	manifest := &blockManifest{
+		Hash:     sha256.Sum256(block),
+		Size:     len(block),
+		PartSize: partSize,
+		Parts:    make([]PartKey, n),
+		Parity:   make([]PartKey, g),
+	}
+	for i, part := range parts {
+		if i < n {
+			manifest.Parts[i] = sha256.Sum256(part)
+		} else {
+			manifest.Parity[i-n] = sha256.Sum256(part)
+		}
+	}
+	return manifest, parts
+}
+
+// xorParts returns XOR of given parts (nil parts are skipped).
+func xorParts(parts [][]byte, partSize int) []byte {
+	res := make([]byte, partSize)
+	for _, part := range parts {
+		for i := range part {
+			res[i] ^= part[i]
+		}
+	}
+	return res
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// blockAssembly collects parts of a single block.
+type blockAssembly struct {
+	manifest *blockManifest
+	from     peer.ID
+	// parts contains data parts followed by parity parts (nil if part is missing)
+	parts    [][]byte
+	complete bool
+}
+
+// index returns position of part with given key, or -1 if it's not a part of the block.
+func (a *blockAssembly) index(key PartKey) int {
+	for i, k := range a.manifest.Parts {
+		if k == key {
+			return i
+		}
+	}
+	for i, k := range a.manifest.Parity {
+		if k == key {
+			return len(a.manifest.Parts) + i
+		}
+	}
+	return -1
+}
+
+// missing returns keys of missing parts.
+func (a *blockAssembly) missing() []PartKey {
+	keys := make([]PartKey, 0)
+	for i, part := range a.parts {
+		if part != nil {
+			continue
+		}
+		if i < len(a.manifest.Parts) {
+			keys = append(keys, a.manifest.Parts[i])
+		} else {
+			keys = append(keys, a.manifest.Parity[i-len(a.manifest.Parts)])
+		}
+	}
+	return keys
+}
+
+// reconstruct recovers missing data parts using parity parts, and returns block bytes. False is returned if
+// block can't be reconstructed yet.
+func (a *blockAssembly) reconstruct() ([]byte, bool) {
+	n := len(a.manifest.Parts)
+	for j := range a.manifest.Parity {
+		group := a.parts[j*blockPartsGroupSize : minInt((j+1)*blockPartsGroupSize, n)]
+		missing := -1
+		for i, part := range group {
+			if part == nil {
+				if missing >= 0 {
+					return nil, false
+				}
+				missing = i
+			}
+		}
+		if missing < 0 {
+			continue
+		}
+		parity := a.parts[n+j]
+		if parity == nil {
+			return nil, false
+		}
+		others := make([][]byte, 0, len(group))
+		for i, part := range group {
+			if i != missing {
+				others = append(others, part)
+			}
+		}
+		group[missing] = xorParts(append(others, parity), a.manifest.PartSize)
+	}
+
+	block := bytes.Join(a.parts[:n], nil)[:a.manifest.Size]
+	return block, true
+}
+
+// blockPartsState keeps parts of recently seen blocks.
+type blockPartsState struct {
+	mtx        sync.Mutex
+	assemblies map[PartKey]*blockAssembly
+	// order of block hashes, used to evict the oldest assemblies
+	order []PartKey
+	// requests is the number of pending requests for block parts, by peer
+	requests map[peer.ID]int
+}
+
+// BlockManifestVerifier checks signature of a block manifest. Manifests are signed with the key of the node
+// gossiping the block, so only blocks gossiped by the sequencer should be accepted.
+type BlockManifestVerifier func(msg []byte, signature []byte) error
+
+// SetBlockValidator sets the callback function, that will be invoked with every block reassembled from parts
+// (see GossipBlock).
+func (c *Client) SetBlockValidator(validator GossipValidator) {
+	c.blockValidator = validator
+}
+
+// SetBlockManifestVerifier sets the callback function, that checks signatures of block manifests. Parts of blocks
+// are collected only if manifest is signed by a trusted node; all manifests are rejected if verifier is not set.
+func (c *Client) SetBlockManifestVerifier(verifier BlockManifestVerifier) {
+	c.blockManifestVerifier = verifier
+}
+
+// GossipBlock splits serialized block into content-addressed, erasure-coded parts and gossips them to the P2P
+// network. Block manifest (list of part hashes) is gossiped first, so peers can fetch parts lost in gossip from
+// multiple other peers. Block gossip has to be enabled in configuration.
+func (c *Client) GossipBlock(ctx context.Context, blockBytes []byte) error {
+	if c.conf.SeedMode {
+		return errSeedMode
+	}
+	if !c.conf.BlockGossip {
+		return errBlockGossipDisabled
+	}
+	if len(blockBytes) == 0 || len(blockBytes) > maxGossipedBlockSize {
+		return fmt.Errorf("invalid block size: %d", len(blockBytes))
+	}
+	c.logger.Debug("gossiping block", "len", len(blockBytes))
+	manifest, parts := splitBlock(blockBytes, c.conf.BlockPartSize)
+	signBytes, err := manifest.signBytes()
+	if err != nil {
+		return err
+	}
+	manifest.Signature, err = c.privKey.Sign(signBytes)
+	if err != nil {
+		return err
+	}
+	c.addAssembly(&blockAssembly{manifest: manifest, from: c.host.ID(), parts: parts, complete: true})
+
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	if err := c.blockManifestGossiper.Publish(ctx, manifestBytes); err != nil {
+		return err
+	}
+	for _, part := range parts {
+		if err := c.blockPartGossiper.Publish(ctx, part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setupBlockParts registers handler for block parts protocol, used to fetch parts that were not received via gossip.
+//
+// Block parts protocol is a request/response protocol: requester opens a stream and writes JSON encoded keys
+// of wanted parts, responder writes the parts back (null for unknown parts).
+func (c *Client) setupBlockParts() {
+	if !c.conf.BlockGossip {
+		return
+	}
+	c.host.SetStreamHandler(c.getBlockPartsProtocol(), c.handleBlockParts)
+}
+
+// closeBlockParts stops serving block parts.
+func (c *Client) closeBlockParts() {
+	if !c.conf.BlockGossip {
+		return
+	}
+	c.host.RemoveStreamHandler(c.getBlockPartsProtocol())
+}
+
+// addAssembly starts collecting parts of a block, evicting the oldest block if cache is full. False is returned if
+// block is already known.
+func (c *Client) addAssembly(a *blockAssembly) bool {
+	c.blockParts.mtx.Lock()
+	defer c.blockParts.mtx.Unlock()
+	if _, ok := c.blockParts.assemblies[a.manifest.Hash]; ok {
+		return false
+	}
+	if len(c.blockParts.order) >= blockPartsCacheSize {
+		delete(c.blockParts.assemblies, c.blockParts.order[0])
+		c.blockParts.order = c.blockParts.order[1:]
+	}
+	c.blockParts.assemblies[a.manifest.Hash] = a
+	c.blockParts.order = append(c.blockParts.order, a.manifest.Hash)
+	return true
+}
+
+// addPart adds part to the block it belongs to. Reassembled block is returned, if part completes the block.
+// False is returned if part doesn't belong to any known block.
+func (c *Client) addPart(part []byte) ([]byte, *blockAssembly, bool) {
+	key := sha256.Sum256(part)
+	c.blockParts.mtx.Lock()
+	defer c.blockParts.mtx.Unlock()
+	for _, a := range c.blockParts.assemblies {
+		i := a.index(key)
+		if i < 0 {
+			continue
+		}
+		if a.complete || a.parts[i] != nil {
+			return nil, a, true
+		}
+		a.parts[i] = part
+		block, ok := a.reconstruct()
+		if !ok {
+			return nil, a, true
+		}
+		if sha256.Sum256(block) != a.manifest.Hash {
+			c.logger.Error("reassembled block doesn't match manifest", "hash", fmt.Sprintf("%X", a.manifest.Hash))
+			c.removeAssembly(a.manifest.Hash)
+			return nil, a, false
+		}
+		a.complete = true
+		return block, a, true
+	}
+	return nil, nil, false
+}
+
+// removeAssembly removes block from the cache. Caller must hold blockParts.mtx.
+func (c *Client) removeAssembly(hash PartKey) {
+	delete(c.blockParts.assemblies, hash)
+	for i, h := range c.blockParts.order {
+		if h == hash {
+			c.blockParts.order = append(c.blockParts.order[:i], c.blockParts.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// getParts returns parts with given keys (nil for unknown parts).
+func (c *Client) getParts(keys []PartKey) [][]byte {
+	c.blockParts.mtx.Lock()
+	defer c.blockParts.mtx.Unlock()
+	parts := make([][]byte, len(keys))
+	for i, key := range keys {
+		for _, a := range c.blockParts.assemblies {
+			if j := a.index(key); j >= 0 {
+				parts[i] = a.parts[j]
+				break
+			}
+		}
+	}
+	return parts
+}
+
+// missingParts returns keys of missing parts of given block, or nil if block is complete (or unknown).
+func (c *Client) missingParts(hash PartKey) []PartKey {
+	c.blockParts.mtx.Lock()
+	defer c.blockParts.mtx.Unlock()
+	a, ok := c.blockParts.assemblies[hash]
+	if !ok || a.complete {
+		return nil
+	}
+	return a.missing()
+}
+
+// verifyManifest checks signature of block manifest (see SetBlockManifestVerifier).
+func (c *Client) verifyManifest(manifest *blockManifest) error {
+	if c.blockManifestVerifier == nil {
+		return errors.New("block manifest verifier is not set")
+	}
+	signBytes, err := manifest.signBytes()
+	if err != nil {
+		return err
+	}
+	return c.blockManifestVerifier(signBytes, manifest.Signature)
+}
+
+// newBlockManifestValidator creates a validator that starts collecting parts of block announced with signed
+// manifest. Missing parts are fetched from peers after blockPartsFetchDelay.
+func (c *Client) newBlockManifestValidator(ctx context.Context) GossipValidator {
+	return func(m *GossipMessage) bool {
+		var manifest blockManifest
+		if err := json.Unmarshal(m.Data, &manifest); err != nil {
+			c.logger.Debug("failed to deserialize block manifest", "from", m.From, "error", err)
+			return false
+		}
+		if err := manifest.validate(); err != nil {
+			c.logger.Debug("invalid block manifest", "from", m.From, "error", err)
+			return false
+		}
+		if err := c.verifyManifest(&manifest); err != nil {
+			c.logger.Debug("failed to verify block manifest", "from", m.From, "error", err)
+			return false
+		}
+		a := &blockAssembly{
+			manifest: &manifest,
+			from:     m.From,
+			parts:    make([][]byte, len(manifest.Parts)+len(manifest.Parity)),
+		}
+		if !c.addAssembly(a) {
+			return true
+		}
+		c.logger.Debug("block manifest received", "from", m.From, "hash", fmt.Sprintf("%X", manifest.Hash),
+			"parts", len(manifest.Parts))
+		go c.fetchMissingParts(ctx, manifest.Hash, m.From)
+		return true
+	}
+}
+
+// newBlockPartValidator creates a validator that adds parts to known blocks. Parts of unknown blocks are not
+// propagated.
+func (c *Client) newBlockPartValidator() GossipValidator {
+	return func(m *GossipMessage) bool {
+		block, a, ok := c.addPart(m.Data)
+		if !ok {
+			return false
+		}
+		if block != nil {
+			c.deliverBlock(block, a.from)
+		}
+		return true
+	}
+}
+
+// deliverBlock passes reassembled block to the block validator.
+func (c *Client) deliverBlock(block []byte, from peer.ID) {
+	c.logger.Debug("block reassembled from parts", "from", from, "len", len(block))
+	if c.blockValidator != nil {
+		c.blockValidator(&GossipMessage{Data: block, From: from})
+	}
+}
+
+// fetchMissingParts waits for gossiped parts of a block, and fetches the missing ones from peers. Missing parts are
+// split between up to blockPartsMaxPeers peers (starting with the peer that announced the block), so they can be
+// fetched in parallel. Peers with blockPartsMaxPeerRequests pending requests are skipped.
+func (c *Client) fetchMissingParts(ctx context.Context, hash PartKey, from peer.ID) {
+	for r := 0; r < blockPartsFetchRetries; r++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(blockPartsFetchDelay):
+		}
+		missing := c.missingParts(hash)
+		if len(missing) == 0 {
+			return
+		}
+
+		peers := make([]peer.ID, 0, blockPartsMaxPeers)
+		seen := make(map[peer.ID]bool)
+		for _, id := range append([]peer.ID{from}, c.host.Network().Peers()...) {
+			if len(peers) == blockPartsMaxPeers {
+				break
+			}
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			if c.acquirePeerRequest(id) {
+				peers = append(peers, id)
+			}
+		}
+		if len(peers) == 0 {
+			c.logger.Debug("all peers are busy, not fetching block parts", "hash", fmt.Sprintf("%X", hash))
+			continue
+		}
+		requests := make(map[peer.ID][]PartKey)
+		for i, key := range missing {
+			id := peers[(i+r)%len(peers)]
+			requests[id] = append(requests[id], key)
+		}
+
+		var wg sync.WaitGroup
+		for _, id := range peers {
+			keys, ok := requests[id]
+			if !ok {
+				c.releasePeerRequest(id)
+				continue
+			}
+			wg.Add(1)
+			go func(id peer.ID, keys []PartKey) {
+				defer wg.Done()
+				defer c.releasePeerRequest(id)
+				parts, err := c.requestParts(ctx, id, keys)
+				if err != nil {
+					c.logger.Debug("failed to fetch block parts", "peer", id, "error", err)
+					return
+				}
+				for _, part := range parts {
+					if part == nil {
+						continue
+					}
+					if block, a, _ := c.addPart(part); block != nil {
+						c.deliverBlock(block, a.from)
+					}
+				}
+			}(id, keys)
+		}
+		wg.Wait()
+	}
+}
+
+// acquirePeerRequest reserves a slot for request of block parts sent to given peer. False is returned if there are
+// already blockPartsMaxPeerRequests requests pending.
+func (c *Client) acquirePeerRequest(id peer.ID) bool {
+	c.blockParts.mtx.Lock()
+	defer c.blockParts.mtx.Unlock()
+	if c.blockParts.requests[id] >= blockPartsMaxPeerRequests {
+		return false
+	}
+	c.blockParts.requests[id]++
+	return true
+}
+
+// releasePeerRequest releases slot reserved by acquirePeerRequest.
+func (c *Client) releasePeerRequest(id peer.ID) {
+	c.blockParts.mtx.Lock()
+	defer c.blockParts.mtx.Unlock()
+	if c.blockParts.requests[id]--; c.blockParts.requests[id] <= 0 {
+		delete(c.blockParts.requests, id)
+	}
+}
+
+func (c *Client) handleBlockParts(s network.Stream) {
+	defer s.Close()
+	remote := s.Conn().RemotePeer()
+
+	_ = s.SetDeadline(time.Now().Add(blockPartsFetchTimeout))
+	var keys []PartKey
+	err := json.NewDecoder(io.LimitReader(s, maxBlockPartsRequestSize)).Decode(&keys)
+	if err != nil {
+		c.logger.Debug("failed to read block parts request", "peer", remote, "error", err)
+		_ = s.Reset()
+		return
+	}
+	if len(keys) > maxBlockPartsRequest {
+		keys = keys[:maxBlockPartsRequest]
+	}
+	err = json.NewEncoder(s).Encode(c.getParts(keys))
+	if err != nil {
+		c.logger.Error("failed to send block parts", "peer", remote, "error", err)
+		_ = s.Reset()
+	}
+}
+
+// requestParts fetches parts with given keys from given peer.
+func (c *Client) requestParts(ctx context.Context, id peer.ID, keys []PartKey) ([][]byte, error) {
+	if len(keys) > maxBlockPartsRequest {
+		keys = keys[:maxBlockPartsRequest]
+	}
+	ctx, cancel := context.WithTimeout(ctx, blockPartsFetchTimeout)
+	defer cancel()
+
+	s, err := c.host.NewStream(ctx, id, c.getBlockPartsProtocol())
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	_ = s.SetDeadline(time.Now().Add(blockPartsFetchTimeout))
+	if err := json.NewEncoder(s).Encode(keys); err != nil {
+		_ = s.Reset()
+		return nil, err
+	}
+	var parts [][]byte
+	if err := json.NewDecoder(io.LimitReader(s, maxBlockPartsResponseSize)).Decode(&parts); err != nil {
+		_ = s.Reset()
+		return nil, err
+	}
+	if len(parts) != len(keys) {
+		return nil, errors.New("unexpected number of block parts")
+	}
+	for i, part := range parts {
+		if part != nil && sha256.Sum256(part) != keys[i] {
+			return nil, errors.New("block part doesn't match requested key")
+		}
+	}
+	return parts, nil
+}
+
+func (c *Client) getBlockManifestTopic() string {
+	return c.getNamespace() + blockManifestTopicSuffix
+}
+
+func (c *Client) getBlockPartTopic() string {
+	return c.getNamespace() + blockPartTopicSuffix
+}
+
+func (c *Client) getBlockPartsProtocol() protocol.ID {
+	return protocol.ID(blockPartsProtocolPrefix + c.getNamespace())
+}
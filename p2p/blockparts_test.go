@@ -0,0 +1,247 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/optimint/config"
+	"github.com/celestiaorg/optimint/log/test"
+)
+
+func TestSplitBlock(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	block := make([]byte, 10*100+17)
+	_, err := rand.Read(block)
+	require.NoError(err)
+
+	manifest, parts := splitBlock(block, 100)
+	require.NoError(manifest.validate())
+	assert.Len(manifest.Parts, 11)
+	assert.Len(manifest.Parity, 3)
+	assert.Len(parts, 14)
+
+	newAssembly := func() *blockAssembly {
+		a := &blockAssembly{manifest: manifest, parts: make([][]byte, len(parts))}
+		copy(a.parts, parts)
+		return a
+	}
+
+	// single missing part of every group is recovered
+	a := newAssembly()
+	a.parts[0], a.parts[6], a.parts[10] = nil, nil, nil
+	reconstructed, ok := a.reconstruct()
+	assert.True(ok)
+	assert.Equal(block, reconstructed)
+
+	// missing parity part doesn't matter if all data parts are available
+	a = newAssembly()
+	a.parts[11] = nil
+	reconstructed, ok = a.reconstruct()
+	assert.True(ok)
+	assert.Equal(block, reconstructed)
+	assert.Equal([]PartKey{manifest.Parity[0]}, a.missing())
+
+	// two missing parts of a single group can't be recovered
+	a = newAssembly()
+	a.parts[4], a.parts[5] = nil, nil
+	_, ok = a.reconstruct()
+	assert.False(ok)
+
+	a = newAssembly()
+	a.parts[4], a.parts[12] = nil, nil
+	_, ok = a.reconstruct()
+	assert.False(ok)
+	assert.Equal([]PartKey{manifest.Parts[4], manifest.Parity[1]}, a.missing())
+}
+
+type testBlockSink struct {
+	mtx    sync.Mutex
+	blocks [][]byte
+}
+
+func (s *testBlockSink) validator(m *GossipMessage) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.blocks = append(s.blocks, m.Data)
+	return true
+}
+
+func (s *testBlockSink) received() [][]byte {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([][]byte{}, s.blocks...)
+}
+
+func TestBlockGossip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	logger := &test.TestLogger{T: t}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinks := []*testBlockSink{{}, {}, {}}
+	validators := []GossipValidator{
+		func(*GossipMessage) bool { return true },
+		func(*GossipMessage) bool { return true },
+		func(*GossipMessage) bool { return true },
+	}
+
+	// network connections topology: 0<->1<->2
+	clients := startTestNetwork(ctx, t, 3, map[int]hostDescr{
+		0: {conns: []int{}, chainID: "1", realKey: true, blockValidator: sinks[0].validator, blockPartSize: 1024},
+		1: {conns: []int{0}, chainID: "1", realKey: true, blockValidator: sinks[1].validator, blockPartSize: 1024},
+		2: {conns: []int{1}, chainID: "1", realKey: true, blockValidator: sinks[2].validator, blockPartSize: 1024},
+	}, validators, logger)
+
+	// wait for gossip meshes to form
+	time.Sleep(time.Second)
+
+	block := make([]byte, 20*1024+1)
+	_, err := rand.Read(block)
+	require.NoError(err)
+	require.NoError(clients[0].GossipBlock(ctx, block))
+
+	for _, i := range []int{1, 2} {
+		sink := sinks[i]
+		assert.Eventually(func() bool {
+			return len(sink.received()) == 1
+		}, 5*time.Second, 50*time.Millisecond)
+		assert.Equal([][]byte{block}, sink.received())
+	}
+	// block is not delivered back to the sender
+	assert.Empty(sinks[0].received())
+
+	// blocks gossiped by other nodes are not accepted
+	other := make([]byte, 2*1024)
+	_, err = rand.Read(other)
+	require.NoError(err)
+	assert.Error(clients[1].GossipBlock(ctx, other))
+	time.Sleep(time.Second)
+	assert.Equal([][]byte{block}, sinks[2].received())
+	assert.Empty(sinks[0].received())
+}
+
+func TestBlockManifestVerification(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(err)
+	other, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(err)
+
+	block := make([]byte, 3*1024)
+	_, err = rand.Read(block)
+	require.NoError(err)
+	manifest, _ := splitBlock(block, 1024)
+	signBytes, err := manifest.signBytes()
+	require.NoError(err)
+	manifest.Signature, err = key.Sign(signBytes)
+	require.NoError(err)
+
+	client, err := NewClient(config.P2PConfig{BlockGossip: true}, key, "test", &test.TestLogger{T: t})
+	require.NoError(err)
+	assert.Error(client.verifyManifest(manifest))
+
+	client.SetBlockManifestVerifier(func(msg []byte, signature []byte) error {
+		ok, err := key.GetPublic().Verify(msg, signature)
+		if err != nil || !ok {
+			return errors.New("invalid signature")
+		}
+		return nil
+	})
+	assert.NoError(client.verifyManifest(manifest))
+
+	tampered := *manifest
+	tampered.Size--
+	assert.Error(client.verifyManifest(&tampered))
+
+	manifest.Signature, err = other.Sign(signBytes)
+	require.NoError(err)
+	assert.Error(client.verifyManifest(manifest))
+}
+
+func TestBlockPartsPeerRequests(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(err)
+	client, err := NewClient(config.P2PConfig{BlockGossip: true}, key, "test", &test.TestLogger{T: t})
+	require.NoError(err)
+	for i := 0; i < blockPartsMaxPeerRequests; i++ {
+		assert.True(client.acquirePeerRequest("a"))
+	}
+	assert.False(client.acquirePeerRequest("a"))
+	assert.True(client.acquirePeerRequest("b"))
+
+	client.releasePeerRequest("a")
+	assert.True(client.acquirePeerRequest("a"))
+	for i := 0; i < blockPartsMaxPeerRequests; i++ {
+		client.releasePeerRequest("a")
+	}
+	client.releasePeerRequest("b")
+	assert.Empty(client.blockParts.requests)
+}
+
+func TestBlockPartsFetch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	logger := &test.TestLogger{T: t}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinks := []*testBlockSink{{}, {}, {}}
+	validators := []GossipValidator{
+		func(*GossipMessage) bool { return true },
+		func(*GossipMessage) bool { return true },
+		func(*GossipMessage) bool { return true },
+	}
+
+	// network connections topology: 0<->2, 1<->2
+	clients := startTestNetwork(ctx, t, 3, map[int]hostDescr{
+		0: {conns: []int{2}, chainID: "1", realKey: true, blockValidator: sinks[0].validator},
+		1: {conns: []int{2}, chainID: "1", realKey: true, blockValidator: sinks[1].validator},
+		2: {conns: []int{}, chainID: "1", realKey: true, blockValidator: sinks[2].validator},
+	}, validators, logger)
+
+	block := make([]byte, 10*1024)
+	_, err := rand.Read(block)
+	require.NoError(err)
+	manifest, parts := splitBlock(block, 1024)
+
+	// nodes 0 and 1 know only some parts of the block, node 2 doesn't have any parts
+	known := func(from int) [][]byte {
+		res := make([][]byte, len(parts))
+		for i := from; i < len(parts); i += 2 {
+			res[i] = parts[i]
+		}
+		return res
+	}
+	clients[0].addAssembly(&blockAssembly{manifest: manifest, parts: known(0), complete: true})
+	clients[1].addAssembly(&blockAssembly{manifest: manifest, parts: known(1), complete: true})
+	clients[2].addAssembly(&blockAssembly{manifest: manifest, parts: make([][]byte, len(parts))})
+	assert.Len(clients[2].missingParts(manifest.Hash), len(parts))
+
+	// missing parts are fetched from both peers
+	clients[2].fetchMissingParts(ctx, manifest.Hash, clients[0].host.ID())
+	assert.Equal([][]byte{block}, sinks[2].received())
+	assert.Empty(clients[2].missingParts(manifest.Hash))
+
+	// fetched parts are served to other peers
+	fetched, err := clients[0].requestParts(ctx, clients[2].host.ID(), manifest.Parts)
+	require.NoError(err)
+	assert.Equal(parts[:len(manifest.Parts)], fetched)
+}
@@ -2,11 +2,13 @@ package p2p
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/libp2p/go-libp2p"
+	circuit "github.com/libp2p/go-libp2p-circuit"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	cdiscovery "github.com/libp2p/go-libp2p-core/discovery"
 	"github.com/libp2p/go-libp2p-core/host"
@@ -15,7 +17,10 @@ import (
 	discovery "github.com/libp2p/go-libp2p-discovery"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	tptu "github.com/libp2p/go-libp2p-transport-upgrader"
 	routedhost "github.com/libp2p/go-libp2p/p2p/host/routed"
+	tcp "github.com/libp2p/go-tcp-transport"
+	ws "github.com/libp2p/go-ws-transport"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/tendermint/tendermint/p2p"
 	"go.uber.org/multierr"
@@ -35,8 +40,14 @@ const (
 	// txTopicSuffix is added after namespace to create pubsub topic for TX gossiping.
 	txTopicSuffix = "-tx"
 
-	// headerTopicSuffix is added after namespace to create pubsub topic for block header gossiping.
-	headerTopicSuffix = "-header"
+	// txBatchTopicSuffix is added after namespace to create pubsub topic for gossiping batches of TXs.
+	txBatchTopicSuffix = "-tx-batch"
+
+	// headerTopicSuffix is added after namespace to create pubsub topic for signed block header gossiping.
+	headerTopicSuffix = "-signed-header"
+
+	// checkpointTopicSuffix is added after namespace to create pubsub topic for signed checkpoint gossiping.
+	checkpointTopicSuffix = "-checkpoint"
 )
 
 // Client is a P2P client, implemented with libp2p.
@@ -44,21 +55,53 @@ const (
 // Initially, client connects to predefined seed nodes (aka bootnodes, bootstrap nodes).
 // Those seed nodes serve Kademlia DHT protocol, and are agnostic to ORU chain. Using DHT
 // peer routing and discovery clients find other peers within ORU network.
+//
+// Addresses of connected peers are stored in address book, and exchanged with other peers
+// using peer exchange protocol (see pex.go). After restart, client reconnects to known peers.
+//
+// In seed mode, client only serves DHT (peer routing and address exchange) - gossiping and
+// namespace advertisement are disabled.
 type Client struct {
 	conf    config.P2PConfig
 	chainID string
 	privKey crypto.PrivKey
 
-	host host.Host
-	dht  *dht.IpfsDHT
-	disc *discovery.RoutingDiscovery
+	host     host.Host
+	shared   *Host
+	dht      *dht.IpfsDHT
+	disc     *discovery.RoutingDiscovery
+	addrBook *AddrBook
+	limiter  *connLimiter
+
+	// gossipStats is created when gossipsub router is set up (it's nil if Client uses shared Host, or in seed mode)
+	gossipStats *gossipStats
 
 	txGossiper  *Gossiper
 	txValidator GossipValidator
 
+	// txBatchGossiper is always set up to receive batches; txBatcher is nil if batching is disabled
+	txBatchGossiper *Gossiper
+	txBatcher       *txBatcher
+
 	headerGossiper  *Gossiper
 	headerValidator GossipValidator
 
+	// checkpoint gossiper is set up only if checkpoint validator is set
+	checkpointGossiper  *Gossiper
+	checkpointValidator GossipValidator
+
+	// block gossipers are set up only if block gossip is enabled
+	blockManifestGossiper *Gossiper
+	blockPartGossiper     *Gossiper
+	blockValidator        GossipValidator
+	blockManifestVerifier BlockManifestVerifier
+	blockParts            blockPartsState
+
+	mempoolSource       MempoolSource
+	mempoolSyncNotifiee *network.NotifyBundle
+
+	handshake handshakeState
+
 	// cancel is used to cancel context passed to libp2p functions
 	// it's required because of discovery.Advertise call
 	cancel context.CancelFunc
@@ -74,15 +117,42 @@ func NewClient(conf config.P2PConfig, privKey crypto.PrivKey, chainID string, lo
 	if privKey == nil {
 		return nil, errNoPrivKey
 	}
+	// gossip topics and discovery namespace are derived from chain ID, so it can't be empty
+	if chainID == "" {
+		return nil, errNoChainID
+	}
+	return newClient(conf, privKey, chainID, logger), nil
+}
+
+// newClient creates new Client object, without validating chain ID. It's also used by Host, which doesn't gossip.
+func newClient(conf config.P2PConfig, privKey crypto.PrivKey, chainID string, logger log.Logger) *Client {
 	if conf.ListenAddress == "" {
 		conf.ListenAddress = config.DefaultListenAddress
 	}
+	if conf.Transports == "" {
+		conf.Transports = config.DefaultTransports
+	}
+	if conf.TxBatchMaxBytes == 0 {
+		conf.TxBatchMaxBytes = config.DefaultTxBatchMaxBytes
+	}
+	if conf.TxBatchTimeout == 0 {
+		conf.TxBatchTimeout = config.DefaultTxBatchTimeout
+	}
+	if conf.BlockPartSize == 0 {
+		conf.BlockPartSize = config.DefaultBlockPartSize
+	}
 	return &Client{
-		conf:    conf,
-		privKey: privKey,
-		chainID: chainID,
-		logger:  logger,
-	}, nil
+		conf:     conf,
+		privKey:  privKey,
+		chainID:  chainID,
+		addrBook: newAddrBook(),
+		limiter:  newConnLimiter(conf, logger),
+		blockParts: blockPartsState{
+			assemblies: make(map[PartKey]*blockAssembly),
+			requests:   make(map[peer.ID]int),
+		},
+		logger: logger,
+	}
 }
 
 // Start establish Client's P2P connectivity.
@@ -91,10 +161,16 @@ func NewClient(conf config.P2PConfig, privKey crypto.PrivKey, chainID string, lo
 // 1. Setup libp2p host, start listening for incoming connections.
 // 2. Setup gossibsub.
 // 3. Setup DHT, establish connection to seed nodes and initialize peer discovery.
-// 4. Use active peer discovery to look for peers from same ORU network.
+// 4. Setup peer exchange and reconnect to peers from address book.
+// 5. Use active peer discovery to look for peers from same ORU network.
+//
+// In seed mode, steps 2 and 5 are skipped and client doesn't reconnect to known peers.
 func (c *Client) Start(ctx context.Context) error {
 	// create new, cancelable context
 	ctx, c.cancel = context.WithCancel(ctx)
+	if c.shared != nil {
+		return c.startWithSharedHost(ctx)
+	}
 	c.logger.Debug("starting P2P client")
 	host, err := c.listen(ctx)
 	if err != nil {
@@ -105,22 +181,41 @@ func (c *Client) Start(ctx context.Context) error {
 
 func (c *Client) startWithHost(ctx context.Context, h host.Host) error {
 	c.host = h
+	c.host.Network().Notify(c.limiter)
 	for _, a := range c.host.Addrs() {
 		c.logger.Info("listening on", "address", fmt.Sprintf("%s/p2p/%s", a, c.host.ID()))
 	}
 
-	c.logger.Debug("setting up gossiping")
-	err := c.setupGossiping(ctx)
-	if err != nil {
-		return err
+	if !c.conf.SeedMode {
+		c.logger.Debug("setting up gossiping")
+		err := c.setupGossiping(ctx)
+		if err != nil {
+			return err
+		}
+		c.setupBlockParts()
 	}
 
 	c.logger.Debug("setting up DHT")
-	err = c.setupDHT(ctx)
+	err := c.setupDHT(ctx)
 	if err != nil {
 		return err
 	}
 
+	c.logger.Debug("setting up peer exchange")
+	c.setupPEX()
+
+	if c.conf.SeedMode {
+		c.logger.Info("working in seed mode - only serving peer discovery")
+		return nil
+	}
+
+	// handshake and mempool sync use c.host in goroutines started on new connections, so they're set up after
+	// setupDHT wraps the host
+	c.setupHandshake(ctx)
+	c.setupMempoolSync(ctx)
+
+	c.connectKnownPeers(ctx)
+
 	c.logger.Debug("setting up active peer discovery")
 	err = c.peerDiscovery(ctx)
 	if err != nil {
@@ -130,20 +225,82 @@ func (c *Client) startWithHost(ctx context.Context, h host.Host) error {
 	return nil
 }
 
+// startWithSharedHost establish Client's P2P connectivity using shared Host.
+// Gossiping, peer exchange and peer discovery are set up for this chain only.
+func (c *Client) startWithSharedHost(ctx context.Context) error {
+	if c.conf.SeedMode {
+		return errSeedModeShared
+	}
+	c.logger.Debug("starting P2P client with shared host")
+	c.host = c.shared.host()
+	c.dht = c.shared.dht()
+
+	c.logger.Debug("setting up gossiping")
+	err := c.setupGossipers(ctx, c.shared.ps)
+	if err != nil {
+		return err
+	}
+	c.setupHandshake(ctx)
+	c.setupMempoolSync(ctx)
+	c.setupBlockParts()
+
+	c.logger.Debug("setting up peer exchange")
+	c.setupPEX()
+
+	c.connectKnownPeers(ctx)
+
+	c.logger.Debug("setting up active peer discovery")
+	return c.peerDiscovery(ctx)
+}
+
 // Close gently stops Client.
 func (c *Client) Close() error {
+	var err error
+	if c.txBatcher != nil {
+		err = c.txBatcher.flush(context.Background())
+	}
 	c.cancel()
 
+	if !c.conf.SeedMode {
+		c.closeHandshake()
+		c.closeMempoolSync()
+		c.closeBlockParts()
+		err = multierr.Combine(
+			err,
+			c.txGossiper.Close(),
+			c.txBatchGossiper.Close(),
+			c.headerGossiper.Close(),
+		)
+		if c.conf.BlockGossip {
+			err = multierr.Combine(
+				err,
+				c.blockManifestGossiper.Close(),
+				c.blockPartGossiper.Close(),
+			)
+		}
+	}
+	if c.shared != nil {
+		c.host.RemoveStreamHandler(c.getPEXProtocol())
+		return err
+	}
 	return multierr.Combine(
-		c.txGossiper.Close(),
-		c.headerGossiper.Close(),
+		err,
 		c.dht.Close(),
 		c.host.Close(),
 	)
 }
 
 // GossipTx sends the transaction to the P2P network.
+//
+// If batching is enabled, transaction is gossiped together with other transactions, once the batch is full
+// or batch timeout elapses.
 func (c *Client) GossipTx(ctx context.Context, tx []byte) error {
+	if c.conf.SeedMode {
+		return errSeedMode
+	}
+	if c.txBatcher != nil {
+		return c.txBatcher.add(ctx, tx)
+	}
 	c.logger.Debug("Gossiping TX", "len", len(tx))
 	return c.txGossiper.Publish(ctx, tx)
 }
@@ -153,17 +310,50 @@ func (c *Client) SetTxValidator(val GossipValidator) {
 	c.txValidator = val
 }
 
-// GossipHeader sends the block header to the P2P network.
+// GossipHeader sends the signed block header to the P2P network.
 func (c *Client) GossipHeader(ctx context.Context, headerBytes []byte) error {
+	if c.conf.SeedMode {
+		return errSeedMode
+	}
 	c.logger.Debug("Gossiping block header", "len", len(headerBytes))
 	return c.headerGossiper.Publish(ctx, headerBytes)
 }
 
-// SetHeaderValidator sets the callback function, that will be invoked after block header is received from P2P network.
+// SetHeaderValidator sets the callback function, that will be invoked after signed block header is received from P2P network.
 func (c *Client) SetHeaderValidator(validator GossipValidator) {
 	c.headerValidator = validator
 }
 
+// GossipCheckpoint sends the signed checkpoint to the P2P network.
+func (c *Client) GossipCheckpoint(ctx context.Context, checkpointBytes []byte) error {
+	if c.conf.SeedMode {
+		return errSeedMode
+	}
+	if c.checkpointGossiper == nil {
+		return errCheckpointGossipDisabled
+	}
+	c.logger.Debug("Gossiping checkpoint", "len", len(checkpointBytes))
+	return c.checkpointGossiper.Publish(ctx, checkpointBytes)
+}
+
+// SetCheckpointValidator sets the callback function, that will be invoked after signed checkpoint is received from
+// P2P network. Checkpoints are gossiped only if validator is set. It has to be called before Start.
+func (c *Client) SetCheckpointValidator(validator GossipValidator) {
+	c.checkpointValidator = validator
+}
+
+// SetHost sets Host shared with other Clients running in the same process.
+// It has to be called before Start.
+func (c *Client) SetHost(h *Host) {
+	c.shared = h
+}
+
+// SetAddrBook sets address book used to store addresses of known peers.
+// It has to be called before Start.
+func (c *Client) SetAddrBook(addrBook *AddrBook) {
+	c.addrBook = addrBook
+}
+
 func (c *Client) Addrs() []multiaddr.Multiaddr {
 	return c.host.Addrs()
 }
@@ -174,6 +364,8 @@ type PeerConnection struct {
 	IsOutbound       bool                 `json:"is_outbound"`
 	ConnectionStatus p2p.ConnectionStatus `json:"connection_status"`
 	RemoteIP         string               `json:"remote_ip"`
+	// PeerInfo is received from peer in handshake (nil if handshake didn't complete).
+	PeerInfo *PeerInfo `json:"peer_info,omitempty"`
 }
 
 func (c *Client) Peers() []PeerConnection {
@@ -193,20 +385,82 @@ func (c *Client) Peers() []PeerConnection {
 				// TODO(tzdybal): fill more fields
 			},
 			RemoteIP: conn.RemoteMultiaddr().String(),
+			PeerInfo: c.PeerInfo(conn.RemotePeer()),
+		}
+		if pc.PeerInfo != nil {
+			pc.NodeInfo.ProtocolVersion.P2P = pc.PeerInfo.ProtocolVersion
+			pc.NodeInfo.ProtocolVersion.App = pc.PeerInfo.AppVersion
+			pc.NodeInfo.Network = pc.PeerInfo.ChainID
 		}
 		res = append(res, pc)
 	}
 	return res
 }
 
+// GossipStats returns statistics of gossip messages received from peers on topics of this Client, sorted by peer ID.
+// Peers that didn't send any message on those topics are omitted.
+func (c *Client) GossipStats() []PeerGossipStats {
+	stats := c.getGossipStats()
+	if stats == nil {
+		return nil
+	}
+	return stats.get(map[string]bool{
+		c.getTxTopic():            true,
+		c.getTxBatchTopic():       true,
+		c.getHeaderTopic():        true,
+		c.getCheckpointTopic():    true,
+		c.getBlockManifestTopic(): true,
+		c.getBlockPartTopic():     true,
+	})
+}
+
+// ReportInvalidTx records that transaction received from given peer was rejected by the mempool. It's counted in
+// gossip statistics of the peer, and decreases its score.
+func (c *Client) ReportInvalidTx(id peer.ID) {
+	if stats := c.getGossipStats(); stats != nil {
+		stats.invalidTx(id)
+	}
+}
+
+// getGossipStats returns statistics of gossipsub router used by the Client (nil if gossiping is not set up).
+func (c *Client) getGossipStats() *gossipStats {
+	if c.shared != nil {
+		return c.shared.client.gossipStats
+	}
+	return c.gossipStats
+}
+
 func (c *Client) listen(ctx context.Context) (host.Host, error) {
-	var err error
-	maddr, err := multiaddr.NewMultiaddr(c.conf.ListenAddress)
+	transports, err := c.transportOptions()
 	if err != nil {
 		return nil, err
 	}
 
-	host, err := libp2p.New(ctx, libp2p.ListenAddrs(maddr), libp2p.Identity(c.privKey))
+	addrs := []string{c.conf.ListenAddress}
+	if c.conf.WSListenAddress != "" {
+		addrs = append(addrs, c.conf.WSListenAddress)
+	}
+	maddrs := make([]multiaddr.Multiaddr, len(addrs))
+	for i, addr := range addrs {
+		maddrs[i], err = multiaddr.NewMultiaddr(addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []libp2p.Option{
+		libp2p.ListenAddrs(maddrs...),
+		libp2p.Identity(c.privKey),
+		libp2p.ConnectionGater(c.limiter),
+	}
+	opts = append(opts, transports...)
+	opts = append(opts, c.natOptions()...)
+	if size := c.conf.StreamBufferSize(); size > 0 {
+		// mplex doesn't limit buffered data, so only yamux is enabled
+		opts = append(opts, libp2p.Muxer(yamuxID, newYamuxTransport(size)))
+	}
+
+	host, err := libp2p.New(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -214,6 +468,66 @@ func (c *Client) listen(ctx context.Context) (host.Host, error) {
 	return host, nil
 }
 
+// transportOptions returns libp2p options enabling configured transports.
+func (c *Client) transportOptions() ([]libp2p.Option, error) {
+	var opts []libp2p.Option
+	for _, name := range strings.Split(c.conf.Transports, ",") {
+		switch strings.TrimSpace(name) {
+		case "tcp":
+			opts = append(opts, libp2p.Transport(tcp.NewTCPTransport))
+		case "ws":
+			opts = append(opts, libp2p.Transport(ws.New))
+		case "wss":
+			opt, err := c.wssTransport()
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, opt)
+		default:
+			return nil, fmt.Errorf("unsupported transport: %q", name)
+		}
+	}
+	return opts, nil
+}
+
+// wssTransport returns libp2p option enabling secure WebSocket transport. Node listens for secure WebSocket
+// connections only if TLS certificate is configured.
+func (c *Client) wssTransport() (libp2p.Option, error) {
+	var serverTLS *tls.Config
+	if c.conf.TLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.conf.TLSCertFile, c.conf.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+		serverTLS = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	}
+	return libp2p.Transport(func(upgrader *tptu.Upgrader) *wssTransport {
+		return newWSSTransport(upgrader, serverTLS, nil)
+	}), nil
+}
+
+// natOptions returns libp2p options enabling configured NAT traversal features.
+//
+// AutoNAT client (reachability detection) and relay client (accepting relayed connections) are always enabled.
+// TODO: enable hole punching (DCUtR) after upgrading libp2p.
+func (c *Client) natOptions() []libp2p.Option {
+	var opts []libp2p.Option
+	if c.conf.NATPortMap {
+		opts = append(opts, libp2p.NATPortMap())
+	}
+	if c.conf.AutoNATService {
+		opts = append(opts, libp2p.EnableNATService())
+	}
+	if c.conf.RelayService {
+		opts = append(opts, libp2p.EnableRelay(circuit.OptHop))
+	}
+	// if node is a relay itself, it's expected to be reachable
+	if relays := c.getSeedAddrInfo(c.conf.Relays); len(relays) > 0 && !c.conf.RelayService {
+		opts = append(opts, libp2p.EnableAutoRelay(), libp2p.StaticRelays(relays))
+	}
+	return opts
+}
+
 func (c *Client) setupDHT(ctx context.Context) error {
 	seedNodes := c.getSeedAddrInfo(c.conf.Seeds)
 	if len(seedNodes) == 0 {
@@ -282,32 +596,78 @@ func (c *Client) findPeers(ctx context.Context) error {
 	}
 
 	for peer := range peerCh {
-		go c.tryConnect(ctx, peer)
+		go c.tryConnect(ctx, peer, "")
 	}
 
 	return nil
 }
 
-// tryConnect attempts to connect to a peer and logs error if necessary
-func (c *Client) tryConnect(ctx context.Context, peer peer.AddrInfo) {
+// connectKnownPeers attempts to connect to peers from address book.
+func (c *Client) connectKnownPeers(ctx context.Context) {
+	known := c.addrBook.Peers(peerLimit)
+	c.logger.Debug("connecting to known peers", "count", len(known))
+	for _, peer := range known {
+		go c.tryConnect(ctx, peer, "")
+	}
+}
+
+// tryConnect attempts to connect to a peer and logs error if necessary.
+// After successful connection, peer is stored in address book and asked for addresses of other peers.
+// Source is the peer that shared the address via peer exchange (empty for other peers).
+func (c *Client) tryConnect(ctx context.Context, peer peer.AddrInfo, source peer.ID) {
 	err := c.host.Connect(ctx, peer)
 	if err != nil {
 		c.logger.Error("failed to connect to peer", "peer", peer, "error", err)
+		if err := c.addrBook.MarkFailed(peer.ID); err != nil {
+			c.logger.Error("failed to update address book", "peer", peer.ID, "error", err)
+		}
+		return
+	}
+	if err := c.addrBook.Add(peer, source); err != nil {
+		c.logger.Error("failed to store peer address", "peer", peer.ID, "error", err)
 	}
+	c.exchangePeers(ctx, peer.ID)
 }
 
 func (c *Client) setupGossiping(ctx context.Context) error {
-	ps, err := pubsub.NewGossipSub(ctx, c.host)
+	ps, err := pubsub.NewGossipSub(ctx, c.host, c.pubsubOptions()...)
 	if err != nil {
 		return err
 	}
+	return c.setupGossipers(ctx, ps)
+}
 
+// pubsubOptions returns options of gossipsub router. Messages larger than MaxMessageSize are dropped by the router,
+// before they are decoded or passed to validators. Messages received from peers are counted in gossip statistics.
+func (c *Client) pubsubOptions() []pubsub.Option {
+	c.gossipStats = newGossipStats(c.host.ID())
+	opts := []pubsub.Option{pubsub.WithRawTracer(c.gossipStats)}
+	if c.conf.MaxMessageSize > 0 {
+		opts = append(opts, pubsub.WithMaxMessageSize(c.conf.MaxMessageSize))
+	}
+	return opts
+}
+
+// setupGossipers creates gossipers for transactions, block headers and (if enabled) checkpoints and blocks, using given pubsub router.
+func (c *Client) setupGossipers(ctx context.Context, ps *pubsub.PubSub) error {
+	var err error
 	c.txGossiper, err = NewGossiper(c.host, ps, c.getTxTopic(), c.logger, WithValidator(c.txValidator))
 	if err != nil {
 		return err
 	}
 	go c.txGossiper.ProcessMessages(ctx)
 
+	c.txBatchGossiper, err = NewGossiper(c.host, ps, c.getTxBatchTopic(), c.logger,
+		WithValidator(c.newTxBatchValidator()))
+	if err != nil {
+		return err
+	}
+	go c.txBatchGossiper.ProcessMessages(ctx)
+	if c.conf.TxBatchSize > 0 {
+		c.txBatcher = newTxBatcher(ctx, c.conf.TxBatchSize, c.conf.TxBatchMaxBytes, c.conf.TxBatchTimeout,
+			c.conf.TxBatchCompression, c.txBatchGossiper.Publish, c.logger)
+	}
+
 	c.headerGossiper, err = NewGossiper(c.host, ps, c.getHeaderTopic(), c.logger,
 		WithValidator(c.headerValidator))
 	if err != nil {
@@ -315,6 +675,31 @@ func (c *Client) setupGossiping(ctx context.Context) error {
 	}
 	go c.headerGossiper.ProcessMessages(ctx)
 
+	if c.checkpointValidator != nil {
+		c.checkpointGossiper, err = NewGossiper(c.host, ps, c.getCheckpointTopic(), c.logger,
+			WithValidator(c.checkpointValidator))
+		if err != nil {
+			return err
+		}
+		go c.checkpointGossiper.ProcessMessages(ctx)
+	}
+
+	if c.conf.BlockGossip {
+		c.blockManifestGossiper, err = NewGossiper(c.host, ps, c.getBlockManifestTopic(), c.logger,
+			WithValidator(c.newBlockManifestValidator(ctx)))
+		if err != nil {
+			return err
+		}
+		go c.blockManifestGossiper.ProcessMessages(ctx)
+
+		c.blockPartGossiper, err = NewGossiper(c.host, ps, c.getBlockPartTopic(), c.logger,
+			WithValidator(c.newBlockPartValidator()))
+		if err != nil {
+			return err
+		}
+		go c.blockPartGossiper.ProcessMessages(ctx)
+	}
+
 	return nil
 }
 
@@ -352,6 +737,33 @@ func (c *Client) getTxTopic() string {
 	return c.getNamespace() + txTopicSuffix
 }
 
+func (c *Client) getTxBatchTopic() string {
+	return c.getNamespace() + txBatchTopicSuffix
+}
+
 func (c *Client) getHeaderTopic() string {
 	return c.getNamespace() + headerTopicSuffix
 }
+
+func (c *Client) getCheckpointTopic() string {
+	return c.getNamespace() + checkpointTopicSuffix
+}
+
+// newTxBatchValidator creates a validator that unpacks transaction batch and validates every transaction
+// individually, using validator set with SetTxValidator. Batch is propagated if any of transactions is valid.
+func (c *Client) newTxBatchValidator() GossipValidator {
+	return func(m *GossipMessage) bool {
+		txs, err := decodeTxBatch(m.Data)
+		if err != nil {
+			c.logger.Debug("invalid transaction batch", "from", m.From, "error", err)
+			return false
+		}
+		valid := false
+		for _, tx := range txs {
+			if c.txValidator(&GossipMessage{Data: tx, From: m.From}) {
+				valid = true
+			}
+		}
+		return valid
+	}
+}
@@ -3,6 +3,7 @@ package p2p
 import (
 	"context"
 	"crypto/rand"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -30,6 +31,74 @@ func TestClientStartup(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestClientStartupWithNATTraversal(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	relayKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	relayID, err := peer.IDFromPrivateKey(relayKey)
+	require.NoError(err)
+	relay, err := NewClient(config.P2PConfig{
+		ListenAddress:  "/ip4/127.0.0.1/tcp/7677",
+		AutoNATService: true,
+		RelayService:   true,
+	}, relayKey, "TestChain", &test.TestLogger{T: t})
+	require.NoError(err)
+	err = relay.Start(context.Background())
+	defer relay.Close()
+	assert.NoError(err)
+
+	privKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	client, err := NewClient(config.P2PConfig{
+		ListenAddress: "/ip4/127.0.0.1/tcp/7678",
+		Relays:        "/ip4/127.0.0.1/tcp/7677/p2p/" + relayID.Pretty(),
+	}, privKey, "TestChain", &test.TestLogger{T: t})
+	require.NoError(err)
+	err = client.Start(context.Background())
+	defer client.Close()
+	assert.NoError(err)
+}
+
+func TestTransports(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key1, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	id1, err := peer.IDFromPrivateKey(key1)
+	require.NoError(err)
+	client1, err := NewClient(config.P2PConfig{
+		ListenAddress:   "/ip4/127.0.0.1/tcp/7679",
+		WSListenAddress: "/ip4/127.0.0.1/tcp/7680/ws",
+		Transports:      "tcp,ws",
+	}, key1, "TestChain", &test.TestLogger{T: t})
+	require.NoError(err)
+	err = client1.Start(context.Background())
+	defer client1.Close()
+	require.NoError(err)
+
+	// WebSocket only client, connecting via WebSocket address
+	key2, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	client2, err := NewClient(config.P2PConfig{
+		ListenAddress: "/ip4/127.0.0.1/tcp/7681/ws",
+		Seeds:         "/ip4/127.0.0.1/tcp/7680/ws/p2p/" + id1.Pretty(),
+		Transports:    "ws",
+	}, key2, "TestChain", &test.TestLogger{T: t})
+	require.NoError(err)
+	err = client2.Start(context.Background())
+	defer client2.Close()
+	require.NoError(err)
+
+	assert.Eventually(func() bool {
+		return len(client1.host.Network().ConnsToPeer(client2.host.ID())) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	privKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	client3, err := NewClient(config.P2PConfig{Transports: "tcp,sctp"}, privKey, "TestChain", &test.TestLogger{T: t})
+	require.NoError(err)
+	_, err = client3.listen(context.Background())
+	assert.Error(err)
+}
+
 func TestBootstrapping(t *testing.T) {
 	_ = log.SetLogLevel("dht", "INFO")
 	//log.SetDebugLogging()
@@ -73,6 +142,64 @@ func TestDiscovery(t *testing.T) {
 	assert.Contains(clients[4].host.Network().Peers(), clients[3].host.ID())
 }
 
+func TestSeedMode(t *testing.T) {
+	assert := assert.New(t)
+	logger := &test.TestLogger{T: t}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clients := startTestNetwork(ctx, t, 3, map[int]hostDescr{
+		0: {seedMode: true},
+		1: {conns: []int{0}, chainID: "ORU1"},
+		2: {conns: []int{0}, chainID: "ORU1"},
+	}, make([]GossipValidator, 3), logger)
+
+	// wait for clients to finish refreshing routing tables
+	clients.WaitForDHT()
+
+	assert.Nil(clients[0].txGossiper)
+	assert.Nil(clients[0].headerGossiper)
+	assert.ErrorIs(clients[0].GossipTx(ctx, []byte("tx")), errSeedMode)
+
+	// peers bootstrapped only from seed node find each other
+	assert.Contains(clients[1].host.Network().Peers(), clients[2].host.ID())
+	assert.Contains(clients[2].host.Network().Peers(), clients[1].host.ID())
+}
+
+func TestPeerExchange(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	logger := &test.TestLogger{T: t}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clients := startTestNetwork(ctx, t, 4, map[int]hostDescr{
+		0: {chainID: "ORU1"},
+		1: {conns: []int{0}, chainID: "ORU1"},
+		2: {conns: []int{0}, chainID: "ORU1"},
+		3: {conns: []int{0}, chainID: "ORU2"},
+	}, make([]GossipValidator, 4), logger)
+
+	// wait for clients to finish refreshing routing tables
+	clients.WaitForDHT()
+
+	addrs, err := clients[1].requestPeers(ctx, clients[0].host.ID())
+	require.NoError(err)
+
+	ids := make([]peer.ID, 0, len(addrs))
+	for _, info := range addrs {
+		ids = append(ids, info.ID)
+	}
+	assert.Contains(ids, clients[2].host.ID())
+	// requester and peers from other networks are not shared
+	assert.NotContains(ids, clients[1].host.ID())
+	assert.NotContains(ids, clients[3].host.ID())
+
+	// peers from other networks don't speak the same peer exchange protocol
+	_, err = clients[3].requestPeers(ctx, clients[0].host.ID())
+	assert.Error(err)
+}
+
 func TestGossiping(t *testing.T) {
 	assert := assert.New(t)
 	logger := &test.TestLogger{T: t}
@@ -176,3 +303,115 @@ func TestSeedStringParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestGossipingBatches(t *testing.T) {
+	assert := assert.New(t)
+	logger := &test.TestLogger{T: t}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	expectedTxs := [][]byte{[]byte("tx1"), []byte("tx2"), []byte("tx3")}
+
+	var mtx sync.Mutex
+	received := make(map[string]int)
+	recv := func(tx *GossipMessage) bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		received[string(tx.Data)]++
+		return true
+	}
+	validators := []GossipValidator{recv, recv, recv}
+
+	// network connections topology: 0<->1<->2; only client 0 batches transactions, others just unpack batches
+	clients := startTestNetwork(ctx, t, 3, map[int]hostDescr{
+		0: {conns: []int{}, chainID: "1", realKey: true, txBatchSize: len(expectedTxs)},
+		1: {conns: []int{0}, chainID: "1", realKey: true},
+		2: {conns: []int{1}, chainID: "1", realKey: true},
+	}, validators, logger)
+
+	clients.WaitForDHT()
+	time.Sleep(1 * time.Second)
+
+	for _, tx := range expectedTxs {
+		assert.NoError(clients[0].GossipTx(ctx, tx))
+	}
+
+	// every transaction is validated individually by every client (including sender)
+	assert.Eventually(func() bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		for _, tx := range expectedTxs {
+			if received[string(tx)] != len(clients) {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestClientChainID(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	privKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	client, err := NewClient(config.P2PConfig{}, privKey, "", &test.TestLogger{T: t})
+	assert.ErrorIs(err, errNoChainID)
+	assert.Nil(client)
+
+	client, err = NewClient(config.P2PConfig{}, privKey, "TestChain", &test.TestLogger{T: t})
+	require.NoError(err)
+	for _, topic := range []string{
+		client.getTxTopic(),
+		client.getTxBatchTopic(),
+		client.getHeaderTopic(),
+		client.getCheckpointTopic(),
+		client.getBlockManifestTopic(),
+		client.getBlockPartTopic(),
+	} {
+		assert.True(strings.HasPrefix(topic, "TestChain-"), topic)
+	}
+}
+
+func TestGossipMaxMessageSize(t *testing.T) {
+	assert := assert.New(t)
+	logger := &test.TestLogger{T: t}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	smallTx := []byte("small")
+	largeTx := make([]byte, 2048)
+
+	var mtx sync.Mutex
+	var received [][]byte
+	recv := func(tx *GossipMessage) bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		received = append(received, tx.Data)
+		return true
+	}
+	validators := []GossipValidator{func(*GossipMessage) bool { return true }, recv}
+
+	clients := startTestNetwork(ctx, t, 2, map[int]hostDescr{
+		0: {conns: []int{}, realKey: true},
+		1: {conns: []int{0}, realKey: true, maxMessageSize: 1024},
+	}, validators, logger)
+
+	clients.WaitForDHT()
+	time.Sleep(1 * time.Second)
+
+	assert.NoError(clients[0].GossipTx(ctx, smallTx))
+	assert.Eventually(func() bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return len(received) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// oversized message is dropped by receiver before validation
+	assert.NoError(clients[0].GossipTx(ctx, largeTx))
+	time.Sleep(500 * time.Millisecond)
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Equal([][]byte{smallTx}, received)
+}
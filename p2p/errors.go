@@ -4,4 +4,11 @@ import "errors"
 
 var (
 	errNoPrivKey = errors.New("private key not provided")
+	errNoChainID = errors.New("chain ID not provided")
+	errSeedMode  = errors.New("gossiping is disabled in seed mode")
+
+	errBlockGossipDisabled      = errors.New("block gossip is disabled")
+	errCheckpointGossipDisabled = errors.New("checkpoint gossip is disabled")
+
+	errSeedModeShared = errors.New("shared host can't be used in seed mode")
 )
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"errors"
 
 	"go.uber.org/multierr"
 
@@ -91,6 +92,9 @@ func (g *Gossiper) Publish(ctx context.Context, data []byte) error {
 func (g *Gossiper) ProcessMessages(ctx context.Context) {
 	for {
 		_, err := g.sub.Next(ctx)
+		if errors.Is(err, context.Canceled) {
+			return
+		}
 		if err != nil {
 			g.logger.Error("failed to read message", "error", err)
 			return
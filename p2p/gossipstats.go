@@ -0,0 +1,231 @@
+package p2p
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+const (
+	// acceptedMessageScore is added to the score of a peer for every message accepted by validators.
+	acceptedMessageScore = 1
+	// rejectedMessageScore is added to the score of a peer for every message rejected by validators (or invalid).
+	rejectedMessageScore = -10
+	// invalidTxScore is added to the score of a peer for every invalid transaction reported with ReportInvalidTx.
+	invalidTxScore = -1
+
+	// maxDisconnectedGossipStats is the number of disconnected peers for which statistics are retained.
+	maxDisconnectedGossipStats = 128
+)
+
+// TopicGossipStats contains counters of messages received from a peer on a single topic.
+//
+// Every received message is counted exactly once in Received and once in one of the other counters.
+type TopicGossipStats struct {
+	Received uint64 `json:"received"`
+	// Accepted messages passed validation and were delivered to subscribers.
+	Accepted uint64 `json:"accepted"`
+	// Rejected messages were invalid (failed validation or signature checks).
+	Rejected uint64 `json:"rejected"`
+	// Ignored messages were dropped without penalty (ignored by validator, or dropped due to throttling).
+	Ignored    uint64 `json:"ignored"`
+	Duplicates uint64 `json:"duplicates"`
+}
+
+// PeerGossipStats contains statistics of gossip messages received from a single peer.
+type PeerGossipStats struct {
+	Peer      peer.ID `json:"peer"`
+	Connected bool    `json:"connected"`
+	// LastActivity is the time when the last message was received from the peer (zero if no message was received).
+	LastActivity time.Time `json:"last_activity"`
+	// Score is a local reputation of the peer, increased by accepted and decreased by rejected messages and invalid
+	// transactions. It's not related to gossipsub peer scoring (which is not enabled).
+	Score int64 `json:"score"`
+	// InvalidTxs is the number of transactions received from the peer that were rejected by the mempool (including
+	// transactions from batches that were propagated, because other transactions were valid).
+	InvalidTxs uint64 `json:"invalid_txs"`
+	// Topics contains statistics per topic, keyed by topic name.
+	Topics map[string]TopicGossipStats `json:"topics"`
+}
+
+// gossipStats collects per-peer statistics of gossip messages. It's registered as a raw tracer of gossipsub router,
+// so messages are counted by the router (including messages dropped before they reach validators).
+// Messages published locally are not counted.
+type gossipStats struct {
+	self peer.ID
+
+	mtx   sync.Mutex
+	peers map[peer.ID]*PeerGossipStats
+}
+
+var _ pubsub.RawTracer = &gossipStats{}
+
+func newGossipStats(self peer.ID) *gossipStats {
+	return &gossipStats{
+		self:  self,
+		peers: make(map[peer.ID]*PeerGossipStats),
+	}
+}
+
+// get returns statistics of given topics, sorted by peer ID. Peers without messages on those topics are omitted.
+func (s *gossipStats) get(topics map[string]bool) []PeerGossipStats {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var res []PeerGossipStats
+	for _, ps := range s.peers {
+		stats := *ps
+		stats.Topics = make(map[string]TopicGossipStats)
+		for topic, ts := range ps.Topics {
+			if topics[topic] {
+				stats.Topics[topic] = ts
+			}
+		}
+		if len(stats.Topics) > 0 {
+			res = append(res, stats)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Peer < res[j].Peer
+	})
+	return res
+}
+
+// update applies fn to topic statistics of the peer that sent the message.
+func (s *gossipStats) update(msg *pubsub.Message, score int64, fn func(ts *TopicGossipStats)) {
+	if msg.ReceivedFrom == s.self {
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	ps := s.getPeer(msg.ReceivedFrom)
+	ts := ps.Topics[msg.GetTopic()]
+	ts.Received++
+	fn(&ts)
+	ps.Topics[msg.GetTopic()] = ts
+	ps.Score += score
+	ps.LastActivity = time.Now()
+}
+
+// invalidTx counts invalid transaction received from the peer.
+func (s *gossipStats) invalidTx(id peer.ID) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	ps := s.getPeer(id)
+	ps.InvalidTxs++
+	ps.Score += invalidTxScore
+}
+
+func (s *gossipStats) getPeer(id peer.ID) *PeerGossipStats {
+	ps, ok := s.peers[id]
+	if !ok {
+		ps = &PeerGossipStats{
+			Peer:   id,
+			Topics: make(map[string]TopicGossipStats),
+		}
+		s.peers[id] = ps
+	}
+	return ps
+}
+
+// prune removes statistics of the least recently active disconnected peers, above maxDisconnectedGossipStats.
+func (s *gossipStats) prune() {
+	var disconnected []*PeerGossipStats
+	for _, ps := range s.peers {
+		if !ps.Connected {
+			disconnected = append(disconnected, ps)
+		}
+	}
+	if len(disconnected) <= maxDisconnectedGossipStats {
+		return
+	}
+	sort.Slice(disconnected, func(i, j int) bool {
+		return disconnected[i].LastActivity.Before(disconnected[j].LastActivity)
+	})
+	for _, ps := range disconnected[:len(disconnected)-maxDisconnectedGossipStats] {
+		delete(s.peers, ps.Peer)
+	}
+}
+
+// AddPeer marks the peer as connected.
+func (s *gossipStats) AddPeer(p peer.ID, proto protocol.ID) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.getPeer(p).Connected = true
+}
+
+// RemovePeer marks the peer as disconnected. Statistics are retained for a limited number of disconnected peers.
+func (s *gossipStats) RemovePeer(p peer.ID) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if ps, ok := s.peers[p]; ok {
+		ps.Connected = false
+		s.prune()
+	}
+}
+
+// DeliverMessage counts accepted message.
+func (s *gossipStats) DeliverMessage(msg *pubsub.Message) {
+	s.update(msg, acceptedMessageScore, func(ts *TopicGossipStats) {
+		ts.Accepted++
+	})
+}
+
+// RejectMessage counts rejected or ignored message, depending on the reason.
+func (s *gossipStats) RejectMessage(msg *pubsub.Message, reason string) {
+	switch reason {
+	case pubsub.RejectValidationIgnored, pubsub.RejectValidationQueueFull, pubsub.RejectValidationThrottled,
+		pubsub.RejectSelfOrigin:
+		s.update(msg, 0, func(ts *TopicGossipStats) {
+			ts.Ignored++
+		})
+	default:
+		s.update(msg, rejectedMessageScore, func(ts *TopicGossipStats) {
+			ts.Rejected++
+		})
+	}
+}
+
+// DuplicateMessage counts duplicated message.
+func (s *gossipStats) DuplicateMessage(msg *pubsub.Message) {
+	s.update(msg, 0, func(ts *TopicGossipStats) {
+		ts.Duplicates++
+	})
+}
+
+// Join is a part of pubsub.RawTracer interface.
+func (s *gossipStats) Join(topic string) {}
+
+// Leave is a part of pubsub.RawTracer interface.
+func (s *gossipStats) Leave(topic string) {}
+
+// Graft is a part of pubsub.RawTracer interface.
+func (s *gossipStats) Graft(p peer.ID, topic string) {}
+
+// Prune is a part of pubsub.RawTracer interface.
+func (s *gossipStats) Prune(p peer.ID, topic string) {}
+
+// ValidateMessage is a part of pubsub.RawTracer interface.
+func (s *gossipStats) ValidateMessage(msg *pubsub.Message) {}
+
+// ThrottlePeer is a part of pubsub.RawTracer interface.
+func (s *gossipStats) ThrottlePeer(p peer.ID) {}
+
+// RecvRPC is a part of pubsub.RawTracer interface.
+func (s *gossipStats) RecvRPC(rpc *pubsub.RPC) {}
+
+// SendRPC is a part of pubsub.RawTracer interface.
+func (s *gossipStats) SendRPC(rpc *pubsub.RPC, p peer.ID) {}
+
+// DropRPC is a part of pubsub.RawTracer interface.
+func (s *gossipStats) DropRPC(rpc *pubsub.RPC, p peer.ID) {}
+
+// UndeliverableMessage is a part of pubsub.RawTracer interface.
+func (s *gossipStats) UndeliverableMessage(msg *pubsub.Message) {}
@@ -0,0 +1,110 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/optimint/log/test"
+)
+
+func TestGossipStatsCounters(t *testing.T) {
+	assert := assert.New(t)
+
+	self, other := peer.ID("self"), peer.ID("other")
+	stats := newGossipStats(self)
+	msg := func(from peer.ID, topic string) *pubsub.Message {
+		return &pubsub.Message{Message: &pb.Message{Topic: &topic}, ReceivedFrom: from}
+	}
+
+	stats.AddPeer(other, "")
+	stats.DeliverMessage(msg(other, "a-tx"))
+	stats.DeliverMessage(msg(other, "a-tx"))
+	stats.DuplicateMessage(msg(other, "a-tx"))
+	stats.RejectMessage(msg(other, "a-tx"), pubsub.RejectValidationFailed)
+	stats.RejectMessage(msg(other, "a-signed-header"), pubsub.RejectValidationIgnored)
+	stats.DeliverMessage(msg(other, "b-tx"))
+	// locally published messages are not counted
+	stats.DeliverMessage(msg(self, "a-tx"))
+	stats.invalidTx(other)
+
+	res := stats.get(map[string]bool{"a-tx": true, "a-signed-header": true})
+	require.Len(t, res, 1)
+	assert.Equal(other, res[0].Peer)
+	assert.True(res[0].Connected)
+	assert.False(res[0].LastActivity.IsZero())
+	assert.EqualValues(2*acceptedMessageScore+rejectedMessageScore+acceptedMessageScore+invalidTxScore, res[0].Score)
+	assert.EqualValues(1, res[0].InvalidTxs)
+	assert.Equal(map[string]TopicGossipStats{
+		"a-tx":            {Received: 4, Accepted: 2, Rejected: 1, Duplicates: 1},
+		"a-signed-header": {Received: 1, Ignored: 1},
+	}, res[0].Topics)
+
+	assert.Empty(stats.get(map[string]bool{"c-tx": true}))
+
+	stats.RemovePeer(other)
+	res = stats.get(map[string]bool{"b-tx": true})
+	require.Len(t, res, 1)
+	assert.False(res[0].Connected)
+}
+
+func TestGossipStatsPruning(t *testing.T) {
+	stats := newGossipStats("self")
+	for i := 0; i < maxDisconnectedGossipStats+10; i++ {
+		topic := "tx"
+		id := peer.ID(fmt.Sprintf("peer-%03d", i))
+		stats.AddPeer(id, "")
+		stats.DeliverMessage(&pubsub.Message{Message: &pb.Message{Topic: &topic}, ReceivedFrom: id})
+		stats.RemovePeer(id)
+	}
+
+	res := stats.get(map[string]bool{"tx": true})
+	require.Len(t, res, maxDisconnectedGossipStats)
+	// least recently active peers are removed
+	assert.Equal(t, peer.ID("peer-010"), res[0].Peer)
+}
+
+func TestGossipStats(t *testing.T) {
+	assert := assert.New(t)
+	logger := &test.TestLogger{T: t}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// sender validates its own messages too, so it has to accept invalid message
+	validators := []GossipValidator{
+		func(*GossipMessage) bool { return true },
+		func(m *GossipMessage) bool { return string(m.Data) != "invalid" },
+	}
+	clients := startTestNetwork(ctx, t, 2, map[int]hostDescr{
+		0: {conns: []int{}, realKey: true},
+		1: {conns: []int{0}, realKey: true},
+	}, validators, logger)
+
+	clients.WaitForDHT()
+	time.Sleep(1 * time.Second)
+
+	assert.NoError(clients[0].GossipTx(ctx, []byte("valid")))
+	assert.NoError(clients[0].GossipTx(ctx, []byte("invalid")))
+
+	var stats []PeerGossipStats
+	assert.Eventually(func() bool {
+		stats = clients[1].GossipStats()
+		return len(stats) == 1 && stats[0].Topics[clients[1].getTxTopic()].Received == 2
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Len(t, stats, 1)
+	assert.Equal(clients[0].host.ID(), stats[0].Peer)
+	assert.True(stats[0].Connected)
+	assert.Equal(TopicGossipStats{Received: 2, Accepted: 1, Rejected: 1}, stats[0].Topics[clients[1].getTxTopic()])
+	assert.EqualValues(acceptedMessageScore+rejectedMessageScore, stats[0].Score)
+
+	// sender doesn't count its own messages
+	assert.Empty(clients[0].GossipStats())
+}
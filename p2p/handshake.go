@@ -0,0 +1,224 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+)
+
+const (
+	// ProtocolVersion is the version of optimint P2P protocol. It's incremented with every incompatible change.
+	ProtocolVersion uint64 = 1
+
+	// MinProtocolVersion is the lowest protocol version of peers supported by this node.
+	MinProtocolVersion uint64 = 1
+
+	// handshakeProtocolPrefix is added before namespace to create protocol ID for handshake.
+	handshakeProtocolPrefix = "/optimint/handshake/0.1.0/"
+
+	// handshakeMaxMessageSize limits size of handshake message read from the stream.
+	handshakeMaxMessageSize = 4096
+
+	// handshakeTimeout defines how long handshake with a single peer can take.
+	handshakeTimeout = 10 * time.Second
+)
+
+// NodeInfo is exchanged by peers in handshake, right after connection is established.
+type NodeInfo struct {
+	ProtocolVersion    uint64 `json:"protocol_version"`
+	MinProtocolVersion uint64 `json:"min_protocol_version"`
+	ChainID            string `json:"chain_id"`
+	GenesisHash        []byte `json:"genesis_hash"`
+	// Height is the latest block height of the node, at the time of handshake.
+	Height uint64 `json:"height"`
+	// AppVersion is the protocol version of the application, reported via ABCI Info.
+	AppVersion uint64 `json:"app_version,omitempty"`
+	// AppSoftwareVersion is the software version of the application, reported via ABCI Info.
+	AppSoftwareVersion string `json:"app_software_version,omitempty"`
+}
+
+// PeerInfo contains information received from peer in handshake.
+type PeerInfo struct {
+	NodeInfo
+	// NegotiatedVersion is the protocol version used with the peer (the highest version supported by both nodes).
+	NegotiatedVersion uint64 `json:"negotiated_version"`
+}
+
+// handshakeState keeps information about local node exchanged in handshake, and information received from peers.
+type handshakeState struct {
+	genesisHash        []byte
+	height             func() uint64
+	appVersion         uint64
+	appSoftwareVersion string
+
+	mtx   sync.RWMutex
+	peers map[peer.ID]*PeerInfo
+
+	notifiee *network.NotifyBundle
+}
+
+// SetHandshakeInfo sets information about local chain sent to peers in handshake: hash of genesis document and
+// a function returning the latest block height. It has to be called before Start.
+func (c *Client) SetHandshakeInfo(genesisHash []byte, height func() uint64) {
+	c.handshake.genesisHash = genesisHash
+	c.handshake.height = height
+}
+
+// SetAppInfo sets versions of the application sent to peers in handshake, so tooling can detect application versions
+// across the network. It has to be called before Start.
+func (c *Client) SetAppInfo(appVersion uint64, softwareVersion string) {
+	c.handshake.appVersion = appVersion
+	c.handshake.appSoftwareVersion = softwareVersion
+}
+
+// PeerInfo returns information received from given peer in handshake (nil if handshake didn't complete).
+func (c *Client) PeerInfo(id peer.ID) *PeerInfo {
+	c.handshake.mtx.RLock()
+	defer c.handshake.mtx.RUnlock()
+	return c.handshake.peers[id]
+}
+
+// setupHandshake registers handler for handshake protocol, and starts handshake with every peer connected by this
+// node. Peers with incompatible protocol version, chain ID or genesis are disconnected.
+//
+// Handshake is a request/response protocol: requester opens a stream and writes JSON encoded NodeInfo, responder
+// validates it and writes back its own NodeInfo.
+func (c *Client) setupHandshake(ctx context.Context) {
+	c.handshake.peers = make(map[peer.ID]*PeerInfo)
+	c.host.SetStreamHandler(c.getHandshakeProtocol(), c.handleHandshake)
+	c.handshake.notifiee = &network.NotifyBundle{
+		ConnectedF: func(_ network.Network, conn network.Conn) {
+			// only the dialing side initiates handshake, to use a single stream per connection
+			if conn.Stat().Direction == network.DirOutbound {
+				go c.doHandshake(ctx, conn.RemotePeer())
+			}
+		},
+		DisconnectedF: func(n network.Network, conn network.Conn) {
+			if n.Connectedness(conn.RemotePeer()) != network.Connected {
+				c.handshake.mtx.Lock()
+				delete(c.handshake.peers, conn.RemotePeer())
+				c.handshake.mtx.Unlock()
+			}
+		},
+	}
+	c.host.Network().Notify(c.handshake.notifiee)
+}
+
+// closeHandshake stops handshakes with new peers.
+func (c *Client) closeHandshake() {
+	if c.handshake.notifiee == nil {
+		return
+	}
+	c.host.Network().StopNotify(c.handshake.notifiee)
+	c.host.RemoveStreamHandler(c.getHandshakeProtocol())
+}
+
+func (c *Client) handleHandshake(s network.Stream) {
+	defer s.Close()
+	remote := s.Conn().RemotePeer()
+
+	_ = s.SetDeadline(time.Now().Add(handshakeTimeout))
+	var info NodeInfo
+	err := json.NewDecoder(io.LimitReader(s, handshakeMaxMessageSize)).Decode(&info)
+	if err != nil {
+		c.logger.Debug("failed to read handshake", "peer", remote, "error", err)
+		_ = s.Reset()
+		return
+	}
+	err = json.NewEncoder(s).Encode(c.nodeInfo())
+	if err != nil {
+		c.logger.Debug("failed to send handshake", "peer", remote, "error", err)
+		_ = s.Reset()
+		return
+	}
+	c.acceptPeer(remote, &info)
+}
+
+// doHandshake exchanges NodeInfo with given peer.
+func (c *Client) doHandshake(ctx context.Context, id peer.ID) {
+	ctx, cancel := context.WithTimeout(ctx, handshakeTimeout)
+	defer cancel()
+
+	s, err := c.host.NewStream(ctx, id, c.getHandshakeProtocol())
+	if err != nil {
+		// peers from other networks (e.g. seed nodes) don't support handshake of this chain
+		c.logger.Debug("failed to open handshake stream", "peer", id, "error", err)
+		return
+	}
+	defer s.Close()
+
+	_ = s.SetDeadline(time.Now().Add(handshakeTimeout))
+	if err := json.NewEncoder(s).Encode(c.nodeInfo()); err != nil {
+		c.logger.Debug("failed to send handshake", "peer", id, "error", err)
+		_ = s.Reset()
+		return
+	}
+	var info NodeInfo
+	if err := json.NewDecoder(io.LimitReader(s, handshakeMaxMessageSize)).Decode(&info); err != nil {
+		c.logger.Debug("failed to read handshake", "peer", id, "error", err)
+		_ = s.Reset()
+		return
+	}
+	c.acceptPeer(id, &info)
+}
+
+// acceptPeer stores information received from compatible peer, or disconnects incompatible one.
+func (c *Client) acceptPeer(id peer.ID, info *NodeInfo) {
+	version, err := c.checkPeer(info)
+	if err != nil {
+		c.logger.Error("disconnecting incompatible peer", "peer", id, "error", err)
+		_ = c.host.Network().ClosePeer(id)
+		return
+	}
+	c.logger.Debug("handshake completed", "peer", id, "version", version, "height", info.Height,
+		"appVersion", info.AppVersion, "appSoftwareVersion", info.AppSoftwareVersion)
+	c.handshake.mtx.Lock()
+	c.handshake.peers[id] = &PeerInfo{NodeInfo: *info, NegotiatedVersion: version}
+	c.handshake.mtx.Unlock()
+}
+
+// checkPeer returns protocol version negotiated with the peer, or error if peer is incompatible.
+func (c *Client) checkPeer(info *NodeInfo) (uint64, error) {
+	if info.ChainID != c.chainID {
+		return 0, fmt.Errorf("chain ID mismatch: %q, expected %q", info.ChainID, c.chainID)
+	}
+	if len(info.GenesisHash) > 0 && len(c.handshake.genesisHash) > 0 && !bytes.Equal(info.GenesisHash, c.handshake.genesisHash) {
+		return 0, fmt.Errorf("genesis hash mismatch: %X, expected %X", info.GenesisHash, c.handshake.genesisHash)
+	}
+	version := ProtocolVersion
+	if info.ProtocolVersion < version {
+		version = info.ProtocolVersion
+	}
+	if version < MinProtocolVersion || version < info.MinProtocolVersion {
+		return 0, fmt.Errorf("incompatible protocol version: %d (min %d), local: %d (min %d)",
+			info.ProtocolVersion, info.MinProtocolVersion, ProtocolVersion, MinProtocolVersion)
+	}
+	return version, nil
+}
+
+func (c *Client) nodeInfo() *NodeInfo {
+	info := &NodeInfo{
+		ProtocolVersion:    ProtocolVersion,
+		MinProtocolVersion: MinProtocolVersion,
+		ChainID:            c.chainID,
+		GenesisHash:        c.handshake.genesisHash,
+		AppVersion:         c.handshake.appVersion,
+		AppSoftwareVersion: c.handshake.appSoftwareVersion,
+	}
+	if c.handshake.height != nil {
+		info.Height = c.handshake.height()
+	}
+	return info
+}
+
+func (c *Client) getHandshakeProtocol() protocol.ID {
+	return protocol.ID(handshakeProtocolPrefix + c.getNamespace())
+}
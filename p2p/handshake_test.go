@@ -0,0 +1,88 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/optimint/log/test"
+)
+
+func TestHandshake(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	logger := &test.TestLogger{T: t}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// network connections topology: 0<->1, 2 (with different genesis) is connected to 0
+	clients := startTestNetwork(ctx, t, 3, map[int]hostDescr{
+		0: {conns: []int{}, chainID: "1", realKey: true, genesisHash: []byte{1}, height: 10},
+		1: {conns: []int{0}, chainID: "1", realKey: true, genesisHash: []byte{1}, height: 20, appVersion: 3},
+		2: {conns: []int{0}, chainID: "1", realKey: true, genesisHash: []byte{2}, height: 30},
+	}, make([]GossipValidator, 3), logger)
+
+	id0 := clients[0].host.ID()
+	id1 := clients[1].host.ID()
+	id2 := clients[2].host.ID()
+
+	assert.Eventually(func() bool {
+		return clients[0].PeerInfo(id1) != nil && clients[1].PeerInfo(id0) != nil
+	}, 5*time.Second, 50*time.Millisecond)
+	info := clients[0].PeerInfo(id1)
+	require.NotNil(info)
+	assert.Equal("1", info.ChainID)
+	assert.Equal([]byte{1}, info.GenesisHash)
+	assert.EqualValues(20, info.Height)
+	assert.Equal(ProtocolVersion, info.NegotiatedVersion)
+	assert.EqualValues(3, info.AppVersion)
+	assert.Equal("v3", info.AppSoftwareVersion)
+	assert.EqualValues(10, clients[1].PeerInfo(id0).Height)
+
+	// peer with different genesis is disconnected
+	assert.Eventually(func() bool {
+		return len(clients[0].host.Network().ConnsToPeer(id2)) == 0
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Nil(clients[0].PeerInfo(id2))
+
+	for _, peer := range clients[0].Peers() {
+		if peer.PeerInfo != nil {
+			assert.Equal(ProtocolVersion, peer.NodeInfo.ProtocolVersion.P2P)
+			assert.Equal(peer.PeerInfo.AppVersion, peer.NodeInfo.ProtocolVersion.App)
+		}
+	}
+}
+
+func TestCheckPeer(t *testing.T) {
+	client := &Client{chainID: "test"}
+	client.handshake.genesisHash = []byte{1}
+
+	cases := []struct {
+		name    string
+		info    NodeInfo
+		version uint64
+		err     bool
+	}{
+		{"compatible", NodeInfo{ProtocolVersion: ProtocolVersion, MinProtocolVersion: MinProtocolVersion, ChainID: "test", GenesisHash: []byte{1}}, ProtocolVersion, false},
+		{"newer", NodeInfo{ProtocolVersion: ProtocolVersion + 1, MinProtocolVersion: MinProtocolVersion, ChainID: "test"}, ProtocolVersion, false},
+		{"too new", NodeInfo{ProtocolVersion: ProtocolVersion + 2, MinProtocolVersion: ProtocolVersion + 1, ChainID: "test"}, 0, true},
+		{"too old", NodeInfo{ProtocolVersion: MinProtocolVersion - 1, MinProtocolVersion: MinProtocolVersion - 1, ChainID: "test"}, 0, true},
+		{"chain ID", NodeInfo{ProtocolVersion: ProtocolVersion, MinProtocolVersion: MinProtocolVersion, ChainID: "other"}, 0, true},
+		{"genesis", NodeInfo{ProtocolVersion: ProtocolVersion, MinProtocolVersion: MinProtocolVersion, ChainID: "test", GenesisHash: []byte{2}}, 0, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			version, err := client.checkPeer(&c.info)
+			if c.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.version, version)
+		})
+	}
+}
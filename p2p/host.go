@@ -0,0 +1,74 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"go.uber.org/multierr"
+
+	"github.com/celestiaorg/optimint/config"
+	"github.com/celestiaorg/optimint/log"
+)
+
+// Host is a libp2p host (with DHT and gossipsub router) that can be shared by multiple Clients running in the same
+// process, one per chain. Each Client still uses its own gossip topics, peer exchange protocol and DHT namespace.
+type Host struct {
+	client *Client
+	ps     *pubsub.PubSub
+}
+
+// NewHost creates new Host object. Only connectivity related parameters of configuration are used.
+func NewHost(conf config.P2PConfig, privKey crypto.PrivKey, logger log.Logger) (*Host, error) {
+	if conf.SeedMode {
+		return nil, errSeedModeShared
+	}
+	if privKey == nil {
+		return nil, errNoPrivKey
+	}
+	return &Host{client: newClient(conf, privKey, "", logger)}, nil
+}
+
+// Start starts listening for incoming connections, sets up gossipsub router and DHT.
+func (h *Host) Start(ctx context.Context) error {
+	c := h.client
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.logger.Debug("starting shared P2P host")
+	lh, err := c.listen(ctx)
+	if err != nil {
+		return err
+	}
+	c.host = lh
+	c.host.Network().Notify(c.limiter)
+	for _, a := range c.host.Addrs() {
+		c.logger.Info("listening on", "address", fmt.Sprintf("%s/p2p/%s", a, c.host.ID()))
+	}
+
+	h.ps, err = pubsub.NewGossipSub(ctx, c.host, c.pubsubOptions()...)
+	if err != nil {
+		return err
+	}
+
+	return c.setupDHT(ctx)
+}
+
+// Close stops the Host. It should be called after all the Clients using the Host are closed.
+func (h *Host) Close() error {
+	c := h.client
+	c.cancel()
+	return multierr.Combine(
+		c.dht.Close(),
+		c.host.Close(),
+	)
+}
+
+func (h *Host) host() host.Host {
+	return h.client.host
+}
+
+func (h *Host) dht() *dht.IpfsDHT {
+	return h.client.dht
+}
@@ -0,0 +1,121 @@
+package p2p
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// NodeKeyFile is the name of file storing P2P identity key, in node data directory.
+const NodeKeyFile = "node_key.json"
+
+// nodeKeyJSON is the content of node key file. ID is stored only for convenience of operators.
+type nodeKeyJSON struct {
+	ID      string `json:"id"`
+	PrivKey string `json:"priv_key"`
+}
+
+// LoadOrGenNodeKey loads P2P identity key from given file. If file doesn't exist, new Ed25519 key is generated and
+// saved, so peer ID of the node is stable across restarts.
+func LoadOrGenNodeKey(path string) (crypto.PrivKey, error) {
+	key, err := LoadNodeKey(path)
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	key, _, err = crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := SaveNodeKey(path, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// LoadNodeKey loads P2P identity key from given file.
+func LoadNodeKey(path string) (crypto.PrivKey, error) {
+	blob, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var keyJSON nodeKeyJSON
+	if err := json.Unmarshal(blob, &keyJSON); err != nil {
+		return nil, fmt.Errorf("failed to parse node key file %s: %w", path, err)
+	}
+	return ImportNodeKey(keyJSON.PrivKey)
+}
+
+// SaveNodeKey saves P2P identity key to given file, readable only by the owner.
+func SaveNodeKey(path string, key crypto.PrivKey) error {
+	encoded, err := ExportNodeKey(key)
+	if err != nil {
+		return err
+	}
+	id, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	blob, err := json.MarshalIndent(nodeKeyJSON{ID: id.Pretty(), PrivKey: encoded}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, blob, 0600)
+}
+
+// ExportNodeKey encodes P2P identity key as base64 encoded libp2p protobuf (the format used in IPFS configuration).
+func ExportNodeKey(key crypto.PrivKey) (string, error) {
+	blob, err := crypto.MarshalPrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return crypto.ConfigEncodeKey(blob), nil
+}
+
+// ImportNodeKey decodes P2P identity key encoded with ExportNodeKey.
+func ImportNodeKey(encoded string) (crypto.PrivKey, error) {
+	blob, err := crypto.ConfigDecodeKey(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode node key: %w", err)
+	}
+	key, err := crypto.UnmarshalPrivateKey(blob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode node key: %w", err)
+	}
+	return key, nil
+}
+
+// ShowNodeID returns peer ID of the node using P2P identity key from given file.
+func ShowNodeID(path string) (peer.ID, error) {
+	key, err := LoadNodeKey(path)
+	if err != nil {
+		return "", err
+	}
+	return peer.IDFromPrivateKey(key)
+}
+
+// NodeAddress returns address of the node (listen address with peer ID), that can be used as a seed by other nodes.
+func NodeAddress(listenAddress string, id peer.ID) (string, error) {
+	addr, err := multiaddr.NewMultiaddr(listenAddress)
+	if err != nil {
+		return "", err
+	}
+	p2pAddr, err := multiaddr.NewComponent("p2p", id.Pretty())
+	if err != nil {
+		return "", err
+	}
+	return addr.Encapsulate(p2pAddr).String(), nil
+}
@@ -0,0 +1,64 @@
+package p2p
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeKey(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "optimint-node-key")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "data", NodeKeyFile)
+
+	key, err := LoadOrGenNodeKey(path)
+	require.NoError(err)
+	info, err := os.Stat(path)
+	require.NoError(err)
+	assert.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	// key is persisted
+	loaded, err := LoadOrGenNodeKey(path)
+	require.NoError(err)
+	assert.True(key.Equals(loaded))
+
+	id, err := ShowNodeID(path)
+	require.NoError(err)
+	expected, err := peer.IDFromPrivateKey(key)
+	require.NoError(err)
+	assert.Equal(expected, id)
+
+	exported, err := ExportNodeKey(key)
+	require.NoError(err)
+	imported, err := ImportNodeKey(exported)
+	require.NoError(err)
+	assert.True(key.Equals(imported))
+	_, err = ImportNodeKey("invalid")
+	assert.Error(err)
+
+	// imported key can be saved in other node's data directory
+	otherPath := filepath.Join(dir, "other", NodeKeyFile)
+	require.NoError(SaveNodeKey(otherPath, imported))
+	id, err = ShowNodeID(otherPath)
+	require.NoError(err)
+	assert.Equal(expected, id)
+
+	addr, err := NodeAddress("/ip4/127.0.0.1/tcp/26656", id)
+	require.NoError(err)
+	assert.Equal("/ip4/127.0.0.1/tcp/26656/p2p/"+id.Pretty(), addr)
+	_, err = NodeAddress("invalid", id)
+	assert.Error(err)
+
+	require.NoError(ioutil.WriteFile(path, []byte("{"), 0600))
+	_, err = LoadOrGenNodeKey(path)
+	assert.Error(err)
+}
@@ -0,0 +1,253 @@
+package p2p
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/control"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	yamux "github.com/libp2p/go-libp2p-yamux"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/celestiaorg/optimint/config"
+	"github.com/celestiaorg/optimint/log"
+)
+
+// reservationTimeout is the time after which slot reserved for a connection that was not established is released.
+// It's longer than libp2p dial and accept timeouts, so connection can't be established after its slot is released.
+const reservationTimeout = time.Minute
+
+// connLimiter enforces limits of connected peers and streams configured in P2PConfig.
+//
+// Peer limits are enforced by connection gater (rejecting new connections when limit is reached),
+// while stream limits are enforced by network notifiee (resetting streams exceeding the limit).
+// Zero value of any limit means no limit.
+// Memory limit is enforced by stream multiplexer (see newYamuxTransport).
+//
+// Peer takes a slot of inbound (outbound) limit as long as it has any inbound (outbound) connection. Slot is reserved
+// before connection is established (in InterceptAccept or InterceptAddrDial), so concurrent connections can't exceed
+// the limit. Slot is released when the last connection of a peer is closed, or after reservationTimeout, if the
+// connection failed.
+type connLimiter struct {
+	maxInbound        int64
+	maxOutbound       int64
+	maxStreamsPerPeer int
+
+	// inbound and outbound are numbers of taken slots (connected peers and reservations)
+	inbound  int64
+	outbound int64
+
+	mtx sync.Mutex
+	// conns counts open connections of peers
+	conns map[peer.ID]*peerConns
+	// pending contains reservations of connections that are being established
+	pending map[string]reservation
+
+	logger log.Logger
+}
+
+// peerConns counts open connections of a peer in each direction.
+type peerConns struct {
+	inbound  int
+	outbound int
+}
+
+// reservation is a slot reserved for a connection that is being established.
+type reservation struct {
+	counter *int64
+	expires time.Time
+}
+
+var _ connmgr.ConnectionGater = &connLimiter{}
+var _ network.Notifiee = &connLimiter{}
+
+func newConnLimiter(conf config.P2PConfig, logger log.Logger) *connLimiter {
+	return &connLimiter{
+		maxInbound:        int64(conf.MaxInboundConns),
+		maxOutbound:       int64(conf.MaxOutboundConns),
+		maxStreamsPerPeer: conf.MaxStreamsPerPeer,
+		conns:             make(map[peer.ID]*peerConns),
+		pending:           make(map[string]reservation),
+		logger:            logger,
+	}
+}
+
+// InterceptPeerDial allows all peers; outbound slots are reserved in InterceptAddrDial.
+func (l *connLimiter) InterceptPeerDial(peer.ID) bool {
+	return true
+}
+
+// InterceptAddrDial reserves outbound slot for a peer, and rejects dial if limit is reached.
+// All addresses of a peer share the same slot.
+func (l *connLimiter) InterceptAddrDial(p peer.ID, _ multiaddr.Multiaddr) bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.expireReservations()
+	if conns, ok := l.conns[p]; ok && conns.outbound > 0 {
+		return true
+	}
+	key := dialKey(p)
+	if _, ok := l.pending[key]; ok {
+		return true
+	}
+	if !reserve(&l.outbound, l.maxOutbound) {
+		l.logger.Debug("outbound connection limit reached", "peer", p)
+		return false
+	}
+	l.pending[key] = reservation{counter: &l.outbound, expires: time.Now().Add(reservationTimeout)}
+	return true
+}
+
+// InterceptAccept reserves inbound slot, and rejects connection if limit is reached.
+// Peer is not known yet, so the slot is released when connection turns out to be another connection of connected peer.
+func (l *connLimiter) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.expireReservations()
+	if !reserve(&l.inbound, l.maxInbound) {
+		l.logger.Debug("inbound connection limit reached", "address", addrs.RemoteMultiaddr())
+		return false
+	}
+	l.pending[acceptKey(addrs)] = reservation{counter: &l.inbound, expires: time.Now().Add(reservationTimeout)}
+	return true
+}
+
+// InterceptSecured allows all secured connections.
+func (l *connLimiter) InterceptSecured(network.Direction, peer.ID, network.ConnMultiaddrs) bool {
+	return true
+}
+
+// InterceptUpgraded allows all upgraded connections.
+func (l *connLimiter) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// Listen is a part of network.Notifiee interface.
+func (l *connLimiter) Listen(network.Network, multiaddr.Multiaddr) {}
+
+// ListenClose is a part of network.Notifiee interface.
+func (l *connLimiter) ListenClose(network.Network, multiaddr.Multiaddr) {}
+
+// Connected takes the slot reserved for the connection. If peer already holds a slot, reservation is released.
+func (l *connLimiter) Connected(_ network.Network, conn network.Conn) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	p := conn.RemotePeer()
+	inbound := conn.Stat().Direction == network.DirInbound
+	key := dialKey(p)
+	if inbound {
+		key = acceptKey(conn)
+	}
+	_, reserved := l.pending[key]
+	delete(l.pending, key)
+
+	conns, ok := l.conns[p]
+	if !ok {
+		conns = &peerConns{}
+		l.conns[p] = conns
+	}
+	count, counter := &conns.outbound, &l.outbound
+	if inbound {
+		count, counter = &conns.inbound, &l.inbound
+	}
+	*count++
+	if *count == 1 && !reserved {
+		atomic.AddInt64(counter, 1)
+	} else if *count > 1 && reserved {
+		atomic.AddInt64(counter, -1)
+	}
+}
+
+// Disconnected releases the slot of a peer after its last connection is closed.
+func (l *connLimiter) Disconnected(_ network.Network, conn network.Conn) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	p := conn.RemotePeer()
+	conns, ok := l.conns[p]
+	if !ok {
+		return
+	}
+	count, counter := &conns.outbound, &l.outbound
+	if conn.Stat().Direction == network.DirInbound {
+		count, counter = &conns.inbound, &l.inbound
+	}
+	*count--
+	if *count == 0 {
+		atomic.AddInt64(counter, -1)
+	}
+	if conns.inbound == 0 && conns.outbound == 0 {
+		delete(l.conns, p)
+	}
+}
+
+// OpenedStream resets stream if peer exceeds the limit of streams.
+func (l *connLimiter) OpenedStream(n network.Network, s network.Stream) {
+	if l.maxStreamsPerPeer <= 0 {
+		return
+	}
+	p := s.Conn().RemotePeer()
+	streams := 0
+	for _, conn := range n.ConnsToPeer(p) {
+		streams += len(conn.GetStreams())
+	}
+	if streams > l.maxStreamsPerPeer {
+		l.logger.Debug("stream limit reached", "peer", p, "streams", streams)
+		// notifiee must not block
+		go func() { _ = s.Reset() }()
+	}
+}
+
+// ClosedStream is a part of network.Notifiee interface.
+func (l *connLimiter) ClosedStream(network.Network, network.Stream) {}
+
+// expireReservations releases slots reserved for connections that failed. It has to be called with mtx held.
+func (l *connLimiter) expireReservations() {
+	now := time.Now()
+	for key, r := range l.pending {
+		if now.After(r.expires) {
+			delete(l.pending, key)
+			atomic.AddInt64(r.counter, -1)
+		}
+	}
+}
+
+// reserve atomically takes a slot, if limit is not reached yet.
+func reserve(counter *int64, limit int64) bool {
+	for {
+		n := atomic.LoadInt64(counter)
+		if limit > 0 && n >= limit {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(counter, n, n+1) {
+			return true
+		}
+	}
+}
+
+func dialKey(p peer.ID) string {
+	return "dial/" + p.String()
+}
+
+func acceptKey(addrs network.ConnMultiaddrs) string {
+	return "accept/" + addrs.LocalMultiaddr().String() + "/" + addrs.RemoteMultiaddr().String()
+}
+
+// yamuxID is a protocol ID of yamux stream multiplexer.
+const yamuxID = "/yamux/1.0.0"
+
+// newYamuxTransport returns yamux multiplexer with receive buffer (window) of every stream limited to given size.
+//
+// Peer can't send more data than the window of a stream, so memory used by streams is bounded by number of streams
+// (limited by connLimiter) multiplied by the window size.
+func newYamuxTransport(streamBufferSize int) *yamux.Transport {
+	conf := *yamux.DefaultTransport.Config()
+	conf.MaxStreamWindowSize = uint32(streamBufferSize)
+	return (*yamux.Transport)(&conf)
+}
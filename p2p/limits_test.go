@@ -0,0 +1,154 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	yamux "github.com/libp2p/go-libp2p-yamux"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/optimint/config"
+	"github.com/celestiaorg/optimint/log/test"
+)
+
+func TestConnLimiter(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mnet, err := mocknet.FullMeshLinked(ctx, 3)
+	require.NoError(err)
+	hosts := mnet.Hosts()
+
+	limiter := newConnLimiter(config.P2PConfig{MaxInboundConns: 2, MaxOutboundConns: 1, MaxStreamsPerPeer: 1}, &test.TestLogger{T: t})
+	hosts[0].Network().Notify(limiter)
+	hosts[0].SetStreamHandler("/test", func(s network.Stream) {})
+
+	conn, err := mnet.ConnectPeers(hosts[1].ID(), hosts[0].ID())
+	require.NoError(err)
+	assert.Eventually(func() bool { return atomic.LoadInt64(&limiter.inbound) == 1 }, time.Second, 10*time.Millisecond)
+
+	// peers are counted, not connections
+	inboundConn := hosts[0].Network().ConnsToPeer(hosts[1].ID())[0]
+	limiter.Connected(hosts[0].Network(), inboundConn)
+	assert.Equal(int64(1), atomic.LoadInt64(&limiter.inbound))
+	limiter.Disconnected(hosts[0].Network(), inboundConn)
+	assert.Equal(int64(1), atomic.LoadInt64(&limiter.inbound))
+
+	// accepted connection reserves a slot
+	assert.True(limiter.InterceptAccept(conn))
+	assert.False(limiter.InterceptAccept(conn))
+	// slot is released if connection is not established in time
+	limiter.mtx.Lock()
+	for key, r := range limiter.pending {
+		r.expires = time.Now().Add(-time.Second)
+		limiter.pending[key] = r
+	}
+	limiter.mtx.Unlock()
+	assert.True(limiter.InterceptAccept(conn))
+	assert.Equal(int64(2), atomic.LoadInt64(&limiter.inbound))
+
+	// all addresses of a peer share the outbound slot
+	assert.True(limiter.InterceptPeerDial(hosts[2].ID()))
+	assert.True(limiter.InterceptAddrDial(hosts[2].ID(), conn.LocalMultiaddr()))
+	assert.True(limiter.InterceptAddrDial(hosts[2].ID(), conn.RemoteMultiaddr()))
+	assert.False(limiter.InterceptAddrDial(hosts[1].ID(), conn.RemoteMultiaddr()))
+	// established connection takes the reserved slot
+	_, err = mnet.ConnectPeers(hosts[0].ID(), hosts[2].ID())
+	require.NoError(err)
+	assert.Eventually(func() bool {
+		limiter.mtx.Lock()
+		defer limiter.mtx.Unlock()
+		_, ok := limiter.conns[hosts[2].ID()]
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(int64(1), atomic.LoadInt64(&limiter.outbound))
+	// connected peer can be dialed again
+	assert.True(limiter.InterceptAddrDial(hosts[2].ID(), conn.LocalMultiaddr()))
+
+	// second stream from the same peer exceeds the limit
+	s1, err := hosts[1].NewStream(ctx, hosts[0].ID(), "/test")
+	require.NoError(err)
+	defer s1.Close()
+	s2, err := hosts[1].NewStream(ctx, hosts[0].ID(), "/test")
+	require.NoError(err)
+	defer s2.Close()
+
+	assert.Eventually(func() bool {
+		streams := 0
+		for _, conn := range hosts[0].Network().ConnsToPeer(hosts[1].ID()) {
+			streams += len(conn.GetStreams())
+		}
+		return streams == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestConnLimiterConcurrentReservations(t *testing.T) {
+	limiter := newConnLimiter(config.P2PConfig{MaxInboundConns: 10}, &test.TestLogger{T: t})
+	mnet, err := mocknet.FullMeshLinked(context.Background(), 2)
+	require.NoError(t, err)
+	conn, err := mnet.ConnectPeers(mnet.Hosts()[0].ID(), mnet.Hosts()[1].ID())
+	require.NoError(t, err)
+
+	var accepted int64
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if reserve(&limiter.inbound, limiter.maxInbound) {
+				atomic.AddInt64(&accepted, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(10), accepted)
+	assert.False(t, limiter.InterceptAccept(conn))
+}
+
+func TestMemoryLimit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	transport := newYamuxTransport(config.MinStreamBufferSize)
+	assert.Equal(uint32(config.MinStreamBufferSize), transport.Config().MaxStreamWindowSize)
+	// default transport is not modified
+	assert.NotEqual(transport.Config().MaxStreamWindowSize, yamux.DefaultTransport.Config().MaxStreamWindowSize)
+
+	conf := config.P2PConfig{MaxInboundConns: 2, MaxOutboundConns: 2, MaxStreamsPerPeer: 4, MaxMemory: 4 << 20}
+	assert.Equal(256*1024, conf.StreamBufferSize())
+
+	key1, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	id1, err := peer.IDFromPrivateKey(key1)
+	require.NoError(err)
+	conf.ListenAddress = "/ip4/127.0.0.1/tcp/7682"
+	client1, err := NewClient(conf, key1, "TestChain", &test.TestLogger{T: t})
+	require.NoError(err)
+	err = client1.Start(context.Background())
+	defer client1.Close()
+	require.NoError(err)
+
+	key2, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	conf.ListenAddress = "/ip4/127.0.0.1/tcp/7683"
+	conf.Seeds = "/ip4/127.0.0.1/tcp/7682/p2p/" + id1.Pretty()
+	client2, err := NewClient(conf, key2, "TestChain", &test.TestLogger{T: t})
+	require.NoError(err)
+	err = client2.Start(context.Background())
+	defer client2.Close()
+	require.NoError(err)
+
+	assert.Eventually(func() bool {
+		return len(client1.host.Network().ConnsToPeer(client2.host.ID())) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+}
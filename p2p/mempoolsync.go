@@ -0,0 +1,188 @@
+package p2p
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+)
+
+const (
+	// mempoolSyncProtocolPrefix is added before namespace to create protocol ID for mempool sync.
+	mempoolSyncProtocolPrefix = "/optimint/mempool-sync/0.1.0/"
+
+	// mempoolSyncMaxTxs defines maximum number of transactions announced in mempool summary.
+	mempoolSyncMaxTxs = 10000
+
+	// mempoolSyncMaxTxsBytes limits total size of transactions sent in single mempool sync response.
+	mempoolSyncMaxTxsBytes = 8 << 20
+
+	// mempoolSyncMaxMessageSize limits size of mempool sync message read from the stream.
+	mempoolSyncMaxMessageSize = 2 * mempoolSyncMaxTxsBytes
+
+	// mempoolSyncTimeout defines how long mempool sync with a single peer can take.
+	mempoolSyncTimeout = 30 * time.Second
+)
+
+// TxKey identifies transaction in mempool sync protocol (SHA256 of transaction bytes).
+type TxKey = [sha256.Size]byte
+
+// MempoolSource gives mempool sync protocol access to pending transactions of local mempool.
+type MempoolSource interface {
+	// PendingTxs returns up to max pending transactions.
+	PendingTxs(max int) [][]byte
+	// HasTx returns true if transaction with given key is in the mempool.
+	HasTx(key TxKey) bool
+}
+
+// SetMempoolSource enables mempool sync with newly connected peers.
+//
+// Transactions fetched from peers are validated with validator set with SetTxValidator, just like gossiped ones.
+func (c *Client) SetMempoolSource(source MempoolSource) {
+	c.mempoolSource = source
+}
+
+// setupMempoolSync registers handler for mempool sync protocol, and starts syncing with every newly connected peer.
+//
+// Mempool sync is a request/response protocol: requester opens a stream, responder writes JSON encoded keys
+// of pending transactions (summary), requester writes keys of missing transactions and responder writes them back.
+func (c *Client) setupMempoolSync(ctx context.Context) {
+	if c.mempoolSource == nil {
+		return
+	}
+	c.host.SetStreamHandler(c.getMempoolSyncProtocol(), c.handleMempoolSync)
+	c.mempoolSyncNotifiee = &network.NotifyBundle{
+		ConnectedF: func(_ network.Network, conn network.Conn) {
+			go c.syncMempool(ctx, conn.RemotePeer())
+		},
+	}
+	c.host.Network().Notify(c.mempoolSyncNotifiee)
+}
+
+// closeMempoolSync stops mempool sync with new peers.
+func (c *Client) closeMempoolSync() {
+	if c.mempoolSyncNotifiee == nil {
+		return
+	}
+	c.host.Network().StopNotify(c.mempoolSyncNotifiee)
+	c.host.RemoveStreamHandler(c.getMempoolSyncProtocol())
+}
+
+func (c *Client) handleMempoolSync(s network.Stream) {
+	defer s.Close()
+	remote := s.Conn().RemotePeer()
+
+	_ = s.SetDeadline(time.Now().Add(mempoolSyncTimeout))
+	pending := make(map[TxKey][]byte)
+	keys := make([]TxKey, 0)
+	for _, tx := range c.mempoolSource.PendingTxs(mempoolSyncMaxTxs) {
+		key := sha256.Sum256(tx)
+		pending[key] = tx
+		keys = append(keys, key)
+	}
+	err := json.NewEncoder(s).Encode(keys)
+	if err != nil {
+		c.logger.Error("failed to send mempool summary", "peer", remote, "error", err)
+		_ = s.Reset()
+		return
+	}
+
+	var wanted []TxKey
+	err = json.NewDecoder(io.LimitReader(s, mempoolSyncMaxMessageSize)).Decode(&wanted)
+	if err != nil {
+		c.logger.Debug("failed to read mempool sync request", "peer", remote, "error", err)
+		_ = s.Reset()
+		return
+	}
+	if len(wanted) == 0 {
+		return
+	}
+
+	txs := make([][]byte, 0, len(wanted))
+	size := 0
+	for _, key := range wanted {
+		tx, ok := pending[key]
+		if !ok {
+			continue
+		}
+		if size += len(tx); size > mempoolSyncMaxTxsBytes {
+			break
+		}
+		txs = append(txs, tx)
+	}
+	err = json.NewEncoder(s).Encode(txs)
+	if err != nil {
+		c.logger.Error("failed to send mempool transactions", "peer", remote, "error", err)
+		_ = s.Reset()
+	}
+}
+
+// requestMissingTxs fetches transactions that are pending in given peer's mempool, but not in local mempool.
+func (c *Client) requestMissingTxs(ctx context.Context, id peer.ID) ([][]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, mempoolSyncTimeout)
+	defer cancel()
+
+	s, err := c.host.NewStream(ctx, id, c.getMempoolSyncProtocol())
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	_ = s.SetDeadline(time.Now().Add(mempoolSyncTimeout))
+	r := io.LimitReader(s, mempoolSyncMaxMessageSize)
+	dec := json.NewDecoder(r)
+	var keys []TxKey
+	if err := dec.Decode(&keys); err != nil {
+		_ = s.Reset()
+		return nil, err
+	}
+	if len(keys) > mempoolSyncMaxTxs {
+		keys = keys[:mempoolSyncMaxTxs]
+	}
+
+	missing := make([]TxKey, 0)
+	for _, key := range keys {
+		if !c.mempoolSource.HasTx(key) {
+			missing = append(missing, key)
+		}
+	}
+	if err := json.NewEncoder(s).Encode(missing); err != nil {
+		_ = s.Reset()
+		return nil, err
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	var txs [][]byte
+	if err := dec.Decode(&txs); err != nil {
+		_ = s.Reset()
+		return nil, err
+	}
+	return txs, nil
+}
+
+// syncMempool fetches missing transactions from given peer and validates them (adding valid ones to the mempool).
+func (c *Client) syncMempool(ctx context.Context, id peer.ID) {
+	txs, err := c.requestMissingTxs(ctx, id)
+	if err != nil {
+		c.logger.Debug("mempool sync failed", "peer", id, "error", err)
+		return
+	}
+	if len(txs) == 0 {
+		return
+	}
+	c.logger.Debug("received mempool transactions", "peer", id, "count", len(txs))
+	for _, tx := range txs {
+		c.txValidator(&GossipMessage{Data: tx, From: id})
+	}
+}
+
+func (c *Client) getMempoolSyncProtocol() protocol.ID {
+	return protocol.ID(mempoolSyncProtocolPrefix + c.getNamespace())
+}
@@ -0,0 +1,85 @@
+package p2p
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/celestiaorg/optimint/log/test"
+)
+
+type testMempool struct {
+	mtx sync.Mutex
+	txs [][]byte
+}
+
+func (m *testMempool) PendingTxs(max int) [][]byte {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if max >= 0 && len(m.txs) > max {
+		return m.txs[:max]
+	}
+	return m.txs
+}
+
+func (m *testMempool) HasTx(key TxKey) bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, tx := range m.txs {
+		if sha256.Sum256(tx) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *testMempool) add(tx []byte) bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.txs = append(m.txs, tx)
+	return true
+}
+
+func TestMempoolSync(t *testing.T) {
+	assert := assert.New(t)
+	logger := &test.TestLogger{T: t}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mempools := []*testMempool{
+		{txs: [][]byte{[]byte("tx1"), []byte("tx2"), []byte("tx3")}},
+		{txs: [][]byte{[]byte("tx2"), []byte("tx4")}},
+		{},
+	}
+	validators := make([]GossipValidator, len(mempools))
+	for i := range mempools {
+		mp := mempools[i]
+		validators[i] = func(m *GossipMessage) bool {
+			return mp.add(m.Data)
+		}
+	}
+
+	// network connections topology: 0<->1, 2 (from other network) is connected to 1
+	startTestNetwork(ctx, t, 3, map[int]hostDescr{
+		0: {conns: []int{}, chainID: "1", realKey: true, mempool: mempools[0]},
+		1: {conns: []int{0}, chainID: "1", realKey: true, mempool: mempools[1]},
+		2: {conns: []int{1}, chainID: "2", realKey: true, mempool: mempools[2]},
+	}, validators, logger)
+
+	// only missing transactions are fetched
+	assert.Eventually(func() bool {
+		return len(mempools[0].PendingTxs(-1)) == 4 && len(mempools[1].PendingTxs(-1)) == 4
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.ElementsMatch(mempools[0].PendingTxs(-1), mempools[1].PendingTxs(-1))
+
+	// peers from other networks don't sync mempool
+	time.Sleep(200 * time.Millisecond)
+	assert.Empty(mempools[2].PendingTxs(-1))
+	assert.Len(mempools[0].PendingTxs(-1), 4)
+	assert.Len(mempools[1].PendingTxs(-1), 4)
+}
@@ -0,0 +1,122 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+)
+
+const (
+	// pexProtocolPrefix is added before namespace to create protocol ID for peer exchange.
+	pexProtocolPrefix = "/optimint/pex/0.1.0/"
+
+	// pexMaxAddrs defines maximum number of peer addresses returned in single peer exchange response.
+	pexMaxAddrs = 100
+
+	// pexMaxResponseSize limits size of peer exchange response read from the stream.
+	pexMaxResponseSize = 1 << 20
+
+	// pexTimeout defines how long peer exchange request can take.
+	pexTimeout = 10 * time.Second
+)
+
+// setupPEX registers handler for peer exchange protocol.
+//
+// Peer exchange is a simple request/response protocol: requester opens a stream,
+// and responder writes JSON encoded list of known peer addresses and closes the stream.
+func (c *Client) setupPEX() {
+	c.host.SetStreamHandler(c.getPEXProtocol(), c.handlePEX)
+}
+
+func (c *Client) handlePEX(s network.Stream) {
+	defer s.Close()
+
+	_ = s.SetWriteDeadline(time.Now().Add(pexTimeout))
+	err := json.NewEncoder(s).Encode(c.getPEXAddrs(s.Conn().RemotePeer()))
+	if err != nil {
+		c.logger.Error("failed to send peer exchange response", "peer", s.Conn().RemotePeer(), "error", err)
+		_ = s.Reset()
+	}
+}
+
+// getPEXAddrs returns addresses shared with requesting peer.
+//
+// Connected peers (speaking peer exchange protocol of the same network) go first, then addresses from address book.
+func (c *Client) getPEXAddrs(requester peer.ID) []peer.AddrInfo {
+	addrs := make([]peer.AddrInfo, 0, pexMaxAddrs)
+	seen := map[peer.ID]bool{requester: true, c.host.ID(): true}
+	add := func(info peer.AddrInfo) {
+		if len(addrs) < pexMaxAddrs && !seen[info.ID] && len(info.Addrs) > 0 {
+			seen[info.ID] = true
+			addrs = append(addrs, info)
+		}
+	}
+
+	pexProtocol := string(c.getPEXProtocol())
+	for _, id := range c.host.Network().Peers() {
+		protocols, err := c.host.Peerstore().SupportsProtocols(id, pexProtocol)
+		if err != nil || len(protocols) == 0 {
+			continue
+		}
+		add(c.host.Peerstore().PeerInfo(id))
+	}
+	for _, info := range c.addrBook.Peers(pexMaxAddrs) {
+		add(info)
+	}
+
+	return addrs
+}
+
+// requestPeers asks given peer for addresses of other peers.
+func (c *Client) requestPeers(ctx context.Context, id peer.ID) ([]peer.AddrInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, pexTimeout)
+	defer cancel()
+
+	s, err := c.host.NewStream(ctx, id, c.getPEXProtocol())
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	_ = s.SetReadDeadline(time.Now().Add(pexTimeout))
+	var addrs []peer.AddrInfo
+	err = json.NewDecoder(io.LimitReader(s, pexMaxResponseSize)).Decode(&addrs)
+	if err != nil {
+		_ = s.Reset()
+		return nil, err
+	}
+	if len(addrs) > pexMaxAddrs {
+		addrs = addrs[:pexMaxAddrs]
+	}
+
+	return addrs, nil
+}
+
+// exchangePeers requests peer addresses from given peer and connects to new peers.
+// Addresses are stored in address book only after successful connection (see tryConnect).
+func (c *Client) exchangePeers(ctx context.Context, id peer.ID) {
+	addrs, err := c.requestPeers(ctx, id)
+	if err != nil {
+		c.logger.Debug("peer exchange failed", "peer", id, "error", err)
+		return
+	}
+	c.logger.Debug("received peer addresses", "peer", id, "count", len(addrs))
+
+	for _, info := range addrs {
+		if info.ID == c.host.ID() {
+			continue
+		}
+		if c.host.Network().Connectedness(info.ID) != network.Connected && len(c.host.Network().Peers()) < peerLimit {
+			go c.tryConnect(ctx, info, id)
+		}
+	}
+}
+
+func (c *Client) getPEXProtocol() protocol.ID {
+	return protocol.ID(pexProtocolPrefix + c.getNamespace())
+}
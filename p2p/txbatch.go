@@ -0,0 +1,198 @@
+package p2p
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/optimint/log"
+)
+
+// Transaction batch is gossiped as a single byte of flags, followed by a sequence of transactions, each prefixed
+// with its length (uvarint). If txBatchCompressed flag is set, the sequence of transactions is compressed with DEFLATE.
+const (
+	txBatchCompressed byte = 1 << iota
+)
+
+// maxTxBatchDecodedSize limits the size of decompressed transaction batch.
+const maxTxBatchDecodedSize = 16 * 1024 * 1024
+
+var (
+	errEmptyTxBatch    = errors.New("empty transaction batch")
+	errTxBatchTooLarge = errors.New("transaction batch too large")
+)
+
+// encodeTxBatch serializes (and optionally compresses) transactions into a single gossip message.
+func encodeTxBatch(txs [][]byte, compress bool) ([]byte, error) {
+	var buf bytes.Buffer
+	var flags byte
+	if compress {
+		flags |= txBatchCompressed
+	}
+	buf.WriteByte(flags)
+
+	var w io.Writer = &buf
+	var fw *flate.Writer
+	if compress {
+		var err error
+		fw, err = flate.NewWriter(&buf, flate.BestSpeed)
+		if err != nil {
+			return nil, err
+		}
+		w = fw
+	}
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	for _, tx := range txs {
+		n := binary.PutUvarint(lenBuf, uint64(len(tx)))
+		if _, err := w.Write(lenBuf[:n]); err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(tx); err != nil {
+			return nil, err
+		}
+	}
+	if fw != nil {
+		if err := fw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeTxBatch deserializes transactions from gossip message created with encodeTxBatch.
+func decodeTxBatch(data []byte) ([][]byte, error) {
+	if len(data) == 0 {
+		return nil, errEmptyTxBatch
+	}
+	flags, payload := data[0], data[1:]
+	if flags&^txBatchCompressed != 0 {
+		return nil, fmt.Errorf("unknown transaction batch flags: %08b", flags)
+	}
+	if flags&txBatchCompressed != 0 {
+		fr := flate.NewReader(bytes.NewReader(payload))
+		defer fr.Close()
+		var err error
+		payload, err = ioutil.ReadAll(io.LimitReader(fr, maxTxBatchDecodedSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(payload) > maxTxBatchDecodedSize {
+			return nil, errTxBatchTooLarge
+		}
+	}
+
+	var txs [][]byte
+	for len(payload) > 0 {
+		size, n := binary.Uvarint(payload)
+		if n <= 0 || size > uint64(len(payload)-n) {
+			return nil, errors.New("malformed transaction batch")
+		}
+		payload = payload[n:]
+		txs = append(txs, payload[:size])
+		payload = payload[size:]
+	}
+	if len(txs) == 0 {
+		return nil, errEmptyTxBatch
+	}
+	return txs, nil
+}
+
+// txBatcher aggregates transactions and publishes them in batches.
+//
+// Batch is published when it reaches maxTxs transactions or maxBytes total size (in the calling goroutine),
+// or when timeout elapses since the first transaction was added (in the background).
+type txBatcher struct {
+	maxTxs   int
+	maxBytes int
+	timeout  time.Duration
+	compress bool
+
+	publish func(ctx context.Context, data []byte) error
+	// ctx is used to publish batches flushed in the background
+	ctx context.Context
+
+	mtx     sync.Mutex
+	pending [][]byte
+	size    int
+	timer   *time.Timer
+
+	logger log.Logger
+}
+
+func newTxBatcher(ctx context.Context, maxTxs, maxBytes int, timeout time.Duration, compress bool,
+	publish func(context.Context, []byte) error, logger log.Logger) *txBatcher {
+	return &txBatcher{
+		maxTxs:   maxTxs,
+		maxBytes: maxBytes,
+		timeout:  timeout,
+		compress: compress,
+		publish:  publish,
+		ctx:      ctx,
+		logger:   logger,
+	}
+}
+
+// add adds transaction to pending batch, and publishes the batch if it's full.
+func (b *txBatcher) add(ctx context.Context, tx []byte) error {
+	b.mtx.Lock()
+	b.pending = append(b.pending, tx)
+	b.size += len(tx)
+	var batch [][]byte
+	if len(b.pending) >= b.maxTxs || (b.maxBytes > 0 && b.size >= b.maxBytes) {
+		batch = b.take()
+	} else if b.timer == nil {
+		b.timer = time.AfterFunc(b.timeout, b.flushInBackground)
+	}
+	b.mtx.Unlock()
+
+	if batch == nil {
+		return nil
+	}
+	return b.publishBatch(ctx, batch)
+}
+
+// flush publishes pending transactions, if any.
+func (b *txBatcher) flush(ctx context.Context) error {
+	b.mtx.Lock()
+	batch := b.take()
+	b.mtx.Unlock()
+
+	if batch == nil {
+		return nil
+	}
+	return b.publishBatch(ctx, batch)
+}
+
+func (b *txBatcher) flushInBackground() {
+	if err := b.flush(b.ctx); err != nil {
+		b.logger.Error("failed to gossip transaction batch", "error", err)
+	}
+}
+
+// take returns pending transactions and resets the batch. Caller must hold the mutex.
+func (b *txBatcher) take() [][]byte {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	batch := b.pending
+	b.pending = nil
+	b.size = 0
+	return batch
+}
+
+func (b *txBatcher) publishBatch(ctx context.Context, batch [][]byte) error {
+	data, err := encodeTxBatch(batch, b.compress)
+	if err != nil {
+		return err
+	}
+	b.logger.Debug("Gossiping TX batch", "txs", len(batch), "len", len(data))
+	return b.publish(ctx, data)
+}
@@ -0,0 +1,94 @@
+package p2p
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/optimint/log/test"
+)
+
+func TestTxBatchEncoding(t *testing.T) {
+	txs := [][]byte{[]byte("tx1"), {}, make([]byte, 1000), []byte("tx4")}
+
+	for _, compress := range []bool{false, true} {
+		data, err := encodeTxBatch(txs, compress)
+		require.NoError(t, err)
+
+		decoded, err := decodeTxBatch(data)
+		require.NoError(t, err)
+		assert.Equal(t, txs, decoded)
+	}
+
+	compressed, err := encodeTxBatch(txs, true)
+	require.NoError(t, err)
+	plain, err := encodeTxBatch(txs, false)
+	require.NoError(t, err)
+	assert.Less(t, len(compressed), len(plain))
+
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty message", nil},
+		{"empty batch", []byte{0}},
+		{"unknown flags", []byte{0x80, 1, 'a'}},
+		{"truncated tx", []byte{0, 5, 'a'}},
+		{"invalid compressed data", []byte{txBatchCompressed, 0xff, 0xff}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := decodeTxBatch(c.data)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTxBatcher(t *testing.T) {
+	require := require.New(t)
+
+	var mtx sync.Mutex
+	var published [][][]byte
+	publish := func(_ context.Context, data []byte) error {
+		txs, err := decodeTxBatch(data)
+		require.NoError(err)
+		mtx.Lock()
+		defer mtx.Unlock()
+		published = append(published, txs)
+		return nil
+	}
+	publishedCount := func() int {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return len(published)
+	}
+
+	ctx := context.Background()
+	b := newTxBatcher(ctx, 3, 10, 100*time.Millisecond, false, publish, &test.TestLogger{T: t})
+
+	// batch is published when it's full
+	require.NoError(b.add(ctx, []byte("a")))
+	require.NoError(b.add(ctx, []byte("b")))
+	require.Equal(0, publishedCount())
+	require.NoError(b.add(ctx, []byte("c")))
+	require.Equal(1, publishedCount())
+	require.Equal([][]byte{[]byte("a"), []byte("b"), []byte("c")}, published[0])
+
+	// ... or it reaches max size
+	require.NoError(b.add(ctx, []byte("0123456789")))
+	require.Equal(2, publishedCount())
+
+	// ... or after timeout
+	require.NoError(b.add(ctx, []byte("d")))
+	require.Equal(2, publishedCount())
+	require.Eventually(func() bool { return publishedCount() == 3 }, time.Second, 10*time.Millisecond)
+	require.Equal([][]byte{[]byte("d")}, published[2])
+
+	// flushing empty batch is no-op
+	require.NoError(b.flush(ctx))
+	require.Equal(3, publishedCount())
+}
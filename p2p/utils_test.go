@@ -3,12 +3,14 @@ package p2p
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"net"
 	"strings"
 	"testing"
 
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/peer"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/multiformats/go-multiaddr"
@@ -35,9 +37,23 @@ func (tn testNet) WaitForDHT() {
 }
 
 type hostDescr struct {
-	chainID string
-	conns   []int
-	realKey bool
+	chainID  string
+	conns    []int
+	realKey  bool
+	seedMode bool
+	// txBatchSize enables batching of gossiped transactions
+	txBatchSize int
+	// mempool enables mempool sync
+	mempool MempoolSource
+	// genesisHash, height and appVersion are sent in handshake
+	genesisHash []byte
+	height      uint64
+	appVersion  uint64
+	// blockValidator enables block gossip (only blocks gossiped by the first host are accepted)
+	blockValidator GossipValidator
+	blockPartSize  int
+	// maxMessageSize limits size of received gossip messages
+	maxMessageSize int
 }
 
 // copied from libp2p net/mock
@@ -101,15 +117,35 @@ func startTestNetwork(ctx context.Context, t *testing.T, n int, conf map[int]hos
 
 	clients := make([]*Client, n)
 	for i := 0; i < n; i++ {
+		chainID := conf[i].chainID
+		if chainID == "" {
+			chainID = "test"
+		}
 		client, err := NewClient(config.P2PConfig{
-			Seeds: seeds[i]},
+			Seeds:              seeds[i],
+			SeedMode:           conf[i].seedMode,
+			TxBatchSize:        conf[i].txBatchSize,
+			TxBatchCompression: true,
+			BlockGossip:        conf[i].blockValidator != nil,
+			BlockPartSize:      conf[i].blockPartSize,
+			MaxMessageSize:     conf[i].maxMessageSize},
 			mnet.Hosts()[i].Peerstore().PrivKey(mnet.Hosts()[i].ID()),
-			conf[i].chainID,
+			chainID,
 			logger)
 		require.NoError(err)
 		require.NotNil(client)
 
 		client.SetTxValidator(validators[i])
+		if conf[i].mempool != nil {
+			client.SetMempoolSource(conf[i].mempool)
+		}
+		if conf[i].blockValidator != nil {
+			client.SetBlockValidator(conf[i].blockValidator)
+			client.SetBlockManifestVerifier(newTestManifestVerifier(mnet.Hosts()[0]))
+		}
+		height := conf[i].height
+		client.SetHandshakeInfo(conf[i].genesisHash, func() uint64 { return height })
+		client.SetAppInfo(conf[i].appVersion, fmt.Sprintf("v%d", conf[i].appVersion))
 		clients[i] = client
 	}
 
@@ -120,3 +156,18 @@ func startTestNetwork(ctx context.Context, t *testing.T, n int, conf map[int]hos
 
 	return clients
 }
+
+// newTestManifestVerifier returns BlockManifestVerifier accepting manifests signed by given host.
+func newTestManifestVerifier(signer host.Host) BlockManifestVerifier {
+	pubKey := signer.Peerstore().PubKey(signer.ID())
+	return func(msg []byte, signature []byte) error {
+		ok, err := pubKey.Verify(msg, signature)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+}
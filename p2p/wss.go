@@ -0,0 +1,196 @@
+package p2p
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/transport"
+	tptu "github.com/libp2p/go-libp2p-transport-upgrader"
+	ws "github.com/libp2p/go-ws-transport"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// wssTransport is a libp2p transport for secure WebSocket connections (addresses ending with /wss).
+//
+// WebSocket transport of this version of libp2p supports only plain WebSocket connections. wssTransport wraps them in
+// TLS, which is required by browsers and proxies. Peers are still authenticated by libp2p security protocol.
+type wssTransport struct {
+	upgrader *tptu.Upgrader
+	// serverTLS is used to accept connections; it's nil if TLS certificate is not configured
+	serverTLS *tls.Config
+	// clientTLS is used to dial peers (nil means default configuration)
+	clientTLS *tls.Config
+}
+
+var _ transport.Transport = &wssTransport{}
+
+var wsUpgrader = websocket.Upgrader{
+	// connections are authenticated by libp2p, so requests from all origins are allowed
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+func newWSSTransport(upgrader *tptu.Upgrader, serverTLS, clientTLS *tls.Config) *wssTransport {
+	return &wssTransport{upgrader: upgrader, serverTLS: serverTLS, clientTLS: clientTLS}
+}
+
+// CanDial returns true for resolved secure WebSocket addresses (e.g. /ip4/1.2.3.4/tcp/443/wss).
+func (t *wssTransport) CanDial(addr multiaddr.Multiaddr) bool {
+	protos := addr.Protocols()
+	return len(protos) == 3 &&
+		(protos[0].Code == multiaddr.P_IP4 || protos[0].Code == multiaddr.P_IP6) &&
+		protos[1].Code == multiaddr.P_TCP &&
+		protos[2].Code == multiaddr.P_WSS
+}
+
+// Dial opens secure WebSocket connection and upgrades it to libp2p connection.
+func (t *wssTransport) Dial(ctx context.Context, raddr multiaddr.Multiaddr, p peer.ID) (transport.CapableConn, error) {
+	_, host, err := manet.DialArgs(raddr)
+	if err != nil {
+		return nil, err
+	}
+	dialer := websocket.Dialer{TLSClientConfig: t.clientTLS}
+	wsConn, _, err := dialer.DialContext(ctx, "wss://"+host, nil)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := newWSSConn(wsConn)
+	if err != nil {
+		_ = wsConn.Close()
+		return nil, err
+	}
+	return t.upgrader.UpgradeOutbound(ctx, t, conn, p)
+}
+
+// Listen accepts secure WebSocket connections on given address. TLS certificate has to be configured.
+func (t *wssTransport) Listen(laddr multiaddr.Multiaddr) (transport.Listener, error) {
+	if t.serverTLS == nil {
+		return nil, errors.New("TLS certificate is required to listen for secure WebSocket connections")
+	}
+	network, addr, err := manet.DialArgs(laddr)
+	if err != nil {
+		return nil, err
+	}
+	nl, err := net.Listen(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	maddr, err := wssMultiaddr(nl.Addr())
+	if err != nil {
+		_ = nl.Close()
+		return nil, err
+	}
+	l := &wssListener{
+		Listener: nl,
+		maddr:    maddr,
+		incoming: make(chan *websocket.Conn),
+		closed:   make(chan struct{}),
+	}
+	go l.serve(tls.NewListener(nl, t.serverTLS))
+	return t.upgrader.UpgradeListener(t, l), nil
+}
+
+// Protocols returns the list of protocols handled by this transport.
+func (t *wssTransport) Protocols() []int {
+	return []int{multiaddr.P_WSS}
+}
+
+// Proxy returns false, as this transport connects directly to peers.
+func (t *wssTransport) Proxy() bool {
+	return false
+}
+
+// wssListener accepts WebSocket connections over TLS listener.
+type wssListener struct {
+	net.Listener
+	maddr multiaddr.Multiaddr
+
+	incoming chan *websocket.Conn
+	closed   chan struct{}
+}
+
+var _ manet.Listener = &wssListener{}
+
+func (l *wssListener) serve(tlsListener net.Listener) {
+	defer close(l.closed)
+	_ = http.Serve(tlsListener, l)
+}
+
+// ServeHTTP upgrades HTTP request to WebSocket connection.
+func (l *wssListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// error response is written by upgrader
+		return
+	}
+	select {
+	case l.incoming <- conn:
+	case <-l.closed:
+		_ = conn.Close()
+	}
+}
+
+// Accept returns the next secure WebSocket connection.
+func (l *wssListener) Accept() (manet.Conn, error) {
+	select {
+	case wsConn := <-l.incoming:
+		conn, err := newWSSConn(wsConn)
+		if err != nil {
+			_ = wsConn.Close()
+			return nil, err
+		}
+		return conn, nil
+	case <-l.closed:
+		return nil, errors.New("listener is closed")
+	}
+}
+
+// Multiaddr returns the address of the listener.
+func (l *wssListener) Multiaddr() multiaddr.Multiaddr {
+	return l.maddr
+}
+
+// wssConn is a secure WebSocket connection with multiaddrs of both ends.
+type wssConn struct {
+	*ws.Conn
+	laddr multiaddr.Multiaddr
+	raddr multiaddr.Multiaddr
+}
+
+var _ manet.Conn = &wssConn{}
+
+func newWSSConn(wsConn *websocket.Conn) (*wssConn, error) {
+	laddr, err := wssMultiaddr(wsConn.UnderlyingConn().LocalAddr())
+	if err != nil {
+		return nil, err
+	}
+	raddr, err := wssMultiaddr(wsConn.UnderlyingConn().RemoteAddr())
+	if err != nil {
+		return nil, err
+	}
+	return &wssConn{Conn: ws.NewConn(wsConn), laddr: laddr, raddr: raddr}, nil
+}
+
+// LocalMultiaddr returns the local address of the connection.
+func (c *wssConn) LocalMultiaddr() multiaddr.Multiaddr {
+	return c.laddr
+}
+
+// RemoteMultiaddr returns the remote address of the connection.
+func (c *wssConn) RemoteMultiaddr() multiaddr.Multiaddr {
+	return c.raddr
+}
+
+// wssMultiaddr converts TCP address to secure WebSocket multiaddr.
+func wssMultiaddr(addr net.Addr) (multiaddr.Multiaddr, error) {
+	tcpAddr, err := manet.FromNetAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	return tcpAddr.Encapsulate(multiaddr.StringCast("/wss")), nil
+}
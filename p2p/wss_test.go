@@ -0,0 +1,112 @@
+package p2p
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	tptu "github.com/libp2p/go-libp2p-transport-upgrader"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/optimint/config"
+	"github.com/celestiaorg/optimint/log/test"
+)
+
+func TestSecureWebSocket(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	certFile, keyFile, roots := generateTestCert(t)
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	client, err := NewClient(config.P2PConfig{
+		ListenAddress:   "/ip4/127.0.0.1/tcp/7684",
+		WSListenAddress: "/ip4/127.0.0.1/tcp/7685/wss",
+		Transports:      "tcp,wss",
+		TLSCertFile:     certFile,
+		TLSKeyFile:      keyFile,
+	}, key, "TestChain", &test.TestLogger{T: t})
+	require.NoError(err)
+	require.NoError(client.Start(context.Background()))
+	defer client.Close()
+
+	wssAddr := multiaddr.StringCast("/ip4/127.0.0.1/tcp/7685/wss")
+	assert.Contains(client.host.Network().ListenAddresses(), wssAddr)
+
+	// secure WebSocket only host, trusting the certificate of the client
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h, err := libp2p.New(ctx, libp2p.NoListenAddrs, libp2p.Transport(func(upgrader *tptu.Upgrader) *wssTransport {
+		return newWSSTransport(upgrader, nil, &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12})
+	}))
+	require.NoError(err)
+	defer h.Close()
+
+	require.NoError(h.Connect(ctx, peer.AddrInfo{ID: client.host.ID(), Addrs: []multiaddr.Multiaddr{wssAddr}}))
+	conns := h.Network().ConnsToPeer(client.host.ID())
+	require.Len(conns, 1)
+	assert.Equal(wssAddr, conns[0].RemoteMultiaddr())
+	assert.Eventually(func() bool {
+		for _, conn := range client.host.Network().ConnsToPeer(h.ID()) {
+			if _, err := conn.RemoteMultiaddr().ValueForProtocol(multiaddr.P_WSS); err == nil {
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+
+	// listening requires certificate
+	_, err = newWSSTransport(nil, nil, nil).Listen(multiaddr.StringCast("/ip4/127.0.0.1/tcp/0/wss"))
+	assert.Error(err)
+}
+
+// generateTestCert writes self-signed certificate for 127.0.0.1 and its key, and returns paths and certificate pool.
+func generateTestCert(t *testing.T) (string, string, *x509.CertPool) {
+	require := require.New(t)
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "optimint test"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	require.NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(err)
+	keyDER, err := x509.MarshalECPrivateKey(priv)
+	require.NoError(err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	return certFile, keyFile, roots
+}
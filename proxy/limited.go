@@ -0,0 +1,178 @@
+package proxy
+
+import (
+	"errors"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproxy "github.com/tendermint/tendermint/proxy"
+
+	"github.com/celestiaorg/optimint/config"
+)
+
+// ErrOverloaded is returned when request is rejected because of too many concurrent requests.
+var ErrOverloaded = errors.New("ABCI connection overloaded, try again later")
+
+// LimitedAppConns wraps AppConns and limits number of concurrent requests on every connection.
+type LimitedAppConns struct {
+	tmproxy.AppConns
+
+	consensus *limitedConsensus
+	mempool   *limitedMempool
+	query     *limitedQuery
+}
+
+var _ tmproxy.AppConns = &LimitedAppConns{}
+
+// NewLimitedAppConns creates new LimitedAppConns. conns has to be already started.
+// ABCI queries over the limit are rejected with ErrOverloaded, other requests are blocked until a slot is available.
+func NewLimitedAppConns(conns tmproxy.AppConns, conf config.ABCIConfig) *LimitedAppConns {
+	return &LimitedAppConns{
+		AppConns:  conns,
+		consensus: &limitedConsensus{AppConnConsensus: conns.Consensus(), sem: newSemaphore(conf.ConsensusConcurrency)},
+		mempool:   &limitedMempool{AppConnMempool: conns.Mempool(), sem: newSemaphore(conf.MempoolConcurrency)},
+		query:     &limitedQuery{AppConnQuery: conns.Query(), sem: newSemaphore(conf.QueryConcurrency)},
+	}
+}
+
+// Consensus returns consensus connection.
+func (l *LimitedAppConns) Consensus() tmproxy.AppConnConsensus {
+	return l.consensus
+}
+
+// Mempool returns mempool connection.
+func (l *LimitedAppConns) Mempool() tmproxy.AppConnMempool {
+	return l.mempool
+}
+
+// Query returns query connection.
+func (l *LimitedAppConns) Query() tmproxy.AppConnQuery {
+	return l.query
+}
+
+type limitedConsensus struct {
+	tmproxy.AppConnConsensus
+	sem semaphore
+}
+
+func (c *limitedConsensus) InitChainSync(req abci.RequestInitChain) (*abci.ResponseInitChain, error) {
+	c.sem.acquire()
+	defer c.sem.release()
+	return c.AppConnConsensus.InitChainSync(req)
+}
+
+func (c *limitedConsensus) BeginBlockSync(req abci.RequestBeginBlock) (*abci.ResponseBeginBlock, error) {
+	c.sem.acquire()
+	defer c.sem.release()
+	return c.AppConnConsensus.BeginBlockSync(req)
+}
+
+func (c *limitedConsensus) DeliverTxAsync(req abci.RequestDeliverTx) *abcicli.ReqRes {
+	c.sem.acquire()
+	return c.sem.releaseWhenDone(c.AppConnConsensus.DeliverTxAsync(req))
+}
+
+func (c *limitedConsensus) EndBlockSync(req abci.RequestEndBlock) (*abci.ResponseEndBlock, error) {
+	c.sem.acquire()
+	defer c.sem.release()
+	return c.AppConnConsensus.EndBlockSync(req)
+}
+
+func (c *limitedConsensus) CommitSync() (*abci.ResponseCommit, error) {
+	c.sem.acquire()
+	defer c.sem.release()
+	return c.AppConnConsensus.CommitSync()
+}
+
+type limitedMempool struct {
+	tmproxy.AppConnMempool
+	sem semaphore
+}
+
+func (m *limitedMempool) CheckTxAsync(req abci.RequestCheckTx) *abcicli.ReqRes {
+	m.sem.acquire()
+	return m.sem.releaseWhenDone(m.AppConnMempool.CheckTxAsync(req))
+}
+
+func (m *limitedMempool) CheckTxSync(req abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	m.sem.acquire()
+	defer m.sem.release()
+	return m.AppConnMempool.CheckTxSync(req)
+}
+
+type limitedQuery struct {
+	tmproxy.AppConnQuery
+	sem semaphore
+}
+
+// EchoSync and InfoSync are used by the node itself (e.g. on startup), so they wait for a free slot instead of being
+// shed like QuerySync.
+func (q *limitedQuery) EchoSync(msg string) (*abci.ResponseEcho, error) {
+	q.sem.acquire()
+	defer q.sem.release()
+	return q.AppConnQuery.EchoSync(msg)
+}
+
+func (q *limitedQuery) InfoSync(req abci.RequestInfo) (*abci.ResponseInfo, error) {
+	q.sem.acquire()
+	defer q.sem.release()
+	return q.AppConnQuery.InfoSync(req)
+}
+
+func (q *limitedQuery) QuerySync(req abci.RequestQuery) (*abci.ResponseQuery, error) {
+	if !q.sem.tryAcquire() {
+		return nil, ErrOverloaded
+	}
+	defer q.sem.release()
+	return q.AppConnQuery.QuerySync(req)
+}
+
+// semaphore limits number of concurrent operations. nil semaphore doesn't limit anything.
+type semaphore chan struct{}
+
+func newSemaphore(n int) semaphore {
+	if n <= 0 {
+		return nil
+	}
+	return make(semaphore, n)
+}
+
+func (s semaphore) acquire() {
+	if s != nil {
+		s <- struct{}{}
+	}
+}
+
+func (s semaphore) tryAcquire() bool {
+	if s == nil {
+		return true
+	}
+	select {
+	case s <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
+
+// releaseWhenDone releases semaphore after asynchronous request is completed.
+func (s semaphore) releaseWhenDone(reqRes *abcicli.ReqRes) *abcicli.ReqRes {
+	if s == nil {
+		return reqRes
+	}
+	if reqRes == nil {
+		s.release()
+		return nil
+	}
+	go func() {
+		reqRes.Wait()
+		s.release()
+	}()
+	return reqRes
+}
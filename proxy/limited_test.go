@@ -0,0 +1,92 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproxy "github.com/tendermint/tendermint/proxy"
+
+	"github.com/celestiaorg/optimint/config"
+	"github.com/celestiaorg/optimint/mocks"
+)
+
+func TestQueryLoadShedding(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	unblock := make(chan struct{})
+	started := make(chan struct{})
+	app := &mocks.Application{}
+	app.On("Query", abci.RequestQuery{Path: "slow"}).Run(func(args mock.Arguments) {
+		close(started)
+		<-unblock
+	}).Return(abci.ResponseQuery{}).Once()
+	app.On("Query", abci.RequestQuery{Path: "fast"}).Return(abci.ResponseQuery{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{})
+
+	conns := tmproxy.NewAppConns(tmproxy.NewLocalClientCreator(app))
+	require.NoError(conns.Start())
+	defer func() {
+		require.NoError(conns.Stop())
+	}()
+
+	limited := NewLimitedAppConns(conns, config.ABCIConfig{QueryConcurrency: 1})
+
+	done := make(chan error)
+	go func() {
+		_, err := limited.Query().QuerySync(abci.RequestQuery{Path: "slow"})
+		done <- err
+	}()
+	<-started
+
+	_, err := limited.Query().QuerySync(abci.RequestQuery{Path: "fast"})
+	assert.ErrorIs(err, ErrOverloaded)
+
+	// Info is not shed, it waits for the slot
+	infoDone := make(chan error)
+	go func() {
+		_, err := limited.Query().InfoSync(abci.RequestInfo{})
+		infoDone <- err
+	}()
+	select {
+	case <-infoDone:
+		t.Fatal("info request should wait for a free slot")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(unblock)
+	for _, ch := range []chan error{done, infoDone} {
+		select {
+		case err = <-ch:
+			assert.NoError(err)
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	_, err = limited.Query().QuerySync(abci.RequestQuery{Path: "fast"})
+	assert.NoError(err)
+}
+
+func TestSemaphore(t *testing.T) {
+	assert := assert.New(t)
+
+	unlimited := newSemaphore(0)
+	for i := 0; i < 10; i++ {
+		assert.True(unlimited.tryAcquire())
+	}
+
+	s := newSemaphore(2)
+	assert.True(s.tryAcquire())
+	s.acquire()
+	assert.False(s.tryAcquire())
+	s.release()
+	assert.True(s.tryAcquire())
+	s.release()
+	s.release()
+	assert.Len(s, 0)
+}
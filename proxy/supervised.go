@@ -0,0 +1,533 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/libs/service"
+	tmproxy "github.com/tendermint/tendermint/proxy"
+
+	"github.com/celestiaorg/optimint/config"
+)
+
+// ErrAppConnLost is returned for requests sent while connection to the application is being re-established.
+var ErrAppConnLost = errors.New("ABCI application connection lost, reconnecting")
+
+const (
+	connConsensus = "consensus"
+	connMempool   = "mempool"
+	connQuery     = "query"
+	connSnapshot  = "snapshot"
+)
+
+// ReconnectHandler is called after connection to the application is re-established, before any other requests are
+// sent to the application. It receives the application info and the new consensus connection, so it can bring the
+// application up to date with the node (handshake). Error returned by handler is fatal.
+type ReconnectHandler func(info *abci.ResponseInfo, consensus tmproxy.AppConnConsensus) error
+
+// SupervisedAppConns implements AppConns, and re-establishes all the connections to the application if any of them is
+// terminated (e.g. because out-of-process application crashed and was restarted).
+//
+// Connections returned by SupervisedAppConns remain valid after reconnection. Requests sent while the application
+// is not connected fail with ErrAppConnLost. If the application can't be reconnected within configured number of
+// retries, the process is killed (the same as in Tendermint).
+type SupervisedAppConns struct {
+	service.BaseService
+
+	clientCreator tmproxy.ClientCreator
+	retries       int
+	interval      time.Duration
+
+	consensus *supervisedConsensus
+	mempool   *supervisedMempool
+	query     *supervisedQuery
+	snapshot  *supervisedSnapshot
+
+	// mtx guards fields below
+	mtx sync.RWMutex
+	// conns is nil if application is not connected
+	conns *appConns
+	// gen is incremented after every successful (re)connection
+	gen              uint64
+	consensusCb      abcicli.Callback
+	mempoolCb        abcicli.Callback
+	reconnectHandler ReconnectHandler
+}
+
+var _ tmproxy.AppConns = &SupervisedAppConns{}
+
+// appConns is a set of connections to the application.
+type appConns struct {
+	consensus tmproxy.AppConnConsensus
+	mempool   tmproxy.AppConnMempool
+	query     tmproxy.AppConnQuery
+	snapshot  tmproxy.AppConnSnapshot
+
+	clients map[string]abcicli.Client
+}
+
+// NewSupervisedAppConns creates new SupervisedAppConns. Reconnection is disabled if conf.ReconnectRetries is 0.
+func NewSupervisedAppConns(clientCreator tmproxy.ClientCreator, conf config.ABCIConfig) *SupervisedAppConns {
+	s := &SupervisedAppConns{
+		clientCreator: clientCreator,
+		retries:       conf.ReconnectRetries,
+		interval:      conf.ReconnectInterval,
+	}
+	s.consensus = &supervisedConsensus{s: s}
+	s.mempool = &supervisedMempool{s: s}
+	s.query = &supervisedQuery{s: s}
+	s.snapshot = &supervisedSnapshot{s: s}
+	s.BaseService = *service.NewBaseService(nil, "SupervisedAppConns", s)
+	return s
+}
+
+// SetReconnectHandler sets handler called after every reconnection to the application.
+func (s *SupervisedAppConns) SetReconnectHandler(handler ReconnectHandler) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.reconnectHandler = handler
+}
+
+// Consensus returns consensus connection.
+func (s *SupervisedAppConns) Consensus() tmproxy.AppConnConsensus {
+	return s.consensus
+}
+
+// Mempool returns mempool connection.
+func (s *SupervisedAppConns) Mempool() tmproxy.AppConnMempool {
+	return s.mempool
+}
+
+// Query returns query connection.
+func (s *SupervisedAppConns) Query() tmproxy.AppConnQuery {
+	return s.query
+}
+
+// Snapshot returns snapshot connection.
+func (s *SupervisedAppConns) Snapshot() tmproxy.AppConnSnapshot {
+	return s.snapshot
+}
+
+// OnStart implements service.Service by connecting to the application and starting supervision.
+func (s *SupervisedAppConns) OnStart() error {
+	conns, err := s.connect()
+	if err != nil {
+		return err
+	}
+	s.setConns(conns)
+	go s.supervise(conns)
+	return nil
+}
+
+// OnStop implements service.Service by closing all connections to the application.
+func (s *SupervisedAppConns) OnStop() {
+	s.mtx.Lock()
+	conns := s.conns
+	s.conns = nil
+	s.mtx.Unlock()
+	if conns != nil {
+		s.stopClients(conns)
+	}
+}
+
+// current returns current connections and their generation, or nil if application is not connected.
+func (s *SupervisedAppConns) current() (*appConns, uint64) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.conns, s.gen
+}
+
+func (s *SupervisedAppConns) setConns(conns *appConns) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.consensusCb != nil {
+		conns.consensus.SetResponseCallback(s.consensusCb)
+	}
+	if s.mempoolCb != nil {
+		conns.mempool.SetResponseCallback(s.mempoolCb)
+	}
+	s.conns = conns
+	s.gen++
+}
+
+// supervise waits for termination of any of the connections and reconnects to the application.
+func (s *SupervisedAppConns) supervise(conns *appConns) {
+	for {
+		conn, err := waitForTermination(conns, s.Quit())
+		if !s.IsRunning() {
+			return
+		}
+		s.Logger.Error("ABCI connection terminated, did the application crash?", "connection", conn, "err", err)
+
+		s.mtx.Lock()
+		s.conns = nil
+		s.mtx.Unlock()
+		s.stopClients(conns)
+
+		conns, err = s.reconnect()
+		if err != nil {
+			if !s.IsRunning() {
+				return
+			}
+			s.Logger.Error("failed to reconnect to ABCI application, please restart the node", "err", err)
+			if killErr := tmos.Kill(); killErr != nil {
+				s.Logger.Error("failed to kill this process - please do so manually", "err", killErr)
+			}
+			return
+		}
+		s.setConns(conns)
+		s.Logger.Info("reconnected to ABCI application")
+	}
+}
+
+// waitForTermination blocks until one of the connections is terminated, or quit channel is closed.
+func waitForTermination(conns *appConns, quit <-chan struct{}) (string, error) {
+	terminated := make(chan string, len(conns.clients))
+	done := make(chan struct{})
+	defer close(done)
+	for name, client := range conns.clients {
+		go func(name string, client abcicli.Client) {
+			select {
+			case <-client.Quit():
+				terminated <- name
+			case <-done:
+			}
+		}(name, client)
+	}
+	select {
+	case name := <-terminated:
+		return name, conns.clients[name].Error()
+	case <-quit:
+		return "", nil
+	}
+}
+
+// reconnect tries to re-establish connections to the application and performs handshake.
+func (s *SupervisedAppConns) reconnect() (*appConns, error) {
+	if s.retries <= 0 {
+		return nil, errors.New("reconnection is disabled")
+	}
+	var err error
+	for attempt := 1; attempt <= s.retries; attempt++ {
+		select {
+		case <-time.After(s.interval):
+		case <-s.Quit():
+			return nil, errors.New("service stopped")
+		}
+
+		s.Logger.Info("reconnecting to ABCI application", "attempt", attempt, "retries", s.retries)
+		var conns *appConns
+		conns, err = s.connect()
+		if err != nil {
+			s.Logger.Error("failed to reconnect to ABCI application", "attempt", attempt, "err", err)
+			continue
+		}
+		var info *abci.ResponseInfo
+		info, err = conns.query.InfoSync(tmproxy.RequestInfo)
+		if err != nil {
+			s.stopClients(conns)
+			s.Logger.Error("failed to get ABCI application info", "attempt", attempt, "err", err)
+			continue
+		}
+
+		s.mtx.RLock()
+		handler := s.reconnectHandler
+		s.mtx.RUnlock()
+		if handler != nil {
+			if err := handler(info, conns.consensus); err != nil {
+				s.stopClients(conns)
+				return nil, fmt.Errorf("handshake failed: %w", err)
+			}
+		}
+		return conns, nil
+	}
+	return nil, fmt.Errorf("giving up after %d retries: %w", s.retries, err)
+}
+
+// connect creates and starts clients for all the connections.
+func (s *SupervisedAppConns) connect() (*appConns, error) {
+	conns := &appConns{clients: make(map[string]abcicli.Client)}
+	for _, name := range []string{connQuery, connSnapshot, connMempool, connConsensus} {
+		c, err := s.clientCreator.NewABCIClient()
+		if err != nil {
+			s.stopClients(conns)
+			return nil, fmt.Errorf("error creating ABCI client (%s connection): %w", name, err)
+		}
+		c.SetLogger(s.Logger.With("module", "abci-client", "connection", name))
+		if err := c.Start(); err != nil {
+			s.stopClients(conns)
+			return nil, fmt.Errorf("error starting ABCI client (%s connection): %w", name, err)
+		}
+		conns.clients[name] = c
+	}
+	conns.consensus = tmproxy.NewAppConnConsensus(conns.clients[connConsensus])
+	conns.mempool = tmproxy.NewAppConnMempool(conns.clients[connMempool])
+	conns.query = tmproxy.NewAppConnQuery(conns.clients[connQuery])
+	conns.snapshot = tmproxy.NewAppConnSnapshot(conns.clients[connSnapshot])
+	return conns, nil
+}
+
+func (s *SupervisedAppConns) stopClients(conns *appConns) {
+	for name, client := range conns.clients {
+		if !client.IsRunning() {
+			continue
+		}
+		if err := client.Stop(); err != nil {
+			s.Logger.Error("error while stopping ABCI client", "connection", name, "error", err)
+		}
+	}
+}
+
+// failedReqRes returns already completed ReqRes with exception response, for asynchronous requests that can't be sent.
+func failedReqRes(req *abci.Request, err error) *abcicli.ReqRes {
+	reqRes := abcicli.NewReqRes(req)
+	reqRes.Response = abci.ToResponseException(err.Error())
+	reqRes.SetDone()
+	reqRes.Done()
+	return reqRes
+}
+
+type supervisedConsensus struct {
+	s *SupervisedAppConns
+
+	// blockGen is the generation of connection used to begin current block.
+	// Block can't be continued after reconnection, as application state was lost.
+	blockGen uint64
+}
+
+func (c *supervisedConsensus) current() (tmproxy.AppConnConsensus, error) {
+	conns, _ := c.s.current()
+	if conns == nil {
+		return nil, ErrAppConnLost
+	}
+	return conns.consensus, nil
+}
+
+// currentForBlock returns current connection, only if it was used to begin current block.
+func (c *supervisedConsensus) currentForBlock() (tmproxy.AppConnConsensus, error) {
+	conns, gen := c.s.current()
+	if conns == nil || gen != c.blockGen {
+		return nil, ErrAppConnLost
+	}
+	return conns.consensus, nil
+}
+
+func (c *supervisedConsensus) SetResponseCallback(cb abcicli.Callback) {
+	c.s.mtx.Lock()
+	defer c.s.mtx.Unlock()
+	c.s.consensusCb = cb
+	if c.s.conns != nil {
+		c.s.conns.consensus.SetResponseCallback(cb)
+	}
+}
+
+func (c *supervisedConsensus) Error() error {
+	conn, err := c.current()
+	if err != nil {
+		return err
+	}
+	return conn.Error()
+}
+
+func (c *supervisedConsensus) InitChainSync(req abci.RequestInitChain) (*abci.ResponseInitChain, error) {
+	conn, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return conn.InitChainSync(req)
+}
+
+func (c *supervisedConsensus) BeginBlockSync(req abci.RequestBeginBlock) (*abci.ResponseBeginBlock, error) {
+	conns, gen := c.s.current()
+	if conns == nil {
+		return nil, ErrAppConnLost
+	}
+	c.blockGen = gen
+	return conns.consensus.BeginBlockSync(req)
+}
+
+func (c *supervisedConsensus) DeliverTxAsync(req abci.RequestDeliverTx) *abcicli.ReqRes {
+	conn, err := c.currentForBlock()
+	if err != nil {
+		return failedReqRes(abci.ToRequestDeliverTx(req), err)
+	}
+	return conn.DeliverTxAsync(req)
+}
+
+func (c *supervisedConsensus) EndBlockSync(req abci.RequestEndBlock) (*abci.ResponseEndBlock, error) {
+	conn, err := c.currentForBlock()
+	if err != nil {
+		return nil, err
+	}
+	return conn.EndBlockSync(req)
+}
+
+func (c *supervisedConsensus) CommitSync() (*abci.ResponseCommit, error) {
+	conn, err := c.currentForBlock()
+	if err != nil {
+		return nil, err
+	}
+	return conn.CommitSync()
+}
+
+type supervisedMempool struct {
+	s *SupervisedAppConns
+}
+
+func (m *supervisedMempool) current() (tmproxy.AppConnMempool, error) {
+	conns, _ := m.s.current()
+	if conns == nil {
+		return nil, ErrAppConnLost
+	}
+	return conns.mempool, nil
+}
+
+func (m *supervisedMempool) SetResponseCallback(cb abcicli.Callback) {
+	m.s.mtx.Lock()
+	defer m.s.mtx.Unlock()
+	m.s.mempoolCb = cb
+	if m.s.conns != nil {
+		m.s.conns.mempool.SetResponseCallback(cb)
+	}
+}
+
+func (m *supervisedMempool) Error() error {
+	conn, err := m.current()
+	if err != nil {
+		return err
+	}
+	return conn.Error()
+}
+
+func (m *supervisedMempool) CheckTxAsync(req abci.RequestCheckTx) *abcicli.ReqRes {
+	conn, err := m.current()
+	if err != nil {
+		return failedReqRes(abci.ToRequestCheckTx(req), err)
+	}
+	return conn.CheckTxAsync(req)
+}
+
+func (m *supervisedMempool) CheckTxSync(req abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	conn, err := m.current()
+	if err != nil {
+		return nil, err
+	}
+	return conn.CheckTxSync(req)
+}
+
+func (m *supervisedMempool) FlushAsync() *abcicli.ReqRes {
+	conn, err := m.current()
+	if err != nil {
+		return failedReqRes(abci.ToRequestFlush(), err)
+	}
+	return conn.FlushAsync()
+}
+
+func (m *supervisedMempool) FlushSync() error {
+	conn, err := m.current()
+	if err != nil {
+		return err
+	}
+	return conn.FlushSync()
+}
+
+type supervisedQuery struct {
+	s *SupervisedAppConns
+}
+
+func (q *supervisedQuery) current() (tmproxy.AppConnQuery, error) {
+	conns, _ := q.s.current()
+	if conns == nil {
+		return nil, ErrAppConnLost
+	}
+	return conns.query, nil
+}
+
+func (q *supervisedQuery) Error() error {
+	conn, err := q.current()
+	if err != nil {
+		return err
+	}
+	return conn.Error()
+}
+
+func (q *supervisedQuery) EchoSync(msg string) (*abci.ResponseEcho, error) {
+	conn, err := q.current()
+	if err != nil {
+		return nil, err
+	}
+	return conn.EchoSync(msg)
+}
+
+func (q *supervisedQuery) InfoSync(req abci.RequestInfo) (*abci.ResponseInfo, error) {
+	conn, err := q.current()
+	if err != nil {
+		return nil, err
+	}
+	return conn.InfoSync(req)
+}
+
+func (q *supervisedQuery) QuerySync(req abci.RequestQuery) (*abci.ResponseQuery, error) {
+	conn, err := q.current()
+	if err != nil {
+		return nil, err
+	}
+	return conn.QuerySync(req)
+}
+
+type supervisedSnapshot struct {
+	s *SupervisedAppConns
+}
+
+func (s *supervisedSnapshot) current() (tmproxy.AppConnSnapshot, error) {
+	conns, _ := s.s.current()
+	if conns == nil {
+		return nil, ErrAppConnLost
+	}
+	return conns.snapshot, nil
+}
+
+func (s *supervisedSnapshot) Error() error {
+	conn, err := s.current()
+	if err != nil {
+		return err
+	}
+	return conn.Error()
+}
+
+func (s *supervisedSnapshot) ListSnapshotsSync(req abci.RequestListSnapshots) (*abci.ResponseListSnapshots, error) {
+	conn, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	return conn.ListSnapshotsSync(req)
+}
+
+func (s *supervisedSnapshot) OfferSnapshotSync(req abci.RequestOfferSnapshot) (*abci.ResponseOfferSnapshot, error) {
+	conn, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	return conn.OfferSnapshotSync(req)
+}
+
+func (s *supervisedSnapshot) LoadSnapshotChunkSync(req abci.RequestLoadSnapshotChunk) (*abci.ResponseLoadSnapshotChunk, error) {
+	conn, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	return conn.LoadSnapshotChunkSync(req)
+}
+
+func (s *supervisedSnapshot) ApplySnapshotChunkSync(req abci.RequestApplySnapshotChunk) (*abci.ResponseApplySnapshotChunk, error) {
+	conn, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	return conn.ApplySnapshotChunkSync(req)
+}
@@ -0,0 +1,71 @@
+package proxy
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abciserver "github.com/tendermint/tendermint/abci/server"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproxy "github.com/tendermint/tendermint/proxy"
+
+	"github.com/celestiaorg/optimint/config"
+)
+
+func TestReconnect(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	addr := "unix://" + filepath.Join(t.TempDir(), "app.sock")
+	app := kvstore.NewApplication()
+	startServer := func() *abciserver.SocketServer {
+		srv := abciserver.NewSocketServer(addr, app).(*abciserver.SocketServer)
+		srv.SetLogger(log.TestingLogger())
+		require.NoError(srv.Start())
+		return srv
+	}
+	srv := startServer()
+
+	conns := NewSupervisedAppConns(tmproxy.NewRemoteClientCreator(addr, "socket", true),
+		config.ABCIConfig{ReconnectRetries: 50, ReconnectInterval: 100 * time.Millisecond})
+	conns.SetLogger(log.TestingLogger())
+
+	handshakes := make(chan *abci.ResponseInfo, 1)
+	conns.SetReconnectHandler(func(info *abci.ResponseInfo, consensus tmproxy.AppConnConsensus) error {
+		handshakes <- info
+		return nil
+	})
+	require.NoError(conns.Start())
+	defer func() {
+		require.NoError(conns.Stop())
+	}()
+
+	_, err := conns.Query().EchoSync("hello")
+	require.NoError(err)
+
+	require.NoError(srv.Stop())
+	assert.Eventually(func() bool {
+		_, err := conns.Query().EchoSync("hello")
+		return err == ErrAppConnLost
+	}, 5*time.Second, 10*time.Millisecond)
+
+	srv = startServer()
+	defer func() {
+		require.NoError(srv.Stop())
+	}()
+
+	select {
+	case info := <-handshakes:
+		assert.NotNil(info)
+	case <-time.After(10 * time.Second):
+		t.Fatal("handshake was not performed")
+	}
+
+	res, err := conns.Query().EchoSync("hello")
+	require.NoError(err)
+	assert.Equal("hello", res.Message)
+}
@@ -0,0 +1,149 @@
+package replication
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+
+	"github.com/celestiaorg/optimint/state"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// SubscribeRequest starts streaming of updates, beginning with block at FromHeight.
+type SubscribeRequest struct {
+	FromHeight uint64
+}
+
+// Update contains everything that replica needs to serve RPC requests about a block, without executing it.
+type Update struct {
+	Block     *types.Block
+	Commit    *types.Commit
+	Responses *tmstate.ABCIResponses
+	// State after applying the block. It's sent only with the latest block of the source node - replica doesn't
+	// need intermediate states while catching up.
+	State *state.State
+}
+
+var (
+	_ encoding.BinaryMarshaler   = &SubscribeRequest{}
+	_ encoding.BinaryUnmarshaler = &SubscribeRequest{}
+	_ encoding.BinaryMarshaler   = &Update{}
+	_ encoding.BinaryUnmarshaler = &Update{}
+)
+
+// MarshalBinary encodes SubscribeRequest into binary form and returns it.
+func (r *SubscribeRequest) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, r.FromHeight)
+	return buf, nil
+}
+
+// UnmarshalBinary decodes binary form of SubscribeRequest into object.
+func (r *SubscribeRequest) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return fmt.Errorf("invalid subscribe request length: %d", len(data))
+	}
+	r.FromHeight = binary.BigEndian.Uint64(data)
+	return nil
+}
+
+// MarshalBinary encodes Update into binary form and returns it.
+// Every field is prefixed with its length (zero length means that optional State is not set).
+func (u *Update) MarshalBinary() ([]byte, error) {
+	blockBytes, err := u.Block.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	commitBytes, err := u.Commit.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	responsesBytes, err := u.Responses.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	var stateBytes []byte
+	if u.State != nil {
+		// tmjson is required to serialize public keys of validators
+		stateBytes, err = tmjson.Marshal(u.State)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields := [][]byte{blockBytes, commitBytes, responsesBytes, stateBytes}
+	size := 0
+	for _, f := range fields {
+		size += binary.MaxVarintLen64 + len(f)
+	}
+	buf := make([]byte, 0, size)
+	for _, f := range fields {
+		var prefix [binary.MaxVarintLen64]byte
+		n := binary.PutUvarint(prefix[:], uint64(len(f)))
+		buf = append(buf, prefix[:n]...)
+		buf = append(buf, f...)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes binary form of Update into object.
+func (u *Update) UnmarshalBinary(data []byte) error {
+	var fields [4][]byte
+	for i := range fields {
+		length, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < length {
+			return errors.New("malformed update")
+		}
+		fields[i] = data[n : n+int(length)]
+		data = data[n+int(length):]
+	}
+
+	u.Block = new(types.Block)
+	if err := u.Block.UnmarshalBinary(fields[0]); err != nil {
+		return fmt.Errorf("failed to decode block: %w", err)
+	}
+	u.Commit = new(types.Commit)
+	if err := u.Commit.UnmarshalBinary(fields[1]); err != nil {
+		return fmt.Errorf("failed to decode commit: %w", err)
+	}
+	u.Responses = new(tmstate.ABCIResponses)
+	if err := u.Responses.Unmarshal(fields[2]); err != nil {
+		return fmt.Errorf("failed to decode block responses: %w", err)
+	}
+	u.State = nil
+	if len(fields[3]) > 0 {
+		u.State = new(state.State)
+		if err := tmjson.Unmarshal(fields[3], u.State); err != nil {
+			return fmt.Errorf("failed to decode state: %w", err)
+		}
+	}
+	return nil
+}
+
+// codec encodes gRPC messages using their binary form (see encoding.BinaryMarshaler), as replication messages
+// reuse serialization of Optimint types.
+type codec struct{}
+
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("unsupported message type %T", v)
+	}
+	return m.MarshalBinary()
+}
+
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return fmt.Errorf("unsupported message type %T", v)
+	}
+	return m.UnmarshalBinary(data)
+}
+
+func (codec) Name() string {
+	return "optimint-replication"
+}
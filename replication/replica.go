@@ -0,0 +1,126 @@
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// DefaultRetryInterval is the default interval between attempts to (re)connect to the replication server.
+const DefaultRetryInterval = time.Second
+
+// AppliedFunc is called after block received from replication server is saved in the store.
+type AppliedFunc func(block *types.Block, responses *tmstate.ABCIResponses)
+
+// Replica receives blocks streamed by replication Server and saves them in the store, without execution.
+// Source node is trusted - blocks are not verified.
+type Replica struct {
+	source  string
+	store   store.Store
+	applied AppliedFunc
+	logger  log.Logger
+
+	// RetryInterval is the interval between attempts to (re)connect to the replication server.
+	RetryInterval time.Duration
+}
+
+// NewReplica creates replica of the node serving replication on given address (host:port).
+// Optional applied callback is invoked for every saved block.
+func NewReplica(source string, store store.Store, applied AppliedFunc, logger log.Logger) *Replica {
+	return &Replica{
+		source:        source,
+		store:         store,
+		applied:       applied,
+		logger:        logger,
+		RetryInterval: DefaultRetryInterval,
+	}
+}
+
+// Run receives and saves blocks until context is cancelled. Connection is re-established after errors,
+// resuming from the height of the store.
+func (r *Replica) Run(ctx context.Context) {
+	for {
+		err := r.replicate(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		r.logger.Error("replication interrupted", "source", r.source, "error", err)
+		select {
+		case <-time.After(r.RetryInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Replica) replicate(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, r.source, grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(codec{})))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := conn.NewStream(ctx, &serviceDesc.Streams[0], "/"+serviceName+"/"+subscribeName)
+	if err != nil {
+		return err
+	}
+	from := r.store.Height() + 1
+	if err := stream.SendMsg(&SubscribeRequest{FromHeight: from}); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	r.logger.Info("replicating blocks", "source", r.source, "fromHeight", from)
+
+	for {
+		var update Update
+		err := stream.RecvMsg(&update)
+		if errors.Is(err, io.EOF) {
+			return errors.New("stream closed by replication server")
+		}
+		if err != nil {
+			return err
+		}
+		if err := r.apply(&update); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *Replica) apply(update *Update) error {
+	height := update.Block.Header.Height
+	if expected := r.store.Height() + 1; height != expected {
+		return fmt.Errorf("unexpected block height %d, expected %d", height, expected)
+	}
+	if err := r.store.SaveBlock(update.Block, update.Commit); err != nil {
+		return fmt.Errorf("failed to save block: %w", err)
+	}
+	if err := r.store.SaveBlockResponses(height, update.Responses); err != nil {
+		return fmt.Errorf("failed to save block responses: %w", err)
+	}
+	if update.State != nil {
+		if err := r.store.UpdateState(*update.State); err != nil {
+			return fmt.Errorf("failed to save state: %w", err)
+		}
+	}
+	r.logger.Debug("replicated block", "height", height)
+	if r.applied != nil {
+		r.applied(update.Block, update.Responses)
+	}
+	return nil
+}
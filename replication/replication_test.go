@@ -0,0 +1,160 @@
+package replication
+
+import (
+	"context"
+	"crypto/rand"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+
+	"github.com/celestiaorg/optimint/log/test"
+	"github.com/celestiaorg/optimint/state"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestUpdateSerialization(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	block := getRandomBlock(7, 3)
+	update := &Update{
+		Block:     block,
+		Commit:    &types.Commit{Height: 7, HeaderHash: block.Header.Hash()},
+		Responses: getResponses(3),
+	}
+	for _, st := range []*state.State{nil, {ChainID: "test", LastBlockHeight: 7}} {
+		update.State = st
+		data, err := update.MarshalBinary()
+		require.NoError(err)
+		var decoded Update
+		require.NoError(decoded.UnmarshalBinary(data))
+		assert.Equal(update.Block, decoded.Block)
+		assert.Equal(update.Commit, decoded.Commit)
+		assert.Equal(update.Responses, decoded.Responses)
+		if st == nil {
+			assert.Nil(decoded.State)
+		} else if assert.NotNil(decoded.State) {
+			assert.Equal(st.ChainID, decoded.State.ChainID)
+			assert.Equal(st.LastBlockHeight, decoded.State.LastBlockHeight)
+		}
+
+		var truncated Update
+		assert.Error(truncated.UnmarshalBinary(data[:len(data)/2]))
+	}
+
+	var req SubscribeRequest
+	assert.Error(req.UnmarshalBinary([]byte{1, 2, 3}))
+}
+
+func TestReplication(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	logger := &test.TestLogger{T: t}
+
+	source := store.New(store.NewDefaultInMemoryKVStore())
+	saveBlock := func(height uint64) {
+		block := getRandomBlock(height, 2)
+		require.NoError(source.SaveBlock(block, &types.Commit{Height: height, HeaderHash: block.Header.Hash()}))
+		require.NoError(source.SaveBlockResponses(height, getResponses(2)))
+		require.NoError(source.UpdateState(state.State{ChainID: "test", LastBlockHeight: int64(height)}))
+	}
+	for h := uint64(1); h <= 3; h++ {
+		saveBlock(h)
+	}
+
+	srv := NewServer(source, logger)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+	defer srv.Stop()
+
+	var mtx sync.Mutex
+	var applied []uint64
+	replicaStore := store.New(store.NewDefaultInMemoryKVStore())
+	replica := NewReplica(listener.Addr().String(), replicaStore, func(block *types.Block, responses *tmstate.ABCIResponses) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		applied = append(applied, block.Header.Height)
+	}, logger)
+	replica.RetryInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go replica.Run(ctx)
+
+	// replica catches up with the source
+	require.Eventually(func() bool { return replicaStore.Height() == 3 }, 5*time.Second, 10*time.Millisecond)
+	st, err := replicaStore.LoadState()
+	require.NoError(err)
+	assert.Equal(int64(3), st.LastBlockHeight)
+
+	// new blocks are streamed after notification
+	saveBlock(4)
+	saveBlock(5)
+	srv.Notify(5)
+	require.Eventually(func() bool { return replicaStore.Height() == 5 }, 5*time.Second, 10*time.Millisecond)
+	for h := uint64(1); h <= 5; h++ {
+		expected, err := source.LoadBlock(h)
+		require.NoError(err)
+		replicated, err := replicaStore.LoadBlock(h)
+		require.NoError(err)
+		assert.Equal(expected, replicated)
+		responses, err := replicaStore.LoadBlockResponses(h)
+		require.NoError(err)
+		assert.Len(responses.DeliverTxs, 2)
+	}
+	st, err = replicaStore.LoadState()
+	require.NoError(err)
+	assert.Equal(int64(5), st.LastBlockHeight)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Equal([]uint64{1, 2, 3, 4, 5}, applied)
+}
+
+func getResponses(nTxs int) *tmstate.ABCIResponses {
+	responses := &tmstate.ABCIResponses{
+		BeginBlock: &abci.ResponseBeginBlock{},
+		EndBlock:   &abci.ResponseEndBlock{},
+	}
+	for i := 0; i < nTxs; i++ {
+		responses.DeliverTxs = append(responses.DeliverTxs, &abci.ResponseDeliverTx{Code: uint32(i), Data: getRandomBytes(8)})
+	}
+	return responses
+}
+
+func getRandomBlock(height uint64, nTxs int) *types.Block {
+	block := &types.Block{
+		Header: types.Header{
+			Height: height,
+		},
+		Data: types.Data{
+			Txs: make(types.Txs, nTxs),
+			IntermediateStateRoots: types.IntermediateStateRoots{
+				RawRootsList: make([][]byte, nTxs),
+			},
+		},
+	}
+
+	for i := 0; i < nTxs; i++ {
+		block.Data.Txs[i] = getRandomBytes(100)
+		block.Data.IntermediateStateRoots.RawRootsList[i] = getRandomBytes(32)
+	}
+
+	return block
+}
+
+func getRandomBytes(n int) []byte {
+	data := make([]byte, n)
+	_, _ = rand.Read(data)
+	return data
+}
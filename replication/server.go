@@ -0,0 +1,161 @@
+package replication
+
+import (
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/store"
+)
+
+const (
+	serviceName   = "optimint.replication.Replication"
+	subscribeName = "Subscribe"
+)
+
+// serviceDesc describes replication gRPC service. Messages are encoded with codec, so there is no generated code.
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*interface{})(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName:    subscribeName,
+		Handler:       subscribeHandler,
+		ServerStreams: true,
+	}},
+	Metadata: "replication",
+}
+
+func subscribeHandler(srv interface{}, stream grpc.ServerStream) error {
+	var req SubscribeRequest
+	if err := stream.RecvMsg(&req); err != nil {
+		return err
+	}
+	return srv.(*Server).subscribe(&req, stream)
+}
+
+// Server streams blocks (with block responses and state) saved in the store to read replicas.
+//
+// Blocks are streamed up to the height reported with Notify, so that state saved after the block is available.
+type Server struct {
+	store  store.Store
+	logger log.Logger
+
+	mtx sync.Mutex
+	// height is the height of the latest block that can be streamed
+	height uint64
+	// newBlock is closed (and replaced) when height changes
+	newBlock chan struct{}
+
+	grpcSrv *grpc.Server
+}
+
+// NewServer creates replication server of blocks from given store.
+func NewServer(store store.Store, logger log.Logger) *Server {
+	s := &Server{
+		store:    store,
+		logger:   logger,
+		height:   store.Height(),
+		newBlock: make(chan struct{}),
+	}
+	s.grpcSrv = grpc.NewServer(grpc.ForceServerCodec(codec{}))
+	s.grpcSrv.RegisterService(&serviceDesc, s)
+	return s
+}
+
+// Start starts serving replicas on given address (host:port).
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := s.Serve(listener); err != nil {
+			s.logger.Error("error while serving replication", "error", err)
+		}
+	}()
+	return nil
+}
+
+// Serve accepts replica connections on given listener. It blocks until server is stopped.
+func (s *Server) Serve(listener net.Listener) error {
+	s.logger.Info("serving replication", "address", listener.Addr())
+	return s.grpcSrv.Serve(listener)
+}
+
+// Stop stops the server, closing all replication streams.
+func (s *Server) Stop() {
+	s.grpcSrv.Stop()
+}
+
+// Notify informs the server that block at given height (and its state) is saved in the store.
+func (s *Server) Notify(height uint64) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if height > s.height {
+		s.height = height
+		close(s.newBlock)
+		s.newBlock = make(chan struct{})
+	}
+}
+
+func (s *Server) latest() (uint64, <-chan struct{}) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.height, s.newBlock
+}
+
+func (s *Server) subscribe(req *SubscribeRequest, stream grpc.ServerStream) error {
+	height := req.FromHeight
+	if base := s.store.Base(); height < base {
+		height = base
+	}
+	s.logger.Info("replica subscribed", "fromHeight", height)
+	for {
+		latest, newBlock := s.latest()
+		for ; height <= latest; height++ {
+			update, err := s.loadUpdate(height, latest)
+			if err != nil {
+				s.logger.Error("failed to load block for replication", "height", height, "error", err)
+				return err
+			}
+			if err := stream.SendMsg(update); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-newBlock:
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// loadUpdate loads block at given height from the store. State is attached only to the latest block.
+func (s *Server) loadUpdate(height, latest uint64) (*Update, error) {
+	block, err := s.store.LoadBlock(height)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := s.store.LoadCommit(height)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := s.store.LoadBlockResponses(height)
+	if err != nil {
+		return nil, err
+	}
+	update := &Update{Block: block, Commit: commit, Responses: responses}
+	if height == latest {
+		st, err := s.store.LoadState()
+		if err != nil {
+			return nil, err
+		}
+		// state may be already updated by the next block, in such case it's sent with the next block
+		if uint64(st.LastBlockHeight) == height {
+			update.State = &st
+		}
+	}
+	return update, nil
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// searchCacheSize is the maximum number of search results kept in cache.
+const searchCacheSize = 128
+
+type searchKind int
+
+const (
+	txSearch searchKind = iota
+	blockSearch
+)
+
+// searchKey identifies cached search results.
+type searchKey struct {
+	kind    searchKind
+	query   string
+	orderBy string
+}
+
+// searchCache is a LRU cache for sorted (but not paginated) results of TxSearch and BlockSearch.
+//
+// Results are valid only for single indexed height - whole cache is purged when height changes.
+// Cached values are shared between callers and must not be modified.
+type searchCache struct {
+	mtx    sync.Mutex
+	height int64
+	cache  *lru.Cache
+}
+
+func newSearchCache(size int) *searchCache {
+	cache, err := lru.New(size)
+	if err != nil {
+		// only possible if size is not positive
+		panic(err)
+	}
+	return &searchCache{cache: cache}
+}
+
+// get returns results cached for given key, if they were found at given indexed height.
+func (c *searchCache) get(height int64, key searchKey) (interface{}, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if height > c.height {
+		c.cache.Purge()
+		c.height = height
+		return nil, false
+	}
+	if height < c.height {
+		return nil, false
+	}
+	return c.cache.Get(key)
+}
+
+// add stores results found at given indexed height. Results of stale height are ignored.
+func (c *searchCache) add(height int64, key searchKey, results interface{}) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.height != height {
+		return
+	}
+	c.cache.Add(key, results)
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -13,14 +15,18 @@ import (
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/proxy"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	"github.com/tendermint/tendermint/types"
 
 	abciconv "github.com/celestiaorg/optimint/conv/abci"
+	"github.com/celestiaorg/optimint/journal"
 	"github.com/celestiaorg/optimint/mempool"
 	"github.com/celestiaorg/optimint/node"
+	"github.com/celestiaorg/optimint/store"
+	optypes "github.com/celestiaorg/optimint/types"
 )
 
 const (
@@ -29,26 +35,53 @@ const (
 
 	// TODO(tzdybal): make this configurable
 	subscribeTimeout = 5 * time.Second
+
+	// maxBroadcastBatchSize is the max number of transactions in a single BroadcastTxBatch call
+	maxBroadcastBatchSize = 1000
+	// broadcastBatchConcurrency is the max number of concurrent CheckTx calls of BroadcastTxBatch
+	broadcastBatchConcurrency = 16
 )
 
 var (
 	ErrConsensusStateNotAvailable = errors.New("consensus state not available in Optimint")
+	ErrTxTraceNotFound            = errors.New("transaction trace not found")
+	ErrBridgeEventsDisabled       = errors.New("extraction of bridge events is disabled")
+	ErrEventJournalDisabled       = errors.New("event journal is disabled")
+	ErrCheckpointsDisabled        = errors.New("checkpoints are disabled")
+	ErrNoBlocks                   = errors.New("no blocks available yet")
 )
 
+// ErrNotImplemented is returned by RPC methods that are not supported by Optimint.
+type ErrNotImplemented struct {
+	Method string
+}
+
+func (e *ErrNotImplemented) Error() string {
+	return e.Method + " is not implemented in Optimint"
+}
+
 var _ rpcclient.Client = &Client{}
 
 type Client struct {
 	*types.EventBus
 	config *config.RPCConfig
 
-	node *node.Node
+	node        *node.Node
+	searchCache *searchCache
+
+	// commits limits the number of concurrent BroadcastTxCommit calls
+	commits *commitQueue
+	// commitSeq is used to create unique subscriber names for BroadcastTxCommit calls
+	commitSeq uint64
 }
 
 func NewClient(node *node.Node) *Client {
 	return &Client{
-		EventBus: node.EventBus(),
-		config:   config.DefaultRPCConfig(),
-		node:     node,
+		EventBus:    node.EventBus(),
+		config:      config.DefaultRPCConfig(),
+		node:        node,
+		searchCache: newSearchCache(searchCacheSize),
+		commits:     newCommitQueue(node.RPCConfig().MaxPendingCommits, maxQueuedCommits),
 	}
 }
 
@@ -64,7 +97,12 @@ func (c *Client) ABCIQuery(ctx context.Context, path string, data tmbytes.HexByt
 	return c.ABCIQueryWithOptions(ctx, path, data, rpcclient.DefaultABCIQueryOptions)
 }
 
+// ABCIQueryWithOptions queries the application. Height is passed to the application - zero means the latest state.
+// Historical state is available only if application keeps it, and is guaranteed only by archive nodes.
 func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data tmbytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	if err := c.validateQueryHeight(opts.Height); err != nil {
+		return nil, err
+	}
 	resQuery, err := c.query().QuerySync(abci.RequestQuery{
 		Path:   path,
 		Data:   data,
@@ -78,13 +116,65 @@ func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data tmb
 	return &ctypes.ResultABCIQuery{Response: *resQuery}, nil
 }
 
+// validateQueryHeight checks if state at given height can be queried. Nodes that are not in archive mode don't
+// serve queries below the lowest available block.
+func (c *Client) validateQueryHeight(height int64) error {
+	if height < 0 {
+		return fmt.Errorf("height must be non-negative, got %d", height)
+	}
+	if height == 0 {
+		return nil
+	}
+	if latest := int64(c.node.Store.Height()); height > latest {
+		return fmt.Errorf("height %d must be less than or equal to the current blockchain height %d", height, latest)
+	}
+	if base := int64(c.node.Store.Base()); !c.node.Archive() && height < base {
+		return fmt.Errorf("height %d is not available, lowest height is %d (query an archive node)", height, base)
+	}
+	return nil
+}
+
+// ListSnapshots returns snapshots of application state, available for state sync.
+func (c *Client) ListSnapshots(ctx context.Context) (*ResultListSnapshots, error) {
+	resp, err := c.snapshot().ListSnapshotsSync(abci.RequestListSnapshots{})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]ResultSnapshot, len(resp.Snapshots))
+	for i, s := range resp.Snapshots {
+		snapshots[i] = ResultSnapshot{
+			Height:   s.Height,
+			Format:   s.Format,
+			Chunks:   s.Chunks,
+			Hash:     s.Hash,
+			Metadata: s.Metadata,
+		}
+	}
+	return &ResultListSnapshots{Snapshots: snapshots}, nil
+}
+
 // BroadcastTxCommit returns with the responses from CheckTx and DeliverTx.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_commit
+//
+// Number of concurrent calls is limited (see config.RPCConfig.MaxPendingCommits). Calls over the limit are queued,
+// and ErrTooManyPendingCommits is returned if the queue is full.
 func (c *Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	// wait for a free slot at most as long as for the transaction to be committed
+	queueCtx, cancelQueue := context.WithTimeout(ctx, c.config.TimeoutBroadcastTxCommit)
+	err := c.commits.acquire(queueCtx)
+	cancelQueue()
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		err = fmt.Errorf("%w: timed out waiting in queue; try again later or use broadcast_tx_sync", ErrTooManyPendingCommits)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer c.commits.release()
+
 	// This implementation corresponds to Tendermints implementation from rpc/core/mempool.go.
-	// ctx.RemoteAddr godoc: If neither HTTPReq nor WSConn is set, an empty string is returned.
-	// This code is a local client, so we can assume that subscriber is ""
-	subscriber := "" //ctx.RemoteAddr()
+	// Every call uses a separate subscriber, as the number of concurrent calls is limited by commit queue.
+	subscriber := fmt.Sprintf("broadcast_tx_commit-%d", atomic.AddUint64(&c.commitSeq, 1))
 
 	if c.EventBus.NumClients() >= c.config.MaxSubscriptionClients {
 		return nil, fmt.Errorf("max_subscription_clients %d reached", c.config.MaxSubscriptionClients)
@@ -109,10 +199,14 @@ func (c *Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.Re
 	}()
 
 	// add to mempool and wait for CheckTx result
+	txInfo, err := c.txInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
 	checkTxResCh := make(chan *abci.Response, 1)
 	err = c.node.Mempool.CheckTx(tx, func(res *abci.Response) {
 		checkTxResCh <- res
-	}, mempool.TxInfo{})
+	}, txInfo)
 	if err != nil {
 		c.Logger.Error("Error on broadcastTxCommit", "err", err)
 		return nil, fmt.Errorf("error on broadcastTxCommit: %v", err)
@@ -172,7 +266,11 @@ func (c *Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.Re
 // CheckTx nor DeliverTx results.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_async
 func (c *Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	err := c.node.Mempool.CheckTx(tx, nil, mempool.TxInfo{})
+	txInfo, err := c.txInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = c.node.Mempool.CheckTx(tx, nil, txInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -188,15 +286,10 @@ func (c *Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.Res
 // DeliverTx result.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_sync
 func (c *Client) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	resCh := make(chan *abci.Response, 1)
-	err := c.node.Mempool.CheckTx(tx, func(res *abci.Response) {
-		resCh <- res
-	}, mempool.TxInfo{})
+	r, err := c.checkTx(ctx, tx)
 	if err != nil {
 		return nil, err
 	}
-	res := <-resCh
-	r := res.GetCheckTx()
 
 	// gossip the transaction if it's in the mempool.
 	// Note: we have to do this here because, unlike the tendermint mempool reactor, there
@@ -222,6 +315,152 @@ func (c *Client) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.Resu
 	}, nil
 }
 
+// BroadcastTxBatch adds multiple transactions to the mempool, like BroadcastTxSync. CheckTx is performed concurrently
+// (with bounded parallelism), and accepted transactions are gossiped after all transactions are checked.
+// Results are returned in order of transactions; failure of a single transaction doesn't fail the whole batch.
+func (c *Client) BroadcastTxBatch(ctx context.Context, txs types.Txs) (*ResultBroadcastTxBatch, error) {
+	if len(txs) > maxBroadcastBatchSize {
+		return nil, fmt.Errorf("too many transactions in batch: %d, max: %d", len(txs), maxBroadcastBatchSize)
+	}
+
+	results := make([]ResultBroadcastTxBatchItem, len(txs))
+	sem := make(chan struct{}, broadcastBatchConcurrency)
+	var wg sync.WaitGroup
+	for i, tx := range txs {
+		results[i].Hash = tx.Hash()
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(res *ResultBroadcastTxBatchItem, tx types.Tx) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			r, err := c.checkTx(ctx, tx)
+			if err != nil {
+				res.Error = err.Error()
+				return
+			}
+			res.Code = r.Code
+			res.Data = r.Data
+			res.Log = r.Log
+			res.Codespace = r.Codespace
+		}(&results[i], tx)
+	}
+	wg.Wait()
+
+	for i, tx := range txs {
+		if results[i].Error != "" || results[i].Code != abci.CodeTypeOK {
+			continue
+		}
+		if err := c.node.P2P.GossipTx(ctx, tx); err != nil {
+			// see BroadcastTxSync
+			c.node.Mempool.RemoveTxByKey(mempool.TxKey(tx), true)
+			results[i].Error = fmt.Sprintf("failed to gossip transaction: %s", err)
+		}
+	}
+
+	return &ResultBroadcastTxBatch{Results: results}, nil
+}
+
+// BroadcastTxOrdered adds transaction to the mempool like BroadcastTxSync, and returns ordering receipt signed by
+// the sequencer, if first-come-first-served ordering is enabled (see types.OrderingReceipt).
+func (c *Client) BroadcastTxOrdered(ctx context.Context, tx types.Tx) (*ResultBroadcastTxOrdered, error) {
+	res, err := c.BroadcastTxSync(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	result := &ResultBroadcastTxOrdered{ResultBroadcastTx: *res}
+	if res.Code == abci.CodeTypeOK {
+		result.Receipt = c.node.OrderingReceipt(tx)
+	}
+	return result, nil
+}
+
+// CheckOrderingReceipt verifies signature of ordering receipt and checks if the chain respects it.
+// Transactions are looked up in transaction index, so only the most recent transactions can be checked
+// (see TxIndexConfig.RetainBlocks).
+func (c *Client) CheckOrderingReceipt(ctx context.Context, receipt *optypes.OrderingReceipt) (*ResultOrderingReceipt, error) {
+	s, err := c.node.Store.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	if receipt.ChainID != s.ChainID {
+		return nil, fmt.Errorf("receipt chain ID %q doesn't match %q", receipt.ChainID, s.ChainID)
+	}
+	if s.Validators.Size() == 0 {
+		return nil, errors.New("sequencer public key is not known")
+	}
+	if err := receipt.VerifySignature(s.Validators.GetProposer().PubKey); err != nil {
+		return nil, fmt.Errorf("invalid ordering receipt: %w", err)
+	}
+
+	result := &ResultOrderingReceipt{Status: ReceiptStatusPending, LastSequence: s.LastTxSequence}
+	res, err := c.node.TxIndexer.Get(receipt.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		if s.LastTxSequence >= receipt.Sequence {
+			result.Status = ReceiptStatusViolated
+		}
+		return result, nil
+	}
+	block, err := c.node.Store.LoadBlock(uint64(res.Height))
+	if err != nil {
+		return nil, err
+	}
+	result.Height = res.Height
+	if int(res.Index) < len(block.Data.TxSequences) {
+		result.Sequence = block.Data.TxSequences[res.Index]
+	}
+	if result.Sequence == receipt.Sequence {
+		result.Status = ReceiptStatusIncluded
+	} else {
+		result.Status = ReceiptStatusViolated
+	}
+	return result, nil
+}
+
+// checkTx adds transaction to the mempool and waits for CheckTx response.
+func (c *Client) checkTx(ctx context.Context, tx types.Tx) (*abci.ResponseCheckTx, error) {
+	txInfo, err := c.txInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resCh := make(chan *abci.Response, 1)
+	err = c.node.Mempool.CheckTx(tx, func(res *abci.Response) {
+		resCh <- res
+	}, txInfo)
+	if err != nil {
+		return nil, err
+	}
+	res := <-resCh
+	return res.GetCheckTx(), nil
+}
+
+type remoteAddrKey struct{}
+
+// ContextWithRemoteAddr returns context carrying remote address of RPC client. Transactions broadcasted with such
+// context are attributed to the client's host, so it can be rate limited after submitting too many invalid
+// transactions (see config.RPCConfig.MaxInvalidTxsPerMinute).
+func ContextWithRemoteAddr(ctx context.Context, addr string) context.Context {
+	return context.WithValue(ctx, remoteAddrKey{}, addr)
+}
+
+// txInfo returns information about origin of transactions broadcasted with given context. It returns
+// node.ErrTooManyInvalidTxs if the origin submitted too many invalid transactions recently.
+func (c *Client) txInfo(ctx context.Context) (mempool.TxInfo, error) {
+	addr, _ := ctx.Value(remoteAddrKey{}).(string)
+	txInfo := mempool.TxInfo{SenderRPCAddr: addr}
+	if err := c.node.TxOrigins.Check(txInfo.Origin()); err != nil {
+		return mempool.TxInfo{}, err
+	}
+	return txInfo, nil
+}
+
+// Subscribe subscribes to events matching the query. Up to outCapacity events (1 by default) are queued for the
+// subscriber, and the oldest events are dropped if the queue is full. If outCapacity is 0, publisher waits for the
+// subscriber to consume every event. Use SubscribeQueued to choose other overflow policy.
 func (c *Client) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
 	q, err := tmquery.New(query)
 	if err != nil {
@@ -232,23 +471,27 @@ func (c *Client) Subscribe(ctx context.Context, subscriber, query string, outCap
 	if len(outCapacity) > 0 {
 		outCap = outCapacity[0]
 	}
+	overflow := defaultOverflow(outCap)
 
-	var sub types.Subscription
-	if outCap > 0 {
-		sub, err = c.EventBus.Subscribe(ctx, subscriber, q, outCap)
-	} else {
-		sub, err = c.EventBus.SubscribeUnbuffered(ctx, subscriber, q)
-	}
+	sub, err := c.SubscribeQueued(ctx, subscriber, q, 0, outCap, overflow)
 	if err != nil {
-		return nil, fmt.Errorf("failed to subscribe: %w", err)
+		return nil, err
 	}
 
-	outc := make(chan ctypes.ResultEvent, outCap)
-	go c.eventsRoutine(sub, subscriber, q, outc)
+	outc := make(chan ctypes.ResultEvent)
+	go c.eventsRoutine(sub, subscriber, q, outCap, overflow, outc)
 
 	return outc, nil
 }
 
+// defaultOverflow returns overflow policy of subscriptions created with Subscribe.
+func defaultOverflow(outCap int) OverflowPolicy {
+	if outCap == 0 {
+		return OverflowBlock
+	}
+	return OverflowDropOldest
+}
+
 func (c *Client) Unsubscribe(ctx context.Context, subscriber, query string) error {
 	q, err := tmquery.New(query)
 	if err != nil {
@@ -263,12 +506,42 @@ func (c *Client) Genesis(_ context.Context) (*ctypes.ResultGenesis, error) {
 
 func (c *Client) GenesisChunked(context context.Context, id uint) (*ctypes.ResultGenesisChunk, error) {
 	// needs genesis provider
-	panic("GenesisChunked - not implemented!")
+	return nil, &ErrNotImplemented{Method: "genesis_chunked"}
 }
 
+// BlockchainInfo returns metadata of blocks in given height range (at most 20 blocks, in descending order).
 func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
-	// needs block store
-	panic("BlockchainInfo - not implemented!")
+	const limit int64 = 20
+
+	height := int64(c.node.Store.Height())
+	minHeight, maxHeight, err := filterMinMax(int64(c.node.Store.Base()), height, minHeight, maxHeight, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*types.BlockMeta, 0, maxHeight-minHeight+1)
+	for h := maxHeight; h >= minHeight; h-- {
+		meta, err := c.node.Store.LoadBlockMeta(uint64(h))
+		if err != nil {
+			return nil, err
+		}
+		header, err := abciconv.ToABCIHeader(&meta.Header)
+		if err != nil {
+			return nil, err
+		}
+		hash := meta.Header.Hash()
+		blocks = append(blocks, &types.BlockMeta{
+			BlockID:   types.BlockID{Hash: hash[:]},
+			BlockSize: meta.Size,
+			Header:    header,
+			NumTxs:    meta.NumTxs,
+		})
+	}
+
+	return &ctypes.ResultBlockchainInfo{
+		LastHeight: height,
+		BlockMetas: blocks,
+	}, nil
 }
 
 func (c *Client) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
@@ -294,6 +567,38 @@ func (c *Client) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
 	return &res, nil
 }
 
+// NodeNetInfo returns network info like NetInfo, along with information received from peers in handshake
+// (protocol version, genesis hash and height at the time of connection).
+func (c *Client) NodeNetInfo(ctx context.Context) (*ResultNetInfo, error) {
+	res := ResultNetInfo{
+		Listening: true,
+	}
+	for _, ma := range c.node.P2P.Addrs() {
+		res.Listeners = append(res.Listeners, ma.String())
+	}
+	peers := c.node.P2P.Peers()
+	res.NPeers = len(peers)
+	for _, peer := range peers {
+		res.Peers = append(res.Peers, ResultPeer{
+			Peer: ctypes.Peer{
+				NodeInfo:         peer.NodeInfo,
+				IsOutbound:       peer.IsOutbound,
+				ConnectionStatus: peer.ConnectionStatus,
+				RemoteIP:         peer.RemoteIP,
+			},
+			Handshake: peer.PeerInfo,
+		})
+	}
+
+	return &res, nil
+}
+
+// GossipStats returns per-peer statistics of gossip messages (received, rejected and ignored messages per topic, last
+// activity and local score), so misbehaving peers can be identified.
+func (c *Client) GossipStats(ctx context.Context) (*ResultGossipStats, error) {
+	return &ResultGossipStats{Peers: c.node.P2P.GossipStats()}, nil
+}
+
 func (c *Client) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
 	return nil, ErrConsensusStateNotAvailable
 }
@@ -303,8 +608,23 @@ func (c *Client) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusSta
 }
 
 func (c *Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
-	// needs state storage
-	panic("ConsensusParams - not implemented!")
+	state, err := c.node.Store.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	h := state.LastBlockHeight
+	if height != nil && *height != 0 {
+		h = *height
+		// consensus params updates are not supported yet, so params set at genesis (or by InitChain) are used for all heights
+		if h < state.LastHeightConsensusParamsChanged || h > state.LastBlockHeight {
+			return nil, fmt.Errorf("consensus params not available for height %d", h)
+		}
+	}
+
+	return &ctypes.ResultConsensusParams{
+		BlockHeight:     h,
+		ConsensusParams: state.ConsensusParams,
+	}, nil
 }
 
 func (c *Client) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
@@ -312,12 +632,9 @@ func (c *Client) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
 }
 
 func (c *Client) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
-	// needs block store
-	var h uint64
-	if height == nil {
-		h = c.node.Store.Height()
-	} else {
-		h = uint64(*height)
+	h, err := c.normalizeHeight(height)
+	if err != nil {
+		return nil, err
 	}
 
 	block, err := c.node.Store.LoadBlock(h)
@@ -367,11 +684,9 @@ func (c *Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBl
 }
 
 func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
-	var h uint64
-	if height == nil {
-		h = c.node.Store.Height()
-	} else {
-		h = uint64(*height)
+	h, err := c.normalizeHeight(height)
+	if err != nil {
+		return nil, err
 	}
 	resp, err := c.node.Store.LoadBlockResponses(h)
 	if err != nil {
@@ -388,49 +703,363 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 	}, nil
 }
 
-func (c *Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
-	// needs block store
-	panic("Commit - not implemented!")
+// BlockResultsDA returns block results along with DA layer inclusion information.
+// Blocks that are not (yet) known to be included in DA layer, or not final yet (see DA confirmation depth),
+// have soft status.
+func (c *Client) BlockResultsDA(ctx context.Context, height *int64) (*ResultBlockResultsDA, error) {
+	res, err := c.BlockResults(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ResultBlockResultsDA{ResultBlockResults: res, Status: BlockStatusSoft}
+	info, err := c.node.Store.LoadDAInfo(uint64(res.Height))
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.DAHeight = info.DAHeight
+	result.DATxHash = info.TxHash
+	if c.node.IsFinal(uint64(res.Height)) {
+		result.Status = BlockStatusFirm
+	}
+	return result, nil
 }
 
-func (c *Client) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
-	panic("Validators - not implemented!")
+// DAConfirmations returns DA layer confirmation status of blocks in given range of heights (see
+// BlockchainInfo for handling of range bounds). At most 100 blocks are returned.
+func (c *Client) DAConfirmations(ctx context.Context, minHeight, maxHeight int64) (*ResultDAConfirmations, error) {
+	const limit int64 = 100
+
+	height := int64(c.node.Store.Height())
+	minHeight, maxHeight, err := filterMinMax(int64(c.node.Store.Base()), height, minHeight, maxHeight, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmations := make([]DAConfirmation, 0, maxHeight-minHeight+1)
+	for h := minHeight; h <= maxHeight; h++ {
+		confirmation := DAConfirmation{Height: h, Status: DAStatusPending}
+		info, err := c.node.Store.LoadDAInfo(uint64(h))
+		if err != nil && !errors.Is(err, store.ErrKeyNotFound) {
+			return nil, err
+		}
+		if err == nil {
+			confirmation.DAHeight = info.DAHeight
+			confirmation.Commitment = info.Commitment
+			switch info.Confirmation {
+			case optypes.DAConfirmationConfirmed:
+				confirmation.Status = DAStatusConfirmed
+			case optypes.DAConfirmationUnavailable:
+				confirmation.Status = DAStatusUnavailable
+			default:
+				confirmation.Status = DAStatusIncluded
+			}
+		}
+		confirmations = append(confirmations, confirmation)
+	}
+
+	return &ResultDAConfirmations{
+		LastHeight:    height,
+		Confirmations: confirmations,
+	}, nil
 }
 
-func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
-	// needs block store, tx index (?)
-	panic("Tx - not implemented!")
+// DACost returns cumulative cost of block submissions to DA layer (tracked by aggregator), and fees paid for
+// inclusion of blocks in given range of heights (see BlockchainInfo for semantics of range).
+func (c *Client) DACost(ctx context.Context, minHeight, maxHeight int64) (*ResultDACost, error) {
+	const limit int64 = 100
+
+	height := int64(c.node.Store.Height())
+	minHeight, maxHeight, err := filterMinMax(int64(c.node.Store.Base()), height, minHeight, maxHeight, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ResultDACost{
+		LastHeight: height,
+		Blocks:     make([]DABlockCost, 0, maxHeight-minHeight+1),
+	}
+	spend, err := c.node.Store.LoadDASpend()
+	if err != nil && !errors.Is(err, store.ErrKeyNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		res.TotalFee = spend.TotalFee
+		res.Submissions = spend.Submissions
+	}
+	for h := minHeight; h <= maxHeight; h++ {
+		info, err := c.node.Store.LoadDAInfo(uint64(h))
+		if errors.Is(err, store.ErrKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		res.Blocks = append(res.Blocks, DABlockCost{Height: h, DAHeight: info.DAHeight, Fee: info.Fee})
+	}
+	return res, nil
 }
 
-func (c *Client) TxSearch(ctx context.Context, query string, prove bool, pagePtr, perPagePtr *int, orderBy string) (*ctypes.ResultTxSearch, error) {
-	q, err := tmquery.New(query)
+// BridgeEvents returns events selected for settlement bridges from block at given height, and their Merkle root.
+func (c *Client) BridgeEvents(ctx context.Context, height *int64) (*ResultBridgeEvents, error) {
+	if c.node.BridgeEvents == nil {
+		return nil, ErrBridgeEventsDisabled
+	}
+	h, err := c.normalizeHeight(height)
 	if err != nil {
 		return nil, err
 	}
+	events, err := c.node.BridgeEvents.Events(h)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bridge events of block %d: %w", h, err)
+	}
+	return &ResultBridgeEvents{Height: int64(h), Root: events.Root, Events: events.Events}, nil
+}
 
-	results, err := c.node.TxIndexer.Search(ctx, q)
+// BridgeEventProof returns Merkle proof of event with given index (in BridgeEvents result) in block at given height.
+func (c *Client) BridgeEventProof(ctx context.Context, height *int64, index int) (*ResultBridgeEventProof, error) {
+	if c.node.BridgeEvents == nil {
+		return nil, ErrBridgeEventsDisabled
+	}
+	h, err := c.normalizeHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := c.node.BridgeEvents.Proof(h, index)
 	if err != nil {
 		return nil, err
 	}
+	return &ResultBridgeEventProof{Height: int64(h), Root: proof.Root, Event: proof.Event, Proof: *proof.Proof}, nil
+}
 
-	// sort results (must be done before pagination)
-	switch orderBy {
-	case "desc":
-		sort.Slice(results, func(i, j int) bool {
-			if results[i].Height == results[j].Height {
-				return results[i].Index > results[j].Index
-			}
-			return results[i].Height > results[j].Height
-		})
-	case "asc", "":
-		sort.Slice(results, func(i, j int) bool {
-			if results[i].Height == results[j].Height {
-				return results[i].Index < results[j].Index
-			}
-			return results[i].Height < results[j].Height
+// EventJournal returns entries of the event journal starting from the cursor. If cursor is nil, entries following the
+// cursor acknowledged by the consumer are returned (or the oldest retained entries, for new consumers).
+func (c *Client) EventJournal(ctx context.Context, consumer string, cursor *journal.Cursor, limitPtr *int) (*ResultEventJournal, error) {
+	if c.node.EventJournal == nil {
+		return nil, ErrEventJournalDisabled
+	}
+	var from journal.Cursor
+	if cursor != nil {
+		from = *cursor
+	} else if consumer != "" {
+		var err error
+		from, err = c.node.EventJournal.Cursor(consumer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load cursor of consumer %q: %w", consumer, err)
+		}
+	}
+	batch, err := c.node.EventJournal.Read(from, validatePerPage(limitPtr))
+	if err != nil {
+		return nil, err
+	}
+	base, head := c.node.EventJournal.Heights()
+	return &ResultEventJournal{
+		Base:    int64(base),
+		Height:  int64(head),
+		Entries: batch.Entries,
+		Next:    batch.Next,
+	}, nil
+}
+
+// EventJournalAck acknowledges that the consumer processed entries of the event journal preceding the cursor.
+// Subsequent EventJournal calls of the consumer return entries starting from the cursor.
+func (c *Client) EventJournalAck(ctx context.Context, consumer string, cursor journal.Cursor) (*ResultEventJournalAck, error) {
+	if c.node.EventJournal == nil {
+		return nil, ErrEventJournalDisabled
+	}
+	if err := c.node.EventJournal.Ack(consumer, cursor); err != nil {
+		return nil, err
+	}
+	return &ResultEventJournalAck{Consumer: consumer, Cursor: cursor}, nil
+}
+
+// ChainStats returns cumulative and rolling chain statistics, computed incrementally by the node.
+func (c *Client) ChainStats(ctx context.Context) (*ResultChainStats, error) {
+	totals := c.node.ChainStats.Totals()
+	res := &ResultChainStats{
+		Since:       int64(totals.Since),
+		Height:      int64(totals.Height),
+		TotalBlocks: totals.Blocks,
+		TotalTxs:    totals.Txs,
+		TotalBytes:  totals.Bytes,
+	}
+	for _, w := range c.node.ChainStats.Windows() {
+		res.Windows = append(res.Windows, ChainStatsWindow{
+			Window:         w.Window.String(),
+			Blocks:         w.Blocks,
+			Txs:            w.Txs,
+			TPS:            w.TPS,
+			AvgBlockSize:   w.AvgBlockSize,
+			AvgDALatencyMs: float64(w.AvgDALatency) / float64(time.Millisecond),
 		})
-	default:
-		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
+	}
+	return res, nil
+}
+
+// Checkpoint returns signed checkpoint at given height, or the latest checkpoint if height is not specified.
+func (c *Client) Checkpoint(ctx context.Context, height *int64) (*ResultCheckpoint, error) {
+	if c.node.Checkpoints == nil {
+		return nil, ErrCheckpointsDisabled
+	}
+	var cp *optypes.Checkpoint
+	if height == nil || *height == 0 {
+		latest, err := c.node.Checkpoints.Latest()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load latest checkpoint: %w", err)
+		}
+		cp = latest
+	} else {
+		h, err := c.normalizeHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		cp, err = c.node.Checkpoints.Checkpoint(h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load checkpoint at height %d: %w", h, err)
+		}
+	}
+	return &ResultCheckpoint{
+		ChainID:   cp.ChainID,
+		Height:    int64(cp.Height),
+		BlockHash: cp.BlockHash[:],
+		AppHash:   cp.AppHash[:],
+		DAHeight:  cp.DAHeight,
+		Signature: cp.Signature,
+	}, nil
+}
+
+// Commit returns signed header of the block at given height (or the latest block).
+// Commit is canonical if it's already included in the next block (as LastCommit).
+func (c *Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	latest := c.node.Store.Height()
+	h, err := c.normalizeHeight(height)
+	if err != nil {
+		return nil, err
+	}
+
+	blockHeader, err := c.node.Store.LoadHeader(h)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := c.node.Store.LoadCommit(h)
+	if err != nil {
+		return nil, err
+	}
+	header, err := abciconv.ToABCIHeader(blockHeader)
+	if err != nil {
+		return nil, err
+	}
+	abciCommit := abciconv.ToABCICommit(commit)
+	// This assumes that we have only one signature
+	if len(abciCommit.Signatures) == 1 {
+		abciCommit.Signatures[0].ValidatorAddress = blockHeader.ProposerAddress
+	}
+	return ctypes.NewResultCommit(&header, abciCommit, h < latest), nil
+}
+
+// Validators returns the sequencer set at given height (or the latest height).
+// Historical sets are not stored, so only heights after the last change of the set are available.
+func (c *Client) Validators(ctx context.Context, heightPtr *int64, pagePtr, perPagePtr *int) (*ctypes.ResultValidators, error) {
+	state, err := c.node.Store.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	h := state.LastBlockHeight
+	if heightPtr != nil && *heightPtr != 0 {
+		h = *heightPtr
+		if err := validateHeight(h, uint64(state.LastBlockHeight)); err != nil {
+			return nil, err
+		}
+		if h < state.LastHeightValidatorsChanged {
+			return nil, fmt.Errorf("validators not available for height %d, lowest height is %d", h, state.LastHeightValidatorsChanged)
+		}
+	}
+
+	validators := state.Validators.Validators
+	totalCount := len(validators)
+	perPage := validatePerPage(perPagePtr)
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+	skipCount := validateSkipCount(page, perPage)
+	validators = validators[skipCount : skipCount+tmmath.MinInt(perPage, totalCount-skipCount)]
+
+	return &ctypes.ResultValidators{
+		BlockHeight: h,
+		Validators:  validators,
+		Count:       len(validators),
+		Total:       totalCount,
+	}, nil
+}
+
+func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	res, err := c.node.TxIndexer.Get(hash)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
+
+	var proof types.TxProof
+	if prove {
+		proof, err = c.txProof(res.Height, res.Index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ctypes.ResultTx{
+		Hash:     hash,
+		Height:   res.Height,
+		Index:    res.Index,
+		TxResult: res.Result,
+		Tx:       res.Tx,
+		Proof:    proof,
+	}, nil
+}
+
+func (c *Client) TxSearch(ctx context.Context, query string, prove bool, pagePtr, perPagePtr *int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*abci.TxResult
+	height := c.node.IndexerService.IndexedHeight()
+	key := searchKey{kind: txSearch, query: q.String(), orderBy: orderBy}
+	if cached, ok := c.searchCache.get(height, key); ok {
+		results = cached.([]*abci.TxResult)
+	} else {
+		results, err = c.node.TxIndexer.Search(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		// sort results (must be done before pagination)
+		switch orderBy {
+		case "desc":
+			sort.Slice(results, func(i, j int) bool {
+				if results[i].Height == results[j].Height {
+					return results[i].Index > results[j].Index
+				}
+				return results[i].Height > results[j].Height
+			})
+		case "asc", "":
+			sort.Slice(results, func(i, j int) bool {
+				if results[i].Height == results[j].Height {
+					return results[i].Index < results[j].Index
+				}
+				return results[i].Height < results[j].Height
+			})
+		default:
+			return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
+		}
+		c.searchCache.add(height, key, results)
 	}
 
 	// paginate results
@@ -450,10 +1079,12 @@ func (c *Client) TxSearch(ctx context.Context, query string, prove bool, pagePtr
 		r := results[i]
 
 		var proof types.TxProof
-		/*if prove {
-			block := nil                               //env.BlockStore.LoadBlock(r.Height)
-			proof = block.Data.Txs.Proof(int(r.Index)) // XXX: overflow on 32-bit machines
-		}*/
+		if prove {
+			proof, err = c.txProof(r.Height, r.Index)
+			if err != nil {
+				return nil, err
+			}
+		}
 
 		apiResults = append(apiResults, &ctypes.ResultTx{
 			Hash:     types.Tx(r.Tx).Hash(),
@@ -468,45 +1099,142 @@ func (c *Client) TxSearch(ctx context.Context, query string, prove bool, pagePtr
 	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
 }
 
+// txProof returns Merkle proof of inclusion of transaction with given index in the block at given height.
+// Proof can be verified against DataHash of block header (see VerifyTxProof).
+func (c *Client) txProof(height int64, index uint32) (types.TxProof, error) {
+	block, err := c.node.Store.LoadBlock(uint64(height))
+	if err != nil {
+		return types.TxProof{}, fmt.Errorf("failed to load block %d: %w", height, err)
+	}
+	if int(index) >= len(block.Data.Txs) {
+		return types.TxProof{}, fmt.Errorf("tx index %d out of range in block %d", index, height)
+	}
+	txs := make(types.Txs, len(block.Data.Txs))
+	for i := range block.Data.Txs {
+		txs[i] = types.Tx(block.Data.Txs[i])
+	}
+	return txs.Proof(int(index)), nil
+}
+
 // BlockSearch defines a method to search for a paginated set of blocks by
 // BeginBlock and EndBlock event search criteria.
-func (c *Client) BlockSearch(ctx context.Context, query string, page, perPage *int, orderBy string) (*ctypes.ResultBlockSearch, error) {
-	panic("BlockSearch - not implemented!")
+func (c *Client) BlockSearch(ctx context.Context, query string, pagePtr, perPagePtr *int, orderBy string) (*ctypes.ResultBlockSearch, error) {
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []int64
+	height := c.node.IndexerService.IndexedHeight()
+	key := searchKey{kind: blockSearch, query: q.String(), orderBy: orderBy}
+	if cached, ok := c.searchCache.get(height, key); ok {
+		results = cached.([]int64)
+	} else {
+		results, err = c.node.BlockIndexer.Search(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		// sort results (must be done before pagination)
+		switch orderBy {
+		case "desc":
+			sort.Slice(results, func(i, j int) bool { return results[i] > results[j] })
+		case "asc", "":
+			sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+		default:
+			return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
+		}
+		c.searchCache.add(height, key, results)
+	}
+
+	// paginate results
+	totalCount := len(results)
+	perPage := validatePerPage(perPagePtr)
+
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+
+	skipCount := validateSkipCount(page, perPage)
+	pageSize := tmmath.MinInt(perPage, totalCount-skipCount)
+
+	apiResults := make([]*ctypes.ResultBlock, 0, pageSize)
+	for i := skipCount; i < skipCount+pageSize; i++ {
+		h := results[i]
+		block, err := c.Block(ctx, &h)
+		if err != nil {
+			return nil, err
+		}
+		apiResults = append(apiResults, block)
+	}
+
+	return &ctypes.ResultBlockSearch{Blocks: apiResults, TotalCount: totalCount}, nil
 }
 
 func (c *Client) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
-	latest, err := c.node.Store.LoadBlock(c.node.Store.Height())
+	latest, err := c.node.Store.LoadHeader(c.node.Store.Height())
 	if err != nil {
 		// TODO(tzdybal): extract error
 		return nil, fmt.Errorf("failed to find latest block: %w", err)
 	}
+	earliest, err := c.node.Store.LoadHeader(c.node.Store.Base())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find earliest block: %w", err)
+	}
+
+	latestBlockHash := latest.Hash()
+	latestAppHash := latest.AppHash
+	latestHeight := latest.Height
+	latestBlockTimeNano := latest.Time
 
-	latestBlockHash := latest.Header.DataHash
-	latestAppHash := latest.Header.AppHash
-	latestHeight := latest.Header.Height
-	latestBlockTimeNano := latest.Header.Time
+	earliestBlockHash := earliest.Hash()
+	earliestAppHash := earliest.AppHash
+	earliestHeight := earliest.Height
+	earliestBlockTimeNano := earliest.Time
 
 	result := &ctypes.ResultStatus{
-		// TODO(tzdybal): NodeInfo, ValidatorInfo
+		// TODO(tzdybal): complete NodeInfo, ValidatorInfo
+		NodeInfo: p2p.DefaultNodeInfo{
+			ProtocolVersion: p2p.ProtocolVersion{App: c.node.AppInfo().AppVersion},
+			Network:         c.node.GetGenesis().ChainID,
+		},
 		SyncInfo: ctypes.SyncInfo{
-			LatestBlockHash:   latestBlockHash[:],
-			LatestAppHash:     latestAppHash[:],
-			LatestBlockHeight: int64(latestHeight),
-			LatestBlockTime:   time.Unix(0, int64(latestBlockTimeNano)),
+			LatestBlockHash:     latestBlockHash[:],
+			LatestAppHash:       latestAppHash[:],
+			LatestBlockHeight:   int64(latestHeight),
+			LatestBlockTime:     time.Unix(0, int64(latestBlockTimeNano)),
+			EarliestBlockHash:   earliestBlockHash[:],
+			EarliestAppHash:     earliestAppHash[:],
+			EarliestBlockHeight: int64(earliestHeight),
+			EarliestBlockTime:   time.Unix(0, int64(earliestBlockTimeNano)),
 			// TODO(tzdybal): add missing fields
-			//EarliestBlockHash:   earliestBlockHash,
-			//EarliestAppHash:     earliestAppHash,
-			//EarliestBlockHeight: earliestBlockHeight,
-			//EarliestBlockTime:   time.Unix(0, earliestBlockTimeNano),
 			//CatchingUp:          env.ConsensusReactor.WaitSync(),
 		},
 	}
 	return result, nil
 }
 
+// NodeStatus returns node status along with block production status.
+func (c *Client) NodeStatus(ctx context.Context) (*ResultStatus, error) {
+	status, err := c.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	appInfo := c.node.AppInfo()
+	return &ResultStatus{
+		ResultStatus:       status,
+		Stalled:            c.node.Stalled(),
+		FirmHeight:         int64(c.node.FirmHeight()),
+		Archive:            c.node.Archive(),
+		AppSoftwareVersion: appInfo.Version,
+		AppData:            appInfo.Data,
+	}, nil
+}
+
 func (c *Client) BroadcastEvidence(ctx context.Context, evidence types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	// needs evidence pool?
-	panic("BroadcastEvidence - not implemented!")
+	return nil, &ErrNotImplemented{Method: "broadcast_evidence"}
 }
 
 func (c *Client) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
@@ -530,6 +1258,11 @@ func (c *Client) UnconfirmedTxs(ctx context.Context, limitPtr *int) (*ctypes.Res
 		Txs:        txs}, nil
 }
 
+// MinGasPrice returns minimal gas price of transactions accepted to mempool, so wallets can set fees accordingly.
+func (c *Client) MinGasPrice(ctx context.Context) (*ResultMinGasPrice, error) {
+	return &ResultMinGasPrice{MinGasPrice: c.node.MinGasPrice().String()}, nil
+}
+
 func (c *Client) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	res, err := c.mempool().CheckTxSync(abci.RequestCheckTx{Tx: tx})
 	if err != nil {
@@ -538,19 +1271,127 @@ func (c *Client) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckT
 	return &ctypes.ResultCheckTx{ResponseCheckTx: *res}, nil
 }
 
-func (c *Client) eventsRoutine(sub types.Subscription, subscriber string, q tmpubsub.Query, outc chan<- ctypes.ResultEvent) {
+// StartAggregating resumes block production paused by StopAggregating. Node has to be an aggregator.
+func (c *Client) StartAggregating(ctx context.Context) (*ResultAggregating, error) {
+	if err := c.node.StartAggregating(); err != nil {
+		return nil, err
+	}
+	return &ResultAggregating{Aggregating: c.node.Aggregating()}, nil
+}
+
+// StopAggregating pauses block production, without stopping the node. Node has to be an aggregator.
+func (c *Client) StopAggregating(ctx context.Context) (*ResultAggregating, error) {
+	if err := c.node.StopAggregating(); err != nil {
+		return nil, err
+	}
+	return &ResultAggregating{Aggregating: c.node.Aggregating()}, nil
+}
+
+// DumpMempool returns all transactions from the mempool, in mempool order. Dumped transactions can be re-injected
+// with LoadMempool, to preserve them across planned restarts of the node.
+func (c *Client) DumpMempool(ctx context.Context) (*ResultDumpMempool, error) {
+	txs := c.node.Mempool.ReapMaxTxs(-1)
+	if txs == nil {
+		txs = types.Txs{}
+	}
+	return &ResultDumpMempool{Txs: txs}, nil
+}
+
+// LoadMempool adds transactions (e.g. dumped with DumpMempool) to the mempool, like BroadcastTxSync.
+// Transactions rejected by CheckTx, already known or invalid after restart are skipped and counted as rejected.
+func (c *Client) LoadMempool(ctx context.Context, txs types.Txs) (*ResultLoadMempool, error) {
+	res := &ResultLoadMempool{}
+	for _, tx := range txs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		r, err := c.BroadcastTxSync(ctx, tx)
+		if err != nil || r.Code != abci.CodeTypeOK {
+			res.Rejected++
+			continue
+		}
+		res.Added++
+	}
+	return res, nil
+}
+
+// TxStatus returns lifecycle status of a transaction with given hash, based on transaction index, mempool,
+// transaction traces (see TxTrace) and DA layer inclusion of blocks.
+func (c *Client) TxStatus(ctx context.Context, hash []byte) (*ResultTxStatus, error) {
+	result := &ResultTxStatus{Hash: hash, Status: TxStatusUnknown}
+	var height uint64
+	res, err := c.node.TxIndexer.Get(hash)
+	if err != nil {
+		return nil, err
+	}
+	trace, traced := c.node.TxTracer.Get(hash)
+	inMempool := false
+	if len(hash) == mempool.TxKeySize {
+		var key [mempool.TxKeySize]byte
+		copy(key[:], hash)
+		inMempool = c.node.Mempool.HasTxByKey(key)
+	}
+	switch {
+	case res != nil:
+		height = uint64(res.Height)
+	case !trace.Included.IsZero():
+		height = trace.Height
+	case inMempool:
+		result.Status = TxStatusPending
+		return result, nil
+	case traced && !trace.Accepted.IsZero():
+		result.Status = TxStatusEvicted
+		return result, nil
+	default:
+		return result, nil
+	}
+
+	result.Status = TxStatusCommitted
+	result.Height = int64(height)
+	info, err := c.node.Store.LoadDAInfo(height)
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !c.node.IsFinal(height) {
+		return result, nil
+	}
+	result.Status = TxStatusFinalized
+	result.DAHeight = info.DAHeight
+	return result, nil
+}
+
+// TxTrace returns lifecycle timestamps of a transaction with given hash.
+// Only the most recent transactions are traced.
+func (c *Client) TxTrace(ctx context.Context, hash []byte) (*ResultTxTrace, error) {
+	trace, ok := c.node.TxTracer.Get(hash)
+	if !ok {
+		return nil, ErrTxTraceNotFound
+	}
+	return &ResultTxTrace{
+		Hash:      hash,
+		Height:    int64(trace.Height),
+		Accepted:  timeOrNil(trace.Accepted),
+		Included:  timeOrNil(trace.Included),
+		Finalized: timeOrNil(trace.Finalized),
+	}, nil
+}
+
+// eventsRoutine converts events of queued subscription and passes them to outc. Overflow is handled by the
+// subscription queue, so outc is unbuffered.
+func (c *Client) eventsRoutine(sub types.Subscription, subscriber string, q tmpubsub.Query, capacity int, overflow OverflowPolicy, outc chan<- ctypes.ResultEvent) {
+	defer c.node.RecoverPanic("events routine")
 	for {
 		select {
 		case msg := <-sub.Out():
 			result := ctypes.ResultEvent{Query: q.String(), Data: msg.Data(), Events: msg.Events()}
-			if cap(outc) == 0 {
-				outc <- result
-			} else {
-				select {
-				case outc <- result:
-				default:
-					c.Logger.Error("wanted to publish ResultEvent, but out channel is full", "result", result, "query", result.Query)
-				}
+			select {
+			case outc <- result:
+			case <-sub.Cancelled():
+			case <-c.Quit():
+				return
 			}
 		case <-sub.Cancelled():
 			if sub.Err() == tmpubsub.ErrUnsubscribed {
@@ -558,7 +1399,7 @@ func (c *Client) eventsRoutine(sub types.Subscription, subscriber string, q tmpu
 			}
 
 			c.Logger.Error("subscription was cancelled, resubscribing...", "err", sub.Err(), "query", q.String())
-			sub = c.resubscribe(subscriber, q)
+			sub = c.resubscribe(subscriber, q, capacity, overflow)
 			if sub == nil { // client was stopped
 				return
 			}
@@ -569,14 +1410,14 @@ func (c *Client) eventsRoutine(sub types.Subscription, subscriber string, q tmpu
 }
 
 // Try to resubscribe with exponential backoff.
-func (c *Client) resubscribe(subscriber string, q tmpubsub.Query) types.Subscription {
+func (c *Client) resubscribe(subscriber string, q tmpubsub.Query, capacity int, overflow OverflowPolicy) types.Subscription {
 	attempts := 0
 	for {
 		if !c.IsRunning() {
 			return nil
 		}
 
-		sub, err := c.EventBus.Subscribe(context.Background(), subscriber, q)
+		sub, err := c.SubscribeQueued(context.Background(), subscriber, q, 0, capacity, overflow)
 		if err == nil {
 			return sub
 		}
@@ -602,6 +1443,13 @@ func (c *Client) snapshot() proxy.AppConnSnapshot {
 	return c.node.ProxyApp().Snapshot()
 }
 
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
 func validatePerPage(perPagePtr *int) int {
 	if perPagePtr == nil { // no per_page parameter
 		return defaultPerPage
@@ -616,6 +1464,31 @@ func validatePerPage(perPagePtr *int) int {
 	return perPage
 }
 
+// filterMinMax returns height range adjusted to available blocks and limit.
+// If min or max is 0, store base or latest height is used respectively.
+func filterMinMax(base, height, min, max, limit int64) (int64, int64, error) {
+	if min < 0 || max < 0 {
+		return min, max, errors.New("heights must be non-negative")
+	}
+
+	if min == 0 {
+		min = 1
+	}
+	if max == 0 {
+		max = height
+	}
+
+	max = tmmath.MinInt64(height, max)
+	min = tmmath.MaxInt64(base, min)
+	// limit number of returned blocks
+	min = tmmath.MaxInt64(min, max-limit+1)
+
+	if min > max {
+		return min, max, fmt.Errorf("min height %d can't be greater than max height %d", min, max)
+	}
+	return min, max, nil
+}
+
 func validatePage(pagePtr *int, perPage, totalCount int) (int, error) {
 	if perPage < 1 {
 		panic(fmt.Sprintf("zero or negative perPage: %d", perPage))
@@ -645,3 +1518,35 @@ func validateSkipCount(page, perPage int) int {
 
 	return skipCount
 }
+
+// normalizeHeight returns requested height, or the latest height if height is not specified.
+// Error is returned if height is outside of the range of heights available in the store.
+// Heights below the base of the store (e.g. before initial height of the chain) are rejected.
+func (c *Client) normalizeHeight(height *int64) (uint64, error) {
+	latest := c.node.Store.Height()
+	if height == nil || *height == 0 {
+		if latest == 0 {
+			return 0, ErrNoBlocks
+		}
+		return latest, nil
+	}
+	if err := validateHeight(*height, latest); err != nil {
+		return 0, err
+	}
+	h := uint64(*height)
+	if base := c.node.Store.Base(); h < base {
+		return 0, fmt.Errorf("height %d is not available, lowest height is %d", h, base)
+	}
+	return h, nil
+}
+
+// validateHeight checks if height is positive and not greater than the latest height, like Tendermint does.
+func validateHeight(height int64, latest uint64) error {
+	if height <= 0 {
+		return fmt.Errorf("height must be greater than 0, but got %d", height)
+	}
+	if uint64(height) > latest {
+		return fmt.Errorf("height %d must be less than or equal to the current blockchain height %d", height, latest)
+	}
+	return nil
+}
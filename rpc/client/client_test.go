@@ -3,7 +3,10 @@ package client
 import (
 	"context"
 	crand "crypto/rand"
+	"errors"
+	"math"
 	"math/rand"
+	"strconv"
 	"testing"
 	"time"
 
@@ -14,13 +17,21 @@ import (
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/libs/log"
-	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	"github.com/tendermint/tendermint/proxy"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 
+	"github.com/celestiaorg/optimint/block"
 	"github.com/celestiaorg/optimint/config"
+	abciconv "github.com/celestiaorg/optimint/conv/abci"
+	"github.com/celestiaorg/optimint/journal"
+	"github.com/celestiaorg/optimint/mempool"
 	"github.com/celestiaorg/optimint/mocks"
 	"github.com/celestiaorg/optimint/node"
 	"github.com/celestiaorg/optimint/state"
@@ -56,6 +67,90 @@ func TestInfo(t *testing.T) {
 	assert.Equal(expectedInfo, info.Response)
 }
 
+func TestABCIQueryHeight(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	mockApp, rpc := getRPC(t)
+	mockApp.On("Query", mock.Anything).Return(func(req abci.RequestQuery) abci.ResponseQuery {
+		return abci.ResponseQuery{Height: req.Height}
+	})
+	for height := uint64(3); height <= 5; height++ {
+		require.NoError(rpc.node.Store.SaveBlock(getRandomBlock(height, 1), &types.Commit{}))
+	}
+
+	query := func(height int64) (*ctypes.ResultABCIQuery, error) {
+		return rpc.ABCIQueryWithOptions(context.Background(), "/store", nil, rpcclient.ABCIQueryOptions{Height: height})
+	}
+	for _, height := range []int64{0, 3, 5} {
+		res, err := query(height)
+		require.NoError(err)
+		assert.Equal(height, res.Response.Height)
+	}
+	for _, height := range []int64{-1, 2, 6} {
+		_, err := query(height)
+		assert.Error(err, height)
+	}
+
+	status, err := rpc.NodeStatus(context.Background())
+	require.NoError(err)
+	assert.False(status.Archive)
+}
+
+func TestArchiveNode(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("Query", mock.Anything).Return(func(req abci.RequestQuery) abci.ResponseQuery {
+		return abci.ResponseQuery{Height: req.Height}
+	})
+	key, _, _ := crypto.GenerateEd25519Key(crand.Reader)
+	node, err := node.NewNode(context.Background(), config.NodeConfig{DALayer: "mock", Archive: true}, key, proxy.NewLocalClientCreator(app), getGenesis(key, t), log.TestingLogger())
+	require.NoError(err)
+	rpc := NewClient(node)
+	for height := uint64(3); height <= 5; height++ {
+		require.NoError(rpc.node.Store.SaveBlock(getRandomBlock(height, 1), &types.Commit{}))
+	}
+
+	// historical queries are passed to the application
+	res, err := rpc.ABCIQueryWithOptions(context.Background(), "/store", nil, rpcclient.ABCIQueryOptions{Height: 1})
+	require.NoError(err)
+	assert.Equal(int64(1), res.Response.Height)
+
+	status, err := rpc.NodeStatus(context.Background())
+	require.NoError(err)
+	assert.True(status.Archive)
+}
+
+func TestListSnapshots(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	expected := []*abci.Snapshot{
+		{Height: 100, Format: 1, Chunks: 5, Hash: []byte{1, 2, 3}},
+		{Height: 200, Format: 2, Chunks: 7, Hash: []byte{4, 5, 6}, Metadata: []byte("meta")},
+	}
+
+	mockApp, rpc := getRPC(t)
+	mockApp.On("ListSnapshots", abci.RequestListSnapshots{}).Return(abci.ResponseListSnapshots{Snapshots: expected})
+
+	res, err := rpc.ListSnapshots(context.Background())
+	require.NoError(err)
+	require.NotNil(res)
+	require.Len(res.Snapshots, len(expected))
+	for i, s := range res.Snapshots {
+		assert.Equal(expected[i].Height, s.Height)
+		assert.Equal(expected[i].Format, s.Format)
+		assert.Equal(expected[i].Chunks, s.Chunks)
+		assert.Equal(bytes.HexBytes(expected[i].Hash), s.Hash)
+		assert.Equal(bytes.HexBytes(expected[i].Metadata), s.Metadata)
+	}
+	mockApp.AssertExpectations(t)
+}
+
 func TestCheckTx(t *testing.T) {
 	assert := assert.New(t)
 
@@ -131,6 +226,80 @@ func TestBroadcastTxSync(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestBroadcastTxBatch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	mockApp, rpc := getRPC(t)
+	mockApp.On("CheckTx", abci.RequestCheckTx{Tx: []byte("bad")}).Return(abci.ResponseCheckTx{Code: 1, Log: "bad tx"})
+	mockApp.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	require.NoError(rpc.node.Start())
+	defer func() {
+		assert.NoError(rpc.node.Stop())
+	}()
+
+	_, err := rpc.BroadcastTxSync(context.Background(), tmtypes.Tx("known"))
+	require.NoError(err)
+
+	txs := tmtypes.Txs{tmtypes.Tx("bad"), tmtypes.Tx("known")}
+	for i := 0; i < 50; i++ {
+		txs = append(txs, tmtypes.Tx("tx"+strconv.Itoa(i)))
+	}
+	res, err := rpc.BroadcastTxBatch(context.Background(), txs)
+	require.NoError(err)
+	require.Len(res.Results, len(txs))
+	for i, r := range res.Results {
+		assert.Equal(bytes.HexBytes(txs[i].Hash()), r.Hash)
+	}
+	assert.EqualValues(1, res.Results[0].Code)
+	assert.Equal("bad tx", res.Results[0].Log)
+	assert.Contains(res.Results[1].Error, "already exists in cache")
+	for _, r := range res.Results[2:] {
+		assert.EqualValues(abci.CodeTypeOK, r.Code)
+		assert.Empty(r.Error)
+	}
+	assert.Equal(51, rpc.node.Mempool.Size())
+
+	_, err = rpc.BroadcastTxBatch(context.Background(), make(tmtypes.Txs, maxBroadcastBatchSize+1))
+	assert.Error(err)
+}
+
+func TestInvalidTxsLimit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("CheckTx", abci.RequestCheckTx{Tx: []byte("bad")}).Return(abci.ResponseCheckTx{Code: 1})
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	key, _, _ := crypto.GenerateEd25519Key(crand.Reader)
+	conf := config.NodeConfig{DALayer: "mock", RPC: config.RPCConfig{MaxInvalidTxsPerMinute: 1}}
+	n, err := node.NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), getGenesis(key, t), log.TestingLogger())
+	require.NoError(err)
+	rpc := NewClient(n)
+	require.NoError(rpc.node.Start())
+	defer func() {
+		assert.NoError(rpc.node.Stop())
+	}()
+
+	ctx := ContextWithRemoteAddr(context.Background(), "1.2.3.4:5678")
+	res, err := rpc.BroadcastTxSync(ctx, tmtypes.Tx("bad"))
+	require.NoError(err)
+	assert.EqualValues(1, res.Code)
+	assert.EqualValues(1, rpc.node.TxOrigins.InvalidTxs("rpc:1.2.3.4"))
+
+	// host exceeded the limit, but other clients are not affected
+	_, err = rpc.BroadcastTxSync(ctx, tmtypes.Tx("good"))
+	assert.ErrorIs(err, node.ErrTooManyInvalidTxs)
+	_, err = rpc.BroadcastTxAsync(ctx, tmtypes.Tx("good"))
+	assert.ErrorIs(err, node.ErrTooManyInvalidTxs)
+	_, err = rpc.BroadcastTxSync(ContextWithRemoteAddr(context.Background(), "5.6.7.8:5678"), tmtypes.Tx("good"))
+	assert.NoError(err)
+	_, err = rpc.BroadcastTxSync(context.Background(), tmtypes.Tx("good2"))
+	assert.NoError(err)
+}
+
 func TestBroadcastTxCommit(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -188,6 +357,57 @@ func TestBroadcastTxCommit(t *testing.T) {
 	require.NoError(err)
 }
 
+func TestCommitQueue(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	q := newCommitQueue(1, 2)
+	ctx := context.Background()
+	require.NoError(q.acquire(ctx))
+
+	// calls over the limit are queued, and get slots in order of arrival
+	order := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		i := i
+		go func() {
+			assert.NoError(q.acquire(ctx))
+			order <- i
+		}()
+		require.Eventually(func() bool {
+			_, queued := q.pending()
+			return queued == i+1
+		}, time.Second, time.Millisecond)
+	}
+
+	// queue is full
+	err := q.acquire(ctx)
+	assert.ErrorIs(err, ErrTooManyPendingCommits)
+	assert.Contains(err.Error(), "1 in progress, 2 queued")
+
+	q.release()
+	assert.Equal(0, <-order)
+	q.release()
+	assert.Equal(1, <-order)
+
+	// cancelled call leaves the queue
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(q.acquire(cancelCtx), context.Canceled)
+	active, queued := q.pending()
+	assert.Equal(1, active)
+	assert.Equal(0, queued)
+
+	q.release()
+	active, _ = q.pending()
+	assert.Equal(0, active)
+
+	// no limit
+	q = newCommitQueue(0, 0)
+	for i := 0; i < 10; i++ {
+		assert.NoError(q.acquire(ctx))
+	}
+}
+
 func TestGetBlock(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -214,6 +434,302 @@ func TestGetBlock(t *testing.T) {
 	require.NoError(err)
 }
 
+func TestCommit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t)
+
+	for height := uint64(1); height <= 3; height++ {
+		commit := &types.Commit{
+			Height:     height,
+			Signatures: []types.Signature{getRandomBytes(64)},
+		}
+		err := rpc.node.Store.SaveBlock(getRandomBlock(height, 1), commit)
+		require.NoError(err)
+	}
+
+	h := int64(2)
+	res, err := rpc.Commit(context.Background(), &h)
+	require.NoError(err)
+	require.NotNil(res)
+	block, err := rpc.node.Store.LoadBlock(2)
+	require.NoError(err)
+	assert.Equal(int64(2), res.Height)
+	assert.Equal(bytes.HexBytes(block.Header.AppHash[:]), res.Header.AppHash)
+	assert.Equal(bytes.HexBytes(block.Header.ProposerAddress), res.Commit.Signatures[0].ValidatorAddress)
+	assert.True(res.CanonicalCommit)
+
+	res, err = rpc.Commit(context.Background(), nil)
+	require.NoError(err)
+	require.NotNil(res)
+	assert.Equal(int64(3), res.Height)
+	assert.False(res.CanonicalCommit)
+
+	h = 10
+	res, err = rpc.Commit(context.Background(), &h)
+	assert.Error(err)
+	assert.Nil(res)
+}
+
+func TestHeightValidation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t)
+	ctx := context.Background()
+
+	_, err := rpc.Block(ctx, nil)
+	assert.ErrorIs(err, ErrNoBlocks)
+
+	for height := uint64(1); height <= 3; height++ {
+		require.NoError(rpc.node.Store.SaveBlock(getRandomBlock(height, 1), &types.Commit{Height: height}))
+		require.NoError(rpc.node.Store.SaveBlockResponses(height, &tmstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}))
+	}
+	valSet := tmtypes.NewValidatorSet([]*tmtypes.Validator{tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), 1)})
+	require.NoError(rpc.node.Store.UpdateState(state.State{LastBlockHeight: 3, LastHeightValidatorsChanged: 1, Validators: valSet}))
+
+	heights := func(h int64) *int64 { return &h }
+	cases := []struct {
+		name   string
+		height *int64
+		err    string
+	}{
+		{"nil", nil, ""},
+		{"zero", heights(0), ""},
+		{"valid", heights(2), ""},
+		{"tip", heights(3), ""},
+		{"negative", heights(-1), "height must be greater than 0, but got -1"},
+		{"above tip", heights(4), "height 4 must be less than or equal to the current blockchain height 3"},
+		{"huge", heights(math.MaxInt64), "must be less than or equal to the current blockchain height 3"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			block, blockErr := rpc.Block(ctx, c.height)
+			results, resultsErr := rpc.BlockResults(ctx, c.height)
+			commit, commitErr := rpc.Commit(ctx, c.height)
+			validators, validatorsErr := rpc.Validators(ctx, c.height, nil, nil)
+			if c.err == "" {
+				require.NoError(blockErr)
+				require.NoError(resultsErr)
+				require.NoError(commitErr)
+				require.NoError(validatorsErr)
+				expected := int64(3)
+				if c.height != nil && *c.height != 0 {
+					expected = *c.height
+				}
+				assert.Equal(expected, block.Block.Height)
+				assert.Equal(expected, results.Height)
+				assert.Equal(expected, commit.Height)
+				assert.Equal(expected, validators.BlockHeight)
+				return
+			}
+			for _, err := range []error{blockErr, resultsErr, commitErr, validatorsErr} {
+				require.Error(err)
+				assert.Contains(err.Error(), c.err)
+			}
+		})
+	}
+}
+
+func TestSubscribeFromHeight(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t)
+	require.NoError(rpc.node.Start())
+	defer func() {
+		assert.NoError(rpc.node.Stop())
+	}()
+	ctx := context.Background()
+
+	for height := uint64(1); height <= 3; height++ {
+		block := getRandomBlock(height, 2)
+		require.NoError(rpc.node.Store.SaveBlock(block, &types.Commit{Height: height}))
+		require.NoError(rpc.node.Store.SaveBlockResponses(height, &tmstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}, {Code: abci.CodeTypeOK}},
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}))
+	}
+
+	_, err := rpc.SubscribeFromHeight(ctx, "test", "tm.event='Tx'", 0)
+	assert.Error(err)
+
+	out, err := rpc.SubscribeFromHeight(ctx, "test", "tm.event='Tx'", 2, 10)
+	require.NoError(err)
+	next := func() tmtypes.EventDataTx {
+		select {
+		case ev := <-out:
+			data, ok := ev.Data.(tmtypes.EventDataTx)
+			require.True(ok)
+			return data
+		case <-time.After(time.Second):
+			require.FailNow("timeout waiting for event")
+		}
+		return tmtypes.EventDataTx{}
+	}
+
+	// events of historical blocks are replayed in order
+	for _, height := range []int64{2, 2, 3, 3} {
+		assert.Equal(height, next().Height)
+	}
+
+	// live events of replayed blocks are not duplicated
+	for _, height := range []int64{3, 4} {
+		require.NoError(rpc.node.EventBus().PublishEventTx(tmtypes.EventDataTx{TxResult: abci.TxResult{
+			Height: height,
+			Tx:     getRandomTx(),
+		}}))
+	}
+	assert.Equal(int64(4), next().Height)
+}
+
+func TestSubscribeQueued(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t)
+	require.NoError(rpc.node.Start())
+	defer func() {
+		assert.NoError(rpc.node.Stop())
+	}()
+	ctx := context.Background()
+	q := tmtypes.EventQueryTx
+
+	publish := func(heights ...int64) {
+		for _, height := range heights {
+			require.NoError(rpc.node.EventBus().PublishEventTx(tmtypes.EventDataTx{TxResult: abci.TxResult{
+				Height: height,
+				Tx:     getRandomTx(),
+			}}))
+		}
+	}
+	heights := func(sub tmtypes.Subscription) []int64 {
+		var res []int64
+		for len(sub.Out()) > 0 {
+			res = append(res, (<-sub.Out()).Data().(tmtypes.EventDataTx).Height)
+		}
+		return res
+	}
+
+	_, err := rpc.SubscribeQueued(ctx, "invalid", q, 0, 1, OverflowPolicy("forget"))
+	assert.Error(err)
+	_, err = rpc.SubscribeQueued(ctx, "invalid", q, 0, 0, OverflowDropOldest)
+	assert.Error(err)
+
+	dropSub, err := rpc.SubscribeQueued(ctx, "drop", q, 0, 2, OverflowDropOldest)
+	require.NoError(err)
+	cancelSub, err := rpc.SubscribeQueued(ctx, "cancel", q, 0, 2, OverflowCancel)
+	require.NoError(err)
+	blockSub, err := rpc.SubscribeQueued(ctx, "block", q, 0, 0, OverflowBlock)
+	require.NoError(err)
+
+	// publisher waits for blocking subscriber
+	done := make(chan struct{})
+	go func() {
+		publish(1, 2, 3, 4)
+		close(done)
+	}()
+	for _, height := range []int64{1, 2, 3, 4} {
+		select {
+		case msg := <-blockSub.Out():
+			assert.Equal(height, msg.Data().(tmtypes.EventDataTx).Height)
+		case <-time.After(time.Second):
+			require.FailNow("timeout waiting for event")
+		}
+	}
+	<-done
+
+	require.Eventually(func() bool {
+		dropSub.(*queuedSubscription).mtx.Lock()
+		defer dropSub.(*queuedSubscription).mtx.Unlock()
+		return dropSub.(*queuedSubscription).dropped == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal([]int64{3, 4}, heights(dropSub))
+
+	select {
+	case <-cancelSub.Cancelled():
+	case <-time.After(time.Second):
+		require.FailNow("subscription not cancelled")
+	}
+	assert.ErrorIs(cancelSub.Err(), ErrSubscriptionOverflow)
+	assert.Equal([]int64{1, 2}, heights(cancelSub))
+	assert.Eventually(func() bool {
+		return rpc.node.EventBus().NumClientSubscriptions("cancel") == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// unsubscribing cancels queued subscription
+	require.NoError(rpc.Unsubscribe(ctx, "drop", q.String()))
+	select {
+	case <-dropSub.Cancelled():
+	case <-time.After(time.Second):
+		require.FailNow("subscription not cancelled")
+	}
+	assert.ErrorIs(dropSub.Err(), tmpubsub.ErrUnsubscribed)
+}
+
+func TestEventJournal(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t)
+	_, err := rpc.EventJournal(context.Background(), "indexer", nil, nil)
+	assert.ErrorIs(err, ErrEventJournalDisabled)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	key, _, _ := crypto.GenerateEd25519Key(crand.Reader)
+	conf := config.NodeConfig{DALayer: "mock", EventJournal: config.EventJournalConfig{Enabled: true}}
+	n, err := node.NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), getGenesis(key, t), log.TestingLogger())
+	require.NoError(err)
+	rpc = NewClient(n)
+
+	for height := uint64(1); height <= 2; height++ {
+		block := getRandomBlock(height, 1)
+		require.NoError(rpc.node.Store.SaveBlock(block, &types.Commit{Height: height}))
+		require.NoError(rpc.node.Store.SaveBlockResponses(height, &tmstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{{Events: []abci.Event{{Type: "transfer"}}}},
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{Events: []abci.Event{{Type: "rewards"}}},
+		}))
+	}
+	require.NoError(rpc.node.EventJournal.Sync(2))
+
+	ctx := context.Background()
+	limit := 3
+	res, err := rpc.EventJournal(ctx, "indexer", nil, &limit)
+	require.NoError(err)
+	assert.EqualValues(1, res.Base)
+	assert.EqualValues(2, res.Height)
+	require.Len(res.Entries, 3)
+	assert.Equal(journal.Cursor{Height: 2, Sequence: 1}, res.Next)
+
+	// without acknowledgement, the same entries are returned
+	res2, err := rpc.EventJournal(ctx, "indexer", nil, &limit)
+	require.NoError(err)
+	assert.Equal(res.Entries, res2.Entries)
+
+	_, err = rpc.EventJournalAck(ctx, "indexer", res.Next)
+	require.NoError(err)
+	res, err = rpc.EventJournal(ctx, "indexer", nil, &limit)
+	require.NoError(err)
+	require.Len(res.Entries, 1)
+	assert.Equal("rewards", res.Entries[0].Type)
+	assert.Equal(journal.Cursor{Height: 3}, res.Next)
+
+	// explicit cursor
+	res, err = rpc.EventJournal(ctx, "", &journal.Cursor{Height: 2}, nil)
+	require.NoError(err)
+	assert.Len(res.Entries, 2)
+	_, err = rpc.EventJournalAck(ctx, "indexer", journal.Cursor{Height: 10})
+	assert.ErrorIs(err, journal.ErrInvalidCursor)
+}
+
 func TestGetBlockByHash(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -242,6 +758,138 @@ func TestGetBlockByHash(t *testing.T) {
 	require.NoError(err)
 }
 
+func TestBlockResultsDA(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t)
+
+	block := getRandomBlock(1, 10)
+	err := rpc.node.Store.SaveBlock(block, &types.Commit{})
+	require.NoError(err)
+	deliverTxs := make([]*abci.ResponseDeliverTx, len(block.Data.Txs))
+	for i := range deliverTxs {
+		deliverTxs[i] = &abci.ResponseDeliverTx{Code: abci.CodeTypeOK}
+	}
+	err = rpc.node.Store.SaveBlockResponses(1, &tmstate.ABCIResponses{
+		DeliverTxs: deliverTxs,
+		BeginBlock: &abci.ResponseBeginBlock{},
+		EndBlock:   &abci.ResponseEndBlock{},
+	})
+	require.NoError(err)
+
+	height := int64(1)
+	res, err := rpc.BlockResultsDA(context.Background(), &height)
+	require.NoError(err)
+	require.NotNil(res)
+	assert.Equal(height, res.Height)
+	assert.Len(res.TxsResults, 10)
+	assert.Equal(BlockStatusSoft, res.Status)
+	assert.Zero(res.DAHeight)
+	assert.Empty(res.DATxHash)
+
+	err = rpc.node.Store.SaveDAInfo(1, &types.DAInfo{DAHeight: 42, TxHash: []byte{1, 2, 3}})
+	require.NoError(err)
+
+	res, err = rpc.BlockResultsDA(context.Background(), &height)
+	require.NoError(err)
+	require.NotNil(res)
+	assert.Equal(BlockStatusFirm, res.Status)
+	assert.Equal(uint64(42), res.DAHeight)
+	assert.Equal(bytes.HexBytes{1, 2, 3}, res.DATxHash)
+}
+
+func TestDAConfirmations(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t)
+
+	for h := uint64(1); h <= 4; h++ {
+		require.NoError(rpc.node.Store.SaveBlock(getRandomBlock(h, 1), &types.Commit{}))
+	}
+	require.NoError(rpc.node.Store.SaveDAInfo(1, &types.DAInfo{DAHeight: 10, Commitment: []byte{1},
+		Confirmation: types.DAConfirmationConfirmed}))
+	require.NoError(rpc.node.Store.SaveDAInfo(2, &types.DAInfo{DAHeight: 11, Commitment: []byte{2},
+		Confirmation: types.DAConfirmationUnavailable}))
+	require.NoError(rpc.node.Store.SaveDAInfo(3, &types.DAInfo{DAHeight: 12}))
+
+	res, err := rpc.DAConfirmations(context.Background(), 0, 0)
+	require.NoError(err)
+	assert.EqualValues(4, res.LastHeight)
+	assert.Equal([]DAConfirmation{
+		{Height: 1, Status: DAStatusConfirmed, DAHeight: 10, Commitment: []byte{1}},
+		{Height: 2, Status: DAStatusUnavailable, DAHeight: 11, Commitment: []byte{2}},
+		{Height: 3, Status: DAStatusIncluded, DAHeight: 12},
+		{Height: 4, Status: DAStatusPending},
+	}, res.Confirmations)
+
+	res, err = rpc.DAConfirmations(context.Background(), 2, 3)
+	require.NoError(err)
+	require.Len(res.Confirmations, 2)
+	assert.EqualValues(2, res.Confirmations[0].Height)
+	assert.EqualValues(3, res.Confirmations[1].Height)
+
+	_, err = rpc.DAConfirmations(context.Background(), 3, 2)
+	assert.Error(err)
+}
+
+func TestDACost(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t)
+
+	for h := uint64(1); h <= 3; h++ {
+		require.NoError(rpc.node.Store.SaveBlock(getRandomBlock(h, 1), &types.Commit{}))
+	}
+	res, err := rpc.DACost(context.Background(), 0, 0)
+	require.NoError(err)
+	assert.EqualValues(3, res.LastHeight)
+	assert.Zero(res.TotalFee)
+	assert.Empty(res.Blocks)
+
+	require.NoError(rpc.node.Store.SaveDAInfo(1, &types.DAInfo{DAHeight: 10, Fee: 5}))
+	require.NoError(rpc.node.Store.SaveDAInfo(2, &types.DAInfo{DAHeight: 11, Fee: 7}))
+	require.NoError(rpc.node.Store.SaveDASpend(&types.DASpend{TotalFee: 15, Submissions: 3}))
+
+	res, err = rpc.DACost(context.Background(), 0, 0)
+	require.NoError(err)
+	assert.EqualValues(15, res.TotalFee)
+	assert.EqualValues(3, res.Submissions)
+	assert.Equal([]DABlockCost{
+		{Height: 1, DAHeight: 10, Fee: 5},
+		{Height: 2, DAHeight: 11, Fee: 7},
+	}, res.Blocks)
+
+	res, err = rpc.DACost(context.Background(), 2, 3)
+	require.NoError(err)
+	assert.Equal([]DABlockCost{{Height: 2, DAHeight: 11, Fee: 7}}, res.Blocks)
+}
+
+func TestChainStats(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t)
+
+	for h := uint64(1); h <= 3; h++ {
+		block := getRandomBlock(h, 2)
+		block.Header.Time = 1000 + h
+		rpc.node.ChainStats.BlockApplied(block)
+	}
+	res, err := rpc.ChainStats(context.Background())
+	require.NoError(err)
+	assert.EqualValues(1, res.Since)
+	assert.EqualValues(3, res.Height)
+	assert.EqualValues(3, res.TotalBlocks)
+	assert.EqualValues(6, res.TotalTxs)
+	require.Len(res.Windows, 3)
+	assert.Equal("1m0s", res.Windows[0].Window)
+	assert.EqualValues(6, res.Windows[0].Txs)
+	assert.InDelta(0.1, res.Windows[0].TPS, 1e-9)
+}
+
 func TestUnconfirmedTxs(t *testing.T) {
 	tx1 := tmtypes.Tx("tx1")
 	tx2 := tmtypes.Tx("another tx")
@@ -295,6 +943,91 @@ func TestUnconfirmedTxs(t *testing.T) {
 	}
 }
 
+func TestDumpAndLoadMempool(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	txs := tmtypes.Txs{tmtypes.Tx("tx1"), tmtypes.Tx("tx2"), tmtypes.Tx("tx3")}
+
+	mockApp, rpc := getRPC(t)
+	mockApp.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	require.NoError(rpc.node.Start())
+	for _, tx := range txs {
+		_, err := rpc.BroadcastTxSync(context.Background(), tx)
+		require.NoError(err)
+	}
+
+	dump, err := rpc.DumpMempool(context.Background())
+	require.NoError(err)
+	assert.Equal(txs, dump.Txs)
+	require.NoError(rpc.node.Stop())
+
+	// transactions are re-injected into mempool of restarted node
+	mockApp, rpc = getRPC(t)
+	mockApp.On("CheckTx", abci.RequestCheckTx{Tx: []byte("tx2")}).Return(abci.ResponseCheckTx{Code: 1})
+	mockApp.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	require.NoError(rpc.node.Start())
+	defer func() {
+		assert.NoError(rpc.node.Stop())
+	}()
+	_, err = rpc.BroadcastTxSync(context.Background(), txs[2])
+	require.NoError(err)
+
+	res, err := rpc.LoadMempool(context.Background(), dump.Txs)
+	require.NoError(err)
+	assert.Equal(1, res.Added)
+	assert.Equal(2, res.Rejected)
+
+	dump, err = rpc.DumpMempool(context.Background())
+	require.NoError(err)
+	assert.Equal(tmtypes.Txs{txs[2], txs[0]}, dump.Txs)
+}
+
+func TestMinGasPrice(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	fee := func(amount string) abci.ResponseCheckTx {
+		return abci.ResponseCheckTx{GasWanted: 100, Events: []abci.Event{{
+			Type: mempool.TxFeeEventType,
+			Attributes: []abci.EventAttribute{
+				{Key: []byte(mempool.TxFeeAmountKey), Value: []byte(amount)},
+				{Key: []byte(mempool.TxFeeDenomKey), Value: []byte("stake")},
+			},
+		}}}
+	}
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("CheckTx", abci.RequestCheckTx{Tx: []byte("cheap")}).Return(fee("1"))
+	app.On("CheckTx", abci.RequestCheckTx{Tx: []byte("expensive")}).Return(fee("10"))
+	key, _, _ := crypto.GenerateEd25519Key(crand.Reader)
+	conf := config.NodeConfig{DALayer: "mock", MinGasPrice: "0.1stake"}
+	n, err := node.NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), getGenesis(key, t), log.TestingLogger())
+	require.NoError(err)
+	rpc := NewClient(n)
+	require.NoError(n.Start())
+	defer func() {
+		assert.NoError(n.Stop())
+	}()
+
+	res, err := rpc.MinGasPrice(context.Background())
+	require.NoError(err)
+	assert.Equal("0.1stake", res.MinGasPrice)
+
+	for _, tx := range []string{"cheap", "expensive"} {
+		_, err := rpc.BroadcastTxSync(context.Background(), tmtypes.Tx(tx))
+		require.NoError(err)
+	}
+	txs, err := rpc.DumpMempool(context.Background())
+	require.NoError(err)
+	assert.Equal(tmtypes.Txs{tmtypes.Tx("expensive")}, txs.Txs)
+
+	conf.MinGasPrice = "stake"
+	_, err = node.NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), getGenesis(key, t), log.TestingLogger())
+	assert.Error(err)
+}
+
 func TestUnconfirmedTxsLimit(t *testing.T) {
 	t.Skip("Test disabled because of known bug")
 	// there's a bug in mempool implementation - count should be 1
@@ -349,6 +1082,244 @@ func TestConsensusState(t *testing.T) {
 	assert.ErrorIs(err, ErrConsensusStateNotAvailable)
 }
 
+func TestNotImplemented(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t)
+	require.NotNil(rpc)
+
+	var notImplemented *ErrNotImplemented
+	resp1, err := rpc.GenesisChunked(context.Background(), 0)
+	assert.Nil(resp1)
+	require.True(errors.As(err, &notImplemented))
+	assert.Equal("genesis_chunked", notImplemented.Method)
+
+	resp2, err := rpc.BroadcastEvidence(context.Background(), nil)
+	assert.Nil(resp2)
+	require.True(errors.As(err, &notImplemented))
+	assert.Equal("broadcast_evidence", notImplemented.Method)
+}
+
+func TestTxProof(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t)
+
+	block := getRandomBlock(1, 5)
+	err := rpc.node.Store.SaveBlock(block, &types.Commit{})
+	require.NoError(err)
+	tx := tmtypes.Tx(block.Data.Txs[2])
+	err = rpc.node.TxIndexer.Index(&abci.TxResult{Height: 1, Index: 2, Tx: tx})
+	require.NoError(err)
+
+	header, err := abciconv.ToABCIHeader(&block.Header)
+	require.NoError(err)
+
+	res, err := rpc.Tx(context.Background(), tx.Hash(), true)
+	require.NoError(err)
+	require.NotNil(res)
+	assert.NoError(VerifyTxProof(res, &header))
+
+	searchRes, err := rpc.TxSearch(context.Background(), "tx.height=1", true, nil, nil, "")
+	require.NoError(err)
+	require.Len(searchRes.Txs, 1)
+	assert.NoError(VerifyTxProof(searchRes.Txs[0], &header))
+
+	// proof is not returned without prove flag
+	res, err = rpc.Tx(context.Background(), tx.Hash(), false)
+	require.NoError(err)
+	assert.Error(VerifyTxProof(res, &header))
+
+	// tampered transaction
+	res, err = rpc.Tx(context.Background(), tx.Hash(), true)
+	require.NoError(err)
+	res.Tx = tmtypes.Tx(block.Data.Txs[1])
+	res.Proof.Data = res.Tx
+	assert.Error(VerifyTxProof(res, &header))
+
+	// untrusted header
+	otherHeader, err := abciconv.ToABCIHeader(&getRandomBlock(1, 5).Header)
+	require.NoError(err)
+	res, err = rpc.Tx(context.Background(), tx.Hash(), true)
+	require.NoError(err)
+	assert.Error(VerifyTxProof(res, &otherHeader))
+
+	_, err = rpc.Tx(context.Background(), getRandomBytes(32), false)
+	assert.Error(err)
+}
+
+func TestBlockEventSearch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{Events: []abci.Event{{
+		Type:       "mint",
+		Attributes: []abci.EventAttribute{{Key: []byte("inflation"), Value: []byte("0.13"), Index: true}},
+	}}})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{Events: []abci.Event{{
+		Type:       "rewards",
+		Attributes: []abci.EventAttribute{{Key: []byte("amount"), Value: []byte("10"), Index: true}},
+	}}})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+	key, _, _ := crypto.GenerateEd25519Key(crand.Reader)
+	conf := config.NodeConfig{DALayer: "mock", Aggregator: true, BlockManagerConfig: config.BlockManagerConfig{BlockTime: time.Second}}
+	// blocks are produced only on demand
+	clock := block.NewManualClock(time.Now())
+	n, err := node.NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), getGenesis(key, t), log.TestingLogger(), node.WithClock(clock))
+	require.NoError(err)
+	rpc := NewClient(n)
+	require.NoError(n.Start())
+	defer func() {
+		assert.NoError(n.Stop())
+	}()
+	clock.BlockUntil(1)
+
+	events, err := rpc.Subscribe(context.Background(), "test", "tm.event='NewBlock' AND mint.inflation EXISTS")
+	require.NoError(err)
+
+	require.NoError(n.ProduceBlockNow(context.Background()))
+	height := int64(n.Store.Height())
+	select {
+	case ev := <-events:
+		assert.Equal(height, ev.Data.(tmtypes.EventDataNewBlock).Block.Height)
+	case <-time.After(time.Second):
+		require.FailNow("timeout waiting for block event")
+	}
+	require.Eventually(func() bool {
+		return n.IndexerService.IndexedHeight() >= height
+	}, time.Second, 10*time.Millisecond)
+
+	for _, query := range []string{"mint.inflation EXISTS", "rewards.amount = 10", "block.height > 0 AND rewards.amount EXISTS"} {
+		res, err := rpc.BlockSearch(context.Background(), query, nil, nil, "")
+		require.NoError(err)
+		require.NotEmpty(res.Blocks, query)
+		assert.Equal(height, res.Blocks[len(res.Blocks)-1].Block.Height, query)
+	}
+	res, err := rpc.BlockSearch(context.Background(), "rewards.amount > 10", nil, nil, "")
+	require.NoError(err)
+	assert.Empty(res.Blocks)
+}
+
+func TestSearchCache(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := newSearchCache(2)
+	key1 := searchKey{kind: txSearch, query: "tx.height = 1"}
+	key2 := searchKey{kind: blockSearch, query: "tx.height = 1"}
+	key3 := searchKey{kind: txSearch, query: "tx.height = 1", orderBy: "desc"}
+
+	_, ok := cache.get(1, key1)
+	assert.False(ok)
+	cache.add(1, key1, []int64{1})
+	cache.add(1, key2, []int64{2})
+
+	res, ok := cache.get(1, key1)
+	assert.True(ok)
+	assert.Equal([]int64{1}, res)
+	res, ok = cache.get(1, key2)
+	assert.True(ok)
+	assert.Equal([]int64{2}, res)
+
+	// least recently used entry is evicted
+	cache.add(1, key3, []int64{3})
+	_, ok = cache.get(1, key1)
+	assert.False(ok)
+
+	// results of stale height are not returned nor stored
+	_, ok = cache.get(2, key2)
+	assert.False(ok)
+	_, ok = cache.get(2, key3)
+	assert.False(ok)
+	cache.add(1, key1, []int64{1})
+	_, ok = cache.get(2, key1)
+	assert.False(ok)
+	_, ok = cache.get(1, key1)
+	assert.False(ok)
+}
+
+func TestStatus(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t)
+
+	for height := uint64(3); height <= 5; height++ {
+		err := rpc.node.Store.SaveBlock(getRandomBlock(height, 1), &types.Commit{})
+		require.NoError(err)
+	}
+	earliest, err := rpc.node.Store.LoadBlock(3)
+	require.NoError(err)
+	latest, err := rpc.node.Store.LoadBlock(5)
+	require.NoError(err)
+
+	res, err := rpc.Status(context.Background())
+	require.NoError(err)
+	require.NotNil(res)
+	earliestHash, latestHash := earliest.Hash(), latest.Hash()
+	assert.Equal(int64(3), res.SyncInfo.EarliestBlockHeight)
+	assert.Equal(bytes.HexBytes(earliestHash[:]), res.SyncInfo.EarliestBlockHash)
+	assert.Equal(bytes.HexBytes(earliest.Header.AppHash[:]), res.SyncInfo.EarliestAppHash)
+	assert.Equal(int64(5), res.SyncInfo.LatestBlockHeight)
+	assert.Equal(bytes.HexBytes(latestHash[:]), res.SyncInfo.LatestBlockHash)
+}
+
+func TestStatusAppInfo(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	// application is queried only once, when node is created
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{Data: "kvstore", Version: "v1.2.3", AppVersion: 7, LastBlockHeight: 100}).Once()
+	key, _, _ := crypto.GenerateEd25519Key(crand.Reader)
+	n, err := node.NewNode(context.Background(), config.NodeConfig{DALayer: "mock"}, key, proxy.NewLocalClientCreator(app), getGenesis(key, t), log.TestingLogger())
+	require.NoError(err)
+	rpc := NewClient(n)
+	require.NoError(rpc.node.Store.SaveBlock(getRandomBlock(1, 1), &types.Commit{}))
+
+	for i := 0; i < 2; i++ {
+		res, err := rpc.NodeStatus(context.Background())
+		require.NoError(err)
+		assert.EqualValues(7, res.NodeInfo.ProtocolVersion.App)
+		assert.Equal("v1.2.3", res.AppSoftwareVersion)
+		assert.Equal("kvstore", res.AppData)
+	}
+	app.AssertExpectations(t)
+}
+
+func TestConsensusParams(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{
+		ConsensusParams: &abci.ConsensusParams{
+			Block: &abci.BlockParams{MaxBytes: 1024, MaxGas: 100},
+		},
+	})
+	key, _, _ := crypto.GenerateEd25519Key(crand.Reader)
+	node, err := node.NewNode(context.Background(), config.NodeConfig{DALayer: "mock"}, key, proxy.NewLocalClientCreator(app), getGenesis(key, t), log.TestingLogger())
+	require.NoError(err)
+	rpc := NewClient(node)
+
+	res, err := rpc.ConsensusParams(context.Background(), nil)
+	require.NoError(err)
+	assert.Equal(int64(0), res.BlockHeight)
+	assert.Equal(int64(1024), res.ConsensusParams.Block.MaxBytes)
+	assert.Equal(int64(100), res.ConsensusParams.Block.MaxGas)
+
+	height := int64(5)
+	res, err = rpc.ConsensusParams(context.Background(), &height)
+	assert.Error(err)
+	assert.Nil(res)
+}
+
 // copy-pasted from store/store_test.go
 func getRandomBlock(height uint64, nTxs int) *types.Block {
 	block := &types.Block{
@@ -377,11 +1348,8 @@ func getRandomBlock(height uint64, nTxs int) *types.Block {
 		block.Data.IntermediateStateRoots.RawRootsList = nil
 	}
 
-	tmprotoLC, err := tmtypes.CommitFromProto(&tmproto.Commit{})
-	if err != nil {
-		return nil
-	}
-	copy(block.Header.LastCommitHash[:], tmprotoLC.Hash())
+	block.Header.LastCommitHash = (&types.Commit{}).Hash()
+	block.Header.DataHash = block.Data.Hash()
 
 	return block
 }
@@ -402,8 +1370,9 @@ func getRPC(t *testing.T) (*mocks.Application, *Client) {
 	require := require.New(t)
 	app := &mocks.Application{}
 	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
 	key, _, _ := crypto.GenerateEd25519Key(crand.Reader)
-	node, err := node.NewNode(context.Background(), config.NodeConfig{DALayer: "mock"}, key, proxy.NewLocalClientCreator(app), &tmtypes.GenesisDoc{ChainID: "test"}, log.TestingLogger())
+	node, err := node.NewNode(context.Background(), config.NodeConfig{DALayer: "mock"}, key, proxy.NewLocalClientCreator(app), getGenesis(key, t), log.TestingLogger())
 	require.NoError(err)
 	require.NotNil(node)
 
@@ -419,6 +1388,7 @@ func TestMempool2Nodes(t *testing.T) {
 
 	app := &mocks.Application{}
 	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Twice()
 	app.On("CheckTx", abci.RequestCheckTx{Tx: []byte("bad")}).Return(abci.ResponseCheckTx{Code: 1})
 	app.On("CheckTx", abci.RequestCheckTx{Tx: []byte("good")}).Return(abci.ResponseCheckTx{Code: 0})
 	key1, _, _ := crypto.GenerateEd25519Key(crand.Reader)
@@ -426,13 +1396,15 @@ func TestMempool2Nodes(t *testing.T) {
 
 	id1, err := peer.IDFromPrivateKey(key1)
 	require.NoError(err)
+	// both nodes have to use the same genesis (completed with genesis time), to pass the handshake
+	genesis := getGenesis(key1, t)
 
 	node1, err := node.NewNode(context.Background(), config.NodeConfig{
 		DALayer: "mock",
 		P2P: config.P2PConfig{
 			ListenAddress: "/ip4/127.0.0.1/tcp/9001",
 		},
-	}, key1, proxy.NewLocalClientCreator(app), &tmtypes.GenesisDoc{ChainID: "test"}, log.TestingLogger())
+	}, key1, proxy.NewLocalClientCreator(app), genesis, log.TestingLogger())
 	require.NoError(err)
 	require.NotNil(node1)
 
@@ -442,7 +1414,7 @@ func TestMempool2Nodes(t *testing.T) {
 			ListenAddress: "/ip4/127.0.0.1/tcp/9002",
 			Seeds:         "/ip4/127.0.0.1/tcp/9001/p2p/" + id1.Pretty(),
 		},
-	}, key2, proxy.NewLocalClientCreator(app), &tmtypes.GenesisDoc{ChainID: "test"}, log.TestingLogger())
+	}, key2, proxy.NewLocalClientCreator(app), genesis, log.TestingLogger())
 	require.NoError(err)
 	require.NotNil(node1)
 
@@ -482,3 +1454,98 @@ func TestMempool2Nodes(t *testing.T) {
 
 	assert.Equal(node2.Mempool.TxsBytes(), int64(len("good")))
 }
+
+func TestTxTrace(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	mockApp, rpc := getRPC(t)
+	mockApp.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+
+	err := rpc.node.Start()
+	require.NoError(err)
+
+	tx := tmtypes.Tx("traced tx")
+	res, err := rpc.BroadcastTxSync(context.Background(), tx)
+	require.NoError(err)
+
+	trace, err := rpc.TxTrace(context.Background(), res.Hash)
+	require.NoError(err)
+	require.NotNil(trace)
+	assert.NotNil(trace.Accepted)
+	assert.Nil(trace.Included)
+	assert.Nil(trace.Finalized)
+
+	trace, err = rpc.TxTrace(context.Background(), tmtypes.Tx("unknown").Hash())
+	assert.ErrorIs(err, ErrTxTraceNotFound)
+	assert.Nil(trace)
+
+	err = rpc.node.Stop()
+	require.NoError(err)
+}
+
+func TestTxStatus(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("Info", mock.Anything).Return(abci.ResponseInfo{}).Once()
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+	key, _, _ := crypto.GenerateEd25519Key(crand.Reader)
+	conf := config.NodeConfig{DALayer: "mock", Aggregator: true, BlockManagerConfig: config.BlockManagerConfig{BlockTime: time.Second}}
+	// blocks are produced only on demand
+	clock := block.NewManualClock(time.Now())
+	n, err := node.NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(app), getGenesis(key, t), log.TestingLogger(), node.WithClock(clock))
+	require.NoError(err)
+	rpc := NewClient(n)
+	require.NoError(n.Start())
+	defer func() {
+		assert.NoError(n.Stop())
+	}()
+	clock.BlockUntil(1)
+
+	status := func(tx tmtypes.Tx) *ResultTxStatus {
+		res, err := rpc.TxStatus(context.Background(), tx.Hash())
+		require.NoError(err)
+		return res
+	}
+
+	assert.Equal(TxStatusUnknown, status(tmtypes.Tx("unknown")).Status)
+
+	included, evicted := tmtypes.Tx("included"), tmtypes.Tx("evicted")
+	for _, tx := range []tmtypes.Tx{included, evicted} {
+		_, err := rpc.BroadcastTxSync(context.Background(), tx)
+		require.NoError(err)
+		assert.Equal(TxStatusPending, status(tx).Status)
+	}
+
+	n.Mempool.RemoveTxByKey(mempool.TxKey(evicted), true)
+	assert.Equal(TxStatusEvicted, status(evicted).Status)
+
+	require.NoError(n.ProduceBlockNow(context.Background()))
+	res := status(included)
+	assert.Equal(TxStatusFinalized, res.Status)
+	assert.EqualValues(n.Store.Height(), res.Height)
+	assert.NotZero(res.DAHeight)
+}
+
+func getGenesis(sequencerKey crypto.PrivKey, t *testing.T) *tmtypes.GenesisDoc {
+	t.Helper()
+	rawKey, err := sequencerKey.GetPublic().Raw()
+	require.NoError(t, err)
+	pubKey := ed25519.PubKey(rawKey)
+	return &tmtypes.GenesisDoc{
+		ChainID: "test",
+		Validators: []tmtypes.GenesisValidator{{
+			Address: pubKey.Address(),
+			PubKey:  pubKey,
+			Power:   1,
+			Name:    "sequencer",
+		}},
+	}
+}
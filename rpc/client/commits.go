@@ -0,0 +1,103 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// maxQueuedCommits is the maximum number of BroadcastTxCommit calls waiting for a free slot.
+const maxQueuedCommits = 100
+
+// ErrTooManyPendingCommits is returned by BroadcastTxCommit if the limit of pending commits is reached and the call
+// can't be queued (or it waited in the queue for too long).
+var ErrTooManyPendingCommits = errors.New("too many pending broadcast_tx_commit calls")
+
+// commitQueue limits the number of concurrent BroadcastTxCommit calls, as each of them holds an event bus
+// subscription until transaction is committed. Calls over the limit wait for a free slot in a FIFO queue, and slots
+// are handed over in order of arrival, so that new calls can't starve the queued ones.
+type commitQueue struct {
+	limit     int
+	maxQueued int
+
+	mtx     sync.Mutex
+	active  int
+	waiting *list.List // of chan struct{}, closed when slot is handed over
+}
+
+// newCommitQueue creates commitQueue with given limit of concurrent calls (0 - no limit).
+func newCommitQueue(limit int, maxQueued int) *commitQueue {
+	return &commitQueue{
+		limit:     limit,
+		maxQueued: maxQueued,
+		waiting:   list.New(),
+	}
+}
+
+// acquire returns when a slot is available. It returns an error wrapping ErrTooManyPendingCommits if the queue is
+// full, or an error of ctx if it's done before the slot is available. Every successful call has to be followed by
+// release.
+func (q *commitQueue) acquire(ctx context.Context) error {
+	if q.limit == 0 {
+		return nil
+	}
+
+	q.mtx.Lock()
+	if q.active < q.limit && q.waiting.Len() == 0 {
+		q.active++
+		q.mtx.Unlock()
+		return nil
+	}
+	if q.waiting.Len() >= q.maxQueued {
+		active, queued := q.active, q.waiting.Len()
+		q.mtx.Unlock()
+		return fmt.Errorf("%w: %d in progress, %d queued; try again later or use broadcast_tx_sync",
+			ErrTooManyPendingCommits, active, queued)
+	}
+	ready := make(chan struct{})
+	e := q.waiting.PushBack(ready)
+	q.mtx.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	q.mtx.Lock()
+	select {
+	case <-ready:
+		// slot was handed over concurrently with cancellation
+		q.mtx.Unlock()
+		q.release()
+	default:
+		q.waiting.Remove(e)
+		q.mtx.Unlock()
+	}
+	return ctx.Err()
+}
+
+// release frees the slot, or hands it over to the first queued call.
+func (q *commitQueue) release() {
+	if q.limit == 0 {
+		return
+	}
+
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if e := q.waiting.Front(); e != nil {
+		q.waiting.Remove(e)
+		close(e.Value.(chan struct{}))
+		return
+	}
+	q.active--
+}
+
+// pending returns the number of calls holding a slot and waiting in the queue.
+func (q *commitQueue) pending() (active int, queued int) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q.active, q.waiting.Len()
+}
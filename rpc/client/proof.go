@@ -0,0 +1,32 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// VerifyTxProof checks if transaction returned by Tx or TxSearch (with prove=true) is included in the block with given
+// header. Header has to be obtained from a trusted source, then there is no need to trust the RPC node.
+func VerifyTxProof(res *ctypes.ResultTx, header *types.Header) error {
+	if res == nil || header == nil {
+		return errors.New("transaction and header are required")
+	}
+	if res.Height != header.Height {
+		return fmt.Errorf("transaction height %d doesn't match header height %d", res.Height, header.Height)
+	}
+	if !bytes.Equal(res.Proof.Data, res.Tx) {
+		return errors.New("proof doesn't match transaction")
+	}
+	if res.Proof.Proof.Index != int64(res.Index) {
+		return fmt.Errorf("proof index %d doesn't match transaction index %d", res.Proof.Proof.Index, res.Index)
+	}
+	err := res.Proof.Validate(header.DataHash)
+	if err != nil {
+		return fmt.Errorf("invalid transaction proof: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+	"go.uber.org/multierr"
+
+	"github.com/celestiaorg/optimint/state"
+)
+
+const (
+	// maxReplayBlocks is the max number of historical blocks, which events can be replayed by a single subscription.
+	maxReplayBlocks = 1000
+	// minReplayLiveCapacity is the min capacity of live subscription, buffering events published during replay.
+	minReplayLiveCapacity = 100
+
+	replaySubscriber = "replay"
+)
+
+// ErrReplayRangeTooLarge is returned if subscription requests replay of more than maxReplayBlocks blocks.
+var ErrReplayRangeTooLarge = fmt.Errorf("replay is limited to %d blocks", maxReplayBlocks)
+
+// errReplayStopped is returned by replayBlocks if replayed events are no longer consumed.
+var errReplayStopped = errors.New("replay stopped")
+
+// SubscribeFromHeight is like Subscribe, but before live events, events of stored blocks from given height up to the
+// current height are replayed. It allows subscribers to catch up with events published since they last processed
+// given height.
+func (c *Client) SubscribeFromHeight(ctx context.Context, subscriber, query string, fromHeight int64, outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	outCap := 1
+	if len(outCapacity) > 0 {
+		outCap = outCapacity[0]
+	}
+
+	if fromHeight <= 0 {
+		return nil, fmt.Errorf("height must be greater than 0, but got %d", fromHeight)
+	}
+	overflow := defaultOverflow(outCap)
+
+	sub, err := c.SubscribeQueued(ctx, subscriber, q, fromHeight, outCap, overflow)
+	if err != nil {
+		return nil, err
+	}
+
+	outc := make(chan ctypes.ResultEvent)
+	go c.eventsRoutine(sub, subscriber, q, outCap, overflow, outc)
+
+	return outc, nil
+}
+
+// SubscribeReplay subscribes to events matching the query, replaying events of stored blocks from given height, before
+// switching to live events. Historical events are rebuilt from stored blocks and block results, exactly like they were
+// published when blocks were applied. Height may be greater than the current height, in which case only live events
+// of blocks starting from given height are delivered.
+func (c *Client) SubscribeReplay(ctx context.Context, subscriber string, q tmpubsub.Query, fromHeight int64, outCapacity int) (types.Subscription, error) {
+	if fromHeight <= 0 {
+		return nil, fmt.Errorf("height must be greater than 0, but got %d", fromHeight)
+	}
+	from := uint64(fromHeight)
+	if base := c.node.Store.Base(); from < base {
+		return nil, fmt.Errorf("height %d is not available, lowest height is %d", from, base)
+	}
+
+	liveCap := outCapacity
+	if liveCap < minReplayLiveCapacity {
+		liveCap = minReplayLiveCapacity
+	}
+	live, err := c.EventBus.Subscribe(ctx, subscriber, q, liveCap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+	// events of blocks up to this height are replayed, events of following blocks are delivered by live subscription
+	to := c.node.Store.Height()
+	if to >= from && to-from >= maxReplayBlocks {
+		return nil, multierr.Append(ErrReplayRangeTooLarge, c.EventBus.Unsubscribe(context.Background(), subscriber, q))
+	}
+
+	sub := &replaySubscription{
+		out:       make(chan tmpubsub.Message, outCapacity),
+		cancelled: make(chan struct{}),
+	}
+	go c.replay(sub, live, q, from, to)
+	return sub, nil
+}
+
+func (c *Client) replay(sub *replaySubscription, live types.Subscription, q tmpubsub.Query, from, to uint64) {
+	send := func(msg tmpubsub.Message) bool {
+		select {
+		case sub.out <- msg:
+			return true
+		case <-live.Cancelled():
+			sub.cancel(live.Err())
+			return false
+		}
+	}
+
+	err := c.replayBlocks(q, from, to, send)
+	if errors.Is(err, errReplayStopped) {
+		return
+	}
+	if err != nil {
+		c.Logger.Error("failed to replay events", "query", q.String(), "from", from, "to", to, "error", err)
+		sub.cancel(err)
+		return
+	}
+
+	for {
+		select {
+		case msg := <-live.Out():
+			if height, ok := eventHeight(msg.Data()); ok && (height < int64(from) || height <= int64(to)) {
+				// not requested or already replayed
+				continue
+			}
+			if !send(msg) {
+				return
+			}
+		case <-live.Cancelled():
+			sub.cancel(live.Err())
+			return
+		}
+	}
+}
+
+// replayBlocks publishes events of stored blocks to the private event bus, and passes messages matching the query to
+// send. If send returns false, replay is stopped and errReplayStopped is returned.
+func (c *Client) replayBlocks(q tmpubsub.Query, from, to uint64, send func(tmpubsub.Message) bool) error {
+	bus := types.NewEventBus()
+	if err := bus.Start(); err != nil {
+		return err
+	}
+	sub, err := bus.SubscribeUnbuffered(context.Background(), replaySubscriber, q)
+	if err != nil {
+		return multierr.Append(err, bus.Stop())
+	}
+
+	done := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+		for h := from; h <= to && err == nil; h++ {
+			select {
+			case <-done:
+				err = errReplayStopped
+			default:
+				err = c.publishBlockEvents(bus, h)
+			}
+		}
+		errCh <- multierr.Append(err, bus.Stop())
+	}()
+
+	stopped := false
+	for {
+		select {
+		case msg := <-sub.Out():
+			// after replay is stopped, remaining messages are discarded, to let publishing goroutine finish
+			if !stopped && !send(msg) {
+				stopped = true
+				close(done)
+			}
+		case <-sub.Cancelled():
+			// subscription is cancelled when the bus is stopped, after all blocks are published
+			err := <-errCh
+			if stopped {
+				return errReplayStopped
+			}
+			return err
+		}
+	}
+}
+
+func (c *Client) publishBlockEvents(bus *types.EventBus, height uint64) error {
+	block, err := c.node.Store.LoadBlock(height)
+	if err != nil {
+		return fmt.Errorf("failed to load block %d: %w", height, err)
+	}
+	resp, err := c.node.Store.LoadBlockResponses(height)
+	if err != nil {
+		return fmt.Errorf("failed to load block results %d: %w", height, err)
+	}
+	return state.PublishBlockEvents(bus, resp, block)
+}
+
+// eventHeight returns height of the block that event was published for.
+func eventHeight(data interface{}) (int64, bool) {
+	switch d := data.(type) {
+	case types.EventDataNewBlock:
+		return d.Block.Height, true
+	case types.EventDataNewBlockHeader:
+		return d.Header.Height, true
+	case types.EventDataTx:
+		return d.Height, true
+	case types.EventDataNewEvidence:
+		return d.Height, true
+	}
+	return 0, false
+}
+
+// replaySubscription implements types.Subscription, delivering replayed events followed by live events.
+type replaySubscription struct {
+	out       chan tmpubsub.Message
+	cancelled chan struct{}
+
+	mtx sync.Mutex
+	err error
+}
+
+func (s *replaySubscription) Out() <-chan tmpubsub.Message {
+	return s.out
+}
+
+func (s *replaySubscription) Cancelled() <-chan struct{} {
+	return s.cancelled
+}
+
+func (s *replaySubscription) Err() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.err
+}
+
+func (s *replaySubscription) cancel(err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.err = err
+	close(s.cancelled)
+}
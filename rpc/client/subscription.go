@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/tendermint/tendermint/libs/log"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	"github.com/tendermint/tendermint/types"
+)
+
+// OverflowPolicy defines what happens to events published when the queue of a subscriber is full.
+type OverflowPolicy string
+
+const (
+	// OverflowBlock makes the publisher wait until the subscriber consumes queued events. It slows down the node
+	// (including block production) to the pace of the subscriber, so it should be used only by trusted, local
+	// subscribers.
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowDropOldest drops the oldest queued event to make room for the new one. Dropped events are counted and
+	// logged.
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowCancel cancels the subscription with ErrSubscriptionOverflow, so the subscriber knows that it missed
+	// events, and can resubscribe (for example with replay from the last processed height).
+	OverflowCancel OverflowPolicy = "cancel"
+)
+
+// ErrSubscriptionOverflow is the error of subscriptions cancelled because of OverflowCancel policy.
+var ErrSubscriptionOverflow = errors.New("subscription cancelled: queue of the subscriber is full")
+
+// ParseOverflowPolicy returns OverflowPolicy with given name.
+func ParseOverflowPolicy(s string) (OverflowPolicy, error) {
+	switch p := OverflowPolicy(s); p {
+	case OverflowBlock, OverflowDropOldest, OverflowCancel:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown overflow policy %q: expected %q, %q or %q", s, OverflowBlock, OverflowDropOldest,
+			OverflowCancel)
+	}
+}
+
+// SubscribeQueued subscribes to events matching the query. Events are queued for the subscriber up to given capacity,
+// and overflow defines what happens to events published when the queue is full. If fromHeight is greater than 0,
+// events of stored blocks are replayed before live events (see SubscribeReplay).
+//
+// Events are never dropped by the event bus itself - it waits for the subscription to queue every event.
+func (c *Client) SubscribeQueued(ctx context.Context, subscriber string, q tmpubsub.Query, fromHeight int64, capacity int, overflow OverflowPolicy) (types.Subscription, error) {
+	if _, err := ParseOverflowPolicy(string(overflow)); err != nil {
+		return nil, err
+	}
+	if capacity < 0 || (capacity == 0 && overflow != OverflowBlock) {
+		return nil, fmt.Errorf("invalid capacity %d of subscription with %s overflow policy", capacity, overflow)
+	}
+
+	var source types.Subscription
+	var err error
+	if fromHeight > 0 {
+		source, err = c.SubscribeReplay(ctx, subscriber, q, fromHeight, 0)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		source, err = c.EventBus.SubscribeUnbuffered(ctx, subscriber, q)
+		if err != nil {
+			return nil, fmt.Errorf("failed to subscribe: %w", err)
+		}
+	}
+
+	logger := c.Logger.With("subscriber", subscriber, "query", q.String())
+	sub := &queuedSubscription{
+		source:   source,
+		overflow: overflow,
+		unsubscribe: func() {
+			if err := c.EventBus.Unsubscribe(context.Background(), subscriber, q); err != nil {
+				logger.Error("failed to unsubscribe overflowed subscription", "error", err)
+			}
+		},
+		logger:    logger,
+		out:       make(chan tmpubsub.Message, capacity),
+		cancelled: make(chan struct{}),
+	}
+	go sub.run()
+	return sub, nil
+}
+
+// queuedSubscription forwards events from the source subscription to a bounded queue, applying overflow policy when
+// the queue is full.
+type queuedSubscription struct {
+	source      types.Subscription
+	overflow    OverflowPolicy
+	unsubscribe func()
+	logger      log.Logger
+
+	out       chan tmpubsub.Message
+	cancelled chan struct{}
+
+	mtx     sync.Mutex
+	err     error
+	dropped uint64
+}
+
+func (s *queuedSubscription) Out() <-chan tmpubsub.Message {
+	return s.out
+}
+
+func (s *queuedSubscription) Cancelled() <-chan struct{} {
+	return s.cancelled
+}
+
+func (s *queuedSubscription) Err() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.err
+}
+
+func (s *queuedSubscription) run() {
+	for {
+		select {
+		case msg := <-s.source.Out():
+			select {
+			case <-s.cancelled:
+				// overflowed, events are drained until unsubscribed
+				continue
+			default:
+			}
+			s.push(msg)
+		case <-s.source.Cancelled():
+			s.cancel(s.source.Err())
+			return
+		}
+	}
+}
+
+// push queues the event, applying overflow policy if the queue is full.
+func (s *queuedSubscription) push(msg tmpubsub.Message) {
+	if s.overflow == OverflowBlock {
+		select {
+		case s.out <- msg:
+		case <-s.source.Cancelled():
+		}
+		return
+	}
+
+	select {
+	case s.out <- msg:
+		return
+	default:
+	}
+
+	switch s.overflow {
+	case OverflowDropOldest:
+		select {
+		case <-s.out:
+			s.mtx.Lock()
+			s.dropped++
+			dropped := s.dropped
+			s.mtx.Unlock()
+			s.logger.Error("subscriber queue is full, dropped oldest event", "dropped", dropped)
+		default:
+			// event was consumed in the meantime
+		}
+		// push is the only sender, so there is a free slot now
+		s.out <- msg
+	case OverflowCancel:
+		s.logger.Error("subscriber queue is full, cancelling subscription")
+		s.cancel(ErrSubscriptionOverflow)
+		// event bus waits for this subscription to receive events, so unsubscribe can't block the loop
+		go s.unsubscribe()
+	}
+}
+
+func (s *queuedSubscription) cancel(err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	select {
+	case <-s.cancelled:
+		return
+	default:
+	}
+	s.err = err
+	close(s.cancelled)
+}
@@ -0,0 +1,288 @@
+package client
+
+import (
+	"time"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/bridge"
+	"github.com/celestiaorg/optimint/journal"
+	"github.com/celestiaorg/optimint/p2p"
+	optypes "github.com/celestiaorg/optimint/types"
+)
+
+// BlockStatus describes finality of a block.
+type BlockStatus string
+
+const (
+	// BlockStatusSoft is a status of block that is not yet confirmed to be included in DA layer.
+	BlockStatusSoft BlockStatus = "soft"
+	// BlockStatusFirm is a status of block that is included in DA layer (and is final).
+	BlockStatusFirm BlockStatus = "firm"
+)
+
+// ResultStatus extends ResultStatus with block production status of the node.
+type ResultStatus struct {
+	*ctypes.ResultStatus
+	// Stalled is true if block production or submission is stalled (only reported in aggregator mode).
+	Stalled bool `json:"stalled"`
+	// FirmHeight is the height of the latest block that is final in DA layer.
+	FirmHeight int64 `json:"firm_height"`
+	// Archive is true if node serves full history, including ABCI queries at historical heights.
+	Archive bool `json:"archive"`
+	// AppSoftwareVersion and AppData are reported by the application via ABCI Info, when the node is started. Protocol
+	// version of the application is reported in NodeInfo.
+	AppSoftwareVersion string `json:"app_software_version"`
+	AppData            string `json:"app_data"`
+}
+
+// ResultPeer is a connected peer, along with information received from peer in handshake.
+type ResultPeer struct {
+	ctypes.Peer
+	// Handshake is nil if handshake with the peer didn't complete.
+	Handshake *p2p.PeerInfo `json:"handshake,omitempty"`
+}
+
+// ResultNetInfo is the result of NodeNetInfo.
+type ResultNetInfo struct {
+	Listening bool         `json:"listening"`
+	Listeners []string     `json:"listeners"`
+	NPeers    int          `json:"n_peers"`
+	Peers     []ResultPeer `json:"peers"`
+}
+
+// ResultGossipStats contains statistics of gossip messages received from peers, sorted by peer ID.
+type ResultGossipStats struct {
+	Peers []p2p.PeerGossipStats `json:"peers"`
+}
+
+// ResultBroadcastTxBatchItem is the result of a single transaction of a batch.
+// Error is set if transaction couldn't be added to the mempool or gossiped (e.g. it's already in the mempool).
+type ResultBroadcastTxBatchItem struct {
+	ctypes.ResultBroadcastTx
+	Error string `json:"error,omitempty"`
+}
+
+// ResultBroadcastTxBatch contains results of all transactions of a batch, in order of transactions.
+type ResultBroadcastTxBatch struct {
+	Results []ResultBroadcastTxBatchItem `json:"results"`
+}
+
+// ResultBroadcastTxOrdered is the result of BroadcastTxOrdered.
+type ResultBroadcastTxOrdered struct {
+	ctypes.ResultBroadcastTx
+	// Receipt is signed by the sequencer (nil if transaction wasn't added to the mempool).
+	Receipt *optypes.OrderingReceipt `json:"receipt,omitempty"`
+}
+
+// ReceiptStatus describes whether the chain respects an ordering receipt.
+type ReceiptStatus string
+
+const (
+	// ReceiptStatusPending means that transaction is not included yet, but it still can be included in order.
+	ReceiptStatusPending ReceiptStatus = "pending"
+	// ReceiptStatusIncluded means that transaction is included with sequence number from the receipt.
+	ReceiptStatusIncluded ReceiptStatus = "included"
+	// ReceiptStatusViolated means that transaction is included with different sequence number, or it was skipped
+	// (transactions with higher sequence numbers are already included).
+	ReceiptStatusViolated ReceiptStatus = "violated"
+)
+
+// ResultOrderingReceipt is the result of ordering receipt verification.
+type ResultOrderingReceipt struct {
+	Status ReceiptStatus `json:"status"`
+	// Height is the height of the block containing transaction (if it's included).
+	Height int64 `json:"height,omitempty"`
+	// Sequence is the sequence number of included transaction.
+	Sequence uint64 `json:"sequence,omitempty"`
+	// LastSequence is the highest sequence number of transaction included in the chain.
+	LastSequence uint64 `json:"last_sequence"`
+}
+
+// ResultTxTrace contains lifecycle timestamps of a transaction.
+// Timestamps are nil if given stage was not reached (yet).
+type ResultTxTrace struct {
+	Hash      tmbytes.HexBytes `json:"hash"`
+	Height    int64            `json:"height"`
+	Accepted  *time.Time       `json:"accepted,omitempty"`
+	Included  *time.Time       `json:"included,omitempty"`
+	Finalized *time.Time       `json:"finalized,omitempty"`
+}
+
+// DAStatus describes confirmation of block inclusion in DA layer.
+type DAStatus string
+
+const (
+	// DAStatusPending is a status of block that is not yet included in DA layer.
+	DAStatusPending DAStatus = "pending"
+	// DAStatusIncluded is a status of block reported as included in DA layer, but not verified yet.
+	DAStatusIncluded DAStatus = "included"
+	// DAStatusConfirmed is a status of block confirmed to be available in DA layer.
+	DAStatusConfirmed DAStatus = "confirmed"
+	// DAStatusUnavailable is a status of block reported as included in DA layer, but not available there.
+	DAStatusUnavailable DAStatus = "unavailable"
+)
+
+// DAConfirmation contains DA layer confirmation status of a single block.
+type DAConfirmation struct {
+	Height     int64            `json:"height"`
+	Status     DAStatus         `json:"status"`
+	DAHeight   uint64           `json:"da_height,omitempty"`
+	Commitment tmbytes.HexBytes `json:"commitment,omitempty"`
+}
+
+// ResultDAConfirmations contains DA layer confirmation status of blocks in requested range, in ascending order.
+type ResultDAConfirmations struct {
+	LastHeight    int64            `json:"last_height"`
+	Confirmations []DAConfirmation `json:"confirmations"`
+}
+
+// DABlockCost contains cost of inclusion of a single block in DA layer.
+type DABlockCost struct {
+	Height   int64  `json:"height"`
+	DAHeight uint64 `json:"da_height"`
+	Fee      uint64 `json:"fee"`
+}
+
+// ResultDACost contains cumulative cost of block submissions to DA layer, and costs of blocks in requested range
+// that are included in DA layer, in ascending order.
+type ResultDACost struct {
+	LastHeight  int64         `json:"last_height"`
+	TotalFee    uint64        `json:"total_fee"`
+	Submissions uint64        `json:"submissions"`
+	Blocks      []DABlockCost `json:"blocks"`
+}
+
+// ResultBridgeEvents contains events selected for settlement bridges from a block, and their Merkle root.
+type ResultBridgeEvents struct {
+	Height int64            `json:"height"`
+	Root   tmbytes.HexBytes `json:"root"`
+	Events []bridge.Event   `json:"events"`
+}
+
+// ResultBridgeEventProof contains Merkle proof of event selected for settlement bridges.
+type ResultBridgeEventProof struct {
+	Height int64            `json:"height"`
+	Root   tmbytes.HexBytes `json:"root"`
+	Event  bridge.Event     `json:"event"`
+	Proof  merkle.Proof     `json:"proof"`
+}
+
+// ResultEventJournal contains entries of the event journal.
+type ResultEventJournal struct {
+	// Base is the lowest retained height, Height is the highest journaled height.
+	Base    int64           `json:"base"`
+	Height  int64           `json:"height"`
+	Entries []journal.Entry `json:"entries"`
+	// Next is the cursor following returned entries, to be acknowledged by consumer after processing them.
+	Next journal.Cursor `json:"next"`
+}
+
+// ResultEventJournalAck contains cursor acknowledged by the consumer of the event journal.
+type ResultEventJournalAck struct {
+	Consumer string         `json:"consumer"`
+	Cursor   journal.Cursor `json:"cursor"`
+}
+
+// ResultChainStats contains chain statistics for block explorers. Totals are counted from block at height Since.
+type ResultChainStats struct {
+	Since       int64              `json:"since"`
+	Height      int64              `json:"height"`
+	TotalBlocks uint64             `json:"total_blocks"`
+	TotalTxs    uint64             `json:"total_txs"`
+	TotalBytes  uint64             `json:"total_bytes"`
+	Windows     []ChainStatsWindow `json:"windows"`
+}
+
+// ChainStatsWindow contains chain statistics over a window of time, ending at the time of the latest block.
+// DA latency is measured only by aggregator.
+type ChainStatsWindow struct {
+	Window         string  `json:"window"`
+	Blocks         uint64  `json:"blocks"`
+	Txs            uint64  `json:"txs"`
+	TPS            float64 `json:"tps"`
+	AvgBlockSize   float64 `json:"avg_block_size"`
+	AvgDALatencyMs float64 `json:"avg_da_latency_ms"`
+}
+
+// ResultCheckpoint contains signed checkpoint of the chain state (see types.Checkpoint).
+type ResultCheckpoint struct {
+	ChainID   string           `json:"chain_id"`
+	Height    int64            `json:"height"`
+	BlockHash tmbytes.HexBytes `json:"block_hash"`
+	AppHash   tmbytes.HexBytes `json:"app_hash"`
+	DAHeight  uint64           `json:"da_height"`
+	Signature tmbytes.HexBytes `json:"signature"`
+}
+
+// ResultBlockResultsDA extends ResultBlockResults with information about block inclusion in DA layer.
+type ResultBlockResultsDA struct {
+	*ctypes.ResultBlockResults
+	Status   BlockStatus      `json:"status"`
+	DAHeight uint64           `json:"da_height,omitempty"`
+	DATxHash tmbytes.HexBytes `json:"da_tx_hash,omitempty"`
+}
+
+// TxStatus describes the lifecycle stage of a transaction.
+type TxStatus string
+
+const (
+	// TxStatusUnknown is a status of transaction that was never seen by the node (or its trace was already dropped).
+	TxStatusUnknown TxStatus = "unknown"
+	// TxStatusPending is a status of transaction waiting in the mempool.
+	TxStatusPending TxStatus = "pending"
+	// TxStatusEvicted is a status of transaction accepted to the mempool, but removed without inclusion in a block
+	// (e.g. it was invalidated by recheck).
+	TxStatusEvicted TxStatus = "evicted"
+	// TxStatusCommitted is a status of transaction included in a block that is not yet confirmed in DA layer.
+	TxStatusCommitted TxStatus = "committed"
+	// TxStatusFinalized is a status of transaction included in a block that is available in DA layer.
+	TxStatusFinalized TxStatus = "finalized"
+)
+
+// ResultTxStatus contains lifecycle status of a transaction.
+// Height is set for committed and finalized transactions, DAHeight only for finalized transactions.
+type ResultTxStatus struct {
+	Hash     tmbytes.HexBytes `json:"hash"`
+	Status   TxStatus         `json:"status"`
+	Height   int64            `json:"height,omitempty"`
+	DAHeight uint64           `json:"da_height,omitempty"`
+}
+
+// ResultMinGasPrice contains minimal gas price of transactions accepted to mempool (empty if not enforced).
+type ResultMinGasPrice struct {
+	MinGasPrice string `json:"min_gas_price"`
+}
+
+// ResultAggregating reports if block production is enabled (after it was paused or resumed).
+type ResultAggregating struct {
+	Aggregating bool `json:"aggregating"`
+}
+
+// ResultDumpMempool contains all transactions from the mempool.
+type ResultDumpMempool struct {
+	Txs types.Txs `json:"txs"`
+}
+
+// ResultLoadMempool reports the number of transactions added to the mempool and rejected.
+type ResultLoadMempool struct {
+	Added    int `json:"added"`
+	Rejected int `json:"rejected"`
+}
+
+// ResultSnapshot describes a snapshot of application state, available for state sync.
+type ResultSnapshot struct {
+	Height   uint64           `json:"height"`
+	Format   uint32           `json:"format"`
+	Chunks   uint32           `json:"chunks"`
+	Hash     tmbytes.HexBytes `json:"hash"`
+	Metadata tmbytes.HexBytes `json:"metadata"`
+}
+
+// ResultListSnapshots contains all snapshots available in the application.
+type ResultListSnapshots struct {
+	Snapshots []ResultSnapshot `json:"snapshots"`
+}
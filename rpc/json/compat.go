@@ -0,0 +1,118 @@
+package json
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/celestiaorg/optimint/config"
+)
+
+// compatFormatter rewrites responses (natively shaped like in Tendermint 0.34) into shapes expected by clients
+// of CometBFT 0.37/0.38.
+//
+// Differences handled:
+//   - 0.37+: event attribute keys and values are strings instead of base64 encoded bytes,
+//   - 0.38: BeginBlock and EndBlock events are replaced with FinalizeBlock events in block results,
+//   - 0.38: DeliverTx result of broadcast_tx_commit is renamed to tx_result.
+//
+// Encoding of event attributes can be also selected independently of the version, for clients that expect it.
+type compatFormatter struct {
+	version string
+	// rawEvents enables decoding of event attribute keys and values into strings
+	rawEvents bool
+}
+
+func newCompatFormatter(version, eventEncoding string) (*compatFormatter, error) {
+	f := &compatFormatter{version: version}
+	switch version {
+	case "", config.RPCCompat034:
+	case config.RPCCompat037, config.RPCCompat038:
+		f.rawEvents = true
+	default:
+		return nil, fmt.Errorf("unsupported RPC compatibility version: %q", version)
+	}
+	switch eventEncoding {
+	case "":
+	case config.EventEncodingBase64:
+		f.rawEvents = false
+	case config.EventEncodingRaw:
+		f.rawEvents = true
+	default:
+		return nil, fmt.Errorf("unsupported RPC event encoding: %q", eventEncoding)
+	}
+	return f, nil
+}
+
+// format returns result of given method in the configured shape.
+func (f *compatFormatter) format(method string, result interface{}) (interface{}, error) {
+	if !f.rawEvents && (f.version == "" || f.version == config.RPCCompat034) {
+		return result, nil
+	}
+
+	blob, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	// numbers are kept as is, to avoid loosing precision of 64-bit integers
+	dec := json.NewDecoder(bytes.NewReader(blob))
+	dec.UseNumber()
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+
+	if f.rawEvents {
+		decodeEventAttributes(tree)
+	}
+
+	obj, ok := tree.(map[string]interface{})
+	if f.version == config.RPCCompat038 && ok {
+		switch method {
+		case "block_results", "block_results_da":
+			finalize := make([]interface{}, 0)
+			for _, key := range []string{"begin_block_events", "end_block_events"} {
+				if events, ok := obj[key].([]interface{}); ok {
+					finalize = append(finalize, events...)
+				}
+				delete(obj, key)
+			}
+			obj["finalize_block_events"] = finalize
+		case "broadcast_tx_commit":
+			obj["tx_result"] = obj["deliver_tx"]
+			delete(obj, "deliver_tx")
+		}
+	}
+
+	return tree, nil
+}
+
+// decodeEventAttributes recursively replaces base64 encoded keys and values of event attributes with strings.
+func decodeEventAttributes(node interface{}) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if attrs, ok := n["attributes"].([]interface{}); ok {
+			for _, a := range attrs {
+				attr, ok := a.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				for _, field := range []string{"key", "value"} {
+					if s, ok := attr[field].(string); ok {
+						if decoded, err := base64.StdEncoding.DecodeString(s); err == nil {
+							attr[field] = string(decoded)
+						}
+					}
+				}
+			}
+		}
+		for _, v := range n {
+			decodeEventAttributes(v)
+		}
+	case []interface{}:
+		for _, v := range n {
+			decodeEventAttributes(v)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/celestiaorg/optimint/config"
+)
+
+func TestCompatFormatter(t *testing.T) {
+	event := func(typ string) abci.Event {
+		return abci.Event{
+			Type:       typ,
+			Attributes: []abci.EventAttribute{{Key: []byte("sender"), Value: []byte("alice"), Index: true}},
+		}
+	}
+	blockResults := &ctypes.ResultBlockResults{
+		Height:           9007199254740993, // 2^53 + 1, not representable as float64
+		TxsResults:       []*abci.ResponseDeliverTx{{Events: []abci.Event{event("tx")}}},
+		BeginBlockEvents: []abci.Event{event("begin")},
+		EndBlockEvents:   []abci.Event{event("end")},
+	}
+	txCommit := &ctypes.ResultBroadcastTxCommit{
+		DeliverTx: abci.ResponseDeliverTx{Events: []abci.Event{event("tx")}},
+	}
+
+	cases := []struct {
+		version      string
+		encoding     string
+		method       string
+		result       interface{}
+		contains     []string
+		notContains  []string
+		expectedSame bool
+	}{
+		{config.RPCCompat034, "", "block_results", blockResults, nil, nil, true},
+		{"", "", "block_results", blockResults, nil, nil, true},
+		{config.RPCCompat034, config.EventEncodingBase64, "block_results", blockResults, nil, nil, true},
+		{config.RPCCompat034, config.EventEncodingRaw, "block_results", blockResults,
+			[]string{`"height":9007199254740993`, `"key":"sender","value":"alice"`, `"begin_block_events"`},
+			[]string{`"finalize_block_events"`}, false},
+		{config.RPCCompat037, "", "block_results", blockResults,
+			[]string{`"height":9007199254740993`, `"key":"sender","value":"alice"`, `"begin_block_events"`, `"end_block_events"`},
+			[]string{`"finalize_block_events"`}, false},
+		{config.RPCCompat037, config.EventEncodingBase64, "block_results", blockResults,
+			[]string{`"key":"c2VuZGVy","value":"YWxpY2U="`}, []string{`"finalize_block_events"`}, false},
+		{config.RPCCompat038, "", "block_results", blockResults,
+			[]string{`"key":"sender","value":"alice"`, `"finalize_block_events":[{`, `"type":"begin"`, `"type":"end"`},
+			[]string{`"begin_block_events"`, `"end_block_events"`}, false},
+		{config.RPCCompat037, "", "broadcast_tx_commit", txCommit,
+			[]string{`"deliver_tx"`}, []string{`"tx_result"`}, false},
+		{config.RPCCompat038, "", "broadcast_tx_commit", txCommit,
+			[]string{`"tx_result"`}, []string{`"deliver_tx"`}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.version+"/"+c.encoding+"/"+c.method, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			f, err := newCompatFormatter(c.version, c.encoding)
+			require.NoError(err)
+			res, err := f.format(c.method, c.result)
+			require.NoError(err)
+			if c.expectedSame {
+				assert.Equal(c.result, res)
+				return
+			}
+			blob, err := json.Marshal(res)
+			require.NoError(err)
+			for _, s := range c.contains {
+				assert.Contains(string(blob), s)
+			}
+			for _, s := range c.notContains {
+				assert.NotContains(string(blob), s)
+			}
+		})
+	}
+
+	_, err := newCompatFormatter("0.36", "")
+	assert.Error(t, err)
+	_, err = newCompatFormatter(config.RPCCompat037, "hex")
+	assert.Error(t, err)
+}
@@ -0,0 +1,322 @@
+package json
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/rpc/client"
+)
+
+// Ethereum JSON-RPC facade (eth_* methods) makes it possible to connect standard Ethereum tooling to rollups running
+// an EVM as ABCI application. It's a view of node data in Ethereum shapes: transactions are passed to the application
+// as is, and transaction details known only to the application (sender, recipient, logs) are not reported.
+//
+// Methods use positional parameters, like Ethereum clients.
+
+// Ethereum block tags, accepted in place of block number.
+const (
+	ethTagLatest    = "latest"
+	ethTagPending   = "pending"
+	ethTagEarliest  = "earliest"
+	ethTagSafe      = "safe"
+	ethTagFinalized = "finalized"
+)
+
+var (
+	// ethEmptyUnclesHash is the Keccak-256 hash of RLP encoded empty list.
+	ethEmptyUnclesHash = "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347"
+	ethEmptyBloom      = "0x" + strings.Repeat("00", 256)
+)
+
+type EthBlockNumberArgs struct {
+}
+type EthGetBlockByNumberArgs struct {
+	Block   string `json:"block"`
+	FullTxs bool   `json:"full_txs"`
+}
+type EthSendRawTransactionArgs struct {
+	Data string `json:"data"`
+}
+type EthGetTransactionReceiptArgs struct {
+	Hash string `json:"hash"`
+}
+
+func (a *EthGetBlockByNumberArgs) UnmarshalJSON(data []byte) error {
+	return unmarshalEthParams(data, &a.Block, &a.FullTxs)
+}
+
+func (a *EthSendRawTransactionArgs) UnmarshalJSON(data []byte) error {
+	return unmarshalEthParams(data, &a.Data)
+}
+
+func (a *EthGetTransactionReceiptArgs) UnmarshalJSON(data []byte) error {
+	return unmarshalEthParams(data, &a.Hash)
+}
+
+// EthBlock is a block in Ethereum JSON-RPC shape. Transactions contain hashes or EthTransaction objects.
+type EthBlock struct {
+	Number           string        `json:"number"`
+	Hash             string        `json:"hash"`
+	ParentHash       string        `json:"parentHash"`
+	Nonce            string        `json:"nonce"`
+	Sha3Uncles       string        `json:"sha3Uncles"`
+	LogsBloom        string        `json:"logsBloom"`
+	TransactionsRoot string        `json:"transactionsRoot"`
+	StateRoot        string        `json:"stateRoot"`
+	ReceiptsRoot     string        `json:"receiptsRoot"`
+	Miner            string        `json:"miner"`
+	Difficulty       string        `json:"difficulty"`
+	TotalDifficulty  string        `json:"totalDifficulty"`
+	ExtraData        string        `json:"extraData"`
+	Size             string        `json:"size"`
+	GasLimit         string        `json:"gasLimit"`
+	GasUsed          string        `json:"gasUsed"`
+	Timestamp        string        `json:"timestamp"`
+	Transactions     []interface{} `json:"transactions"`
+	Uncles           []string      `json:"uncles"`
+}
+
+// EthTransaction is a transaction in Ethereum JSON-RPC shape. Input contains raw transaction bytes.
+type EthTransaction struct {
+	Hash             string `json:"hash"`
+	BlockHash        string `json:"blockHash"`
+	BlockNumber      string `json:"blockNumber"`
+	TransactionIndex string `json:"transactionIndex"`
+	Input            string `json:"input"`
+}
+
+// EthReceipt is a transaction receipt in Ethereum JSON-RPC shape. Status is 0x1 if DeliverTx response code is OK.
+type EthReceipt struct {
+	TransactionHash   string        `json:"transactionHash"`
+	TransactionIndex  string        `json:"transactionIndex"`
+	BlockHash         string        `json:"blockHash"`
+	BlockNumber       string        `json:"blockNumber"`
+	CumulativeGasUsed string        `json:"cumulativeGasUsed"`
+	GasUsed           string        `json:"gasUsed"`
+	EffectiveGasPrice string        `json:"effectiveGasPrice"`
+	ContractAddress   *string       `json:"contractAddress"`
+	Logs              []interface{} `json:"logs"`
+	LogsBloom         string        `json:"logsBloom"`
+	Type              string        `json:"type"`
+	Status            string        `json:"status"`
+}
+
+func (s *service) EthBlockNumber(req *http.Request, args *EthBlockNumberArgs) (*string, error) {
+	status, err := s.client.NodeStatus(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	n := ethQuantity(uint64(status.SyncInfo.LatestBlockHeight))
+	return &n, nil
+}
+
+// EthGetBlockByNumber returns block with given number or tag (null if block doesn't exist).
+// Pending block is not known to the node, so latest block is returned instead.
+func (s *service) EthGetBlockByNumber(req *http.Request, args *EthGetBlockByNumberArgs) (*EthBlock, error) {
+	ctx := req.Context()
+	status, err := s.client.NodeStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var height int64
+	switch args.Block {
+	case ethTagLatest, ethTagPending, "":
+		height = status.SyncInfo.LatestBlockHeight
+	case ethTagEarliest:
+		height = status.SyncInfo.EarliestBlockHeight
+	case ethTagSafe, ethTagFinalized:
+		height = status.FirmHeight
+	default:
+		n, err := parseEthQuantity(args.Block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block number: %w", err)
+		}
+		height = int64(n)
+	}
+	if height <= 0 || height > status.SyncInfo.LatestBlockHeight {
+		return nil, nil
+	}
+
+	block, err := s.client.Block(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.client.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	params, err := s.client.ConsensusParams(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	return newEthBlock(block, results, params.ConsensusParams.Block.MaxGas, args.FullTxs), nil
+}
+
+// EthSendRawTransaction adds transaction to the mempool (see BroadcastTxSync) and returns its hash.
+func (s *service) EthSendRawTransaction(req *http.Request, args *EthSendRawTransactionArgs) (*string, error) {
+	tx, err := decodeEthData(args.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction data: %w", err)
+	}
+	res, err := s.client.BroadcastTxSync(txContext(req), tx)
+	if err != nil {
+		return nil, err
+	}
+	if res.Code != abci.CodeTypeOK {
+		return nil, fmt.Errorf("transaction rejected (code %d): %s", res.Code, res.Log)
+	}
+	hash := ethData(res.Hash)
+	return &hash, nil
+}
+
+// EthGetTransactionReceipt returns receipt of committed transaction, using transaction index (null if transaction is
+// not committed or not indexed).
+func (s *service) EthGetTransactionReceipt(req *http.Request, args *EthGetTransactionReceiptArgs) (*EthReceipt, error) {
+	ctx := req.Context()
+	hash, err := decodeEthData(args.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hash: %w", err)
+	}
+	status, err := s.client.TxStatus(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if status.Status != client.TxStatusCommitted && status.Status != client.TxStatusFinalized {
+		return nil, nil
+	}
+	tx, err := s.client.Tx(ctx, hash, false)
+	if err != nil {
+		return nil, nil
+	}
+	block, err := s.client.Block(ctx, &tx.Height)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.client.BlockResults(ctx, &tx.Height)
+	if err != nil {
+		return nil, err
+	}
+	if int(tx.Index) >= len(results.TxsResults) {
+		return nil, errors.New("missing transaction result")
+	}
+	var cumulativeGasUsed int64
+	for _, res := range results.TxsResults[:tx.Index+1] {
+		cumulativeGasUsed += res.GasUsed
+	}
+
+	receipt := &EthReceipt{
+		TransactionHash:   ethData(hash),
+		TransactionIndex:  ethQuantity(uint64(tx.Index)),
+		BlockHash:         ethData(block.BlockID.Hash),
+		BlockNumber:       ethQuantity(uint64(tx.Height)),
+		CumulativeGasUsed: ethQuantity(uint64(cumulativeGasUsed)),
+		GasUsed:           ethQuantity(uint64(tx.TxResult.GasUsed)),
+		EffectiveGasPrice: ethQuantity(0),
+		Logs:              []interface{}{},
+		LogsBloom:         ethEmptyBloom,
+		Type:              ethQuantity(0),
+		Status:            ethQuantity(0),
+	}
+	if tx.TxResult.Code == abci.CodeTypeOK {
+		receipt.Status = ethQuantity(1)
+	}
+	return receipt, nil
+}
+
+func newEthBlock(block *ctypes.ResultBlock, results *ctypes.ResultBlockResults, maxGas int64, fullTxs bool) *EthBlock {
+	header := block.Block.Header
+	var gasUsed int64
+	for _, res := range results.TxsResults {
+		gasUsed += res.GasUsed
+	}
+	if maxGas < 0 {
+		maxGas = 0
+	}
+
+	txs := make([]interface{}, len(block.Block.Txs))
+	for i, tx := range block.Block.Txs {
+		if !fullTxs {
+			txs[i] = ethData(tx.Hash())
+			continue
+		}
+		txs[i] = &EthTransaction{
+			Hash:             ethData(tx.Hash()),
+			BlockHash:        ethData(block.BlockID.Hash),
+			BlockNumber:      ethQuantity(uint64(header.Height)),
+			TransactionIndex: ethQuantity(uint64(i)),
+			Input:            ethData(tx),
+		}
+	}
+
+	return &EthBlock{
+		Number:           ethQuantity(uint64(header.Height)),
+		Hash:             ethData(block.BlockID.Hash),
+		ParentHash:       ethData(header.LastBlockID.Hash),
+		Nonce:            "0x0000000000000000",
+		Sha3Uncles:       ethEmptyUnclesHash,
+		LogsBloom:        ethEmptyBloom,
+		TransactionsRoot: ethData(header.DataHash),
+		StateRoot:        ethData(header.AppHash),
+		ReceiptsRoot:     ethData(types.NewResults(results.TxsResults).Hash()),
+		Miner:            ethData(header.ProposerAddress),
+		Difficulty:       ethQuantity(0),
+		TotalDifficulty:  ethQuantity(0),
+		ExtraData:        "0x",
+		Size:             ethQuantity(uint64(block.Block.Size())),
+		GasLimit:         ethQuantity(uint64(maxGas)),
+		GasUsed:          ethQuantity(uint64(gasUsed)),
+		Timestamp:        ethQuantity(uint64(header.Time.Unix())),
+		Transactions:     txs,
+		Uncles:           []string{},
+	}
+}
+
+// unmarshalEthParams decodes positional parameters. Missing trailing parameters are left unchanged.
+func unmarshalEthParams(data []byte, params ...interface{}) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) > len(params) {
+		return fmt.Errorf("too many params: %d, expected at most %d", len(raw), len(params))
+	}
+	for i := range raw {
+		if err := json.Unmarshal(raw[i], params[i]); err != nil {
+			return fmt.Errorf("invalid param %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ethQuantity encodes number as hex string with 0x prefix and without leading zeroes.
+func ethQuantity(n uint64) string {
+	return "0x" + strconv.FormatUint(n, 16)
+}
+
+func parseEthQuantity(s string) (uint64, error) {
+	if !strings.HasPrefix(s, "0x") {
+		return 0, errors.New("missing 0x prefix")
+	}
+	return strconv.ParseUint(s[2:], 16, 64)
+}
+
+// ethData encodes bytes as hex string with 0x prefix.
+func ethData(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+func decodeEthData(s string) ([]byte, error) {
+	if !strings.HasPrefix(s, "0x") {
+		return nil, errors.New("missing 0x prefix")
+	}
+	return hex.DecodeString(s[2:])
+}
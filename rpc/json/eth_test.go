@@ -0,0 +1,109 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/rpc/v2/json2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestEthNamespace(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app, local := getRPC(t)
+	app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{GasUsed: 21000})
+
+	handler, err := GetHttpHandler(local, log.TestingLogger())
+	require.NoError(err)
+	_, rpcErr := ethCall(t, handler, "eth_blockNumber", `[]`)
+	require.NotNil(rpcErr)
+	assert.Equal(json2.E_NO_METHOD, rpcErr.Code)
+
+	handler, err = GetHttpHandler(local, log.TestingLogger(), WithEthNamespace(true))
+	require.NoError(err)
+
+	_, rpcErr = ethCall(t, handler, "eth_sendRawTransaction", `["deadbeef"]`)
+	assert.NotNil(rpcErr)
+	result, rpcErr := ethCall(t, handler, "eth_sendRawTransaction", `["0xdeadbeef"]`)
+	require.Nil(rpcErr)
+	var txHash string
+	require.NoError(json.Unmarshal(result, &txHash))
+	assert.Len(txHash, 66)
+
+	var receipt *EthReceipt
+	require.Eventually(func() bool {
+		result, rpcErr := ethCall(t, handler, "eth_getTransactionReceipt", `["`+txHash+`"]`)
+		require.Nil(rpcErr)
+		require.NoError(json.Unmarshal(result, &receipt))
+		return receipt != nil
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.Equal(txHash, receipt.TransactionHash)
+	assert.Equal("0x1", receipt.Status)
+	assert.Equal("0x5208", receipt.GasUsed)
+	assert.Equal("0x5208", receipt.CumulativeGasUsed)
+	assert.Equal("0x0", receipt.TransactionIndex)
+
+	result, rpcErr = ethCall(t, handler, "eth_blockNumber", `[]`)
+	require.Nil(rpcErr)
+	var number string
+	require.NoError(json.Unmarshal(result, &number))
+	n, err := parseEthQuantity(number)
+	require.NoError(err)
+	assert.GreaterOrEqual(n, uint64(1))
+
+	result, rpcErr = ethCall(t, handler, "eth_getBlockByNumber", `["`+receipt.BlockNumber+`", true]`)
+	require.Nil(rpcErr)
+	var block struct {
+		EthBlock
+		Transactions []EthTransaction `json:"transactions"`
+	}
+	require.NoError(json.Unmarshal(result, &block))
+	assert.Equal(receipt.BlockNumber, block.Number)
+	assert.Equal(receipt.BlockHash, block.Hash)
+	assert.Equal("0x5208", block.GasUsed)
+	require.Len(block.Transactions, 1)
+	assert.Equal(txHash, block.Transactions[0].Hash)
+	assert.Equal("0xdeadbeef", block.Transactions[0].Input)
+
+	result, rpcErr = ethCall(t, handler, "eth_getBlockByNumber", `["`+receipt.BlockNumber+`"]`)
+	require.Nil(rpcErr)
+	var hashes struct {
+		Transactions []string `json:"transactions"`
+	}
+	require.NoError(json.Unmarshal(result, &hashes))
+	assert.Equal([]string{txHash}, hashes.Transactions)
+
+	result, rpcErr = ethCall(t, handler, "eth_getBlockByNumber", `["0xffffff", false]`)
+	require.Nil(rpcErr)
+	assert.Equal("null", string(result))
+
+	result, rpcErr = ethCall(t, handler, "eth_getTransactionReceipt", `["0x00"]`)
+	require.Nil(rpcErr)
+	assert.Equal("null", string(result))
+}
+
+func ethCall(t *testing.T, handler http.Handler, method, params string) (json.RawMessage, *json2.Error) {
+	t.Helper()
+	body := `{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":` + params + `}`
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	var jsonResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *json2.Error    `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+	return jsonResp.Result, jsonResp.Error
+}
@@ -9,8 +9,11 @@ import (
 	"net/url"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/proxy"
+	"github.com/celestiaorg/optimint/rpc/client"
 	"github.com/gorilla/rpc/v2"
 	"github.com/gorilla/rpc/v2/json2"
 )
@@ -35,7 +38,7 @@ func newHandler(s *service, codec rpc.Codec, logger log.Logger) *handler {
 	mux.HandleFunc("/websocket", h.wsHandler)
 	for name, method := range s.methods {
 		logger.Debug("registering method", "name", name)
-		mux.HandleFunc("/"+name, h.newHandler(method))
+		mux.HandleFunc("/"+name, h.newHandler(name, method))
 	}
 
 	return h
@@ -66,7 +69,7 @@ func (h *handler) serveJSONRPCforWS(w http.ResponseWriter, r *http.Request, wsCo
 	}
 	methodSpec, ok := h.srv.methods[method]
 	if !ok {
-		codecReq.WriteError(w, int(json2.E_NO_METHOD), err)
+		codecReq.WriteError(w, http.StatusNotFound, &json2.Error{Code: json2.E_NO_METHOD, Message: "method not found: " + method})
 		return
 	}
 
@@ -84,7 +87,7 @@ func (h *handler) serveJSONRPCforWS(w http.ResponseWriter, r *http.Request, wsCo
 	if methodSpec.ws {
 		callArgs = append(callArgs, reflect.ValueOf(wsConn))
 	}
-	rets := methodSpec.m.Call(callArgs)
+	rets := h.srv.call(method, methodSpec, callArgs)
 
 	// Extract the result to error if needed.
 	var errResult error
@@ -100,14 +103,22 @@ func (h *handler) serveJSONRPCforWS(w http.ResponseWriter, r *http.Request, wsCo
 	w.Header().Set("x-content-type-options", "nosniff")
 
 	// Encode the response.
+	var result interface{}
 	if errResult == nil {
-		codecReq.WriteResponse(w, rets[0].Interface())
+		result, errResult = h.srv.compat.format(method, rets[0].Interface())
+	}
+	if errResult == nil {
+		codecReq.WriteResponse(w, result)
 	} else {
+		writeOverloadedHeader(w, errResult)
+		if errorCode(errResult) == int(json2.E_NO_METHOD) {
+			errResult = &json2.Error{Code: json2.E_NO_METHOD, Message: errResult.Error()}
+		}
 		codecReq.WriteError(w, statusCode, errResult)
 	}
 }
 
-func (h *handler) newHandler(methodSpec *method) func(http.ResponseWriter, *http.Request) {
+func (h *handler) newHandler(name string, methodSpec *method) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		args := reflect.New(methodSpec.argsType)
 		values, err := url.ParseQuery(r.URL.RawQuery)
@@ -145,23 +156,56 @@ func (h *handler) newHandler(methodSpec *method) func(http.ResponseWriter, *http
 				return
 			}
 		}
-		rets := methodSpec.m.Call([]reflect.Value{
+		rets := h.srv.call(name, methodSpec, []reflect.Value{
 			reflect.ValueOf(r),
 			args,
 		})
 
 		// Extract the result to error if needed.
 		statusCode := http.StatusOK
+		var result interface{}
 		errInter := rets[1].Interface()
 		if errInter != nil {
-			statusCode = int(json2.E_INTERNAL)
 			err = errInter.(error)
+			statusCode = errorCode(err)
+		} else {
+			result, err = h.srv.compat.format(name, rets[0].Interface())
+			if err != nil {
+				statusCode = int(json2.E_INTERNAL)
+			}
 		}
 
-		h.encodeAndWriteResponse(w, rets[0].Interface(), err, statusCode)
+		h.encodeAndWriteResponse(w, result, err, statusCode)
 	}
 }
 
+// call invokes RPC method, recording its duration and number of returned items. Calls slower than configured
+// threshold are logged with their arguments.
+func (s *service) call(name string, m *method, args []reflect.Value) []reflect.Value {
+	start := time.Now()
+	rets := m.m.Call(args)
+	duration := time.Since(start)
+
+	status := "ok"
+	if !rets[1].IsNil() {
+		status = "error"
+	} else if n, ok := resultItems(rets[0].Interface()); ok {
+		s.metrics.ResultItems.With("method", name).Observe(float64(n))
+	}
+	s.metrics.RequestDuration.With("method", name, "status", status).Observe(duration.Seconds())
+
+	if s.slowQueryThreshold > 0 && duration >= s.slowQueryThreshold {
+		s.metrics.SlowRequests.With("method", name).Add(1)
+		// args[1] is a pointer to arguments struct
+		params, err := json.Marshal(args[1].Interface())
+		if err != nil {
+			params = []byte(err.Error())
+		}
+		s.logger.Info("slow RPC request", "method", name, "duration", duration, "status", status, "params", string(params))
+	}
+	return rets
+}
+
 func (h *handler) encodeAndWriteResponse(w http.ResponseWriter, result interface{}, errResult error, statusCode int) {
 	// Prevents Internet Explorer from MIME-sniffing a response away
 	// from the declared content-type
@@ -174,6 +218,7 @@ func (h *handler) encodeAndWriteResponse(w http.ResponseWriter, result interface
 	}
 
 	if errResult != nil {
+		writeOverloadedHeader(w, errResult)
 		resp.Error = &json2.Error{Code: json2.ErrorCode(statusCode), Data: errResult.Error()}
 	} else {
 		resp.Result = result
@@ -186,6 +231,23 @@ func (h *handler) encodeAndWriteResponse(w http.ResponseWriter, result interface
 	}
 }
 
+// errorCode returns JSON-RPC error code of error returned by RPC method.
+func errorCode(err error) int {
+	var notImplemented *client.ErrNotImplemented
+	if errors.As(err, &notImplemented) {
+		return int(json2.E_NO_METHOD)
+	}
+	return int(json2.E_INTERNAL)
+}
+
+// writeOverloadedHeader sets HTTP status code to 429 (Too Many Requests) if request was rejected because of load shedding.
+func writeOverloadedHeader(w http.ResponseWriter, err error) {
+	if errors.Is(err, proxy.ErrOverloaded) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}
+}
+
 func setBoolParam(rawVal string, args *reflect.Value, i int) error {
 	v, err := strconv.ParseBool(rawVal)
 	if err != nil {
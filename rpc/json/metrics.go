@@ -0,0 +1,94 @@
+package json
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	optmetrics "github.com/celestiaorg/optimint/metrics"
+	"github.com/celestiaorg/optimint/rpc/client"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "rpc"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Duration of RPC method calls in seconds, by method and status ("ok" or "error").
+	RequestDuration metrics.Histogram
+	// Number of items (transactions, blocks, etc) returned by list methods, by method.
+	ResultItems metrics.Histogram
+	// Number of RPC method calls slower than configured threshold, by method.
+	SlowRequests metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library, registered in given registerer.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(registerer stdprometheus.Registerer, namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		RequestDuration: optmetrics.NewHistogramFrom(registerer, stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of RPC method calls, by method and status.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, append(labels, "method", "status")).With(labelsAndValues...),
+		ResultItems: optmetrics.NewHistogramFrom(registerer, stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "result_items",
+			Help:      "Number of items returned by list methods, by method.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 4, 8),
+		}, append(labels, "method")).With(labelsAndValues...),
+		SlowRequests: optmetrics.NewCounterFrom(registerer, stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "slow_requests",
+			Help:      "Number of RPC method calls slower than configured threshold, by method.",
+		}, append(labels, "method")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		RequestDuration: discard.NewHistogram(),
+		ResultItems:     discard.NewHistogram(),
+		SlowRequests:    discard.NewCounter(),
+	}
+}
+
+// resultItems returns number of items in result of list method. False is returned for other methods.
+func resultItems(result interface{}) (int, bool) {
+	switch r := result.(type) {
+	case *ctypes.ResultTxSearch:
+		return len(r.Txs), true
+	case *ctypes.ResultBlockSearch:
+		return len(r.Blocks), true
+	case *ctypes.ResultBlockchainInfo:
+		return len(r.BlockMetas), true
+	case *ctypes.ResultUnconfirmedTxs:
+		return len(r.Txs), true
+	case *ctypes.ResultValidators:
+		return len(r.Validators), true
+	case *client.ResultDAConfirmations:
+		return len(r.Confirmations), true
+	case *client.ResultDACost:
+		return len(r.Blocks), true
+	case *client.ResultBridgeEvents:
+		return len(r.Events), true
+	case *client.ResultEventJournal:
+		return len(r.Entries), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,58 @@
+package json
+
+import "time"
+
+// Option sets optional parameter of the JSON-RPC handler.
+type Option func(*options)
+
+type options struct {
+	compatVersion string
+	eventEncoding string
+	unsafe        bool
+	eth           bool
+	readOnly      bool
+
+	metrics            *Metrics
+	slowQueryThreshold time.Duration
+}
+
+// WithCompatVersion sets the shape of JSON-RPC responses (one of config.RPCCompat* values).
+// By default, responses are compatible with Tendermint 0.34.
+func WithCompatVersion(version string) Option {
+	return func(o *options) { o.compatVersion = version }
+}
+
+// WithEventEncoding overrides encoding of event attribute keys and values (config.EventEncodingBase64 or
+// config.EventEncodingRaw) implied by compatibility version.
+func WithEventEncoding(encoding string) Option {
+	return func(o *options) { o.eventEncoding = encoding }
+}
+
+// WithUnsafe enables admin methods (prefixed with "unsafe_"), e.g. pausing block production.
+// It corresponds to `rpc.unsafe` Tendermint configuration option.
+func WithUnsafe(unsafe bool) Option {
+	return func(o *options) { o.unsafe = unsafe }
+}
+
+// WithEthNamespace enables Ethereum JSON-RPC facade (methods prefixed with "eth_"), for rollups running an EVM as ABCI
+// application.
+func WithEthNamespace(enabled bool) Option {
+	return func(o *options) { o.eth = enabled }
+}
+
+// WithReadOnly disables methods that broadcast transactions or evidence, and admin methods. Disabled methods return
+// ErrReadOnly, so query-only replicas can be safely exposed to the public.
+func WithReadOnly(readOnly bool) Option {
+	return func(o *options) { o.readOnly = readOnly }
+}
+
+// WithMetrics sets metrics of RPC method calls. By default, metrics are not collected.
+func WithMetrics(metrics *Metrics) Option {
+	return func(o *options) { o.metrics = metrics }
+}
+
+// WithSlowQueryThreshold enables logging of RPC method calls that take longer than threshold, together with their
+// arguments (e.g. query of tx_search). Zero disables logging.
+func WithSlowQueryThreshold(threshold time.Duration) Option {
+	return func(o *options) { o.slowQueryThreshold = threshold }
+}
@@ -3,6 +3,7 @@ package json
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -14,12 +15,40 @@ import (
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 
+	"github.com/celestiaorg/optimint/journal"
 	"github.com/celestiaorg/optimint/log"
 	"github.com/celestiaorg/optimint/rpc/client"
 )
 
-func GetHttpHandler(l *client.Client, logger log.Logger) (http.Handler, error) {
-	return newHandler(newService(l, logger), json2.NewCodec(), logger), nil
+func GetHttpHandler(l *client.Client, logger log.Logger, opts ...Option) (http.Handler, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	compat, err := newCompatFormatter(o.compatVersion, o.eventEncoding)
+	if err != nil {
+		return nil, err
+	}
+	return newHandler(newService(l, compat, o, logger), json2.NewCodec(), logger), nil
+}
+
+// ErrReadOnly is returned by methods disabled in read-only mode.
+var ErrReadOnly = errors.New("read-only node: transaction broadcasting and admin methods are disabled")
+
+// writeMethods are methods disabled in read-only mode.
+var writeMethods = []string{
+	"broadcast_tx_commit",
+	"broadcast_tx_sync",
+	"broadcast_tx_async",
+	"broadcast_tx_batch",
+	"broadcast_tx_ordered",
+	"broadcast_evidence",
+	"eth_sendRawTransaction",
+	"unsafe_start_aggregating",
+	"unsafe_stop_aggregating",
+	"unsafe_dump_mempool",
+	"unsafe_load_mempool",
+	"event_journal_ack",
 }
 
 type method struct {
@@ -40,47 +69,103 @@ func newMethod(m interface{}) *method {
 	}
 }
 
+// disabled returns method with the same signature, that always fails with given error.
+func (m *method) disabled(err error) *method {
+	errValue := reflect.ValueOf(&err).Elem()
+	fn := reflect.MakeFunc(m.m.Type(), func([]reflect.Value) []reflect.Value {
+		return []reflect.Value{reflect.Zero(m.m.Type().Out(0)), errValue}
+	})
+	return &method{m: fn, argsType: m.argsType, returnType: m.returnType, ws: m.ws}
+}
+
 type service struct {
 	client  *client.Client
+	compat  *compatFormatter
 	methods map[string]*method
 	logger  log.Logger
+
+	metrics            *Metrics
+	slowQueryThreshold time.Duration
 }
 
-func newService(c *client.Client, l log.Logger) *service {
+func newService(c *client.Client, compat *compatFormatter, o options, l log.Logger) *service {
 	s := service{
-		client: c,
-		logger: l,
+		client:             c,
+		compat:             compat,
+		logger:             l,
+		metrics:            o.metrics,
+		slowQueryThreshold: o.slowQueryThreshold,
+	}
+	if s.metrics == nil {
+		s.metrics = NopMetrics()
 	}
 	s.methods = map[string]*method{
-		"subscribe":            newMethod(s.Subscribe),
-		"unsubscribe":          newMethod(s.Unsubscribe),
-		"unsubscribe_all":      newMethod(s.UnsubscribeAll),
-		"health":               newMethod(s.Health),
-		"status":               newMethod(s.Status),
-		"net_info":             newMethod(s.NetInfo),
-		"blockchain":           newMethod(s.BlockchainInfo),
-		"genesis":              newMethod(s.Genesis),
-		"genesis_chunked":      newMethod(s.GenesisChunked),
-		"block":                newMethod(s.Block),
-		"block_by_hash":        newMethod(s.BlockByHash),
-		"block_results":        newMethod(s.BlockResults),
-		"commit":               newMethod(s.Commit),
-		"check_tx":             newMethod(s.CheckTx),
-		"tx":                   newMethod(s.Tx),
-		"tx_search":            newMethod(s.TxSearch),
-		"block_search":         newMethod(s.BlockSearch),
-		"validators":           newMethod(s.Validators),
-		"dump_consensus_state": newMethod(s.DumpConsensusState),
-		"consensus_state":      newMethod(s.GetConsensusState),
-		"consensus_params":     newMethod(s.ConsensusParams),
-		"unconfirmed_txs":      newMethod(s.UnconfirmedTxs),
-		"num_unconfirmed_txs":  newMethod(s.NumUnconfirmedTxs),
-		"broadcast_tx_commit":  newMethod(s.BroadcastTxCommit),
-		"broadcast_tx_sync":    newMethod(s.BroadcastTxSync),
-		"broadcast_tx_async":   newMethod(s.BroadcastTxAsync),
-		"abci_query":           newMethod(s.ABCIQuery),
-		"abci_info":            newMethod(s.ABCIInfo),
-		"broadcast_evidence":   newMethod(s.BroadcastEvidence),
+		"subscribe":              newMethod(s.Subscribe),
+		"unsubscribe":            newMethod(s.Unsubscribe),
+		"unsubscribe_all":        newMethod(s.UnsubscribeAll),
+		"health":                 newMethod(s.Health),
+		"status":                 newMethod(s.Status),
+		"net_info":               newMethod(s.NetInfo),
+		"gossip_stats":           newMethod(s.GossipStats),
+		"blockchain":             newMethod(s.BlockchainInfo),
+		"genesis":                newMethod(s.Genesis),
+		"genesis_chunked":        newMethod(s.GenesisChunked),
+		"block":                  newMethod(s.Block),
+		"block_by_hash":          newMethod(s.BlockByHash),
+		"block_results":          newMethod(s.BlockResults),
+		"commit":                 newMethod(s.Commit),
+		"check_tx":               newMethod(s.CheckTx),
+		"tx":                     newMethod(s.Tx),
+		"tx_search":              newMethod(s.TxSearch),
+		"block_search":           newMethod(s.BlockSearch),
+		"validators":             newMethod(s.Validators),
+		"dump_consensus_state":   newMethod(s.DumpConsensusState),
+		"consensus_state":        newMethod(s.GetConsensusState),
+		"consensus_params":       newMethod(s.ConsensusParams),
+		"unconfirmed_txs":        newMethod(s.UnconfirmedTxs),
+		"num_unconfirmed_txs":    newMethod(s.NumUnconfirmedTxs),
+		"min_gas_price":          newMethod(s.MinGasPrice),
+		"broadcast_tx_commit":    newMethod(s.BroadcastTxCommit),
+		"broadcast_tx_sync":      newMethod(s.BroadcastTxSync),
+		"broadcast_tx_async":     newMethod(s.BroadcastTxAsync),
+		"broadcast_tx_batch":     newMethod(s.BroadcastTxBatch),
+		"broadcast_tx_ordered":   newMethod(s.BroadcastTxOrdered),
+		"check_ordering_receipt": newMethod(s.CheckOrderingReceipt),
+		"abci_query":             newMethod(s.ABCIQuery),
+		"abci_info":              newMethod(s.ABCIInfo),
+		"broadcast_evidence":     newMethod(s.BroadcastEvidence),
+		"tx_trace":               newMethod(s.TxTrace),
+		"tx_status":              newMethod(s.TxStatus),
+		"block_results_da":       newMethod(s.BlockResultsDA),
+		"da_confirmations":       newMethod(s.DAConfirmations),
+		"da_cost":                newMethod(s.DACost),
+		"bridge_events":          newMethod(s.BridgeEvents),
+		"bridge_event_proof":     newMethod(s.BridgeEventProof),
+		"event_journal":          newMethod(s.EventJournal),
+		"event_journal_ack":      newMethod(s.EventJournalAck),
+		"checkpoint":             newMethod(s.Checkpoint),
+		"chain_stats":            newMethod(s.ChainStats),
+		"list_snapshots":         newMethod(s.ListSnapshots),
+	}
+	// admin methods are registered in read-only mode, to return meaningful error
+	if o.unsafe || o.readOnly {
+		s.methods["unsafe_start_aggregating"] = newMethod(s.StartAggregating)
+		s.methods["unsafe_stop_aggregating"] = newMethod(s.StopAggregating)
+		s.methods["unsafe_dump_mempool"] = newMethod(s.DumpMempool)
+		s.methods["unsafe_load_mempool"] = newMethod(s.LoadMempool)
+	}
+	if o.eth {
+		s.methods["eth_blockNumber"] = newMethod(s.EthBlockNumber)
+		s.methods["eth_getBlockByNumber"] = newMethod(s.EthGetBlockByNumber)
+		s.methods["eth_sendRawTransaction"] = newMethod(s.EthSendRawTransaction)
+		s.methods["eth_getTransactionReceipt"] = newMethod(s.EthGetTransactionReceipt)
+	}
+	if o.readOnly {
+		for _, name := range writeMethods {
+			if m, ok := s.methods[name]; ok {
+				s.methods[name] = m.disabled(ErrReadOnly)
+			}
+		}
 	}
 	return &s
 }
@@ -95,7 +180,19 @@ func (s *service) Subscribe(req *http.Request, args *SubscribeArgs, wsConn *wsCo
 		return nil, fmt.Errorf("failed to parse query: %w", err)
 	}
 
-	s.logger.Debug("subscribe to query", "remote", addr, "query", args.Query)
+	overflow := client.OverflowCancel
+	if args.Overflow != "" {
+		overflow, err = client.ParseOverflowPolicy(args.Overflow)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if overflow == client.OverflowBlock {
+		// remote clients can't be allowed to stall the node
+		return nil, fmt.Errorf("%s overflow policy is not available for RPC subscriptions", overflow)
+	}
+
+	s.logger.Debug("subscribe to query", "remote", addr, "query", args.Query, "fromHeight", args.FromHeight, "overflow", overflow)
 
 	// TODO(tzdybal): extract consts or configs
 	const SubscribeTimeout = 5 * time.Second
@@ -103,16 +200,22 @@ func (s *service) Subscribe(req *http.Request, args *SubscribeArgs, wsConn *wsCo
 	ctx, cancel := context.WithTimeout(req.Context(), SubscribeTimeout)
 	defer cancel()
 
-	sub, err := s.client.EventBus.Subscribe(ctx, addr, q, subBufferSize)
+	// if from height is set, events of historical blocks are replayed before live events
+	sub, err := s.client.SubscribeQueued(ctx, addr, q, int64(args.FromHeight), subBufferSize, overflow)
 	if err != nil {
-		return nil, fmt.Errorf("failed to subscribe: %w", err)
+		return nil, err
 	}
 
 	go func() {
 		for {
 			select {
 			case msg := <-sub.Out():
-				data, err := json.Marshal(msg.Data())
+				result, err := s.compat.format("subscribe", msg.Data())
+				if err != nil {
+					s.logger.Error("failed to format response data", "error", err)
+					continue
+				}
+				data, err := json.Marshal(result)
 				if err != nil {
 					s.logger.Error("failed to marshal response data", "error", err)
 					continue
@@ -164,12 +267,16 @@ func (s *service) Health(req *http.Request, args *HealthArgs) (*ctypes.ResultHea
 	return s.client.Health(req.Context())
 }
 
-func (s *service) Status(req *http.Request, args *StatusArgs) (*ctypes.ResultStatus, error) {
-	return s.client.Status(req.Context())
+func (s *service) Status(req *http.Request, args *StatusArgs) (*client.ResultStatus, error) {
+	return s.client.NodeStatus(req.Context())
 }
 
-func (s *service) NetInfo(req *http.Request, args *NetInfoArgs) (*ctypes.ResultNetInfo, error) {
-	return s.client.NetInfo(req.Context())
+func (s *service) NetInfo(req *http.Request, args *NetInfoArgs) (*client.ResultNetInfo, error) {
+	return s.client.NodeNetInfo(req.Context())
+}
+
+func (s *service) GossipStats(req *http.Request, args *GossipStatsArgs) (*client.ResultGossipStats, error) {
+	return s.client.GossipStats(req.Context())
 }
 
 func (s *service) BlockchainInfo(req *http.Request, args *BlockchainInfoArgs) (*ctypes.ResultBlockchainInfo, error) {
@@ -196,6 +303,47 @@ func (s *service) BlockResults(req *http.Request, args *BlockResultsArgs) (*ctyp
 	return s.client.BlockResults(req.Context(), (*int64)(&args.Height))
 }
 
+func (s *service) BlockResultsDA(req *http.Request, args *BlockResultsDAArgs) (*client.ResultBlockResultsDA, error) {
+	return s.client.BlockResultsDA(req.Context(), (*int64)(&args.Height))
+}
+
+func (s *service) DAConfirmations(req *http.Request, args *DAConfirmationsArgs) (*client.ResultDAConfirmations, error) {
+	return s.client.DAConfirmations(req.Context(), int64(args.MinHeight), int64(args.MaxHeight))
+}
+
+func (s *service) DACost(req *http.Request, args *DACostArgs) (*client.ResultDACost, error) {
+	return s.client.DACost(req.Context(), int64(args.MinHeight), int64(args.MaxHeight))
+}
+
+func (s *service) BridgeEvents(req *http.Request, args *BridgeEventsArgs) (*client.ResultBridgeEvents, error) {
+	return s.client.BridgeEvents(req.Context(), (*int64)(&args.Height))
+}
+
+func (s *service) BridgeEventProof(req *http.Request, args *BridgeEventProofArgs) (*client.ResultBridgeEventProof, error) {
+	return s.client.BridgeEventProof(req.Context(), (*int64)(&args.Height), int(args.Index))
+}
+
+func (s *service) EventJournal(req *http.Request, args *EventJournalArgs) (*client.ResultEventJournal, error) {
+	var cursor *journal.Cursor
+	if args.Height > 0 {
+		cursor = &journal.Cursor{Height: uint64(args.Height), Sequence: uint32(args.Sequence)}
+	}
+	return s.client.EventJournal(req.Context(), args.Consumer, cursor, (*int)(&args.Limit))
+}
+
+func (s *service) EventJournalAck(req *http.Request, args *EventJournalAckArgs) (*client.ResultEventJournalAck, error) {
+	cursor := journal.Cursor{Height: uint64(args.Height), Sequence: uint32(args.Sequence)}
+	return s.client.EventJournalAck(req.Context(), args.Consumer, cursor)
+}
+
+func (s *service) ChainStats(req *http.Request, args *ChainStatsArgs) (*client.ResultChainStats, error) {
+	return s.client.ChainStats(req.Context())
+}
+
+func (s *service) Checkpoint(req *http.Request, args *CheckpointArgs) (*client.ResultCheckpoint, error) {
+	return s.client.Checkpoint(req.Context(), (*int64)(&args.Height))
+}
+
 func (s *service) Commit(req *http.Request, args *CommitArgs) (*ctypes.ResultCommit, error) {
 	return s.client.Commit(req.Context(), (*int64)(&args.Height))
 }
@@ -208,6 +356,14 @@ func (s *service) Tx(req *http.Request, args *TxArgs) (*ctypes.ResultTx, error)
 	return s.client.Tx(req.Context(), args.Hash, args.Prove)
 }
 
+func (s *service) TxTrace(req *http.Request, args *TxTraceArgs) (*client.ResultTxTrace, error) {
+	return s.client.TxTrace(req.Context(), args.Hash)
+}
+
+func (s *service) TxStatus(req *http.Request, args *TxStatusArgs) (*client.ResultTxStatus, error) {
+	return s.client.TxStatus(req.Context(), args.Hash)
+}
+
 func (s *service) TxSearch(req *http.Request, args *TxSearchArgs) (*ctypes.ResultTxSearch, error) {
 	return s.client.TxSearch(req.Context(), args.Query, args.Prove, (*int)(&args.Page), (*int)(&args.PerPage), args.OrderBy)
 }
@@ -240,17 +396,39 @@ func (s *service) NumUnconfirmedTxs(req *http.Request, args *NumUnconfirmedTxsAr
 	return s.client.NumUnconfirmedTxs(req.Context())
 }
 
+func (s *service) MinGasPrice(req *http.Request, args *MinGasPriceArgs) (*client.ResultMinGasPrice, error) {
+	return s.client.MinGasPrice(req.Context())
+}
+
 // tx broadcast API
+
+// txContext returns context of request, carrying remote address of RPC client as origin of broadcasted transactions.
+func txContext(req *http.Request) context.Context {
+	return client.ContextWithRemoteAddr(req.Context(), req.RemoteAddr)
+}
+
 func (s *service) BroadcastTxCommit(req *http.Request, args *BroadcastTxCommitArgs) (*ctypes.ResultBroadcastTxCommit, error) {
-	return s.client.BroadcastTxCommit(req.Context(), args.Tx)
+	return s.client.BroadcastTxCommit(txContext(req), args.Tx)
 }
 
 func (s *service) BroadcastTxSync(req *http.Request, args *BroadcastTxSyncArgs) (*ctypes.ResultBroadcastTx, error) {
-	return s.client.BroadcastTxSync(req.Context(), args.Tx)
+	return s.client.BroadcastTxSync(txContext(req), args.Tx)
 }
 
 func (s *service) BroadcastTxAsync(req *http.Request, args *BroadcastTxAsyncArgs) (*ctypes.ResultBroadcastTx, error) {
-	return s.client.BroadcastTxAsync(req.Context(), args.Tx)
+	return s.client.BroadcastTxAsync(txContext(req), args.Tx)
+}
+
+func (s *service) BroadcastTxBatch(req *http.Request, args *BroadcastTxBatchArgs) (*client.ResultBroadcastTxBatch, error) {
+	return s.client.BroadcastTxBatch(txContext(req), args.Txs)
+}
+
+func (s *service) BroadcastTxOrdered(req *http.Request, args *BroadcastTxOrderedArgs) (*client.ResultBroadcastTxOrdered, error) {
+	return s.client.BroadcastTxOrdered(txContext(req), args.Tx)
+}
+
+func (s *service) CheckOrderingReceipt(req *http.Request, args *CheckOrderingReceiptArgs) (*client.ResultOrderingReceipt, error) {
+	return s.client.CheckOrderingReceipt(req.Context(), &args.Receipt)
 }
 
 // abci API
@@ -261,6 +439,10 @@ func (s *service) ABCIQuery(req *http.Request, args *ABCIQueryArgs) (*ctypes.Res
 	})
 }
 
+func (s *service) ListSnapshots(req *http.Request, args *ListSnapshotsArgs) (*client.ResultListSnapshots, error) {
+	return s.client.ListSnapshots(req.Context())
+}
+
 func (s *service) ABCIInfo(req *http.Request, args *ABCIInfoArgs) (*ctypes.ResultABCIInfo, error) {
 	return s.client.ABCIInfo(req.Context())
 }
@@ -269,3 +451,20 @@ func (s *service) ABCIInfo(req *http.Request, args *ABCIInfoArgs) (*ctypes.Resul
 func (s *service) BroadcastEvidence(req *http.Request, args *BroadcastEvidenceArgs) (*ctypes.ResultBroadcastEvidence, error) {
 	return s.client.BroadcastEvidence(req.Context(), args.Evidence)
 }
+
+// admin API
+func (s *service) StartAggregating(req *http.Request, args *StartAggregatingArgs) (*client.ResultAggregating, error) {
+	return s.client.StartAggregating(req.Context())
+}
+
+func (s *service) StopAggregating(req *http.Request, args *StopAggregatingArgs) (*client.ResultAggregating, error) {
+	return s.client.StopAggregating(req.Context())
+}
+
+func (s *service) DumpMempool(req *http.Request, args *DumpMempoolArgs) (*client.ResultDumpMempool, error) {
+	return s.client.DumpMempool(req.Context())
+}
+
+func (s *service) LoadMempool(req *http.Request, args *LoadMempoolArgs) (*client.ResultLoadMempool, error) {
+	return s.client.LoadMempool(req.Context(), args.Txs)
+}
@@ -16,9 +16,11 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	metricskit "github.com/go-kit/kit/metrics"
 	"github.com/gorilla/rpc/v2/json2"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/types"
@@ -70,7 +72,7 @@ func TestREST(t *testing.T) {
 		{"invalid/missing param", "/block", http.StatusOK, int(json2.E_INVALID_REQ), `missing param 'height'`},
 		{"valid/no params", "/abci_info", http.StatusOK, -1, `"last_block_height":345`},
 		// to keep test simple, allow returning application error in following case
-		{"valid/int param", "/block?height=321", http.StatusOK, int(json2.E_INTERNAL), `"key not found"`},
+		{"valid/int param", "/block?height=321", http.StatusOK, int(json2.E_INTERNAL), `"height 321 must be less than or equal to the current blockchain height 0"`},
 		{"invalid/int param", "/block?height=foo", http.StatusOK, int(json2.E_PARSE), "failed to parse param 'height'"},
 		{"valid/bool int string params",
 			"/tx_search?" + txSearchParams.Encode(),
@@ -80,6 +82,7 @@ func TestREST(t *testing.T) {
 			http.StatusOK, int(json2.E_PARSE), "failed to parse param 'prove'"},
 		{"valid/hex param", "/check_tx?tx=DEADBEEF", http.StatusOK, -1, `"gas_used":"1000"`},
 		{"invalid/hex param", "/check_tx?tx=QWERTY", http.StatusOK, int(json2.E_PARSE), "failed to parse param 'tx'"},
+		{"not implemented", "/genesis_chunked?chunk=0", http.StatusOK, int(json2.E_NO_METHOD), "genesis_chunked is not implemented"},
 	}
 
 	_, local := getRPC(t)
@@ -108,6 +111,27 @@ func TestREST(t *testing.T) {
 
 }
 
+func TestNotImplemented(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, local := getRPC(t)
+	handler, err := GetHttpHandler(local, log.TestingLogger())
+	require.NoError(err)
+
+	jsonReq, err := json2.EncodeClientRequest("broadcast_evidence", &BroadcastEvidenceArgs{})
+	require.NoError(err)
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(jsonReq))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	var jsonResp response
+	require.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+	require.NotNil(jsonResp.Error)
+	assert.Equal(json2.E_NO_METHOD, jsonResp.Error.Code)
+	assert.Equal("broadcast_evidence is not implemented in Optimint", jsonResp.Error.Message)
+}
+
 func TestEmptyRequest(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -144,6 +168,125 @@ func TestStringyRequest(t *testing.T) {
 	assert.Equal(respJson, resp.Body.String())
 }
 
+func TestUnsafeMethods(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, local := getRPC(t)
+	call := func(handler http.Handler, method string) string {
+		jsonReq, err := json2.EncodeClientRequest(method, &StopAggregatingArgs{})
+		require.NoError(err)
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(jsonReq))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		return resp.Body.String()
+	}
+
+	// admin methods are not available by default
+	handler, err := GetHttpHandler(local, log.TestingLogger())
+	require.NoError(err)
+	assert.Contains(call(handler, "unsafe_stop_aggregating"), "method not found")
+
+	handler, err = GetHttpHandler(local, log.TestingLogger(), WithUnsafe(true))
+	require.NoError(err)
+	assert.Contains(call(handler, "unsafe_stop_aggregating"), `"result":{"aggregating":false}`)
+	assert.Contains(call(handler, "unsafe_start_aggregating"), `"result":{"aggregating":true}`)
+	assert.Contains(call(handler, "unsafe_dump_mempool"), `"result":{"txs":[]}`)
+}
+
+func TestReadOnlyMethods(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, local := getRPC(t)
+	handler, err := GetHttpHandler(local, log.TestingLogger(), WithReadOnly(true))
+	require.NoError(err)
+	call := func(method string, args interface{}) string {
+		jsonReq, err := json2.EncodeClientRequest(method, args)
+		require.NoError(err)
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(jsonReq))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		return resp.Body.String()
+	}
+
+	// broadcasting and admin methods are disabled, both in JSON-RPC and URI mode
+	assert.Contains(call("broadcast_tx_sync", &BroadcastTxSyncArgs{Tx: []byte{1, 2, 3}}), ErrReadOnly.Error())
+	assert.Contains(call("broadcast_evidence", &BroadcastEvidenceArgs{}), ErrReadOnly.Error())
+	assert.Contains(call("unsafe_stop_aggregating", &StopAggregatingArgs{}), ErrReadOnly.Error())
+	req := httptest.NewRequest(http.MethodGet, "/broadcast_tx_async?tx=010203", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Contains(resp.Body.String(), ErrReadOnly.Error())
+
+	// queries are still served
+	assert.Contains(call("health", &HealthArgs{}), `"result":{}`)
+	assert.Contains(call("num_unconfirmed_txs", &NumUnconfirmedTxsArgs{}), `"result":{"n_txs":0`)
+}
+
+func TestMethodMetrics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var logs bytes.Buffer
+	metrics := NopMetrics()
+	durations := &testHistogram{observations: make(map[string][]float64)}
+	items := &testHistogram{observations: make(map[string][]float64)}
+	metrics.RequestDuration = durations
+	metrics.ResultItems = items
+
+	_, local := getRPC(t)
+	handler, err := GetHttpHandler(local, log.NewTMLogger(log.NewSyncWriter(&logs)),
+		WithMetrics(metrics), WithSlowQueryThreshold(time.Nanosecond))
+	require.NoError(err)
+	call := func(uri string) {
+		req := httptest.NewRequest(http.MethodGet, uri, nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		assert.Equal(http.StatusOK, resp.Code)
+	}
+
+	txSearchParams := url.Values{}
+	txSearchParams.Set("query", "tx.height>0")
+	txSearchParams.Set("prove", "false")
+	txSearchParams.Set("page", "1")
+	txSearchParams.Set("per_page", "10")
+	txSearchParams.Set("order_by", "asc")
+	call("/tx_search?" + txSearchParams.Encode())
+	txSearchParams.Set("query", "tx.height>>0")
+	call("/tx_search?" + txSearchParams.Encode())
+	call("/health")
+
+	assert.Len(durations.observations["method=tx_search,status=ok"], 1)
+	assert.Len(durations.observations["method=tx_search,status=error"], 1)
+	assert.Len(durations.observations["method=health,status=ok"], 1)
+	assert.Equal([]float64{0}, items.observations["method=tx_search"])
+	assert.NotContains(items.observations, "method=health")
+
+	// every call is slower than 1ns
+	assert.Equal(3, strings.Count(logs.String(), "slow RPC request"))
+	assert.Contains(logs.String(), `tx.height\\u003e0`)
+}
+
+// testHistogram records observations by label values.
+type testHistogram struct {
+	observations map[string][]float64
+	lvs          []string
+}
+
+func (h *testHistogram) With(labelValues ...string) metricskit.Histogram {
+	return &testHistogram{observations: h.observations, lvs: append(h.lvs, labelValues...)}
+}
+
+func (h *testHistogram) Observe(value float64) {
+	var labels []string
+	for i := 0; i+1 < len(h.lvs); i += 2 {
+		labels = append(labels, h.lvs[i]+"="+h.lvs[i+1])
+	}
+	key := strings.Join(labels, ",")
+	h.observations[key] = append(h.observations[key], value)
+}
+
 func TestSubscription(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -160,17 +303,32 @@ func TestSubscription(t *testing.T) {
 	require.NotEmpty(subscribeReq)
 
 	subscribeReq2, err := json2.EncodeClientRequest("subscribe", &SubscribeArgs{
-		Query: query2,
+		Query:    query2,
+		Overflow: "drop_oldest",
 	})
 	require.NoError(err)
 	require.NotEmpty(subscribeReq2)
 
+	replaySubscribeReq, err := json2.EncodeClientRequest("subscribe", &SubscribeArgs{
+		Query:      "tm.event='Tx'",
+		FromHeight: 1,
+	})
+	require.NoError(err)
+	require.NotEmpty(replaySubscribeReq)
+
 	invalidSubscribeReq, err := json2.EncodeClientRequest("subscribe", &SubscribeArgs{
 		Query: invalidQuery,
 	})
 	require.NoError(err)
 	require.NotEmpty(invalidSubscribeReq)
 
+	blockingSubscribeReq, err := json2.EncodeClientRequest("subscribe", &SubscribeArgs{
+		Query:    "tm.event='NewBlock'",
+		Overflow: "block",
+	})
+	require.NoError(err)
+	require.NotEmpty(blockingSubscribeReq)
+
 	unsubscribeReq, err := json2.EncodeClientRequest("unsubscribe", &UnsubscribeArgs{
 		Query: query,
 	})
@@ -207,6 +365,15 @@ func TestSubscription(t *testing.T) {
 	assert.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
 	assert.Nil(jsonResp.Error)
 
+	// test valid subscription with replay of historical events
+	req = httptest.NewRequest(http.MethodGet, "/", bytes.NewReader(replaySubscribeReq))
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(http.StatusOK, resp.Code)
+	jsonResp = response{}
+	assert.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+	assert.Nil(jsonResp.Error)
+
 	// test subscription with invalid query
 	req = httptest.NewRequest(http.MethodGet, "/", bytes.NewReader(invalidSubscribeReq))
 	resp = httptest.NewRecorder()
@@ -217,6 +384,16 @@ func TestSubscription(t *testing.T) {
 	require.NotNil(jsonResp.Error)
 	assert.Contains(jsonResp.Error.Message, "failed to parse query")
 
+	// test subscription blocking the node
+	req = httptest.NewRequest(http.MethodGet, "/", bytes.NewReader(blockingSubscribeReq))
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(http.StatusOK, resp.Code)
+	jsonResp = response{}
+	assert.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+	require.NotNil(jsonResp.Error)
+	assert.Contains(jsonResp.Error.Message, "not available for RPC subscriptions")
+
 	// test valid, but duplicate subscription
 	req = httptest.NewRequest(http.MethodGet, "/", bytes.NewReader(subscribeReq))
 	resp = httptest.NewRecorder()
@@ -287,7 +464,7 @@ func getRPC(t *testing.T) (*mocks.Application, *client.Client) {
 		LastBlockAppHash: nil,
 	})
 	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
-	node, err := node.NewNode(context.Background(), config.NodeConfig{Aggregator: true, DALayer: "mock", BlockManagerConfig: config.BlockManagerConfig{BlockTime: 1 * time.Second}}, key, proxy.NewLocalClientCreator(app), &types.GenesisDoc{ChainID: "test"}, log.TestingLogger())
+	node, err := node.NewNode(context.Background(), config.NodeConfig{Aggregator: true, DALayer: "mock", BlockManagerConfig: config.BlockManagerConfig{BlockTime: 1 * time.Second}}, key, proxy.NewLocalClientCreator(app), getGenesis(key, t), log.TestingLogger())
 	require.NoError(err)
 	require.NotNil(node)
 
@@ -299,3 +476,19 @@ func getRPC(t *testing.T) (*mocks.Application, *client.Client) {
 
 	return app, local
 }
+
+func getGenesis(sequencerKey crypto.PrivKey, t *testing.T) *types.GenesisDoc {
+	t.Helper()
+	rawKey, err := sequencerKey.GetPublic().Raw()
+	require.NoError(t, err)
+	pubKey := ed25519.PubKey(rawKey)
+	return &types.GenesisDoc{
+		ChainID: "test",
+		Validators: []types.GenesisValidator{{
+			Address: pubKey.Address(),
+			PubKey:  pubKey,
+			Power:   1,
+			Name:    "sequencer",
+		}},
+	}
+}
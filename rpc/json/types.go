@@ -8,10 +8,17 @@ import (
 	"github.com/gorilla/rpc/v2/json2"
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/types"
+
+	optypes "github.com/celestiaorg/optimint/types"
 )
 
 type SubscribeArgs struct {
 	Query string `json:"query"`
+	// FromHeight enables replay of events of blocks starting from given height, before live events (0 - disabled).
+	FromHeight StrInt64 `json:"from_height"`
+	// Overflow defines what happens when the client doesn't consume events fast enough: "cancel" (default) or
+	// "drop_oldest".
+	Overflow string `json:"overflow"`
 }
 type UnsubscribeArgs struct {
 	Query string `json:"query"`
@@ -26,6 +33,8 @@ type StatusArgs struct {
 }
 type NetInfoArgs struct {
 }
+type GossipStatsArgs struct {
+}
 type BlockchainInfoArgs struct {
 	MinHeight StrInt64
 	MaxHeight StrInt64
@@ -44,6 +53,41 @@ type BlockByHashArgs struct {
 type BlockResultsArgs struct {
 	Height StrInt64 `json:"height"`
 }
+type BlockResultsDAArgs struct {
+	Height StrInt64 `json:"height"`
+}
+type DAConfirmationsArgs struct {
+	MinHeight StrInt64
+	MaxHeight StrInt64
+}
+type DACostArgs struct {
+	MinHeight StrInt64
+	MaxHeight StrInt64
+}
+type BridgeEventsArgs struct {
+	Height StrInt64 `json:"height"`
+}
+type BridgeEventProofArgs struct {
+	Height StrInt64 `json:"height"`
+	Index  StrInt   `json:"index"`
+}
+type EventJournalArgs struct {
+	Consumer string `json:"consumer"`
+	// Height and Sequence are the cursor of the first returned entry (0 height - cursor acknowledged by consumer).
+	Height   StrInt64 `json:"height"`
+	Sequence StrInt64 `json:"sequence"`
+	Limit    StrInt   `json:"limit"`
+}
+type EventJournalAckArgs struct {
+	Consumer string   `json:"consumer"`
+	Height   StrInt64 `json:"height"`
+	Sequence StrInt64 `json:"sequence"`
+}
+type ChainStatsArgs struct {
+}
+type CheckpointArgs struct {
+	Height StrInt64 `json:"height"`
+}
 type CommitArgs struct {
 	Height StrInt64 `json:"height"`
 }
@@ -54,6 +98,12 @@ type TxArgs struct {
 	Hash  []byte `json:"hash"`
 	Prove bool   `json:"prove"`
 }
+type TxTraceArgs struct {
+	Hash []byte `json:"hash"`
+}
+type TxStatusArgs struct {
+	Hash []byte `json:"hash"`
+}
 type TxSearchArgs struct {
 	Query   string `json:"query"`
 	Prove   bool   `json:"prove"`
@@ -84,6 +134,8 @@ type UnconfirmedTxsArgs struct {
 }
 type NumUnconfirmedTxsArgs struct {
 }
+type MinGasPriceArgs struct {
+}
 
 // tx broadcast API
 type BroadcastTxCommitArgs struct {
@@ -95,6 +147,15 @@ type BroadcastTxSyncArgs struct {
 type BroadcastTxAsyncArgs struct {
 	Tx types.Tx `json:"tx"`
 }
+type BroadcastTxBatchArgs struct {
+	Txs []types.Tx `json:"txs"`
+}
+type BroadcastTxOrderedArgs struct {
+	Tx types.Tx `json:"tx"`
+}
+type CheckOrderingReceiptArgs struct {
+	Receipt optypes.OrderingReceipt `json:"receipt"`
+}
 
 // abci API
 type ABCIQueryArgs struct {
@@ -105,12 +166,25 @@ type ABCIQueryArgs struct {
 }
 type ABCIInfoArgs struct {
 }
+type ListSnapshotsArgs struct {
+}
 
 // evidence API
 type BroadcastEvidenceArgs struct {
 	Evidence types.Evidence `json:"evidence"`
 }
 
+// admin API
+type StartAggregatingArgs struct {
+}
+type StopAggregatingArgs struct {
+}
+type DumpMempoolArgs struct {
+}
+type LoadMempoolArgs struct {
+	Txs []types.Tx `json:"txs"`
+}
+
 type EmptyResult struct{}
 
 // JSON-deserialization specific types
@@ -140,6 +214,8 @@ func unmarshalStrInt64(b []byte, s *StrInt64) error {
 	}
 
 	switch v := i.(type) {
+	case float64:
+		*s = StrInt64(v)
 	case int:
 		*s = StrInt64(v)
 	case int64:
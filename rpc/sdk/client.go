@@ -0,0 +1,48 @@
+// Package sdk adapts Optimint RPC client to the interface used by Cosmos SDK client.Context.
+package sdk
+
+import (
+	"context"
+
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/celestiaorg/optimint/node"
+	"github.com/celestiaorg/optimint/rpc/client"
+)
+
+// TendermintRPC is the subset of Tendermint RPC client used by Cosmos SDK queries (baseapp gRPC routing),
+// tx service and Tendermint service. It mirrors client.TendermintRPC from Cosmos SDK, and is defined here
+// to avoid dependency on Cosmos SDK.
+type TendermintRPC interface {
+	rpcclient.ABCIClient
+
+	Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error)
+	Status(context.Context) (*ctypes.ResultStatus, error)
+	Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error)
+	BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error)
+	BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error)
+	BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error)
+	Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
+	Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
+	TxSearch(
+		ctx context.Context,
+		query string,
+		prove bool,
+		page, perPage *int,
+		orderBy string,
+	) (*ctypes.ResultTxSearch, error)
+	BlockSearch(
+		ctx context.Context,
+		query string,
+		page, perPage *int,
+		orderBy string,
+	) (*ctypes.ResultBlockSearch, error)
+}
+
+var _ TendermintRPC = &client.Client{}
+
+// New returns client of given node, that can be used by Cosmos SDK, e.g. clientCtx.WithClient(sdk.New(node)).
+func New(n *node.Node) TendermintRPC {
+	return client.NewClient(n)
+}
@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/config"
+	"github.com/celestiaorg/optimint/node"
+)
+
+func TestTendermintRPC(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	rawKey, err := key.GetPublic().Raw()
+	require.NoError(err)
+	pubKey := ed25519.PubKey(rawKey)
+	genesis := &types.GenesisDoc{
+		ChainID: "sdk-test",
+		Validators: []types.GenesisValidator{{
+			Address: pubKey.Address(),
+			PubKey:  pubKey,
+			Power:   1,
+			Name:    "sequencer",
+		}},
+	}
+	conf := config.NodeConfig{
+		DALayer:            "mock",
+		Aggregator:         true,
+		BlockManagerConfig: config.BlockManagerConfig{BlockTime: 100 * time.Millisecond},
+	}
+	n, err := node.NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(kvstore.NewApplication()), genesis, log.TestingLogger())
+	require.NoError(err)
+	require.NoError(n.Start())
+	defer func() {
+		assert.NoError(n.Stop())
+	}()
+
+	rpc := New(n)
+	ctx := context.Background()
+
+	// tx service
+	txRes, err := rpc.BroadcastTxCommit(ctx, types.Tx("name=satoshi"))
+	require.NoError(err)
+	require.EqualValues(0, txRes.CheckTx.Code)
+	require.EqualValues(0, txRes.DeliverTx.Code)
+
+	// transactions are indexed asynchronously
+	require.Eventually(func() bool {
+		tx, err := rpc.Tx(ctx, txRes.Hash, false)
+		return err == nil && tx.Height == txRes.Height
+	}, time.Second, 10*time.Millisecond)
+
+	search, err := rpc.TxSearch(ctx, "app.key='name'", false, nil, nil, "")
+	require.NoError(err)
+	assert.Equal(1, search.TotalCount)
+
+	// queries
+	query, err := rpc.ABCIQuery(ctx, "", []byte("name"))
+	require.NoError(err)
+	assert.Equal([]byte("satoshi"), query.Response.Value)
+
+	// tendermint service
+	status, err := rpc.Status(ctx)
+	require.NoError(err)
+	assert.Equal(genesis.ChainID, status.NodeInfo.Network)
+	assert.GreaterOrEqual(status.SyncInfo.LatestBlockHeight, txRes.Height)
+
+	block, err := rpc.Block(ctx, &txRes.Height)
+	require.NoError(err)
+	assert.Len(block.Block.Txs, 1)
+
+	results, err := rpc.BlockResults(ctx, &txRes.Height)
+	require.NoError(err)
+	assert.Len(results.TxsResults, 1)
+
+	commit, err := rpc.Commit(ctx, &txRes.Height)
+	require.NoError(err)
+	assert.Equal(txRes.Height, commit.Height)
+
+	info, err := rpc.BlockchainInfo(ctx, 1, txRes.Height)
+	require.NoError(err)
+	require.NotEmpty(info.BlockMetas)
+	assert.Equal(txRes.Height, info.BlockMetas[0].Header.Height)
+	assert.Equal(1, info.BlockMetas[0].NumTxs)
+
+	vals, err := rpc.Validators(ctx, nil, nil, nil)
+	require.NoError(err)
+	require.Len(vals.Validators, 1)
+	assert.Equal(pubKey.Address(), vals.Validators[0].Address)
+}
@@ -25,14 +25,36 @@ type Server struct {
 
 	config *config.RPCConfig
 	client *client.Client
+	// seedMode is set if node only participates in peer discovery; RPC can't be served then.
+	seedMode bool
+	// compatVersion and eventEncoding select the shape of JSON-RPC responses (see optimint config.RPCConfig).
+	compatVersion string
+	eventEncoding string
+	// ethNamespace enables Ethereum JSON-RPC facade (see optimint config.RPCConfig).
+	ethNamespace bool
+	// readOnly disables transaction broadcasting and admin methods (see optimint config.RPCConfig).
+	readOnly bool
+	// slowQueryThreshold enables logging of slow method calls (see optimint config.RPCConfig).
+	slowQueryThreshold time.Duration
+	metrics            *json.Metrics
 
 	server http.Server
 }
 
 func NewServer(node *node.Node, config *config.RPCConfig, logger log.Logger) *Server {
 	srv := &Server{
-		config: config,
-		client: client.NewClient(node),
+		config:             config,
+		client:             client.NewClient(node),
+		seedMode:           node.SeedMode(),
+		compatVersion:      node.RPCConfig().CompatVersion,
+		eventEncoding:      node.RPCConfig().EventEncoding,
+		ethNamespace:       node.RPCConfig().EthNamespace,
+		readOnly:           node.RPCConfig().ReadOnly,
+		slowQueryThreshold: node.RPCConfig().SlowQueryThreshold,
+		metrics:            json.NopMetrics(),
+	}
+	if instrumentation := node.InstrumentationConfig(); instrumentation.Prometheus {
+		srv.metrics = json.PrometheusMetrics(node.MetricsRegistry(), instrumentation.Namespace, "chain_id", node.GetGenesis().ChainID)
 	}
 	srv.BaseService = service.NewBaseService(logger, "RPC", srv)
 	return srv
@@ -43,6 +65,9 @@ func (s *Server) Client() rpcclient.Client {
 }
 
 func (s *Server) OnStart() error {
+	if s.seedMode {
+		return errors.New("RPC is not available in seed mode")
+	}
 	return s.startRPC()
 }
 
@@ -76,7 +101,10 @@ func (s *Server) startRPC() error {
 		listener = netutil.LimitListener(listener, s.config.MaxOpenConnections)
 	}
 
-	handler, err := json.GetHttpHandler(s.client, s.Logger)
+	handler, err := json.GetHttpHandler(s.client, s.Logger, json.WithCompatVersion(s.compatVersion),
+		json.WithEventEncoding(s.eventEncoding), json.WithUnsafe(s.config.Unsafe),
+		json.WithEthNamespace(s.ethNamespace), json.WithReadOnly(s.readOnly),
+		json.WithMetrics(s.metrics), json.WithSlowQueryThreshold(s.slowQueryThreshold))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,43 @@
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	tmcfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/config"
+	"github.com/celestiaorg/optimint/mocks"
+	"github.com/celestiaorg/optimint/node"
+)
+
+func TestSeedModeRefused(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	rawKey, err := key.GetPublic().Raw()
+	require.NoError(err)
+	genesis := &tmtypes.GenesisDoc{
+		ChainID:    "test",
+		Validators: []tmtypes.GenesisValidator{{PubKey: ed25519.PubKey(rawKey), Power: 1}},
+	}
+	conf := config.NodeConfig{P2P: config.P2PConfig{SeedMode: true}}
+	n, err := node.NewNode(context.Background(), conf, key, proxy.NewLocalClientCreator(&mocks.Application{}),
+		genesis, log.TestingLogger())
+	require.NoError(err)
+
+	srv := NewServer(n, tmcfg.DefaultRPCConfig(), log.TestingLogger())
+	err = srv.Start()
+	assert.EqualError(err, "RPC is not available in seed mode")
+	assert.False(srv.IsRunning())
+}
@@ -0,0 +1,95 @@
+// Package server helps Cosmos SDK based chains to run Optimint instead of Tendermint node.
+package server
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/spf13/viper"
+	tmcfg "github.com/tendermint/tendermint/config"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/proxy"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/config"
+	"github.com/celestiaorg/optimint/conv"
+	"github.com/celestiaorg/optimint/node"
+)
+
+// GenesisDocProvider returns genesis document of the chain (see Tendermint node.GenesisDocProvider).
+type GenesisDocProvider func() (*tmtypes.GenesisDoc, error)
+
+// DefaultGenesisDocProvider returns GenesisDocProvider reading genesis document from the file set in Tendermint config.
+func DefaultGenesisDocProvider(tmConf *tmcfg.Config) GenesisDocProvider {
+	return func() (*tmtypes.GenesisDoc, error) {
+		return tmtypes.GenesisDocFromFile(tmConf.GenesisFile())
+	}
+}
+
+// NewNode creates Optimint node, using the same inputs as Tendermint node created in Cosmos SDK `start` command.
+//
+// Tendermint configuration is translated into Optimint configuration. Optimint specific options are read from v
+// (flags registered with config.AddFlags); if v is nil, default values are used.
+//
+// Aggregator signs blocks with the key of private validator (so it matches the validator in genesis), and the same
+// key is used as P2P identity. Other nodes use node key (generated, if it doesn't exist).
+func NewNode(
+	ctx context.Context,
+	tmConf *tmcfg.Config,
+	v *viper.Viper,
+	clientCreator proxy.ClientCreator,
+	genesisDocProvider GenesisDocProvider,
+	logger log.Logger,
+	opts ...node.Option,
+) (*node.Node, error) {
+	nodeConf := config.DefaultNodeConfig
+	if v != nil {
+		if err := nodeConf.GetViperConfig(v); err != nil {
+			return nil, fmt.Errorf("failed to read Optimint configuration: %w", err)
+		}
+	}
+	conv.GetNodeConfig(&nodeConf, tmConf)
+	if err := conv.TranslateAddresses(&nodeConf); err != nil {
+		return nil, fmt.Errorf("failed to translate addresses: %w", err)
+	}
+
+	genesis, err := genesisDocProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load genesis: %w", err)
+	}
+
+	key, err := loadKey(tmConf, nodeConf.Aggregator)
+	if err != nil {
+		return nil, err
+	}
+
+	return node.NewNode(ctx, nodeConf, key, clientCreator, genesis, logger, opts...)
+}
+
+// loadKey returns private validator key for aggregator, and node key for other nodes.
+func loadKey(tmConf *tmcfg.Config, aggregator bool) (crypto.PrivKey, error) {
+	var nodeKey *p2p.NodeKey
+	if aggregator {
+		blob, err := ioutil.ReadFile(tmConf.PrivValidatorKeyFile())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private validator key: %w", err)
+		}
+		var pvKey privval.FilePVKey
+		if err := tmjson.Unmarshal(blob, &pvKey); err != nil {
+			return nil, fmt.Errorf("failed to parse private validator key: %w", err)
+		}
+		nodeKey = &p2p.NodeKey{PrivKey: pvKey.PrivKey}
+	} else {
+		var err error
+		nodeKey, err = p2p.LoadOrGenNodeKey(tmConf.NodeKeyFile())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load node key: %w", err)
+		}
+	}
+	return conv.GetNodeKey(nodeKey)
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	tmcfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/proxy"
+
+	"github.com/celestiaorg/optimint/config"
+)
+
+func TestNewNode(t *testing.T) {
+	cases := []struct {
+		name       string
+		aggregator bool
+	}{
+		{"full node", false},
+		{"aggregator", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			tmConf := tmcfg.ResetTestRoot("optimint-server")
+			defer os.RemoveAll(tmConf.RootDir)
+			tmConf.P2P.ListenAddress = "tcp://127.0.0.1:0"
+
+			cmd := &cobra.Command{}
+			config.AddFlags(cmd)
+			v := viper.New()
+			require.NoError(v.BindPFlags(cmd.Flags()))
+			v.Set("optimint.aggregator", c.aggregator)
+
+			n, err := NewNode(context.Background(), tmConf, v, proxy.NewLocalClientCreator(kvstore.NewApplication()),
+				DefaultGenesisDocProvider(tmConf), log.TestingLogger())
+			require.NoError(err)
+			require.NotNil(n)
+			assert.Equal(tmConf.ChainID(), n.GetGenesis().ChainID)
+
+			require.NoError(n.Start())
+			require.NoError(n.Stop())
+
+			key, err := loadKey(tmConf, c.aggregator)
+			require.NoError(err)
+			raw, err := key.Raw()
+			require.NoError(err)
+			if c.aggregator {
+				pv := privval.LoadFilePV(tmConf.PrivValidatorKeyFile(), tmConf.PrivValidatorStateFile())
+				assert.Equal(pv.Key.PrivKey.Bytes(), raw)
+			} else {
+				nodeKey, err := p2p.LoadNodeKey(tmConf.NodeKeyFile())
+				require.NoError(err)
+				assert.Equal(nodeKey.PrivKey.Bytes(), raw)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/settlement"
+	"github.com/celestiaorg/optimint/store"
+)
+
+// SettlementLayerClient connects to settlement layer via gRPC.
+type SettlementLayerClient struct {
+	config Config
+
+	conn   *grpc.ClientConn
+	client SettlementServiceClient
+
+	logger log.Logger
+}
+
+// Config is a configuration of gRPC settlement layer client.
+type Config struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+// DefaultConfig is used when no configuration is provided.
+var DefaultConfig = Config{
+	Host: "127.0.0.1",
+	Port: 7981,
+}
+
+var _ settlement.SettlementLayerClient = &SettlementLayerClient{}
+
+// Init is called once to allow settlement client to read configuration and initialize resources.
+func (s *SettlementLayerClient) Init(config []byte, _ store.KVStore, logger log.Logger) error {
+	s.logger = logger
+	if len(config) == 0 {
+		s.config = DefaultConfig
+		return nil
+	}
+	return json.Unmarshal(config, &s.config)
+}
+
+// Start dials settlement layer service.
+func (s *SettlementLayerClient) Start() error {
+	s.logger.Info("starting gRPC settlement layer client", "host", s.config.Host, "port", s.config.Port)
+	var err error
+	s.conn, err = grpc.Dial(s.config.Host+":"+strconv.Itoa(s.config.Port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	s.client = NewSettlementServiceClient(s.conn)
+	return nil
+}
+
+// Stop closes connection to settlement layer service.
+func (s *SettlementLayerClient) Stop() error {
+	s.logger.Info("stopping gRPC settlement layer client")
+	return s.conn.Close()
+}
+
+// SubmitCommitment posts state commitment of an epoch to settlement layer.
+func (s *SettlementLayerClient) SubmitCommitment(commitment *settlement.StateCommitment) settlement.ResultSubmitCommitment {
+	resp, err := s.client.SubmitCommitment(context.TODO(), commitment)
+	if err != nil {
+		return settlement.ResultSubmitCommitment{Result: errorResult(err)}
+	}
+	return *resp
+}
+
+// RetrieveCommitment returns commitment of the epoch containing block at given height.
+func (s *SettlementLayerClient) RetrieveCommitment(height uint64) settlement.ResultRetrieveCommitment {
+	resp, err := s.client.RetrieveCommitment(context.TODO(), &RetrieveCommitmentRequest{Height: height})
+	if err != nil {
+		return settlement.ResultRetrieveCommitment{Result: errorResult(err)}
+	}
+	return *resp
+}
+
+// LatestCommitment returns the latest commitment.
+func (s *SettlementLayerClient) LatestCommitment() settlement.ResultRetrieveCommitment {
+	resp, err := s.client.LatestCommitment(context.TODO(), &LatestCommitmentRequest{})
+	if err != nil {
+		return settlement.ResultRetrieveCommitment{Result: errorResult(err)}
+	}
+	return *resp
+}
+
+func errorResult(err error) settlement.Result {
+	return settlement.Result{Code: settlement.StatusError, Message: err.Error()}
+}
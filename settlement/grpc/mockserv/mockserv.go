@@ -0,0 +1,48 @@
+package mockserv
+
+import (
+	"context"
+	"os"
+
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	"google.golang.org/grpc"
+
+	"github.com/celestiaorg/optimint/settlement"
+	grpcsl "github.com/celestiaorg/optimint/settlement/grpc"
+	"github.com/celestiaorg/optimint/settlement/mock"
+	"github.com/celestiaorg/optimint/store"
+)
+
+// GetServer returns gRPC server of mock settlement layer. config is passed to mock settlement layer client.
+func GetServer(kv store.KVStore, config []byte) *grpc.Server {
+	logger := tmlog.NewTMLogger(os.Stdout)
+
+	srv := grpc.NewServer()
+	mockImpl := &mockImpl{}
+	err := mockImpl.mock.Init(config, kv, logger)
+	if err != nil {
+		logger.Error("failed to initialize mock settlement layer client", "error", err)
+		panic(err)
+	}
+	grpcsl.RegisterSettlementServiceServer(srv, mockImpl)
+	return srv
+}
+
+type mockImpl struct {
+	mock mock.SettlementLayerClient
+}
+
+func (m *mockImpl) SubmitCommitment(_ context.Context, commitment *settlement.StateCommitment) (*settlement.ResultSubmitCommitment, error) {
+	resp := m.mock.SubmitCommitment(commitment)
+	return &resp, nil
+}
+
+func (m *mockImpl) RetrieveCommitment(_ context.Context, request *grpcsl.RetrieveCommitmentRequest) (*settlement.ResultRetrieveCommitment, error) {
+	resp := m.mock.RetrieveCommitment(request.Height)
+	return &resp, nil
+}
+
+func (m *mockImpl) LatestCommitment(context.Context, *grpcsl.LatestCommitmentRequest) (*settlement.ResultRetrieveCommitment, error) {
+	resp := m.mock.LatestCommitment()
+	return &resp, nil
+}
@@ -0,0 +1,156 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+
+	"github.com/celestiaorg/optimint/settlement"
+)
+
+// codecName is the content-subtype of settlement service messages. There are no protobuf definitions for settlement
+// service - messages are encoded as JSON, and service descriptor is written by hand.
+const codecName = "settlement-json"
+
+func init() {
+	encoding.RegisterCodec(codec{})
+}
+
+// RetrieveCommitmentRequest is a request of commitment of the epoch containing block at given height.
+type RetrieveCommitmentRequest struct {
+	Height uint64 `json:"height"`
+}
+
+// LatestCommitmentRequest is a request of the latest commitment.
+type LatestCommitmentRequest struct {
+}
+
+// SettlementServiceClient is the client API of settlement service.
+type SettlementServiceClient interface {
+	SubmitCommitment(ctx context.Context, in *settlement.StateCommitment, opts ...grpc.CallOption) (*settlement.ResultSubmitCommitment, error)
+	RetrieveCommitment(ctx context.Context, in *RetrieveCommitmentRequest, opts ...grpc.CallOption) (*settlement.ResultRetrieveCommitment, error)
+	LatestCommitment(ctx context.Context, in *LatestCommitmentRequest, opts ...grpc.CallOption) (*settlement.ResultRetrieveCommitment, error)
+}
+
+type settlementServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+// NewSettlementServiceClient returns settlement service client using given connection.
+func NewSettlementServiceClient(cc *grpc.ClientConn) SettlementServiceClient {
+	return &settlementServiceClient{cc}
+}
+
+func (c *settlementServiceClient) SubmitCommitment(ctx context.Context, in *settlement.StateCommitment, opts ...grpc.CallOption) (*settlement.ResultSubmitCommitment, error) {
+	out := new(settlement.ResultSubmitCommitment)
+	err := c.cc.Invoke(ctx, "/"+serviceName+"/SubmitCommitment", in, out, append(opts, grpc.CallContentSubtype(codecName))...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settlementServiceClient) RetrieveCommitment(ctx context.Context, in *RetrieveCommitmentRequest, opts ...grpc.CallOption) (*settlement.ResultRetrieveCommitment, error) {
+	out := new(settlement.ResultRetrieveCommitment)
+	err := c.cc.Invoke(ctx, "/"+serviceName+"/RetrieveCommitment", in, out, append(opts, grpc.CallContentSubtype(codecName))...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *settlementServiceClient) LatestCommitment(ctx context.Context, in *LatestCommitmentRequest, opts ...grpc.CallOption) (*settlement.ResultRetrieveCommitment, error) {
+	out := new(settlement.ResultRetrieveCommitment)
+	err := c.cc.Invoke(ctx, "/"+serviceName+"/LatestCommitment", in, out, append(opts, grpc.CallContentSubtype(codecName))...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SettlementServiceServer is the server API of settlement service.
+type SettlementServiceServer interface {
+	SubmitCommitment(context.Context, *settlement.StateCommitment) (*settlement.ResultSubmitCommitment, error)
+	RetrieveCommitment(context.Context, *RetrieveCommitmentRequest) (*settlement.ResultRetrieveCommitment, error)
+	LatestCommitment(context.Context, *LatestCommitmentRequest) (*settlement.ResultRetrieveCommitment, error)
+}
+
+// RegisterSettlementServiceServer registers settlement service implementation in gRPC server.
+func RegisterSettlementServiceServer(s *grpc.Server, srv SettlementServiceServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+const serviceName = "settlement.SettlementService"
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*SettlementServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "SubmitCommitment", Handler: submitCommitmentHandler},
+		{MethodName: "RetrieveCommitment", Handler: retrieveCommitmentHandler},
+		{MethodName: "LatestCommitment", Handler: latestCommitmentHandler},
+	},
+	Metadata: "settlement",
+}
+
+func submitCommitmentHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(settlement.StateCommitment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettlementServiceServer).SubmitCommitment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/SubmitCommitment"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettlementServiceServer).SubmitCommitment(ctx, req.(*settlement.StateCommitment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func retrieveCommitmentHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetrieveCommitmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettlementServiceServer).RetrieveCommitment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/RetrieveCommitment"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettlementServiceServer).RetrieveCommitment(ctx, req.(*RetrieveCommitmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func latestCommitmentHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LatestCommitmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SettlementServiceServer).LatestCommitment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/LatestCommitment"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SettlementServiceServer).LatestCommitment(ctx, req.(*LatestCommitmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// codec encodes messages of settlement service as JSON.
+type codec struct{}
+
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (codec) Name() string {
+	return codecName
+}
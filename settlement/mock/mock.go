@@ -0,0 +1,185 @@
+package mock
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/settlement"
+	"github.com/celestiaorg/optimint/store"
+)
+
+// SettlementLayerClient is intended only for usage in tests. It stores commitments in KVStore, and enforces
+// contiguity of epochs like a settlement contract would.
+type SettlementLayerClient struct {
+	config        Config
+	finalityDelay time.Duration
+	logger        log.Logger
+	kv            store.KVStore
+
+	// mtx guards submissions, as every submission depends on the previous one
+	mtx sync.Mutex
+}
+
+// Config is a configuration of mock settlement layer client.
+type Config struct {
+	// FinalityDelay is the time after which submitted commitment is finalized, e.g. "10s".
+	// If it's empty, commitments are finalized immediately.
+	FinalityDelay string `json:"finality_delay"`
+}
+
+// record is a commitment saved in KVStore.
+type record struct {
+	Commitment       settlement.StateCommitment `json:"commitment"`
+	SettlementHeight uint64                     `json:"settlement_height"`
+	SubmittedAt      time.Time                  `json:"submitted_at"`
+}
+
+var _ settlement.SettlementLayerClient = &SettlementLayerClient{}
+
+var (
+	commitmentPrefix = []byte("commitment/")
+	latestKey        = []byte("latest")
+)
+
+// Init is called once to allow settlement client to read configuration and initialize resources.
+func (m *SettlementLayerClient) Init(config []byte, kv store.KVStore, logger log.Logger) error {
+	m.logger = logger
+	m.kv = kv
+	if m.kv == nil {
+		m.kv = store.NewDefaultInMemoryKVStore()
+	}
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &m.config); err != nil {
+			return err
+		}
+	}
+	if m.config.FinalityDelay != "" {
+		delay, err := time.ParseDuration(m.config.FinalityDelay)
+		if err != nil {
+			return fmt.Errorf("invalid finality delay: %w", err)
+		}
+		m.finalityDelay = delay
+	}
+	return nil
+}
+
+// Start implements SettlementLayerClient interface.
+func (m *SettlementLayerClient) Start() error {
+	m.logger.Debug("Mock Settlement Layer Client starting")
+	return nil
+}
+
+// Stop implements SettlementLayerClient interface.
+func (m *SettlementLayerClient) Stop() error {
+	m.logger.Debug("Mock Settlement Layer Client stopped")
+	return nil
+}
+
+// SubmitCommitment saves commitment, if it starts right after the latest one.
+func (m *SettlementLayerClient) SubmitCommitment(commitment *settlement.StateCommitment) settlement.ResultSubmitCommitment {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	latest, err := m.latest()
+	if err != nil && !errors.Is(err, store.ErrKeyNotFound) {
+		return settlement.ResultSubmitCommitment{Result: errorResult(err)}
+	}
+	if commitment.EndHeight < commitment.StartHeight {
+		return settlement.ResultSubmitCommitment{Result: errorResult(fmt.Errorf("invalid epoch %d-%d",
+			commitment.StartHeight, commitment.EndHeight))}
+	}
+	// the first epoch may start at any height (chain may start at height greater than 1)
+	var settlementHeight uint64 = 1
+	if latest != nil {
+		if expected := latest.Commitment.EndHeight + 1; commitment.StartHeight != expected {
+			return settlement.ResultSubmitCommitment{Result: errorResult(fmt.Errorf("invalid epoch %d-%d, expected start height %d",
+				commitment.StartHeight, commitment.EndHeight, expected))}
+		}
+		settlementHeight = latest.SettlementHeight + 1
+	}
+
+	blob, err := json.Marshal(&record{Commitment: *commitment, SettlementHeight: settlementHeight, SubmittedAt: time.Now()})
+	if err != nil {
+		return settlement.ResultSubmitCommitment{Result: errorResult(err)}
+	}
+	batch := m.kv.NewBatch()
+	err = multierr.Append(batch.Set(getCommitmentKey(commitment.EndHeight), blob), batch.Set(latestKey, blob))
+	if err != nil {
+		batch.Discard()
+		return settlement.ResultSubmitCommitment{Result: errorResult(err)}
+	}
+	if err := batch.Commit(); err != nil {
+		return settlement.ResultSubmitCommitment{Result: errorResult(err)}
+	}
+	return settlement.ResultSubmitCommitment{
+		Result: settlement.Result{Code: settlement.StatusSuccess, Message: "OK", SettlementHeight: settlementHeight},
+	}
+}
+
+// RetrieveCommitment returns commitment of the epoch containing block at given height.
+func (m *SettlementLayerClient) RetrieveCommitment(height uint64) settlement.ResultRetrieveCommitment {
+	it := m.kv.PrefixIterator(commitmentPrefix)
+	defer it.Discard()
+	for ; it.Valid(); it.Next() {
+		var r record
+		if err := json.Unmarshal(it.Value(), &r); err != nil {
+			return settlement.ResultRetrieveCommitment{Result: errorResult(err)}
+		}
+		if r.Commitment.StartHeight <= height && height <= r.Commitment.EndHeight {
+			return m.result(&r)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return settlement.ResultRetrieveCommitment{Result: errorResult(err)}
+	}
+	return settlement.ResultRetrieveCommitment{Result: settlement.Result{Code: settlement.StatusNotFound}}
+}
+
+// LatestCommitment returns the latest commitment.
+func (m *SettlementLayerClient) LatestCommitment() settlement.ResultRetrieveCommitment {
+	latest, err := m.latest()
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return settlement.ResultRetrieveCommitment{Result: settlement.Result{Code: settlement.StatusNotFound}}
+	}
+	if err != nil {
+		return settlement.ResultRetrieveCommitment{Result: errorResult(err)}
+	}
+	return m.result(latest)
+}
+
+func (m *SettlementLayerClient) latest() (*record, error) {
+	blob, err := m.kv.Get(latestKey)
+	if err != nil {
+		return nil, err
+	}
+	var r record
+	err = json.Unmarshal(blob, &r)
+	return &r, err
+}
+
+func (m *SettlementLayerClient) result(r *record) settlement.ResultRetrieveCommitment {
+	return settlement.ResultRetrieveCommitment{
+		Result:     settlement.Result{Code: settlement.StatusSuccess, SettlementHeight: r.SettlementHeight},
+		Commitment: &r.Commitment,
+		Finalized:  time.Since(r.SubmittedAt) >= m.finalityDelay,
+	}
+}
+
+func errorResult(err error) settlement.Result {
+	return settlement.Result{Code: settlement.StatusError, Message: err.Error()}
+}
+
+// getCommitmentKey returns key of commitment, ordered by end height.
+func getCommitmentKey(endHeight uint64) []byte {
+	key := make([]byte, len(commitmentPrefix)+8)
+	copy(key, commitmentPrefix)
+	binary.BigEndian.PutUint64(key[len(commitmentPrefix):], endHeight)
+	return key
+}
@@ -0,0 +1,22 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	assert := assert.New(t)
+
+	expected := []string{"mock", "grpc"}
+	actual := RegisteredClients()
+
+	assert.ElementsMatch(expected, actual)
+
+	for _, e := range expected {
+		assert.NotNil(GetClient(e))
+	}
+
+	assert.Nil(GetClient("nonexistent"))
+}
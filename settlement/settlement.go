@@ -0,0 +1,78 @@
+package settlement
+
+import (
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/store"
+)
+
+// StatusCode is a type for settlement layer return status.
+type StatusCode uint64
+
+// Settlement layer return codes.
+const (
+	StatusUnknown StatusCode = iota
+	StatusSuccess
+	StatusNotFound
+	StatusError
+)
+
+// Result is a common part of results returned from settlement layer client.
+type Result struct {
+	// Code is to determine if the action succeeded.
+	Code StatusCode `json:"code"`
+	// Message may contain settlement layer specific information (like transaction hash, detailed error message, etc)
+	Message string `json:"message"`
+	// SettlementHeight informs about a height on settlement layer for given result.
+	SettlementHeight uint64 `json:"settlement_height"`
+}
+
+// StateCommitment commits to the state of the chain after a range of blocks (epoch). Epochs are contiguous - every
+// commitment starts right after the end of the previous one.
+type StateCommitment struct {
+	// StartHeight is the height of the first block of the epoch.
+	StartHeight uint64 `json:"start_height"`
+	// EndHeight is the height of the last block of the epoch.
+	EndHeight uint64 `json:"end_height"`
+	// BlockHash is the hash of the last block of the epoch.
+	BlockHash []byte `json:"block_hash"`
+	// StateRoot is the application state root (app hash) after applying the last block of the epoch.
+	StateRoot []byte `json:"state_root"`
+	// DAHeight is the height of DA layer block that includes the last block of the epoch.
+	DAHeight uint64 `json:"da_height"`
+}
+
+// ResultSubmitCommitment contains information returned from settlement layer after commitment submission.
+type ResultSubmitCommitment struct {
+	Result
+}
+
+// ResultRetrieveCommitment contains commitment returned from settlement layer client.
+type ResultRetrieveCommitment struct {
+	Result
+	// Commitment is the state commitment. If Code is not equal to StatusSuccess, it has to be nil.
+	Commitment *StateCommitment `json:"commitment"`
+	// Finalized is true if the commitment can't be disputed or reverted anymore.
+	Finalized bool `json:"finalized"`
+}
+
+// SettlementLayerClient defines generic interface for posting state commitments to settlement layer, e.g. L1
+// smart contract or settlement chain. It's optional - Optimint doesn't require a settlement layer.
+// It also contains life-cycle methods.
+type SettlementLayerClient interface {
+	// Init is called once to allow settlement client to read configuration and initialize resources.
+	Init(config []byte, kvStore store.KVStore, logger log.Logger) error
+
+	Start() error
+	Stop() error
+
+	// SubmitCommitment posts state commitment of an epoch to settlement layer.
+	SubmitCommitment(commitment *StateCommitment) ResultSubmitCommitment
+
+	// RetrieveCommitment returns commitment of the epoch containing block at given height.
+	// Code is StatusNotFound if there is no such commitment.
+	RetrieveCommitment(height uint64) ResultRetrieveCommitment
+
+	// LatestCommitment returns the latest commitment (finalized or not). Code is StatusNotFound if there are no
+	// commitments yet.
+	LatestCommitment() ResultRetrieveCommitment
+}
@@ -0,0 +1,208 @@
+package settlement
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// Submitter posts state commitments of epochs to settlement layer. Commitment of an epoch is submitted after the last
+// block of the epoch is applied and included in DA layer.
+type Submitter struct {
+	client SettlementLayerClient
+	store  store.Store
+	epoch  uint64
+	logger log.Logger
+
+	mtx sync.Mutex
+	// pending contains commitments of epochs, by end height, that are not submitted yet
+	pending map[uint64]*pendingCommitment
+	// daHeights contains DA heights of last blocks of epochs, by block height
+	daHeights map[uint64]uint64
+	// submitted is the end height of the latest commitment on settlement layer, valid if initialized is true
+	submitted   uint64
+	initialized bool
+
+	ready chan struct{}
+}
+
+// NewSubmitter creates new Submitter, committing to the state every epoch blocks.
+func NewSubmitter(client SettlementLayerClient, store store.Store, epoch uint64, logger log.Logger) *Submitter {
+	return &Submitter{
+		client:    client,
+		store:     store,
+		epoch:     epoch,
+		logger:    logger,
+		pending:   make(map[uint64]*pendingCommitment),
+		daHeights: make(map[uint64]uint64),
+		ready:     make(chan struct{}, 1),
+	}
+}
+
+// BlockApplied records state root after the last block of epoch is applied. It's intended to be used as
+// block.Hooks.OnBlockApplied.
+func (s *Submitter) BlockApplied(block *types.Block) {
+	height := block.Header.Height
+	if height%s.epoch != 0 {
+		return
+	}
+	st, err := s.store.LoadState()
+	if err != nil {
+		s.logger.Error("failed to load state for settlement", "height", height, "error", err)
+		return
+	}
+	hash := block.Hash()
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	start := uint64(st.InitialHeight)
+	if height >= s.epoch && height-s.epoch+1 > start {
+		start = height - s.epoch + 1
+	}
+	p := &pendingCommitment{commitment: StateCommitment{
+		StartHeight: start,
+		EndHeight:   height,
+		BlockHash:   hash[:],
+		StateRoot:   st.AppHash[:],
+	}}
+	s.pending[height] = p
+	if daHeight, ok := s.daHeights[height]; ok {
+		delete(s.daHeights, height)
+		p.setDAHeight(daHeight)
+		s.notify()
+	}
+}
+
+// DAIncluded records DA height of the last block of epoch. It's intended to be used as block.Hooks.OnDAIncluded.
+func (s *Submitter) DAIncluded(block *types.Block, daHeight uint64) {
+	height := block.Header.Height
+	if height%s.epoch != 0 {
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if p, ok := s.pending[height]; ok {
+		p.setDAHeight(daHeight)
+		s.notify()
+		return
+	}
+	s.daHeights[height] = daHeight
+}
+
+// SubmitLoop submits commitments as soon as they are ready, until context is cancelled. Failed submissions are
+// retried every retryInterval.
+func (s *Submitter) SubmitLoop(ctx context.Context, retryInterval time.Duration) {
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ready:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		if err := s.Submit(); err != nil {
+			s.logger.Error("failed to submit state commitment", "error", err)
+		}
+	}
+}
+
+// Submit submits all ready commitments, in order of heights.
+func (s *Submitter) Submit() error {
+	if err := s.init(); err != nil {
+		return err
+	}
+	for {
+		commitment := s.next()
+		if commitment == nil {
+			return nil
+		}
+		res := s.client.SubmitCommitment(commitment)
+		if res.Code != StatusSuccess {
+			return fmt.Errorf("epoch %d-%d: %s", commitment.StartHeight, commitment.EndHeight, res.Message)
+		}
+		s.logger.Info("submitted state commitment", "start", commitment.StartHeight, "end", commitment.EndHeight,
+			"settlementHeight", res.SettlementHeight)
+
+		s.mtx.Lock()
+		s.submitted = commitment.EndHeight
+		delete(s.pending, commitment.EndHeight)
+		s.mtx.Unlock()
+	}
+}
+
+// init reads the latest commitment from settlement layer, so that submission resumes after restart.
+func (s *Submitter) init() error {
+	s.mtx.Lock()
+	initialized := s.initialized
+	s.mtx.Unlock()
+	if initialized {
+		return nil
+	}
+
+	res := s.client.LatestCommitment()
+	if res.Code != StatusSuccess && res.Code != StatusNotFound {
+		return fmt.Errorf("failed to retrieve latest commitment: %s", res.Message)
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if res.Code == StatusSuccess {
+		s.submitted = res.Commitment.EndHeight
+	}
+	s.initialized = true
+	return nil
+}
+
+// next returns the first pending commitment that is included in DA layer. Commitments that are already submitted are
+// dropped. The start height of returned commitment follows the latest submitted one.
+func (s *Submitter) next() *StateCommitment {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	heights := make([]uint64, 0, len(s.pending))
+	for h := range s.pending {
+		heights = append(heights, h)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	for _, h := range heights {
+		p := s.pending[h]
+		if s.submitted > 0 && h <= s.submitted {
+			delete(s.pending, h)
+			continue
+		}
+		if !p.included {
+			return nil
+		}
+		commitment := p.commitment
+		if s.submitted > 0 {
+			commitment.StartHeight = s.submitted + 1
+		}
+		return &commitment
+	}
+	return nil
+}
+
+// pendingCommitment is a commitment waiting for DA inclusion of the last block of epoch, or for submission.
+type pendingCommitment struct {
+	commitment StateCommitment
+	included   bool
+}
+
+func (p *pendingCommitment) setDAHeight(daHeight uint64) {
+	p.commitment.DAHeight = daHeight
+	p.included = true
+}
+
+func (s *Submitter) notify() {
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,91 @@
+package test
+
+import (
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/celestiaorg/optimint/log/test"
+	"github.com/celestiaorg/optimint/settlement"
+	grpcsl "github.com/celestiaorg/optimint/settlement/grpc"
+	"github.com/celestiaorg/optimint/settlement/grpc/mockserv"
+	"github.com/celestiaorg/optimint/settlement/registry"
+	"github.com/celestiaorg/optimint/store"
+)
+
+func TestSettlementLayerClient(t *testing.T) {
+	for _, name := range registry.RegisteredClients() {
+		t.Run(name, func(t *testing.T) {
+			// every client works with fresh settlement layer
+			srv := startMockServ(t)
+			defer srv.GracefulStop()
+			doTestSettlementLayerClient(t, registry.GetClient(name))
+		})
+	}
+}
+
+func doTestSettlementLayerClient(t *testing.T, slc settlement.SettlementLayerClient) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	require.NoError(slc.Init(nil, store.NewDefaultInMemoryKVStore(), &test.TestLogger{T: t}))
+	require.NoError(slc.Start())
+	defer func() {
+		require.NoError(slc.Stop())
+	}()
+
+	latest := slc.LatestCommitment()
+	assert.Equal(settlement.StatusNotFound, latest.Code)
+	assert.Nil(latest.Commitment)
+
+	c1 := &settlement.StateCommitment{StartHeight: 1, EndHeight: 10, BlockHash: []byte{1}, StateRoot: []byte{2}, DAHeight: 3}
+	c2 := &settlement.StateCommitment{StartHeight: 11, EndHeight: 20, BlockHash: []byte{4}, StateRoot: []byte{5}, DAHeight: 6}
+
+	res := slc.SubmitCommitment(c1)
+	require.Equal(settlement.StatusSuccess, res.Code, res.Message)
+	assert.Equal(uint64(1), res.SettlementHeight)
+
+	// epochs have to be contiguous
+	res = slc.SubmitCommitment(&settlement.StateCommitment{StartHeight: 12, EndHeight: 20})
+	assert.Equal(settlement.StatusError, res.Code)
+	assert.Contains(res.Message, "expected start height 11")
+
+	res = slc.SubmitCommitment(c2)
+	require.Equal(settlement.StatusSuccess, res.Code, res.Message)
+	assert.Equal(uint64(2), res.SettlementHeight)
+
+	latest = slc.LatestCommitment()
+	require.Equal(settlement.StatusSuccess, latest.Code)
+	assert.Equal(c2, latest.Commitment)
+	assert.True(latest.Finalized)
+
+	for _, h := range []uint64{1, 5, 10} {
+		ret := slc.RetrieveCommitment(h)
+		require.Equal(settlement.StatusSuccess, ret.Code)
+		assert.Equal(c1, ret.Commitment)
+		assert.Equal(uint64(1), ret.SettlementHeight)
+	}
+	ret := slc.RetrieveCommitment(11)
+	require.Equal(settlement.StatusSuccess, ret.Code)
+	assert.Equal(c2, ret.Commitment)
+
+	ret = slc.RetrieveCommitment(21)
+	assert.Equal(settlement.StatusNotFound, ret.Code)
+}
+
+func startMockServ(t *testing.T) *grpc.Server {
+	conf := grpcsl.DefaultConfig
+	srv := mockserv.GetServer(store.NewDefaultInMemoryKVStore(), nil)
+	lis, err := net.Listen("tcp", conf.Host+":"+strconv.Itoa(conf.Port))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	return srv
+}
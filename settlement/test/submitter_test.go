@@ -0,0 +1,86 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/optimint/log/test"
+	"github.com/celestiaorg/optimint/settlement"
+	"github.com/celestiaorg/optimint/settlement/mock"
+	"github.com/celestiaorg/optimint/state"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+func TestSubmitter(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	logger := &test.TestLogger{T: t}
+	slc := &mock.SettlementLayerClient{}
+	require.NoError(slc.Init(nil, nil, logger))
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	submitter := settlement.NewSubmitter(slc, s, 5, logger)
+
+	blocks := make(map[uint64]*types.Block)
+	apply := func(height uint64) {
+		blocks[height] = &types.Block{Header: types.Header{Height: height}}
+		require.NoError(s.UpdateState(state.State{InitialHeight: 1, LastBlockHeight: int64(height), AppHash: [32]byte{byte(height)}}))
+		submitter.BlockApplied(blocks[height])
+	}
+
+	for h := uint64(1); h <= 10; h++ {
+		apply(h)
+	}
+	// nothing is submitted before blocks are included in DA layer
+	require.NoError(submitter.Submit())
+	assert.Equal(settlement.StatusNotFound, slc.LatestCommitment().Code)
+
+	// epochs are submitted in order
+	submitter.DAIncluded(blocks[10], 2)
+	require.NoError(submitter.Submit())
+	assert.Equal(settlement.StatusNotFound, slc.LatestCommitment().Code)
+
+	submitter.DAIncluded(blocks[5], 1)
+	require.NoError(submitter.Submit())
+	latest := slc.LatestCommitment()
+	require.Equal(settlement.StatusSuccess, latest.Code)
+	hash := blocks[10].Hash()
+	root := [32]byte{10}
+	assert.Equal(&settlement.StateCommitment{StartHeight: 6, EndHeight: 10, BlockHash: hash[:], StateRoot: root[:], DAHeight: 2},
+		latest.Commitment)
+	first := slc.RetrieveCommitment(1)
+	require.Equal(settlement.StatusSuccess, first.Code)
+	assert.Equal(uint64(5), first.Commitment.EndHeight)
+	assert.Equal(uint64(1), first.Commitment.DAHeight)
+
+	// DA inclusion may be reported before block is applied
+	submitter.DAIncluded(&types.Block{Header: types.Header{Height: 15}}, 3)
+	for h := uint64(11); h <= 15; h++ {
+		apply(h)
+	}
+	require.NoError(submitter.Submit())
+	latest = slc.LatestCommitment()
+	require.Equal(settlement.StatusSuccess, latest.Code)
+	assert.Equal(uint64(11), latest.Commitment.StartHeight)
+	assert.Equal(uint64(15), latest.Commitment.EndHeight)
+
+	// submission resumes after the latest commitment found in settlement layer
+	restarted := settlement.NewSubmitter(slc, s, 5, logger)
+	restarted.BlockApplied(blocks[15])
+	restarted.DAIncluded(blocks[15], 3)
+	for h := uint64(16); h <= 20; h++ {
+		apply(h)
+	}
+	// apply only notifies the first submitter
+	restarted.BlockApplied(blocks[20])
+	restarted.DAIncluded(blocks[20], 4)
+	require.NoError(restarted.Submit())
+	latest = slc.LatestCommitment()
+	require.Equal(settlement.StatusSuccess, latest.Code)
+	assert.Equal(uint64(16), latest.Commitment.StartHeight)
+	assert.Equal(uint64(20), latest.Commitment.EndHeight)
+	assert.Equal(uint64(4), latest.SettlementHeight)
+}
@@ -0,0 +1,196 @@
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/celestiaorg/optimint/types"
+)
+
+// Encrypted transactions are wrapped in an envelope: encryptedTxPrefix, uvarint length of outer transaction, outer
+// transaction and the ciphertext of inner transaction.
+var encryptedTxPrefix = []byte("optimint/enc/v1:")
+
+// DeliverTx response of encrypted transaction that can't be decrypted has DecryptionFailedCode and
+// DecryptionFailedCodespace. Inner transaction is not passed to the application.
+const (
+	DecryptionFailedCode      uint32 = 1
+	DecryptionFailedCodespace        = "optimint"
+)
+
+// TxDecrypter enables encrypted mempool mode, in which users submit transactions encrypted to a committee (threshold)
+// key. Sequencer orders ciphertexts without knowing their content, and transactions are decrypted only when the block
+// is executed - this prevents frontrunning by the sequencer.
+//
+// Every encrypted transaction is an envelope (see EncodeEncryptedTx) with plaintext outer transaction, that pays fees
+// for inclusion of the ciphertext. Mempool checks only the outer transaction. When block is executed, outer
+// transaction is delivered to the application, followed by decrypted inner transaction. Inner transaction is a regular
+// transaction, so it's executed regardless of the result of the outer one.
+//
+// Decryption happens on all nodes, so all of them have to use the same decrypter and DecryptTx has to be
+// deterministic (see ThresholdTxDecrypter).
+type TxDecrypter interface {
+	// DecryptTx returns inner transaction of envelope included in block at given height. If the envelope can't be
+	// decrypted by anyone, returned error wraps ErrInvalidCiphertext, and the transaction fails. Other errors (e.g.
+	// decryption shares are not available yet) abort block execution.
+	DecryptTx(height uint64, tx types.Tx) (types.Tx, error)
+}
+
+// SetTxDecrypter sets TxDecrypter used to decrypt encrypted transactions before execution.
+func (e *BlockExecutor) SetTxDecrypter(decrypter TxDecrypter) {
+	e.decrypter = decrypter
+}
+
+// txExecution describes how block transaction is executed.
+type txExecution struct {
+	// encrypted is set for envelopes
+	encrypted bool
+	// outer is set if outer transaction of the envelope is executed (i.e. envelope is not malformed)
+	outer bool
+	// decrypted is set if inner transaction is executed after the outer one
+	decrypted bool
+	// err is set if envelope can't be decrypted
+	err error
+}
+
+// decryptTxs returns transactions passed to the application: envelopes are replaced by outer transactions followed
+// by decrypted inner transactions. Returned executions describe how results should be mapped to block transactions
+// (see mergeResponses).
+func (e *BlockExecutor) decryptTxs(height uint64, txs types.Txs) (types.Txs, []txExecution, error) {
+	executions := make([]txExecution, len(txs))
+	if e.decrypter == nil {
+		return txs, executions, nil
+	}
+	executed := make(types.Txs, 0, len(txs))
+	for i, tx := range txs {
+		if !IsEncryptedTx(tx) {
+			executed = append(executed, tx)
+			continue
+		}
+		executions[i].encrypted = true
+		outer, _, err := ParseEncryptedTx(tx)
+		if err != nil {
+			executions[i].err = err
+			continue
+		}
+		executions[i].outer = true
+		executed = append(executed, outer)
+		inner, err := e.decrypter.DecryptTx(height, tx)
+		if errors.Is(err, ErrInvalidCiphertext) {
+			e.logger.Debug("failed to decrypt tx", "height", height, "index", i, "error", err)
+			executions[i].err = err
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decrypt tx %d: %w", i, err)
+		}
+		executions[i].decrypted = true
+		executed = append(executed, inner)
+	}
+	return executed, executions, nil
+}
+
+// mergeResponses returns one DeliverTx response for every block transaction, given responses of executed
+// transactions (see decryptTxs).
+//
+// Response of an envelope is the response of inner transaction, with gas and events of both outer and inner
+// transactions. If the envelope can't be decrypted, response has DecryptionFailedCode (with gas and events of outer
+// transaction).
+func mergeResponses(executions []txExecution, responses []*abci.ResponseDeliverTx) ([]*abci.ResponseDeliverTx, error) {
+	executed := 0
+	for _, execution := range executions {
+		switch {
+		case !execution.encrypted:
+			executed++
+		case execution.decrypted:
+			executed += 2
+		case execution.outer:
+			executed++
+		}
+	}
+	if len(responses) != executed {
+		return nil, fmt.Errorf("executor returned %d transaction results, expected %d", len(responses), executed)
+	}
+
+	merged := make([]*abci.ResponseDeliverTx, len(executions))
+	for i, execution := range executions {
+		if !execution.encrypted {
+			merged[i] = responses[0]
+			responses = responses[1:]
+			continue
+		}
+		res := &abci.ResponseDeliverTx{}
+		if execution.outer {
+			res = responses[0]
+			responses = responses[1:]
+		}
+		if execution.decrypted {
+			outer := res
+			res = responses[0]
+			responses = responses[1:]
+			res.GasWanted += outer.GasWanted
+			res.GasUsed += outer.GasUsed
+			res.Events = append(outer.Events, res.Events...)
+		}
+		if execution.err != nil {
+			res.Code = DecryptionFailedCode
+			res.Codespace = DecryptionFailedCodespace
+			res.Data = nil
+			res.Log = execution.err.Error()
+		}
+		merged[i] = res
+	}
+	return merged, nil
+}
+
+// IsEncryptedTx returns true if transaction is wrapped in encrypted envelope (see EncodeEncryptedTx).
+func IsEncryptedTx(tx types.Tx) bool {
+	return bytes.HasPrefix(tx, encryptedTxPrefix)
+}
+
+// EncodeEncryptedTx wraps fee paying outer transaction and ciphertext of inner transaction in encrypted envelope.
+func EncodeEncryptedTx(outer types.Tx, ciphertext []byte) types.Tx {
+	tx := make(types.Tx, 0, len(encryptedTxPrefix)+binary.MaxVarintLen64+len(outer)+len(ciphertext))
+	tx = append(tx, encryptedTxPrefix...)
+	tx = append(tx, make([]byte, binary.MaxVarintLen64)...)
+	n := binary.PutUvarint(tx[len(encryptedTxPrefix):], uint64(len(outer)))
+	tx = tx[:len(encryptedTxPrefix)+n]
+	tx = append(tx, outer...)
+	return append(tx, ciphertext...)
+}
+
+// ParseEncryptedTx returns outer transaction and ciphertext of encrypted envelope. If the envelope is malformed,
+// returned error wraps ErrInvalidCiphertext.
+func ParseEncryptedTx(tx types.Tx) (types.Tx, []byte, error) {
+	if !IsEncryptedTx(tx) {
+		return nil, nil, errors.New("not an encrypted transaction")
+	}
+	rest := tx[len(encryptedTxPrefix):]
+	size, n := binary.Uvarint(rest)
+	if n <= 0 || size > uint64(len(rest)-n) {
+		return nil, nil, fmt.Errorf("%w: malformed envelope", ErrInvalidCiphertext)
+	}
+	rest = rest[n:]
+	outer, ciphertext := rest[:size], rest[size:]
+	if len(outer) == 0 {
+		return nil, nil, fmt.Errorf("%w: missing outer transaction", ErrInvalidCiphertext)
+	}
+	if len(ciphertext) < elementSize {
+		return nil, nil, fmt.Errorf("%w: ciphertext too short", ErrInvalidCiphertext)
+	}
+	return outer, ciphertext, nil
+}
+
+// OuterTx returns transaction checked by the application in mempool: outer transaction of encrypted envelope, or
+// the transaction itself, if it's not encrypted.
+func OuterTx(tx types.Tx) (types.Tx, error) {
+	if !IsEncryptedTx(tx) {
+		return tx, nil
+	}
+	outer, _, err := ParseEncryptedTx(tx)
+	return outer, err
+}
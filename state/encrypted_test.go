@@ -0,0 +1,195 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/mempool"
+	"github.com/celestiaorg/optimint/mocks"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// committeeShares is a DecryptionShareSource returning shares of given committee members.
+type committeeShares struct {
+	members []KeyShare
+	err     error
+}
+
+func (c *committeeShares) DecryptionShares(_ uint64, tx types.Tx) ([]DecryptionShare, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	shares := make([]DecryptionShare, 0, len(c.members))
+	for i := range c.members {
+		share, err := c.members[i].DecryptionShare(tx)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, *share)
+	}
+	return shares, nil
+}
+
+func TestEncryptedTxEnvelope(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tx := EncodeEncryptedTx(types.Tx("outer"), make([]byte, elementSize))
+	assert.True(IsEncryptedTx(tx))
+	outer, ciphertext, err := ParseEncryptedTx(tx)
+	require.NoError(err)
+	assert.Equal(types.Tx("outer"), outer)
+	assert.Len(ciphertext, elementSize)
+
+	outer, err = OuterTx(tx)
+	require.NoError(err)
+	assert.Equal(types.Tx("outer"), outer)
+	outer, err = OuterTx(types.Tx("plain"))
+	require.NoError(err)
+	assert.Equal(types.Tx("plain"), outer)
+
+	for _, malformed := range []types.Tx{
+		append(types.Tx{}, encryptedTxPrefix...),
+		append(append(types.Tx{}, encryptedTxPrefix...), 100, 1, 2),
+		EncodeEncryptedTx(nil, make([]byte, elementSize)),
+		EncodeEncryptedTx(types.Tx("outer"), []byte{1, 2, 3}),
+	} {
+		_, _, err := ParseEncryptedTx(malformed)
+		assert.ErrorIs(err, ErrInvalidCiphertext)
+	}
+}
+
+func TestThresholdDecryption(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key, members, err := DealThresholdKey(3, 5)
+	require.NoError(err)
+	require.Len(members, 5)
+
+	tx, err := EncryptTx(key, types.Tx("outer"), types.Tx("secret"))
+	require.NoError(err)
+	assert.NotContains(string(tx), "secret")
+
+	shares := make([]DecryptionShare, len(members))
+	for i := range members {
+		share, err := members[i].DecryptionShare(tx)
+		require.NoError(err)
+		shares[i] = *share
+	}
+
+	// any threshold of shares decrypts the transaction
+	for _, subset := range [][]DecryptionShare{shares[:3], shares[2:], {shares[4], shares[0], shares[2]}} {
+		plain, err := key.Decrypt(tx, subset)
+		require.NoError(err)
+		assert.Equal(types.Tx("secret"), plain)
+	}
+
+	// not enough shares
+	_, err = key.Decrypt(tx, shares[:2])
+	assert.Error(err)
+	assert.NotErrorIs(err, ErrInvalidCiphertext)
+	// duplicated shares are counted once
+	_, err = key.Decrypt(tx, []DecryptionShare{shares[0], shares[0], shares[1]})
+	assert.Error(err)
+	// shares with invalid proofs are ignored
+	forged := shares[0]
+	forged.Share = shares[1].Share
+	_, err = key.Decrypt(tx, []DecryptionShare{forged, shares[1], shares[2]})
+	assert.Error(err)
+	plain, err := key.Decrypt(tx, []DecryptionShare{forged, shares[1], shares[2], shares[3]})
+	require.NoError(err)
+	assert.Equal(types.Tx("secret"), plain)
+
+	// ciphertext is bound to the outer transaction
+	_, ciphertext, err := ParseEncryptedTx(tx)
+	require.NoError(err)
+	rewrapped := EncodeEncryptedTx(types.Tx("other"), ciphertext)
+	_, err = key.Decrypt(rewrapped, shares)
+	assert.ErrorIs(err, ErrInvalidCiphertext)
+
+	// transaction encrypted to other key
+	otherKey, _, err := DealThresholdKey(3, 5)
+	require.NoError(err)
+	tx, err = EncryptTx(otherKey, types.Tx("outer"), types.Tx("secret"))
+	require.NoError(err)
+	decrypter := NewThresholdTxDecrypter(key, &committeeShares{members: members})
+	_, err = decrypter.DecryptTx(1, tx)
+	assert.ErrorIs(err, ErrInvalidCiphertext)
+
+	_, _, err = DealThresholdKey(6, 5)
+	assert.Error(err)
+}
+
+func TestApplyBlockEncryptedTxs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key, members, err := DealThresholdKey(2, 3)
+	require.NoError(err)
+	encrypted, err := EncryptTx(key, types.Tx("fee"), types.Tx("secret"))
+	require.NoError(err)
+	garbled := EncodeEncryptedTx(types.Tx("fee2"), make([]byte, 64))
+
+	event := func(t string) []abci.Event { return []abci.Event{{Type: t}} }
+	app := &mocks.Application{}
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("DeliverTx", abci.RequestDeliverTx{Tx: []byte("plain")}).Return(abci.ResponseDeliverTx{Data: []byte("plain")})
+	app.On("DeliverTx", abci.RequestDeliverTx{Tx: []byte("fee")}).Return(abci.ResponseDeliverTx{GasUsed: 1, Events: event("fee")})
+	app.On("DeliverTx", abci.RequestDeliverTx{Tx: []byte("fee2")}).Return(abci.ResponseDeliverTx{GasUsed: 1, Events: event("fee2")})
+	app.On("DeliverTx", abci.RequestDeliverTx{Tx: []byte("secret")}).Return(abci.ResponseDeliverTx{Data: []byte("secret"), GasUsed: 2, Events: event("secret")})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+
+	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
+	executor := NewBlockExecutor([]byte("test address"), [8]byte{}, "test", mpool, NewABCIExecutor(proxy.NewAppConnConsensus(client), "test", log.TestingLogger()), nil, log.TestingLogger())
+	source := &committeeShares{members: members[1:], err: errors.New("shares not published yet")}
+	executor.SetTxDecrypter(NewThresholdTxDecrypter(key, source))
+
+	state := State{}
+	state.InitialHeight = 1
+	state.Validators = tmtypes.NewValidatorSet(nil)
+	state.NextValidators = tmtypes.NewValidatorSet(nil)
+	state.ConsensusParams.Block.MaxBytes = 1000
+	state.ConsensusParams.Block.MaxGas = 100000
+
+	for _, tx := range []types.Tx{garbled, types.Tx("plain"), encrypted} {
+		require.NoError(mpool.CheckTx(tmtypes.Tx(tx), nil, mempool.TxInfo{}))
+	}
+	block, err := executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	require.NoError(err)
+	require.Equal(types.Txs{garbled, types.Tx("plain"), encrypted}, block.Data.Txs)
+
+	// block can't be executed until decryption shares are available
+	_, _, _, err = executor.ApplyBlock(context.Background(), state, block)
+	assert.Error(err)
+	app.AssertNotCalled(t, "BeginBlock", mock.Anything)
+
+	source.err = nil
+	_, resp, _, err := executor.ApplyBlock(context.Background(), state, block)
+	require.NoError(err)
+	require.Len(resp.DeliverTxs, 3)
+	assert.Equal(DecryptionFailedCode, resp.DeliverTxs[0].Code)
+	assert.Equal(DecryptionFailedCodespace, resp.DeliverTxs[0].Codespace)
+	assert.Equal(event("fee2"), resp.DeliverTxs[0].Events)
+	assert.Equal([]byte("plain"), resp.DeliverTxs[1].Data)
+	assert.Equal([]byte("secret"), resp.DeliverTxs[2].Data)
+	assert.Equal(int64(3), resp.DeliverTxs[2].GasUsed)
+	assert.Equal(append(event("fee"), event("secret")...), resp.DeliverTxs[2].Events)
+	app.AssertNumberOfCalls(t, "DeliverTx", 4)
+}
@@ -0,0 +1,143 @@
+package state
+
+import (
+	"context"
+	"errors"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/proxy"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	abciconv "github.com/celestiaorg/optimint/conv/abci"
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// Executor is the execution layer of the chain. BlockExecutor is responsible for block creation, validation and
+// state updates, and delegates execution of transactions to Executor.
+//
+// ABCIExecutor executes blocks using ABCI application connected to the node. Other implementations can drive
+// an external execution engine (e.g. EVM engine API). Results are expressed with ABCI types, because they are used to
+// compute results hash, publish events and index transactions.
+type Executor interface {
+	// InitChain initializes the execution layer with genesis. Returned app hash, consensus params and validators are
+	// applied to the initial state.
+	InitChain(genesis *tmtypes.GenesisDoc) (*abci.ResponseInitChain, error)
+	// ExecuteBlock executes given transactions of the block. Transactions may differ from block transactions (e.g.
+	// after decryption). Exactly one DeliverTx response has to be returned for every transaction.
+	ExecuteBlock(ctx context.Context, block *types.Block, txs types.Txs) (*tmstate.ABCIResponses, error)
+	// Commit persists the state after block execution. Returned response contains the app hash and the retain height.
+	Commit(ctx context.Context) (*abci.ResponseCommit, error)
+}
+
+// ABCIExecutor executes blocks using ABCI consensus connection.
+type ABCIExecutor struct {
+	proxyApp proxy.AppConnConsensus
+	chainID  string
+	logger   log.Logger
+}
+
+var _ Executor = &ABCIExecutor{}
+
+// NewABCIExecutor returns ABCIExecutor using given consensus connection.
+func NewABCIExecutor(proxyApp proxy.AppConnConsensus, chainID string, logger log.Logger) *ABCIExecutor {
+	return &ABCIExecutor{
+		proxyApp: proxyApp,
+		chainID:  chainID,
+		logger:   logger,
+	}
+}
+
+// InitChain implements Executor.
+func (e *ABCIExecutor) InitChain(genesis *tmtypes.GenesisDoc) (*abci.ResponseInitChain, error) {
+	params := genesis.ConsensusParams
+
+	validators := make([]*tmtypes.Validator, len(genesis.Validators))
+	for i, val := range genesis.Validators {
+		validators[i] = tmtypes.NewValidator(val.PubKey, val.Power)
+	}
+
+	return e.proxyApp.InitChainSync(abci.RequestInitChain{
+		Time:    genesis.GenesisTime,
+		ChainId: genesis.ChainID,
+		ConsensusParams: &abci.ConsensusParams{
+			Block: &abci.BlockParams{
+				MaxBytes: params.Block.MaxBytes,
+				MaxGas:   params.Block.MaxGas,
+			},
+			Evidence: &tmproto.EvidenceParams{
+				MaxAgeNumBlocks: params.Evidence.MaxAgeNumBlocks,
+				MaxAgeDuration:  params.Evidence.MaxAgeDuration,
+				MaxBytes:        params.Evidence.MaxBytes,
+			},
+			Validator: &tmproto.ValidatorParams{
+				PubKeyTypes: params.Validator.PubKeyTypes,
+			},
+			Version: &tmproto.VersionParams{
+				AppVersion: params.Version.AppVersion,
+			},
+		},
+		Validators:    tmtypes.TM2PB.ValidatorUpdates(tmtypes.NewValidatorSet(validators)),
+		AppStateBytes: genesis.AppState,
+		InitialHeight: genesis.InitialHeight,
+	})
+}
+
+// ExecuteBlock implements Executor.
+func (e *ABCIExecutor) ExecuteBlock(ctx context.Context, block *types.Block, txs types.Txs) (*tmstate.ABCIResponses, error) {
+	abciResponses := new(tmstate.ABCIResponses)
+	abciResponses.DeliverTxs = make([]*abci.ResponseDeliverTx, len(txs))
+
+	txIdx := 0
+	e.proxyApp.SetResponseCallback(func(req *abci.Request, res *abci.Response) {
+		if r, ok := res.Value.(*abci.Response_DeliverTx); ok {
+			txRes := r.DeliverTx
+			if txRes.Code != abci.CodeTypeOK {
+				e.logger.Debug("Invalid tx", "code", txRes.Code, "log", txRes.Log)
+			}
+			abciResponses.DeliverTxs[txIdx] = txRes
+			txIdx++
+		}
+	})
+
+	hash := block.Hash()
+	abciHeader, err := abciconv.ToABCIHeaderPB(&block.Header)
+	if err != nil {
+		return nil, err
+	}
+	abciHeader.ChainID = e.chainID
+	abciResponses.BeginBlock, err = e.proxyApp.BeginBlockSync(
+		abci.RequestBeginBlock{
+			Hash:   hash[:],
+			Header: abciHeader,
+			LastCommitInfo: abci.LastCommitInfo{
+				Round: 0,
+				Votes: nil,
+			},
+			ByzantineValidators: nil,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range txs {
+		res := e.proxyApp.DeliverTxAsync(abci.RequestDeliverTx{Tx: tx})
+		if res.GetException() != nil {
+			return nil, errors.New(res.GetException().GetError())
+		}
+	}
+
+	abciResponses.EndBlock, err = e.proxyApp.EndBlockSync(abci.RequestEndBlock{Height: int64(block.Header.Height)})
+	if err != nil {
+		return nil, err
+	}
+
+	return abciResponses, nil
+}
+
+// Commit implements Executor.
+func (e *ABCIExecutor) Commit(_ context.Context) (*abci.ResponseCommit, error) {
+	return e.proxyApp.CommitSync()
+}
@@ -0,0 +1,89 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	"github.com/tendermint/tendermint/proxy"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/mempool"
+	"github.com/celestiaorg/optimint/mocks"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// testExecutor executes transactions without ABCI application - it records them, and returns their length as data.
+type testExecutor struct {
+	executed types.Txs
+	commits  int
+}
+
+func (e *testExecutor) InitChain(genesis *tmtypes.GenesisDoc) (*abci.ResponseInitChain, error) {
+	return &abci.ResponseInitChain{AppHash: []byte("genesis")}, nil
+}
+
+func (e *testExecutor) ExecuteBlock(_ context.Context, block *types.Block, txs types.Txs) (*tmstate.ABCIResponses, error) {
+	resp := &tmstate.ABCIResponses{
+		BeginBlock: &abci.ResponseBeginBlock{},
+		EndBlock:   &abci.ResponseEndBlock{},
+	}
+	for _, tx := range txs {
+		e.executed = append(e.executed, tx)
+		resp.DeliverTxs = append(resp.DeliverTxs, &abci.ResponseDeliverTx{Data: []byte{byte(len(tx))}})
+	}
+	return resp, nil
+}
+
+func (e *testExecutor) Commit(_ context.Context) (*abci.ResponseCommit, error) {
+	e.commits++
+	return &abci.ResponseCommit{Data: []byte{byte(e.commits)}}, nil
+}
+
+func TestExternalExecutor(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+
+	exec := &testExecutor{}
+	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
+	executor := NewBlockExecutor([]byte("test address"), [8]byte{}, "test", mpool, exec, nil, log.TestingLogger())
+
+	res, err := executor.InitChain(&tmtypes.GenesisDoc{ChainID: "test"})
+	require.NoError(err)
+	assert.Equal([]byte("genesis"), res.AppHash)
+
+	state := State{}
+	state.InitialHeight = 1
+	state.Validators = tmtypes.NewValidatorSet(nil)
+	state.NextValidators = tmtypes.NewValidatorSet(nil)
+	state.ConsensusParams.Block.MaxBytes = 1000
+	state.ConsensusParams.Block.MaxGas = 100000
+
+	require.NoError(mpool.CheckTx([]byte{1, 2, 3}, nil, mempool.TxInfo{}))
+	require.NoError(mpool.CheckTx([]byte{4, 5}, nil, mempool.TxInfo{}))
+	block, err := executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	require.NoError(err)
+	require.Len(block.Data.Txs, 2)
+
+	newState, resp, _, err := executor.ApplyBlock(context.Background(), state, block)
+	require.NoError(err)
+	assert.Equal(block.Data.Txs, exec.executed)
+	require.Len(resp.DeliverTxs, 2)
+	assert.Equal([]byte{3}, resp.DeliverTxs[0].Data)
+	assert.Equal([]byte{2}, resp.DeliverTxs[1].Data)
+	assert.Equal(byte(1), newState.AppHash[0])
+	assert.Equal(0, mpool.Size())
+	app.AssertNotCalled(t, "DeliverTx", mock.Anything)
+}
@@ -3,13 +3,18 @@ package state
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
-	"github.com/tendermint/tendermint/proxy"
 	tmtypes "github.com/tendermint/tendermint/types"
 	"go.uber.org/multierr"
 
@@ -24,62 +29,126 @@ type BlockExecutor struct {
 	proposerAddress []byte
 	namespaceID     [8]byte
 	chainID         string
-	proxyApp        proxy.AppConnConsensus
+	executor        Executor
 	mempool         mempool.Mempool
 
-	eventBus *tmtypes.EventBus
+	eventBus   *tmtypes.EventBus
+	txInjector TxInjector
+	// forcedTxs provides transactions of forced inclusion lane (placed after pre-block system transactions)
+	forcedTxs TxInjector
+	budgets   LaneBudgets
+
+	// additional mempool filters, applied together with consensus params based checks
+	txPreCheck  mempool.PreCheckFunc
+	txPostCheck mempool.PostCheckFunc
+
+	// fcfsOrdering enables validation of transaction sequence numbers (see SetFCFSOrdering)
+	fcfsOrdering bool
+	// sequencer provides sequence numbers of mempool transactions in created blocks
+	sequencer TxSequencer
+
+	// decrypter decrypts transactions before execution (encrypted mempool mode)
+	decrypter TxDecrypter
+
+	// maxBlobSize limits the size of serialized block (0 - no limit)
+	maxBlobSize uint64
 
 	logger log.Logger
 }
 
+// maxBlockOverhead is an upper bound of the size of serialized block without transactions.
+var maxBlockOverhead = computeMaxBlockOverhead()
+
+// MaxTxsBytes returns maximum total size of transactions (see ComputeProtoSizeForTxs) of a block, that fits in DA layer
+// blob of given size. Result is not positive if blob is too small even for an empty block.
+func MaxTxsBytes(maxBlobSize uint64) int64 {
+	return int64(maxBlobSize) - maxBlockOverhead
+}
+
+// computeMaxBlockOverhead returns the size of serialized empty block, with all numbers set to max values and
+// a single commit signature, increased by the max size of length prefix of block data.
+func computeMaxBlockOverhead() int64 {
+	block := &types.Block{
+		Header: types.Header{
+			Version:         types.Version{Block: math.MaxUint64, App: math.MaxUint64},
+			Height:          math.MaxUint64,
+			Time:            math.MaxUint64,
+			ProposerAddress: make([]byte, crypto.AddressSize),
+		},
+		LastCommit: types.Commit{
+			Height:     math.MaxUint64,
+			Signatures: []types.Signature{make([]byte, ed25519.SignatureSize)},
+		},
+	}
+	return int64(block.Size() + binary.MaxVarintLen64)
+}
+
 // NewBlockExecutor creates new instance of BlockExecutor.
 // Proposer address and namespace ID will be used in all newly created blocks.
-func NewBlockExecutor(proposerAddress []byte, namespaceID [8]byte, chainID string, mempool mempool.Mempool, proxyApp proxy.AppConnConsensus, eventBus *tmtypes.EventBus, logger log.Logger) *BlockExecutor {
+// Transactions are executed by given Executor (see NewABCIExecutor).
+func NewBlockExecutor(proposerAddress []byte, namespaceID [8]byte, chainID string, mempool mempool.Mempool, executor Executor, eventBus *tmtypes.EventBus, logger log.Logger) *BlockExecutor {
 	return &BlockExecutor{
 		proposerAddress: proposerAddress,
 		namespaceID:     namespaceID,
 		chainID:         chainID,
-		proxyApp:        proxyApp,
+		executor:        executor,
 		mempool:         mempool,
 		eventBus:        eventBus,
 		logger:          logger,
 	}
 }
 
+// SetMaxBlobSize limits the size of created blocks to the size of DA layer blob (0 - no limit).
+func (e *BlockExecutor) SetMaxBlobSize(maxBlobSize uint64) {
+	e.maxBlobSize = maxBlobSize
+}
+
+// SetMempoolChecks sets additional filters used by mempool after every block (see TxPreCheck and TxPostCheck).
+func (e *BlockExecutor) SetMempoolChecks(preCheck mempool.PreCheckFunc, postCheck mempool.PostCheckFunc) {
+	e.txPreCheck = preCheck
+	e.txPostCheck = postCheck
+}
+
 func (e *BlockExecutor) InitChain(genesis *tmtypes.GenesisDoc) (*abci.ResponseInitChain, error) {
-	params := genesis.ConsensusParams
-	return e.proxyApp.InitChainSync(abci.RequestInitChain{
-		Time:    genesis.GenesisTime,
-		ChainId: genesis.ChainID,
-		ConsensusParams: &abci.ConsensusParams{
-			Block: &abci.BlockParams{
-				MaxBytes: params.Block.MaxBytes,
-				MaxGas:   params.Block.MaxGas,
-			},
-			Evidence: &tmproto.EvidenceParams{
-				MaxAgeNumBlocks: params.Evidence.MaxAgeNumBlocks,
-				MaxAgeDuration:  params.Evidence.MaxAgeDuration,
-				MaxBytes:        params.Evidence.MaxBytes,
-			},
-			Validator: &tmproto.ValidatorParams{
-				PubKeyTypes: params.Validator.PubKeyTypes,
-			},
-			Version: &tmproto.VersionParams{
-				AppVersion: params.Version.AppVersion,
-			},
-		},
-		Validators:    nil,
-		AppStateBytes: genesis.AppState,
-		InitialHeight: genesis.InitialHeight,
-	})
+	return e.executor.InitChain(genesis)
 }
 
 // CreateBlock reaps transactions from mempool and builds a block.
-func (e *BlockExecutor) CreateBlock(height uint64, lastCommit *types.Commit, lastHeaderHash [32]byte, state State) *types.Block {
+// System transactions returned by TxInjector (if set) are placed before and after mempool transactions.
+func (e *BlockExecutor) CreateBlock(height uint64, lastCommit *types.Commit, lastHeaderHash [32]byte, state State) (*types.Block, error) {
 	maxBytes := state.ConsensusParams.Block.MaxBytes
 	maxGas := state.ConsensusParams.Block.MaxGas
+	if e.maxBlobSize > 0 {
+		blobMaxBytes := MaxTxsBytes(e.maxBlobSize)
+		if blobMaxBytes <= 0 {
+			return nil, fmt.Errorf("max DA blob size %d is too small for a block", e.maxBlobSize)
+		}
+		if maxBytes <= 0 || blobMaxBytes < maxBytes {
+			maxBytes = blobMaxBytes
+		}
+	}
 
-	mempoolTxs := e.mempool.ReapMaxBytesMaxGas(maxBytes, maxGas)
+	pre, post, err := e.injectedTxs(height, state)
+	if err != nil {
+		return nil, err
+	}
+	if maxBytes > 0 {
+		maxBytes -= tmtypes.ComputeProtoSizeForTxs(fromOptimintTxs(pre)) + tmtypes.ComputeProtoSizeForTxs(fromOptimintTxs(post))
+		if maxBytes < 0 {
+			return nil, errors.New("system transactions exceed max block size")
+		}
+	}
+
+	maxBytes, maxGas = e.budgets.mempoolLimits(maxBytes, maxGas)
+	mempoolTxs := toOptimintTxs(e.mempool.ReapMaxBytesMaxGas(maxBytes, maxGas))
+	var seqs []uint64
+	if e.fcfsOrdering {
+		mempoolTxs, seqs = e.sequenceTxs(state, mempoolTxs, maxBytes, len(pre), len(post))
+	}
+	txs := make(types.Txs, 0, len(pre)+len(mempoolTxs)+len(post))
+	txs = append(txs, pre...)
+	txs = append(txs, mempoolTxs...)
+	txs = append(txs, post...)
 
 	block := &types.Block{
 		Header: types.Header{
@@ -87,48 +156,56 @@ func (e *BlockExecutor) CreateBlock(height uint64, lastCommit *types.Commit, las
 				Block: state.Version.Consensus.Block,
 				App:   state.Version.Consensus.App,
 			},
-			NamespaceID:    e.namespaceID,
-			Height:         height,
-			Time:           uint64(time.Now().Unix()), // TODO(tzdybal): how to get TAI64?
-			LastHeaderHash: lastHeaderHash,
-			//LastCommitHash:  lastCommitHash,
-			DataHash:        [32]byte{},
+			NamespaceID:     e.namespaceID,
+			Height:          height,
+			Time:            uint64(time.Now().Unix()), // TODO(tzdybal): how to get TAI64?
+			LastHeaderHash:  lastHeaderHash,
+			LastCommitHash:  lastCommit.Hash(),
 			ConsensusHash:   [32]byte{},
 			AppHash:         state.AppHash,
 			LastResultsHash: state.LastResultsHash,
 			ProposerAddress: e.proposerAddress,
 		},
 		Data: types.Data{
-			Txs:                    toOptimintTxs(mempoolTxs),
+			Txs:                    txs,
 			IntermediateStateRoots: types.IntermediateStateRoots{RawRootsList: nil},
 			Evidence:               types.EvidenceData{Evidence: nil},
+			TxSequences:            seqs,
 		},
+		LastCommit: *lastCommit,
 	}
-	copy(block.Header.LastCommitHash[:], e.getLastCommitHash(lastCommit, &block.Header))
+	block.Header.DataHash = block.Data.Hash()
 
-	return block
+	if e.maxBlobSize > 0 {
+		if size := block.Size(); uint64(size) > e.maxBlobSize {
+			return nil, fmt.Errorf("block size %d exceeds max DA blob size %d", size, e.maxBlobSize)
+		}
+	}
+
+	return block, nil
 }
 
 // ApplyBlock validates, executes and commits the block.
-func (e *BlockExecutor) ApplyBlock(ctx context.Context, state State, block *types.Block) (State, uint64, error) {
+// ABCI responses are returned, so they can be persisted by the caller.
+func (e *BlockExecutor) ApplyBlock(ctx context.Context, state State, block *types.Block) (State, *tmstate.ABCIResponses, uint64, error) {
 	err := e.validate(state, block)
 	if err != nil {
-		return State{}, 0, err
+		return State{}, nil, 0, err
 	}
 
 	resp, err := e.execute(ctx, state, block)
 	if err != nil {
-		return State{}, 0, err
+		return State{}, nil, 0, err
 	}
 
 	state, err = e.updateState(state, block, resp)
 	if err != nil {
-		return State{}, 0, err
+		return State{}, nil, 0, err
 	}
 
 	appHash, retainHeight, err := e.commit(ctx, state, block, resp.DeliverTxs)
 	if err != nil {
-		return State{}, 0, err
+		return State{}, nil, 0, err
 	}
 
 	copy(state.AppHash[:], appHash[:])
@@ -138,10 +215,36 @@ func (e *BlockExecutor) ApplyBlock(ctx context.Context, state State, block *type
 		e.logger.Error("failed to fire block events", "error", err)
 	}
 
-	return state, retainHeight, nil
+	return state, resp, retainHeight, nil
+}
+
+// ReplayBlock executes and commits already applied block again, without validation, state update and events.
+// It's used to bring the application up to date with the node, after application lost its latest state.
+// Application hash returned by Commit is returned.
+func (e *BlockExecutor) ReplayBlock(ctx context.Context, state State, block *types.Block) ([]byte, error) {
+	_, err := e.execute(ctx, state, block)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := e.executor.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
 }
 
 func (e *BlockExecutor) updateState(state State, block *types.Block, abciResponses *tmstate.ABCIResponses) (State, error) {
+	nValSet := state.NextValidators.Copy()
+	lastHeightValSetChanged := state.LastHeightValidatorsChanged
+	if len(abciResponses.EndBlock.ValidatorUpdates) > 0 {
+		err := e.updateValidators(nValSet, abciResponses.EndBlock.ValidatorUpdates, state.ConsensusParams.Validator)
+		if err != nil {
+			return State{}, err
+		}
+		// Change results from this height but only applies to the next next height.
+		lastHeightValSetChanged = int64(block.Header.Height) + 1 + 1
+	}
+
 	hash := block.Header.Hash()
 	s := State{
 		Version:         state.Version,
@@ -153,15 +256,56 @@ func (e *BlockExecutor) updateState(state State, block *types.Block, abciRespons
 			Hash: hash[:],
 			// for now, we don't care about part set headers
 		},
-		// skipped all "Validators" fields
+		NextValidators:                   nValSet,
+		Validators:                       state.NextValidators.Copy(),
+		LastValidators:                   state.Validators.Copy(),
+		LastHeightValidatorsChanged:      lastHeightValSetChanged,
 		ConsensusParams:                  state.ConsensusParams,
 		LastHeightConsensusParamsChanged: state.LastHeightConsensusParamsChanged,
+		LastTxSequence:                   lastTxSequence(state, block),
 	}
 	copy(s.LastResultsHash[:], tmtypes.NewResults(abciResponses.DeliverTxs).Hash())
 
 	return s, nil
 }
 
+// updateValidators applies validator updates returned by the app in EndBlock to the validator set.
+// Updates are used to rotate the sequencer key, so resulting set has to contain exactly one validator.
+func (e *BlockExecutor) updateValidators(vals *tmtypes.ValidatorSet, updates []abci.ValidatorUpdate, params tmproto.ValidatorParams) error {
+	changes, err := tmtypes.PB2TM.ValidatorUpdates(updates)
+	if err != nil {
+		return fmt.Errorf("invalid validator updates: %w", err)
+	}
+	for _, val := range changes {
+		if val.VotingPower < 0 {
+			return fmt.Errorf("voting power can't be negative: %v", val)
+		}
+		if !tmtypes.IsValidPubkeyType(params, val.PubKey.Type()) {
+			return fmt.Errorf("validator %v is using pubkey %s, which is unsupported for consensus", val.Address, val.PubKey.Type())
+		}
+	}
+
+	var oldSequencer []byte
+	if vals.Size() > 0 {
+		oldSequencer = vals.GetProposer().Address
+	}
+	err = vals.UpdateWithChangeSet(changes)
+	if err != nil {
+		return fmt.Errorf("failed to apply validator updates: %w", err)
+	}
+	if vals.Size() != 1 {
+		return fmt.Errorf("only single sequencer is supported, but validator updates result in %d validators", vals.Size())
+	}
+	// recalculate proposer, as it's not updated by UpdateWithChangeSet
+	vals.IncrementProposerPriority(1)
+
+	newSequencer := vals.GetProposer().Address
+	if !bytes.Equal(oldSequencer, newSequencer) {
+		e.logger.Info("sequencer changed", "old", tmbytes.HexBytes(oldSequencer), "new", tmbytes.HexBytes(newSequencer))
+	}
+	return nil
+}
+
 func (e *BlockExecutor) commit(ctx context.Context, state State, block *types.Block, deliverTxs []*abci.ResponseDeliverTx) ([]byte, uint64, error) {
 	e.mempool.Lock()
 	defer e.mempool.Unlock()
@@ -171,14 +315,14 @@ func (e *BlockExecutor) commit(ctx context.Context, state State, block *types.Bl
 		return nil, 0, err
 	}
 
-	resp, err := e.proxyApp.CommitSync()
+	resp, err := e.executor.Commit(ctx)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	maxBytes := state.ConsensusParams.Block.MaxBytes
-	maxGas := state.ConsensusParams.Block.MaxGas
-	err = e.mempool.Update(int64(block.Header.Height), fromOptimintTxs(block.Data.Txs), deliverTxs, mempool.PreCheckMaxBytes(maxBytes), mempool.PostCheckMaxGas(maxGas))
+	err = e.mempool.Update(int64(block.Header.Height), fromOptimintTxs(block.Data.Txs), deliverTxs,
+		TxPreCheck(state, mempool.ChainPreChecks(TxPreCheckBlobSize(e.maxBlobSize), e.txPreCheck)),
+		TxPostCheck(state, e.txPostCheck))
 	if err != nil {
 		return nil, 0, err
 	}
@@ -198,9 +342,15 @@ func (e *BlockExecutor) validate(state State, block *types.Block) error {
 	if state.LastBlockHeight <= 0 && block.Header.Height != uint64(state.InitialHeight) {
 		return errors.New("initial block height mismatch")
 	}
+	if state.LastBlockHeight <= 0 && block.LastCommit.Height != 0 {
+		return errors.New("initial block can't have last commit")
+	}
 	if state.LastBlockHeight > 0 && block.Header.Height != uint64(state.LastBlockHeight)+1 {
 		return errors.New("block height mismatch")
 	}
+	if block.Header.DataHash != block.Data.Hash() {
+		return errors.New("DataHash mismatch")
+	}
 	if !bytes.Equal(block.Header.AppHash[:], state.AppHash[:]) {
 		return errors.New("AppHash mismatch")
 	}
@@ -208,62 +358,29 @@ func (e *BlockExecutor) validate(state State, block *types.Block) error {
 	if !bytes.Equal(block.Header.LastResultsHash[:], state.LastResultsHash[:]) {
 		return errors.New("LastResultsHash mismatch")
 	}
+	if state.Validators.Size() > 0 && !bytes.Equal(block.Header.ProposerAddress, state.Validators.GetProposer().Address) {
+		return errors.New("ProposerAddress mismatch")
+	}
 
-	return nil
+	pre, post, err := e.validateInjectedTxs(state, block)
+	if err != nil {
+		return err
+	}
+	return e.validateTxSequences(state, block, pre, post)
 }
 
+// execute passes block transactions to the Executor. Transactions that can't be decrypted are not executed.
 func (e *BlockExecutor) execute(ctx context.Context, state State, block *types.Block) (*tmstate.ABCIResponses, error) {
-	abciResponses := new(tmstate.ABCIResponses)
-	abciResponses.DeliverTxs = make([]*abci.ResponseDeliverTx, len(block.Data.Txs))
-
-	txIdx := 0
-	validTxs := 0
-	invalidTxs := 0
-
-	var err error
-
-	e.proxyApp.SetResponseCallback(func(req *abci.Request, res *abci.Response) {
-		if r, ok := res.Value.(*abci.Response_DeliverTx); ok {
-			txRes := r.DeliverTx
-			if txRes.Code == abci.CodeTypeOK {
-				validTxs++
-			} else {
-				e.logger.Debug("Invalid tx", "code", txRes.Code, "log", txRes.Log)
-				invalidTxs++
-			}
-			abciResponses.DeliverTxs[txIdx] = txRes
-			txIdx++
-		}
-	})
-
-	hash := block.Hash()
-	abciHeader, err := abciconv.ToABCIHeaderPB(&block.Header)
-	abciHeader.ChainID = e.chainID
+	executed, executions, err := e.decryptTxs(block.Header.Height, block.Data.Txs)
 	if err != nil {
 		return nil, err
 	}
-	abciResponses.BeginBlock, err = e.proxyApp.BeginBlockSync(
-		abci.RequestBeginBlock{
-			Hash:   hash[:],
-			Header: abciHeader,
-			LastCommitInfo: abci.LastCommitInfo{
-				Round: 0,
-				Votes: nil,
-			},
-			ByzantineValidators: nil,
-		})
+
+	abciResponses, err := e.executor.ExecuteBlock(ctx, block, executed)
 	if err != nil {
 		return nil, err
 	}
-
-	for _, tx := range block.Data.Txs {
-		res := e.proxyApp.DeliverTxAsync(abci.RequestDeliverTx{Tx: tx})
-		if res.GetException() != nil {
-			return nil, errors.New(res.GetException().GetError())
-		}
-	}
-
-	abciResponses.EndBlock, err = e.proxyApp.EndBlockSync(abci.RequestEndBlock{Height: int64(block.Header.Height)})
+	abciResponses.DeliverTxs, err = mergeResponses(executions, abciResponses.DeliverTxs)
 	if err != nil {
 		return nil, err
 	}
@@ -271,17 +388,14 @@ func (e *BlockExecutor) execute(ctx context.Context, state State, block *types.B
 	return abciResponses, nil
 }
 
-func (e *BlockExecutor) getLastCommitHash(lastCommit *types.Commit, header *types.Header) []byte {
-	lastABCICommit := abciconv.ToABCICommit(lastCommit)
-	if len(lastCommit.Signatures) == 1 {
-		lastABCICommit.Signatures[0].ValidatorAddress = e.proposerAddress
-		lastABCICommit.Signatures[0].Timestamp = time.UnixMilli(int64(header.Time))
-	}
-	return lastABCICommit.Hash()
+func (e *BlockExecutor) publishEvents(resp *tmstate.ABCIResponses, block *types.Block) error {
+	return PublishBlockEvents(e.eventBus, resp, block)
 }
 
-func (e *BlockExecutor) publishEvents(resp *tmstate.ABCIResponses, block *types.Block) error {
-	if e.eventBus == nil {
+// PublishBlockEvents publishes events of applied block (new block, new block header, validator set updates, evidence
+// and transactions), e.g. for blocks replicated from other node without execution.
+func PublishBlockEvents(eventBus *tmtypes.EventBus, resp *tmstate.ABCIResponses, block *types.Block) error {
+	if eventBus == nil {
 		return nil
 	}
 
@@ -290,25 +404,35 @@ func (e *BlockExecutor) publishEvents(resp *tmstate.ABCIResponses, block *types.
 		return err
 	}
 
-	err = multierr.Append(err, e.eventBus.PublishEventNewBlock(tmtypes.EventDataNewBlock{
+	err = multierr.Append(err, eventBus.PublishEventNewBlock(tmtypes.EventDataNewBlock{
 		Block:            abciBlock,
 		ResultBeginBlock: *resp.BeginBlock,
 		ResultEndBlock:   *resp.EndBlock,
 	}))
-	err = multierr.Append(err, e.eventBus.PublishEventNewBlockHeader(tmtypes.EventDataNewBlockHeader{
+	err = multierr.Append(err, eventBus.PublishEventNewBlockHeader(tmtypes.EventDataNewBlockHeader{
 		Header:           abciBlock.Header,
 		NumTxs:           int64(len(abciBlock.Txs)),
 		ResultBeginBlock: *resp.BeginBlock,
 		ResultEndBlock:   *resp.EndBlock,
 	}))
+	if len(resp.EndBlock.ValidatorUpdates) > 0 {
+		validators, verr := tmtypes.PB2TM.ValidatorUpdates(resp.EndBlock.ValidatorUpdates)
+		if verr != nil {
+			err = multierr.Append(err, verr)
+		} else {
+			err = multierr.Append(err, eventBus.PublishEventValidatorSetUpdates(tmtypes.EventDataValidatorSetUpdates{
+				ValidatorUpdates: validators,
+			}))
+		}
+	}
 	for _, ev := range abciBlock.Evidence.Evidence {
-		err = multierr.Append(err, e.eventBus.PublishEventNewEvidence(tmtypes.EventDataNewEvidence{
+		err = multierr.Append(err, eventBus.PublishEventNewEvidence(tmtypes.EventDataNewEvidence{
 			Evidence: ev,
 			Height:   int64(block.Header.Height),
 		}))
 	}
 	for i, dtx := range resp.DeliverTxs {
-		err = multierr.Append(err, e.eventBus.PublishEventTx(tmtypes.EventDataTx{
+		err = multierr.Append(err, eventBus.PublishEventTx(tmtypes.EventDataTx{
 			TxResult: abci.TxResult{
 				Height: int64(block.Header.Height),
 				Index:  uint32(i),
@@ -12,6 +12,9 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
 	"github.com/tendermint/tendermint/proxy"
@@ -38,14 +41,15 @@ func TestCreateBlock(t *testing.T) {
 	nsID := [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
 
 	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
-	executor := NewBlockExecutor([]byte("test address"), nsID, "test", mpool, proxy.NewAppConnConsensus(client), nil, logger)
+	executor := NewBlockExecutor([]byte("test address"), nsID, "test", mpool, NewABCIExecutor(proxy.NewAppConnConsensus(client), "test", logger), nil, logger)
 
 	state := State{}
 	state.ConsensusParams.Block.MaxBytes = 100
 	state.ConsensusParams.Block.MaxGas = 100000
 
 	// empty block
-	block := executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	block, err := executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	require.NoError(err)
 	require.NotNil(block)
 	assert.Empty(block.Data.Txs)
 	assert.Equal(uint64(1), block.Header.Height)
@@ -53,7 +57,8 @@ func TestCreateBlock(t *testing.T) {
 	// one small Tx
 	err = mpool.CheckTx([]byte{1, 2, 3, 4}, func(r *abci.Response) {}, mempool.TxInfo{})
 	require.NoError(err)
-	block = executor.CreateBlock(2, &types.Commit{}, [32]byte{}, state)
+	block, err = executor.CreateBlock(2, &types.Commit{}, [32]byte{}, state)
+	require.NoError(err)
 	require.NotNil(block)
 	assert.Equal(uint64(2), block.Header.Height)
 	assert.Len(block.Data.Txs, 1)
@@ -63,11 +68,56 @@ func TestCreateBlock(t *testing.T) {
 	require.NoError(err)
 	err = mpool.CheckTx(make([]byte, 100), func(r *abci.Response) {}, mempool.TxInfo{})
 	require.NoError(err)
-	block = executor.CreateBlock(3, &types.Commit{}, [32]byte{}, state)
+	block, err = executor.CreateBlock(3, &types.Commit{}, [32]byte{}, state)
+	require.NoError(err)
 	require.NotNil(block)
 	assert.Len(block.Data.Txs, 2)
 }
 
+func TestCreateBlockMaxBlobSize(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	logger := log.TestingLogger()
+
+	app := &mocks.Application{}
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+
+	maxBlobSize := uint64(maxBlockOverhead + 250)
+	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0,
+		mempool.WithPreCheck(TxPreCheckBlobSize(maxBlobSize)))
+	executor := NewBlockExecutor([]byte("test address"), [8]byte{}, "test", mpool, NewABCIExecutor(proxy.NewAppConnConsensus(client), "test", logger), nil, logger)
+	executor.SetMaxBlobSize(maxBlobSize)
+
+	state := State{}
+	state.ConsensusParams.Block.MaxBytes = 1000
+	state.ConsensusParams.Block.MaxGas = 100000
+
+	// transaction that can't fit in any block is rejected early
+	err = mpool.CheckTx(make([]byte, 300), func(r *abci.Response) {}, mempool.TxInfo{})
+	assert.Error(err)
+
+	// only two transactions fit in blob, even if consensus params allow more
+	for i := byte(0); i < 3; i++ {
+		tx := make([]byte, 100)
+		tx[0] = i
+		require.NoError(mpool.CheckTx(tx, func(r *abci.Response) {}, mempool.TxInfo{}))
+	}
+	block, err := executor.CreateBlock(1, &types.Commit{Signatures: []types.Signature{make([]byte, 64)}}, [32]byte{}, state)
+	require.NoError(err)
+	assert.Len(block.Data.Txs, 2)
+	blob, err := block.MarshalBinary()
+	require.NoError(err)
+	assert.LessOrEqual(uint64(len(blob)), maxBlobSize)
+
+	executor.SetMaxBlobSize(10)
+	_, err = executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	assert.Error(err)
+}
+
 func TestApplyBlock(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -96,7 +146,7 @@ func TestApplyBlock(t *testing.T) {
 	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
 	eventBus := tmtypes.NewEventBus()
 	require.NoError(eventBus.Start())
-	executor := NewBlockExecutor([]byte("test address"), nsID, chainID, mpool, proxy.NewAppConnConsensus(client), eventBus, logger)
+	executor := NewBlockExecutor([]byte("test address"), nsID, chainID, mpool, NewABCIExecutor(proxy.NewAppConnConsensus(client), chainID, logger), eventBus, logger)
 
 	txQuery, err := query.New("tm.event='Tx'")
 	require.NoError(err)
@@ -113,35 +163,43 @@ func TestApplyBlock(t *testing.T) {
 	state := State{}
 	state.InitialHeight = 1
 	state.LastBlockHeight = 0
+	state.Validators = tmtypes.NewValidatorSet(nil)
+	state.NextValidators = tmtypes.NewValidatorSet(nil)
 	state.ConsensusParams.Block.MaxBytes = 100
 	state.ConsensusParams.Block.MaxGas = 100000
 
 	_ = mpool.CheckTx([]byte{1, 2, 3, 4}, func(r *abci.Response) {}, mempool.TxInfo{})
 	require.NoError(err)
-	block := executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	block, err := executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	require.NoError(err)
 	require.NotNil(block)
 	assert.Equal(uint64(1), block.Header.Height)
 	assert.Len(block.Data.Txs, 1)
 
-	newState, _, err := executor.ApplyBlock(context.TODO(), state, block)
+	newState, resp, _, err := executor.ApplyBlock(context.TODO(), state, block)
 	require.NoError(err)
 	require.NotNil(newState)
 	assert.Equal(int64(1), newState.LastBlockHeight)
 	assert.Equal(mockAppHash, newState.AppHash)
+	require.NotNil(resp)
+	assert.Len(resp.DeliverTxs, 1)
 
 	require.NoError(mpool.CheckTx([]byte{0, 1, 2, 3, 4}, func(r *abci.Response) {}, mempool.TxInfo{}))
 	require.NoError(mpool.CheckTx([]byte{5, 6, 7, 8, 9}, func(r *abci.Response) {}, mempool.TxInfo{}))
 	require.NoError(mpool.CheckTx([]byte{1, 2, 3, 4, 5}, func(r *abci.Response) {}, mempool.TxInfo{}))
 	require.NoError(mpool.CheckTx(make([]byte, 90), func(r *abci.Response) {}, mempool.TxInfo{}))
-	block = executor.CreateBlock(2, &types.Commit{}, [32]byte{}, newState)
+	block, err = executor.CreateBlock(2, &types.Commit{}, [32]byte{}, newState)
+	require.NoError(err)
 	require.NotNil(block)
 	assert.Equal(uint64(2), block.Header.Height)
 	assert.Len(block.Data.Txs, 3)
 
-	newState, _, err = executor.ApplyBlock(context.TODO(), newState, block)
+	newState, resp, _, err = executor.ApplyBlock(context.TODO(), newState, block)
 	require.NoError(err)
 	require.NotNil(newState)
 	assert.Equal(int64(2), newState.LastBlockHeight)
+	require.NotNil(resp)
+	assert.Len(resp.DeliverTxs, 3)
 
 	// wait for at least 4 Tx events, for up to 3 second.
 	// 3 seconds is a fail-scenario only
@@ -174,3 +232,83 @@ func TestApplyBlock(t *testing.T) {
 		}
 	}
 }
+
+func TestSequencerRotation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	logger := log.TestingLogger()
+
+	oldKey, newKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	oldPubKey, err := cryptoenc.PubKeyToProto(oldKey.PubKey())
+	require.NoError(err)
+	newPubKey, err := cryptoenc.PubKeyToProto(newKey.PubKey())
+	require.NoError(err)
+
+	app := &mocks.Application{}
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("EndBlock", abci.RequestEndBlock{Height: 1}).Return(abci.ResponseEndBlock{
+		ValidatorUpdates: []abci.ValidatorUpdate{{PubKey: oldPubKey, Power: 0}, {PubKey: newPubKey, Power: 1}},
+	})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+
+	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
+	eventBus := tmtypes.NewEventBus()
+	require.NoError(eventBus.Start())
+	valQuery, err := query.New("tm.event='ValidatorSetUpdates'")
+	require.NoError(err)
+	valSub, err := eventBus.Subscribe(context.Background(), "test", valQuery, 10)
+	require.NoError(err)
+
+	newExecutor := func(key crypto.PrivKey) *BlockExecutor {
+		return NewBlockExecutor(key.PubKey().Address(), [8]byte{}, "test", mpool, NewABCIExecutor(proxy.NewAppConnConsensus(client), "test", logger), eventBus, logger)
+	}
+	oldExecutor, rotatedExecutor := newExecutor(oldKey), newExecutor(newKey)
+	applyBlock := func(executor *BlockExecutor, height uint64, state State) (State, error) {
+		block, err := executor.CreateBlock(height, &types.Commit{}, [32]byte{}, state)
+		require.NoError(err)
+		newState, _, _, err := executor.ApplyBlock(context.TODO(), state, block)
+		return newState, err
+	}
+
+	state := State{}
+	state.InitialHeight = 1
+	state.Validators = tmtypes.NewValidatorSet([]*tmtypes.Validator{tmtypes.NewValidator(oldKey.PubKey(), 1)})
+	state.NextValidators = state.Validators.Copy()
+	state.ConsensusParams = *tmtypes.DefaultConsensusParams()
+
+	// sequencer change is scheduled in block 1
+	state, err = applyBlock(oldExecutor, 1, state)
+	require.NoError(err)
+	assert.Equal(oldKey.PubKey().Address(), state.Validators.GetProposer().Address)
+	assert.Equal(newKey.PubKey().Address(), state.NextValidators.GetProposer().Address)
+	assert.Equal(int64(3), state.LastHeightValidatorsChanged)
+
+	select {
+	case evt := <-valSub.Out():
+		data, ok := evt.Data().(tmtypes.EventDataValidatorSetUpdates)
+		require.True(ok)
+		assert.Len(data.ValidatorUpdates, 2)
+	case <-time.After(time.Second):
+		t.Fatal("no validator set updates event")
+	}
+
+	// block 2 is still produced by the old sequencer
+	state, err = applyBlock(oldExecutor, 2, state)
+	require.NoError(err)
+	assert.Equal(newKey.PubKey().Address(), state.Validators.GetProposer().Address)
+
+	// block 3 has to be produced by the new sequencer
+	_, err = applyBlock(oldExecutor, 3, state)
+	assert.Error(err)
+	_, err = applyBlock(rotatedExecutor, 3, state)
+	assert.NoError(err)
+
+	// adding second sequencer is not allowed
+	err = oldExecutor.updateValidators(state.NextValidators.Copy(), []abci.ValidatorUpdate{{PubKey: oldPubKey, Power: 1}}, state.ConsensusParams.Validator)
+	assert.Error(err)
+}
@@ -0,0 +1,44 @@
+//go:build go1.18
+// +build go1.18
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// FuzzStateUnmarshal checks that decoding of arbitrary (e.g. corrupted in store) state can fail, but it can't panic.
+//
+// Run with: go test ./state -run '^$' -fuzz FuzzStateUnmarshal
+func FuzzStateUnmarshal(f *testing.F) {
+	genesis := &tmtypes.GenesisDoc{
+		ChainID:     "test",
+		GenesisTime: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		Validators: []tmtypes.GenesisValidator{{
+			PubKey: ed25519.GenPrivKeyFromSecret([]byte("fuzz")).PubKey(),
+			Power:  1,
+		}},
+	}
+	s, err := NewFromGenesisDoc(genesis)
+	if err != nil {
+		f.Fatal(err)
+	}
+	blob, err := s.MarshalBinary()
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(blob)
+	f.Add([]byte("{}"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var s State
+		if err := s.UnmarshalBinary(data); err != nil {
+			return
+		}
+		_, _ = s.MarshalBinary()
+	})
+}
@@ -129,6 +129,18 @@ func TestBlockIndexer(t *testing.T) {
 			q:       query.MustParse("begin_event.proposer CONTAINS 'FCAA001'"),
 			results: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
 		},
+		"end_event.foo EXISTS": {
+			q:       query.MustParse("end_event.foo EXISTS"),
+			results: []int64{1, 2, 4, 6, 8, 10},
+		},
+		"end_event.bar EXISTS": {
+			q:       query.MustParse("end_event.bar EXISTS"),
+			results: []int64{},
+		},
+		"begin_event.proposer EXISTS AND end_event.foo > 5": {
+			q:       query.MustParse("begin_event.proposer EXISTS AND end_event.foo > 5"),
+			results: []int64{1, 6, 8, 10},
+		},
 	}
 
 	for name, tc := range testCases {
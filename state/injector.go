@@ -0,0 +1,129 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/optimint/types"
+)
+
+// TxInjector allows embedders to inject system transactions (e.g. oracle updates or bridge messages) into blocks.
+//
+// Injected transactions are added by the sequencer to every produced block and verified by full nodes,
+// so both methods have to be deterministic - they must return the same transactions on all nodes.
+type TxInjector interface {
+	// PreBlockTxs returns transactions that are placed at the beginning of the block at given height.
+	PreBlockTxs(height uint64, state State) (types.Txs, error)
+	// PostBlockTxs returns transactions that are placed at the end of the block at given height.
+	PostBlockTxs(height uint64, state State) (types.Txs, error)
+}
+
+// ChainTxInjectors returns TxInjector that concatenates transactions returned by given injectors (in order).
+// Nil injectors are ignored.
+func ChainTxInjectors(injectors ...TxInjector) TxInjector {
+	var chain txInjectorChain
+	for _, injector := range injectors {
+		if injector != nil {
+			chain = append(chain, injector)
+		}
+	}
+	switch len(chain) {
+	case 0:
+		return nil
+	case 1:
+		return chain[0]
+	}
+	return chain
+}
+
+type txInjectorChain []TxInjector
+
+func (c txInjectorChain) PreBlockTxs(height uint64, state State) (types.Txs, error) {
+	return c.collect(func(injector TxInjector) (types.Txs, error) { return injector.PreBlockTxs(height, state) })
+}
+
+func (c txInjectorChain) PostBlockTxs(height uint64, state State) (types.Txs, error) {
+	return c.collect(func(injector TxInjector) (types.Txs, error) { return injector.PostBlockTxs(height, state) })
+}
+
+func (c txInjectorChain) collect(get func(TxInjector) (types.Txs, error)) (types.Txs, error) {
+	var txs types.Txs
+	for _, injector := range c {
+		injected, err := get(injector)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, injected...)
+	}
+	return txs, nil
+}
+
+// SetTxInjector sets TxInjector used to create and validate blocks.
+func (e *BlockExecutor) SetTxInjector(injector TxInjector) {
+	e.txInjector = injector
+}
+
+// SetForcedTxInjector sets TxInjector providing transactions of forced inclusion lane.
+// Only pre-block transactions are used, they are placed after pre-block system transactions.
+func (e *BlockExecutor) SetForcedTxInjector(injector TxInjector) {
+	e.forcedTxs = injector
+}
+
+// SetLaneBudgets sets budgets of block lanes (see LaneBudgets).
+func (e *BlockExecutor) SetLaneBudgets(budgets LaneBudgets) {
+	e.budgets = budgets
+}
+
+// injectedTxs returns transactions placed before (system and forced inclusion lanes) and after (system lane)
+// mempool transactions in a block at given height.
+func (e *BlockExecutor) injectedTxs(height uint64, state State) (types.Txs, types.Txs, error) {
+	var pre, post types.Txs
+	var err error
+	if e.txInjector != nil {
+		pre, err = e.txInjector.PreBlockTxs(height, state)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get pre-block transactions: %w", err)
+		}
+		post, err = e.txInjector.PostBlockTxs(height, state)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get post-block transactions: %w", err)
+		}
+		if err := e.budgets.checkSystemLane(pre, post); err != nil {
+			return nil, nil, err
+		}
+	}
+	if e.forcedTxs != nil {
+		forced, err := e.forcedTxs.PreBlockTxs(height, state)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get forced inclusion transactions: %w", err)
+		}
+		pre = append(pre, forced...)
+	}
+	return pre, post, nil
+}
+
+// validateInjectedTxs checks if block starts and ends with expected system transactions.
+// Numbers of transactions placed before and after mempool transactions are returned.
+func (e *BlockExecutor) validateInjectedTxs(state State, block *types.Block) (int, int, error) {
+	pre, post, err := e.injectedTxs(block.Header.Height, state)
+	if err != nil {
+		return 0, 0, err
+	}
+	txs := block.Data.Txs
+	if len(txs) < len(pre)+len(post) {
+		return 0, 0, errors.New("block doesn't contain all system transactions")
+	}
+	for i := range pre {
+		if !bytes.Equal(txs[i], pre[i]) {
+			return 0, 0, fmt.Errorf("pre-block transaction %d mismatch", i)
+		}
+	}
+	offset := len(txs) - len(post)
+	for i := range post {
+		if !bytes.Equal(txs[offset+i], post[i]) {
+			return 0, 0, fmt.Errorf("post-block transaction %d mismatch", i)
+		}
+	}
+	return len(pre), len(post), nil
+}
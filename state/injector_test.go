@@ -0,0 +1,102 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/mempool"
+	"github.com/celestiaorg/optimint/mocks"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// heightInjector adds transactions with block height at the beginning and at the end of the block.
+type heightInjector struct{}
+
+func (heightInjector) PreBlockTxs(height uint64, _ State) (types.Txs, error) {
+	return types.Txs{heightTx("pre", height)}, nil
+}
+
+func (heightInjector) PostBlockTxs(height uint64, _ State) (types.Txs, error) {
+	return types.Txs{heightTx("post", height)}, nil
+}
+
+func heightTx(prefix string, height uint64) types.Tx {
+	return types.Tx(fmt.Sprintf("%s-%d", prefix, height))
+}
+
+func TestTxInjector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+
+	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
+	executor := NewBlockExecutor([]byte("test address"), [8]byte{}, "test", mpool, NewABCIExecutor(proxy.NewAppConnConsensus(client), "test", log.TestingLogger()), nil, log.TestingLogger())
+	executor.SetTxInjector(heightInjector{})
+
+	state := State{}
+	state.InitialHeight = 1
+	state.Validators = tmtypes.NewValidatorSet(nil)
+	state.NextValidators = tmtypes.NewValidatorSet(nil)
+	state.ConsensusParams.Block.MaxBytes = 100
+	state.ConsensusParams.Block.MaxGas = 100000
+
+	require.NoError(mpool.CheckTx([]byte{1, 2, 3, 4}, func(r *abci.Response) {}, mempool.TxInfo{}))
+	block, err := executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	require.NoError(err)
+	require.Len(block.Data.Txs, 3)
+	assert.Equal(heightTx("pre", 1), block.Data.Txs[0])
+	assert.Equal(types.Tx{1, 2, 3, 4}, block.Data.Txs[1])
+	assert.Equal(heightTx("post", 1), block.Data.Txs[2])
+
+	_, _, _, err = executor.ApplyBlock(context.TODO(), state, block)
+	assert.NoError(err)
+
+	// block without system transactions is rejected
+	invalid, err := executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	require.NoError(err)
+	invalid.Data.Txs = invalid.Data.Txs[:len(invalid.Data.Txs)-1]
+	invalid.Header.DataHash = invalid.Data.Hash()
+	_, _, _, err = executor.ApplyBlock(context.TODO(), state, invalid)
+	assert.Error(err)
+
+	// system transactions must fit into the block
+	state.ConsensusParams.Block.MaxBytes = 10
+	_, err = executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	assert.Error(err)
+}
+
+func TestChainTxInjectors(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(ChainTxInjectors())
+	assert.Nil(ChainTxInjectors(nil, nil))
+	assert.Equal(heightInjector{}, ChainTxInjectors(nil, heightInjector{}))
+
+	chain := ChainTxInjectors(heightInjector{}, heightInjector{})
+	pre, err := chain.PreBlockTxs(3, State{})
+	assert.NoError(err)
+	assert.Equal(types.Txs{heightTx("pre", 3), heightTx("pre", 3)}, pre)
+	post, err := chain.PostBlockTxs(3, State{})
+	assert.NoError(err)
+	assert.Equal(types.Txs{heightTx("post", 3), heightTx("post", 3)}, post)
+}
@@ -0,0 +1,56 @@
+package state
+
+import (
+	"fmt"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/types"
+)
+
+// LaneBudget limits transactions of a single lane of the block. Zero values mean no limit (other than block limits).
+type LaneBudget struct {
+	// MaxBytes limits total size of lane transactions (see ComputeProtoSizeForTxs).
+	MaxBytes int64
+	// MaxGas limits total gas wanted by lane transactions. It's applied only to mempool lane, because gas wanted
+	// by system and forced inclusion transactions is not known before execution.
+	MaxGas int64
+}
+
+// LaneBudgets contains budgets of block lanes.
+//
+// Block is built from lanes, in order of priority: system transactions (returned by TxInjector), forced inclusion
+// transactions (posted directly to DA layer) and mempool transactions (ordered by mempool, e.g. by fee).
+// Pre-block system transactions are placed at the beginning of the block, followed by forced inclusion transactions
+// and mempool transactions; post-block system transactions are placed at the end of the block.
+//
+// Lanes with higher priority take space first, so mempool lane gets what remains of block limits.
+// System lane budget is verified by all nodes, so it has to be the same on all of them.
+type LaneBudgets struct {
+	System  LaneBudget
+	Mempool LaneBudget
+}
+
+// checkSystemLane returns error if system transactions exceed system lane budget.
+func (b LaneBudgets) checkSystemLane(pre, post types.Txs) error {
+	if b.System.MaxBytes <= 0 {
+		return nil
+	}
+	size := tmtypes.ComputeProtoSizeForTxs(fromOptimintTxs(pre)) + tmtypes.ComputeProtoSizeForTxs(fromOptimintTxs(post))
+	if size > b.System.MaxBytes {
+		return fmt.Errorf("system transactions exceed system lane budget: %d bytes, max: %d", size, b.System.MaxBytes)
+	}
+	return nil
+}
+
+// mempoolLimits returns max bytes and max gas of mempool lane, given limits remaining in the block.
+// Negative value means no limit.
+func (b LaneBudgets) mempoolLimits(maxBytes, maxGas int64) (int64, int64) {
+	if b.Mempool.MaxBytes > 0 && (maxBytes < 0 || b.Mempool.MaxBytes < maxBytes) {
+		maxBytes = b.Mempool.MaxBytes
+	}
+	if b.Mempool.MaxGas > 0 && (maxGas < 0 || b.Mempool.MaxGas < maxGas) {
+		maxGas = b.Mempool.MaxGas
+	}
+	return maxBytes, maxGas
+}
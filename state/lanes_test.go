@@ -0,0 +1,98 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/mempool"
+	"github.com/celestiaorg/optimint/mocks"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// forcedInjector adds a single forced inclusion transaction with block height.
+type forcedInjector struct{}
+
+func (forcedInjector) PreBlockTxs(height uint64, _ State) (types.Txs, error) {
+	return types.Txs{heightTx("forced", height)}, nil
+}
+
+func (forcedInjector) PostBlockTxs(uint64, State) (types.Txs, error) {
+	return nil, nil
+}
+
+func TestLanes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{GasWanted: 10})
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+
+	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
+	executor := NewBlockExecutor([]byte("test address"), [8]byte{}, "test", mpool, NewABCIExecutor(proxy.NewAppConnConsensus(client), "test", log.TestingLogger()), nil, log.TestingLogger())
+	executor.SetTxInjector(heightInjector{})
+	executor.SetForcedTxInjector(forcedInjector{})
+	executor.SetLaneBudgets(LaneBudgets{
+		System:  LaneBudget{MaxBytes: 20},
+		Mempool: LaneBudget{MaxBytes: 100, MaxGas: 25},
+	})
+
+	state := State{}
+	state.InitialHeight = 1
+	state.Validators = tmtypes.NewValidatorSet(nil)
+	state.NextValidators = tmtypes.NewValidatorSet(nil)
+	state.ConsensusParams.Block.MaxBytes = 1000
+	state.ConsensusParams.Block.MaxGas = 100000
+
+	for i := byte(1); i <= 3; i++ {
+		require.NoError(mpool.CheckTx([]byte{i, i, i}, func(r *abci.Response) {}, mempool.TxInfo{}))
+	}
+
+	// mempool lane is limited by gas budget
+	block, err := executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	require.NoError(err)
+	assert.Equal(types.Txs{
+		heightTx("pre", 1),
+		heightTx("forced", 1),
+		types.Tx{1, 1, 1},
+		types.Tx{2, 2, 2},
+		heightTx("post", 1),
+	}, block.Data.Txs)
+	_, _, _, err = executor.ApplyBlock(context.TODO(), state, block)
+	assert.NoError(err)
+
+	// forced inclusion transactions must follow pre-block system transactions
+	invalid, err := executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	require.NoError(err)
+	invalid.Data.Txs[0], invalid.Data.Txs[1] = invalid.Data.Txs[1], invalid.Data.Txs[0]
+	invalid.Header.DataHash = invalid.Data.Hash()
+	_, _, _, err = executor.ApplyBlock(context.TODO(), state, invalid)
+	assert.Error(err)
+
+	// mempool lane is limited by bytes budget
+	executor.SetLaneBudgets(LaneBudgets{Mempool: LaneBudget{MaxBytes: 5}})
+	block, err = executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	require.NoError(err)
+	assert.Len(block.Data.Txs, 4)
+
+	// system transactions must fit into system lane
+	executor.SetLaneBudgets(LaneBudgets{System: LaneBudget{MaxBytes: 10}})
+	_, err = executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	assert.Error(err)
+}
@@ -0,0 +1,59 @@
+package state
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/mempool"
+)
+
+// TxPreCheck returns a function to filter transactions before processing.
+// Transactions bigger than max block size (according to consensus params in state) are rejected.
+// Additional check (if not nil) is applied afterwards.
+func TxPreCheck(state State, check mempool.PreCheckFunc) mempool.PreCheckFunc {
+	maxBytes := mempool.PreCheckMaxBytes(state.ConsensusParams.Block.MaxBytes)
+	if check == nil {
+		return maxBytes
+	}
+	return func(tx tmtypes.Tx) error {
+		if err := maxBytes(tx); err != nil {
+			return err
+		}
+		return check(tx)
+	}
+}
+
+// TxPreCheckBlobSize returns a function rejecting transactions that don't fit in DA layer blob of given size,
+// even in a block without other transactions. Returns nil if maxBlobSize is zero (no limit).
+func TxPreCheckBlobSize(maxBlobSize uint64) mempool.PreCheckFunc {
+	if maxBlobSize == 0 {
+		return nil
+	}
+	maxBytes := MaxTxsBytes(maxBlobSize)
+	return func(tx tmtypes.Tx) error {
+		txSize := tmtypes.ComputeProtoSizeForTxs([]tmtypes.Tx{tx})
+		if txSize > maxBytes {
+			return fmt.Errorf("tx size is too big for DA layer: %d, max: %d (max blob size: %d)",
+				txSize, maxBytes, maxBlobSize)
+		}
+		return nil
+	}
+}
+
+// TxPostCheck returns a function to filter transactions after processing.
+// Transactions that require more gas than available in a block (according to consensus params in state) are rejected.
+// Additional check (if not nil) is applied afterwards.
+func TxPostCheck(state State, check mempool.PostCheckFunc) mempool.PostCheckFunc {
+	maxGas := mempool.PostCheckMaxGas(state.ConsensusParams.Block.MaxGas)
+	if check == nil {
+		return maxGas
+	}
+	return func(tx tmtypes.Tx, res *abci.ResponseCheckTx) error {
+		if err := maxGas(tx, res); err != nil {
+			return err
+		}
+		return check(tx, res)
+	}
+}
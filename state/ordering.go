@@ -0,0 +1,102 @@
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/types"
+)
+
+// TxSequencer provides first-come-first-served sequence numbers of mempool transactions.
+type TxSequencer interface {
+	// TxSequence returns sequence number assigned to transaction when it was added to the mempool (0 if none).
+	TxSequence(tx types.Tx) uint64
+}
+
+// SetFCFSOrdering enables first-come-first-served ordering of mempool transactions. Every block contains sequence
+// numbers of transactions (see types.OrderingReceipt); sequence numbers of mempool transactions have to be strictly
+// increasing, across blocks. System and forced inclusion transactions don't have sequence numbers.
+//
+// Full nodes only check that sequence numbers are increasing. They can't detect that receipted transaction was
+// omitted (sequencer can skip it and include transactions with higher sequence numbers) - only the user holding the
+// receipt can prove that (see rpc/client.CheckOrderingReceipt).
+//
+// Sequencer is used to create blocks, so it may be nil if node doesn't produce blocks. All nodes of the chain have to
+// use the same setting.
+func (e *BlockExecutor) SetFCFSOrdering(sequencer TxSequencer) {
+	e.fcfsOrdering = true
+	e.sequencer = sequencer
+}
+
+// sequenceTxs returns mempool transactions that can be included in a block in order of sequence numbers, and sequence
+// numbers of all block transactions. Size of sequence numbers is accounted in maxBytes (negative value - no limit).
+// Transactions without valid sequence number are skipped.
+func (e *BlockExecutor) sequenceTxs(state State, txs types.Txs, maxBytes int64, pre, post int) (types.Txs, []uint64) {
+	// field overhead and zero sequence numbers of system transactions
+	size := int64(1 + binary.MaxVarintLen64 + pre + post)
+	seqs := make([]uint64, pre, pre+len(txs)+post)
+	sequenced := make(types.Txs, 0, len(txs))
+	last := state.LastTxSequence
+	for _, tx := range txs {
+		var seq uint64
+		if e.sequencer != nil {
+			seq = e.sequencer.TxSequence(tx)
+		}
+		if seq <= last {
+			e.logger.Debug("skipping transaction without valid sequence number", "tx", tmtypes.Tx(tx).Hash(),
+				"sequence", seq, "last", last)
+			continue
+		}
+		size += tmtypes.ComputeProtoSizeForTxs(tmtypes.Txs{tmtypes.Tx(tx)}) + binary.MaxVarintLen64
+		if maxBytes >= 0 && size > maxBytes {
+			break
+		}
+		last = seq
+		sequenced = append(sequenced, tx)
+		seqs = append(seqs, seq)
+	}
+	return sequenced, append(seqs, make([]uint64, post)...)
+}
+
+// validateTxSequences checks if sequence numbers of mempool transactions (placed between pre and post system
+// transactions) are strictly increasing.
+func (e *BlockExecutor) validateTxSequences(state State, block *types.Block, pre, post int) error {
+	seqs := block.Data.TxSequences
+	if !e.fcfsOrdering {
+		if len(seqs) > 0 {
+			return errors.New("unexpected transaction sequence numbers")
+		}
+		return nil
+	}
+	if len(seqs) != len(block.Data.Txs) {
+		return errors.New("missing transaction sequence numbers")
+	}
+	last := state.LastTxSequence
+	for i, seq := range seqs {
+		if i < pre || i >= len(seqs)-post {
+			if seq != 0 {
+				return fmt.Errorf("system transaction %d has sequence number", i)
+			}
+			continue
+		}
+		if seq <= last {
+			return fmt.Errorf("transaction %d out of order: sequence number %d, previous: %d", i, seq, last)
+		}
+		last = seq
+	}
+	return nil
+}
+
+// lastTxSequence returns the highest transaction sequence number after applying the block.
+func lastTxSequence(state State, block *types.Block) uint64 {
+	last := state.LastTxSequence
+	for _, seq := range block.Data.TxSequences {
+		if seq > last {
+			last = seq
+		}
+	}
+	return last
+}
@@ -0,0 +1,77 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/mempool"
+	"github.com/celestiaorg/optimint/mocks"
+	"github.com/celestiaorg/optimint/types"
+)
+
+type mapSequencer map[string]uint64
+
+func (s mapSequencer) TxSequence(tx types.Tx) uint64 {
+	return s[string(tx)]
+}
+
+func TestFCFSOrdering(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+
+	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
+	executor := NewBlockExecutor([]byte("test address"), [8]byte{}, "test", mpool, NewABCIExecutor(proxy.NewAppConnConsensus(client), "test", log.TestingLogger()), nil, log.TestingLogger())
+	executor.SetFCFSOrdering(mapSequencer{"tx1": 3, "tx2": 5, "tx3": 0, "tx4": 2})
+
+	state := State{}
+	state.InitialHeight = 1
+	state.LastTxSequence = 2
+	state.Validators = tmtypes.NewValidatorSet(nil)
+	state.NextValidators = tmtypes.NewValidatorSet(nil)
+	state.ConsensusParams.Block.MaxBytes = 1000
+	state.ConsensusParams.Block.MaxGas = 100000
+
+	for _, tx := range []string{"tx1", "tx2", "tx3", "tx4"} {
+		require.NoError(mpool.CheckTx(tmtypes.Tx(tx), nil, mempool.TxInfo{}))
+	}
+	block, err := executor.CreateBlock(1, &types.Commit{}, [32]byte{}, state)
+	require.NoError(err)
+	assert.Equal(types.Txs{types.Tx("tx1"), types.Tx("tx2")}, block.Data.Txs)
+	assert.Equal([]uint64{3, 5}, block.Data.TxSequences)
+
+	newState, _, _, err := executor.ApplyBlock(context.Background(), state, block)
+	require.NoError(err)
+	assert.Equal(uint64(5), newState.LastTxSequence)
+
+	block.Data.TxSequences = []uint64{5, 3}
+	_, _, _, err = executor.ApplyBlock(context.Background(), state, block)
+	assert.Error(err)
+
+	block.Data.TxSequences = []uint64{2, 5}
+	_, _, _, err = executor.ApplyBlock(context.Background(), state, block)
+	assert.Error(err)
+
+	block.Data.TxSequences = nil
+	_, _, _, err = executor.ApplyBlock(context.Background(), state, block)
+	assert.Error(err)
+}
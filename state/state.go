@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	// TODO(tzdybal): copy to local project?
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -53,6 +54,9 @@ type State struct {
 
 	// the latest AppHash we've received from calling abci.Commit()
 	AppHash [32]byte
+
+	// the highest sequence number of transaction included in the chain (FCFS ordering, see types.OrderingReceipt)
+	LastTxSequence uint64
 }
 
 // NewFromGenesisDoc reads blockchain State from genesis.
@@ -96,3 +100,17 @@ func NewFromGenesisDoc(genDoc *types.GenesisDoc) (State, error) {
 
 	return s, nil
 }
+
+// MarshalBinary returns canonical encoding of the State, used to persist it.
+//
+// State is encoded with Tendermint JSON encoding (required to serialize public keys of validators), with fields in
+// order of declaration. Block time is normalized to UTC, so that encoding doesn't depend on local time zone.
+func (s State) MarshalBinary() ([]byte, error) {
+	s.LastBlockTime = s.LastBlockTime.UTC()
+	return tmjson.Marshal(s)
+}
+
+// UnmarshalBinary decodes State encoded with MarshalBinary.
+func (s *State) UnmarshalBinary(data []byte) error {
+	return tmjson.Unmarshal(data, s)
+}
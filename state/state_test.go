@@ -0,0 +1,51 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestStateSerialization(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	genesis := &tmtypes.GenesisDoc{
+		ChainID: "test",
+		Validators: []tmtypes.GenesisValidator{{
+			PubKey: ed25519.GenPrivKey().PubKey(),
+			Power:  1,
+		}},
+	}
+	s, err := NewFromGenesisDoc(genesis)
+	require.NoError(err)
+	s.LastBlockHeight = 10
+	s.LastBlockTime = time.Date(2022, 1, 1, 12, 0, 0, 1, time.FixedZone("test", 3600))
+	s.AppHash = [32]byte{1, 2, 3}
+	s.LastTxSequence = 42
+
+	blob, err := s.MarshalBinary()
+	require.NoError(err)
+
+	var decoded State
+	require.NoError(decoded.UnmarshalBinary(blob))
+	assert.True(s.LastBlockTime.Equal(decoded.LastBlockTime))
+	assert.Equal(time.UTC, decoded.LastBlockTime.Location())
+	assert.Equal(s.AppHash, decoded.AppHash)
+	assert.Equal(s.LastTxSequence, decoded.LastTxSequence)
+	assert.Equal(s.Validators.Hash(), decoded.Validators.Hash())
+
+	// encoding is stable and doesn't depend on time zone
+	again, err := decoded.MarshalBinary()
+	require.NoError(err)
+	assert.Equal(blob, again)
+	s.LastBlockTime = s.LastBlockTime.UTC()
+	utc, err := s.MarshalBinary()
+	require.NoError(err)
+	assert.Equal(blob, utc)
+}
@@ -0,0 +1,329 @@
+package state
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/gtank/ristretto255"
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/celestiaorg/optimint/types"
+)
+
+// Threshold encryption of transactions uses hashed ElGamal over ristretto255 group. Committee key is shared with
+// Shamir's secret sharing, so any Threshold members can decrypt a transaction together, while smaller groups can't.
+//
+// Ciphertext is R || AEAD(tx), where R = r*G for random r, and AEAD (ChaCha20-Poly1305) key is derived from R and
+// r*Y, where Y is the committee public key. Member i decrypts by publishing decryption share D_i = x_i*R, with a proof
+// that D_i and public key share X_i = x_i*G use the same secret x_i (Chaum-Pedersen DLEQ proof). Any Threshold valid
+// shares are combined (with Lagrange interpolation) into r*Y, which is used to decrypt the transaction.
+
+const (
+	elementSize = 32
+	proofSize   = 64
+
+	// domain separation tags
+	keyDerivationTag = "optimint/enc/v1/key"
+	dleqTag          = "optimint/enc/v1/dleq"
+)
+
+// ErrInvalidCiphertext is returned if encrypted transaction can't be decrypted by anyone (e.g. it's malformed).
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+// ThresholdKey contains public parameters of committee key.
+type ThresholdKey struct {
+	// PublicKey is the committee public key Y, used to encrypt transactions.
+	PublicKey [elementSize]byte
+	// Shares are public key shares X_i of committee members, used to verify decryption shares. Member index is
+	// position in Shares plus 1.
+	Shares [][elementSize]byte
+	// Threshold is the number of decryption shares required to decrypt a transaction.
+	Threshold int
+}
+
+// KeyShare is a private key share x_i of committee member with given index (starting from 1).
+type KeyShare struct {
+	Index  int
+	Secret [elementSize]byte
+}
+
+// DecryptionShare is a share of committee member published to decrypt single transaction.
+type DecryptionShare struct {
+	Index int
+	Share [elementSize]byte
+	Proof [proofSize]byte
+}
+
+// DealThresholdKey generates committee key, split into members shares, any threshold of which can decrypt
+// transactions. Dealer knows the whole key, so it should be used only if dealer is trusted (e.g. in test networks);
+// otherwise, key should be generated using distributed key generation.
+func DealThresholdKey(threshold, members int) (*ThresholdKey, []KeyShare, error) {
+	if threshold < 1 || threshold > members {
+		return nil, nil, fmt.Errorf("invalid threshold %d of %d members", threshold, members)
+	}
+
+	coeffs := make([]*ristretto255.Scalar, threshold)
+	for i := range coeffs {
+		var err error
+		coeffs[i], err = randomScalar()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	key := &ThresholdKey{Shares: make([][elementSize]byte, members), Threshold: threshold}
+	ristretto255.NewElement().ScalarBaseMult(coeffs[0]).Encode(key.PublicKey[:0])
+	shares := make([]KeyShare, members)
+	for i := range shares {
+		// polynomial evaluated at member index, using Horner's method
+		x := indexScalar(i + 1)
+		secret := ristretto255.NewScalar()
+		for j := len(coeffs) - 1; j >= 0; j-- {
+			secret.Multiply(secret, x)
+			secret.Add(secret, coeffs[j])
+		}
+		shares[i].Index = i + 1
+		secret.Encode(shares[i].Secret[:0])
+		ristretto255.NewElement().ScalarBaseMult(secret).Encode(key.Shares[i][:0])
+	}
+	return key, shares, nil
+}
+
+// EncryptTx encrypts transaction to committee key, and wraps it in encrypted envelope together with fee paying outer
+// transaction. Ciphertext is bound to the outer transaction, so it can't be rewrapped.
+func EncryptTx(key *ThresholdKey, outer, tx types.Tx) (types.Tx, error) {
+	y, err := decodeElement(key.PublicKey[:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	r, err := randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	rG := ristretto255.NewElement().ScalarBaseMult(r)
+	rY := ristretto255.NewElement().ScalarMult(r, y)
+
+	aead, err := newAEAD(rG, rY)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext := rG.Encode(make([]byte, 0, elementSize+len(tx)+aead.Overhead()))
+	ciphertext = aead.Seal(ciphertext, make([]byte, aead.NonceSize()), tx, outer)
+	return EncodeEncryptedTx(outer, ciphertext), nil
+}
+
+// DecryptionShare returns decryption share of given envelope.
+func (s *KeyShare) DecryptionShare(tx types.Tx) (*DecryptionShare, error) {
+	_, ciphertext, err := ParseEncryptedTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	rG, err := decodeElement(ciphertext[:elementSize])
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	x := ristretto255.NewScalar()
+	if err := x.Decode(s.Secret[:]); err != nil {
+		return nil, fmt.Errorf("invalid key share: %w", err)
+	}
+	xG := ristretto255.NewElement().ScalarBaseMult(x)
+	d := ristretto255.NewElement().ScalarMult(x, rG)
+
+	// Chaum-Pedersen proof that log_G(xG) == log_rG(d)
+	k, err := randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	a := ristretto255.NewElement().ScalarBaseMult(k)
+	b := ristretto255.NewElement().ScalarMult(k, rG)
+	c := dleqChallenge(xG, rG, d, a, b)
+	z := ristretto255.NewScalar().Multiply(c, x)
+	z.Add(z, k)
+
+	share := &DecryptionShare{Index: s.Index}
+	d.Encode(share.Share[:0])
+	c.Encode(share.Proof[:0])
+	z.Encode(share.Proof[elementSize:elementSize])
+	return share, nil
+}
+
+// Decrypt combines decryption shares and decrypts the envelope. Invalid shares are ignored. If there are not enough
+// valid shares, an error is returned; if the envelope can't be decrypted with valid shares, returned error wraps
+// ErrInvalidCiphertext.
+func (k *ThresholdKey) Decrypt(tx types.Tx, shares []DecryptionShare) (types.Tx, error) {
+	outer, ciphertext, err := ParseEncryptedTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	rG, err := decodeElement(ciphertext[:elementSize])
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+
+	indices := make([]int, 0, k.Threshold)
+	points := make([]*ristretto255.Element, 0, k.Threshold)
+	seen := make(map[int]bool)
+	for i := range shares {
+		if len(indices) == k.Threshold {
+			break
+		}
+		if seen[shares[i].Index] {
+			continue
+		}
+		d, err := k.verifyShare(rG, &shares[i])
+		if err != nil {
+			continue
+		}
+		seen[shares[i].Index] = true
+		indices = append(indices, shares[i].Index)
+		points = append(points, d)
+	}
+	if len(indices) < k.Threshold {
+		return nil, fmt.Errorf("not enough valid decryption shares: %d of %d", len(indices), k.Threshold)
+	}
+
+	coeffs := make([]*ristretto255.Scalar, len(indices))
+	for i := range indices {
+		coeffs[i] = lagrangeCoefficient(indices[i], indices)
+	}
+	rY := ristretto255.NewElement().MultiScalarMult(coeffs, points)
+
+	aead, err := newAEAD(rG, rY)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := aead.Open(nil, make([]byte, aead.NonceSize()), ciphertext[elementSize:], outer)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return plain, nil
+}
+
+// verifyShare checks decryption share proof, and returns decoded share.
+func (k *ThresholdKey) verifyShare(rG *ristretto255.Element, share *DecryptionShare) (*ristretto255.Element, error) {
+	if share.Index < 1 || share.Index > len(k.Shares) {
+		return nil, fmt.Errorf("invalid member index %d", share.Index)
+	}
+	xG, err := decodeElement(k.Shares[share.Index-1][:])
+	if err != nil {
+		return nil, err
+	}
+	d, err := decodeElement(share.Share[:])
+	if err != nil {
+		return nil, err
+	}
+	c, z := ristretto255.NewScalar(), ristretto255.NewScalar()
+	if err := c.Decode(share.Proof[:elementSize]); err != nil {
+		return nil, err
+	}
+	if err := z.Decode(share.Proof[elementSize:]); err != nil {
+		return nil, err
+	}
+
+	// a = z*G - c*xG, b = z*rG - c*d
+	a := ristretto255.NewElement().ScalarBaseMult(z)
+	a.Subtract(a, ristretto255.NewElement().ScalarMult(c, xG))
+	b := ristretto255.NewElement().ScalarMult(z, rG)
+	b.Subtract(b, ristretto255.NewElement().ScalarMult(c, d))
+	if dleqChallenge(xG, rG, d, a, b).Equal(c) != 1 {
+		return nil, errors.New("invalid decryption share proof")
+	}
+	return d, nil
+}
+
+// ThresholdTxDecrypter decrypts transactions encrypted with EncryptTx, using decryption shares of committee members.
+// No node holds the committee private key.
+type ThresholdTxDecrypter struct {
+	key    *ThresholdKey
+	source DecryptionShareSource
+}
+
+// DecryptionShareSource provides decryption shares published by committee members after the encrypted transaction
+// was included in a block. All nodes use decryption shares of the same transactions, so the source has to be
+// reliable: if shares are not available yet, block execution is retried later.
+type DecryptionShareSource interface {
+	DecryptionShares(height uint64, tx types.Tx) ([]DecryptionShare, error)
+}
+
+var _ TxDecrypter = &ThresholdTxDecrypter{}
+
+// NewThresholdTxDecrypter returns ThresholdTxDecrypter using given committee key and source of decryption shares.
+func NewThresholdTxDecrypter(key *ThresholdKey, source DecryptionShareSource) *ThresholdTxDecrypter {
+	return &ThresholdTxDecrypter{key: key, source: source}
+}
+
+// DecryptTx implements TxDecrypter.
+func (d *ThresholdTxDecrypter) DecryptTx(height uint64, tx types.Tx) (types.Tx, error) {
+	if _, _, err := ParseEncryptedTx(tx); err != nil {
+		return nil, err
+	}
+	shares, err := d.source.DecryptionShares(height, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decryption shares: %w", err)
+	}
+	return d.key.Decrypt(tx, shares)
+}
+
+func newAEAD(rG, rY *ristretto255.Element) (cipher.AEAD, error) {
+	h := sha256.New()
+	h.Write([]byte(keyDerivationTag))
+	h.Write(rG.Encode(nil))
+	h.Write(rY.Encode(nil))
+	// key is used for single message, so zero nonce is safe
+	return chacha20poly1305.New(h.Sum(nil))
+}
+
+func dleqChallenge(elements ...*ristretto255.Element) *ristretto255.Scalar {
+	h := sha512.New()
+	h.Write([]byte(dleqTag))
+	for _, e := range elements {
+		h.Write(e.Encode(nil))
+	}
+	return ristretto255.NewScalar().FromUniformBytes(h.Sum(nil))
+}
+
+// lagrangeCoefficient returns Lagrange coefficient of member i for interpolation at 0, using shares of given members.
+func lagrangeCoefficient(i int, indices []int) *ristretto255.Scalar {
+	num := indexScalar(1)
+	den := indexScalar(1)
+	xi := indexScalar(i)
+	for _, j := range indices {
+		if j == i {
+			continue
+		}
+		xj := indexScalar(j)
+		num.Multiply(num, xj)
+		den.Multiply(den, ristretto255.NewScalar().Subtract(xj, xi))
+	}
+	return num.Multiply(num, den.Invert(den))
+}
+
+func indexScalar(i int) *ristretto255.Scalar {
+	b := make([]byte, 32)
+	binary.LittleEndian.PutUint64(b, uint64(i))
+	s := ristretto255.NewScalar()
+	// small integers are always canonical encodings
+	_ = s.Decode(b)
+	return s
+}
+
+func randomScalar() (*ristretto255.Scalar, error) {
+	b := make([]byte, 64)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return ristretto255.NewScalar().FromUniformBytes(b), nil
+}
+
+func decodeElement(b []byte) (*ristretto255.Element, error) {
+	e := ristretto255.NewElement()
+	if err := e.Decode(b); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
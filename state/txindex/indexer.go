@@ -24,6 +24,9 @@ type TxIndexer interface {
 
 	// Search allows you to query for transactions.
 	Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error)
+
+	// Prune removes transactions indexed at heights lower than retainHeight.
+	Prune(retainHeight int64) error
 }
 
 // Batch groups together multiple Index operations to be performed at the same time.
@@ -2,8 +2,11 @@ package txindex
 
 import (
 	"context"
+	"sync/atomic"
+	"time"
 
 	"github.com/celestiaorg/optimint/state/indexer"
+	"github.com/celestiaorg/optimint/store"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/types"
 )
@@ -22,6 +25,13 @@ type IndexerService struct {
 	txIdxr    TxIndexer
 	blockIdxr indexer.BlockIndexer
 	eventBus  *types.EventBus
+
+	// indexedHeight is the height of the last block processed by indexers (accessed atomically)
+	indexedHeight int64
+
+	retainBlocks       int64
+	compactor          store.Compactor
+	compactionInterval time.Duration
 }
 
 // NewIndexerService returns a new service instance.
@@ -36,6 +46,19 @@ func NewIndexerService(
 	return is
 }
 
+// SetRetention configures removal of transactions indexed more than retainBlocks blocks ago (0 - keep all).
+// Must be called before service is started.
+func (is *IndexerService) SetRetention(retainBlocks int64) {
+	is.retainBlocks = retainBlocks
+}
+
+// SetCompaction configures periodic compaction of the index store, to reclaim space after pruning
+// (0 interval - disabled). Must be called before service is started.
+func (is *IndexerService) SetCompaction(compactor store.Compactor, interval time.Duration) {
+	is.compactor = compactor
+	is.compactionInterval = interval
+}
+
 // OnStart implements service.Service by subscribing for all transactions
 // and indexing them by events.
 func (is *IndexerService) OnStart() error {
@@ -87,11 +110,43 @@ func (is *IndexerService) OnStart() error {
 			} else {
 				is.Logger.Debug("indexed block txs", "height", height, "num_txs", eventDataHeader.NumTxs)
 			}
+
+			if is.retainBlocks > 0 && height > is.retainBlocks {
+				if err := is.txIdxr.Prune(height - is.retainBlocks + 1); err != nil {
+					is.Logger.Error("failed to prune indexed txs", "height", height, "err", err)
+				}
+			}
+			atomic.StoreInt64(&is.indexedHeight, height)
 		}
 	}()
+
+	if is.compactor != nil && is.compactionInterval > 0 {
+		go is.compactionLoop()
+	}
 	return nil
 }
 
+func (is *IndexerService) compactionLoop() {
+	ticker := time.NewTicker(is.compactionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := is.compactor.Compact(); err != nil {
+				is.Logger.Error("failed to compact index store", "err", err)
+			}
+		case <-is.Quit():
+			return
+		}
+	}
+}
+
+// IndexedHeight returns the height of the last block processed by indexers.
+// Search results can change only when this height changes.
+func (is *IndexerService) IndexedHeight() int64 {
+	return atomic.LoadInt64(&is.indexedHeight)
+}
+
 // OnStop implements service.Service by unsubscribing from all transactions.
 func (is *IndexerService) OnStop() {
 	if is.eventBus.IsRunning() {
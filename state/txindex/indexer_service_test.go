@@ -1,18 +1,22 @@
 package txindex_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 
 	blockidxkv "github.com/celestiaorg/optimint/state/indexer/block/kv"
 	"github.com/celestiaorg/optimint/state/txindex"
 	"github.com/celestiaorg/optimint/state/txindex/kv"
 	"github.com/celestiaorg/optimint/store"
+	optypes "github.com/celestiaorg/optimint/types"
 )
 
 func TestIndexerServiceIndexesBlocks(t *testing.T) {
@@ -79,3 +83,56 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, txResult2, res)
 }
+
+func TestReindex(t *testing.T) {
+	s := store.New(store.NewDefaultInMemoryKVStore())
+	for height := uint64(1); height <= 3; height++ {
+		block := &optypes.Block{
+			Header: optypes.Header{Height: height},
+			Data:   optypes.Data{Txs: optypes.Txs{optypes.Tx{byte(height), 1}, optypes.Tx{byte(height), 2}}},
+		}
+		require.NoError(t, s.SaveBlock(block, &optypes.Commit{}))
+		event := abci.Event{Type: "account", Attributes: []abci.EventAttribute{{Key: []byte("owner"), Value: []byte("Alice"), Index: true}}}
+		require.NoError(t, s.SaveBlockResponses(height, &tmstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{{Events: []abci.Event{event}}, {}},
+			BeginBlock: &abci.ResponseBeginBlock{Events: []abci.Event{event}},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}))
+	}
+
+	kvStore := store.NewDefaultInMemoryKVStore()
+	txIndexer := kv.NewTxIndex(kvStore)
+	blockIndexer := blockidxkv.New(store.NewPrefixKV(kvStore, []byte("block_events")))
+
+	ctx := context.Background()
+	require.Error(t, txindex.Reindex(ctx, s, txIndexer, blockIndexer, 0, 2))
+	require.Error(t, txindex.Reindex(ctx, s, txIndexer, blockIndexer, 3, 2))
+	require.Error(t, txindex.Reindex(ctx, s, txIndexer, blockIndexer, 1, 4))
+
+	require.NoError(t, txindex.Reindex(ctx, s, txIndexer, blockIndexer, 2, 0))
+
+	ok, err := blockIndexer.Has(1)
+	require.NoError(t, err)
+	require.False(t, ok)
+	res, err := txIndexer.Get(types.Tx{1, 1}.Hash())
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	for height := int64(2); height <= 3; height++ {
+		ok, err := blockIndexer.Has(height)
+		require.NoError(t, err)
+		require.True(t, ok)
+		res, err := txIndexer.Get(types.Tx{byte(height), 2}.Hash())
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		require.Equal(t, height, res.Height)
+		require.Equal(t, uint32(1), res.Index)
+	}
+
+	txs, err := txIndexer.Search(ctx, query.MustParse("account.owner = 'Alice'"))
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	heights, err := blockIndexer.Search(ctx, query.MustParse("account.owner = 'Alice'"))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []int64{2, 3}, heights)
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/gogo/protobuf/proto"
 
@@ -21,8 +24,14 @@ import (
 
 const (
 	tagKeySeparator = "/"
+
+	// minParallelEncoding is the number of transactions below which batch is hashed and serialized sequentially.
+	minParallelEncoding = 128
 )
 
+// prunedHeightKey stores the highest height removed from index by Prune.
+var prunedHeightKey = []byte("txindex.pruned_height")
+
 var _ txindex.TxIndexer = (*TxIndex)(nil)
 
 // TxIndex is the simplest possible indexer, backed by key-value storage (levelDB).
@@ -45,6 +54,9 @@ func (txi *TxIndex) Get(hash []byte) (*abci.TxResult, error) {
 	}
 
 	rawBytes, err := txi.store.Get(hash)
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return nil, nil
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -66,11 +78,17 @@ func (txi *TxIndex) Get(hash []byte) (*abci.TxResult, error) {
 // the respective attribute's key delimited by a "." (eg. "account.number").
 // Any event with an empty type is not indexed.
 func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
+	encoded, err := encodeResults(b.Ops)
+	if err != nil {
+		return err
+	}
+
+	// all index writes of the batch are committed in a single KV store transaction
 	storeBatch := txi.store.NewBatch()
 	defer storeBatch.Discard()
 
-	for _, result := range b.Ops {
-		hash := types.Tx(result.Tx).Hash()
+	for i, result := range b.Ops {
+		hash := encoded[i].hash
 
 		// index tx by events
 		err := txi.indexEvents(result, hash, storeBatch)
@@ -84,12 +102,8 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 			return err
 		}
 
-		rawBytes, err := proto.Marshal(result)
-		if err != nil {
-			return err
-		}
 		// index by hash (always)
-		err = storeBatch.Set(hash, rawBytes)
+		err = storeBatch.Set(hash, encoded[i].rawBytes)
 		if err != nil {
 			return err
 		}
@@ -98,6 +112,54 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 	return storeBatch.Commit()
 }
 
+// encodedResult is a hash and serialized form of indexed transaction result.
+type encodedResult struct {
+	hash     []byte
+	rawBytes []byte
+}
+
+// encodeResults hashes and serializes transaction results. Large batches are processed in parallel, by a pool of
+// GOMAXPROCS workers.
+func encodeResults(results []*abci.TxResult) ([]encodedResult, error) {
+	encoded := make([]encodedResult, len(results))
+	errs := make([]error, len(results))
+	encode := func(i int) {
+		encoded[i].hash = types.Tx(results[i].Tx).Hash()
+		encoded[i].rawBytes, errs[i] = proto.Marshal(results[i])
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if len(results) < minParallelEncoding || workers == 1 {
+		for i := range results {
+			encode(i)
+		}
+	} else {
+		next := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range next {
+					encode(i)
+				}
+			}()
+		}
+		for i := range results {
+			next <- i
+		}
+		close(next)
+		wg.Wait()
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return encoded, nil
+}
+
 // Index indexes a single transaction using the given list of events. Each key
 // that indexed from the tx's events is a composite of the event type and the
 // respective attribute's key delimited by a "." (eg. "account.number").
@@ -133,6 +195,90 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 	return b.Commit()
 }
 
+// Prune removes transactions indexed at heights lower than retainHeight, together with their events.
+// Heights are removed one by one, starting from the height following the last pruned height.
+func (txi *TxIndex) Prune(retainHeight int64) error {
+	pruned, err := txi.prunedHeight()
+	if err != nil {
+		return err
+	}
+	for height := pruned + 1; height < retainHeight; height++ {
+		if err := txi.pruneHeight(height); err != nil {
+			return fmt.Errorf("failed to prune transactions at height %d: %w", height, err)
+		}
+	}
+	return nil
+}
+
+func (txi *TxIndex) prunedHeight() (int64, error) {
+	raw, err := txi.store.Get(prunedHeightKey)
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(raw), 10, 64)
+}
+
+func (txi *TxIndex) pruneHeight(height int64) error {
+	b := txi.store.NewBatch()
+	defer b.Discard()
+
+	it := txi.store.PrefixIterator(startKey(types.TxHeightKey, height))
+	defer it.Discard()
+	for ; it.Valid(); it.Next() {
+		hash := it.Value()
+		key := it.Key()
+		index, err := strconv.ParseUint(string(key[bytes.LastIndex(key, []byte(tagKeySeparator))+1:]), 10, 32)
+		if err != nil {
+			return err
+		}
+		pruned := &abci.TxResult{Height: height, Index: uint32(index)}
+		err = b.Delete(keyForHeight(pruned))
+		if err != nil {
+			return err
+		}
+
+		result, err := txi.Get(hash)
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			continue
+		}
+		// the same transaction could be indexed again at greater height - events of pruned transaction are not
+		// stored anymore, so events of the later one are used
+		for _, event := range result.Result.Events {
+			for _, attr := range event.Attributes {
+				if len(event.Type) == 0 || len(attr.Key) == 0 || !attr.GetIndex() {
+					continue
+				}
+				compositeTag := fmt.Sprintf("%s.%s", event.Type, string(attr.Key))
+				err = b.Delete(keyForEvent(compositeTag, attr.Value, pruned))
+				if err != nil {
+					return err
+				}
+			}
+		}
+		if result.Height == height {
+			err = b.Delete(hash)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	err := b.Set(prunedHeightKey, []byte(strconv.FormatInt(height, 10)))
+	if err != nil {
+		return err
+	}
+	return b.Commit()
+}
+
 func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store store.Batch) error {
 	for _, event := range result.Result.Events {
 		// only index events with a non-empty type
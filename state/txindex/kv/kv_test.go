@@ -66,6 +66,34 @@ func TestTxIndex(t *testing.T) {
 	assert.True(t, proto.Equal(txResult2, loadedTxResult2))
 }
 
+func TestTxIndexLargeBatch(t *testing.T) {
+	indexer := NewTxIndex(store.NewDefaultInMemoryKVStore())
+
+	// big enough to be encoded in parallel
+	const n = 2 * minParallelEncoding
+	batch := txindex.NewBatch(n)
+	for i := 0; i < n; i++ {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{{Key: []byte("number"), Value: []byte(fmt.Sprint(i)), Index: true}}},
+		})
+		txResult.Index = uint32(i)
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", i))
+		require.NoError(t, batch.Add(txResult))
+	}
+	require.NoError(t, indexer.AddBatch(batch))
+
+	for _, txResult := range batch.Ops {
+		loaded, err := indexer.Get(types.Tx(txResult.Tx).Hash())
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(txResult, loaded))
+	}
+
+	results, err := indexer.Search(context.Background(), query.MustParse("account.number = 42"))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, uint32(42), results[0].Index)
+}
+
 func TestTxSearch(t *testing.T) {
 	indexer := NewTxIndex(store.NewDefaultInMemoryKVStore())
 
@@ -313,6 +341,67 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestTxIndexPrune(t *testing.T) {
+	kvStore := store.NewDefaultInMemoryKVStore()
+	indexer := NewTxIndex(kvStore)
+
+	newTxResult := func(tx string, height int64, index uint32) *abci.TxResult {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{{Key: []byte("owner"), Value: []byte(tx), Index: true}}},
+		})
+		txResult.Tx = types.Tx(tx)
+		txResult.Height = height
+		txResult.Index = index
+		return txResult
+	}
+	txResults := []*abci.TxResult{
+		newTxResult("Alice", 1, 0),
+		newTxResult("Bob", 1, 1),
+		newTxResult("Jack", 2, 0),
+		newTxResult("Mike", 3, 0),
+		// the same transaction indexed again at greater height
+		newTxResult("Bob", 3, 1),
+	}
+	for _, txResult := range txResults {
+		require.NoError(t, indexer.Index(txResult))
+	}
+
+	require.NoError(t, indexer.Prune(3))
+	// already pruned heights are skipped
+	require.NoError(t, indexer.Prune(2))
+
+	for _, tx := range []string{"Alice", "Jack"} {
+		res, err := indexer.Get(types.Tx(tx).Hash())
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	}
+	for _, txResult := range txResults[3:] {
+		res, err := indexer.Get(types.Tx(txResult.Tx).Hash())
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(txResult, res))
+	}
+
+	ctx := context.Background()
+	results, err := indexer.Search(ctx, query.MustParse("tx.height < 3"))
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	results, err = indexer.Search(ctx, query.MustParse("account.owner = 'Alice'"))
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	results, err = indexer.Search(ctx, query.MustParse("account.owner = 'Mike'"))
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	// only entries of height 3 (hash, height and event keys) and pruned height are left
+	keys := 0
+	it := kvStore.PrefixIterator(nil)
+	for ; it.Valid(); it.Next() {
+		keys++
+	}
+	it.Discard()
+	assert.Equal(t, 2*3+1, keys)
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
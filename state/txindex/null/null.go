@@ -29,6 +29,11 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 	return nil
 }
 
+// Prune is a noop and always returns nil.
+func (txi *TxIndex) Prune(retainHeight int64) error {
+	return nil
+}
+
 func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
 	return []*abci.TxResult{}, nil
 }
@@ -0,0 +1,84 @@
+package txindex
+
+import (
+	"context"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+
+	abciconv "github.com/celestiaorg/optimint/conv/abci"
+	"github.com/celestiaorg/optimint/state/indexer"
+	"github.com/celestiaorg/optimint/store"
+)
+
+// Reindex (re)builds transaction and block indexes for blocks from given height range (inclusive), using blocks and
+// block responses saved in the store. Value of 0 as the end of range means the latest block in the store.
+//
+// It allows to backfill indexes of a node that was running with indexing disabled, or to repair corrupted indexes,
+// without syncing the chain again.
+func Reindex(ctx context.Context, s store.Store, txIdxr TxIndexer, blockIdxr indexer.BlockIndexer, from, to uint64) error {
+	if to == 0 {
+		to = s.Height()
+	}
+	if from == 0 || from > to || to > s.Height() {
+		return fmt.Errorf("invalid height range [%d, %d], store height: %d", from, to, s.Height())
+	}
+
+	for height := from; height <= to; height++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := reindexHeight(s, txIdxr, blockIdxr, height); err != nil {
+			return fmt.Errorf("failed to reindex block %d: %w", height, err)
+		}
+	}
+	return nil
+}
+
+func reindexHeight(s store.Store, txIdxr TxIndexer, blockIdxr indexer.BlockIndexer, height uint64) error {
+	block, err := s.LoadBlock(height)
+	if err != nil {
+		return err
+	}
+	responses, err := s.LoadBlockResponses(height)
+	if err != nil {
+		return err
+	}
+	if len(responses.DeliverTxs) != len(block.Data.Txs) {
+		return fmt.Errorf("number of tx responses (%d) doesn't match number of txs (%d)",
+			len(responses.DeliverTxs), len(block.Data.Txs))
+	}
+
+	header, err := abciconv.ToABCIHeader(&block.Header)
+	if err != nil {
+		return err
+	}
+	eventDataHeader := types.EventDataNewBlockHeader{
+		Header: header,
+		NumTxs: int64(len(block.Data.Txs)),
+	}
+	if responses.BeginBlock != nil {
+		eventDataHeader.ResultBeginBlock = *responses.BeginBlock
+	}
+	if responses.EndBlock != nil {
+		eventDataHeader.ResultEndBlock = *responses.EndBlock
+	}
+	if err := blockIdxr.Index(eventDataHeader); err != nil {
+		return err
+	}
+
+	batch := NewBatch(eventDataHeader.NumTxs)
+	for i, tx := range block.Data.Txs {
+		err = batch.Add(&abci.TxResult{
+			Height: int64(height),
+			Index:  uint32(i),
+			Tx:     tx,
+			Result: *responses.DeliverTxs[i],
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return txIdxr.AddBatch(batch)
+}
@@ -0,0 +1,177 @@
+package statesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/proxy"
+
+	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// maxPendingStates limits number of states kept in memory, waiting for application to create a snapshot.
+const maxPendingStates = 4
+
+// Publisher publishes snapshots created by ABCI application to the snapshot store (e.g. DA layer).
+//
+// Application creates snapshots on its own schedule - snapshot interval of Publisher has to match (or divide) it.
+// Optimint state is recorded at every interval, because only the latest state is persisted in the store.
+type Publisher struct {
+	app       proxy.AppConnSnapshot
+	snapshots da.SnapshotStore
+	store     store.Store
+	interval  uint64
+	logger    log.Logger
+
+	mtx sync.Mutex
+	// states contains serialized states, recorded after applying blocks at heights divisible by interval
+	states map[uint64][]byte
+	// published is the height of the last published snapshot
+	published uint64
+}
+
+// NewPublisher creates new Publisher of snapshots taken every interval blocks.
+func NewPublisher(app proxy.AppConnSnapshot, snapshots da.SnapshotStore, store store.Store, interval uint64, logger log.Logger) *Publisher {
+	return &Publisher{
+		app:       app,
+		snapshots: snapshots,
+		store:     store,
+		interval:  interval,
+		logger:    logger,
+		states:    make(map[uint64][]byte),
+	}
+}
+
+// BlockApplied records the state after block at snapshot height is applied. It's intended to be used as
+// block.Hooks.OnBlockApplied.
+func (p *Publisher) BlockApplied(block *types.Block) {
+	height := block.Header.Height
+	if p.interval == 0 || height%p.interval != 0 {
+		return
+	}
+	st, err := p.store.LoadState()
+	if err != nil {
+		p.logger.Error("failed to load state for snapshot", "height", height, "error", err)
+		return
+	}
+	// tmjson is required to serialize public keys of validators
+	blob, err := tmjson.Marshal(st)
+	if err != nil {
+		p.logger.Error("failed to serialize state for snapshot", "height", height, "error", err)
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.states[height] = blob
+	for h := range p.states {
+		if h+maxPendingStates*p.interval <= height {
+			delete(p.states, h)
+		}
+	}
+}
+
+// PublishLoop periodically checks for new application snapshots and publishes them, until context is cancelled.
+func (p *Publisher) PublishLoop(ctx context.Context, checkInterval time.Duration) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.Publish(); err != nil {
+				p.logger.Error("failed to publish snapshot", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Publish publishes the latest application snapshot, if it's newer than previously published one and Optimint
+// state at its height is known.
+func (p *Publisher) Publish() error {
+	res, err := p.app.ListSnapshotsSync(abci.RequestListSnapshots{})
+	if err != nil {
+		return err
+	}
+
+	p.mtx.Lock()
+	var latest *abci.Snapshot
+	for _, s := range res.Snapshots {
+		if _, ok := p.states[s.Height]; ok && s.Height > p.published && (latest == nil || s.Height > latest.Height) {
+			latest = s
+		}
+	}
+	if latest == nil {
+		p.mtx.Unlock()
+		return nil
+	}
+	stateBlob := p.states[latest.Height]
+	p.mtx.Unlock()
+
+	if err := p.publish(latest, stateBlob); err != nil {
+		return fmt.Errorf("snapshot at height %d: %w", latest.Height, err)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.published = latest.Height
+	for h := range p.states {
+		if h <= latest.Height {
+			delete(p.states, h)
+		}
+	}
+	p.logger.Info("published snapshot", "height", latest.Height, "format", latest.Format, "chunks", latest.Chunks)
+	return nil
+}
+
+func (p *Publisher) publish(snapshot *abci.Snapshot, stateBlob []byte) error {
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk, err := p.app.LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Chunk:  i,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load chunk %d: %w", i, err)
+		}
+		if len(chunk.Chunk) == 0 {
+			return fmt.Errorf("chunk %d is not available", i)
+		}
+		res := p.snapshots.SubmitSnapshotChunk(snapshot.Height, snapshot.Format, i, chunk.Chunk)
+		if res.Code != da.StatusSuccess {
+			return fmt.Errorf("failed to submit chunk %d: %s", i, res.Message)
+		}
+	}
+
+	block, err := p.store.LoadBlock(snapshot.Height)
+	if err != nil {
+		return err
+	}
+	commit, err := p.store.LoadCommit(snapshot.Height)
+	if err != nil {
+		return err
+	}
+	res := p.snapshots.SubmitSnapshot(&types.Snapshot{
+		Height:   snapshot.Height,
+		Format:   snapshot.Format,
+		Chunks:   snapshot.Chunks,
+		Hash:     snapshot.Hash,
+		Metadata: snapshot.Metadata,
+		Block:    block,
+		Commit:   commit,
+		State:    stateBlob,
+	})
+	if res.Code != da.StatusSuccess {
+		return errors.New(res.Message)
+	}
+	return nil
+}
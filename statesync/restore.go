@@ -0,0 +1,163 @@
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/proxy"
+
+	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/state"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// maxChunkRetries limits number of attempts to apply single chunk.
+const maxChunkRetries = 3
+
+// errRejected is returned when application rejects a snapshot, so that the next one can be tried.
+var errRejected = errors.New("snapshot rejected")
+
+// Restore restores application state from the latest acceptable snapshot available in the snapshot store and saves
+// block, commit and state at snapshot height in the store. Node can continue syncing from the next block.
+//
+// Restore returns height of restored snapshot, or 0 if there is no snapshot accepted by the application.
+func Restore(snapshotConn proxy.AppConnSnapshot, queryConn proxy.AppConnQuery, snapshots da.SnapshotStore, store store.Store, logger log.Logger) (uint64, error) {
+	res := snapshots.ListSnapshots()
+	if res.Code != da.StatusSuccess {
+		return 0, fmt.Errorf("failed to list snapshots: %s", res.Message)
+	}
+	candidates := res.Snapshots
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Height > candidates[j].Height
+	})
+
+	for _, snapshot := range candidates {
+		err := restore(snapshotConn, queryConn, snapshots, store, snapshot, logger)
+		if errors.Is(err, errRejected) {
+			logger.Info("snapshot rejected", "height", snapshot.Height, "format", snapshot.Format, "reason", err)
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to restore snapshot at height %d: %w", snapshot.Height, err)
+		}
+		logger.Info("restored snapshot", "height", snapshot.Height, "format", snapshot.Format)
+		return snapshot.Height, nil
+	}
+	return 0, nil
+}
+
+func restore(snapshotConn proxy.AppConnSnapshot, queryConn proxy.AppConnQuery, snapshots da.SnapshotStore, store store.Store, snapshot *types.Snapshot, logger log.Logger) error {
+	var st state.State
+	if err := tmjson.Unmarshal(snapshot.State, &st); err != nil {
+		return fmt.Errorf("%w: failed to decode state: %s", errRejected, err)
+	}
+	if err := verify(snapshot, &st); err != nil {
+		return fmt.Errorf("%w: %s", errRejected, err)
+	}
+
+	offer, err := snapshotConn.OfferSnapshotSync(abci.RequestOfferSnapshot{
+		Snapshot: &abci.Snapshot{
+			Height:   snapshot.Height,
+			Format:   snapshot.Format,
+			Chunks:   snapshot.Chunks,
+			Hash:     snapshot.Hash,
+			Metadata: snapshot.Metadata,
+		},
+		AppHash: st.AppHash[:],
+	})
+	if err != nil {
+		return err
+	}
+	switch offer.Result {
+	case abci.ResponseOfferSnapshot_ACCEPT:
+	case abci.ResponseOfferSnapshot_REJECT, abci.ResponseOfferSnapshot_REJECT_FORMAT, abci.ResponseOfferSnapshot_REJECT_SENDER:
+		return fmt.Errorf("%w: %s", errRejected, offer.Result)
+	default:
+		return fmt.Errorf("snapshot offer failed: %s", offer.Result)
+	}
+
+	if err := applyChunks(snapshotConn, snapshots, snapshot, logger); err != nil {
+		return err
+	}
+
+	info, err := queryConn.InfoSync(proxy.RequestInfo)
+	if err != nil {
+		return err
+	}
+	if uint64(info.LastBlockHeight) != snapshot.Height {
+		return fmt.Errorf("application height %d doesn't match snapshot height %d", info.LastBlockHeight, snapshot.Height)
+	}
+	if !bytes.Equal(info.LastBlockAppHash, st.AppHash[:]) {
+		return fmt.Errorf("application hash %X doesn't match snapshot state %X", info.LastBlockAppHash, st.AppHash)
+	}
+
+	if err := store.SaveBlock(snapshot.Block, snapshot.Commit); err != nil {
+		return err
+	}
+	return store.UpdateState(st)
+}
+
+// verify checks if block, commit and state of the snapshot are consistent.
+func verify(snapshot *types.Snapshot, st *state.State) error {
+	if snapshot.Block == nil || snapshot.Commit == nil {
+		return errors.New("missing block or commit")
+	}
+	if snapshot.Block.Header.Height != snapshot.Height || snapshot.Commit.Height != snapshot.Height ||
+		uint64(st.LastBlockHeight) != snapshot.Height {
+		return errors.New("block, commit or state doesn't match snapshot height")
+	}
+	if hash := snapshot.Block.Header.Hash(); !bytes.Equal(snapshot.Commit.HeaderHash[:], hash[:]) {
+		return errors.New("commit doesn't match block")
+	}
+	return nil
+}
+
+func applyChunks(snapshotConn proxy.AppConnSnapshot, snapshots da.SnapshotStore, snapshot *types.Snapshot, logger log.Logger) error {
+	pending := make([]uint32, 0, snapshot.Chunks)
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		pending = append(pending, i)
+	}
+	attempts := make(map[uint32]int)
+
+	for len(pending) > 0 {
+		index := pending[0]
+		pending = pending[1:]
+		attempts[index]++
+		if attempts[index] > maxChunkRetries {
+			return fmt.Errorf("failed to apply chunk %d after %d attempts", index, maxChunkRetries)
+		}
+
+		chunk := snapshots.RetrieveSnapshotChunk(snapshot.Height, snapshot.Format, index)
+		if chunk.Code != da.StatusSuccess {
+			return fmt.Errorf("failed to retrieve chunk %d: %s", index, chunk.Message)
+		}
+		res, err := snapshotConn.ApplySnapshotChunkSync(abci.RequestApplySnapshotChunk{
+			Index: index,
+			Chunk: chunk.Chunk,
+		})
+		if err != nil {
+			return err
+		}
+		logger.Debug("applied snapshot chunk", "height", snapshot.Height, "index", index, "result", res.Result)
+		for _, refetch := range res.RefetchChunks {
+			pending = append(pending, refetch)
+		}
+
+		switch res.Result {
+		case abci.ResponseApplySnapshotChunk_ACCEPT:
+		case abci.ResponseApplySnapshotChunk_RETRY:
+			pending = append([]uint32{index}, pending...)
+		case abci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT:
+			return fmt.Errorf("%w: chunk %d", errRejected, index)
+		default:
+			return fmt.Errorf("failed to apply chunk %d: %s", index, res.Result)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package statesync
+
+import (
+	"crypto/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/proxy"
+
+	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/da/mock"
+	"github.com/celestiaorg/optimint/log/test"
+	"github.com/celestiaorg/optimint/state"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// snapshotApp is an application that keeps the state as a list of chunks.
+type snapshotApp struct {
+	abci.BaseApplication
+
+	mtx       sync.Mutex
+	snapshots []*abci.Snapshot
+	chunks    [][]byte
+	appHash   []byte
+	rejectAt  uint64
+
+	offered  *abci.Snapshot
+	restored [][]byte
+	height   int64
+}
+
+func (app *snapshotApp) Info(abci.RequestInfo) abci.ResponseInfo {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return abci.ResponseInfo{LastBlockHeight: app.height, LastBlockAppHash: app.appHash}
+}
+
+func (app *snapshotApp) ListSnapshots(abci.RequestListSnapshots) abci.ResponseListSnapshots {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return abci.ResponseListSnapshots{Snapshots: app.snapshots}
+}
+
+func (app *snapshotApp) LoadSnapshotChunk(req abci.RequestLoadSnapshotChunk) abci.ResponseLoadSnapshotChunk {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return abci.ResponseLoadSnapshotChunk{Chunk: app.chunks[req.Chunk]}
+}
+
+func (app *snapshotApp) OfferSnapshot(req abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	if req.Snapshot.Height == app.rejectAt {
+		return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_REJECT}
+	}
+	app.offered = req.Snapshot
+	app.appHash = req.AppHash
+	return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}
+}
+
+func (app *snapshotApp) ApplySnapshotChunk(req abci.RequestApplySnapshotChunk) abci.ResponseApplySnapshotChunk {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.restored = append(app.restored, req.Chunk)
+	if len(app.restored) == int(app.offered.Chunks) {
+		app.height = int64(app.offered.Height)
+	}
+	return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}
+}
+
+func TestPublishAndRestore(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	logger := &test.TestLogger{T: t}
+
+	dalc := &mock.MockDataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(nil, store.NewDefaultInMemoryKVStore(), logger))
+
+	// source node with application snapshots at heights 10 and 20
+	source := store.New(store.NewDefaultInMemoryKVStore())
+	app := &snapshotApp{chunks: [][]byte{getRandomBytes(100), getRandomBytes(100), getRandomBytes(50)}}
+	publisher := NewPublisher(newSnapshotConn(t, app), dalc, source, 10, logger)
+	for h := uint64(1); h <= 20; h++ {
+		block := &types.Block{Header: types.Header{Height: h}}
+		require.NoError(source.SaveBlock(block, &types.Commit{Height: h, HeaderHash: block.Header.Hash()}))
+		st := state.State{ChainID: "test", LastBlockHeight: int64(h)}
+		st.AppHash[0] = byte(h)
+		require.NoError(source.UpdateState(st))
+		publisher.BlockApplied(block)
+	}
+
+	// nothing to publish until application creates snapshots
+	require.NoError(publisher.Publish())
+	assert.Empty(dalc.ListSnapshots().Snapshots)
+
+	app.snapshots = []*abci.Snapshot{{Height: 10, Format: 1, Chunks: 3, Hash: []byte{10}}}
+	require.NoError(publisher.Publish())
+	app.snapshots = append(app.snapshots, &abci.Snapshot{Height: 20, Format: 1, Chunks: 3, Hash: []byte{20}})
+	require.NoError(publisher.Publish())
+	// already published snapshots are not published again
+	require.NoError(publisher.Publish())
+	snapshots := dalc.ListSnapshots()
+	require.Equal(da.StatusSuccess, snapshots.Code)
+	require.Len(snapshots.Snapshots, 2)
+
+	// rejected snapshot is skipped
+	target := store.New(store.NewDefaultInMemoryKVStore())
+	restoredApp := &snapshotApp{rejectAt: 20}
+	conn := newSnapshotConn(t, restoredApp)
+	height, err := Restore(conn, conn, dalc, target, logger)
+	require.NoError(err)
+	assert.Equal(uint64(10), height)
+	assert.Equal(uint64(10), target.Height())
+
+	target = store.New(store.NewDefaultInMemoryKVStore())
+	restoredApp = &snapshotApp{}
+	conn = newSnapshotConn(t, restoredApp)
+	height, err = Restore(conn, conn, dalc, target, logger)
+	require.NoError(err)
+	assert.Equal(uint64(20), height)
+	assert.Equal(app.chunks, restoredApp.restored)
+
+	assert.Equal(uint64(20), target.Height())
+	expected, err := source.LoadBlock(20)
+	require.NoError(err)
+	restored, err := target.LoadBlock(20)
+	require.NoError(err)
+	assert.Equal(expected, restored)
+	st, err := target.LoadState()
+	require.NoError(err)
+	assert.Equal(int64(20), st.LastBlockHeight)
+	assert.Equal(byte(20), st.AppHash[0])
+}
+
+func TestRestoreWithoutSnapshots(t *testing.T) {
+	require := require.New(t)
+	logger := &test.TestLogger{T: t}
+
+	dalc := &mock.MockDataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(nil, store.NewDefaultInMemoryKVStore(), logger))
+	conn := newSnapshotConn(t, &snapshotApp{})
+	target := store.New(store.NewDefaultInMemoryKVStore())
+
+	height, err := Restore(conn, conn, dalc, target, logger)
+	require.NoError(err)
+	require.Zero(height)
+	require.Zero(target.Height())
+}
+
+// snapshotConn implements both snapshot and query ABCI connections.
+type snapshotConn struct {
+	proxy.AppConnSnapshot
+	proxy.AppConnQuery
+}
+
+func (c *snapshotConn) Error() error {
+	return c.AppConnSnapshot.Error()
+}
+
+func newSnapshotConn(t *testing.T, app abci.Application) *snapshotConn {
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(t, err)
+	return &snapshotConn{AppConnSnapshot: proxy.NewAppConnSnapshot(client), AppConnQuery: proxy.NewAppConnQuery(client)}
+}
+
+func getRandomBytes(n int) []byte {
+	data := make([]byte, n)
+	_, _ = rand.Read(data)
+	return data
+}
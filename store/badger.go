@@ -7,6 +7,7 @@ import (
 )
 
 var _ KVStore = &BadgerKV{}
+var _ Compactor = &BadgerKV{}
 var _ Batch = &BadgerBatch{}
 
 var (
@@ -55,6 +56,19 @@ func (b *BadgerKV) Delete(key []byte) error {
 	return txn.Commit()
 }
 
+// Compact runs value log garbage collection, to reclaim space used by deleted and overwritten values.
+func (b *BadgerKV) Compact() error {
+	for {
+		err := b.db.RunValueLogGC(0.5)
+		if errors.Is(err, badger.ErrNoRewrite) || errors.Is(err, badger.ErrGCInMemoryMode) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
 // NewBatch creates new batch.
 // Note: badger batches should be short lived as they use extra resources.
 func (b *BadgerKV) NewBatch() Batch {
@@ -0,0 +1,141 @@
+package store
+
+import (
+	"errors"
+
+	lru "github.com/hashicorp/golang-lru"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+
+	"github.com/celestiaorg/optimint/types"
+)
+
+// CachedStore is a Store with in-memory LRU cache of recent blocks, commits and block responses.
+//
+// Latest blocks dominate RPC traffic (and block production), so caching them saves KV store reads and
+// deserialization. Cached objects are shared between callers and must not be modified.
+type CachedStore struct {
+	Store
+
+	// index maps block height to block header hash
+	index     *lru.Cache
+	blocks    *lru.Cache
+	commits   *lru.Cache
+	responses *lru.Cache
+}
+
+var _ Store = &CachedStore{}
+
+// NewCachedStore returns store caching up to size recent blocks (with commits and block responses) of given store.
+func NewCachedStore(store Store, size int) (*CachedStore, error) {
+	if size <= 0 {
+		return nil, errors.New("cache size must be positive")
+	}
+	s := &CachedStore{Store: store}
+	// lru.New returns error only if size is not positive
+	s.index, _ = lru.New(size)
+	s.blocks, _ = lru.New(size)
+	s.commits, _ = lru.New(size)
+	s.responses, _ = lru.New(size)
+	return s, nil
+}
+
+// SaveBlock saves block along with its seen commit, and adds them to the cache.
+func (s *CachedStore) SaveBlock(block *types.Block, commit *types.Commit) error {
+	if err := s.Store.SaveBlock(block, commit); err != nil {
+		return err
+	}
+	hash := block.Header.Hash()
+	s.index.Add(block.Header.Height, hash)
+	s.blocks.Add(hash, block)
+	s.commits.Add(hash, commit)
+	return nil
+}
+
+// LoadBlock returns block at given height, or error if it's not found in Store.
+func (s *CachedStore) LoadBlock(height uint64) (*types.Block, error) {
+	if hash, ok := s.index.Get(height); ok {
+		return s.LoadBlockByHash(hash.([32]byte))
+	}
+	block, err := s.Store.LoadBlock(height)
+	if err != nil {
+		return nil, err
+	}
+	hash := block.Header.Hash()
+	s.index.Add(height, hash)
+	s.blocks.Add(hash, block)
+	return block, nil
+}
+
+// LoadBlockByHash returns block with given block header hash, or error if it's not found in Store.
+func (s *CachedStore) LoadBlockByHash(hash [32]byte) (*types.Block, error) {
+	if block, ok := s.blocks.Get(hash); ok {
+		return block.(*types.Block), nil
+	}
+	block, err := s.Store.LoadBlockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	s.blocks.Add(hash, block)
+	return block, nil
+}
+
+// LoadHeader returns header of block at given height, or error if it's not found in Store.
+// Header of cached block is returned without reading the store; headers are not cached on their own.
+func (s *CachedStore) LoadHeader(height uint64) (*types.Header, error) {
+	if hash, ok := s.index.Get(height); ok {
+		return s.LoadHeaderByHash(hash.([32]byte))
+	}
+	return s.Store.LoadHeader(height)
+}
+
+// LoadHeaderByHash returns header of block with given block header hash, or error if it's not found in Store.
+func (s *CachedStore) LoadHeaderByHash(hash [32]byte) (*types.Header, error) {
+	if block, ok := s.blocks.Get(hash); ok {
+		return &block.(*types.Block).Header, nil
+	}
+	return s.Store.LoadHeaderByHash(hash)
+}
+
+// LoadCommit returns commit for a block at given height, or error if it's not found in Store.
+// Commits are cached by block header hash, so commit is cached only if hash of the block is known.
+func (s *CachedStore) LoadCommit(height uint64) (*types.Commit, error) {
+	if hash, ok := s.index.Get(height); ok {
+		return s.LoadCommitByHash(hash.([32]byte))
+	}
+	return s.Store.LoadCommit(height)
+}
+
+// LoadCommitByHash returns commit for a block with given block header hash, or error if it's not found in Store.
+func (s *CachedStore) LoadCommitByHash(hash [32]byte) (*types.Commit, error) {
+	if commit, ok := s.commits.Get(hash); ok {
+		return commit.(*types.Commit), nil
+	}
+	commit, err := s.Store.LoadCommitByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	s.commits.Add(hash, commit)
+	return commit, nil
+}
+
+// SaveBlockResponses saves block responses in Store, and adds them to the cache.
+func (s *CachedStore) SaveBlockResponses(height uint64, responses *tmstate.ABCIResponses) error {
+	if err := s.Store.SaveBlockResponses(height, responses); err != nil {
+		return err
+	}
+	s.responses.Add(height, responses)
+	return nil
+}
+
+// LoadBlockResponses returns block results at given height, or error if it's not found in Store.
+func (s *CachedStore) LoadBlockResponses(height uint64) (*tmstate.ABCIResponses, error) {
+	if responses, ok := s.responses.Get(height); ok {
+		return responses.(*tmstate.ABCIResponses), nil
+	}
+	responses, err := s.Store.LoadBlockResponses(height)
+	if err != nil {
+		return nil, err
+	}
+	s.responses.Add(height, responses)
+	return responses, nil
+}
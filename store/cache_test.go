@@ -0,0 +1,86 @@
+package store
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+
+	"github.com/celestiaorg/optimint/types"
+)
+
+// countingKV counts reads from underlying KVStore.
+type countingKV struct {
+	KVStore
+	gets int64
+}
+
+func (kv *countingKV) Get(key []byte) ([]byte, error) {
+	atomic.AddInt64(&kv.gets, 1)
+	return kv.KVStore.Get(key)
+}
+
+func (kv *countingKV) reads() int64 {
+	return atomic.LoadInt64(&kv.gets)
+}
+
+func TestCachedStore(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, err := NewCachedStore(New(NewDefaultInMemoryKVStore()), 0)
+	assert.Error(err)
+
+	kv := &countingKV{KVStore: NewDefaultInMemoryKVStore()}
+	s, err := NewCachedStore(New(kv), 2)
+	require.NoError(err)
+
+	blocks := []*types.Block{getRandomBlock(1, 2), getRandomBlock(2, 3), getRandomBlock(3, 4)}
+	for _, block := range blocks {
+		hash := block.Header.Hash()
+		require.NoError(s.SaveBlock(block, &types.Commit{Height: block.Header.Height, HeaderHash: hash}))
+		require.NoError(s.SaveBlockResponses(block.Header.Height, &tmstate.ABCIResponses{}))
+	}
+	assert.Equal(uint64(3), s.Height())
+
+	// recent blocks are served from cache
+	reads := kv.reads()
+	for _, block := range blocks[1:] {
+		hash := block.Header.Hash()
+		loaded, err := s.LoadBlock(block.Header.Height)
+		require.NoError(err)
+		assert.Equal(block, loaded)
+		loaded, err = s.LoadBlockByHash(hash)
+		require.NoError(err)
+		assert.Equal(block, loaded)
+		header, err := s.LoadHeader(block.Header.Height)
+		require.NoError(err)
+		assert.Equal(&block.Header, header)
+		commit, err := s.LoadCommit(block.Header.Height)
+		require.NoError(err)
+		assert.Equal(hash, commit.HeaderHash)
+		_, err = s.LoadBlockResponses(block.Header.Height)
+		require.NoError(err)
+	}
+	assert.Equal(reads, kv.reads())
+
+	// evicted block is read from KV store, and cached again
+	loaded, err := s.LoadBlock(1)
+	require.NoError(err)
+	assert.Equal(blocks[0], loaded)
+	assert.Greater(kv.reads(), reads)
+	reads = kv.reads()
+	loaded, err = s.LoadBlock(1)
+	require.NoError(err)
+	assert.Equal(blocks[0], loaded)
+	assert.Equal(reads, kv.reads())
+
+	// missing blocks are not cached
+	_, err = s.LoadBlock(4)
+	assert.Error(err)
+	_, err = s.LoadBlockResponses(4)
+	assert.Error(err)
+}
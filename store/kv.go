@@ -35,6 +35,11 @@ type Iterator interface {
 	Discard()
 }
 
+// Compactor is implemented by KVStores that can reclaim disk space after deletions.
+type Compactor interface {
+	Compact() error
+}
+
 // NewInMemoryKVStore builds KVStore that works in-memory (without accessing disk).
 func NewDefaultInMemoryKVStore() KVStore {
 	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true))
@@ -1,6 +1,7 @@
 package store
 
 var _ KVStore = &PrefixKV{}
+var _ Compactor = &PrefixKV{}
 var _ Batch = &PrefixKVBatch{}
 
 type PrefixKV struct {
@@ -33,8 +34,21 @@ func (p *PrefixKV) NewBatch() Batch {
 	}
 }
 
+// PrefixIterator creates iterator to traverse given prefix. Keys returned by the iterator don't include prefix of
+// PrefixKV, so they are the same as keys passed to Set.
 func (p *PrefixKV) PrefixIterator(prefix []byte) Iterator {
-	return p.kv.PrefixIterator(append(p.prefix, prefix...))
+	return &prefixIterator{
+		Iterator: p.kv.PrefixIterator(append(p.prefix, prefix...)),
+		prefix:   p.prefix,
+	}
+}
+
+// Compact compacts underlying KVStore (if supported). Whole store is compacted, not only the prefix.
+func (p *PrefixKV) Compact() error {
+	if c, ok := p.kv.(Compactor); ok {
+		return c.Compact()
+	}
+	return nil
 }
 
 type PrefixKVBatch struct {
@@ -57,3 +71,13 @@ func (pb *PrefixKVBatch) Commit() error {
 func (pb *PrefixKVBatch) Discard() {
 	pb.b.Discard()
 }
+
+// prefixIterator strips prefix of PrefixKV from keys returned by the underlying iterator.
+type prefixIterator struct {
+	Iterator
+	prefix []byte
+}
+
+func (i *prefixIterator) Key() []byte {
+	return i.Iterator.Key()[len(i.prefix):]
+}
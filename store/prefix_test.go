@@ -104,3 +104,29 @@ func TestPrefixKVBatch(t *testing.T) {
 	require.NoError(err)
 
 }
+
+func TestPrefixKVIterator(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+	require := require.New(t)
+
+	base := NewDefaultInMemoryKVStore()
+	require.NoError(base.Set([]byte("other"), []byte("value")))
+	// nested prefixes are stripped as well
+	p := NewPrefixKV(NewPrefixKV(base, []byte("outer")), []byte("inner"))
+	require.NoError(p.Set([]byte("key1"), []byte("value1")))
+	require.NoError(p.Set([]byte("key2"), []byte("value2")))
+	require.NoError(p.Set([]byte("other"), []byte("value3")))
+
+	it := p.PrefixIterator([]byte("key"))
+	defer it.Discard()
+	var keys, values []string
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, string(it.Key()))
+		values = append(values, string(it.Value()))
+	}
+	require.NoError(it.Error())
+	assert.Equal([]string{"key1", "key2"}, keys)
+	assert.Equal([]string{"value1", "value2"}, values)
+}
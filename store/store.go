@@ -14,11 +14,25 @@ import (
 )
 
 var (
-	blockPrefix     = [1]byte{1}
-	indexPrefix     = [1]byte{2}
-	commitPrefix    = [1]byte{3}
-	statePrefix     = [1]byte{4}
-	responsesPrefix = [1]byte{5}
+	blockPrefix            = [1]byte{1}
+	indexPrefix            = [1]byte{2}
+	commitPrefix           = [1]byte{3}
+	statePrefix            = [1]byte{4}
+	responsesPrefix        = [1]byte{5}
+	daInfoPrefix           = [1]byte{6}
+	basePrefix             = [1]byte{7}
+	daSpendPrefix          = [1]byte{8}
+	forcedPrefix           = [1]byte{9}
+	heightPrefix           = [1]byte{10}
+	daIndexPrefix          = [1]byte{11}
+	syncStatePrefix        = [1]byte{12}
+	proofPrefix            = [1]byte{13}
+	checkpointPrefix       = [1]byte{14}
+	latestCheckpointPrefix = [1]byte{15}
+	headerPrefix           = [1]byte{16}
+	dataPrefix             = [1]byte{17}
+	lastCommitPrefix       = [1]byte{18}
+	blockMetaPrefix        = [1]byte{19}
 )
 
 // DefaultStore is a default store implmementation.
@@ -26,8 +40,10 @@ type DefaultStore struct {
 	db KVStore
 
 	height uint64
+	// base is lazily loaded from db (0 - not loaded yet, or no blocks in store)
+	base uint64
 
-	// mtx ensures that db is in sync with height
+	// mtx ensures that db is in sync with height and base
 	mtx sync.RWMutex
 }
 
@@ -47,11 +63,51 @@ func (s *DefaultStore) Height() uint64 {
 	return s.height
 }
 
+// SetHeight sets height of the Store, if given height is greater than current one.
+func (s *DefaultStore) SetHeight(height uint64) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if height > s.height {
+		s.height = height
+	}
+}
+
+// Base returns height of the lowest block saved in the Store.
+func (s *DefaultStore) Base() uint64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.loadBase()
+}
+
+// loadBase returns base height, reading it from db if required. Caller must hold the lock.
+func (s *DefaultStore) loadBase() uint64 {
+	if s.base == 0 {
+		blob, err := s.db.Get(getBaseKey())
+		if err == nil && len(blob) == 8 {
+			s.base = binary.BigEndian.Uint64(blob)
+		}
+	}
+	return s.base
+}
+
 // SaveBlock adds block to the store along with corresponding commit.
+// Header, data and last commit of the block are saved under separate keys, so that header can be loaded without
+// decoding transactions.
 // Stored height is updated if block height is greater than stored value.
+// Stored base is updated if block height is lower than stored value.
 func (s *DefaultStore) SaveBlock(block *types.Block, commit *types.Commit) error {
 	hash := block.Header.Hash()
-	blockBlob, err := block.MarshalBinary()
+	headerBlob, err := block.Header.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	dataBlob, err := block.Data.MarshalBlob()
+	if err != nil {
+		return err
+	}
+	// buffer can be reused after the batch is committed
+	defer dataBlob.Release()
+	lastCommitBlob, err := block.LastCommit.MarshalBinary()
 	if err != nil {
 		return err
 	}
@@ -61,13 +117,24 @@ func (s *DefaultStore) SaveBlock(block *types.Block, commit *types.Commit) error
 		return err
 	}
 
+	// size of block binary form, in which header, data and last commit are length-prefixed fields
+	size := fieldSize(len(headerBlob)) + fieldSize(len(dataBlob.Bytes())) + fieldSize(len(lastCommitBlob))
+
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
 	bb := s.db.NewBatch()
-	err = multierr.Append(err, bb.Set(getBlockKey(hash), blockBlob))
+	err = multierr.Append(err, bb.Set(getHeaderKey(hash), headerBlob))
+	err = multierr.Append(err, bb.Set(getDataKey(hash), dataBlob.Bytes()))
+	err = multierr.Append(err, bb.Set(getLastCommitKey(hash), lastCommitBlob))
+	err = multierr.Append(err, bb.Set(getBlockMetaKey(hash), encodeBlockMeta(size, len(block.Data.Txs))))
 	err = multierr.Append(err, bb.Set(getCommitKey(hash), commitBlob))
 	err = multierr.Append(err, bb.Set(getIndexKey(block.Header.Height), hash[:]))
+	err = multierr.Append(err, bb.Set(getHeightKey(hash), encodeHeight(block.Header.Height)))
+	updateBase := s.loadBase() == 0 || block.Header.Height < s.base
+	if updateBase {
+		err = multierr.Append(err, bb.Set(getBaseKey(), encodeHeight(block.Header.Height)))
+	}
 
 	if err != nil {
 		bb.Discard()
@@ -81,6 +148,9 @@ func (s *DefaultStore) SaveBlock(block *types.Block, commit *types.Commit) error
 	if block.Header.Height > s.height {
 		s.height = block.Header.Height
 	}
+	if updateBase {
+		s.base = block.Header.Height
+	}
 
 	return nil
 }
@@ -99,8 +169,95 @@ func (s *DefaultStore) LoadBlock(height uint64) (*types.Block, error) {
 
 // LoadBlockByHash returns block with given block header hash, or error if it's not found in Store.
 func (s *DefaultStore) LoadBlockByHash(hash [32]byte) (*types.Block, error) {
-	blockData, err := s.db.Get(getBlockKey(hash))
+	headerBlob, err := s.db.Get(getHeaderKey(hash))
+	if errors.Is(err, ErrKeyNotFound) {
+		return s.loadLegacyBlock(hash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	block := new(types.Block)
+	if err := block.Header.UnmarshalBinary(headerBlob); err != nil {
+		return nil, err
+	}
+	dataBlob, err := s.db.Get(getDataKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	if err := block.Data.UnmarshalBinary(dataBlob); err != nil {
+		return nil, err
+	}
+	lastCommitBlob, err := s.db.Get(getLastCommitKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	err = block.LastCommit.UnmarshalBinary(lastCommitBlob)
+	return block, err
+}
+
+// LoadHeader returns header of block at given height, or error if it's not found in Store.
+func (s *DefaultStore) LoadHeader(height uint64) (*types.Header, error) {
+	h, err := s.loadHashFromIndex(height)
+	if err != nil {
+		return nil, err
+	}
+	return s.LoadHeaderByHash(h)
+}
+
+// LoadHeaderByHash returns header of block with given block header hash, or error if it's not found in Store.
+func (s *DefaultStore) LoadHeaderByHash(hash [32]byte) (*types.Header, error) {
+	blob, err := s.db.Get(getHeaderKey(hash))
+	if errors.Is(err, ErrKeyNotFound) {
+		block, err := s.loadLegacyBlock(hash)
+		if err != nil {
+			return nil, err
+		}
+		return &block.Header, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	header := new(types.Header)
+	err = header.UnmarshalBinary(blob)
+	return header, err
+}
+
+// LoadBlockMeta returns header, size and number of transactions of block at given height, or error if it's not found
+// in Store.
+func (s *DefaultStore) LoadBlockMeta(height uint64) (*types.BlockMeta, error) {
+	h, err := s.loadHashFromIndex(height)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := s.db.Get(getBlockMetaKey(h))
+	if errors.Is(err, ErrKeyNotFound) {
+		block, err := s.loadLegacyBlock(h)
+		if err != nil {
+			return nil, err
+		}
+		return &types.BlockMeta{Header: block.Header, Size: block.Size(), NumTxs: len(block.Data.Txs)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) != 16 {
+		return nil, errors.New("invalid block meta length")
+	}
+	header, err := s.LoadHeaderByHash(h)
+	if err != nil {
+		return nil, err
+	}
+	return &types.BlockMeta{
+		Header: *header,
+		Size:   int(binary.BigEndian.Uint64(blob)),
+		NumTxs: int(binary.BigEndian.Uint64(blob[8:])),
+	}, nil
+}
 
+// loadLegacyBlock returns block saved as a whole, before header, data and last commit were stored separately.
+func (s *DefaultStore) loadLegacyBlock(hash [32]byte) (*types.Block, error) {
+	blockData, err := s.db.Get(getBlockKey(hash))
 	if err != nil {
 		return nil, err
 	}
@@ -111,6 +268,26 @@ func (s *DefaultStore) LoadBlockByHash(hash [32]byte) (*types.Block, error) {
 	return block, err
 }
 
+// LoadBlockHeight returns height of block with given block header hash, or error if it's not found in Store.
+func (s *DefaultStore) LoadBlockHeight(hash [32]byte) (uint64, error) {
+	blob, err := s.db.Get(getHeightKey(hash))
+	if err == nil {
+		if len(blob) != 8 {
+			return 0, errors.New("invalid height length")
+		}
+		return binary.BigEndian.Uint64(blob), nil
+	}
+	if !errors.Is(err, ErrKeyNotFound) {
+		return 0, err
+	}
+	// blocks saved before hash index was introduced
+	block, err := s.LoadBlockByHash(hash)
+	if err != nil {
+		return 0, err
+	}
+	return block.Header.Height, nil
+}
+
 // SaveBlockResponses saves block responses (events, tx responses, validator set updates, etc) in Store.
 func (s *DefaultStore) SaveBlockResponses(height uint64, responses *tmstate.ABCIResponses) error {
 	data, err := responses.Marshal()
@@ -131,6 +308,205 @@ func (s *DefaultStore) LoadBlockResponses(height uint64) (*tmstate.ABCIResponses
 	return &responses, err
 }
 
+// SaveDAInfo saves information about inclusion of block at given height in DA layer.
+// DA height index is updated in the same batch.
+func (s *DefaultStore) SaveDAInfo(height uint64, info *types.DAInfo) error {
+	blob, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	prev, err := s.LoadDAInfo(height)
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		return err
+	}
+
+	bb := s.db.NewBatch()
+	defer bb.Discard()
+	err = multierr.Append(bb.Set(getDAInfoKey(height), blob), bb.Set(getDAIndexKey(info.DAHeight, height), []byte{}))
+	if prev != nil && prev.DAHeight != info.DAHeight {
+		err = multierr.Append(err, bb.Delete(getDAIndexKey(prev.DAHeight, height)))
+	}
+	if err != nil {
+		return err
+	}
+	return bb.Commit()
+}
+
+// LoadDAInfo returns DA layer inclusion information of block at given height, or error if it's not found in Store.
+func (s *DefaultStore) LoadDAInfo(height uint64) (*types.DAInfo, error) {
+	blob, err := s.db.Get(getDAInfoKey(height))
+	if err != nil {
+		return nil, err
+	}
+	var info types.DAInfo
+	err = json.Unmarshal(blob, &info)
+	return &info, err
+}
+
+// DeleteDAInfo removes information about inclusion of block at given height in DA layer.
+// DA height index is updated in the same batch.
+func (s *DefaultStore) DeleteDAInfo(height uint64) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	info, err := s.LoadDAInfo(height)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	bb := s.db.NewBatch()
+	defer bb.Discard()
+	err = multierr.Append(bb.Delete(getDAInfoKey(height)), bb.Delete(getDAIndexKey(info.DAHeight, height)))
+	if err != nil {
+		return err
+	}
+	return bb.Commit()
+}
+
+// LoadHeightsByDAHeight returns heights of blocks included in DA layer block at given height, in ascending order.
+func (s *DefaultStore) LoadHeightsByDAHeight(daHeight uint64) ([]uint64, error) {
+	prefix := getDAIndexKey(daHeight, 0)[:1+8]
+	it := s.db.PrefixIterator(prefix)
+	defer it.Discard()
+
+	var heights []uint64
+	for ; it.Valid(); it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8 {
+			return nil, errors.New("invalid DA index key length")
+		}
+		heights = append(heights, binary.BigEndian.Uint64(key[len(prefix):]))
+	}
+	return heights, it.Error()
+}
+
+// SaveForcedTxsCursor saves position of the first forced inclusion transaction not included in blocks up to given height.
+func (s *DefaultStore) SaveForcedTxsCursor(height uint64, cursor *types.ForcedTxsCursor) error {
+	blob, err := json.Marshal(cursor)
+	if err != nil {
+		return err
+	}
+	return s.db.Set(getForcedTxsCursorKey(height), blob)
+}
+
+// LoadForcedTxsCursor returns position of the first forced inclusion transaction not included in blocks up to given
+// height, or error if it's not found in Store.
+func (s *DefaultStore) LoadForcedTxsCursor(height uint64) (*types.ForcedTxsCursor, error) {
+	blob, err := s.db.Get(getForcedTxsCursorKey(height))
+	if err != nil {
+		return nil, err
+	}
+	var cursor types.ForcedTxsCursor
+	err = json.Unmarshal(blob, &cursor)
+	return &cursor, err
+}
+
+// SaveDASpend saves cumulative cost of block submissions to DA layer.
+func (s *DefaultStore) SaveDASpend(spend *types.DASpend) error {
+	blob, err := json.Marshal(spend)
+	if err != nil {
+		return err
+	}
+	return s.db.Set(getDASpendKey(), blob)
+}
+
+// LoadDASpend returns cumulative cost of block submissions to DA layer, or error if it's not found in Store.
+func (s *DefaultStore) LoadDASpend() (*types.DASpend, error) {
+	blob, err := s.db.Get(getDASpendKey())
+	if err != nil {
+		return nil, err
+	}
+	var spend types.DASpend
+	err = json.Unmarshal(blob, &spend)
+	return &spend, err
+}
+
+// SaveValidityProof saves validity proof of block at given height.
+func (s *DefaultStore) SaveValidityProof(height uint64, proof []byte) error {
+	return s.db.Set(getValidityProofKey(height), proof)
+}
+
+// LoadValidityProof returns validity proof of block at given height, or error if it's not found in Store.
+func (s *DefaultStore) LoadValidityProof(height uint64) ([]byte, error) {
+	return s.db.Get(getValidityProofKey(height))
+}
+
+// SaveCheckpoint saves checkpoint, and marks it as the latest one if it has the greatest height.
+func (s *DefaultStore) SaveCheckpoint(checkpoint *types.Checkpoint) error {
+	blob, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	latest, err := s.LoadLatestCheckpoint()
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		return err
+	}
+	bb := s.db.NewBatch()
+	err = bb.Set(getCheckpointKey(checkpoint.Height), blob)
+	if latest == nil || checkpoint.Height > latest.Height {
+		err = multierr.Append(err, bb.Set(latestCheckpointPrefix[:], encodeHeight(checkpoint.Height)))
+	}
+	if err != nil {
+		bb.Discard()
+		return err
+	}
+	return bb.Commit()
+}
+
+// LoadCheckpoint returns checkpoint at given height, or error if it's not found in Store.
+func (s *DefaultStore) LoadCheckpoint(height uint64) (*types.Checkpoint, error) {
+	blob, err := s.db.Get(getCheckpointKey(height))
+	if err != nil {
+		return nil, err
+	}
+	var checkpoint types.Checkpoint
+	err = json.Unmarshal(blob, &checkpoint)
+	return &checkpoint, err
+}
+
+// LoadLatestCheckpoint returns checkpoint with the greatest height, or error if there are no checkpoints in Store.
+func (s *DefaultStore) LoadLatestCheckpoint() (*types.Checkpoint, error) {
+	heightBytes, err := s.db.Get(latestCheckpointPrefix[:])
+	if err != nil {
+		return nil, err
+	}
+	if len(heightBytes) != 8 {
+		return nil, errors.New("invalid latest checkpoint height")
+	}
+	return s.LoadCheckpoint(binary.BigEndian.Uint64(heightBytes))
+}
+
+// SaveSyncState saves progress of block syncing and submission to DA layer. Only one SyncState is stored.
+func (s *DefaultStore) SaveSyncState(syncState *types.SyncState) error {
+	blob, err := json.Marshal(syncState)
+	if err != nil {
+		return err
+	}
+	return s.db.Set(getSyncStateKey(), blob)
+}
+
+// LoadSyncState returns sync state saved with SaveSyncState, or error if it's not found in Store.
+func (s *DefaultStore) LoadSyncState() (*types.SyncState, error) {
+	blob, err := s.db.Get(getSyncStateKey())
+	if err != nil {
+		return nil, err
+	}
+	var syncState types.SyncState
+	err = json.Unmarshal(blob, &syncState)
+	return &syncState, err
+}
+
 // LoadCommit returns commit for a block at given height, or error if it's not found in Store.
 func (s *DefaultStore) LoadCommit(height uint64) (*types.Commit, error) {
 	hash, err := s.loadHashFromIndex(height)
@@ -154,7 +530,7 @@ func (s *DefaultStore) LoadCommitByHash(hash [32]byte) (*types.Commit, error) {
 // UpdateState updates state saved in Store. Only one State is stored.
 // If there is no State in Store, state will be saved.
 func (s *DefaultStore) UpdateState(state state.State) error {
-	blob, err := json.Marshal(state)
+	blob, err := state.MarshalBinary()
 	if err != nil {
 		return err
 	}
@@ -170,9 +546,11 @@ func (s *DefaultStore) LoadState() (state.State, error) {
 		return state, err
 	}
 
-	err = json.Unmarshal(blob, &state)
+	err = state.UnmarshalBinary(blob)
 	s.mtx.Lock()
-	s.height = uint64(state.LastBlockHeight)
+	if uint64(state.LastBlockHeight) > s.height {
+		s.height = uint64(state.LastBlockHeight)
+	}
 	s.mtx.Unlock()
 	return state, err
 }
@@ -195,16 +573,71 @@ func getBlockKey(hash [32]byte) []byte {
 	return append(blockPrefix[:], hash[:]...)
 }
 
+func getHeaderKey(hash [32]byte) []byte {
+	return append(headerPrefix[:], hash[:]...)
+}
+
+func getDataKey(hash [32]byte) []byte {
+	return append(dataPrefix[:], hash[:]...)
+}
+
+func getLastCommitKey(hash [32]byte) []byte {
+	return append(lastCommitPrefix[:], hash[:]...)
+}
+
+func getBlockMetaKey(hash [32]byte) []byte {
+	return append(blockMetaPrefix[:], hash[:]...)
+}
+
+func encodeBlockMeta(size, numTxs int) []byte {
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf, uint64(size))
+	binary.BigEndian.PutUint64(buf[8:], uint64(numTxs))
+	return buf
+}
+
+// fieldSize returns length of protobuf field (with field number below 16) holding n bytes.
+func fieldSize(n int) int {
+	size := 1 + n
+	for v := uint64(n); ; v >>= 7 {
+		size++
+		if v < 0x80 {
+			return size
+		}
+	}
+}
+
 func getCommitKey(hash [32]byte) []byte {
 	return append(commitPrefix[:], hash[:]...)
 }
 
+func getHeightKey(hash [32]byte) []byte {
+	return append(heightPrefix[:], hash[:]...)
+}
+
+func getDAIndexKey(daHeight, height uint64) []byte {
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], daHeight)
+	binary.BigEndian.PutUint64(buf[8:], height)
+	return append(daIndexPrefix[:], buf[:]...)
+}
+
+func encodeHeight(height uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, height)
+	return buf
+}
+
 func getIndexKey(height uint64) []byte {
 	buf := make([]byte, 8)
 	binary.BigEndian.PutUint64(buf, height)
 	return append(indexPrefix[:], buf[:]...)
 }
 
+func getBaseKey() []byte {
+	return basePrefix[:]
+}
+
 func getStateKey() []byte {
 	return statePrefix[:]
 }
@@ -214,3 +647,31 @@ func getResponsesKey(height uint64) []byte {
 	binary.BigEndian.PutUint64(buf, height)
 	return append(responsesPrefix[:], buf[:]...)
 }
+
+func getDAInfoKey(height uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, height)
+	return append(daInfoPrefix[:], buf[:]...)
+}
+
+func getForcedTxsCursorKey(height uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], height)
+	return append(forcedPrefix[:], buf[:]...)
+}
+
+func getDASpendKey() []byte {
+	return daSpendPrefix[:]
+}
+
+func getSyncStateKey() []byte {
+	return syncStatePrefix[:]
+}
+
+func getValidityProofKey(height uint64) []byte {
+	return append(proofPrefix[:], encodeHeight(height)...)
+}
+
+func getCheckpointKey(height uint64) []byte {
+	return append(checkpointPrefix[:], encodeHeight(height)...)
+}
@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/celestiaorg/optimint/state"
 	"github.com/celestiaorg/optimint/types"
@@ -17,32 +19,39 @@ import (
 func TestStoreHeight(t *testing.T) {
 	t.Parallel()
 	cases := []struct {
-		name     string
-		blocks   []*types.Block
-		expected uint64
+		name         string
+		blocks       []*types.Block
+		expected     uint64
+		expectedBase uint64
 	}{
-		{"single block", []*types.Block{getRandomBlock(1, 0)}, 1},
+		{"single block", []*types.Block{getRandomBlock(1, 0)}, 1, 1},
 		{"two consecutive blocks", []*types.Block{
 			getRandomBlock(1, 0),
 			getRandomBlock(2, 0),
-		}, 2},
+		}, 2, 1},
 		{"blocks out of order", []*types.Block{
 			getRandomBlock(2, 0),
 			getRandomBlock(3, 0),
 			getRandomBlock(1, 0),
-		}, 3},
+		}, 3, 1},
 		{"with a gap", []*types.Block{
 			getRandomBlock(1, 0),
 			getRandomBlock(9, 0),
 			getRandomBlock(10, 0),
-		}, 10},
+		}, 10, 1},
+		{"non-zero initial height", []*types.Block{
+			getRandomBlock(5, 0),
+			getRandomBlock(6, 0),
+		}, 6, 5},
 	}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			assert := assert.New(t)
-			bstore := New(NewDefaultInMemoryKVStore())
+			kv := NewDefaultInMemoryKVStore()
+			bstore := New(kv)
 			assert.Equal(uint64(0), bstore.Height())
+			assert.Equal(uint64(0), bstore.Base())
 
 			for _, block := range c.blocks {
 				err := bstore.SaveBlock(block, &types.Commit{})
@@ -50,6 +59,9 @@ func TestStoreHeight(t *testing.T) {
 			}
 
 			assert.Equal(c.expected, bstore.Height())
+			assert.Equal(c.expectedBase, bstore.Base())
+			// base is persisted
+			assert.Equal(c.expectedBase, New(kv).Base())
 		})
 	}
 }
@@ -102,6 +114,13 @@ func TestStoreLoad(t *testing.T) {
 					assert.NotNil(block)
 					assert.Equal(expected, block)
 
+					header, err := bstore.LoadHeader(expected.Header.Height)
+					assert.NoError(err)
+					assert.Equal(&expected.Header, header)
+					meta, err := bstore.LoadBlockMeta(expected.Header.Height)
+					assert.NoError(err)
+					assert.Equal(&types.BlockMeta{Header: expected.Header, Size: expected.Size(), NumTxs: len(expected.Data.Txs)}, meta)
+
 					commit, err := bstore.LoadCommit(expected.Header.Height)
 					assert.NoError(err)
 					assert.NotNil(commit)
@@ -136,6 +155,33 @@ func TestRestart(t *testing.T) {
 	assert.Equal(expectedHeight, s2.Height())
 }
 
+func TestStateWithValidators(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+
+	validators := tmtypes.NewValidatorSet([]*tmtypes.Validator{tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), 1)})
+	expected := state.State{
+		ChainID:         "test",
+		LastBlockHeight: 5,
+		NextValidators:  validators.CopyIncrementProposerPriority(1),
+		Validators:      validators,
+		LastValidators:  tmtypes.NewValidatorSet(nil),
+		ConsensusParams: *tmtypes.DefaultConsensusParams(),
+		AppHash:         [32]byte{1, 2, 3},
+	}
+
+	s := New(NewDefaultInMemoryKVStore())
+	require.NoError(s.UpdateState(expected))
+
+	actual, err := s.LoadState()
+	require.NoError(err)
+	require.Equal(expected.Validators.Hash(), actual.Validators.Hash())
+	require.Equal(expected.NextValidators.Hash(), actual.NextValidators.Hash())
+	require.Equal(expected.ConsensusParams, actual.ConsensusParams)
+	require.Equal(expected.AppHash, actual.AppHash)
+}
+
 func TestBlockResponses(t *testing.T) {
 	t.Parallel()
 	assert := assert.New(t)
@@ -179,6 +225,253 @@ func TestBlockResponses(t *testing.T) {
 	assert.Equal(expected, resp)
 }
 
+func TestDAInfo(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	s := New(NewDefaultInMemoryKVStore())
+
+	expected := &types.DAInfo{DAHeight: 7, TxHash: getRandomBytes(32)}
+	err := s.SaveDAInfo(1, expected)
+	assert.NoError(err)
+
+	info, err := s.LoadDAInfo(2)
+	assert.ErrorIs(err, ErrKeyNotFound)
+	assert.Nil(info)
+
+	info, err = s.LoadDAInfo(1)
+	assert.NoError(err)
+	assert.Equal(expected, info)
+
+	err = s.DeleteDAInfo(1)
+	assert.NoError(err)
+	info, err = s.LoadDAInfo(1)
+	assert.ErrorIs(err, ErrKeyNotFound)
+	assert.Nil(info)
+}
+
+func TestDAHeightIndex(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := New(NewDefaultInMemoryKVStore())
+
+	heights, err := s.LoadHeightsByDAHeight(7)
+	assert.NoError(err)
+	assert.Empty(heights)
+
+	require.NoError(s.SaveDAInfo(3, &types.DAInfo{DAHeight: 7}))
+	require.NoError(s.SaveDAInfo(1, &types.DAInfo{DAHeight: 7}))
+	require.NoError(s.SaveDAInfo(2, &types.DAInfo{DAHeight: 8}))
+	heights, err = s.LoadHeightsByDAHeight(7)
+	assert.NoError(err)
+	assert.Equal([]uint64{1, 3}, heights)
+
+	// block re-included in another DA block is moved in index
+	require.NoError(s.SaveDAInfo(3, &types.DAInfo{DAHeight: 8, DAHash: []byte{1}}))
+	heights, err = s.LoadHeightsByDAHeight(7)
+	assert.NoError(err)
+	assert.Equal([]uint64{1}, heights)
+	heights, err = s.LoadHeightsByDAHeight(8)
+	assert.NoError(err)
+	assert.Equal([]uint64{2, 3}, heights)
+
+	require.NoError(s.DeleteDAInfo(2))
+	require.NoError(s.DeleteDAInfo(4))
+	heights, err = s.LoadHeightsByDAHeight(8)
+	assert.NoError(err)
+	assert.Equal([]uint64{3}, heights)
+}
+
+func TestLoadBlockHeight(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kv := NewDefaultInMemoryKVStore()
+	s := New(kv)
+
+	block := getRandomBlock(5, 10)
+	hash := block.Header.Hash()
+	require.NoError(s.SaveBlock(block, &types.Commit{Height: 5, HeaderHash: hash}))
+	height, err := s.LoadBlockHeight(hash)
+	assert.NoError(err)
+	assert.EqualValues(5, height)
+
+	// blocks without hash index entry are still found
+	require.NoError(kv.Delete(getHeightKey(hash)))
+	height, err = s.LoadBlockHeight(hash)
+	assert.NoError(err)
+	assert.EqualValues(5, height)
+
+	_, err = s.LoadBlockHeight([32]byte{1})
+	assert.ErrorIs(err, ErrKeyNotFound)
+}
+
+func TestLoadHeader(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kv := NewDefaultInMemoryKVStore()
+	s := New(kv)
+
+	block := getRandomBlock(5, 10)
+	hash := block.Header.Hash()
+	require.NoError(s.SaveBlock(block, &types.Commit{Height: 5, HeaderHash: hash}))
+
+	// header is loaded without block data
+	require.NoError(kv.Delete(getDataKey(hash)))
+	header, err := s.LoadHeaderByHash(hash)
+	require.NoError(err)
+	assert.Equal(&block.Header, header)
+	meta, err := s.LoadBlockMeta(5)
+	require.NoError(err)
+	assert.Equal(10, meta.NumTxs)
+	_, err = s.LoadBlock(5)
+	assert.ErrorIs(err, ErrKeyNotFound)
+
+	// blocks saved as a whole are still found
+	legacy := getRandomBlock(6, 10)
+	legacyHash := legacy.Header.Hash()
+	blob, err := legacy.MarshalBinary()
+	require.NoError(err)
+	require.NoError(kv.Set(getBlockKey(legacyHash), blob))
+	require.NoError(kv.Set(getIndexKey(6), legacyHash[:]))
+	loaded, err := s.LoadBlock(6)
+	require.NoError(err)
+	assert.Equal(legacy, loaded)
+	header, err = s.LoadHeader(6)
+	require.NoError(err)
+	assert.Equal(&legacy.Header, header)
+	meta, err = s.LoadBlockMeta(6)
+	require.NoError(err)
+	assert.Equal(&types.BlockMeta{Header: legacy.Header, Size: len(blob), NumTxs: 10}, meta)
+
+	_, err = s.LoadHeaderByHash([32]byte{1})
+	assert.ErrorIs(err, ErrKeyNotFound)
+}
+
+func TestDASpend(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	s := New(NewDefaultInMemoryKVStore())
+
+	spend, err := s.LoadDASpend()
+	assert.ErrorIs(err, ErrKeyNotFound)
+	assert.Nil(spend)
+
+	expected := &types.DASpend{TotalFee: 30, Submissions: 3}
+	assert.NoError(s.SaveDASpend(expected))
+	spend, err = s.LoadDASpend()
+	assert.NoError(err)
+	assert.Equal(expected, spend)
+}
+
+func TestSyncState(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	s := New(NewDefaultInMemoryKVStore())
+
+	syncState, err := s.LoadSyncState()
+	assert.ErrorIs(err, ErrKeyNotFound)
+	assert.Nil(syncState)
+
+	expected := &types.SyncState{DAHeight: 12, Height: 30, IncludedHeight: 28, SubmittedHeight: 29}
+	assert.NoError(s.SaveSyncState(expected))
+	syncState, err = s.LoadSyncState()
+	assert.NoError(err)
+	assert.Equal(expected, syncState)
+}
+
+func TestForcedTxsCursor(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	s := New(NewDefaultInMemoryKVStore())
+
+	expected := &types.ForcedTxsCursor{DAHeight: 12, Index: 3}
+	assert.NoError(s.SaveForcedTxsCursor(5, expected))
+
+	cursor, err := s.LoadForcedTxsCursor(4)
+	assert.ErrorIs(err, ErrKeyNotFound)
+	assert.Nil(cursor)
+
+	cursor, err = s.LoadForcedTxsCursor(5)
+	assert.NoError(err)
+	assert.Equal(expected, cursor)
+}
+
+func TestValidityProof(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	s := New(NewDefaultInMemoryKVStore())
+
+	proof, err := s.LoadValidityProof(3)
+	assert.ErrorIs(err, ErrKeyNotFound)
+	assert.Nil(proof)
+
+	expected := []byte("proof of block 3")
+	assert.NoError(s.SaveValidityProof(3, expected))
+	proof, err = s.LoadValidityProof(3)
+	assert.NoError(err)
+	assert.Equal(expected, proof)
+}
+
+func TestCheckpoints(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := New(NewDefaultInMemoryKVStore())
+
+	_, err := s.LoadLatestCheckpoint()
+	assert.ErrorIs(err, ErrKeyNotFound)
+
+	cp10 := &types.Checkpoint{ChainID: "test", Height: 10, AppHash: [32]byte{10}, DAHeight: 1, Signature: []byte{1}}
+	cp20 := &types.Checkpoint{ChainID: "test", Height: 20, AppHash: [32]byte{20}, DAHeight: 2, Signature: []byte{2}}
+	require.NoError(s.SaveCheckpoint(cp20))
+	// checkpoints received out of order don't replace the latest one
+	require.NoError(s.SaveCheckpoint(cp10))
+
+	cp, err := s.LoadCheckpoint(10)
+	require.NoError(err)
+	assert.Equal(cp10, cp)
+	cp, err = s.LoadLatestCheckpoint()
+	require.NoError(err)
+	assert.Equal(cp20, cp)
+
+	_, err = s.LoadCheckpoint(15)
+	assert.ErrorIs(err, ErrKeyNotFound)
+}
+
+func BenchmarkLoadBlock(b *testing.B) {
+	s := New(NewDefaultInMemoryKVStore())
+	block := getRandomBlock(1, 2000)
+	require.NoError(b, s.SaveBlock(block, &types.Commit{Height: 1, HeaderHash: block.Header.Hash()}))
+
+	b.Run("block", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := s.LoadBlock(1); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("header", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := s.LoadHeader(1); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 func getRandomBlock(height uint64, nTxs int) *types.Block {
 	block := &types.Block{
 		Header: types.Header{
@@ -12,6 +12,14 @@ type Store interface {
 	// Height returns height of the highest block in store.
 	Height() uint64
 
+	// SetHeight sets height of the store, if it's greater than current height.
+	// It's used when chain starts at height greater than 1 (genesis initial_height).
+	SetHeight(height uint64)
+
+	// Base returns height of the lowest block in store (0 if there are no blocks).
+	// Blocks below this height are not available, e.g. because they were pruned.
+	Base() uint64
+
 	// SaveBlock saves block along with its seen commit (which will be included in the next block).
 	SaveBlock(block *types.Block, commit *types.Commit) error
 
@@ -19,6 +27,17 @@ type Store interface {
 	LoadBlock(height uint64) (*types.Block, error)
 	// LoadBlockByHash returns block with given block header hash, or error if it's not found in Store.
 	LoadBlockByHash(hash [32]byte) (*types.Block, error)
+	// LoadBlockHeight returns height of block with given block header hash, or error if it's not found in Store.
+	LoadBlockHeight(hash [32]byte) (uint64, error)
+
+	// LoadHeader returns header of block at given height, or error if it's not found in Store.
+	// Unlike LoadBlock, it doesn't read and decode block data.
+	LoadHeader(height uint64) (*types.Header, error)
+	// LoadHeaderByHash returns header of block with given block header hash, or error if it's not found in Store.
+	LoadHeaderByHash(hash [32]byte) (*types.Header, error)
+	// LoadBlockMeta returns header, size and number of transactions of block at given height, or error if it's not
+	// found in Store. Unlike LoadBlock, it doesn't read and decode block data.
+	LoadBlockMeta(height uint64) (*types.BlockMeta, error)
 
 	// SaveBlockResponses saves block responses (events, tx responses, validator set updates, etc) in Store.
 	SaveBlockResponses(height uint64, responses *tmstate.ABCIResponses) error
@@ -26,6 +45,52 @@ type Store interface {
 	// LoadBlockResponses returns block results at given height, or error if it's not found in Store.
 	LoadBlockResponses(height uint64) (*tmstate.ABCIResponses, error)
 
+	// SaveDAInfo saves information about inclusion of block at given height in DA layer.
+	SaveDAInfo(height uint64, info *types.DAInfo) error
+
+	// LoadDAInfo returns DA layer inclusion information of block at given height, or error if it's not found in Store.
+	LoadDAInfo(height uint64) (*types.DAInfo, error)
+
+	// DeleteDAInfo removes information about inclusion of block at given height in DA layer.
+	DeleteDAInfo(height uint64) error
+
+	// LoadHeightsByDAHeight returns heights of blocks included in DA layer block at given height, in ascending order.
+	LoadHeightsByDAHeight(daHeight uint64) ([]uint64, error)
+
+	// SaveForcedTxsCursor saves position of the first forced inclusion transaction not included in blocks up to given height.
+	SaveForcedTxsCursor(height uint64, cursor *types.ForcedTxsCursor) error
+
+	// LoadForcedTxsCursor returns position of the first forced inclusion transaction not included in blocks up to given
+	// height, or error if it's not found in Store.
+	LoadForcedTxsCursor(height uint64) (*types.ForcedTxsCursor, error)
+
+	// SaveDASpend saves cumulative cost of block submissions to DA layer.
+	SaveDASpend(spend *types.DASpend) error
+
+	// LoadDASpend returns cumulative cost of block submissions to DA layer, or error if it's not found in Store.
+	LoadDASpend() (*types.DASpend, error)
+
+	// SaveValidityProof saves validity proof of block at given height (see block.ValidityProver).
+	SaveValidityProof(height uint64, proof []byte) error
+
+	// LoadValidityProof returns validity proof of block at given height, or error if it's not found in Store.
+	LoadValidityProof(height uint64) ([]byte, error)
+
+	// SaveCheckpoint saves signed checkpoint of the chain state. Checkpoint with the greatest height is the latest one.
+	SaveCheckpoint(checkpoint *types.Checkpoint) error
+
+	// LoadCheckpoint returns checkpoint at given height, or error if it's not found in Store.
+	LoadCheckpoint(height uint64) (*types.Checkpoint, error)
+
+	// LoadLatestCheckpoint returns checkpoint with the greatest height, or error if there are no checkpoints in Store.
+	LoadLatestCheckpoint() (*types.Checkpoint, error)
+
+	// SaveSyncState saves progress of block syncing and submission to DA layer. Only one SyncState is stored.
+	SaveSyncState(syncState *types.SyncState) error
+
+	// LoadSyncState returns sync state saved with SaveSyncState, or error if it's not found in Store.
+	LoadSyncState() (*types.SyncState, error)
+
 	// LoadCommit returns commit for a block at given height, or error if it's not found in Store.
 	LoadCommit(height uint64) (*types.Commit, error)
 	// LoadCommitByHash returns commit for a block with given block header hash, or error if it's not found in Store.
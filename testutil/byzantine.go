@@ -0,0 +1,77 @@
+package testutil
+
+import (
+	"sync/atomic"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// ByzantineConfig configures misbehaviour of devnet aggregator. Zero values disable given misbehaviour.
+type ByzantineConfig struct {
+	// SkipDASubmissionsFrom makes aggregator report successful submission of blocks starting from given height,
+	// without actually submitting them to DA layer.
+	SkipDASubmissionsFrom uint64
+	// EquivocateAt makes aggregator submit to DA layer a block conflicting with the block it applied and signed
+	// at given height.
+	EquivocateAt uint64
+	// InvalidAppHashAt makes application of aggregator return invalid app hash after executing block at given
+	// height, so the next block carries app hash that doesn't match honest execution.
+	InvalidAppHashAt uint64
+}
+
+// byzantineDALC misbehaves while submitting blocks, according to ByzantineConfig.
+type byzantineDALC struct {
+	*FaultyDALC
+	conf ByzantineConfig
+}
+
+// SubmitBlock implements DataAvailabilityLayerClient interface.
+func (b *byzantineDALC) SubmitBlock(block *types.Block) da.ResultSubmitBlock {
+	height := block.Header.Height
+	if b.conf.SkipDASubmissionsFrom > 0 && height >= b.conf.SkipDASubmissionsFrom {
+		return da.ResultSubmitBlock{DAResult: da.DAResult{Code: da.StatusSuccess}}
+	}
+	if height == b.conf.EquivocateAt {
+		block = conflictingBlock(block)
+	}
+	return b.FaultyDALC.SubmitBlock(block)
+}
+
+// conflictingBlock returns block with the same height, but different data than given block.
+func conflictingBlock(block *types.Block) *types.Block {
+	conflicting := *block
+	conflicting.Data.Txs = append(append(types.Txs{}, block.Data.Txs...), types.Tx("equivocation"))
+	conflicting.Header.DataHash = conflicting.Data.Hash()
+	return &conflicting
+}
+
+// byzantineApp returns invalid app hash after executing block at given height.
+type byzantineApp struct {
+	*kvstore.Application
+	invalidAppHashAt uint64
+
+	height uint64
+}
+
+// BeginBlock implements abci.Application interface.
+func (a *byzantineApp) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	atomic.StoreUint64(&a.height, uint64(req.Header.Height))
+	return a.Application.BeginBlock(req)
+}
+
+// Commit implements abci.Application interface.
+func (a *byzantineApp) Commit() abci.ResponseCommit {
+	res := a.Application.Commit()
+	if atomic.LoadUint64(&a.height) == a.invalidAppHashAt {
+		invalid := make([]byte, len(res.Data))
+		for i := range res.Data {
+			invalid[i] = ^res.Data[i]
+		}
+		res.Data = invalid
+	}
+	return res
+}
@@ -0,0 +1,109 @@
+package testutil
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/celestiaorg/optimint/da"
+	"github.com/celestiaorg/optimint/log"
+	"github.com/celestiaorg/optimint/store"
+	"github.com/celestiaorg/optimint/types"
+)
+
+// FaultyDALC wraps data availability layer client, allowing to simulate DA layer failures and latency.
+// It's safe to share FaultyDALC between nodes of the same chain.
+type FaultyDALC struct {
+	dalc da.DataAvailabilityLayerClient
+
+	failSubmit   int32
+	failRetrieve int32
+	// latency and jitter are durations in nanoseconds (accessed atomically)
+	latency int64
+	jitter  int64
+}
+
+var _ da.DataAvailabilityLayerClient = &FaultyDALC{}
+var _ da.BlockRetriever = &FaultyDALC{}
+
+// NewFaultyDALC returns FaultyDALC wrapping given client. Client has to implement da.BlockRetriever.
+func NewFaultyDALC(dalc da.DataAvailabilityLayerClient) *FaultyDALC {
+	return &FaultyDALC{dalc: dalc}
+}
+
+// SetSubmitFailure makes all block submissions fail (if fail is true), or restores normal operation.
+func (f *FaultyDALC) SetSubmitFailure(fail bool) {
+	atomic.StoreInt32(&f.failSubmit, boolToInt32(fail))
+}
+
+// SetRetrieveFailure makes all block retrievals fail (if fail is true), or restores normal operation.
+func (f *FaultyDALC) SetRetrieveFailure(fail bool) {
+	atomic.StoreInt32(&f.failRetrieve, boolToInt32(fail))
+}
+
+// SetLatency delays every DA layer call by given latency plus random duration up to jitter, to simulate latency of
+// real DA layer. Zero values restore immediate calls.
+func (f *FaultyDALC) SetLatency(latency, jitter time.Duration) {
+	atomic.StoreInt64(&f.latency, int64(latency))
+	atomic.StoreInt64(&f.jitter, int64(jitter))
+}
+
+// delay sleeps for configured latency.
+func (f *FaultyDALC) delay() {
+	d := atomic.LoadInt64(&f.latency)
+	if jitter := atomic.LoadInt64(&f.jitter); jitter > 0 {
+		d += rand.Int63n(jitter)
+	}
+	if d > 0 {
+		time.Sleep(time.Duration(d))
+	}
+}
+
+// Init implements DataAvailabilityLayerClient interface.
+func (f *FaultyDALC) Init(config []byte, kvStore store.KVStore, logger log.Logger) error {
+	return f.dalc.Init(config, kvStore, logger)
+}
+
+// Start implements DataAvailabilityLayerClient interface.
+func (f *FaultyDALC) Start() error {
+	return f.dalc.Start()
+}
+
+// Stop implements DataAvailabilityLayerClient interface.
+func (f *FaultyDALC) Stop() error {
+	return f.dalc.Stop()
+}
+
+// SubmitBlock implements DataAvailabilityLayerClient interface.
+func (f *FaultyDALC) SubmitBlock(block *types.Block) da.ResultSubmitBlock {
+	f.delay()
+	if atomic.LoadInt32(&f.failSubmit) != 0 {
+		return da.ResultSubmitBlock{DAResult: da.DAResult{Code: da.StatusError, Message: "injected submission failure"}}
+	}
+	return f.dalc.SubmitBlock(block)
+}
+
+// CheckBlockAvailability implements DataAvailabilityLayerClient interface.
+func (f *FaultyDALC) CheckBlockAvailability(header *types.Header) da.ResultCheckBlock {
+	f.delay()
+	if atomic.LoadInt32(&f.failRetrieve) != 0 {
+		return da.ResultCheckBlock{DAResult: da.DAResult{Code: da.StatusError, Message: "injected retrieval failure"}}
+	}
+	return f.dalc.CheckBlockAvailability(header)
+}
+
+// RetrieveBlock implements BlockRetriever interface.
+func (f *FaultyDALC) RetrieveBlock(height uint64) da.ResultRetrieveBlock {
+	f.delay()
+	if atomic.LoadInt32(&f.failRetrieve) != 0 {
+		return da.ResultRetrieveBlock{DAResult: da.DAResult{Code: da.StatusError, Message: "injected retrieval failure"}}
+	}
+	return f.dalc.(da.BlockRetriever).RetrieveBlock(height)
+}
+
+func boolToInt32(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,218 @@
+// Package testutil contains helpers for integration tests, e.g. in-process devnet of connected nodes.
+package testutil
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/optimint/config"
+	"github.com/celestiaorg/optimint/da"
+	mockda "github.com/celestiaorg/optimint/da/mock"
+	"github.com/celestiaorg/optimint/node"
+	"github.com/celestiaorg/optimint/rpc/client"
+	"github.com/celestiaorg/optimint/store"
+)
+
+// DevnetConfig configures in-process devnet.
+type DevnetConfig struct {
+	// Nodes is the total number of nodes: 1 aggregator and Nodes-1 full nodes.
+	Nodes int
+	// BlockTime is the block time of aggregator.
+	BlockTime time.Duration
+	// ChainID is the chain ID used in genesis.
+	ChainID string
+	// Byzantine configures misbehaviour of aggregator.
+	Byzantine ByzantineConfig
+	// VerifyResults enables results verification on full nodes (see config.BlockManagerConfig).
+	VerifyResults bool
+	// MaxBlockBytes is the max size of block transactions, set in genesis consensus params (0 - default).
+	MaxBlockBytes int64
+}
+
+// DefaultDevnetConfig returns configuration of devnet with 1 aggregator and 2 full nodes.
+func DefaultDevnetConfig() DevnetConfig {
+	return DevnetConfig{
+		Nodes:     3,
+		BlockTime: 100 * time.Millisecond,
+		ChainID:   "devnet",
+	}
+}
+
+// Devnet is a set of in-process nodes of a single chain, connected with P2P network and sharing mock DA layer.
+// Node with index 0 is the aggregator (it may be byzantine, see ByzantineConfig).
+type Devnet struct {
+	t testing.TB
+
+	Genesis *tmtypes.GenesisDoc
+	Nodes   []*node.Node
+	Apps    []*kvstore.Application
+	Clients []*client.Client
+	// DA is the data availability layer client shared by all nodes, used to inject DA layer faults.
+	DA *FaultyDALC
+}
+
+// NewDevnet creates nodes of devnet. Nodes are connected to each other (every node is a seed of other nodes).
+func NewDevnet(t testing.TB, conf DevnetConfig) *Devnet {
+	t.Helper()
+	require := require.New(t)
+	require.Greater(conf.Nodes, 0, "devnet requires at least one node")
+
+	keys := make([]crypto.PrivKey, conf.Nodes)
+	addrs := make([]string, conf.Nodes)
+	for i := range keys {
+		var err error
+		keys[i], _, err = crypto.GenerateEd25519Key(rand.Reader)
+		require.NoError(err)
+		id, err := peer.IDFromPrivateKey(keys[i])
+		require.NoError(err)
+		addrs[i] = "/ip4/127.0.0.1/tcp/" + strconv.Itoa(freePort(t)) + "/p2p/" + id.Pretty()
+	}
+
+	rawKey, err := keys[0].GetPublic().Raw()
+	require.NoError(err)
+	pubKey := ed25519.PubKey(rawKey)
+	genesis := &tmtypes.GenesisDoc{
+		ChainID: conf.ChainID,
+		Validators: []tmtypes.GenesisValidator{{
+			Address: pubKey.Address(),
+			PubKey:  pubKey,
+			Power:   1,
+			Name:    "sequencer",
+		}},
+	}
+	if conf.MaxBlockBytes > 0 {
+		params := tmtypes.DefaultConsensusParams()
+		params.Block.MaxBytes = conf.MaxBlockBytes
+		if params.Evidence.MaxBytes > conf.MaxBlockBytes {
+			params.Evidence.MaxBytes = conf.MaxBlockBytes
+		}
+		genesis.ConsensusParams = params
+	}
+
+	mock := &mockda.MockDataAvailabilityLayerClient{}
+	require.NoError(mock.Init(nil, store.NewDefaultInMemoryKVStore(), log.TestingLogger()))
+	d := &Devnet{
+		t:       t,
+		Genesis: genesis,
+		DA:      NewFaultyDALC(mock),
+	}
+
+	for i := 0; i < conf.Nodes; i++ {
+		var seeds []string
+		for j, addr := range addrs {
+			if j != i {
+				seeds = append(seeds, addr)
+			}
+		}
+		nodeConf := config.NodeConfig{
+			P2P: config.P2PConfig{
+				ListenAddress: strings.SplitN(addrs[i], "/p2p/", 2)[0],
+				Seeds:         strings.Join(seeds, ","),
+			},
+			Aggregator: i == 0,
+			BlockManagerConfig: config.BlockManagerConfig{
+				BlockTime:          conf.BlockTime,
+				HeaderVerification: config.HeaderVerificationStrict,
+				VerifyResults:      conf.VerifyResults,
+			},
+		}
+		app := kvstore.NewApplication()
+		var abciApp abci.Application = app
+		var dalc da.DataAvailabilityLayerClient = d.DA
+		if i == 0 {
+			abciApp = &byzantineApp{Application: app, invalidAppHashAt: conf.Byzantine.InvalidAppHashAt}
+			dalc = &byzantineDALC{FaultyDALC: d.DA, conf: conf.Byzantine}
+		}
+		n, err := node.NewNode(context.Background(), nodeConf, keys[i], proxy.NewLocalClientCreator(abciApp), genesis,
+			log.TestingLogger().With("node", i), node.WithDALayerClient(dalc))
+		require.NoError(err)
+
+		d.Nodes = append(d.Nodes, n)
+		d.Apps = append(d.Apps, app)
+		d.Clients = append(d.Clients, client.NewClient(n))
+	}
+
+	return d
+}
+
+// Aggregator returns the aggregator node.
+func (d *Devnet) Aggregator() *node.Node {
+	return d.Nodes[0]
+}
+
+// Start starts all nodes (aggregator first). Nodes are stopped automatically at the end of the test.
+func (d *Devnet) Start() {
+	d.t.Helper()
+	for _, n := range d.Nodes {
+		require.NoError(d.t, n.Start())
+	}
+	d.t.Cleanup(d.Stop)
+}
+
+// Stop stops all running nodes.
+func (d *Devnet) Stop() {
+	for i := range d.Nodes {
+		d.StopNode(i)
+	}
+}
+
+// StopNode stops i-th node (e.g. to simulate node crash). Stopped node can't be started again.
+func (d *Devnet) StopNode(i int) {
+	if d.Nodes[i].IsRunning() {
+		_ = d.Nodes[i].Stop()
+	}
+}
+
+// WaitForHeight waits until i-th node has block at given height in its store.
+func (d *Devnet) WaitForHeight(i int, height uint64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		current := d.Nodes[i].Store.Height()
+		if current >= height {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("node %d: timeout waiting for height %d (current height: %d)", i, height, current)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// WaitForAllHeight waits until all running nodes have block at given height in their stores.
+func (d *Devnet) WaitForAllHeight(height uint64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for i, n := range d.Nodes {
+		if !n.IsRunning() {
+			continue
+		}
+		if err := d.WaitForHeight(i, height, time.Until(deadline)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// freePort returns TCP port that is currently not used on local interface.
+func freePort(t testing.TB) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
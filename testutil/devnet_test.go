@@ -0,0 +1,112 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/types"
+
+	optypes "github.com/celestiaorg/optimint/types"
+)
+
+func TestDevnet(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	d := NewDevnet(t, DefaultDevnetConfig())
+	d.Start()
+	require.NoError(d.WaitForAllHeight(3, 5*time.Second))
+
+	// transaction sent to full node is gossiped to aggregator and synced by all nodes
+	res, err := d.Clients[1].BroadcastTxSync(context.Background(), types.Tx("devnet=works"))
+	require.NoError(err)
+	require.EqualValues(0, res.Code)
+	for _, c := range d.Clients {
+		assert.Eventually(func() bool {
+			query, err := c.ABCIQuery(context.Background(), "", []byte("devnet"))
+			return err == nil && string(query.Response.Value) == "works"
+		}, 5*time.Second, 50*time.Millisecond)
+	}
+
+	// full nodes can't sync while DA layer is unavailable
+	d.DA.SetRetrieveFailure(true)
+	stalled := d.Nodes[1].Store.Height()
+	require.NoError(d.WaitForHeight(0, stalled+5, 5*time.Second))
+	assert.LessOrEqual(d.Nodes[1].Store.Height(), stalled+1)
+
+	// ... and catch up after recovery
+	d.DA.SetRetrieveFailure(false)
+	require.NoError(d.WaitForAllHeight(stalled+5, 5*time.Second))
+
+	d.StopNode(2)
+	assert.False(d.Nodes[2].IsRunning())
+	require.NoError(d.WaitForAllHeight(stalled+7, 5*time.Second))
+}
+
+func TestByzantineAggregator(t *testing.T) {
+	const faultHeight = 4
+
+	cases := []struct {
+		name string
+		conf ByzantineConfig
+		// height of last block accepted by full nodes; block is applied only when next block (with commit) is known
+		lastValid uint64
+	}{
+		{"skip DA submissions", ByzantineConfig{SkipDASubmissionsFrom: faultHeight}, faultHeight - 2},
+		{"equivocation", ByzantineConfig{EquivocateAt: faultHeight}, faultHeight - 1},
+		{"invalid app hash", ByzantineConfig{InvalidAppHashAt: faultHeight}, faultHeight},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require := require.New(t)
+
+			conf := DefaultDevnetConfig()
+			conf.Nodes = 2
+			conf.Byzantine = c.conf
+			d := NewDevnet(t, conf)
+			d.Start()
+
+			// aggregator is not affected by its own misbehaviour
+			require.NoError(d.WaitForHeight(0, faultHeight+5, 5*time.Second))
+			require.NoError(d.WaitForHeight(1, c.lastValid, 5*time.Second))
+			// give full node some time to (incorrectly) accept next block
+			time.Sleep(200 * time.Millisecond)
+			require.Equal(c.lastValid, d.Nodes[1].Store.Height())
+		})
+	}
+}
+
+func TestVerifyResults(t *testing.T) {
+	const faultHeight = 4
+	require := require.New(t)
+
+	conf := DefaultDevnetConfig()
+	conf.Nodes = 2
+	conf.Byzantine = ByzantineConfig{InvalidAppHashAt: faultHeight}
+	conf.VerifyResults = true
+	d := NewDevnet(t, conf)
+
+	sub, err := d.Nodes[1].EventBus().Subscribe(context.Background(), "test", optypes.EventQueryResultsMismatch)
+	require.NoError(err)
+	d.Start()
+
+	// mismatch is detected as soon as the block with invalid app hash is known
+	select {
+	case msg := <-sub.Out():
+		data, ok := msg.Data().(optypes.EventDataResultsMismatch)
+		require.True(ok)
+		require.EqualValues(faultHeight, data.Height)
+		require.NotEqual(data.ExpectedAppHash, data.AppHash)
+		require.Equal(data.ExpectedResultsHash, data.ResultsHash)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for results mismatch event")
+	}
+
+	// ... and syncing is halted
+	require.NoError(d.WaitForHeight(0, faultHeight+5, 5*time.Second))
+	require.EqualValues(faultHeight, d.Nodes[1].Store.Height())
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+
+	"github.com/celestiaorg/optimint/testutil/loadtest"
+)
+
+func main() {
+	conf := loadtest.DefaultConfig()
+	addr := flag.String("rpc", "http://127.0.0.1:26657", "RPC address of the node")
+	flag.IntVar(&conf.TxSize, "size", conf.TxSize, "transaction size in bytes")
+	flag.IntVar(&conf.Rate, "rate", conf.Rate, "transactions per second (0 - as fast as possible)")
+	flag.DurationVar(&conf.Duration, "duration", conf.Duration, "duration of load generation")
+	flag.IntVar(&conf.Concurrency, "concurrency", conf.Concurrency, "number of concurrent broadcasts")
+	flag.DurationVar(&conf.Drain, "drain", conf.Drain, "max time of waiting for inclusion after load generation")
+	flag.Int64Var(&conf.MaxBlockBytes, "max-block-bytes", conf.MaxBlockBytes,
+		"max size of block transactions, used to compute block fill ratio (0 - not reported)")
+	flag.Parse()
+
+	c, err := rpchttp.New(*addr, "/websocket")
+	if err != nil {
+		log.Panic(err)
+	}
+	if err := c.Start(); err != nil {
+		log.Panic(err)
+	}
+	defer func() {
+		_ = c.Stop()
+	}()
+
+	report, err := loadtest.Run(context.Background(), c, conf)
+	if err != nil {
+		log.Panic(err)
+	}
+	log.Println(report)
+}
@@ -0,0 +1,286 @@
+// Package loadtest floods a node with transactions and measures end-to-end throughput: transactions per second, block
+// fill ratio and latency of inclusion in blocks.
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	subscriber = "loadtest"
+	// blockEventsCapacity is the capacity of NewBlock subscription, large enough to not drop events while waiting for
+	// broadcasts.
+	blockEventsCapacity = 1000
+	// pollInterval is the interval of checking if all transactions were included, after load generation is finished.
+	pollInterval = 10 * time.Millisecond
+)
+
+// Client is a subset of RPC client methods used to generate load. It's implemented by the in-process client
+// (rpc/client) and by Tendermint HTTP client, so load can be generated in tests and against running nodes.
+type Client interface {
+	BroadcastTxSync(ctx context.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error)
+	Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (<-chan ctypes.ResultEvent, error)
+	Unsubscribe(ctx context.Context, subscriber, query string) error
+}
+
+// Config configures load generation.
+type Config struct {
+	// TxSize is the size of generated transactions in bytes. Transactions are in "key=value" format accepted by
+	// kvstore application, with unique keys, so they can't be smaller than the key.
+	TxSize int
+	// Rate is the number of transactions submitted per second (0 - as fast as possible).
+	Rate int
+	// Duration is the duration of load generation.
+	Duration time.Duration
+	// Concurrency is the number of concurrent broadcasts.
+	Concurrency int
+	// Drain is the max time of waiting for inclusion of submitted transactions, after load generation is finished.
+	Drain time.Duration
+	// MaxBlockBytes is the max size of block transactions, used to compute block fill ratio (0 - not reported).
+	MaxBlockBytes int64
+}
+
+// DefaultConfig returns configuration generating 1000 transactions of 256 bytes per second, for 10 seconds.
+func DefaultConfig() Config {
+	return Config{
+		TxSize:      256,
+		Rate:        1000,
+		Duration:    10 * time.Second,
+		Concurrency: 16,
+		Drain:       10 * time.Second,
+	}
+}
+
+// Report contains results of load generation.
+type Report struct {
+	// Submitted is the number of broadcasted transactions, Rejected of them were rejected by CheckTx (or broadcast
+	// failed).
+	Submitted int
+	Rejected  int
+	// Included is the number of accepted transactions included in blocks, Pending were not included before drain
+	// timeout.
+	Included int
+	Pending  int
+	// Elapsed is the time from the first broadcast until the last inclusion.
+	Elapsed time.Duration
+	// TPS is the number of included transactions per second.
+	TPS float64
+	// Blocks is the number of blocks produced during the test.
+	Blocks int
+	// BlockFillRatio is the average ratio of block transactions size to Config.MaxBlockBytes.
+	BlockFillRatio float64
+	// LatencyP50 and LatencyP99 are percentiles of latency between broadcast and observed inclusion in a block.
+	LatencyP50 time.Duration
+	LatencyP99 time.Duration
+}
+
+func (r *Report) String() string {
+	return fmt.Sprintf("submitted=%d rejected=%d included=%d pending=%d elapsed=%s tps=%.1f blocks=%d fill=%.1f%% latency_p50=%s latency_p99=%s",
+		r.Submitted, r.Rejected, r.Included, r.Pending, r.Elapsed, r.TPS, r.Blocks, 100*r.BlockFillRatio, r.LatencyP50,
+		r.LatencyP99)
+}
+
+// run keeps state of a single load generation.
+type run struct {
+	conf   Config
+	prefix string
+	start  time.Time
+
+	mtx           sync.Mutex
+	pending       map[string]time.Time
+	submitted     int
+	rejected      int
+	latencies     []time.Duration
+	lastInclusion time.Time
+	blocks        int
+	fill          float64
+}
+
+// Run generates load according to configuration, and waits for inclusion of submitted transactions.
+func Run(ctx context.Context, c Client, conf Config) (*Report, error) {
+	if conf.Duration <= 0 || conf.Concurrency <= 0 || conf.Rate < 0 || conf.TxSize <= 0 {
+		return nil, errors.New("duration, concurrency and transaction size must be positive, rate can't be negative")
+	}
+
+	query := tmtypes.EventQueryNewBlock.String()
+	blocks, err := c.Subscribe(ctx, subscriber, query, blockEventsCapacity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to blocks: %w", err)
+	}
+	defer func() {
+		_ = c.Unsubscribe(context.Background(), subscriber, query)
+	}()
+
+	r := &run{
+		conf:    conf,
+		prefix:  fmt.Sprintf("load-%d-", time.Now().UnixNano()),
+		start:   time.Now(),
+		pending: make(map[string]time.Time),
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go r.observe(ctx, blocks)
+
+	r.generate(ctx, c)
+
+	drain := time.NewTimer(conf.Drain)
+	defer drain.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for r.numPending() > 0 {
+		select {
+		case <-ticker.C:
+		case <-drain.C:
+			return r.report(), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return r.report(), nil
+}
+
+// generate broadcasts transactions at configured rate, until configured duration elapses.
+func (r *run) generate(ctx context.Context, c Client) {
+	seqs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < r.conf.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for seq := range seqs {
+				r.broadcast(ctx, c, seq)
+			}
+		}()
+	}
+
+	end := r.start.Add(r.conf.Duration)
+	for seq := 0; ; seq++ {
+		next := time.Now()
+		if r.conf.Rate > 0 {
+			next = r.start.Add(time.Duration(seq) * time.Second / time.Duration(r.conf.Rate))
+		}
+		if !next.Before(end) {
+			break
+		}
+		time.Sleep(time.Until(next))
+		select {
+		case seqs <- seq:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil || time.Now().After(end) {
+			break
+		}
+	}
+	close(seqs)
+	wg.Wait()
+}
+
+func (r *run) broadcast(ctx context.Context, c Client, seq int) {
+	tx := r.tx(seq)
+	r.mtx.Lock()
+	r.pending[string(tx)] = time.Now()
+	r.submitted++
+	r.mtx.Unlock()
+
+	res, err := c.BroadcastTxSync(ctx, tx)
+	if err != nil || res.Code != 0 {
+		r.mtx.Lock()
+		delete(r.pending, string(tx))
+		r.rejected++
+		r.mtx.Unlock()
+	}
+}
+
+// tx returns unique transaction of configured size.
+func (r *run) tx(seq int) tmtypes.Tx {
+	tx := fmt.Sprintf("%s%d=", r.prefix, seq)
+	if len(tx) < r.conf.TxSize {
+		tx += strings.Repeat("x", r.conf.TxSize-len(tx))
+	}
+	return tmtypes.Tx(tx)
+}
+
+// observe records inclusion of transactions in blocks.
+func (r *run) observe(ctx context.Context, blocks <-chan ctypes.ResultEvent) {
+	for {
+		select {
+		case ev := <-blocks:
+			data, ok := ev.Data.(tmtypes.EventDataNewBlock)
+			if !ok || data.Block == nil {
+				continue
+			}
+			r.blockIncluded(data.Block, time.Now())
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *run) blockIncluded(block *tmtypes.Block, now time.Time) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.blocks++
+	if r.conf.MaxBlockBytes > 0 {
+		r.fill += float64(tmtypes.ComputeProtoSizeForTxs(block.Txs)) / float64(r.conf.MaxBlockBytes)
+	}
+	for _, tx := range block.Txs {
+		submitted, ok := r.pending[string(tx)]
+		if !ok {
+			continue
+		}
+		delete(r.pending, string(tx))
+		r.latencies = append(r.latencies, now.Sub(submitted))
+		r.lastInclusion = now
+	}
+}
+
+func (r *run) numPending() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return len(r.pending)
+}
+
+func (r *run) report() *Report {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	rep := &Report{
+		Submitted: r.submitted,
+		Rejected:  r.rejected,
+		Included:  len(r.latencies),
+		Pending:   len(r.pending),
+		Blocks:    r.blocks,
+	}
+	if !r.lastInclusion.IsZero() {
+		rep.Elapsed = r.lastInclusion.Sub(r.start)
+		rep.TPS = float64(rep.Included) / rep.Elapsed.Seconds()
+	}
+	if r.blocks > 0 {
+		rep.BlockFillRatio = r.fill / float64(r.blocks)
+	}
+	latencies := append([]time.Duration{}, r.latencies...)
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	rep.LatencyP50 = percentile(latencies, 0.50)
+	rep.LatencyP99 = percentile(latencies, 0.99)
+	return rep
+}
+
+// percentile returns p-th percentile of sorted durations (0 if there are none).
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(math.Ceil(p*float64(len(sorted))))-1]
+}
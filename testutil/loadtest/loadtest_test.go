@@ -0,0 +1,94 @@
+package loadtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/optimint/testutil"
+)
+
+const maxBlockBytes = 64 * 1024
+
+func TestRun(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	d := newDevnet(t)
+	conf := Config{
+		TxSize:        128,
+		Rate:          200,
+		Duration:      time.Second,
+		Concurrency:   4,
+		Drain:         5 * time.Second,
+		MaxBlockBytes: maxBlockBytes,
+	}
+	report, err := Run(context.Background(), d.Clients[0], conf)
+	require.NoError(err)
+	t.Log(report)
+
+	assert.InDelta(200, report.Submitted, 20)
+	assert.Zero(report.Rejected)
+	assert.Zero(report.Pending)
+	assert.Equal(report.Submitted, report.Included)
+	assert.Greater(report.TPS, 0.0)
+	assert.Greater(report.Blocks, 0)
+	assert.Greater(report.BlockFillRatio, 0.0)
+	assert.Less(report.BlockFillRatio, 1.0)
+	assert.LessOrEqual(report.LatencyP50, report.LatencyP99)
+	// DA submission latency is not on the path of transaction inclusion in aggregator blocks
+	assert.Less(report.LatencyP99, 2*time.Second)
+
+	_, err = Run(context.Background(), d.Clients[0], Config{})
+	assert.Error(err)
+}
+
+func TestPercentile(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Zero(percentile(nil, 0.99))
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i + 1)
+	}
+	assert.EqualValues(50, percentile(sorted, 0.50))
+	assert.EqualValues(99, percentile(sorted, 0.99))
+	assert.EqualValues(1, percentile(sorted[:1], 0.99))
+}
+
+// BenchmarkThroughput measures end-to-end throughput of a single aggregator, with DA layer latency. Results are
+// reported as custom metrics, so regressions are visible in benchmark comparisons (e.g. with benchstat).
+func BenchmarkThroughput(b *testing.B) {
+	d := newDevnet(b)
+	conf := DefaultConfig()
+	conf.Rate = 0
+	conf.Duration = 2 * time.Second
+	conf.MaxBlockBytes = maxBlockBytes
+
+	b.ResetTimer()
+	var tps, fill, p99 float64
+	for i := 0; i < b.N; i++ {
+		report, err := Run(context.Background(), d.Clients[0], conf)
+		require.NoError(b, err)
+		tps += report.TPS
+		fill += report.BlockFillRatio
+		p99 += float64(report.LatencyP99) / float64(time.Millisecond)
+	}
+	b.ReportMetric(tps/float64(b.N), "tx/s")
+	b.ReportMetric(fill/float64(b.N), "fill")
+	b.ReportMetric(p99/float64(b.N), "p99-ms")
+}
+
+func newDevnet(t testing.TB) *testutil.Devnet {
+	conf := testutil.DefaultDevnetConfig()
+	conf.Nodes = 1
+	conf.MaxBlockBytes = maxBlockBytes
+	d := testutil.NewDevnet(t, conf)
+	d.DA.SetLatency(50*time.Millisecond, 50*time.Millisecond)
+	d.Start()
+	require.NoError(t, d.WaitForHeight(0, 2, 5*time.Second))
+	return d
+}
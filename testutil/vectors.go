@@ -0,0 +1,188 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	"github.com/celestiaorg/optimint/types"
+)
+
+// Parameters of the scenario used to generate golden vectors. Changing any of them changes generated vectors.
+const (
+	vectorsChainID   = "optimint-golden"
+	vectorsKeySecret = "optimint golden vectors"
+	vectorsStartTime = 1640995200
+)
+
+var (
+	vectorsNamespaceID = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+	// vectorsTxs are transactions included in subsequent blocks of the scenario (empty block included).
+	vectorsTxs = [][]string{
+		{"alice=1", "bob=2"},
+		{},
+		{"carol=3"},
+		{"alice=4", "dave=5", "erin=6"},
+	}
+)
+
+// Vectors are golden vectors - known-good outputs of optimint for a scripted scenario. They can be used to test
+// alternate implementations of clients and bridge contracts, without running optimint.
+type Vectors struct {
+	ChainID     string
+	NamespaceID tmbytes.HexBytes
+	// ProposerPubKey is the ed25519 public key of the sequencer signing all blocks.
+	ProposerPubKey  tmbytes.HexBytes
+	ProposerAddress tmbytes.HexBytes
+	Blocks          []BlockVector
+}
+
+// BlockVector contains serialized forms and hashes of a single block of the scenario.
+type BlockVector struct {
+	Height uint64
+	// Header is the binary form of header; it's also the message signed by the sequencer.
+	Header     tmbytes.HexBytes
+	HeaderHash tmbytes.HexBytes
+	Data       tmbytes.HexBytes
+	DataHash   tmbytes.HexBytes
+	// Commit is the binary form of commit for this block (included as LastCommit in the next block).
+	Commit       tmbytes.HexBytes
+	CommitHash   tmbytes.HexBytes
+	SignedHeader tmbytes.HexBytes
+	Block        tmbytes.HexBytes
+	// DABlob is the blob submitted to Data Availability Layer.
+	DABlob tmbytes.HexBytes
+}
+
+// GenerateVectors deterministically generates golden vectors. Chain of blocks is created and signed exactly like by
+// aggregator, using fixed key, timestamps and transactions.
+func GenerateVectors() (*Vectors, error) {
+	key := ed25519.GenPrivKeyFromSecret([]byte(vectorsKeySecret))
+	pubKey := key.PubKey()
+	v := &Vectors{
+		ChainID:         vectorsChainID,
+		NamespaceID:     vectorsNamespaceID[:],
+		ProposerPubKey:  pubKey.Bytes(),
+		ProposerAddress: pubKey.Address(),
+	}
+
+	// first block has no previous commit
+	lastCommit := &types.Commit{}
+	var lastHeaderHash [32]byte
+	for i, txs := range vectorsTxs {
+		height := uint64(i + 1)
+		block := &types.Block{
+			Header: types.Header{
+				Version:         types.Version{Block: 11, App: 1},
+				NamespaceID:     vectorsNamespaceID,
+				Height:          height,
+				Time:            vectorsStartTime + height,
+				LastHeaderHash:  lastHeaderHash,
+				LastCommitHash:  lastCommit.Hash(),
+				AppHash:         fillHash(byte(height)),
+				LastResultsHash: fillHash(byte(0x80 + height)),
+				ProposerAddress: pubKey.Address(),
+			},
+			LastCommit: *lastCommit,
+		}
+		for _, tx := range txs {
+			block.Data.Txs = append(block.Data.Txs, types.Tx(tx))
+		}
+		block.Header.DataHash = block.Data.Hash()
+
+		headerBytes, err := block.Header.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		sig, err := key.Sign(headerBytes)
+		if err != nil {
+			return nil, err
+		}
+		commit := &types.Commit{
+			Height:     height,
+			HeaderHash: block.Header.Hash(),
+			Signatures: []types.Signature{sig},
+		}
+
+		bv, err := blockVector(block, commit)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", height, err)
+		}
+		v.Blocks = append(v.Blocks, bv)
+
+		lastCommit = commit
+		lastHeaderHash = commit.HeaderHash
+	}
+	return v, nil
+}
+
+// WriteJSON writes vectors to w as indented JSON; binary values are hex-encoded.
+func (v *Vectors) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func blockVector(block *types.Block, commit *types.Commit) (BlockVector, error) {
+	if err := block.ValidateBasic(); err != nil {
+		return BlockVector{}, err
+	}
+	signedHeader := &types.SignedHeader{Header: block.Header, Commit: *commit}
+	if err := signedHeader.ValidateBasic(); err != nil {
+		return BlockVector{}, err
+	}
+
+	header, err := block.Header.MarshalBinary()
+	if err != nil {
+		return BlockVector{}, err
+	}
+	data, err := block.Data.MarshalBinary()
+	if err != nil {
+		return BlockVector{}, err
+	}
+	commitBytes, err := commit.MarshalBinary()
+	if err != nil {
+		return BlockVector{}, err
+	}
+	signedHeaderBytes, err := signedHeader.MarshalBinary()
+	if err != nil {
+		return BlockVector{}, err
+	}
+	blockBytes, err := block.MarshalBinary()
+	if err != nil {
+		return BlockVector{}, err
+	}
+	blob, err := block.MarshalBlob()
+	if err != nil {
+		return BlockVector{}, err
+	}
+	defer blob.Release()
+
+	headerHash := block.Header.Hash()
+	dataHash := block.Data.Hash()
+	commitHash := commit.Hash()
+	return BlockVector{
+		Height:       block.Header.Height,
+		Header:       header,
+		HeaderHash:   headerHash[:],
+		Data:         data,
+		DataHash:     dataHash[:],
+		Commit:       commitBytes,
+		CommitHash:   commitHash[:],
+		SignedHeader: signedHeaderBytes,
+		Block:        blockBytes,
+		DABlob:       append([]byte(nil), blob.Bytes()...),
+	}, nil
+}
+
+func fillHash(b byte) [32]byte {
+	var h [32]byte
+	for i := range h {
+		h[i] = b
+	}
+	return h
+}
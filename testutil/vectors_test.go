@@ -0,0 +1,97 @@
+package testutil
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	optypes "github.com/celestiaorg/optimint/types"
+)
+
+var updateVectors = flag.Bool("update-vectors", false, "regenerate golden vectors in testdata")
+
+var vectorsFile = filepath.Join("testdata", "golden_vectors.json")
+
+// any change of golden vectors is a breaking change for external implementations; regenerate with -update-vectors
+func TestGoldenVectors(t *testing.T) {
+	t.Parallel()
+
+	v, err := GenerateVectors()
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, v.WriteJSON(&buf))
+
+	if *updateVectors {
+		require.NoError(t, os.MkdirAll(filepath.Dir(vectorsFile), 0755))
+		require.NoError(t, os.WriteFile(vectorsFile, buf.Bytes(), 0644)) //nolint:gosec
+	}
+	expected, err := os.ReadFile(vectorsFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), buf.String())
+
+	again, err := GenerateVectors()
+	require.NoError(t, err)
+	assert.Equal(t, v, again)
+}
+
+func TestGoldenVectorsConsistency(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	v, err := GenerateVectors()
+	require.NoError(err)
+	require.NotEmpty(v.Blocks)
+	pubKey := ed25519.PubKey(v.ProposerPubKey)
+	assert.Equal([]byte(pubKey.Address()), []byte(v.ProposerAddress))
+
+	var lastHeaderHash []byte
+	var lastCommit []byte
+	for i, bv := range v.Blocks {
+		assert.Equal(uint64(i+1), bv.Height)
+
+		block := &optypes.Block{}
+		require.NoError(optypes.UnmarshalCanonical(bv.Block, block))
+		assert.Equal([]byte(bv.Block), []byte(bv.DABlob))
+		assert.Equal([]byte(v.NamespaceID), block.Header.NamespaceID[:])
+
+		header, err := block.Header.MarshalBinary()
+		require.NoError(err)
+		assert.Equal([]byte(bv.Header), header)
+		data, err := block.Data.MarshalBinary()
+		require.NoError(err)
+		assert.Equal([]byte(bv.Data), data)
+
+		headerHash := block.Header.Hash()
+		dataHash := block.Data.Hash()
+		assert.Equal([]byte(bv.HeaderHash), headerHash[:])
+		assert.Equal([]byte(bv.DataHash), dataHash[:])
+		assert.Equal([]byte(bv.DataHash), block.Header.DataHash[:])
+
+		signedHeader := &optypes.SignedHeader{}
+		require.NoError(optypes.UnmarshalCanonical(bv.SignedHeader, signedHeader))
+		require.NoError(signedHeader.ValidateBasic())
+		require.NoError(signedHeader.VerifySignature(pubKey))
+		commit, err := signedHeader.Commit.MarshalBinary()
+		require.NoError(err)
+		assert.Equal([]byte(bv.Commit), commit)
+		commitHash := signedHeader.Commit.Hash()
+		assert.Equal([]byte(bv.CommitHash), commitHash[:])
+
+		// blocks are linked by header hashes and commits
+		if i > 0 {
+			assert.Equal(lastHeaderHash, block.Header.LastHeaderHash[:])
+			lastCommitBytes, err := block.LastCommit.MarshalBinary()
+			require.NoError(err)
+			assert.Equal(lastCommit, lastCommitBytes)
+		}
+		lastHeaderHash = bv.HeaderHash
+		lastCommit = bv.Commit
+	}
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"sync"
+)
+
+// maxPooledBlobSize is the capacity above which buffers are not returned to the pool, so that a single huge block
+// doesn't pin lots of memory for the lifetime of the process.
+const maxPooledBlobSize = 16 << 20
+
+var blobPool = sync.Pool{
+	New: func() interface{} {
+		return &Blob{}
+	},
+}
+
+// Blob is a binary form of a Block (or its Data), backed by a buffer from a pool shared by all blocks.
+//
+// Reusing buffers reduces GC pressure when large blocks are produced at short block times.
+// Blob must be released when it's no longer needed, and its bytes must not be accessed after release.
+type Blob struct {
+	buf []byte
+}
+
+// Bytes returns binary form of the object. Returned slice is valid only until Release is called.
+func (bl *Blob) Bytes() []byte {
+	return bl.buf
+}
+
+// Release returns buffer to the pool. It's safe to call Release on nil Blob.
+func (bl *Blob) Release() {
+	if bl == nil {
+		return
+	}
+	if cap(bl.buf) > maxPooledBlobSize {
+		bl.buf = nil
+	}
+	bl.buf = bl.buf[:0]
+	blobPool.Put(bl)
+}
+
+// MarshalBlob encodes Block into binary form (exactly like MarshalBinary), using buffer from the pool.
+// Caller is responsible for releasing returned Blob.
+func (b *Block) MarshalBlob() (*Blob, error) {
+	return marshalBlob(b.ToProto())
+}
+
+// MarshalBlob encodes Data into binary form (exactly like MarshalBinary), using buffer from the pool.
+// Caller is responsible for releasing returned Blob.
+func (d *Data) MarshalBlob() (*Blob, error) {
+	return marshalBlob(d.ToProto())
+}
+
+func marshalBlob(msg interface {
+	Size() int
+	MarshalTo([]byte) (int, error)
+}) (*Blob, error) {
+	size := msg.Size()
+
+	bl := blobPool.Get().(*Blob)
+	if cap(bl.buf) < size {
+		bl.buf = make([]byte, size)
+	}
+	bl.buf = bl.buf[:size]
+	n, err := msg.MarshalTo(bl.buf)
+	if err != nil {
+		bl.Release()
+		return nil, err
+	}
+	bl.buf = bl.buf[:n]
+	return bl, nil
+}
+
+// Size returns length of binary form of the Block, without serializing it.
+func (b *Block) Size() int {
+	return b.ToProto().Size()
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalBlob(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// last block reuses bigger buffer released by previous iteration
+	for _, block := range []*Block{{}, getRandomBlockWithTxs(10, 100), getRandomBlockWithTxs(1000, 1000), {}} {
+		expected, err := block.MarshalBinary()
+		require.NoError(err)
+		assert.Equal(len(expected), block.Size())
+
+		blob, err := block.MarshalBlob()
+		require.NoError(err)
+		assert.Equal(expected, blob.Bytes())
+
+		var decoded Block
+		require.NoError(decoded.UnmarshalBinary(blob.Bytes()))
+		blob.Release()
+
+		expected, err = block.Data.MarshalBinary()
+		require.NoError(err)
+		blob, err = block.Data.MarshalBlob()
+		require.NoError(err)
+		assert.Equal(expected, blob.Bytes())
+		blob.Release()
+	}
+
+	// releasing nil blob is a no-op
+	var blob *Blob
+	blob.Release()
+}
+
+func BenchmarkBlockMarshalBinary(b *testing.B) {
+	block := getRandomBlockWithTxs(1000, 1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := block.MarshalBinary(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBlockMarshalBlob(b *testing.B) {
+	block := getRandomBlockWithTxs(1000, 1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		blob, err := block.MarshalBlob()
+		if err != nil {
+			b.Fatal(err)
+		}
+		blob.Release()
+	}
+}
+
+func getRandomBlockWithTxs(n, size int) *Block {
+	block := &Block{
+		Header: Header{Height: 1, ProposerAddress: []byte{1, 2, 3}},
+		Data:   Data{Txs: make(Txs, n)},
+	}
+	for i := range block.Data.Txs {
+		tx := make(Tx, size)
+		_, _ = rand.Read(tx)
+		block.Data.Txs[i] = tx
+	}
+	block.LastCommit.Signatures = []Signature{make([]byte, 64)}
+	return block
+}
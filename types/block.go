@@ -59,11 +59,22 @@ type Block struct {
 var _ encoding.BinaryMarshaler = &Block{}
 var _ encoding.BinaryUnmarshaler = &Block{}
 
+// BlockMeta contains header and size of the block, so that they can be loaded without decoding block data.
+type BlockMeta struct {
+	Header Header
+	// Size is the length of binary form of the block.
+	Size   int
+	NumTxs int
+}
+
 // Data defines Optimint block data.
 type Data struct {
 	Txs                    Txs
 	IntermediateStateRoots IntermediateStateRoots
 	Evidence               EvidenceData
+	// TxSequences contains first-come-first-served sequence numbers of transactions (see OrderingReceipt), one per
+	// transaction (0 for transactions without sequence number). It's empty if FCFS ordering is disabled.
+	TxSequences []uint64
 }
 
 // EvidenceData defines how evidence is stored in block.
@@ -78,6 +89,17 @@ type Commit struct {
 	Signatures []Signature // most of the time this is a single signature
 }
 
+// SignedHeader combines Header and its Commit.
+//
+// Used mostly for gossiping.
+type SignedHeader struct {
+	Header Header
+	Commit Commit
+}
+
+var _ encoding.BinaryMarshaler = &SignedHeader{}
+var _ encoding.BinaryUnmarshaler = &SignedHeader{}
+
 // Signature represents signature of block creator.
 type Signature []byte
 
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+)
+
+// ErrNonCanonical is returned if data is a valid encoding of a value, but not its canonical encoding.
+var ErrNonCanonical = errors.New("non-canonical encoding")
+
+// BinaryCodec is implemented by types with canonical binary encoding (Header, Data, Commit, Block and SignedHeader).
+//
+// Canonical encoding is the protobuf encoding produced by MarshalBinary: fields are written in field number order,
+// fields with default values are omitted, and there are no unknown fields. Hashed structures don't contain maps.
+// Empty slices are decoded as nil, so nil is the canonical representation of empty slices.
+type BinaryCodec interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// UnmarshalCanonical decodes data into v, and checks if data is the canonical encoding of decoded value, i.e. that
+// encoding the value again yields exactly the same bytes. Encodings with unknown or duplicated fields, fields out of
+// order, explicitly encoded default values or non-minimal varints are decoded by protobuf into the same value, but
+// they are rejected with ErrNonCanonical, so that bytes received from other implementations can be hashed and signed
+// consistently.
+func UnmarshalCanonical(data []byte, v BinaryCodec) error {
+	if err := v.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	canonical, err := v.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(data, canonical) {
+		return ErrNonCanonical
+	}
+	return nil
+}
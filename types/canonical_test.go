@@ -0,0 +1,158 @@
+package types
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// number of random values checked by randomized tests
+const canonicalIterations = 500
+
+func TestCanonicalRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1)) //nolint:gosec
+	for i := 0; i < canonicalIterations; i++ {
+		block := randomBlock(rnd)
+		signedHeader := &SignedHeader{Header: block.Header, Commit: block.LastCommit}
+
+		cases := []struct {
+			name    string
+			input   BinaryCodec
+			decoded BinaryCodec
+		}{
+			{"header", &block.Header, &Header{}},
+			{"data", &block.Data, &Data{}},
+			{"commit", &block.LastCommit, &Commit{}},
+			{"block", block, &Block{}},
+			{"signed header", signedHeader, &SignedHeader{}},
+		}
+		for _, c := range cases {
+			blob, err := c.input.MarshalBinary()
+			require.NoError(t, err, c.name)
+			require.NoError(t, UnmarshalCanonical(blob, c.decoded), c.name)
+			require.Equal(t, c.input, c.decoded, c.name)
+
+			again, err := c.decoded.MarshalBinary()
+			require.NoError(t, err, c.name)
+			require.Equal(t, blob, again, c.name)
+		}
+
+		decoded := &Block{}
+		blob, err := block.MarshalBinary()
+		require.NoError(t, err)
+		require.NoError(t, decoded.UnmarshalBinary(blob))
+		assert.Equal(t, block.Hash(), decoded.Hash())
+		assert.Equal(t, block.Data.Hash(), decoded.Data.Hash())
+		assert.Equal(t, block.LastCommit.Hash(), decoded.LastCommit.Hash())
+	}
+}
+
+func TestNonCanonicalEncoding(t *testing.T) {
+	t.Parallel()
+
+	commit := &Commit{Height: 1, HeaderHash: fill(0x01), Signatures: []Signature{{1, 2, 3}}}
+	blob, err := commit.MarshalBinary()
+	require.NoError(t, err)
+
+	// commit fields: height (1, varint), header_hash (2, bytes), signatures (3, repeated bytes)
+	hash := fill(0x01)
+	headerHash := append([]byte{0x12, 32}, hash[:]...)
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"unknown field", append(append([]byte{}, blob...), 0x78, 0x01)},
+		{"duplicated field", append([]byte{0x08, 0x01}, blob...)},
+		{"fields out of order", append(append(append([]byte{}, headerHash...), 0x08, 0x01), 0x1a, 3, 1, 2, 3)},
+		{"non-minimal varint", append([]byte{0x08, 0x81, 0x00}, blob[2:]...)},
+		{"explicit default value", append(append([]byte{}, blob...), 0x08, 0x00, 0x08, 0x01)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			decoded := &Commit{}
+			require.NoError(t, decoded.UnmarshalBinary(c.data))
+			assert.Equal(t, commit, decoded)
+			assert.ErrorIs(t, UnmarshalCanonical(c.data, &Commit{}), ErrNonCanonical)
+		})
+	}
+	assert.NoError(t, UnmarshalCanonical(blob, &Commit{}))
+}
+
+// decoding of random bytes can fail, but it can't panic
+func TestUnmarshalRandomBytes(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(2)) //nolint:gosec
+	for i := 0; i < canonicalIterations; i++ {
+		data := randomBytes(rnd, rnd.Intn(256))
+		for _, v := range []BinaryCodec{&Header{}, &Data{}, &Commit{}, &Block{}, &SignedHeader{}} {
+			assert.NotPanics(t, func() { _ = UnmarshalCanonical(data, v) })
+		}
+	}
+
+	// valid block with single random byte flipped
+	for i := 0; i < canonicalIterations; i++ {
+		blob, err := randomBlock(rnd).MarshalBinary()
+		require.NoError(t, err)
+		blob[rnd.Intn(len(blob))] ^= byte(1 + rnd.Intn(255))
+		assert.NotPanics(t, func() { _ = UnmarshalCanonical(blob, &Block{}) })
+	}
+}
+
+// randomBlock returns a block with random values, in canonical form (empty slices are nil).
+func randomBlock(rnd *rand.Rand) *Block {
+	block := &Block{
+		Header: Header{
+			Version:         Version{Block: rnd.Uint64(), App: rnd.Uint64()},
+			Height:          rnd.Uint64(),
+			Time:            rnd.Uint64(),
+			LastHeaderHash:  randomHash(rnd),
+			LastCommitHash:  randomHash(rnd),
+			DataHash:        randomHash(rnd),
+			ConsensusHash:   randomHash(rnd),
+			AppHash:         randomHash(rnd),
+			LastResultsHash: randomHash(rnd),
+			ProposerAddress: randomBytes(rnd, rnd.Intn(3)*10),
+		},
+		LastCommit: Commit{
+			Height:     rnd.Uint64(),
+			HeaderHash: randomHash(rnd),
+		},
+	}
+	rnd.Read(block.Header.NamespaceID[:])
+	for i := rnd.Intn(4); i > 0; i-- {
+		block.Data.Txs = append(block.Data.Txs, randomBytes(rnd, 1+rnd.Intn(100)))
+	}
+	if len(block.Data.Txs) > 0 && rnd.Intn(2) == 0 {
+		for range block.Data.Txs {
+			block.Data.TxSequences = append(block.Data.TxSequences, rnd.Uint64())
+		}
+	}
+	for i := rnd.Intn(3); i > 0; i-- {
+		block.Data.IntermediateStateRoots.RawRootsList = append(block.Data.IntermediateStateRoots.RawRootsList, randomBytes(rnd, 32))
+	}
+	for i := rnd.Intn(3); i > 0; i-- {
+		block.LastCommit.Signatures = append(block.LastCommit.Signatures, randomBytes(rnd, 64))
+	}
+	return block
+}
+
+func randomHash(rnd *rand.Rand) [32]byte {
+	var h [32]byte
+	rnd.Read(h[:])
+	return h
+}
+
+func randomBytes(rnd *rand.Rand, n int) []byte {
+	if n == 0 {
+		return nil
+	}
+	b := make([]byte, n)
+	rnd.Read(b)
+	return b
+}
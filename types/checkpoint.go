@@ -0,0 +1,49 @@
+package types
+
+import (
+	"errors"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+)
+
+// checkpointSignPrefix separates checkpoint signatures from signatures of other messages.
+const checkpointSignPrefix = "optimint/checkpoint"
+
+// Checkpoint is a summary of the chain state after block at given height, signed by the sequencer. Checkpoints are
+// produced periodically, so external verifiers can track the chain without following every block.
+type Checkpoint struct {
+	ChainID string
+	// Height is the height of the last block covered by the checkpoint.
+	Height uint64
+	// BlockHash is the hash of the block at Height.
+	BlockHash [32]byte
+	// AppHash is the application state root after executing the block at Height.
+	AppHash [32]byte
+	// DAHeight is the height of DA layer block containing the block at Height.
+	DAHeight  uint64
+	Signature []byte
+}
+
+// SignBytes returns the bytes signed by the sequencer.
+func (c *Checkpoint) SignBytes() []byte {
+	b := make([]byte, 0, len(checkpointSignPrefix)+len(c.ChainID)+88)
+	b = append(b, checkpointSignPrefix...)
+	b = append(b, encodeUint64(uint64(len(c.ChainID)))...)
+	b = append(b, c.ChainID...)
+	b = append(b, encodeUint64(c.Height)...)
+	b = append(b, c.BlockHash[:]...)
+	b = append(b, c.AppHash[:]...)
+	b = append(b, encodeUint64(c.DAHeight)...)
+	return b
+}
+
+// VerifySignature checks if checkpoint was signed by the owner of given public key.
+func (c *Checkpoint) VerifySignature(pubKey tmcrypto.PubKey) error {
+	if c.Height == 0 {
+		return errors.New("checkpoint without height")
+	}
+	if !pubKey.VerifySignature(c.SignBytes(), c.Signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package types
+
+// DAConfirmation is the result of verification of block inclusion in Data Availability Layer.
+type DAConfirmation string
+
+const (
+	// DAConfirmationConfirmed means that block data was confirmed to be available in DA layer.
+	DAConfirmationConfirmed DAConfirmation = "confirmed"
+	// DAConfirmationUnavailable means that block was reported as included in DA layer, but it's not available there.
+	DAConfirmationUnavailable DAConfirmation = "unavailable"
+)
+
+// DAInfo contains information about inclusion of a block in Data Availability Layer.
+type DAInfo struct {
+	// DAHeight is the height of DA layer block containing the block.
+	DAHeight uint64
+	// DAHash is the hash of DA layer block containing the block (empty if it's not known yet).
+	DAHash []byte `json:",omitempty"`
+	// TxHash is the hash of DA layer transaction that submitted the block (if known).
+	TxHash []byte
+	// Fee is the fee paid for block submission, in DA layer units (if known).
+	Fee uint64 `json:",omitempty"`
+	// Commitment is the commitment to block data (header hash) checked in DA layer.
+	Commitment []byte `json:",omitempty"`
+	// Confirmation is the result of inclusion verification (empty if block wasn't verified yet).
+	Confirmation DAConfirmation `json:",omitempty"`
+}
+
+// DASpend contains cumulative cost of block submissions to Data Availability Layer.
+type DASpend struct {
+	// TotalFee is the sum of fees paid for all block submissions (including re-submissions), in DA layer units.
+	TotalFee uint64
+	// Submissions is the number of successful block submissions.
+	Submissions uint64
+}
+
+// SyncState is the progress of block syncing and submission to Data Availability Layer. It's persisted, so that
+// restarted node resumes exactly where it left off.
+type SyncState struct {
+	// DAHeight is the height of the latest DA layer block containing a block processed by the node.
+	DAHeight uint64
+	// Height is the height of the last applied block.
+	Height uint64
+	// IncludedHeight is the height of the latest block included in DA layer.
+	IncludedHeight uint64
+	// SubmittedHeight is the height of the last block submitted to DA layer by the node. All blocks below are
+	// submitted as well, blocks above are pending submission.
+	SubmittedHeight uint64
+}
+
+// ForcedTxsCursor points at the first forced inclusion transaction (posted directly to DA layer) that was not included
+// in a block yet.
+type ForcedTxsCursor struct {
+	// DAHeight is the DA layer height at which transaction was posted.
+	DAHeight uint64
+	// Index is the index of transaction among forced inclusion transactions posted at DAHeight.
+	Index uint64
+}
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"fmt"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// Optimint specific event types, published on the node event bus (in addition to Tendermint events).
+const (
+	// EventNewSoftBlock is published when block is applied by the node, before it's known to be included in DA layer.
+	EventNewSoftBlock = "NewSoftBlock"
+	// EventNewFinalizedBlock is published when block is known to be included in DA layer.
+	EventNewFinalizedBlock = "NewFinalizedBlock"
+	// EventDALowBalance is published when balance of DA layer account paying fees drops below configured minimum.
+	EventDALowBalance = "DALowBalance"
+	// EventResultsMismatch is published when results of executing synced block don't match the next block header.
+	EventResultsMismatch = "ResultsMismatch"
+	// EventDAConfirmation is published when inclusion of a block in DA layer is verified.
+	EventDAConfirmation = "DAConfirmation"
+	// EventDAReorg is published when DA block containing a block was replaced by DA layer reorg.
+	EventDAReorg = "DAReorg"
+)
+
+var (
+	// EventQueryNewSoftBlock matches EventNewSoftBlock events.
+	EventQueryNewSoftBlock = queryForEvent(EventNewSoftBlock)
+	// EventQueryNewFinalizedBlock matches EventNewFinalizedBlock events.
+	EventQueryNewFinalizedBlock = queryForEvent(EventNewFinalizedBlock)
+	// EventQueryDALowBalance matches EventDALowBalance events.
+	EventQueryDALowBalance = queryForEvent(EventDALowBalance)
+	// EventQueryResultsMismatch matches EventResultsMismatch events.
+	EventQueryResultsMismatch = queryForEvent(EventResultsMismatch)
+	// EventQueryDAConfirmation matches EventDAConfirmation events.
+	EventQueryDAConfirmation = queryForEvent(EventDAConfirmation)
+	// EventQueryDAReorg matches EventDAReorg events.
+	EventQueryDAReorg = queryForEvent(EventDAReorg)
+)
+
+// EventDataNewSoftBlock is published with EventNewSoftBlock.
+type EventDataNewSoftBlock struct {
+	Header tmtypes.Header `json:"header"`
+	NumTxs int64          `json:"num_txs"`
+}
+
+// EventDataNewFinalizedBlock is published with EventNewFinalizedBlock.
+type EventDataNewFinalizedBlock struct {
+	Header tmtypes.Header `json:"header"`
+	NumTxs int64          `json:"num_txs"`
+	// DAHeight is the height of DA layer block containing the block.
+	DAHeight uint64 `json:"da_height"`
+}
+
+// EventDataDALowBalance is published with EventDALowBalance.
+type EventDataDALowBalance struct {
+	Address    tmbytes.HexBytes `json:"address"`
+	Balance    uint64           `json:"balance"`
+	MinBalance uint64           `json:"min_balance"`
+}
+
+// EventDataResultsMismatch is published with EventResultsMismatch.
+type EventDataResultsMismatch struct {
+	// Height is the height of executed block.
+	Height              uint64           `json:"height"`
+	AppHash             tmbytes.HexBytes `json:"app_hash"`
+	ExpectedAppHash     tmbytes.HexBytes `json:"expected_app_hash"`
+	ResultsHash         tmbytes.HexBytes `json:"results_hash"`
+	ExpectedResultsHash tmbytes.HexBytes `json:"expected_results_hash"`
+}
+
+// EventDataDAConfirmation is published with EventDAConfirmation.
+type EventDataDAConfirmation struct {
+	Height       uint64           `json:"height"`
+	DAHeight     uint64           `json:"da_height"`
+	Commitment   tmbytes.HexBytes `json:"commitment"`
+	Confirmation DAConfirmation   `json:"confirmation"`
+}
+
+// EventDataDAReorg is published with EventDAReorg.
+type EventDataDAReorg struct {
+	Height    uint64           `json:"height"`
+	DAHeight  uint64           `json:"da_height"`
+	DAHash    tmbytes.HexBytes `json:"da_hash"`
+	NewDAHash tmbytes.HexBytes `json:"new_da_hash"`
+	// Orphaned is true if block is no longer available in DA layer.
+	Orphaned bool `json:"orphaned"`
+}
+
+func init() {
+	tmjson.RegisterType(EventDataNewSoftBlock{}, "optimint/event/NewSoftBlock")
+	tmjson.RegisterType(EventDataNewFinalizedBlock{}, "optimint/event/NewFinalizedBlock")
+	tmjson.RegisterType(EventDataDALowBalance{}, "optimint/event/DALowBalance")
+	tmjson.RegisterType(EventDataResultsMismatch{}, "optimint/event/ResultsMismatch")
+	tmjson.RegisterType(EventDataDAConfirmation{}, "optimint/event/DAConfirmation")
+	tmjson.RegisterType(EventDataDAReorg{}, "optimint/event/DAReorg")
+}
+
+func queryForEvent(eventType string) tmpubsub.Query {
+	return tmquery.MustParse(fmt.Sprintf("%s='%s'", tmtypes.EventTypeKey, eventType))
+}
@@ -0,0 +1,72 @@
+//go:build go1.18
+// +build go1.18
+
+package types
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Fuzz targets for decoding of data received from P2P network and DA layer. Decoding of arbitrary bytes can fail,
+// but it can't panic, and every successfully decoded value has to survive encoding round trip.
+//
+// Run with: go test ./types -run '^$' -fuzz FuzzBlock
+
+func FuzzHeader(f *testing.F) {
+	fuzzDecoding(f, func() BinaryCodec { return &Header{} }, func(b *Block) BinaryCodec { return &b.Header })
+}
+
+func FuzzData(f *testing.F) {
+	fuzzDecoding(f, func() BinaryCodec { return &Data{} }, func(b *Block) BinaryCodec { return &b.Data })
+}
+
+func FuzzCommit(f *testing.F) {
+	fuzzDecoding(f, func() BinaryCodec { return &Commit{} }, func(b *Block) BinaryCodec { return &b.LastCommit })
+}
+
+// FuzzBlock covers blocks gossiped over P2P and block blobs retrieved from DA layer.
+func FuzzBlock(f *testing.F) {
+	fuzzDecoding(f, func() BinaryCodec { return &Block{} }, func(b *Block) BinaryCodec { return b })
+}
+
+func FuzzSignedHeader(f *testing.F) {
+	fuzzDecoding(f, func() BinaryCodec { return &SignedHeader{} }, func(b *Block) BinaryCodec {
+		return &SignedHeader{Header: b.Header, Commit: b.LastCommit}
+	})
+}
+
+// fuzzDecoding seeds corpus with encoded random blocks (and their parts), and checks decoding of fuzzed inputs.
+func fuzzDecoding(f *testing.F, newValue func() BinaryCodec, seed func(b *Block) BinaryCodec) {
+	rnd := rand.New(rand.NewSource(3)) //nolint:gosec
+	f.Add([]byte{})
+	for i := 0; i < 10; i++ {
+		data, err := seed(randomBlock(rnd)).MarshalBinary()
+		require.NoError(f, err)
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		v := newValue()
+		if err := v.UnmarshalBinary(data); err != nil {
+			return
+		}
+		switch v := v.(type) {
+		case *Block:
+			_ = v.Hash()
+			_ = v.Data.Hash()
+			_ = v.LastCommit.Hash()
+			_ = v.ValidateBasic()
+		case *SignedHeader:
+			_ = v.ValidateBasic()
+		}
+
+		encoded, err := v.MarshalBinary()
+		require.NoError(t, err)
+		decoded := newValue()
+		require.NoError(t, UnmarshalCanonical(encoded, decoded))
+		require.Equal(t, v, decoded)
+	})
+}
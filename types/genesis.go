@@ -0,0 +1,108 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// chainIDRegexp defines allowed chain ID format. Chain ID is used in P2P topic names and protocol IDs.
+var chainIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// GenesisExtension contains Optimint specific genesis parameters.
+//
+// It's stored in genesis file under "optimint" key, next to standard Tendermint fields.
+type GenesisExtension struct {
+	// DANamespaceID is hex encoded namespace ID used to store blocks in DA layer.
+	DANamespaceID string `json:"da_namespace_id"`
+	// DAStartHeight is the first DA layer height that can contain blocks of the chain.
+	DAStartHeight uint64 `json:"da_start_height,string"`
+}
+
+// GenesisExtensionFromFile reads GenesisExtension from genesis file.
+// If genesis file doesn't contain the extension, nil is returned.
+func GenesisExtensionFromFile(path string) (*GenesisExtension, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read genesis file: %w", err)
+	}
+	var genesis struct {
+		Optimint *GenesisExtension `json:"optimint"`
+	}
+	err = json.Unmarshal(data, &genesis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse optimint genesis extension: %w", err)
+	}
+	return genesis.Optimint, nil
+}
+
+// NamespaceID returns decoded DA namespace ID.
+func (e *GenesisExtension) NamespaceID() ([8]byte, error) {
+	var nsID [8]byte
+	bytes, err := hex.DecodeString(e.DANamespaceID)
+	if err != nil {
+		return nsID, fmt.Errorf("invalid DA namespace ID: %w", err)
+	}
+	if len(bytes) != len(nsID) {
+		return nsID, fmt.Errorf("invalid DA namespace ID: expected %d bytes, got %d", len(nsID), len(bytes))
+	}
+	copy(nsID[:], bytes)
+	return nsID, nil
+}
+
+// ValidateBasic performs basic validation of genesis extension.
+func (e *GenesisExtension) ValidateBasic() error {
+	_, err := e.NamespaceID()
+	if err != nil {
+		return err
+	}
+	if e.DAStartHeight == 0 {
+		return errors.New("DA start height must be greater than 0")
+	}
+	return nil
+}
+
+// ValidateGenesis checks if genesis doc can be used to start Optimint node.
+//
+// In addition to Tendermint validation, it checks chain ID format and that there is at most one validator (sequencer).
+// Validators may be omitted if they are provided by the application in InitChain response (see ValidateSequencer).
+// Genesis doc is completed with default values (see GenesisDoc.ValidateAndComplete).
+func ValidateGenesis(genesis *tmtypes.GenesisDoc) error {
+	if genesis == nil {
+		return errors.New("genesis doc is missing")
+	}
+	for i, v := range genesis.Validators {
+		if v.PubKey == nil {
+			return fmt.Errorf("validator %d in genesis doc has no public key", i)
+		}
+	}
+	err := genesis.ValidateAndComplete()
+	if err != nil {
+		return fmt.Errorf("invalid genesis doc: %w", err)
+	}
+	if !chainIDRegexp.MatchString(genesis.ChainID) {
+		return fmt.Errorf("invalid chain ID %q: only letters, digits, '.', '_' and '-' are allowed", genesis.ChainID)
+	}
+	if len(genesis.Validators) > 1 {
+		return fmt.Errorf("only single sequencer is supported, but genesis doc contains %d validators", len(genesis.Validators))
+	}
+	return nil
+}
+
+// ValidateSequencer checks if validator set resulting from genesis doc and InitChain response contains exactly one
+// validator (sequencer).
+func ValidateSequencer(validators *tmtypes.ValidatorSet) error {
+	switch validators.Size() {
+	case 0:
+		return errors.New("sequencer public key is missing: neither genesis doc nor InitChain response contains a validator")
+	case 1:
+		return nil
+	default:
+		return fmt.Errorf("only single sequencer is supported, but validator set contains %d validators", validators.Size())
+	}
+}
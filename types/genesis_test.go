@@ -0,0 +1,116 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	t.Parallel()
+
+	pubKey := ed25519.GenPrivKey().PubKey()
+	sequencer := tmtypes.GenesisValidator{PubKey: pubKey, Power: 1}
+	other := tmtypes.GenesisValidator{PubKey: ed25519.GenPrivKey().PubKey(), Power: 1}
+
+	cases := []struct {
+		name    string
+		input   *tmtypes.GenesisDoc
+		wantErr string
+	}{
+		{"valid", &tmtypes.GenesisDoc{ChainID: "optimint-test_1.0", Validators: []tmtypes.GenesisValidator{sequencer}}, ""},
+		{"nil", nil, "genesis doc is missing"},
+		{"empty chain ID", &tmtypes.GenesisDoc{Validators: []tmtypes.GenesisValidator{sequencer}}, "chain_id"},
+		{"invalid chain ID", &tmtypes.GenesisDoc{ChainID: "test/chain", Validators: []tmtypes.GenesisValidator{sequencer}}, "invalid chain ID"},
+		{"no sequencer", &tmtypes.GenesisDoc{ChainID: "test"}, ""},
+		{"no public key", &tmtypes.GenesisDoc{ChainID: "test", Validators: []tmtypes.GenesisValidator{{Power: 1}}}, "no public key"},
+		{"multiple sequencers", &tmtypes.GenesisDoc{ChainID: "test", Validators: []tmtypes.GenesisValidator{sequencer, other}}, "only single sequencer"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateGenesis(c.input)
+			if c.wantErr == "" {
+				assert.NoError(t, err)
+				for _, v := range c.input.Validators {
+					assert.Equal(t, pubKey.Address(), v.Address)
+				}
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), c.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSequencer(t *testing.T) {
+	t.Parallel()
+
+	sequencer := tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), 1)
+	other := tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), 1)
+
+	assert.NoError(t, ValidateSequencer(tmtypes.NewValidatorSet([]*tmtypes.Validator{sequencer})))
+	assert.Error(t, ValidateSequencer(tmtypes.NewValidatorSet(nil)))
+	assert.Error(t, ValidateSequencer(tmtypes.NewValidatorSet([]*tmtypes.Validator{sequencer, other})))
+}
+
+func TestGenesisExtensionFromFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	cases := []struct {
+		name     string
+		content  string
+		expected *GenesisExtension
+		parseErr bool
+		validErr bool
+	}{
+		{"no extension", `{"chain_id": "test"}`, nil, false, false},
+		{"valid", `{"chain_id": "test", "optimint": {"da_namespace_id": "0102030405060708", "da_start_height": "5"}}`,
+			&GenesisExtension{DANamespaceID: "0102030405060708", DAStartHeight: 5}, false, false},
+		{"invalid namespace", `{"optimint": {"da_namespace_id": "0102", "da_start_height": "5"}}`,
+			&GenesisExtension{DANamespaceID: "0102", DAStartHeight: 5}, false, true},
+		{"zero start height", `{"optimint": {"da_namespace_id": "0102030405060708", "da_start_height": "0"}}`,
+			&GenesisExtension{DANamespaceID: "0102030405060708"}, false, true},
+		{"malformed", `{"optimint": []}`, nil, true, false},
+	}
+
+	for i, c := range cases {
+		path := write(string(rune('a'+i))+".json", c.content)
+		t.Run(c.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ext, err := GenesisExtensionFromFile(path)
+			if c.parseErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(c.expected, ext)
+			if ext == nil {
+				return
+			}
+			if c.validErr {
+				assert.Error(ext.ValidateBasic())
+			} else {
+				assert.NoError(ext.ValidateBasic())
+				nsID, err := ext.NamespaceID()
+				assert.NoError(err)
+				assert.Equal([8]byte{1, 2, 3, 4, 5, 6, 7, 8}, nsID)
+			}
+		})
+	}
+
+	_, err := GenesisExtensionFromFile(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
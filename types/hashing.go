@@ -1,24 +1,81 @@
 package types
 
 import (
-	"encoding"
+	"encoding/binary"
 
-	"github.com/minio/sha256-simd"
+	"github.com/tendermint/tendermint/crypto/merkle"
 )
 
+// Hash returns canonical hash of the header.
+//
+// It's a RFC-6962 Merkle root of all header fields, encoded as specified in ADR-008, in order of declaration.
 func (h *Header) Hash() [32]byte {
-	return hash(h)
+	var hash [32]byte
+	copy(hash[:], merkle.HashFromByteSlices([][]byte{
+		append(encodeUint64(h.Version.Block), encodeUint64(h.Version.App)...),
+		h.NamespaceID[:],
+		encodeUint64(h.Height),
+		encodeUint64(h.Time),
+		h.LastHeaderHash[:],
+		h.LastCommitHash[:],
+		h.DataHash[:],
+		h.ConsensusHash[:],
+		h.AppHash[:],
+		h.LastResultsHash[:],
+		h.ProposerAddress,
+	}))
+	return hash
 }
 
+// Hash returns hash of the block.
+// Block is identified by its header, which commits to block data (see DataHash) and last commit (see LastCommitHash).
 func (b *Block) Hash() [32]byte {
-	return hash(b)
+	return b.Header.Hash()
 }
 
-func hash(obj encoding.BinaryMarshaler) [32]byte {
-	blob, err := obj.MarshalBinary()
-	if err != nil {
-		return [32]byte{}
+// Hash returns canonical hash of block data, that should be used as DataHash in block header.
+//
+// It's computed exactly like in Tendermint: as a RFC-6962 Merkle root of SHA-256 hashes of transactions.
+// If block contains transaction sequence numbers, it's a Merkle root of transactions root and sequence numbers root.
+func (d *Data) Hash() [32]byte {
+	hashes := d.Txs.Hashes()
+	leaves := make([][]byte, len(hashes))
+	for i := range hashes {
+		leaves[i] = hashes[i][:]
 	}
-	return sha256.Sum256(blob)
+	txsRoot := merkle.HashFromByteSlices(leaves)
+	var hash [32]byte
+	if len(d.TxSequences) == 0 {
+		copy(hash[:], txsRoot)
+		return hash
+	}
+	seqs := make([][]byte, len(d.TxSequences))
+	for i, seq := range d.TxSequences {
+		seqs[i] = encodeUint64(seq)
+	}
+	copy(hash[:], merkle.HashFromByteSlices([][]byte{txsRoot, merkle.HashFromByteSlices(seqs)}))
+	return hash
+}
+
+// Hash returns canonical hash of the commit, that should be used as LastCommitHash in the header of next block.
+//
+// It's a RFC-6962 Merkle root of commit height, header hash and Merkle root of all signatures.
+func (c *Commit) Hash() [32]byte {
+	sigs := make([][]byte, len(c.Signatures))
+	for i := range c.Signatures {
+		sigs[i] = c.Signatures[i]
+	}
+	var hash [32]byte
+	copy(hash[:], merkle.HashFromByteSlices([][]byte{
+		encodeUint64(c.Height),
+		c.HeaderHash[:],
+		merkle.HashFromByteSlices(sigs),
+	}))
+	return hash
+}
 
+func encodeUint64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
 }
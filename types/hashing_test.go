@@ -0,0 +1,156 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// golden vectors - any change of values below is a breaking change of hashing scheme (see ADR-008)
+func TestHashGoldenVectors(t *testing.T) {
+	t.Parallel()
+
+	header := Header{
+		Version:         Version{Block: 11, App: 1},
+		NamespaceID:     [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Height:          42,
+		Time:            1640995200,
+		LastHeaderHash:  fill(0x01),
+		LastCommitHash:  fill(0x02),
+		DataHash:        fill(0x03),
+		ConsensusHash:   fill(0x04),
+		AppHash:         fill(0x05),
+		LastResultsHash: fill(0x06),
+		ProposerAddress: []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+	data := Data{Txs: Txs{Tx("tx1"), Tx("tx2"), Tx("tx3")}}
+	commit := Commit{
+		Height:     42,
+		HeaderHash: fill(0x07),
+		Signatures: []Signature{[]byte{1, 2, 3}, []byte{4, 5, 6}},
+	}
+
+	cases := []struct {
+		name     string
+		hash     [32]byte
+		expected string
+	}{
+		{"empty header", (&Header{}).Hash(), "c87dd543cc48573a62d6ee5a986cc00ec8a73bd66003ac186b0c7e58bb12366f"},
+		{"header", header.Hash(), "323fc966ad53f29c4223e5d73b6a3f118037c5eab1fd6586865b00dfc83381da"},
+		{"block", (&Block{Header: header, Data: data, LastCommit: commit}).Hash(), "323fc966ad53f29c4223e5d73b6a3f118037c5eab1fd6586865b00dfc83381da"},
+		{"empty data", (&Data{}).Hash(), "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{"data", data.Hash(), "5732471026f00afd6c7f3d11bbef4fc3b5e47b97a248037d33283bee1034f2ac"},
+		{"data with sequences", (&Data{Txs: data.Txs, TxSequences: []uint64{0, 1, 2}}).Hash(), "c35db8ef31b0bd53f9a533d4213b2e369a7dc92c56466815fb8116be162a4eeb"},
+		{"empty commit", (&Commit{}).Hash(), "fd6d45ba7fa01ad9e750d83444b883d17965efc5347af5b231f1d98ce1afbeba"},
+		{"commit", commit.Hash(), "884f1f24120bb372c643e1d196a7b60d429cc9e366ed6408efbed60e5d42f83b"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, hex.EncodeToString(c.hash[:]))
+		})
+	}
+}
+
+func TestHeaderHashCoversAllFields(t *testing.T) {
+	t.Parallel()
+
+	base := Header{ProposerAddress: []byte{1}}
+	baseHash := base.Hash()
+
+	mutations := []func(h *Header){
+		func(h *Header) { h.Version.Block = 1 },
+		func(h *Header) { h.Version.App = 1 },
+		func(h *Header) { h.NamespaceID[0] = 1 },
+		func(h *Header) { h.Height = 1 },
+		func(h *Header) { h.Time = 1 },
+		func(h *Header) { h.LastHeaderHash[0] = 1 },
+		func(h *Header) { h.LastCommitHash[0] = 1 },
+		func(h *Header) { h.DataHash[0] = 1 },
+		func(h *Header) { h.ConsensusHash[0] = 1 },
+		func(h *Header) { h.AppHash[0] = 1 },
+		func(h *Header) { h.LastResultsHash[0] = 1 },
+		func(h *Header) { h.ProposerAddress = []byte{2} },
+	}
+
+	for i, mutate := range mutations {
+		h := base
+		mutate(&h)
+		assert.NotEqual(t, baseHash, h.Hash(), "mutation %d", i)
+	}
+}
+
+func TestBlockDataHashValidation(t *testing.T) {
+	t.Parallel()
+
+	newBlock := func() *Block {
+		b := &Block{
+			Header: Header{ProposerAddress: []byte{1}, LastCommitHash: (&Commit{}).Hash()},
+			Data:   Data{Txs: Txs{Tx("tx1"), Tx("tx2")}},
+		}
+		b.Header.DataHash = b.Data.Hash()
+		return b
+	}
+
+	tamperedTxs := newBlock()
+	tamperedTxs.Data.Txs[0] = Tx("tx3")
+	withISRs := newBlock()
+	withISRs.Data.IntermediateStateRoots.RawRootsList = [][]byte{{1}}
+	withEvidence := newBlock()
+	withEvidence.Data.Evidence.Evidence = []Evidence{nil}
+
+	cases := []struct {
+		name   string
+		input  *Block
+		errMsg string
+	}{
+		{"valid", newBlock(), ""},
+		{"tampered transactions", tamperedTxs, "DataHash doesn't match block data"},
+		{"intermediate state roots", withISRs, "intermediate state roots are not supported"},
+		{"evidence", withEvidence, "evidence is not supported"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.input.ValidateBasic()
+			if c.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, c.errMsg)
+			}
+		})
+	}
+}
+
+func TestTxsHashes(t *testing.T) {
+	t.Parallel()
+
+	// big enough to be hashed in parallel
+	txs := make(Txs, 1000)
+	tmTxs := make(tmtypes.Txs, len(txs))
+	for i := range txs {
+		txs[i] = Tx(fmt.Sprintf("tx%d", i))
+		tmTxs[i] = tmtypes.Tx(txs[i])
+	}
+
+	hashes := txs.Hashes()
+	assert.Len(t, hashes, len(txs))
+	for i := range txs {
+		assert.Equal(t, tmTxs[i].Hash(), hashes[i][:])
+	}
+
+	data := Data{Txs: txs}
+	hash := data.Hash()
+	assert.Equal(t, tmTxs.Hash(), hash[:])
+}
+
+func fill(b byte) [32]byte {
+	var h [32]byte
+	for i := range h {
+		h[i] = b
+	}
+	return h
+}
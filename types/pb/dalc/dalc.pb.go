@@ -69,8 +69,9 @@ func (x StatusCode) String() string {
 func (StatusCode) EnumDescriptor() ([]byte, []int) { return fileDescriptorDalc, []int{0} }
 
 type DAResponse struct {
-	Code    StatusCode `protobuf:"varint,1,opt,name=code,proto3,enum=dalc.StatusCode" json:"code,omitempty"`
-	Message string     `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Code            StatusCode `protobuf:"varint,1,opt,name=code,proto3,enum=dalc.StatusCode" json:"code,omitempty"`
+	Message         string     `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	DataLayerHeight uint64     `protobuf:"varint,3,opt,name=data_layer_height,json=dataLayerHeight,proto3" json:"data_layer_height,omitempty"`
 }
 
 func (m *DAResponse) Reset()                    { *m = DAResponse{} }
@@ -92,6 +93,13 @@ func (m *DAResponse) GetMessage() string {
 	return ""
 }
 
+func (m *DAResponse) GetDataLayerHeight() uint64 {
+	if m != nil {
+		return m.DataLayerHeight
+	}
+	return 0
+}
+
 type SubmitBlockRequest struct {
 	Block *optimint.Block `protobuf:"bytes,1,opt,name=block" json:"block,omitempty"`
 }
@@ -110,6 +118,7 @@ func (m *SubmitBlockRequest) GetBlock() *optimint.Block {
 
 type SubmitBlockResponse struct {
 	Result *DAResponse `protobuf:"bytes,1,opt,name=result" json:"result,omitempty"`
+	TxHash []byte      `protobuf:"bytes,2,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
 }
 
 func (m *SubmitBlockResponse) Reset()                    { *m = SubmitBlockResponse{} }
@@ -124,6 +133,13 @@ func (m *SubmitBlockResponse) GetResult() *DAResponse {
 	return nil
 }
 
+func (m *SubmitBlockResponse) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
 type CheckBlockAvailabilityRequest struct {
 	Header *optimint.Header `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 }
@@ -383,6 +399,11 @@ func (m *DAResponse) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintDalc(dAtA, i, uint64(len(m.Message)))
 		i += copy(dAtA[i:], m.Message)
 	}
+	if m.DataLayerHeight != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintDalc(dAtA, i, uint64(m.DataLayerHeight))
+	}
 	return i, nil
 }
 
@@ -439,6 +460,12 @@ func (m *SubmitBlockResponse) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n2
 	}
+	if len(m.TxHash) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDalc(dAtA, i, uint64(len(m.TxHash)))
+		i += copy(dAtA[i:], m.TxHash)
+	}
 	return i, nil
 }
 
@@ -606,6 +633,9 @@ func (m *DAResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovDalc(uint64(l))
 	}
+	if m.DataLayerHeight != 0 {
+		n += 1 + sovDalc(uint64(m.DataLayerHeight))
+	}
 	return n
 }
 
@@ -626,6 +656,10 @@ func (m *SubmitBlockResponse) Size() (n int) {
 		l = m.Result.Size()
 		n += 1 + l + sovDalc(uint64(l))
 	}
+	l = len(m.TxHash)
+	if l > 0 {
+		n += 1 + l + sovDalc(uint64(l))
+	}
 	return n
 }
 
@@ -765,6 +799,25 @@ func (m *DAResponse) Unmarshal(dAtA []byte) error {
 			}
 			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataLayerHeight", wireType)
+			}
+			m.DataLayerHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDalc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DataLayerHeight |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDalc(dAtA[iNdEx:])
@@ -931,6 +984,37 @@ func (m *SubmitBlockResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDalc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDalc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxHash = append(m.TxHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.TxHash == nil {
+				m.TxHash = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDalc(dAtA[iNdEx:])
@@ -1431,37 +1515,39 @@ var (
 func init() { proto.RegisterFile("dalc/dalc.proto", fileDescriptorDalc) }
 
 var fileDescriptorDalc = []byte{
-	// 501 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xae, 0x43, 0x08, 0x30, 0x51, 0xdb, 0xb0, 0xa5, 0x4d, 0x48, 0x45, 0x54, 0x99, 0x56, 0x8a,
-	0x90, 0xb0, 0xa5, 0x70, 0xe4, 0x50, 0xb9, 0xb6, 0x11, 0x41, 0x85, 0xa0, 0x75, 0x72, 0xe1, 0x12,
-	0xad, 0xed, 0x51, 0xbc, 0xaa, 0x53, 0xbb, 0xf6, 0x3a, 0x52, 0x5f, 0x80, 0x67, 0xe0, 0x91, 0x38,
-	0xf2, 0x08, 0x28, 0xbc, 0x08, 0xf2, 0x6f, 0x93, 0x12, 0x22, 0xc1, 0xc5, 0xda, 0x99, 0x6f, 0xe6,
-	0xdb, 0xcf, 0x3b, 0xdf, 0x2e, 0xec, 0xbb, 0xcc, 0x77, 0xd4, 0xf4, 0xa3, 0x84, 0x51, 0x20, 0x02,
-	0x52, 0x4f, 0xd7, 0xdd, 0x76, 0x10, 0x0a, 0x3e, 0xe7, 0xd7, 0x42, 0x2d, 0x17, 0x39, 0x2c, 0x5f,
-	0x02, 0x18, 0x1a, 0xc5, 0x38, 0x0c, 0xae, 0x63, 0x24, 0xa7, 0x50, 0x77, 0x02, 0x17, 0x3b, 0xd2,
-	0x89, 0xd4, 0xdf, 0x1b, 0xb4, 0x94, 0x8c, 0xc7, 0x12, 0x4c, 0x24, 0xb1, 0x1e, 0xb8, 0x48, 0x33,
-	0x94, 0x74, 0xe0, 0xd1, 0x1c, 0xe3, 0x98, 0xcd, 0xb0, 0x53, 0x3b, 0x91, 0xfa, 0x4f, 0x68, 0x19,
-	0xca, 0x6f, 0x81, 0x58, 0x89, 0x3d, 0xe7, 0xe2, 0xc2, 0x0f, 0x9c, 0x2b, 0x8a, 0x37, 0x09, 0xc6,
-	0x82, 0x9c, 0xc1, 0x43, 0x3b, 0x8d, 0x33, 0xda, 0xe6, 0x60, 0x5f, 0xa9, 0x34, 0xe4, 0x65, 0x39,
-	0x2a, 0x9f, 0xc3, 0xc1, 0x5a, 0x73, 0xa1, 0xa9, 0x0f, 0x8d, 0x08, 0xe3, 0xc4, 0x17, 0x45, 0x7b,
-	0xa1, 0xea, 0x4e, 0x35, 0x2d, 0x70, 0x79, 0x08, 0x2f, 0x74, 0x0f, 0x9d, 0xab, 0xac, 0x5f, 0x5b,
-	0x30, 0xee, 0x33, 0x9b, 0xfb, 0x5c, 0xdc, 0x96, 0x42, 0xfa, 0xd0, 0xf0, 0x90, 0xb9, 0x18, 0x55,
-	0x54, 0x95, 0x92, 0xf7, 0x59, 0x9e, 0x16, 0xb8, 0x7c, 0x03, 0xbd, 0xbf, 0x51, 0xfd, 0xab, 0x2c,
-	0x72, 0x06, 0x7b, 0x2e, 0x13, 0x6c, 0xca, 0x72, 0x1a, 0x3f, 0x3f, 0xb5, 0xc7, 0x74, 0x37, 0xcd,
-	0x6a, 0x65, 0x52, 0x56, 0xe0, 0x19, 0x45, 0x11, 0x71, 0x5c, 0xe0, 0xda, 0xe9, 0x1d, 0xa5, 0xa2,
-	0xf9, 0xcc, 0xcb, 0x37, 0xaa, 0xd3, 0x22, 0x92, 0x3d, 0x38, 0xbc, 0x57, 0xff, 0x1f, 0xca, 0x8a,
-	0xc1, 0xd4, 0xb6, 0x0d, 0xe6, 0x55, 0x04, 0x70, 0xe7, 0x01, 0x72, 0x0c, 0x6d, 0x6b, 0xac, 0x8d,
-	0x27, 0xd6, 0x54, 0x1f, 0x19, 0xe6, 0x74, 0xf2, 0xc9, 0xfa, 0x6c, 0xea, 0xc3, 0x77, 0x43, 0xd3,
-	0x68, 0xed, 0x90, 0x36, 0x1c, 0xac, 0x82, 0xd6, 0x44, 0xd7, 0x4d, 0xcb, 0x6a, 0x49, 0xf7, 0x81,
-	0xf1, 0xf0, 0xa3, 0x39, 0x9a, 0x8c, 0x5b, 0x35, 0x72, 0x08, 0x4f, 0x57, 0x01, 0x93, 0xd2, 0x11,
-	0x6d, 0x3d, 0x18, 0x7c, 0xad, 0x41, 0xd3, 0xd0, 0x2e, 0x75, 0x0b, 0xa3, 0x05, 0x77, 0x90, 0x18,
-	0xd0, 0x5c, 0x31, 0x07, 0xe9, 0x14, 0xd6, 0xfc, 0xc3, 0x6c, 0xdd, 0xe7, 0x1b, 0x90, 0xfc, 0xb7,
-	0xe5, 0x1d, 0x82, 0x70, 0xb4, 0x79, 0xac, 0xe4, 0x65, 0xde, 0xb6, 0xd5, 0x3f, 0xdd, 0xd3, 0xed,
-	0x45, 0xd5, 0x36, 0x1f, 0x60, 0x77, 0x6d, 0x34, 0xa4, 0x9b, 0x37, 0x6e, 0x9a, 0x6f, 0xf7, 0x78,
-	0x23, 0x56, 0x72, 0x5d, 0x9c, 0x7f, 0x5f, 0xf6, 0xa4, 0x1f, 0xcb, 0x9e, 0xf4, 0x73, 0xd9, 0x93,
-	0xbe, 0xfd, 0xea, 0xed, 0x7c, 0x79, 0x3d, 0xe3, 0xc2, 0x4b, 0x6c, 0xc5, 0x09, 0xe6, 0xaa, 0x83,
-	0x3e, 0xc6, 0x82, 0xb3, 0x20, 0x9a, 0x55, 0x37, 0x5b, 0x15, 0xb7, 0x21, 0xc6, 0x6a, 0x68, 0x67,
-	0xcf, 0x80, 0xdd, 0xc8, 0x2e, 0xfa, 0x9b, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xb9, 0xb4, 0x5b,
-	0xe6, 0x1a, 0x04, 0x00, 0x00,
+	// 542 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xe1, 0x6e, 0x12, 0x41,
+	0x10, 0xe6, 0x28, 0x52, 0x1d, 0x6c, 0xa1, 0x5b, 0x5b, 0x90, 0x46, 0x42, 0xce, 0x36, 0x21, 0x4d,
+	0x84, 0x04, 0x7f, 0xfa, 0xc3, 0xd0, 0xe3, 0x0c, 0x98, 0x2a, 0x66, 0x0f, 0x12, 0xe3, 0x1f, 0xb2,
+	0x77, 0x4c, 0xb8, 0x4d, 0x8f, 0x1e, 0xbd, 0x5d, 0x08, 0xbc, 0x80, 0xcf, 0xe0, 0x23, 0xf9, 0xd3,
+	0x47, 0x30, 0xf8, 0x22, 0xe6, 0xf6, 0x0e, 0x0a, 0x15, 0x49, 0xf4, 0xcf, 0xe5, 0x66, 0xbe, 0x99,
+	0xd9, 0x6f, 0xe6, 0x9b, 0x0c, 0x64, 0x07, 0xcc, 0x73, 0x6a, 0xe1, 0xa7, 0x3a, 0x0e, 0x7c, 0xe9,
+	0x93, 0x54, 0xf8, 0x5f, 0xcc, 0xfb, 0x63, 0xc9, 0x47, 0xfc, 0x56, 0xd6, 0x96, 0x3f, 0x11, 0xac,
+	0xcf, 0x00, 0x9a, 0x0d, 0x8a, 0x62, 0xec, 0xdf, 0x0a, 0x24, 0xe7, 0x90, 0x72, 0xfc, 0x01, 0x16,
+	0xb4, 0xb2, 0x56, 0x39, 0xac, 0xe7, 0xaa, 0xaa, 0x8e, 0x25, 0x99, 0x9c, 0x08, 0xc3, 0x1f, 0x20,
+	0x55, 0x28, 0x29, 0xc0, 0xfe, 0x08, 0x85, 0x60, 0x43, 0x2c, 0x24, 0xcb, 0x5a, 0xe5, 0x09, 0x5d,
+	0x9a, 0xe4, 0x12, 0x8e, 0x06, 0x4c, 0xb2, 0xbe, 0xc7, 0xe6, 0x18, 0xf4, 0x5d, 0xe4, 0x43, 0x57,
+	0x16, 0xf6, 0xca, 0x5a, 0x25, 0x45, 0xb3, 0x21, 0x70, 0x1d, 0xfa, 0x5b, 0xca, 0xad, 0xbf, 0x01,
+	0x62, 0x4d, 0xec, 0x11, 0x97, 0x57, 0x9e, 0xef, 0xdc, 0x50, 0xbc, 0x9b, 0xa0, 0x90, 0xe4, 0x02,
+	0x1e, 0xd9, 0xa1, 0xad, 0x28, 0x64, 0xea, 0xd9, 0xea, 0x8a, 0x6f, 0x14, 0x16, 0xa1, 0xfa, 0x67,
+	0x38, 0xde, 0x48, 0x8e, 0xf9, 0x57, 0x20, 0x1d, 0xa0, 0x98, 0x78, 0x32, 0x4e, 0x8f, 0x3b, 0xb8,
+	0xef, 0x90, 0xc6, 0x38, 0xc9, 0xc3, 0xbe, 0x9c, 0xf5, 0x5d, 0x26, 0x5c, 0xd5, 0xc3, 0x53, 0x9a,
+	0x96, 0xb3, 0x16, 0x13, 0xae, 0xde, 0x86, 0x17, 0x86, 0x8b, 0xce, 0x8d, 0x2a, 0xdc, 0x98, 0x32,
+	0xee, 0x31, 0x9b, 0x7b, 0x5c, 0xce, 0x97, 0x0c, 0x2b, 0x90, 0x76, 0x91, 0x0d, 0x30, 0x58, 0xbd,
+	0xb1, 0xa2, 0xd8, 0x52, 0x7e, 0x1a, 0xe3, 0xfa, 0x1d, 0x94, 0xfe, 0x56, 0xea, 0x9f, 0xf9, 0x5e,
+	0xc0, 0xa1, 0x9a, 0x2c, 0x8b, 0xca, 0x78, 0xd1, 0xe8, 0x1f, 0xd3, 0x83, 0xd0, 0xdb, 0x58, 0x3a,
+	0xf5, 0x2a, 0x3c, 0xa3, 0x28, 0x03, 0x8e, 0x53, 0xdc, 0x18, 0xeb, 0x69, 0x48, 0x5a, 0xa9, 0xa1,
+	0x29, 0x35, 0x62, 0x4b, 0x77, 0xe1, 0xe4, 0x41, 0xfc, 0x7f, 0x30, 0x8b, 0x15, 0x4b, 0xee, 0x52,
+	0xec, 0x32, 0x00, 0xb8, 0x5f, 0x24, 0x72, 0x06, 0x79, 0xab, 0xdb, 0xe8, 0xf6, 0xac, 0xbe, 0xd1,
+	0x69, 0x9a, 0xfd, 0xde, 0x47, 0xeb, 0x93, 0x69, 0xb4, 0xdf, 0xb5, 0xcd, 0x66, 0x2e, 0x41, 0xf2,
+	0x70, 0xbc, 0x0e, 0x5a, 0x3d, 0xc3, 0x30, 0x2d, 0x2b, 0xa7, 0x3d, 0x04, 0xba, 0xed, 0x0f, 0x66,
+	0xa7, 0xd7, 0xcd, 0x25, 0xc9, 0x09, 0x1c, 0xad, 0x03, 0x26, 0xa5, 0x1d, 0x9a, 0xdb, 0xab, 0x7f,
+	0x4d, 0x42, 0xa6, 0xd9, 0xb8, 0x36, 0x2c, 0x0c, 0xa6, 0xdc, 0x41, 0xd2, 0x84, 0xcc, 0xda, 0xd6,
+	0x90, 0x42, 0xbc, 0xdf, 0x7f, 0x6c, 0x61, 0xf1, 0xf9, 0x16, 0x24, 0x6a, 0x5b, 0x4f, 0x10, 0x84,
+	0xd3, 0xed, 0xb2, 0x92, 0x97, 0x51, 0xda, 0xce, 0xfd, 0x29, 0x9e, 0xef, 0x0e, 0x5a, 0x3d, 0xf3,
+	0x1e, 0x0e, 0x36, 0xa4, 0x21, 0xc5, 0x28, 0x71, 0x9b, 0xbe, 0xc5, 0xb3, 0xad, 0xd8, 0xb2, 0xd6,
+	0xd5, 0xdb, 0x2f, 0xaf, 0x86, 0x5c, 0xba, 0x13, 0xbb, 0xea, 0xf8, 0xa3, 0x9a, 0x83, 0x1e, 0x0a,
+	0xc9, 0x99, 0x1f, 0x0c, 0x57, 0xe7, 0xa0, 0x26, 0xe7, 0x63, 0x14, 0xb5, 0xb1, 0xad, 0x6e, 0xc7,
+	0xf7, 0x45, 0x49, 0xfb, 0xb1, 0x28, 0x69, 0x3f, 0x17, 0x25, 0xed, 0xdb, 0xaf, 0x52, 0xc2, 0x4e,
+	0xab, 0x6b, 0xf1, 0xfa, 0xf7, 0x00, 0x7b, 0xe4, 0x8e, 0xb4, 0x5f, 0x04, 0x00, 0x00,
 }
@@ -13,6 +13,7 @@
 		Commit
 		Data
 		Block
+		SignedHeader
 */
 package optimint
 
@@ -212,6 +213,7 @@ type Data struct {
 	Txs                    [][]byte                    `protobuf:"bytes,1,rep,name=txs" json:"txs,omitempty"`
 	IntermediateStateRoots [][]byte                    `protobuf:"bytes,2,rep,name=intermediate_state_roots,json=intermediateStateRoots" json:"intermediate_state_roots,omitempty"`
 	Evidence               []*tendermint_abci.Evidence `protobuf:"bytes,3,rep,name=evidence" json:"evidence,omitempty"`
+	TxSequences            []uint64                    `protobuf:"varint,4,rep,packed,name=tx_sequences,json=txSequences" json:"tx_sequences,omitempty"`
 }
 
 func (m *Data) Reset()                    { *m = Data{} }
@@ -240,6 +242,13 @@ func (m *Data) GetEvidence() []*tendermint_abci.Evidence {
 	return nil
 }
 
+func (m *Data) GetTxSequences() []uint64 {
+	if m != nil {
+		return m.TxSequences
+	}
+	return nil
+}
+
 type Block struct {
 	Header     *Header `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	Data       *Data   `protobuf:"bytes,2,opt,name=data" json:"data,omitempty"`
@@ -272,12 +281,37 @@ func (m *Block) GetLastCommit() *Commit {
 	return nil
 }
 
+type SignedHeader struct {
+	Header *Header `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	Commit *Commit `protobuf:"bytes,2,opt,name=commit" json:"commit,omitempty"`
+}
+
+func (m *SignedHeader) Reset()                    { *m = SignedHeader{} }
+func (m *SignedHeader) String() string            { return proto.CompactTextString(m) }
+func (*SignedHeader) ProtoMessage()               {}
+func (*SignedHeader) Descriptor() ([]byte, []int) { return fileDescriptorOptimint, []int{5} }
+
+func (m *SignedHeader) GetHeader() *Header {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SignedHeader) GetCommit() *Commit {
+	if m != nil {
+		return m.Commit
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Version)(nil), "optimint.Version")
 	proto.RegisterType((*Header)(nil), "optimint.Header")
 	proto.RegisterType((*Commit)(nil), "optimint.Commit")
 	proto.RegisterType((*Data)(nil), "optimint.Data")
 	proto.RegisterType((*Block)(nil), "optimint.Block")
+	proto.RegisterType((*SignedHeader)(nil), "optimint.SignedHeader")
 }
 func (m *Version) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -473,6 +507,18 @@ func (m *Data) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.TxSequences) > 0 {
+		dAtA[i] = 0x22
+		i++
+		j := 0
+		for _, num := range m.TxSequences {
+			j += sovOptimint(num)
+		}
+		i = encodeVarintOptimint(dAtA, i, uint64(j))
+		for _, num := range m.TxSequences {
+			i = encodeVarintOptimint(dAtA, i, num)
+		}
+	}
 	return i, nil
 }
 
@@ -524,6 +570,44 @@ func (m *Block) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *SignedHeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignedHeader) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOptimint(dAtA, i, uint64(m.Header.Size()))
+		n5, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if m.Commit != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOptimint(dAtA, i, uint64(m.Commit.Size()))
+		n6, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	return i, nil
+}
+
 func encodeFixed64Optimint(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	dAtA[offset+1] = uint8(v >> 8)
@@ -651,6 +735,13 @@ func (m *Data) Size() (n int) {
 			n += 1 + l + sovOptimint(uint64(l))
 		}
 	}
+	if len(m.TxSequences) > 0 {
+		l = 0
+		for _, e := range m.TxSequences {
+			l += sovOptimint(e)
+		}
+		n += 1 + sovOptimint(uint64(l)) + l
+	}
 	return n
 }
 
@@ -672,6 +763,20 @@ func (m *Block) Size() (n int) {
 	return n
 }
 
+func (m *SignedHeader) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovOptimint(uint64(l))
+	}
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovOptimint(uint64(l))
+	}
+	return n
+}
+
 func sovOptimint(x uint64) (n int) {
 	for {
 		n++
@@ -1389,6 +1494,68 @@ func (m *Data) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOptimint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.TxSequences = append(m.TxSequences, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOptimint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOptimint
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOptimint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.TxSequences = append(m.TxSequences, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxSequences", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOptimint(dAtA[iNdEx:])
@@ -1559,6 +1726,122 @@ func (m *Block) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SignedHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOptimint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignedHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignedHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptimint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOptimint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &Header{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptimint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOptimint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Commit == nil {
+				m.Commit = &Commit{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOptimint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOptimint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipOptimint(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1667,39 +1950,41 @@ var (
 func init() { proto.RegisterFile("optimint/optimint.proto", fileDescriptorOptimint) }
 
 var fileDescriptorOptimint = []byte{
-	// 541 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x93, 0xcf, 0x8e, 0xd3, 0x30,
-	0x10, 0xc6, 0xc9, 0xf6, 0xef, 0x4e, 0x96, 0xd2, 0xb5, 0xd0, 0x92, 0x65, 0xa5, 0xb2, 0x44, 0x42,
-	0x2a, 0x20, 0xa5, 0x6a, 0x11, 0x12, 0x57, 0x76, 0x41, 0x5a, 0xae, 0x41, 0xe2, 0xc0, 0xa5, 0x72,
-	0x93, 0x51, 0x63, 0xd1, 0xc4, 0x96, 0xed, 0xae, 0xe0, 0x0d, 0xe0, 0xc2, 0x99, 0x47, 0xe2, 0xc8,
-	0x23, 0xa0, 0xf2, 0x22, 0xc8, 0xe3, 0x34, 0xe9, 0x5e, 0x22, 0xfb, 0xfb, 0x7e, 0x99, 0x78, 0x26,
-	0x9f, 0xe1, 0x91, 0x54, 0x56, 0x94, 0xa2, 0xb2, 0xb3, 0xfd, 0x22, 0x51, 0x5a, 0x5a, 0xc9, 0x86,
-	0xfb, 0xfd, 0xe3, 0x0b, 0x8b, 0x55, 0x8e, 0x9a, 0x20, 0xbe, 0xca, 0xc4, 0xcc, 0x7e, 0x53, 0x68,
-	0x3c, 0x16, 0xcf, 0x61, 0xf0, 0x09, 0xb5, 0x11, 0xb2, 0x62, 0x0f, 0xa1, 0xb7, 0xda, 0xc8, 0xec,
-	0x4b, 0x14, 0x5c, 0x06, 0xd3, 0x6e, 0xea, 0x37, 0x6c, 0x0c, 0x1d, 0xae, 0x54, 0x74, 0x44, 0x9a,
-	0x5b, 0xc6, 0x3f, 0x3b, 0xd0, 0xbf, 0x41, 0x9e, 0xa3, 0x66, 0x2f, 0x61, 0x70, 0xeb, 0xdf, 0xa6,
-	0x97, 0xc2, 0xc5, 0x69, 0xd2, 0x1c, 0xa3, 0x2e, 0x9b, 0xee, 0x09, 0xf6, 0x14, 0x4e, 0x2a, 0x5e,
-	0xa2, 0x51, 0x3c, 0xc3, 0xa5, 0xc8, 0xa9, 0xe4, 0x49, 0x1a, 0x36, 0xda, 0x87, 0x9c, 0x9d, 0x41,
-	0xbf, 0x40, 0xb1, 0x2e, 0x6c, 0xd4, 0xa1, 0xef, 0xd5, 0x3b, 0xc6, 0xa0, 0x6b, 0x45, 0x89, 0x51,
-	0x97, 0x54, 0x5a, 0xb3, 0x29, 0x8c, 0x37, 0xdc, 0xd8, 0x65, 0x41, 0x47, 0x59, 0x16, 0xdc, 0x14,
-	0x51, 0x8f, 0x4a, 0x8e, 0x9c, 0xee, 0x4f, 0x78, 0xc3, 0x4d, 0xd1, 0x90, 0x99, 0x2c, 0x4b, 0x61,
-	0x3d, 0xd9, 0x6f, 0xc9, 0x6b, 0x92, 0x89, 0xbc, 0x80, 0xe3, 0x9c, 0x5b, 0xee, 0x91, 0x01, 0x21,
-	0x43, 0x27, 0x90, 0xf9, 0x0c, 0x46, 0x99, 0xac, 0x0c, 0x56, 0x66, 0x6b, 0x3c, 0x31, 0x24, 0xe2,
-	0x7e, 0xa3, 0x12, 0x76, 0x0e, 0x43, 0xae, 0x94, 0x07, 0x8e, 0x09, 0x18, 0x70, 0xa5, 0xc8, 0x7a,
-	0x01, 0xa7, 0x74, 0x10, 0x8d, 0x66, 0xbb, 0xb1, 0x75, 0x11, 0x20, 0xe6, 0x81, 0x33, 0x52, 0xaf,
-	0x13, 0xfb, 0x1c, 0xc6, 0x4a, 0x4b, 0x25, 0x0d, 0xea, 0x25, 0xcf, 0x73, 0x8d, 0xc6, 0x44, 0xa1,
-	0x47, 0xf7, 0xfa, 0x5b, 0x2f, 0xc7, 0x1c, 0xfa, 0xbe, 0x87, 0x83, 0xf9, 0x05, 0x77, 0xe6, 0xf7,
-	0x04, 0xc2, 0xc3, 0x31, 0xf9, 0xc9, 0x43, 0xd1, 0x8e, 0x68, 0x02, 0x60, 0xc4, 0xba, 0xe2, 0x76,
-	0xab, 0xd1, 0x44, 0x9d, 0xcb, 0x8e, 0xf3, 0x5b, 0x25, 0xfe, 0x11, 0x40, 0xf7, 0x1d, 0xb7, 0xdc,
-	0xc5, 0xc1, 0x7e, 0x35, 0x51, 0x40, 0x84, 0x5b, 0xb2, 0x37, 0x10, 0x89, 0xca, 0xa2, 0x2e, 0x31,
-	0x17, 0xdc, 0xe2, 0xd2, 0x58, 0xf7, 0xd4, 0x52, 0x5a, 0x13, 0x1d, 0x11, 0x76, 0x76, 0xe8, 0x7f,
-	0x74, 0x76, 0xea, 0x5c, 0xf6, 0x1a, 0x86, 0x78, 0x2b, 0x72, 0xac, 0x32, 0xa4, 0x4f, 0x86, 0x8b,
-	0xf3, 0xa4, 0xcd, 0x6a, 0xe2, 0xb2, 0x9a, 0xbc, 0xaf, 0x81, 0xb4, 0x41, 0xe3, 0xef, 0x01, 0xf4,
-	0xae, 0x28, 0x9b, 0x53, 0xd7, 0xae, 0xeb, 0xa1, 0x4e, 0xdf, 0xb8, 0x4d, 0x9f, 0xff, 0xfd, 0x69,
-	0xed, 0xb3, 0x18, 0xba, 0xee, 0x3f, 0x52, 0xe7, 0xe1, 0x62, 0xd4, 0x72, 0xae, 0xa9, 0x94, 0x3c,
-	0x36, 0x87, 0xf0, 0x20, 0x26, 0x94, 0xc0, 0x3b, 0x25, 0xfd, 0x8c, 0x53, 0x68, 0x33, 0x73, 0x75,
-	0xfd, 0x7b, 0x37, 0x09, 0xfe, 0xec, 0x26, 0xc1, 0xdf, 0xdd, 0x24, 0xf8, 0xf5, 0x6f, 0x72, 0xef,
-	0xf3, 0x7c, 0x2d, 0x6c, 0xb1, 0x5d, 0x25, 0x99, 0x2c, 0x67, 0x19, 0x6e, 0xd0, 0x58, 0xc1, 0xa5,
-	0x5e, 0x37, 0xb7, 0xd3, 0x5f, 0xbe, 0x99, 0x5a, 0x35, 0xca, 0xaa, 0x4f, 0x37, 0xf1, 0xd5, 0xff,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xb8, 0x92, 0x07, 0x7c, 0xcb, 0x03, 0x00, 0x00,
+	// 564 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xc1, 0x6e, 0xd4, 0x3c,
+	0x10, 0xc7, 0xbf, 0x74, 0xb7, 0xd9, 0xed, 0xa4, 0x5f, 0xd9, 0x5a, 0xa8, 0xa4, 0x54, 0x5a, 0x4a,
+	0x24, 0xa4, 0x05, 0xa4, 0xac, 0x5a, 0x84, 0xc4, 0x95, 0x16, 0xa4, 0x72, 0x4d, 0x25, 0x0e, 0x5c,
+	0x56, 0x4e, 0x32, 0xda, 0x58, 0x6c, 0x62, 0xcb, 0xf6, 0x56, 0xf0, 0x06, 0x70, 0xe1, 0xcc, 0x23,
+	0x71, 0xe4, 0x11, 0x50, 0x79, 0x11, 0xe4, 0x71, 0x36, 0x59, 0x90, 0x90, 0xb8, 0x44, 0xf6, 0xff,
+	0xff, 0xcb, 0x78, 0x3c, 0x9e, 0x81, 0x7b, 0x52, 0x59, 0x51, 0x8b, 0xc6, 0xce, 0x37, 0x8b, 0x54,
+	0x69, 0x69, 0x25, 0x1b, 0x6f, 0xf6, 0xf7, 0x4f, 0x2c, 0x36, 0x25, 0x6a, 0x82, 0x78, 0x5e, 0x88,
+	0xb9, 0xfd, 0xa8, 0xd0, 0x78, 0x2c, 0x39, 0x83, 0xd1, 0x5b, 0xd4, 0x46, 0xc8, 0x86, 0xdd, 0x85,
+	0xdd, 0x7c, 0x25, 0x8b, 0xf7, 0x71, 0x70, 0x1a, 0xcc, 0x86, 0x99, 0xdf, 0xb0, 0x09, 0x0c, 0xb8,
+	0x52, 0xf1, 0x0e, 0x69, 0x6e, 0x99, 0x7c, 0x19, 0x40, 0x78, 0x85, 0xbc, 0x44, 0xcd, 0x9e, 0xc2,
+	0xe8, 0xc6, 0xff, 0x4d, 0x3f, 0x45, 0xe7, 0x87, 0x69, 0x97, 0x46, 0x1b, 0x36, 0xdb, 0x10, 0xec,
+	0x21, 0xec, 0x37, 0xbc, 0x46, 0xa3, 0x78, 0x81, 0x0b, 0x51, 0x52, 0xc8, 0xfd, 0x2c, 0xea, 0xb4,
+	0x37, 0x25, 0x3b, 0x82, 0xb0, 0x42, 0xb1, 0xac, 0x6c, 0x3c, 0xa0, 0xf3, 0xda, 0x1d, 0x63, 0x30,
+	0xb4, 0xa2, 0xc6, 0x78, 0x48, 0x2a, 0xad, 0xd9, 0x0c, 0x26, 0x2b, 0x6e, 0xec, 0xa2, 0xa2, 0x54,
+	0x16, 0x15, 0x37, 0x55, 0xbc, 0x4b, 0x21, 0x0f, 0x9c, 0xee, 0x33, 0xbc, 0xe2, 0xa6, 0xea, 0xc8,
+	0x42, 0xd6, 0xb5, 0xb0, 0x9e, 0x0c, 0x7b, 0xf2, 0x92, 0x64, 0x22, 0x4f, 0x60, 0xaf, 0xe4, 0x96,
+	0x7b, 0x64, 0x44, 0xc8, 0xd8, 0x09, 0x64, 0x3e, 0x82, 0x83, 0x42, 0x36, 0x06, 0x1b, 0xb3, 0x36,
+	0x9e, 0x18, 0x13, 0xf1, 0x7f, 0xa7, 0x12, 0x76, 0x0c, 0x63, 0xae, 0x94, 0x07, 0xf6, 0x08, 0x18,
+	0x71, 0xa5, 0xc8, 0x7a, 0x02, 0x87, 0x94, 0x88, 0x46, 0xb3, 0x5e, 0xd9, 0x36, 0x08, 0x10, 0x73,
+	0xc7, 0x19, 0x99, 0xd7, 0x89, 0x7d, 0x0c, 0x13, 0xa5, 0xa5, 0x92, 0x06, 0xf5, 0x82, 0x97, 0xa5,
+	0x46, 0x63, 0xe2, 0xc8, 0xa3, 0x1b, 0xfd, 0xa5, 0x97, 0x13, 0x0e, 0xa1, 0xbf, 0xc3, 0x56, 0xfd,
+	0x82, 0xdf, 0xea, 0xf7, 0x00, 0xa2, 0xed, 0x32, 0xf9, 0xca, 0x43, 0xd5, 0x97, 0x68, 0x0a, 0x60,
+	0xc4, 0xb2, 0xe1, 0x76, 0xad, 0xd1, 0xc4, 0x83, 0xd3, 0x81, 0xf3, 0x7b, 0x25, 0xf9, 0x1c, 0xc0,
+	0xf0, 0x15, 0xb7, 0xdc, 0xb5, 0x83, 0xfd, 0x60, 0xe2, 0x80, 0x08, 0xb7, 0x64, 0x2f, 0x20, 0x16,
+	0x8d, 0x45, 0x5d, 0x63, 0x29, 0xb8, 0xc5, 0x85, 0xb1, 0xee, 0xab, 0xa5, 0xb4, 0x26, 0xde, 0x21,
+	0xec, 0x68, 0xdb, 0xbf, 0x76, 0x76, 0xe6, 0x5c, 0xf6, 0x1c, 0xc6, 0x78, 0x23, 0x4a, 0x6c, 0x0a,
+	0xa4, 0x23, 0xa3, 0xf3, 0xe3, 0xb4, 0xef, 0xd5, 0xd4, 0xf5, 0x6a, 0xfa, 0xba, 0x05, 0xb2, 0x0e,
+	0x4d, 0x3e, 0x05, 0xb0, 0x7b, 0x41, 0xbd, 0x39, 0x83, 0xd0, 0xdf, 0xa1, 0xed, 0xbe, 0x49, 0xdf,
+	0x7d, 0xfe, 0xf9, 0xb3, 0xd6, 0x67, 0x09, 0x0c, 0xdd, 0x3b, 0xd2, 0xcd, 0xa3, 0xf3, 0x83, 0x9e,
+	0x73, 0x97, 0xca, 0xc8, 0x63, 0x67, 0x10, 0x6d, 0xb5, 0x49, 0x3c, 0xf8, 0x33, 0xa4, 0xaf, 0x71,
+	0x06, 0x7d, 0xcf, 0x24, 0x39, 0xec, 0x5f, 0x8b, 0x65, 0x83, 0x65, 0x3b, 0x0f, 0xff, 0x9e, 0xd0,
+	0x0c, 0xc2, 0xf6, 0x9c, 0x9d, 0xbf, 0x9c, 0xd3, 0xfa, 0x17, 0x97, 0xef, 0xce, 0x96, 0xc2, 0x56,
+	0xeb, 0x3c, 0x2d, 0x64, 0x3d, 0x2f, 0x70, 0x85, 0xc6, 0x0a, 0x2e, 0xf5, 0xb2, 0x9b, 0x78, 0x3f,
+	0xd0, 0x73, 0x95, 0x77, 0xca, 0xb7, 0xdb, 0x69, 0xf0, 0xfd, 0x76, 0x1a, 0xfc, 0xb8, 0x9d, 0x06,
+	0x5f, 0x7f, 0x4e, 0xff, 0xcb, 0x43, 0x9a, 0xf6, 0x67, 0xbf, 0x06, 0x00, 0x4b, 0x0b, 0xdc, 0x29,
+	0x2f, 0x04, 0x00, 0x00,
 }
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"errors"
+	"time"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+)
+
+// receiptSignPrefix separates receipt signatures from signatures of other messages.
+const receiptSignPrefix = "optimint/ordering-receipt"
+
+// OrderingReceipt is issued by the sequencer when transaction is added to the mempool in first-come-first-served
+// ordering mode. Sequence numbers of transactions included in blocks are strictly increasing (see
+// Data.TxSequences), so signed receipt is an evidence against reordering: transaction with a receipt has to be
+// included with receipt sequence number, before all transactions with higher sequence numbers.
+type OrderingReceipt struct {
+	ChainID   string
+	TxHash    []byte
+	Sequence  uint64
+	Timestamp time.Time
+	Signature []byte
+}
+
+// SignBytes returns the bytes signed by the sequencer.
+func (r *OrderingReceipt) SignBytes() []byte {
+	b := make([]byte, 0, len(receiptSignPrefix)+len(r.ChainID)+len(r.TxHash)+24)
+	b = append(b, receiptSignPrefix...)
+	b = append(b, encodeUint64(uint64(len(r.ChainID)))...)
+	b = append(b, r.ChainID...)
+	b = append(b, r.TxHash...)
+	b = append(b, encodeUint64(r.Sequence)...)
+	b = append(b, encodeUint64(uint64(r.Timestamp.UnixNano()))...)
+	return b
+}
+
+// VerifySignature checks if receipt was signed by the owner of given public key.
+func (r *OrderingReceipt) VerifySignature(pubKey tmcrypto.PubKey) error {
+	if r.Sequence == 0 {
+		return errors.New("receipt without sequence number")
+	}
+	if !pubKey.VerifySignature(r.SignBytes(), r.Signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
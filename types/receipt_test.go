@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestOrderingReceiptSignature(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key := ed25519.GenPrivKey()
+	receipt := &OrderingReceipt{
+		ChainID:   "test",
+		TxHash:    []byte{1, 2, 3},
+		Sequence:  7,
+		Timestamp: time.Unix(1000, 0),
+	}
+	sig, err := key.Sign(receipt.SignBytes())
+	require.NoError(err)
+	receipt.Signature = sig
+	assert.NoError(receipt.VerifySignature(key.PubKey()))
+	assert.Error(receipt.VerifySignature(ed25519.GenPrivKey().PubKey()))
+
+	receipt.Sequence = 8
+	assert.Error(receipt.VerifySignature(key.PubKey()))
+
+	receipt.Sequence = 0
+	assert.Error(receipt.VerifySignature(key.PubKey()))
+}
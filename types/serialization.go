@@ -44,6 +44,16 @@ func (d *Data) MarshalBinary() ([]byte, error) {
 	return d.ToProto().Marshal()
 }
 
+// UnmarshalBinary decodes binary form of Data into object.
+func (d *Data) UnmarshalBinary(data []byte) error {
+	var pData pb.Data
+	err := pData.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	return d.FromProto(&pData)
+}
+
 // MarshalBinary encodes Commit into binary form and returns it.
 func (c *Commit) MarshalBinary() ([]byte, error) {
 	return c.ToProto().Marshal()
@@ -60,6 +70,22 @@ func (c *Commit) UnmarshalBinary(data []byte) error {
 	return err
 }
 
+// MarshalBinary encodes SignedHeader into binary form and returns it.
+func (sh *SignedHeader) MarshalBinary() ([]byte, error) {
+	return sh.ToProto().Marshal()
+}
+
+// UnmarshalBinary decodes binary form of SignedHeader into object.
+func (sh *SignedHeader) UnmarshalBinary(data []byte) error {
+	var pSignedHeader pb.SignedHeader
+	err := pSignedHeader.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	err = sh.FromProto(&pSignedHeader)
+	return err
+}
+
 // ToProto converts Header into protobuf representation and returns it.
 func (h *Header) ToProto() *pb.Header {
 	return &pb.Header{
@@ -82,6 +108,9 @@ func (h *Header) ToProto() *pb.Header {
 
 // FromProto fills Header with data from its protobuf representation.
 func (h *Header) FromProto(other *pb.Header) error {
+	if other == nil || other.Version == nil {
+		return errors.New("missing header version")
+	}
 	h.Version.Block = other.Version.Block
 	h.Version.App = other.Version.App
 	if !safeCopy(h.NamespaceID[:], other.NamespaceId) {
@@ -140,18 +169,35 @@ func (d *Data) ToProto() *pb.Data {
 		Txs:                    txsToByteSlices(d.Txs),
 		IntermediateStateRoots: d.IntermediateStateRoots.RawRootsList,
 		Evidence:               evidenceToProto(d.Evidence),
+		TxSequences:            d.TxSequences,
 	}
 }
 
+// FromProto fills Data with data from its protobuf representation.
+func (d *Data) FromProto(other *pb.Data) error {
+	if other == nil {
+		return errors.New("missing block data")
+	}
+	d.Txs = byteSlicesToTxs(other.Txs)
+	d.IntermediateStateRoots.RawRootsList = other.IntermediateStateRoots
+	d.Evidence = evidenceFromProto(other.Evidence)
+	d.TxSequences = other.TxSequences
+	return nil
+}
+
 // FromProto fills Block with data from its protobuf representation.
 func (b *Block) FromProto(other *pb.Block) error {
+	if other == nil {
+		return errors.New("missing block")
+	}
 	err := b.Header.FromProto(other.Header)
 	if err != nil {
 		return err
 	}
-	b.Data.Txs = byteSlicesToTxs(other.Data.Txs)
-	b.Data.IntermediateStateRoots.RawRootsList = other.Data.IntermediateStateRoots
-	b.Data.Evidence = evidenceFromProto(other.Data.Evidence)
+	err = b.Data.FromProto(other.Data)
+	if err != nil {
+		return err
+	}
 	if other.LastCommit != nil {
 		err := b.LastCommit.FromProto(other.LastCommit)
 		if err != nil {
@@ -162,6 +208,29 @@ func (b *Block) FromProto(other *pb.Block) error {
 	return nil
 }
 
+// ToProto converts SignedHeader into protobuf representation and returns it.
+func (sh *SignedHeader) ToProto() *pb.SignedHeader {
+	return &pb.SignedHeader{
+		Header: sh.Header.ToProto(),
+		Commit: sh.Commit.ToProto(),
+	}
+}
+
+// FromProto fills SignedHeader with data from its protobuf representation.
+func (sh *SignedHeader) FromProto(other *pb.SignedHeader) error {
+	if other == nil || other.Header == nil || other.Header.Version == nil {
+		return errors.New("missing header")
+	}
+	if other.Commit == nil {
+		return errors.New("missing commit")
+	}
+	err := sh.Header.FromProto(other.Header)
+	if err != nil {
+		return err
+	}
+	return sh.Commit.FromProto(other.Commit)
+}
+
 // ToProto converts Commit into protobuf representation and returns it.
 func (c *Commit) ToProto() *pb.Commit {
 	return &pb.Commit{
@@ -173,6 +242,9 @@ func (c *Commit) ToProto() *pb.Commit {
 
 // FromProto fills Commit with data from its protobuf representation.
 func (c *Commit) FromProto(other *pb.Commit) error {
+	if other == nil {
+		return errors.New("missing commit")
+	}
 	c.Height = other.Height
 	if !safeCopy(c.HeaderHash[:], other.HeaderHash) {
 		return errors.New("invalid length of HeaderHash")
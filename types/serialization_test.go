@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	pb "github.com/celestiaorg/optimint/types/pb/optimint"
 )
 
 func TestBlockSerializationRoundTrip(t *testing.T) {
@@ -57,6 +59,12 @@ func TestBlockSerializationRoundTrip(t *testing.T) {
 				Signatures: []Signature{Signature([]byte{1, 1, 1}), Signature([]byte{2, 2, 2})},
 			},
 		}},
+		{"tx sequences", &Block{
+			Data: Data{
+				Txs:         Txs{Tx("tx1"), Tx("tx2"), Tx("tx3")},
+				TxSequences: []uint64{0, 7, 1 << 40},
+			},
+		}},
 	}
 
 	for _, c := range cases {
@@ -74,3 +82,34 @@ func TestBlockSerializationRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+// protobuf messages received from network (e.g. from gRPC DA server) can have missing fields
+func TestFromProtoMissingFields(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		fromProto func() error
+	}{
+		{"nil header", func() error { return (&Header{}).FromProto(nil) }},
+		{"nil data", func() error { return (&Data{}).FromProto(nil) }},
+		{"nil commit", func() error { return (&Commit{}).FromProto(nil) }},
+		{"nil block", func() error { return (&Block{}).FromProto(nil) }},
+		{"nil signed header", func() error { return (&SignedHeader{}).FromProto(nil) }},
+		{"block without header", func() error { return (&Block{}).FromProto(&pb.Block{Data: &pb.Data{}}) }},
+		{"block without data", func() error {
+			return (&Block{}).FromProto(&pb.Block{Header: (&Header{}).ToProto()})
+		}},
+		{"signed header without commit", func() error {
+			return (&SignedHeader{}).FromProto(&pb.SignedHeader{Header: (&Header{}).ToProto()})
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var err error
+			require.NotPanics(t, func() { err = c.fromProto() })
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package types
+
+// Snapshot describes application state snapshot distributed via DA layer (or other snapshot store), together with
+// the data required to resume syncing from snapshot height.
+type Snapshot struct {
+	// Height, Format, Chunks, Hash and Metadata are reported by the application (see ABCI ListSnapshots).
+	Height   uint64
+	Format   uint32
+	Chunks   uint32
+	Hash     []byte
+	Metadata []byte
+
+	// Block is the block at snapshot height, Commit is its commit.
+	Block  *Block
+	Commit *Commit
+	// State is Optimint state after applying the block, serialized with tmjson.
+	State []byte
+}
@@ -1,7 +1,53 @@
 package types
 
+import (
+	"crypto/sha256"
+	"runtime"
+	"sync"
+)
+
+// minParallelHashing is the number of transactions below which hashing is not worth spreading across goroutines.
+const minParallelHashing = 128
+
 // Tx represents transactoin.
 type Tx []byte
 
 // Txs represents a slice of transactions.
 type Txs []Tx
+
+// Hash returns SHA-256 hash of transaction (the same as in Tendermint).
+func (tx Tx) Hash() [32]byte {
+	return sha256.Sum256(tx)
+}
+
+// Hashes returns SHA-256 hashes of all transactions, in order.
+//
+// Large slices are hashed in parallel, by a pool of GOMAXPROCS workers.
+func (txs Txs) Hashes() [][32]byte {
+	hashes := make([][32]byte, len(txs))
+	workers := runtime.GOMAXPROCS(0)
+	if len(txs) < minParallelHashing || workers == 1 {
+		for i, tx := range txs {
+			hashes[i] = tx.Hash()
+		}
+		return hashes
+	}
+
+	chunk := (len(txs) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(txs); start += chunk {
+		end := start + chunk
+		if end > len(txs) {
+			end = len(txs)
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				hashes[i] = txs[i].Hash()
+			}
+		}(start, end)
+	}
+	wg.Wait()
+	return hashes
+}
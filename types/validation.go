@@ -1,6 +1,11 @@
 package types
 
-import "errors"
+import (
+	"bytes"
+	"errors"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+)
 
 // ValidateBasic performs basic validation of a block.
 func (b *Block) ValidateBasic() error {
@@ -13,11 +18,20 @@ func (b *Block) ValidateBasic() error {
 	if err != nil {
 		return err
 	}
+	if b.Data.Hash() != b.Header.DataHash {
+		return errors.New("DataHash doesn't match block data")
+	}
 
 	err = b.LastCommit.ValidateBasic()
 	if err != nil {
 		return err
 	}
+	if b.LastCommit.Hash() != b.Header.LastCommitHash {
+		return errors.New("LastCommitHash doesn't match last commit")
+	}
+	if b.LastCommit.HeaderHash != b.Header.LastHeaderHash {
+		return errors.New("last commit doesn't match LastHeaderHash")
+	}
 
 	return nil
 }
@@ -31,9 +45,61 @@ func (h *Header) ValidateBasic() error {
 	return nil
 }
 
+// ValidateBasic performs basic validation of a signed header.
+// It checks if commit matches the header, but doesn't verify signatures (see VerifySignature).
+func (sh *SignedHeader) ValidateBasic() error {
+	err := sh.Header.ValidateBasic()
+	if err != nil {
+		return err
+	}
+
+	err = sh.Commit.ValidateBasic()
+	if err != nil {
+		return err
+	}
+
+	if sh.Commit.Height != sh.Header.Height {
+		return errors.New("commit height doesn't match header height")
+	}
+	if sh.Commit.HeaderHash != sh.Header.Hash() {
+		return errors.New("commit header hash doesn't match header hash")
+	}
+	if len(sh.Commit.Signatures) == 0 {
+		return errors.New("no signatures")
+	}
+
+	return nil
+}
+
+// VerifySignature checks if header was signed by the owner of given public key.
+func (sh *SignedHeader) VerifySignature(pubKey tmcrypto.PubKey) error {
+	if !bytes.Equal(sh.Header.ProposerAddress, pubKey.Address()) {
+		return errors.New("proposer address doesn't match public key")
+	}
+	headerBytes, err := sh.Header.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	for _, sig := range sh.Commit.Signatures {
+		if pubKey.VerifySignature(headerBytes, sig) {
+			return nil
+		}
+	}
+	return errors.New("invalid signature")
+}
+
 // ValidateBasic performs basic validation of block data.
-// Actually it's a placeholder, because nothing is checked.
 func (d *Data) ValidateBasic() error {
+	// intermediate state roots and evidence are not covered by DataHash (see ADR-008), so they can't be accepted
+	if len(d.IntermediateStateRoots.RawRootsList) > 0 {
+		return errors.New("intermediate state roots are not supported")
+	}
+	if len(d.Evidence.Evidence) > 0 {
+		return errors.New("evidence is not supported")
+	}
+	if len(d.TxSequences) > 0 && len(d.TxSequences) != len(d.Txs) {
+		return errors.New("number of transaction sequence numbers doesn't match number of transactions")
+	}
 	return nil
 }
 
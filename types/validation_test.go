@@ -0,0 +1,134 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestSignedHeaderVerification(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+
+	sequencerKey := ed25519.GenPrivKey()
+	otherKey := ed25519.GenPrivKey()
+
+	header := Header{
+		Version:         Version{Block: 1, App: 2},
+		Height:          3,
+		Time:            4567,
+		ProposerAddress: sequencerKey.PubKey().Address(),
+	}
+	headerBytes, err := header.MarshalBinary()
+	require.NoError(err)
+
+	sign := func(key ed25519.PrivKey) *SignedHeader {
+		sig, err := key.Sign(headerBytes)
+		require.NoError(err)
+		return &SignedHeader{
+			Header: header,
+			Commit: Commit{
+				Height:     header.Height,
+				HeaderHash: header.Hash(),
+				Signatures: []Signature{sig},
+			},
+		}
+	}
+
+	valid := sign(sequencerKey)
+	spoofed := sign(otherKey)
+	wrongHeight := sign(sequencerKey)
+	wrongHeight.Commit.Height++
+
+	cases := []struct {
+		name        string
+		input       *SignedHeader
+		validateErr bool
+		verifyErr   bool
+	}{
+		{"valid", valid, false, false},
+		{"signed by other key", spoofed, false, true},
+		{"commit height mismatch", wrongHeight, true, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			blob, err := c.input.MarshalBinary()
+			assert.NoError(err)
+			deserialized := &SignedHeader{}
+			err = deserialized.UnmarshalBinary(blob)
+			assert.NoError(err)
+			assert.Equal(c.input, deserialized)
+
+			err = deserialized.ValidateBasic()
+			if c.validateErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+
+			err = deserialized.VerifySignature(sequencerKey.PubKey())
+			if c.verifyErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
+
+func TestBlockLastCommitValidation(t *testing.T) {
+	t.Parallel()
+
+	lastCommit := Commit{
+		Height:     1,
+		HeaderHash: [32]byte{1, 2, 3},
+		Signatures: []Signature{[]byte{4, 5, 6}},
+	}
+	newBlock := func() *Block {
+		return &Block{
+			Header: Header{
+				Height:          2,
+				ProposerAddress: []byte{7, 8, 9},
+				LastHeaderHash:  lastCommit.HeaderHash,
+				LastCommitHash:  lastCommit.Hash(),
+				DataHash:        (&Data{}).Hash(),
+			},
+			LastCommit: lastCommit,
+		}
+	}
+
+	wrongCommitHash := newBlock()
+	wrongCommitHash.Header.LastCommitHash = [32]byte{}
+	wrongHeaderHash := newBlock()
+	wrongHeaderHash.Header.LastHeaderHash = [32]byte{}
+	tampered := newBlock()
+	tampered.LastCommit.Signatures = []Signature{[]byte{6, 5, 4}}
+
+	cases := []struct {
+		name   string
+		input  *Block
+		errMsg string
+	}{
+		{"valid", newBlock(), ""},
+		{"last commit hash mismatch", wrongCommitHash, "LastCommitHash doesn't match last commit"},
+		{"last header hash mismatch", wrongHeaderHash, "last commit doesn't match LastHeaderHash"},
+		{"tampered last commit", tampered, "LastCommitHash doesn't match last commit"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.input.ValidateBasic()
+			if c.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, c.errMsg)
+			}
+		})
+	}
+}